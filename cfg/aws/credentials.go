@@ -137,17 +137,26 @@ func (c *CredentialConfig) rootCredentials() client.ConfigProvider {
 }
 
 func (c *CredentialConfig) assumeCredentials() client.ConfigProvider {
-	rootCredentials := c.rootCredentials()
+	return c.assumeRole(c.rootCredentials(), c.RoleARN)
+}
+
+func (c *CredentialConfig) assumeRole(p client.ConfigProvider, roleARN string) client.ConfigProvider {
 	config := &aws.Config{
 		Region:     aws.String(c.Region),
 		HTTPClient: &http.Client{Timeout: 1 * time.Minute},
 		LogLevel:   SDKLogLevel(),
 		Logger:     SDKLogger{},
 	}
-	config.Credentials = newStsCredentials(rootCredentials, c.RoleARN, c.Region)
+	config.Credentials = newStsCredentials(p, roleARN, c.Region)
 	return getSession(config)
 }
 
+// ChainedCredentials returns the credentials of a role that is assumed with the credentials of the config, such as a
+// role in another account that trusts the role of the config.
+func (c *CredentialConfig) ChainedCredentials(roleARN string) client.ConfigProvider {
+	return c.assumeRole(c.Credentials(), roleARN)
+}
+
 func (c *CredentialConfig) Credentials() client.ConfigProvider {
 	if c.RoleARN != "" {
 		return c.assumeCredentials()
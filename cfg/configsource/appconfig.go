@@ -0,0 +1,71 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package configsource
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/appconfigdata"
+	"github.com/aws/aws-sdk-go/service/appconfigdata/appconfigdataiface"
+)
+
+type appConfigSource struct {
+	client      appconfigdataiface.AppConfigDataAPI
+	application string
+	environment string
+	profile     string
+
+	mu      sync.Mutex
+	token   *string
+	content string
+}
+
+var _ Source = (*appConfigSource)(nil)
+
+// NewAppConfigSource creates a Source that fetches the json config from the AppConfig configuration profile. The
+// configuration session is started on the first fetch and polled with the token of the previous fetch.
+func NewAppConfigSource(client appconfigdataiface.AppConfigDataAPI, application, environment, profile string) Source {
+	return &appConfigSource{
+		client:      client,
+		application: application,
+		environment: environment,
+		profile:     profile,
+	}
+}
+
+func (s *appConfigSource) Name() string {
+	return fileName(LocationAppConfig, s.application+"/"+s.environment+"/"+s.profile)
+}
+
+func (s *appConfigSource) Fetch() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token == nil {
+		output, err := s.client.StartConfigurationSession(&appconfigdata.StartConfigurationSessionInput{
+			ApplicationIdentifier:          aws.String(s.application),
+			EnvironmentIdentifier:          aws.String(s.environment),
+			ConfigurationProfileIdentifier: aws.String(s.profile),
+		})
+		if err != nil {
+			return "", fmt.Errorf("unable to start appconfig session for %s/%s/%s: %w", s.application, s.environment, s.profile, err)
+		}
+		s.token = output.InitialConfigurationToken
+	}
+	output, err := s.client.GetLatestConfiguration(&appconfigdata.GetLatestConfigurationInput{
+		ConfigurationToken: s.token,
+	})
+	if err != nil {
+		// the token cannot be reused after an error, so a new session is started on the next fetch
+		s.token = nil
+		return "", fmt.Errorf("unable to get appconfig configuration for %s/%s/%s: %w", s.application, s.environment, s.profile, err)
+	}
+	s.token = output.NextPollConfigurationToken
+	// the configuration is only returned when it has changed since the previous poll of the session
+	if len(output.Configuration) > 0 {
+		s.content = string(output.Configuration)
+	}
+	return s.content, nil
+}
@@ -0,0 +1,65 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package configsource
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/service/appconfigdata"
+	"github.com/aws/aws-sdk-go/service/ssm"
+)
+
+const (
+	LocationSSM       = "ssm"
+	LocationAppConfig = "appconfig"
+
+	locationSeparator = ":"
+)
+
+// Source fetches the json config of the agent from a centrally-managed location.
+type Source interface {
+	// Name is the name of the json config file in the config directory, such as ssm_<parameter-store-name>.
+	Name() string
+	// Fetch returns the current content of the json config.
+	Fetch() (string, error)
+}
+
+// New creates the Source of a location such as ssm:<parameter-store-name> or
+// appconfig:<application>/<environment>/<configuration-profile>.
+func New(location string, p client.ConfigProvider) (Source, error) {
+	locationType, name, ok := strings.Cut(location, locationSeparator)
+	if !ok || name == "" {
+		return nil, fmt.Errorf("config source %q is malformed", location)
+	}
+	switch locationType {
+	case LocationSSM:
+		return NewSSMSource(ssm.New(p), name), nil
+	case LocationAppConfig:
+		application, environment, profile, err := parseAppConfigName(name)
+		if err != nil {
+			return nil, err
+		}
+		return NewAppConfigSource(appconfigdata.New(p), application, environment, profile), nil
+	default:
+		return nil, fmt.Errorf("config source type %q is not supported", locationType)
+	}
+}
+
+func parseAppConfigName(name string) (application, environment, profile string, err error) {
+	parts := strings.Split(name, "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return "", "", "", fmt.Errorf("appconfig source %q must be <application>/<environment>/<configuration-profile>", name)
+	}
+	return parts[0], parts[1], parts[2], nil
+}
+
+// fileName returns the name of the json config file of the source, in the same format as the config-downloader.
+func fileName(locationType, name string) string {
+	escaped := filepath.ToSlash(name)
+	escaped = strings.NewReplacer("/", "_", " ", "_", ":", "_").Replace(escaped)
+	return locationType + "_" + escaped
+}
@@ -0,0 +1,108 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package configsource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/appconfigdata"
+	"github.com/aws/aws-sdk-go/service/appconfigdata/appconfigdataiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	p := session.Must(session.NewSession(&aws.Config{Region: aws.String("us-east-1")}))
+	testCases := map[string]struct {
+		location string
+		wantName string
+		wantErr  string
+	}{
+		"SSM": {
+			location: "ssm:AmazonCloudWatch-linux",
+			wantName: "ssm_AmazonCloudWatch-linux",
+		},
+		"AppConfig": {
+			location: "appconfig:agent/prod/linux",
+			wantName: "appconfig_agent_prod_linux",
+		},
+		"MissingName": {
+			location: "ssm:",
+			wantErr:  "malformed",
+		},
+		"InvalidAppConfig": {
+			location: "appconfig:agent/prod",
+			wantErr:  "<application>/<environment>/<configuration-profile>",
+		},
+		"Unsupported": {
+			location: "file:/tmp/config.json",
+			wantErr:  "not supported",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := New(testCase.location, p)
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, err, testCase.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.wantName, got.Name())
+		})
+	}
+}
+
+type mockAppConfigClient struct {
+	appconfigdataiface.AppConfigDataAPI
+	sessions      int
+	tokens        []string
+	configuration []byte
+	err           error
+}
+
+func (m *mockAppConfigClient) StartConfigurationSession(*appconfigdata.StartConfigurationSessionInput) (*appconfigdata.StartConfigurationSessionOutput, error) {
+	m.sessions++
+	return &appconfigdata.StartConfigurationSessionOutput{InitialConfigurationToken: aws.String("initial")}, nil
+}
+
+func (m *mockAppConfigClient) GetLatestConfiguration(input *appconfigdata.GetLatestConfigurationInput) (*appconfigdata.GetLatestConfigurationOutput, error) {
+	m.tokens = append(m.tokens, aws.StringValue(input.ConfigurationToken))
+	if m.err != nil {
+		return nil, m.err
+	}
+	configuration := m.configuration
+	m.configuration = nil
+	return &appconfigdata.GetLatestConfigurationOutput{
+		Configuration:              configuration,
+		NextPollConfigurationToken: aws.String("next"),
+	}, nil
+}
+
+func TestAppConfigSource(t *testing.T) {
+	client := &mockAppConfigClient{configuration: []byte(`{"agent":{}}`)}
+	source := NewAppConfigSource(client, "agent", "prod", "linux")
+
+	got, err := source.Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, `{"agent":{}}`, got)
+
+	// an empty configuration is unchanged since the previous poll
+	got, err = source.Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, `{"agent":{}}`, got)
+	assert.Equal(t, []string{"initial", "next"}, client.tokens)
+
+	client.err = errors.New("expired")
+	_, err = source.Fetch()
+	assert.ErrorContains(t, err, "expired")
+	client.err = nil
+	client.configuration = []byte(`{"logs":{}}`)
+	got, err = source.Fetch()
+	require.NoError(t, err)
+	assert.Equal(t, `{"logs":{}}`, got)
+	assert.Equal(t, 2, client.sessions)
+}
@@ -0,0 +1,43 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package configsource
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+)
+
+type ssmSource struct {
+	client ssmiface.SSMAPI
+	name   string
+}
+
+var _ Source = (*ssmSource)(nil)
+
+// NewSSMSource creates a Source that fetches the json config from the SSM Parameter Store parameter. SecureString
+// parameters are decrypted.
+func NewSSMSource(client ssmiface.SSMAPI, name string) Source {
+	return &ssmSource{client: client, name: name}
+}
+
+func (s *ssmSource) Name() string {
+	return fileName(LocationSSM, s.name)
+}
+
+func (s *ssmSource) Fetch() (string, error) {
+	output, err := s.client.GetParameter(&ssm.GetParameterInput{
+		Name:           aws.String(s.name),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return "", fmt.Errorf("unable to get parameter %q: %w", s.name, err)
+	}
+	if output.Parameter == nil {
+		return "", fmt.Errorf("parameter %q has no value", s.name)
+	}
+	return aws.StringValue(output.Parameter.Value), nil
+}
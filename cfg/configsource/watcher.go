@@ -0,0 +1,84 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package configsource
+
+import (
+	"log"
+	"time"
+)
+
+// Applier validates and applies the json configs fetched by the Watcher.
+type Applier interface {
+	// Validate returns an error if the json config cannot be applied.
+	Validate(content string) error
+	// Apply writes the json config and reloads the agent with it. An empty json config, which is rolled back to when
+	// the agent was not running with a config from the source, removes it.
+	Apply(content string) error
+}
+
+// Watcher periodically fetches the json config from a Source and applies it when it changes.
+type Watcher struct {
+	source   Source
+	applier  Applier
+	interval time.Duration
+
+	// current is the last applied json config.
+	current string
+	// rejected is the last json config that failed, which is not retried until the source changes.
+	rejected string
+}
+
+// NewWatcher creates a Watcher of the source. The current json config is the one that the agent is running with,
+// which is the one rolled back to if a fetched config fails to apply.
+func NewWatcher(source Source, applier Applier, interval time.Duration, current string) *Watcher {
+	return &Watcher{
+		source:   source,
+		applier:  applier,
+		interval: interval,
+		current:  current,
+	}
+}
+
+// Run polls the source until the stop channel is closed.
+func (w *Watcher) Run(stop <-chan struct{}) {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.poll()
+		case <-stop:
+			return
+		}
+	}
+}
+
+// poll fetches the json config and applies it if it has changed and is valid. If the config fails to apply, the
+// current config is applied again so that the agent keeps running with a working config.
+func (w *Watcher) poll() {
+	content, err := w.source.Fetch()
+	if err != nil {
+		log.Printf("E! Unable to fetch the json config from %s: %v", w.source.Name(), err)
+		return
+	}
+	if content == w.current || content == w.rejected {
+		return
+	}
+	if err = w.applier.Validate(content); err != nil {
+		log.Printf("E! The json config fetched from %s is invalid and is not applied: %v", w.source.Name(), err)
+		w.rejected = content
+		return
+	}
+	if err = w.applier.Apply(content); err != nil {
+		log.Printf("E! Unable to apply the json config fetched from %s, rolling back: %v", w.source.Name(), err)
+		w.rejected = content
+		if err = w.applier.Apply(w.current); err != nil {
+			log.Printf("E! Unable to roll back to the previous json config of %s: %v", w.source.Name(), err)
+		}
+		return
+	}
+	log.Printf("I! Applied the json config fetched from %s", w.source.Name())
+	w.current = content
+	w.rejected = ""
+}
@@ -0,0 +1,104 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package configsource
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/ssm"
+	"github.com/aws/aws-sdk-go/service/ssm/ssmiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mockSSMClient struct {
+	ssmiface.SSMAPI
+	value string
+	err   error
+}
+
+func (m *mockSSMClient) GetParameter(input *ssm.GetParameterInput) (*ssm.GetParameterOutput, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &ssm.GetParameterOutput{
+		Parameter: &ssm.Parameter{Name: input.Name, Value: aws.String(m.value)},
+	}, nil
+}
+
+type mockApplier struct {
+	invalid  map[string]bool
+	failing  map[string]bool
+	applied  []string
+	reloaded string
+}
+
+func (m *mockApplier) Validate(content string) error {
+	if m.invalid[content] {
+		return errors.New("invalid")
+	}
+	return nil
+}
+
+func (m *mockApplier) Apply(content string) error {
+	m.applied = append(m.applied, content)
+	if m.failing[content] {
+		return errors.New("failed")
+	}
+	m.reloaded = content
+	return nil
+}
+
+func TestWatcher(t *testing.T) {
+	client := &mockSSMClient{value: `{"agent":{"interval":"60s"}}`}
+	applier := &mockApplier{
+		invalid: map[string]bool{`{"agent":`: true},
+		failing: map[string]bool{`{"agent":{"interval":"1s"}}`: true},
+	}
+	source := NewSSMSource(client, "AmazonCloudWatch-linux")
+	assert.Equal(t, "ssm_AmazonCloudWatch-linux", source.Name())
+	w := NewWatcher(source, applier, 0, `{"agent":{"interval":"60s"}}`)
+
+	// unchanged
+	w.poll()
+	assert.Empty(t, applier.applied)
+
+	// updated config is reapplied
+	client.value = `{"agent":{"interval":"30s"}}`
+	w.poll()
+	assert.Equal(t, []string{`{"agent":{"interval":"30s"}}`}, applier.applied)
+	assert.Equal(t, `{"agent":{"interval":"30s"}}`, applier.reloaded)
+	w.poll()
+	assert.Len(t, applier.applied, 1)
+
+	// invalid config is not applied
+	client.value = `{"agent":`
+	w.poll()
+	assert.Len(t, applier.applied, 1)
+
+	// failed config is rolled back and not retried
+	client.value = `{"agent":{"interval":"1s"}}`
+	w.poll()
+	assert.Equal(t, []string{
+		`{"agent":{"interval":"30s"}}`,
+		`{"agent":{"interval":"1s"}}`,
+		`{"agent":{"interval":"30s"}}`,
+	}, applier.applied)
+	assert.Equal(t, `{"agent":{"interval":"30s"}}`, applier.reloaded)
+	w.poll()
+	assert.Len(t, applier.applied, 3)
+
+	// fetch errors keep the current config
+	client.err = errors.New("throttled")
+	w.poll()
+	assert.Len(t, applier.applied, 3)
+
+	client.err = nil
+	client.value = `{"agent":{"interval":"10s"}}`
+	w.poll()
+	require.Len(t, applier.applied, 4)
+	assert.Equal(t, `{"agent":{"interval":"10s"}}`, applier.reloaded)
+}
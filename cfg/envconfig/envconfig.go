@@ -20,6 +20,7 @@ const (
 	CWAGENT_USER_AGENT        = "CWAGENT_USER_AGENT"
 	CWAGENT_LOG_LEVEL         = "CWAGENT_LOG_LEVEL"
 	CWAGENT_USAGE_DATA        = "CWAGENT_USAGE_DATA"
+	CWAGENT_CONFIG_RULES      = "CWAGENT_CONFIG_RULES"
 	IMDS_NUMBER_RETRY         = "IMDS_NUMBER_RETRY"
 	RunInContainer            = "RUN_IN_CONTAINER"
 	RunAsHostProcessContainer = "RUN_AS_HOST_PROCESS_CONTAINER"
@@ -310,6 +310,10 @@ func runAgent(ctx context.Context,
 		}
 	}
 
+	if err = checkOutputPermissions(c); err != nil {
+		return err
+	}
+
 	if len(c.Inputs) != 0 && len(c.Outputs) != 0 {
 		log.Println("creating new logs agent")
 		logAgent := logs.NewLogAgent(c)
@@ -725,6 +729,24 @@ func validateAgentFinalConfigAndPlugins(c *config.Config) error {
 	return nil
 }
 
+// permissionChecker is implemented by the outputs that can check the permissions of their credentials on start.
+type permissionChecker interface {
+	CheckPermissions() error
+}
+
+// checkOutputPermissions fails fast if an output is missing the permissions to send its data, instead of the agent
+// starting and dropping the data.
+func checkOutputPermissions(c *config.Config) error {
+	for _, output := range c.Outputs {
+		if checker, ok := output.Output.(permissionChecker); ok {
+			if err := checker.CheckPermissions(); err != nil {
+				return fmt.Errorf("output %s: %w", output.Config.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
 func checkRightForBinariesFileWithInputPlugins(inputPlugins []string) (string, error) {
 	for _, inputPlugin := range inputPlugins {
 		if inputPlugin == "nvidia_smi" {
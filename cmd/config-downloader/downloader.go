@@ -18,6 +18,7 @@ import (
 
 	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
 	"github.com/aws/amazon-cloudwatch-agent/cfg/commonconfig"
+	"github.com/aws/amazon-cloudwatch-agent/cfg/configsource"
 	"github.com/aws/amazon-cloudwatch-agent/internal/constants"
 	"github.com/aws/amazon-cloudwatch-agent/translator/config"
 	"github.com/aws/amazon-cloudwatch-agent/translator/util"
@@ -25,9 +26,10 @@ import (
 )
 
 const (
-	locationDefault = "default"
-	locationSSM     = "ssm"
-	locationFile    = "file"
+	locationDefault   = "default"
+	locationSSM       = "ssm"
+	locationAppConfig = configsource.LocationAppConfig
+	locationFile      = "file"
 
 	locationSeparator = ":"
 
@@ -38,7 +40,7 @@ func defaultJsonConfig(mode string) (string, error) {
 	return config.DefaultJsonConfig(config.ToValidOs(""), mode), nil
 }
 
-func downloadFromSSM(region, parameterStoreName, mode string, credsConfig map[string]string) (string, error) {
+func newSession(region, mode string, credsConfig map[string]string) (*session.Session, error) {
 	fmt.Printf("Region: %v\n", region)
 	fmt.Printf("credsConfig: %v\n", credsConfig)
 	var ses *session.Session
@@ -60,6 +62,14 @@ func downloadFromSSM(region, parameterStoreName, mode string, credsConfig map[st
 	ses, err := session.NewSession(rootconfig)
 	if err != nil {
 		fmt.Printf("Error in creating session: %v\n", err)
+		return nil, err
+	}
+	return ses, nil
+}
+
+func downloadFromSSM(region, parameterStoreName, mode string, credsConfig map[string]string) (string, error) {
+	ses, err := newSession(region, mode, credsConfig)
+	if err != nil {
 		return "", err
 	}
 
@@ -77,6 +87,23 @@ func downloadFromSSM(region, parameterStoreName, mode string, credsConfig map[st
 	return *output.Parameter.Value, nil
 }
 
+func downloadFromAppConfig(region, location, mode string, credsConfig map[string]string) (string, error) {
+	ses, err := newSession(region, mode, credsConfig)
+	if err != nil {
+		return "", err
+	}
+	source, err := configsource.New(location, ses)
+	if err != nil {
+		return "", err
+	}
+	config, err := source.Fetch()
+	if err != nil {
+		fmt.Printf("Error in retrieving appconfig content: %v\n", err)
+		return "", err
+	}
+	return config, nil
+}
+
 func readFromFile(filePath string) (string, error) {
 	bytes, err := os.ReadFile(filePath)
 	return string(bytes), err
@@ -111,7 +138,8 @@ func main() {
 
 	flag.StringVar(&mode, "mode", "ec2", "Please provide the mode, i.e. ec2, onPremise, onPrem, auto")
 	flag.StringVar(&downloadLocation, "download-source", "",
-		"Download source. Example: \"ssm:my-parameter-store-name\" for an EC2 SSM Parameter Store Name holding your CloudWatch Agent configuration.")
+		"Download source. Example: \"ssm:my-parameter-store-name\" for an EC2 SSM Parameter Store Name holding your CloudWatch Agent configuration, "+
+			"or \"appconfig:my-application/my-environment/my-configuration-profile\" for an AppConfig configuration profile.")
 	flag.StringVar(&outputDir, "output-dir", "", "Path of output json config directory.")
 	flag.StringVar(&inputConfig, "config", "", "Please provide the common-config file")
 	flag.StringVar(&multiConfig, "multi-config", "default", "valid values: default, append, remove")
@@ -197,6 +225,11 @@ func main() {
 		if multiConfig != "remove" {
 			config, err = downloadFromSSM(region, locationArray[1], mode, cc.CredentialsMap())
 		}
+	case locationAppConfig:
+		outputFilePath = locationAppConfig + "_" + EscapeFilePath(locationArray[1])
+		if multiConfig != "remove" {
+			config, err = downloadFromAppConfig(region, downloadLocation, mode, cc.CredentialsMap())
+		}
 	case locationFile:
 		outputFilePath = locationFile + "_" + EscapeFilePath(filepath.Base(locationArray[1]))
 		if multiConfig != "remove" {
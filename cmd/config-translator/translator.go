@@ -6,6 +6,8 @@ package main
 import (
 	"errors"
 	"flag"
+	"fmt"
+	"io"
 	"log"
 	"os"
 	"os/user"
@@ -21,27 +23,35 @@ import (
 )
 
 const (
-	exitErrorMessage   = "Configuration validation first phase failed. Agent version: %v. Verify the JSON input is only using features supported by this version.\n"
-	exitSuccessMessage = "Configuration validation first phase succeeded"
-	version            = "1.0"
-	envConfigFileName  = "env-config.json"
-	yamlConfigFileName = "amazon-cloudwatch-agent.yaml"
+	exitErrorMessage       = "Configuration validation first phase failed. Agent version: %v. Verify the JSON input is only using features supported by this version.\n"
+	exitSuccessMessage     = "Configuration validation first phase succeeded"
+	validateErrorMessage   = "Configuration validation failed. Agent version: %v. Remove or replace the keys that are not supported by this version.\n"
+	validateSuccessMessage = "Configuration validation succeeded"
+	version                = "1.0"
+	envConfigFileName      = "env-config.json"
+	yamlConfigFileName     = "amazon-cloudwatch-agent.yaml"
 )
 
+// validateOnly validates the json config without writing the translated configs.
+var validateOnly bool
+
 func initFlags() {
 	var inputOs = flag.String("os", "", "Please provide the os preference, valid value: windows/linux.")
 	var inputJsonFile = flag.String("input", "", "Please provide the path of input agent json config file")
 	var inputJsonDir = flag.String("input-dir", "", "Please provide the path of input agent json config directory.")
+	var inputFragmentDir = flag.String("input-fragment-dir", "", "Please provide the path of a directory of agent json config fragments to merge before translation.")
 	var inputTomlFile = flag.String("output", "", "Please provide the path of the output CWAgent config file")
 	var inputMode = flag.String("mode", "ec2", "Please provide the mode, i.e. ec2, onPremise, onPrem, auto")
 	var inputConfig = flag.String("config", "", "Please provide the common-config file")
 	var multiConfig = flag.String("multi-config", "remove", "valid values: default, append, remove")
+	flag.BoolVar(&validateOnly, "validate", false, "Validate the json config against the keys known to this version of the agent without writing the translated configs.")
 	flag.Parse()
 
 	ctx := context.CurrentContext()
 	ctx.SetOs(*inputOs)
 	ctx.SetInputJsonFilePath(*inputJsonFile)
 	ctx.SetInputJsonDirPath(*inputJsonDir)
+	ctx.SetInputFragmentDirPath(*inputFragmentDir)
 	ctx.SetMultiConfig(*multiConfig)
 	ctx.SetOutputTomlFilePath(*inputTomlFile)
 
@@ -70,12 +80,20 @@ func initFlags() {
 
 /**
  *	config-translator --input ${JSON} --input-dir ${JSON_DIR} --output ${TOML} --mode ${param_mode} --config ${COMMON_CONFIG}
- *  --multi-config [default|append|remove]
+ *  --multi-config [default|append|remove] --input-fragment-dir ${FRAGMENT_DIR} [--validate]
  *
  *		multi-config:
  *			default:	only process .tmp files
  *			append:		process both existing files and .tmp files
  *			remove:		only process existing files
+ *
+ *		input-fragment-dir:
+ *			.json files in the directory are merged in filename order before the multi-config merge:
+ *			arrays are concatenated, objects are deep-merged and scalars are last-wins.
+ *
+ *		validate:
+ *			reports the unknown, removed and deprecated keys and the translation errors of the merged config
+ *			without writing the translated configs, and exits with a non-zero code if there are errors.
  */
 func main() {
 	initFlags()
@@ -95,6 +113,9 @@ func main() {
 		}
 	}()
 	ctx := context.CurrentContext()
+	if validateOnly {
+		os.Exit(runValidation(ctx, os.Stdout))
+	}
 
 	mergedJsonConfigMap, err := cmdutil.GenerateMergedJsonConfigMap(ctx)
 	if err != nil {
@@ -135,3 +156,25 @@ func main() {
 	envConfigPath := filepath.Join(tomlConfigDir, envConfigFileName)
 	cmdutil.TranslateJsonMapToEnvConfigFile(mergedJsonConfigMap, envConfigPath)
 }
+
+// runValidation writes the validation report of the json configs and returns the exit code, which is non-zero
+// if the config has errors.
+func runValidation(ctx *context.Context, w io.Writer) int {
+	report, err := cmdutil.ValidateJsonConfig(ctx)
+	if err != nil {
+		fmt.Fprintf(w, "E! Failed to validate json config: %v\n", err)
+		return 1
+	}
+	for _, warning := range report.Warnings {
+		fmt.Fprintln(w, warning)
+	}
+	for _, errMessage := range report.Errors {
+		fmt.Fprintln(w, errMessage)
+	}
+	if !report.Valid() {
+		fmt.Fprintf(w, validateErrorMessage, version)
+		return 1
+	}
+	fmt.Fprintln(w, validateSuccessMessage)
+	return 0
+}
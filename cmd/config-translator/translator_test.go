@@ -4,13 +4,18 @@
 package main
 
 import (
+	"bytes"
 	"os"
 	"regexp"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/aws/amazon-cloudwatch-agent/translator"
 	"github.com/aws/amazon-cloudwatch-agent/translator/cmdutil"
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+	"github.com/aws/amazon-cloudwatch-agent/translator/context"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
 	"github.com/aws/amazon-cloudwatch-agent/translator/util"
 )
 
@@ -65,6 +70,48 @@ func TestTracesConfig(t *testing.T) {
 	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidTrace.json", false, expectedErrorMap)
 }
 
+func TestTracesOtlpDestinationConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validTracesOtlpDestination.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{
+		"enum":         1,
+		"invalid_type": 1,
+		"number_gte":   1,
+		"required":     1,
+	}
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidTracesOtlpDestination.json", false, expectedErrorMap)
+}
+
+func TestTcpStateConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validTcpState.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{
+		"invalid_type":  1,
+		"number_all_of": 1,
+		"number_gte":    2,
+		"required":      1,
+	}
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidTcpState.json", false, expectedErrorMap)
+}
+
+func TestKubeletSummaryConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validKubeletSummary.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{
+		"additional_property_not_allowed": 1,
+		"number_gte":                      1,
+		"string_gte":                      1,
+	}
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidKubeletSummary.json", false, expectedErrorMap)
+}
+
+func TestLogStalenessConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validLogStaleness.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{
+		"additional_property_not_allowed": 1,
+		"number_gte":                      1,
+		"string_gte":                      1,
+	}
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidLogStaleness.json", false, expectedErrorMap)
+}
+
 func TestJMXConfig(t *testing.T) {
 	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validJMX.json", true, map[string]int{})
 	expectedErrorMap := map[string]int{}
@@ -83,6 +130,72 @@ func TestOTLPMetricsConfig(t *testing.T) {
 	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidOTLPMetrics.json", false, expectedErrorMap)
 }
 
+func TestWindowsETWConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validWindowsETW.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["required"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidWindowsETW.json", false, expectedErrorMap)
+}
+
+func TestOTLPLogsConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validOTLPLogs.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["additional_property_not_allowed"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidOTLPLogs.json", false, expectedErrorMap)
+}
+
+func TestHeartbeatConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validHeartbeat.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["additional_property_not_allowed"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidHeartbeat.json", false, expectedErrorMap)
+}
+
+func TestDockerLogsConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validDockerLogs.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["required"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidDockerLogs.json", false, expectedErrorMap)
+}
+
+func TestSyslogConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validSyslog.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["required"] = 1
+	expectedErrorMap["pattern"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidSyslog.json", false, expectedErrorMap)
+}
+
+func TestKubernetesLogsConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validKubernetesLogs.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["required"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidKubernetesLogs.json", false, expectedErrorMap)
+}
+
+func TestSpotInterruptionConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validSpotInterruption.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["required"] = 1
+	expectedErrorMap["number_gte"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidSpotInterruption.json", false, expectedErrorMap)
+}
+
+func TestPipelineErrorsConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validPipelineErrors.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["required"] = 1
+	expectedErrorMap["enum"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidPipelineErrors.json", false, expectedErrorMap)
+}
+
+func TestPrometheusMetricNameRulesConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validPrometheusMetricNameRules.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{}
+	expectedErrorMap["enum"] = 1
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidPrometheusMetricNameRules.json", false, expectedErrorMap)
+}
+
 func TestLogFilesConfig(t *testing.T) {
 	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validLogFiles.json", true, map[string]int{})
 	expectedErrorMap := map[string]int{}
@@ -169,6 +282,15 @@ func TestNvidiaGpuConfig(t *testing.T) {
 	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validNvidiaGpuConfig.json", true, map[string]int{})
 }
 
+func TestContainerInsightsGpuNodeAggregatesConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validContainerInsightsGpuNodeAggregates.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{
+		"invalid_type": 1,
+		"pattern":      1,
+	}
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidContainerInsightsGpuNodeAggregates.json", false, expectedErrorMap)
+}
+
 func TestValidLogFilterConfig(t *testing.T) {
 	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validLogFilesWithFilters.json", true, map[string]int{})
 }
@@ -181,6 +303,37 @@ func TestInvalidLogFilterConfig(t *testing.T) {
 	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidLogFilesWithFilters.json", false, expectedErrorMap)
 }
 
+func TestLogFilesWithSeverityConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validLogFilesWithSeverity.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{
+		"number_one_of": 2,
+		"pattern":       1,
+		"number_lte":    1,
+	}
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidLogFilesWithSeverity.json", false, expectedErrorMap)
+}
+
+func TestLogFilesWithRateLimitConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validLogFilesWithRateLimit.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{
+		"enum":          1,
+		"invalid_type":  1,
+		"number_any_of": 1,
+		"number_gt":     1,
+		"required":      1,
+	}
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidLogFilesWithRateLimit.json", false, expectedErrorMap)
+}
+
+func TestLogFilesWithSampleFirstNConfig(t *testing.T) {
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validLogFilesWithSampleFirstN.json", true, map[string]int{})
+	expectedErrorMap := map[string]int{
+		"invalid_type": 1,
+		"number_gte":   1,
+	}
+	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/invalidLogFilesWithSampleFirstN.json", false, expectedErrorMap)
+}
+
 func TestMetricsDestinationsConfig(t *testing.T) {
 	checkIfSchemaValidateAsExpected(t, "../../translator/config/sampleSchema/validMetricsDestinations.json", true, map[string]int{})
 	expectedErrorMap := map[string]int{}
@@ -206,3 +359,58 @@ func TestSampleConfigSchema(t *testing.T) {
 		panic(err)
 	}
 }
+
+func TestRunValidation(t *testing.T) {
+	util.DetectRegion = func(string, map[string]string) (string, string) {
+		return "us-west-2", "ACJ"
+	}
+	testCases := map[string]struct {
+		input        string
+		wantExitCode int
+		wantOutput   []string
+	}{
+		"Valid": {
+			input:      "testdata/validConfig.json",
+			wantOutput: []string{validateSuccessMessage},
+		},
+		"SchemaError": {
+			input:        "testdata/invalidTypeConfig.json",
+			wantExitCode: 1,
+			wantOutput:   []string{"Under path : /agent/metrics_collection_interval | Error : Invalid type. Expected: integer, given: string"},
+		},
+		// the invalid windows config is dropped on linux
+		"PlatformConfig": {
+			input:      "testdata/platformConfig.json",
+			wantOutput: []string{validateSuccessMessage},
+		},
+		"DeprecatedAndUnknownKeys": {
+			input:        "testdata/deprecatedKeyConfig.json",
+			wantExitCode: 1,
+			wantOutput: []string{
+				"Under path : /csm | Warning : deprecated key in testdata/deprecatedKeyConfig.json: CSM is deprecated and will be removed in a future version",
+				"Under path : /statsd | Error : unknown key in testdata/deprecatedKeyConfig.json",
+				"Configuration validation failed. Agent version: 1.0.",
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			context.ResetContext()
+			translator.ResetMessages()
+			agent.Global_Config = *new(agent.Agent)
+			translator.SetTargetPlatform(config.OS_TYPE_LINUX)
+			ctx := context.CurrentContext()
+			ctx.SetOs(config.OS_TYPE_LINUX)
+			ctx.SetMode(config.ModeEC2)
+			ctx.SetMultiConfig("remove")
+			ctx.SetInputJsonFilePath(testCase.input)
+			ctx.SetInputJsonDirPath(t.TempDir())
+
+			var output bytes.Buffer
+			assert.Equal(t, testCase.wantExitCode, runValidation(ctx, &output))
+			for _, want := range testCase.wantOutput {
+				assert.Contains(t, output.String(), want)
+			}
+		})
+	}
+}
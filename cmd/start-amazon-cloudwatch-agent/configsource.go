@@ -0,0 +1,159 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build linux || darwin
+// +build linux darwin
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
+	"github.com/aws/amazon-cloudwatch-agent/cfg/commonconfig"
+	"github.com/aws/amazon-cloudwatch-agent/cfg/configsource"
+	"github.com/aws/amazon-cloudwatch-agent/tool/paths"
+	"github.com/aws/amazon-cloudwatch-agent/translator/util"
+)
+
+const (
+	configSourceInterval = 5 * time.Minute
+
+	// agentChildEnv is set on the start-amazon-cloudwatch-agent process started by the supervisor, which starts the
+	// agent as the run_as_user like without a config source.
+	agentChildEnv = "CWAGENT_CONFIG_SOURCE_CHILD"
+)
+
+// readConfigSource returns the location of the config source that amazon-cloudwatch-agent-ctl -w has written, if any.
+func readConfigSource() string {
+	if os.Getenv(agentChildEnv) != "" {
+		return ""
+	}
+	content, err := os.ReadFile(paths.ConfigSourcePath)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			log.Printf("E! Unable to read the config source: %v", err)
+		}
+		return ""
+	}
+	return strings.TrimSpace(string(content))
+}
+
+// superviseAgent starts the agent in a child process and keeps running as the privileged user to fetch the json
+// config from the config source, since the agent may run as a user that cannot write the config directory. A changed
+// json config is translated, and the agent is reloaded with a SIGHUP.
+func superviseAgent(location string) error {
+	source, err := newConfigSource(location)
+	if err != nil {
+		return fmt.Errorf("unable to create the config source: %w", err)
+	}
+	applier := &configSourceApplier{path: filepath.Join(paths.ConfigDirPath, source.Name())}
+	current, err := os.ReadFile(applier.path)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("unable to read the json config of the config source: %w", err)
+	}
+
+	self, err := os.Executable()
+	if err != nil {
+		return err
+	}
+	cmd := exec.Command(self)
+	cmd.Env = append(os.Environ(), agentChildEnv+"=true")
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	if err = cmd.Start(); err != nil {
+		return fmt.Errorf("unable to start the agent: %w", err)
+	}
+	applier.agent = cmd.Process
+
+	// the service only stops the main process, so the signals are passed on to the agent
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGTERM, syscall.SIGINT)
+	go func() {
+		for sig := range signals {
+			_ = cmd.Process.Signal(sig)
+		}
+	}()
+
+	stop := make(chan struct{})
+	defer close(stop)
+	log.Printf("I! Fetching the json config from %s every %v", location, configSourceInterval)
+	go configsource.NewWatcher(source, applier, configSourceInterval, string(current)).Run(stop)
+	return cmd.Wait()
+}
+
+func newConfigSource(location string) (configsource.Source, error) {
+	cc := commonconfig.New()
+	if f, err := os.Open(paths.CommonConfigPath); err == nil {
+		err = cc.Parse(f)
+		_ = f.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+	util.SetProxyEnv(cc.ProxyMap())
+	util.SetSSLEnv(cc.SSLMap())
+	mode := util.DetectAgentMode("auto")
+	region, _ := util.DetectRegion(mode, cc.CredentialsMap())
+	credsMap := util.GetCredentials(mode, cc.CredentialsMap())
+	credentialConfig := &configaws.CredentialConfig{
+		Region:   region,
+		Profile:  credsMap[commonconfig.CredentialProfile],
+		Filename: credsMap[commonconfig.CredentialFile],
+	}
+	return configsource.New(location, credentialConfig.Credentials())
+}
+
+// configSourceApplier validates the json configs with the config-translator, and applies them by translating the
+// configs of the config directory again and reloading the agent.
+type configSourceApplier struct {
+	path  string
+	agent *os.Process
+}
+
+var _ configsource.Applier = (*configSourceApplier)(nil)
+
+func (a *configSourceApplier) Validate(content string) error {
+	dir, err := os.MkdirTemp("", "config-source")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	if err = os.WriteFile(filepath.Join(dir, filepath.Base(a.path)), []byte(content), 0644); err != nil {
+		return err
+	}
+	return runTranslator("--input-dir", dir, "--mode", "auto", "--validate")
+}
+
+func (a *configSourceApplier) Apply(content string) error {
+	if content == "" {
+		if err := os.Remove(a.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	} else if err := os.WriteFile(a.path, []byte(content), 0644); err != nil {
+		return err
+	}
+	if err := runTranslator("--output", paths.TomlConfigPath, "--mode", "auto", "--input", paths.JsonConfigPath,
+		"--input-dir", paths.ConfigDirPath, "--input-fragment-dir", paths.FragmentDirPath, "--config", paths.CommonConfigPath); err != nil {
+		return err
+	}
+	return a.agent.Signal(syscall.SIGHUP)
+}
+
+func runTranslator(args ...string) error {
+	output, err := exec.Command(paths.TranslatorBinaryPath, args...).CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, output)
+	}
+	return nil
+}
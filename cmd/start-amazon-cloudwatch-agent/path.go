@@ -39,6 +39,16 @@ func startAgent(writer io.WriteCloser) error {
 		return nil
 	}
 
+	if location := readConfigSource(); location != "" {
+		// the agent writes the log file, so the supervisor logs to the service output
+		if err := writer.Close(); err != nil {
+			log.Printf("E! Cannot close the log file, ERROR is %v ", err)
+			return err
+		}
+		log.SetOutput(os.Stdout)
+		return superviseAgent(location)
+	}
+
 	configMap, err := getTOMLConfigMap()
 	if err != nil {
 		log.Printf("E! Failed to read TOML config: %v ", err)
@@ -30,6 +30,10 @@ type Stats struct {
 	RegionType                *string           `json:"rt,omitempty"`
 	Mode                      *string           `json:"m,omitempty"`
 	EntityRejected            *int              `json:"ent,omitempty"`
+	InFlightRequests          *int              `json:"inf,omitempty"`
+	ConfigRulesApplied        *int              `json:"cra,omitempty"`
+	ConfigRuleWarnings        *int              `json:"crw,omitempty"`
+	ConfigRuleErrors          *int              `json:"cre,omitempty"`
 	StatusCodes               map[string][5]int `json:"codes,omitempty"` //represents status codes 200,400,408,413,429,
 }
 
@@ -81,6 +85,18 @@ func (s *Stats) Merge(other Stats) {
 	if other.EntityRejected != nil {
 		s.EntityRejected = other.EntityRejected
 	}
+	if other.InFlightRequests != nil {
+		s.InFlightRequests = other.InFlightRequests
+	}
+	if other.ConfigRulesApplied != nil {
+		s.ConfigRulesApplied = other.ConfigRulesApplied
+	}
+	if other.ConfigRuleWarnings != nil {
+		s.ConfigRuleWarnings = other.ConfigRuleWarnings
+	}
+	if other.ConfigRuleErrors != nil {
+		s.ConfigRuleErrors = other.ConfigRuleErrors
+	}
 	if other.StatusCodes != nil {
 		if s.StatusCodes == nil {
 			s.StatusCodes = make(map[string][5]int)
@@ -35,6 +35,10 @@ func TestMerge(t *testing.T) {
 		RunningInContainer:        aws.Int(0),
 		RegionType:                aws.String("RegionType"),
 		Mode:                      aws.String("Mode"),
+		InFlightRequests:          aws.Int(3),
+		ConfigRulesApplied:        aws.Int(38),
+		ConfigRuleWarnings:        aws.Int(1),
+		ConfigRuleErrors:          aws.Int(0),
 	})
 	assert.EqualValues(t, 1.5, *stats.CPUPercent)
 	assert.EqualValues(t, 133, *stats.MemoryBytes)
@@ -50,6 +54,10 @@ func TestMerge(t *testing.T) {
 	assert.EqualValues(t, 0, *stats.RunningInContainer)
 	assert.EqualValues(t, "RegionType", *stats.RegionType)
 	assert.EqualValues(t, "Mode", *stats.Mode)
+	assert.EqualValues(t, 3, *stats.InFlightRequests)
+	assert.EqualValues(t, 38, *stats.ConfigRulesApplied)
+	assert.EqualValues(t, 1, *stats.ConfigRuleWarnings)
+	assert.EqualValues(t, 0, *stats.ConfigRuleErrors)
 }
 
 func TestMergeWithStatusCodes(t *testing.T) {
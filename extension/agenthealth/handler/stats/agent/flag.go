@@ -24,6 +24,9 @@ const (
 	FlagRunningInContainer
 	FlagMode
 	FlagRegionType
+	FlagConfigRulesApplied
+	FlagConfigRuleWarnings
+	FlagConfigRuleErrors
 
 	flagIMDSFallbackSuccessStr       = "imds_fallback_success"
 	flagSharedConfigFallbackStr      = "shared_config_fallback"
@@ -32,6 +35,9 @@ const (
 	flagRunningInContainerStr        = "running_in_container"
 	flagModeStr                      = "mode"
 	flagRegionTypeStr                = "region_type"
+	flagConfigRulesAppliedStr        = "config_rules_applied"
+	flagConfigRuleWarningsStr        = "config_rule_warnings"
+	flagConfigRuleErrorsStr          = "config_rule_errors"
 )
 
 type Flag int
@@ -55,6 +61,12 @@ func (f Flag) String() string {
 		return flagRunningInContainerStr
 	case FlagSharedConfigFallback:
 		return flagSharedConfigFallbackStr
+	case FlagConfigRulesApplied:
+		return flagConfigRulesAppliedStr
+	case FlagConfigRuleWarnings:
+		return flagConfigRuleWarningsStr
+	case FlagConfigRuleErrors:
+		return flagConfigRuleErrorsStr
 	}
 	return ""
 }
@@ -83,6 +95,12 @@ func (f *Flag) UnmarshalText(text []byte) error {
 		*f = FlagRunningInContainer
 	case flagSharedConfigFallbackStr:
 		*f = FlagSharedConfigFallback
+	case flagConfigRulesAppliedStr:
+		*f = FlagConfigRulesApplied
+	case flagConfigRuleWarningsStr:
+		*f = FlagConfigRuleWarnings
+	case flagConfigRuleErrorsStr:
+		*f = FlagConfigRuleErrors
 	default:
 		return fmt.Errorf("%w: %s", errUnsupportedFlag, s)
 	}
@@ -100,6 +118,8 @@ type FlagSet interface {
 	IsSet(flag Flag) bool
 	// GetString if the value stored with the flag is a string. If not, returns nil.
 	GetString(flag Flag) *string
+	// GetInt if the value stored with the flag is an integer. If not, returns nil.
+	GetInt(flag Flag) *int
 	// Set adds the Flag with an unused value.
 	Set(flag Flag)
 	// SetValue adds the Flag with a value.
@@ -136,6 +156,22 @@ func (p *flagSet) GetString(flag Flag) *string {
 	return aws.String(str)
 }
 
+func (p *flagSet) GetInt(flag Flag) *int {
+	value, ok := p.m.Load(flag)
+	if !ok {
+		return nil
+	}
+	switch v := value.(type) {
+	case int:
+		return aws.Int(v)
+	case int64:
+		return aws.Int(int(v))
+	case uint64:
+		return aws.Int(int(v))
+	}
+	return nil
+}
+
 func (p *flagSet) Set(flag Flag) {
 	p.SetValue(flag, 1)
 }
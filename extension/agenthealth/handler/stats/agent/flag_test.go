@@ -53,6 +53,18 @@ func TestFlagSet(t *testing.T) {
 	assert.Equal(t, "test/mode", *got)
 	assert.True(t, fs.IsSet(FlagRunningInContainer))
 	assert.Equal(t, 3, notifyCount)
+	assert.Nil(t, fs.GetInt(FlagConfigRulesApplied))
+	fs.SetValues(map[Flag]any{
+		FlagConfigRulesApplied: 38,
+		FlagConfigRuleWarnings: uint64(1),
+	})
+	gotInt := fs.GetInt(FlagConfigRulesApplied)
+	assert.NotNil(t, gotInt)
+	assert.Equal(t, 38, *gotInt)
+	gotInt = fs.GetInt(FlagConfigRuleWarnings)
+	assert.NotNil(t, gotInt)
+	assert.Equal(t, 1, *gotInt)
+	assert.Nil(t, fs.GetInt(FlagMode))
 }
 
 func TestFlag(t *testing.T) {
@@ -67,6 +79,9 @@ func TestFlag(t *testing.T) {
 		{flag: FlagRegionType, str: flagRegionTypeStr},
 		{flag: FlagRunningInContainer, str: flagRunningInContainerStr},
 		{flag: FlagSharedConfigFallback, str: flagSharedConfigFallbackStr},
+		{flag: FlagConfigRulesApplied, str: flagConfigRulesAppliedStr},
+		{flag: FlagConfigRuleWarnings, str: flagConfigRuleWarningsStr},
+		{flag: FlagConfigRuleErrors, str: flagConfigRuleErrorsStr},
 	}
 	for _, testCase := range testCases {
 		flag := testCase.flag
@@ -41,7 +41,7 @@ func NewHandlers(logger *zap.Logger, cfg agent.StatsConfig, statusCodeEnabled bo
 	if agentStatsEnabled {
 		filter := agent.NewOperationsFilter(cfg.Operations...)
 		clientStats := client.NewHandler(filter)
-		statsProviders = append(statsProviders, clientStats, provider.GetProcessStats(), provider.GetFlagsStats())
+		statsProviders = append(statsProviders, clientStats, provider.GetProcessStats(), provider.GetFlagsStats(), provider.GetInFlightStats())
 		responseHandlers = append(responseHandlers, clientStats)
 		stats := newStatsHandler(logger, filter, statsProviders)
 		requestHandlers = append(requestHandlers, clientStats, stats)
@@ -4,6 +4,9 @@
 package provider
 
 import (
+	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
@@ -37,6 +40,9 @@ func (p *flagStats) update() {
 		RunningInContainer:        boolToInt(p.flagSet.IsSet(agent.FlagRunningInContainer)),
 		Mode:                      p.flagSet.GetString(agent.FlagMode),
 		RegionType:                p.flagSet.GetString(agent.FlagRegionType),
+		ConfigRulesApplied:        p.flagSet.GetInt(agent.FlagConfigRulesApplied),
+		ConfigRuleWarnings:        p.flagSet.GetInt(agent.FlagConfigRuleWarnings),
+		ConfigRuleErrors:          p.flagSet.GetInt(agent.FlagConfigRuleErrors),
 	})
 }
 
@@ -55,12 +61,34 @@ func boolToSparseInt(value bool) *int {
 	return nil
 }
 
+// configRuleCounts gets the applied, warning and error counts of the config rules that the config translator sets in
+// the environment of the agent.
+func configRuleCounts() (map[agent.Flag]any, bool) {
+	parts := strings.Split(os.Getenv(envconfig.CWAGENT_CONFIG_RULES), ",")
+	if len(parts) != 3 {
+		return nil, false
+	}
+	flags := []agent.Flag{agent.FlagConfigRulesApplied, agent.FlagConfigRuleWarnings, agent.FlagConfigRuleErrors}
+	counts := make(map[agent.Flag]any, len(flags))
+	for i, flag := range flags {
+		count, err := strconv.Atoi(parts[i])
+		if err != nil {
+			return nil, false
+		}
+		counts[flag] = count
+	}
+	return counts, true
+}
+
 func newFlagStats(flagSet agent.FlagSet, interval time.Duration) *flagStats {
 	stats := &flagStats{
 		flagSet:       flagSet,
 		intervalStats: newIntervalStats(interval),
 	}
 	stats.flagSet.OnChange(stats.update)
+	if counts, ok := configRuleCounts(); ok {
+		stats.flagSet.SetValues(counts)
+	}
 	if envconfig.IsRunningInContainer() {
 		stats.flagSet.Set(agent.FlagRunningInContainer)
 	} else {
@@ -37,3 +37,19 @@ func TestFlagStats(t *testing.T) {
 	assert.NotNil(t, got.Mode)
 	assert.Equal(t, "test", *got.Mode)
 }
+
+func TestConfigRuleCounts(t *testing.T) {
+	t.Setenv(envconfig.CWAGENT_CONFIG_RULES, "38,1,0")
+	got, ok := configRuleCounts()
+	assert.True(t, ok)
+	assert.Equal(t, map[agent.Flag]any{
+		agent.FlagConfigRulesApplied: 38,
+		agent.FlagConfigRuleWarnings: 1,
+		agent.FlagConfigRuleErrors:   0,
+	}, got)
+	for _, value := range []string{"", "38,1", "38,invalid,0"} {
+		t.Setenv(envconfig.CWAGENT_CONFIG_RULES, value)
+		_, ok = configRuleCounts()
+		assert.False(t, ok, value)
+	}
+}
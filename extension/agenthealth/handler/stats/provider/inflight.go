@@ -0,0 +1,29 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/stats/agent"
+	"github.com/aws/amazon-cloudwatch-agent/handlers"
+)
+
+// inFlightStats reports the number of requests being sent by the clients with an in-flight limit.
+type inFlightStats struct {
+	inFlight func() int
+}
+
+var _ agent.StatsProvider = (*inFlightStats)(nil)
+
+func (p *inFlightStats) Stats(string) agent.Stats {
+	if n := p.inFlight(); n > 0 {
+		return agent.Stats{InFlightRequests: aws.Int(n)}
+	}
+	return agent.Stats{}
+}
+
+func GetInFlightStats() agent.StatsProvider {
+	return &inFlightStats{inFlight: handlers.InFlightRequests}
+}
@@ -0,0 +1,20 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package provider
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInFlightStats(t *testing.T) {
+	var n int
+	p := &inFlightStats{inFlight: func() int { return n }}
+	assert.Nil(t, p.Stats("").InFlightRequests)
+	n = 4
+	got := p.Stats("")
+	assert.NotNil(t, got.InFlightRequests)
+	assert.Equal(t, 4, *got.InFlightRequests)
+}
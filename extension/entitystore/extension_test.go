@@ -113,6 +113,10 @@ func (m *mockMetadataProvider) InstanceID(ctx context.Context) (string, error) {
 	return "MockInstanceID", nil
 }
 
+func (m *mockMetadataProvider) AvailabilityZoneID(ctx context.Context) (string, error) {
+	return "MockAvailabilityZoneID", nil
+}
+
 func (m *mockMetadataProvider) InstanceTags(_ context.Context) ([]string, error) {
 	if m.InstanceTagError {
 		return nil, errors.New("an error occurred for instance tag retrieval")
@@ -0,0 +1,48 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pipelinehealth
+
+import (
+	"sync"
+)
+
+// Check returns an error if the component is not able to keep up, e.g. an output whose queue is full.
+type Check func() error
+
+var (
+	checksMu sync.RWMutex
+	checks   = map[string]Check{}
+)
+
+// RegisterCheck adds a readiness check for the named component. Replaces the check previously registered
+// with the same name.
+func RegisterCheck(name string, check Check) {
+	checksMu.Lock()
+	defer checksMu.Unlock()
+	checks[name] = check
+}
+
+// UnregisterCheck removes the readiness check of the named component.
+func UnregisterCheck(name string) {
+	checksMu.Lock()
+	defer checksMu.Unlock()
+	delete(checks, name)
+}
+
+// runChecks returns the result of each registered check keyed by name.
+func runChecks() map[string]error {
+	checksMu.RLock()
+	snapshot := make(map[string]Check, len(checks))
+	for name, check := range checks {
+		snapshot[name] = check
+	}
+	checksMu.RUnlock()
+
+	// run the checks without holding the lock so that a slow check doesn't block registration
+	results := make(map[string]error, len(snapshot))
+	for name, check := range snapshot {
+		results[name] = check()
+	}
+	return results
+}
@@ -0,0 +1,31 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pipelinehealth
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+var errMissingEndpoint = errors.New("endpoint must be specified")
+
+type Config struct {
+	// Endpoint is the address the health server binds to, e.g. 127.0.0.1:13133.
+	Endpoint string `mapstructure:"endpoint"`
+	// DebugState serves the internal state of the agent, such as the buffers of the outputs, at /debug/state.
+	DebugState bool `mapstructure:"debug_state,omitempty"`
+	// AuthToken is the bearer token that the requests to the debug state endpoint must have. Not required if empty.
+	AuthToken configopaque.String `mapstructure:"auth_token,omitempty"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errMissingEndpoint
+	}
+	return nil
+}
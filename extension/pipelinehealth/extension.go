@@ -0,0 +1,238 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pipelinehealth
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
+	"go.uber.org/zap"
+)
+
+const (
+	healthPath     = "/health"
+	readyPath      = "/ready"
+	debugStatePath = "/debug/state"
+
+	statusOK          = "ok"
+	statusUnavailable = "unavailable"
+)
+
+// response is the JSON body returned by the health and readiness endpoints.
+type response struct {
+	Status string `json:"status"`
+	// Ready is true once all pipelines have been started.
+	Ready bool `json:"ready"`
+	// Components has the last reported status of each component, e.g. "receiver/telegraf_cpu": "StatusOK".
+	Components map[string]string `json:"components,omitempty"`
+	// Checks has the result of each readiness check, e.g. "cloudwatchlogs": "ok".
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// stateResponse is the JSON body returned by the debug state endpoint.
+type stateResponse struct {
+	Time  time.Time `json:"time"`
+	Ready bool      `json:"ready"`
+	// Inputs are the receivers that are running, e.g. "receiver/telegraf_cpu".
+	Inputs []string `json:"inputs"`
+	// Components has the last reported status of each component.
+	Components map[string]string `json:"components,omitempty"`
+	// Outputs has the state of each output that registered one, e.g. its buffers and last flush.
+	Outputs map[string]State `json:"outputs,omitempty"`
+}
+
+type pipelineHealth struct {
+	logger *zap.Logger
+	config *Config
+	server *http.Server
+
+	ready      atomic.Bool
+	mu         sync.Mutex
+	components map[string]componentstatus.Status
+}
+
+var _ extension.Extension = (*pipelineHealth)(nil)
+var _ extensioncapabilities.PipelineWatcher = (*pipelineHealth)(nil)
+var _ componentstatus.Watcher = (*pipelineHealth)(nil)
+
+func newPipelineHealth(logger *zap.Logger, config *Config) *pipelineHealth {
+	return &pipelineHealth{
+		logger:     logger,
+		config:     config,
+		components: make(map[string]componentstatus.Status),
+	}
+}
+
+func (p *pipelineHealth) Start(context.Context, component.Host) error {
+	listener, err := net.Listen("tcp", p.config.Endpoint)
+	if err != nil {
+		return err
+	}
+	p.server = &http.Server{Handler: p.handler(), ReadHeaderTimeout: 90 * time.Second}
+	go func() {
+		if err := p.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			p.logger.Error("Health server stopped unexpectedly", zap.Error(err))
+		}
+	}()
+	p.logger.Debug("Started health server", zap.String("endpoint", listener.Addr().String()))
+	return nil
+}
+
+func (p *pipelineHealth) Shutdown(ctx context.Context) error {
+	if p.server == nil {
+		return nil
+	}
+	return p.server.Shutdown(ctx)
+}
+
+// Ready is called once all pipelines have been built and the receivers started.
+func (p *pipelineHealth) Ready() error {
+	p.ready.Store(true)
+	return nil
+}
+
+// NotReady is called before the receivers are stopped.
+func (p *pipelineHealth) NotReady() error {
+	p.ready.Store(false)
+	return nil
+}
+
+func (p *pipelineHealth) ComponentStatusChanged(source *componentstatus.InstanceID, event *componentstatus.Event) {
+	name := strings.ToLower(source.Kind().String()) + "/" + source.ComponentID().String()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.components[name] = event.Status()
+}
+
+func (p *pipelineHealth) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(healthPath, p.healthHandler)
+	mux.HandleFunc(readyPath, p.readyHandler)
+	if p.config.DebugState {
+		mux.HandleFunc(debugStatePath, p.debugStateHandler)
+	}
+	return mux
+}
+
+// healthHandler reports the agent as unhealthy if any component has hit an error that it cannot recover from.
+func (p *pipelineHealth) healthHandler(w http.ResponseWriter, _ *http.Request) {
+	resp, healthy := p.componentStatus(func(status componentstatus.Status) bool {
+		return status == componentstatus.StatusPermanentError || status == componentstatus.StatusFatalError
+	})
+	p.writeResponse(w, resp, healthy)
+}
+
+// readyHandler reports the agent as ready once the pipelines are started, none of the components are in an
+// error state, and the outputs are keeping up with the incoming data.
+func (p *pipelineHealth) readyHandler(w http.ResponseWriter, _ *http.Request) {
+	resp, ready := p.componentStatus(componentstatus.StatusIsError)
+	ready = ready && resp.Ready
+	for name, err := range runChecks() {
+		if resp.Checks == nil {
+			resp.Checks = make(map[string]string)
+		}
+		if err != nil {
+			resp.Checks[name] = err.Error()
+			ready = false
+		} else {
+			resp.Checks[name] = statusOK
+		}
+	}
+	p.writeResponse(w, resp, ready)
+}
+
+// componentStatus returns the response with the status of the components. Returns false if any of the
+// components has a status that is unhealthy.
+func (p *pipelineHealth) componentStatus(unhealthy func(componentstatus.Status) bool) (*response, bool) {
+	resp := &response{Ready: p.ready.Load()}
+	healthy := true
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.components) > 0 {
+		resp.Components = make(map[string]string, len(p.components))
+	}
+	for name, status := range p.components {
+		resp.Components[name] = status.String()
+		if unhealthy(status) {
+			healthy = false
+		}
+	}
+	return resp, healthy
+}
+
+func (p *pipelineHealth) writeResponse(w http.ResponseWriter, resp *response, ok bool) {
+	code := http.StatusOK
+	resp.Status = statusOK
+	if !ok {
+		code = http.StatusServiceUnavailable
+		resp.Status = statusUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		p.logger.Error("Failed to encode health response", zap.Error(err))
+	}
+}
+
+// debugStateHandler dumps the internal state of the agent to diagnose pipelines that are stuck.
+func (p *pipelineHealth) debugStateHandler(w http.ResponseWriter, r *http.Request) {
+	if !p.authorized(r) {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	resp := &stateResponse{
+		Time:    time.Now(),
+		Ready:   p.ready.Load(),
+		Inputs:  []string{},
+		Outputs: collectStates(),
+	}
+	p.mu.Lock()
+	if len(p.components) > 0 {
+		resp.Components = make(map[string]string, len(p.components))
+	}
+	for name, status := range p.components {
+		resp.Components[name] = status.String()
+		if strings.HasPrefix(name, "receiver/") && isActive(status) {
+			resp.Inputs = append(resp.Inputs, name)
+		}
+	}
+	p.mu.Unlock()
+	sort.Strings(resp.Inputs)
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		p.logger.Error("Failed to encode debug state response", zap.Error(err))
+	}
+}
+
+// authorized returns true if the auth token is not set or the request has it as its bearer token.
+func (p *pipelineHealth) authorized(r *http.Request) bool {
+	if p.config.AuthToken == "" {
+		return true
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(token), []byte(p.config.AuthToken)) == 1
+}
+
+// isActive returns true if the component is started and has not stopped or failed permanently.
+func isActive(status componentstatus.Status) bool {
+	switch status {
+	case componentstatus.StatusStarting, componentstatus.StatusOK, componentstatus.StatusRecoverableError:
+		return true
+	}
+	return false
+}
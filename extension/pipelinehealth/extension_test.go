@@ -0,0 +1,202 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pipelinehealth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+)
+
+func get(t *testing.T, p *pipelineHealth, path string) (int, response) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	p.handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var resp response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	return rec.Code, resp
+}
+
+func TestReady(t *testing.T) {
+	t.Cleanup(func() {
+		UnregisterCheck("outputs/test")
+	})
+	p := newPipelineHealth(zap.NewNop(), &Config{Endpoint: defaultEndpoint})
+	receiverID := componentstatus.NewInstanceID(component.MustNewID("telegraf_cpu"), component.KindReceiver)
+	p.ComponentStatusChanged(receiverID, componentstatus.NewEvent(componentstatus.StatusStarting))
+
+	// not ready until the pipelines are started
+	code, resp := get(t, p, readyPath)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, statusUnavailable, resp.Status)
+	assert.False(t, resp.Ready)
+
+	saturated := false
+	RegisterCheck("outputs/test", func() error {
+		if saturated {
+			return errors.New("queue is full")
+		}
+		return nil
+	})
+	require.NoError(t, p.Ready())
+	p.ComponentStatusChanged(receiverID, componentstatus.NewEvent(componentstatus.StatusOK))
+	code, resp = get(t, p, readyPath)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, response{
+		Status:     statusOK,
+		Ready:      true,
+		Components: map[string]string{"receiver/telegraf_cpu": "StatusOK"},
+		Checks:     map[string]string{"outputs/test": statusOK},
+	}, resp)
+
+	// outputs that are backed up make the agent unready, but still alive
+	saturated = true
+	code, resp = get(t, p, readyPath)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, map[string]string{"outputs/test": "queue is full"}, resp.Checks)
+	code, _ = get(t, p, healthPath)
+	assert.Equal(t, http.StatusOK, code)
+
+	saturated = false
+	code, _ = get(t, p, readyPath)
+	assert.Equal(t, http.StatusOK, code)
+
+	require.NoError(t, p.NotReady())
+	code, _ = get(t, p, readyPath)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+}
+
+func TestHealth(t *testing.T) {
+	p := newPipelineHealth(zap.NewNop(), &Config{Endpoint: defaultEndpoint})
+	code, resp := get(t, p, healthPath)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, statusOK, resp.Status)
+
+	receiverID := componentstatus.NewInstanceID(component.MustNewID("otlp"), component.KindReceiver)
+	exporterID := componentstatus.NewInstanceID(component.MustNewID("awscloudwatch"), component.KindExporter)
+	p.ComponentStatusChanged(receiverID, componentstatus.NewEvent(componentstatus.StatusOK))
+	p.ComponentStatusChanged(exporterID, componentstatus.NewRecoverableErrorEvent(errors.New("throttled")))
+	code, _ = get(t, p, healthPath)
+	assert.Equal(t, http.StatusOK, code)
+	require.NoError(t, p.Ready())
+	code, _ = get(t, p, readyPath)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+
+	p.ComponentStatusChanged(receiverID, componentstatus.NewPermanentErrorEvent(errors.New("address in use")))
+	code, resp = get(t, p, healthPath)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, "StatusPermanentError", resp.Components["receiver/otlp"])
+}
+
+func TestStartShutdown(t *testing.T) {
+	p := newPipelineHealth(zap.NewNop(), &Config{Endpoint: "127.0.0.1:0"})
+	// shutdown before start is a no-op
+	assert.NoError(t, p.Shutdown(context.Background()))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	p.config.Endpoint = listener.Addr().String()
+	// fails if the endpoint is already in use
+	assert.Error(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, listener.Close())
+
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, p.Ready())
+	resp, err := http.Get(fmt.Sprintf("http://%s%s", p.config.Endpoint, readyPath))
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	require.NoError(t, resp.Body.Close())
+	assert.NoError(t, p.Shutdown(context.Background()))
+}
+
+func TestDebugState(t *testing.T) {
+	t.Cleanup(func() {
+		UnregisterState("outputs/test")
+	})
+	lastFlush := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	RegisterState("outputs/test", func() State {
+		return State{
+			Buffers:   map[string]int{"metrics": 25, "requests": 2},
+			LastFlush: &lastFlush,
+			Errors:    3,
+		}
+	})
+	p := newPipelineHealth(zap.NewNop(), &Config{Endpoint: defaultEndpoint, DebugState: true})
+	cpuID := componentstatus.NewInstanceID(component.MustNewID("telegraf_cpu"), component.KindReceiver)
+	diskID := componentstatus.NewInstanceID(component.MustNewID("telegraf_disk"), component.KindReceiver)
+	memID := componentstatus.NewInstanceID(component.MustNewID("telegraf_mem"), component.KindReceiver)
+	exporterID := componentstatus.NewInstanceID(component.MustNewID("awscloudwatch"), component.KindExporter)
+	p.ComponentStatusChanged(cpuID, componentstatus.NewEvent(componentstatus.StatusOK))
+	p.ComponentStatusChanged(diskID, componentstatus.NewRecoverableErrorEvent(errors.New("no such device")))
+	p.ComponentStatusChanged(memID, componentstatus.NewEvent(componentstatus.StatusStopped))
+	p.ComponentStatusChanged(exporterID, componentstatus.NewEvent(componentstatus.StatusOK))
+	require.NoError(t, p.Ready())
+
+	rec := httptest.NewRecorder()
+	p.handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, debugStatePath, nil))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var resp stateResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+	assert.True(t, resp.Ready)
+	assert.WithinDuration(t, time.Now(), resp.Time, time.Minute)
+	// stopped receivers are not active
+	assert.Equal(t, []string{"receiver/telegraf_cpu", "receiver/telegraf_disk"}, resp.Inputs)
+	assert.Equal(t, "StatusStopped", resp.Components["receiver/telegraf_mem"])
+	assert.Equal(t, "StatusOK", resp.Components["exporter/awscloudwatch"])
+	require.Contains(t, resp.Outputs, "outputs/test")
+	output := resp.Outputs["outputs/test"]
+	assert.Equal(t, map[string]int{"metrics": 25, "requests": 2}, output.Buffers)
+	require.NotNil(t, output.LastFlush)
+	assert.True(t, lastFlush.Equal(*output.LastFlush))
+	assert.EqualValues(t, 3, output.Errors)
+}
+
+func TestDebugStateDisabled(t *testing.T) {
+	p := newPipelineHealth(zap.NewNop(), &Config{Endpoint: defaultEndpoint})
+	rec := httptest.NewRecorder()
+	p.handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, debugStatePath, nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestDebugStateAuthToken(t *testing.T) {
+	p := newPipelineHealth(zap.NewNop(), &Config{Endpoint: defaultEndpoint, DebugState: true, AuthToken: "secret"})
+	testCases := map[string]struct {
+		authorization string
+		want          int
+	}{
+		"Missing":   {want: http.StatusUnauthorized},
+		"Wrong":     {authorization: "Bearer wrong", want: http.StatusUnauthorized},
+		"NotBearer": {authorization: "Basic secret", want: http.StatusUnauthorized},
+		"Valid":     {authorization: "Bearer secret", want: http.StatusOK},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, debugStatePath, nil)
+			if testCase.authorization != "" {
+				req.Header.Set("Authorization", testCase.authorization)
+			}
+			rec := httptest.NewRecorder()
+			p.handler().ServeHTTP(rec, req)
+			assert.Equal(t, testCase.want, rec.Code)
+		})
+	}
+	// the health endpoints are not guarded
+	code, _ := get(t, p, healthPath)
+	assert.Equal(t, http.StatusOK, code)
+}
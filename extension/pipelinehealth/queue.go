@@ -0,0 +1,42 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pipelinehealth
+
+import (
+	"sync"
+)
+
+// QueueUsage returns how full the queue of a component is, from 0 when it is empty to 1 when it is full.
+type QueueUsage func() float64
+
+var (
+	queuesMu sync.RWMutex
+	queues   = map[string]QueueUsage{}
+)
+
+// RegisterQueue adds the queue usage of the named component. Replaces the usage previously registered with the
+// same name.
+func RegisterQueue(name string, usage QueueUsage) {
+	queuesMu.Lock()
+	defer queuesMu.Unlock()
+	queues[name] = usage
+}
+
+// UnregisterQueue removes the queue usage of the named component.
+func UnregisterQueue(name string) {
+	queuesMu.Lock()
+	defer queuesMu.Unlock()
+	delete(queues, name)
+}
+
+// MaxQueueUsage returns the usage of the fullest registered queue, or 0 if there are none.
+func MaxQueueUsage() float64 {
+	queuesMu.RLock()
+	defer queuesMu.RUnlock()
+	var result float64
+	for _, usage := range queues {
+		result = max(result, usage())
+	}
+	return result
+}
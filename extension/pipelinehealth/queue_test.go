@@ -0,0 +1,20 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pipelinehealth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxQueueUsage(t *testing.T) {
+	assert.Equal(t, 0.0, MaxQueueUsage())
+	RegisterQueue("a", func() float64 { return 0.25 })
+	RegisterQueue("b", func() float64 { return 0.75 })
+	defer UnregisterQueue("a")
+	assert.Equal(t, 0.75, MaxQueueUsage())
+	UnregisterQueue("b")
+	assert.Equal(t, 0.25, MaxQueueUsage())
+}
@@ -0,0 +1,59 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pipelinehealth
+
+import (
+	"sync"
+	"time"
+)
+
+// State is the internal state of a component that is reported by the debug state endpoint.
+type State struct {
+	// Buffers has the number of items waiting in each buffer of the component, e.g. "metrics": 10.
+	Buffers map[string]int `json:"buffers,omitempty"`
+	// LastFlush is the last time that the component sent its data. Not set if it has not sent anything yet.
+	LastFlush *time.Time `json:"last_flush,omitempty"`
+	// Errors is the number of times that the component failed to send its data.
+	Errors uint64 `json:"errors"`
+	// Counters has the number of times that each event of the component happened, e.g. "throttled": 3.
+	Counters map[string]uint64 `json:"counters,omitempty"`
+}
+
+// StateFunc returns the current state of a component.
+type StateFunc func() State
+
+var (
+	statesMu sync.RWMutex
+	states   = map[string]StateFunc{}
+)
+
+// RegisterState adds the state of the named component to the debug state endpoint. Replaces the state previously
+// registered with the same name.
+func RegisterState(name string, state StateFunc) {
+	statesMu.Lock()
+	defer statesMu.Unlock()
+	states[name] = state
+}
+
+// UnregisterState removes the state of the named component.
+func UnregisterState(name string) {
+	statesMu.Lock()
+	defer statesMu.Unlock()
+	delete(states, name)
+}
+
+// collectStates returns the state of each registered component keyed by name.
+func collectStates() map[string]State {
+	statesMu.RLock()
+	snapshot := make(map[string]StateFunc, len(states))
+	for name, state := range states {
+		snapshot[name] = state
+	}
+	statesMu.RUnlock()
+	results := make(map[string]State, len(snapshot))
+	for name, state := range snapshot {
+		results[name] = state()
+	}
+	return results
+}
@@ -0,0 +1,25 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pipelinehealth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectStates(t *testing.T) {
+	assert.Empty(t, collectStates())
+	RegisterState("a", func() State { return State{Errors: 1} })
+	RegisterState("b", func() State {
+		return State{Buffers: map[string]int{"events": 5}, Counters: map[string]uint64{"throttled": 2}}
+	})
+	defer UnregisterState("a")
+	assert.Equal(t, map[string]State{
+		"a": {Errors: 1},
+		"b": {Buffers: map[string]int{"events": 5}, Counters: map[string]uint64{"throttled": 2}},
+	}, collectStates())
+	UnregisterState("b")
+	assert.Equal(t, map[string]State{"a": {Errors: 1}}, collectStates())
+}
@@ -0,0 +1,63 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package handlers
+
+import (
+	"sync/atomic"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+)
+
+const (
+	inFlightAcquireHandlerName = "InFlightAcquireHandler"
+	inFlightReleaseHandlerName = "InFlightReleaseHandler"
+)
+
+// inFlightRequests is the number of requests being sent across all the limiters.
+var inFlightRequests atomic.Int64
+
+// InFlightRequests returns the number of requests currently being sent by the clients with an InFlightLimiter.
+func InFlightRequests() int {
+	return int(inFlightRequests.Load())
+}
+
+// InFlightLimiter limits the number of requests that are sent at the same time by the clients it is configured on.
+// Requests that are waiting to be retried do not count towards the limit.
+type InFlightLimiter struct {
+	slots    chan struct{}
+	inFlight atomic.Int64
+}
+
+func NewInFlightLimiter(limit int) *InFlightLimiter {
+	return &InFlightLimiter{slots: make(chan struct{}, limit)}
+}
+
+// Configure adds the handlers that acquire a slot before the request is sent and release it once the response is
+// received.
+func (l *InFlightLimiter) Configure(handlers *request.Handlers) {
+	handlers.Send.PushFrontNamed(request.NamedHandler{Name: inFlightAcquireHandlerName, Fn: l.acquire})
+	handlers.Send.PushBackNamed(request.NamedHandler{Name: inFlightReleaseHandlerName, Fn: l.release})
+}
+
+// InFlight returns the number of requests currently being sent.
+func (l *InFlightLimiter) InFlight() int {
+	return int(l.inFlight.Load())
+}
+
+// Limit returns the maximum number of requests that can be sent at the same time.
+func (l *InFlightLimiter) Limit() int {
+	return cap(l.slots)
+}
+
+func (l *InFlightLimiter) acquire(*request.Request) {
+	l.slots <- struct{}{}
+	l.inFlight.Add(1)
+	inFlightRequests.Add(1)
+}
+
+func (l *InFlightLimiter) release(*request.Request) {
+	inFlightRequests.Add(-1)
+	l.inFlight.Add(-1)
+	<-l.slots
+}
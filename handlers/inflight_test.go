@@ -0,0 +1,50 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package handlers
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInFlightLimiter(t *testing.T) {
+	limiter := NewInFlightLimiter(3)
+	assert.Equal(t, 3, limiter.Limit())
+
+	var current, peak atomic.Int32
+	var handlers request.Handlers
+	handlers.Send.PushBack(func(*request.Request) {
+		n := current.Add(1)
+		defer current.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		assert.LessOrEqual(t, limiter.InFlight(), 3)
+		assert.LessOrEqual(t, InFlightRequests(), 3)
+		time.Sleep(10 * time.Millisecond)
+	})
+	limiter.Configure(&handlers)
+	assert.Equal(t, 3, handlers.Send.Len())
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			handlers.Send.Run(&request.Request{})
+		}()
+	}
+	wg.Wait()
+	assert.EqualValues(t, 3, peak.Load())
+	assert.Equal(t, 0, limiter.InFlight())
+	assert.Equal(t, 0, InFlightRequests())
+}
@@ -17,6 +17,7 @@ const (
 	MetricType              = "Type"
 	SourcesKey              = "Sources"
 	GpuDeviceKey            = "GpuDevice"
+	MigDeviceKey            = "MigDevice"
 
 	ClusterQueueNameKey     = "ClusterQueue"
 	ClusterQueueStatusKey   = "Status"
@@ -92,6 +93,10 @@ const (
 	GpuLimit          = "gpu_limit"
 	GpuTotal          = "gpu_total"
 	GpuUniqueId       = "UUID"
+	// GPU reliability metrics, which are only published with the node schema since they are errors of the device
+	GpuEccSingleBitErrors = "gpu_ecc_single_bit_errors"
+	GpuEccDoubleBitErrors = "gpu_ecc_double_bit_errors"
+	GpuXidError           = "gpu_xid_error"
 
 	NeuronCoreUtilization                       = "neuroncore_utilization"
 	NeuronCoreMemoryUtilizationTotal            = "neuroncore_memory_usage_total"
@@ -21,6 +21,7 @@ type MetadataProvider interface {
 	Get(ctx context.Context) (ec2metadata.EC2InstanceIdentityDocument, error)
 	Hostname(ctx context.Context) (string, error)
 	InstanceID(ctx context.Context) (string, error)
+	AvailabilityZoneID(ctx context.Context) (string, error)
 	InstanceTags(ctx context.Context) ([]string, error)
 	ClientIAMRole(ctx context.Context) (string, error)
 	InstanceTagValue(ctx context.Context, tagKey string) (string, error)
@@ -56,6 +57,12 @@ func (c *metadataClient) InstanceID(ctx context.Context) (string, error) {
 	})
 }
 
+func (c *metadataClient) AvailabilityZoneID(ctx context.Context) (string, error) {
+	return withMetadataFallbackRetry(ctx, c, func(metadataClient *ec2metadata.EC2Metadata) (string, error) {
+		return metadataClient.GetMetadataWithContext(ctx, "placement/availability-zone-id")
+	})
+}
+
 func (c *metadataClient) Hostname(ctx context.Context) (string, error) {
 	return withMetadataFallbackRetry(ctx, c, func(metadataClient *ec2metadata.EC2Metadata) (string, error) {
 		return metadataClient.GetMetadataWithContext(ctx, "hostname")
@@ -15,6 +15,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	stats "k8s.io/kubelet/pkg/apis/stats/v1alpha1"
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/tls"
 )
@@ -32,18 +33,50 @@ var ErrKubeClientAccessFailure = errors.New("KubeClient Access Failure")
 
 func (k *KubeClient) ListPods() ([]corev1.Pod, error) {
 	var result []corev1.Pod
-	url := fmt.Sprintf("https://%s:%s/pods", k.KubeIP, k.Port)
-
-	var req, _ = http.NewRequest("GET", url, nil)
-	var resp *http.Response
-
 	k.InsecureSkipVerify = true
-	tlsCfg, err := k.ClientConfig.TLSConfig()
+	b, err := k.get("/pods")
+	if err != nil {
+		return result, err
+	}
+
+	pods := corev1.PodList{}
+	err = json.Unmarshal(b, &pods)
 	if err != nil {
+		log.Printf("E! parsing response: %s", err)
 		return result, err
 	}
 
+	return pods.Items, nil
+}
+
+// Summary returns the node, pod and container resource usage from the kubelet
+// summary API.
+func (k *KubeClient) Summary() (*stats.Summary, error) {
+	b, err := k.get("/stats/summary")
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &stats.Summary{}
+	err = json.Unmarshal(b, summary)
+	if err != nil {
+		log.Printf("E! parsing response: %s", err)
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+func (k *KubeClient) get(path string) ([]byte, error) {
+	url := fmt.Sprintf("https://%s:%s%s", k.KubeIP, k.Port, path)
+
+	var req, _ = http.NewRequest("GET", url, nil)
+
 	if k.roundTripper == nil {
+		tlsCfg, err := k.ClientConfig.TLSConfig()
+		if err != nil {
+			return nil, err
+		}
 		// Set default values
 		if k.responseTimeout < time.Second {
 			k.responseTimeout = time.Second * 5
@@ -58,36 +91,28 @@ func (k *KubeClient) ListPods() ([]corev1.Pod, error) {
 	if k.BearerToken != "" {
 		token, err := os.ReadFile(k.BearerToken)
 		if err != nil {
-			return result, err
+			return nil, err
 		}
 		req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
 	}
 	req.Header.Add("Accept", "application/json")
 
-	resp, err = k.roundTripper.RoundTrip(req)
+	resp, err := k.roundTripper.RoundTrip(req)
 	if err != nil {
 		log.Printf("E! error making HTTP request to %s: %s", url, err)
-		return result, ErrKubeClientAccessFailure
+		return nil, ErrKubeClientAccessFailure
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
 		log.Printf("E! %s returned HTTP status %s", url, resp.Status)
-		return result, ErrKubeClientAccessFailure
+		return nil, ErrKubeClientAccessFailure
 	}
 
 	b, err := io.ReadAll(resp.Body)
 	if err != nil {
 		log.Printf("E! Fail to read request %s body: %s", url, err)
-		return result, err
+		return nil, err
 	}
-
-	pods := corev1.PodList{}
-	err = json.Unmarshal(b, &pods)
-	if err != nil {
-		log.Printf("E! parsing response: %s", err)
-		return result, err
-	}
-
-	return pods.Items, nil
+	return b, nil
 }
@@ -193,3 +193,20 @@ func TestKubeClient_ListPods(t *testing.T) {
 	assert.Equal(t, nil, err)
 	assert.Equal(t, 1, len(pods))
 }
+
+func TestKubeClient_Summary(t *testing.T) {
+	mockRoundTripper := new(MockHttpRoundTripper)
+	mockRoundTripper.On("RoundTrip", mock.Anything).Return(&http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader(`{"node":{"nodeName":"node","cpu":{"usageNanoCores":1000}},"pods":[{"podRef":{"name":"pod","namespace":"default"}}]}`))})
+	client := KubeClient{roundTripper: mockRoundTripper}
+	summary, err := client.Summary()
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "node", summary.Node.NodeName)
+	assert.Equal(t, uint64(1000), *summary.Node.CPU.UsageNanoCores)
+	assert.Equal(t, 1, len(summary.Pods))
+
+	mockRoundTripper = new(MockHttpRoundTripper)
+	mockRoundTripper.On("RoundTrip", mock.Anything).Return(&http.Response{StatusCode: http.StatusForbidden, Status: "403 Forbidden", Body: io.NopCloser(strings.NewReader(""))})
+	client = KubeClient{roundTripper: mockRoundTripper}
+	_, err = client.Summary()
+	assert.Equal(t, ErrKubeClientAccessFailure, err)
+}
@@ -51,6 +51,37 @@ func RangeMetrics(md pmetric.Metrics, fn func(m pmetric.Metric)) {
 	}
 }
 
+// RemoveEmptyMetrics removes the metrics without data points along with the
+// scopes and resources that are left empty.
+func RemoveEmptyMetrics(md pmetric.Metrics) {
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				return DataPointsLen(m) == 0
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+}
+
+// DataPointsLen returns the number of data points in the metric.
+func DataPointsLen(m pmetric.Metric) int {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return m.Gauge().DataPoints().Len()
+	case pmetric.MetricTypeSum:
+		return m.Sum().DataPoints().Len()
+	case pmetric.MetricTypeHistogram:
+		return m.Histogram().DataPoints().Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		return m.ExponentialHistogram().DataPoints().Len()
+	case pmetric.MetricTypeSummary:
+		return m.Summary().DataPoints().Len()
+	}
+	return 0
+}
+
 func RangeDataPointAttributes(m pmetric.Metric, fn func(attrs pcommon.Map)) {
 	switch m.Type() {
 	case pmetric.MetricTypeGauge:
@@ -106,3 +106,31 @@ func TestAddGaugeMetricDataPoint(t *testing.T) {
 
 	assert.Equal(t, expected, metrics.metrics)
 }
+
+func TestRemoveEmptyMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	ms.AppendEmpty().SetName("empty_gauge")
+	ms.At(0).SetEmptyGauge()
+	ms.AppendEmpty().SetName("sum")
+	ms.At(1).SetEmptySum().DataPoints().AppendEmpty().SetIntValue(1)
+	ms.AppendEmpty().SetName("empty_histogram")
+	ms.At(2).SetEmptyHistogram()
+	// metrics without a type have no data points
+	ms.AppendEmpty().SetName("empty")
+	// scope left empty after its metrics are removed
+	empty := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	empty.SetName("empty_summary")
+	empty.SetEmptySummary()
+	// resource left empty after its scopes are removed
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyExponentialHistogram()
+
+	RemoveEmptyMetrics(md)
+	assert.Equal(t, 1, md.ResourceMetrics().Len())
+	assert.Equal(t, 1, md.ResourceMetrics().At(0).ScopeMetrics().Len())
+	got := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	assert.Equal(t, 1, got.Len())
+	assert.Equal(t, "sum", got.At(0).Name())
+	assert.Equal(t, 1, md.DataPointCount())
+}
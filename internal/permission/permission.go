@@ -0,0 +1,159 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package permission checks the permissions of the credentials of the outputs at startup, so that missing permissions
+// stop the agent with an actionable error instead of failing every request.
+package permission
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/arn"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/client"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+)
+
+const (
+	actionGetCallerIdentity       = "sts:GetCallerIdentity"
+	actionSimulatePrincipalPolicy = "iam:SimulatePrincipalPolicy"
+
+	// PartitionPlaceholder and AccountPlaceholder are replaced in the resource ARNs with the partition and account of
+	// the principal of the credentials.
+	PartitionPlaceholder = "{partition}"
+	AccountPlaceholder   = "{account}"
+)
+
+// accessDeniedCodes are the error codes of the AWS services for requests that the credentials are not allowed to make.
+var accessDeniedCodes = map[string]bool{
+	"AccessDenied":          true,
+	"AccessDeniedException": true,
+	"UnauthorizedOperation": true,
+}
+
+// errNotAllowed is the error of an action that the policies of the principal do not allow.
+var errNotAllowed = errors.New("not allowed")
+
+// Error is a failed permission check.
+type Error struct {
+	// Action is the IAM action that was checked, such as cloudwatch:PutMetricData.
+	Action string
+	// Resource is what the action was checked on, such as the namespace or log group.
+	Resource string
+	// Region is the region that the action was checked in.
+	Region string
+	Err    error
+}
+
+func (e *Error) Error() string {
+	if IsAccessDenied(e.Err) || errors.Is(e.Err, errNotAllowed) {
+		return fmt.Sprintf("missing permission %s for %s in %s: grant %s to the IAM role or user of the agent, "+
+			"such as with the CloudWatchAgentServerPolicy managed policy: %v", e.Action, e.Resource, e.Region, e.Action, e.Err)
+	}
+	return fmt.Sprintf("unable to check permission %s for %s in %s: %v", e.Action, e.Resource, e.Region, e.Err)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// IsAccessDenied returns whether the error is from a request that the credentials are not allowed to make.
+func IsAccessDenied(err error) bool {
+	var awsErr awserr.Error
+	return errors.As(err, &awsErr) && accessDeniedCodes[awsErr.Code()]
+}
+
+// Checker checks whether the credentials allow the actions on a resource in a region.
+type Checker interface {
+	Check(region, resource, resourceARN string, actions []string, contextEntries map[string]string) error
+}
+
+// Simulator checks the actions of the outputs with iam:SimulatePrincipalPolicy, which evaluates the policies of the
+// principal of the credentials without making the requests of the actions, so the check does not write any data.
+type Simulator struct {
+	sts stsiface.STSAPI
+	iam iamiface.IAMAPI
+}
+
+var _ Checker = (*Simulator)(nil)
+
+func NewSimulator(p client.ConfigProvider, cfgs ...*aws.Config) *Simulator {
+	return &Simulator{sts: sts.New(p, cfgs...), iam: iam.New(p, cfgs...)}
+}
+
+// Check returns an Error for the first of the actions that the policies of the principal do not allow on the resource
+// ARN in the region. The context entries are the condition keys of the requests, such as cloudwatch:namespace.
+func (s *Simulator) Check(region, resource, resourceARN string, actions []string, contextEntries map[string]string) error {
+	identity, err := s.sts.GetCallerIdentity(&sts.GetCallerIdentityInput{})
+	if err != nil {
+		return &Error{Action: actionGetCallerIdentity, Resource: resource, Region: region, Err: err}
+	}
+	principal, err := principalARN(aws.StringValue(identity.Arn))
+	if err != nil {
+		return &Error{Action: actionGetCallerIdentity, Resource: resource, Region: region, Err: err}
+	}
+	input := &iam.SimulatePrincipalPolicyInput{
+		PolicySourceArn: aws.String(principal.String()),
+		ActionNames:     aws.StringSlice(actions),
+		ContextEntries:  []*iam.ContextEntry{contextEntry("aws:RequestedRegion", region)},
+	}
+	if resourceARN != "" {
+		resourceARN = strings.NewReplacer(PartitionPlaceholder, principal.Partition, AccountPlaceholder, principal.AccountID).Replace(resourceARN)
+		input.ResourceArns = aws.StringSlice([]string{resourceARN})
+	}
+	keys := make([]string, 0, len(contextEntries))
+	for key := range contextEntries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		input.ContextEntries = append(input.ContextEntries, contextEntry(key, contextEntries[key]))
+	}
+	output, err := s.iam.SimulatePrincipalPolicy(input)
+	if err != nil {
+		return &Error{Action: actionSimulatePrincipalPolicy, Resource: principal.String(), Region: region, Err: err}
+	}
+	for _, result := range output.EvaluationResults {
+		if decision := aws.StringValue(result.EvalDecision); decision != iam.PolicyEvaluationDecisionTypeAllowed {
+			return &Error{
+				Action:   aws.StringValue(result.EvalActionName),
+				Resource: resource,
+				Region:   region,
+				Err:      fmt.Errorf("%w by the policies of %s: %s", errNotAllowed, principal, decision),
+			}
+		}
+	}
+	return nil
+}
+
+// principalARN returns the ARN of the IAM role of an assumed role session, which is the principal that the policies
+// are attached to. The ARN of other principals, such as an IAM user, is used as is.
+func principalARN(callerARN string) (arn.ARN, error) {
+	principal, err := arn.Parse(callerARN)
+	if err != nil {
+		return principal, err
+	}
+	if principal.Service == sts.ServiceName {
+		parts := strings.Split(principal.Resource, "/")
+		if len(parts) == 3 && parts[0] == "assumed-role" {
+			principal.Service = iam.ServiceName
+			principal.Resource = "role/" + parts[1]
+		}
+	}
+	return principal, nil
+}
+
+func contextEntry(key, value string) *iam.ContextEntry {
+	return &iam.ContextEntry{
+		ContextKeyName:   aws.String(key),
+		ContextKeyType:   aws.String(iam.ContextKeyTypeEnumString),
+		ContextKeyValues: aws.StringSlice([]string{value}),
+	}
+}
@@ -0,0 +1,120 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package permission
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/iam"
+	"github.com/aws/aws-sdk-go/service/iam/iamiface"
+	"github.com/aws/aws-sdk-go/service/sts"
+	"github.com/aws/aws-sdk-go/service/sts/stsiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubSTS struct {
+	stsiface.STSAPI
+	arn string
+	err error
+}
+
+func (s *stubSTS) GetCallerIdentity(*sts.GetCallerIdentityInput) (*sts.GetCallerIdentityOutput, error) {
+	return &sts.GetCallerIdentityOutput{Arn: aws.String(s.arn)}, s.err
+}
+
+type stubIAM struct {
+	iamiface.IAMAPI
+	inputs    []*iam.SimulatePrincipalPolicyInput
+	decisions map[string]string
+	err       error
+}
+
+func (s *stubIAM) SimulatePrincipalPolicy(input *iam.SimulatePrincipalPolicyInput) (*iam.SimulatePolicyResponse, error) {
+	s.inputs = append(s.inputs, input)
+	output := &iam.SimulatePolicyResponse{}
+	for _, action := range input.ActionNames {
+		decision, ok := s.decisions[*action]
+		if !ok {
+			decision = iam.PolicyEvaluationDecisionTypeAllowed
+		}
+		output.EvaluationResults = append(output.EvaluationResults, &iam.EvaluationResult{
+			EvalActionName: action,
+			EvalDecision:   aws.String(decision),
+		})
+	}
+	return output, s.err
+}
+
+func TestIsAccessDenied(t *testing.T) {
+	assert.True(t, IsAccessDenied(awserr.New("AccessDenied", "not authorized", nil)))
+	assert.True(t, IsAccessDenied(fmt.Errorf("wrapped: %w", awserr.New("AccessDeniedException", "not authorized", nil))))
+	assert.False(t, IsAccessDenied(awserr.New("ThrottlingException", "rate exceeded", nil)))
+	assert.False(t, IsAccessDenied(errors.New("AccessDenied")))
+	assert.False(t, IsAccessDenied(nil))
+}
+
+func TestSimulatorCheck(t *testing.T) {
+	stubIAM := &stubIAM{}
+	s := &Simulator{
+		sts: &stubSTS{arn: "arn:aws-cn:sts::123456789012:assumed-role/CloudWatchAgent/i-0123456789abcdef0"},
+		iam: stubIAM,
+	}
+	err := s.Check("cn-north-1", "log groups", "arn:{partition}:logs:cn-north-1:{account}:log-group:*",
+		[]string{"logs:CreateLogStream", "logs:PutLogEvents"}, map[string]string{"logs:key": "value"})
+	require.NoError(t, err)
+	require.Len(t, stubIAM.inputs, 1)
+	input := stubIAM.inputs[0]
+	assert.Equal(t, "arn:aws-cn:iam::123456789012:role/CloudWatchAgent", *input.PolicySourceArn)
+	assert.Equal(t, []string{"logs:CreateLogStream", "logs:PutLogEvents"}, aws.StringValueSlice(input.ActionNames))
+	assert.Equal(t, []string{"arn:aws-cn:logs:cn-north-1:123456789012:log-group:*"}, aws.StringValueSlice(input.ResourceArns))
+	require.Len(t, input.ContextEntries, 2)
+	assert.Equal(t, "aws:RequestedRegion", *input.ContextEntries[0].ContextKeyName)
+	assert.Equal(t, []string{"cn-north-1"}, aws.StringValueSlice(input.ContextEntries[0].ContextKeyValues))
+	assert.Equal(t, "logs:key", *input.ContextEntries[1].ContextKeyName)
+	assert.Equal(t, []string{"value"}, aws.StringValueSlice(input.ContextEntries[1].ContextKeyValues))
+}
+
+func TestSimulatorCheckUser(t *testing.T) {
+	stubIAM := &stubIAM{}
+	s := &Simulator{sts: &stubSTS{arn: "arn:aws:iam::123456789012:user/agent"}, iam: stubIAM}
+	require.NoError(t, s.Check("us-east-1", "namespace CWAgent", "", []string{"cloudwatch:PutMetricData"}, nil))
+	require.Len(t, stubIAM.inputs, 1)
+	assert.Equal(t, "arn:aws:iam::123456789012:user/agent", *stubIAM.inputs[0].PolicySourceArn)
+	assert.Empty(t, stubIAM.inputs[0].ResourceArns)
+}
+
+func TestSimulatorCheckNotAllowed(t *testing.T) {
+	s := &Simulator{
+		sts: &stubSTS{arn: "arn:aws:sts::123456789012:assumed-role/CloudWatchAgent/session"},
+		iam: &stubIAM{decisions: map[string]string{"cloudwatch:PutMetricData": iam.PolicyEvaluationDecisionTypeImplicitDeny}},
+	}
+	err := s.Check("us-east-1", "namespace CWAgent", "", []string{"cloudwatch:PutMetricData"}, nil)
+	var permissionErr *Error
+	require.ErrorAs(t, err, &permissionErr)
+	assert.Equal(t, "cloudwatch:PutMetricData", permissionErr.Action)
+	assert.ErrorIs(t, err, errNotAllowed)
+	assert.Contains(t, err.Error(), "missing permission cloudwatch:PutMetricData for namespace CWAgent in us-east-1")
+	assert.Contains(t, err.Error(), "grant cloudwatch:PutMetricData to the IAM role or user of the agent")
+	assert.Contains(t, err.Error(), "arn:aws:iam::123456789012:role/CloudWatchAgent: implicitDeny")
+}
+
+func TestSimulatorCheckErrors(t *testing.T) {
+	accessDenied := awserr.New("AccessDenied", "User is not authorized to perform: iam:SimulatePrincipalPolicy", nil)
+	s := &Simulator{
+		sts: &stubSTS{arn: "arn:aws:iam::123456789012:user/agent"},
+		iam: &stubIAM{err: accessDenied},
+	}
+	err := s.Check("us-east-1", "namespace CWAgent", "", []string{"cloudwatch:PutMetricData"}, nil)
+	assert.ErrorIs(t, err, accessDenied)
+	assert.Contains(t, err.Error(), "missing permission iam:SimulatePrincipalPolicy for arn:aws:iam::123456789012:user/agent in us-east-1")
+
+	s.sts = &stubSTS{err: errors.New("connection refused")}
+	err = s.Check("us-east-1", "namespace CWAgent", "", []string{"cloudwatch:PutMetricData"}, nil)
+	assert.EqualError(t, err, "unable to check permission sts:GetCallerIdentity for namespace CWAgent in us-east-1: connection refused")
+}
@@ -0,0 +1,81 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logger
+
+import (
+	"sync"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// EntryHook receives the warning and error entries of the zap logger with the fields of the logger that wrote them,
+// e.g. the kind and name of the OTel component. A hook is called on the goroutine that logs the entry, so it must not
+// block.
+type EntryHook func(entry zapcore.Entry, fields []zapcore.Field)
+
+var (
+	hooksMu    sync.RWMutex
+	hooks      = map[int]EntryHook{}
+	nextHookID int
+)
+
+// AddEntryHook adds a hook for the entries of the loggers created by NewLogger. Returns the function that removes the
+// hook.
+func AddEntryHook(hook EntryHook) func() {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	id := nextHookID
+	nextHookID++
+	hooks[id] = hook
+	return func() {
+		hooksMu.Lock()
+		defer hooksMu.Unlock()
+		delete(hooks, id)
+	}
+}
+
+// hookCore passes the warning and error entries to the hooks, whatever the level of the agent log.
+type hookCore struct {
+	fields []zapcore.Field
+}
+
+var _ zapcore.Core = (*hookCore)(nil)
+
+func (c *hookCore) Enabled(level zapcore.Level) bool {
+	return level >= zapcore.WarnLevel
+}
+
+func (c *hookCore) With(fields []zapcore.Field) zapcore.Core {
+	withFields := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	withFields = append(withFields, c.fields...)
+	return &hookCore{fields: append(withFields, fields...)}
+}
+
+func (c *hookCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !c.Enabled(entry.Level) {
+		return checked
+	}
+	hooksMu.RLock()
+	defer hooksMu.RUnlock()
+	if len(hooks) == 0 {
+		return checked
+	}
+	return checked.AddCore(entry, c)
+}
+
+func (c *hookCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	allFields := make([]zapcore.Field, 0, len(c.fields)+len(fields))
+	allFields = append(allFields, c.fields...)
+	allFields = append(allFields, fields...)
+	hooksMu.RLock()
+	defer hooksMu.RUnlock()
+	for _, hook := range hooks {
+		hook(entry, allFields)
+	}
+	return nil
+}
+
+func (c *hookCore) Sync() error {
+	return nil
+}
@@ -21,10 +21,13 @@ type TelegrafWrapperEncoder struct {
 }
 
 func NewLogger(writer io.Writer, level zap.AtomicLevel) (*zap.Logger, []zap.Option) {
-	core := zapcore.NewCore(
-		createTelegrafWrapperEncoder(),
-		zapcore.AddSync(writer),
-		loggerLevel,
+	core := zapcore.NewTee(
+		zapcore.NewCore(
+			createTelegrafWrapperEncoder(),
+			zapcore.AddSync(writer),
+			loggerLevel,
+		),
+		&hookCore{},
 	)
 	option := zap.WrapCore(func(zapcore.Core) zapcore.Core {
 		return core
@@ -35,10 +38,13 @@ func NewLogger(writer io.Writer, level zap.AtomicLevel) (*zap.Logger, []zap.Opti
 	return logger, []zap.Option{option}
 }
 func getLoggingOptions(writer io.Writer) []zap.Option {
-	core := zapcore.NewCore(
-		createTelegrafWrapperEncoder(),
-		zapcore.AddSync(writer),
-		loggerLevel,
+	core := zapcore.NewTee(
+		zapcore.NewCore(
+			createTelegrafWrapperEncoder(),
+			zapcore.AddSync(writer),
+			loggerLevel,
+		),
+		&hookCore{},
 	)
 	option := zap.WrapCore(func(zapcore.Core) zapcore.Core {
 		return core
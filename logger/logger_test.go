@@ -247,3 +247,34 @@ func TestTelegrafWrapperEncoder_EncodeEntry(t1 *testing.T) {
 		})
 	}
 }
+
+func TestEntryHook(t *testing.T) {
+	var entries []zapcore.Entry
+	var fields [][]zapcore.Field
+	remove := AddEntryHook(func(entry zapcore.Entry, f []zapcore.Field) {
+		entries = append(entries, entry)
+		fields = append(fields, f)
+	})
+	defer SetLevel(loggerLevel)
+	SetLevel(zap.NewAtomicLevelAt(zapcore.ErrorLevel))
+	var sb strings.Builder
+	logger, _ := NewLogger(&sb, loggerLevel)
+	logger = logger.With(zap.String("kind", "processor"))
+
+	logger.Info("info message")
+	logger.Warn("warn message", zap.String("key", "value"))
+	logger.Error("error message")
+	// the hooks get the warnings whatever the level of the agent log
+	assert.NotContains(t, sb.String(), "warn message")
+	assert.Contains(t, sb.String(), "error message")
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, "warn message", entries[0].Message)
+		assert.Equal(t, []zapcore.Field{zap.String("kind", "processor"), zap.String("key", "value")}, fields[0])
+		assert.Equal(t, "error message", entries[1].Message)
+		assert.Equal(t, []zapcore.Field{zap.String("kind", "processor")}, fields[1])
+	}
+
+	remove()
+	logger.Error("removed")
+	assert.Len(t, entries, 2)
+}
@@ -27,7 +27,7 @@ $UsageString = @"
         usage:  amazon-cloudwatch-agent-ctl.ps1 -a
                 stop|start|status|fetch-config|append-config|remove-config|set-log-level
                 [-m ec2|onPremise|onPrem|auto]
-                [-c default|all|ssm:<parameter-store-name>|file:<file-path>]
+                [-c default|all|ssm:<parameter-store-name>|appconfig:<application>/<environment>/<configuration-profile>|file:<file-path>]
                 [-s]
                 [-l INFO|DEBUG|WARN|ERROR|OFF]
 
@@ -56,6 +56,7 @@ $UsageString = @"
         -c: amazon-cloudwatch-agent configuration
             default:                                default configuration for quick trial.
             ssm:<parameter-store-name>:             ssm parameter store name.
+            appconfig:<application>/<environment>/<configuration-profile>: appconfig configuration profile.
             file:<file-path>:                       file path on the host.
             all:                                    all existing configs. Only apply to remove-config action.
 
@@ -91,6 +92,7 @@ $TOML="${CWAProgramData}\amazon-cloudwatch-agent.toml"
 $OTEL_YAML="${CWAProgramData}\amazon-cloudwatch-agent.yaml"
 $JSON="${CWAProgramData}\amazon-cloudwatch-agent.json"
 $JSON_DIR = "${CWAProgramData}\Configs"
+$FRAGMENT_DIR = "${CWAProgramData}\conf.d"
 $COMMON_CONIG="${CWAProgramData}\common-config.toml"
 $ENV_CONFIG="${CWAProgramData}\env-config.json"
 
@@ -306,14 +308,15 @@ Function CWAConfig() {
     }
 
     $jsonDirContent = Get-ChildItem "${JSON_DIR}" | Measure-Object
+    $fragmentDirContent = Get-ChildItem "${FRAGMENT_DIR}" -Filter *.json -ErrorAction SilentlyContinue | Measure-Object
 
-    if ($jsonDirContent.count -eq 0) {
+    if ($jsonDirContent.count -eq 0 -And $fragmentDirContent.count -eq 0) {
         Write-Output "All amazon-cloudwatch-agent configurations have been removed"
         Remove-Item "${TOML}" -Force -ErrorAction SilentlyContinue
         Remove-Item "${OTEL_YAML}" -Force -ErrorAction SilentlyContinue
     } else {
         Write-Output "Start configuration validation..."
-        & cmd /c "`"$CWAProgramFiles\config-translator.exe`" --input ${JSON} --input-dir ${JSON_DIR} --output ${TOML} --mode ${param_mode} --config ${COMMON_CONIG} --multi-config ${multi_config} 2>&1"
+        & cmd /c "`"$CWAProgramFiles\config-translator.exe`" --input ${JSON} --input-dir ${JSON_DIR} --input-fragment-dir ${FRAGMENT_DIR} --output ${TOML} --mode ${param_mode} --config ${COMMON_CONIG} --multi-config ${multi_config} 2>&1"
         CheckCMDResult
         # Let command pass so we can check return code and give user-friendly error-message
         $ErrorActionPreference = "Continue"
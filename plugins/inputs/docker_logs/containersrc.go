@@ -0,0 +1,270 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package docker_logs
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+)
+
+const (
+	streamStdout = "stdout"
+	streamStderr = "stderr"
+
+	// frameHeaderSize is the size of the header of each frame of a multiplexed log stream. The first
+	// byte is the stream and the last 4 bytes are the big endian size of the frame.
+	frameHeaderSize = 8
+)
+
+type LogEvent struct {
+	msg string
+	t   time.Time
+}
+
+func (le LogEvent) Message() string {
+	return le.msg
+}
+
+func (le LogEvent) Time() time.Time {
+	return le.t
+}
+
+func (le LogEvent) Done() {}
+
+// containerLogMessage is the message of the events of a container, tagged with the container the logs
+// are from.
+type containerLogMessage struct {
+	Log            string `json:"log"`
+	Stream         string `json:"stream"`
+	ContainerID    string `json:"container_id"`
+	ContainerName  string `json:"container_name"`
+	ContainerImage string `json:"container_image"`
+}
+
+// containerSrc is the log source of a single container. It streams the logs of the container until the
+// container stops.
+type containerSrc struct {
+	plugin *DockerLogs
+	id     string
+	name   string
+	image  string
+	tty    bool
+	since  time.Time
+
+	group       string
+	stream      string
+	class       string
+	retention   int
+	destination string
+
+	outputFn func(logs.LogEvent)
+	ctx      context.Context
+	cancel   context.CancelFunc
+	stopOnce sync.Once
+}
+
+var _ logs.LogSrc = (*containerSrc)(nil)
+
+func newContainerSrc(plugin *DockerLogs, config *ContainerConfig, id, name, image string, tty bool) *containerSrc {
+	ctx, cancel := context.WithCancel(context.Background())
+	replacer := strings.NewReplacer(containerNamePlaceholder, name, containerIDPlaceholder, shortID(id))
+	return &containerSrc{
+		plugin:      plugin,
+		id:          id,
+		name:        name,
+		image:       image,
+		tty:         tty,
+		group:       replacer.Replace(config.LogGroupName),
+		stream:      replacer.Replace(config.LogStreamName),
+		class:       config.LogGroupClass,
+		retention:   config.RetentionInDays,
+		destination: config.Destination,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+func (s *containerSrc) SetOutput(fn func(logs.LogEvent)) {
+	if fn == nil {
+		return
+	}
+	s.outputFn = fn
+	go s.runLogs()
+}
+
+func (s *containerSrc) Group() string {
+	return s.group
+}
+
+func (s *containerSrc) Stream() string {
+	return s.stream
+}
+
+func (s *containerSrc) Description() string {
+	return "docker container " + s.name
+}
+
+func (s *containerSrc) Destination() string {
+	return s.destination
+}
+
+func (s *containerSrc) Retention() int {
+	return s.retention
+}
+
+func (s *containerSrc) Class() string {
+	return s.class
+}
+
+func (s *containerSrc) Entity() *cloudwatchlogs.Entity {
+	return nil
+}
+
+func (s *containerSrc) Stop() {
+	s.stopOnce.Do(s.cancel)
+}
+
+// runLogs streams the logs of the container and publishes an event per line. The output is closed when
+// the container stops or the source is stopped.
+func (s *containerSrc) runLogs() {
+	var lastEvent time.Time
+	defer func() {
+		s.plugin.removeSrc(s, lastEvent)
+		s.outputFn(nil)
+	}()
+	reader, err := s.plugin.client.ContainerLogs(s.ctx, s.id, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     true,
+		Timestamps: true,
+		Since:      formatSince(s.since),
+	})
+	if err != nil {
+		if s.ctx.Err() == nil {
+			s.plugin.Log.Errorf("Unable to stream logs of docker container %v: %v", s.name, err)
+		}
+		return
+	}
+	defer reader.Close()
+
+	publish := func(stream, line string) {
+		t, msg := parseLine(line)
+		// the since parameter only has a precision of seconds
+		if !t.After(s.since) {
+			return
+		}
+		lastEvent = t
+		s.publish(stream, t, msg)
+	}
+	if s.tty {
+		err = readLines(reader, func(line string) { publish(streamStdout, line) })
+	} else {
+		err = readFrames(reader, publish)
+	}
+	if err != nil && s.ctx.Err() == nil {
+		s.plugin.Log.Errorf("Stopped reading logs of docker container %v: %v", s.name, err)
+	}
+}
+
+func (s *containerSrc) publish(stream string, t time.Time, msg string) {
+	message, err := json.Marshal(containerLogMessage{
+		Log:            msg,
+		Stream:         stream,
+		ContainerID:    s.id,
+		ContainerName:  s.name,
+		ContainerImage: s.image,
+	})
+	if err != nil {
+		s.plugin.Log.Errorf("Unable to encode log of docker container %v: %v", s.name, err)
+		return
+	}
+	s.outputFn(LogEvent{msg: string(message), t: t})
+}
+
+// readLines calls fn for each line of the raw log stream of a container with a TTY.
+func readLines(reader io.Reader, fn func(line string)) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fn(strings.TrimSuffix(scanner.Text(), "\r"))
+	}
+	return scanner.Err()
+}
+
+// readFrames calls fn for each line of the multiplexed log stream of a container without a TTY. Lines
+// that are split across frames of the same stream are joined.
+func readFrames(reader io.Reader, fn func(stream, line string)) error {
+	header := make([]byte, frameHeaderSize)
+	partial := map[string]*bytes.Buffer{}
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		var stream string
+		switch header[0] {
+		case 1:
+			stream = streamStdout
+		case 2:
+			stream = streamStderr
+		default:
+			return fmt.Errorf("unexpected stream %d in log frame", header[0])
+		}
+		frame := make([]byte, binary.BigEndian.Uint32(header[4:]))
+		if _, err := io.ReadFull(reader, frame); err != nil {
+			return err
+		}
+		buf, ok := partial[stream]
+		if !ok {
+			buf = &bytes.Buffer{}
+			partial[stream] = buf
+		}
+		buf.Write(frame)
+		for {
+			line, err := buf.ReadString('\n')
+			if err != nil {
+				// keep the incomplete line for the next frame
+				buf.Reset()
+				buf.WriteString(line)
+				break
+			}
+			fn(stream, strings.TrimSuffix(line, "\n"))
+		}
+	}
+}
+
+// parseLine splits the timestamp added by the Docker API from the log line. Falls back to the current
+// time if the line has no valid timestamp.
+func parseLine(line string) (time.Time, string) {
+	timestamp, msg, ok := strings.Cut(line, " ")
+	if ok {
+		if t, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+			return t, msg
+		}
+	}
+	return time.Now(), line
+}
+
+func formatSince(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return fmt.Sprintf("%d.%09d", t.Unix(), t.Nanosecond())
+}
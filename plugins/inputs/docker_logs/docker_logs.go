@@ -0,0 +1,295 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package docker_logs
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal"
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+const (
+	defaultEndpoint        = "unix:///var/run/docker.sock"
+	defaultRefreshInterval = 10 * time.Second
+	defaultLogStreamName   = "{container_name}"
+	requestTimeout         = 10 * time.Second
+
+	containerNamePlaceholder = "{container_name}"
+	containerIDPlaceholder   = "{container_id}"
+	shortContainerIDLength   = 12
+)
+
+// dockerClient is the subset of the Docker API used to discover containers and stream their logs.
+type dockerClient interface {
+	ContainerList(ctx context.Context, options container.ListOptions) ([]types.Container, error)
+	ContainerInspect(ctx context.Context, containerID string) (types.ContainerJSON, error)
+	ContainerLogs(ctx context.Context, containerID string, options container.LogsOptions) (io.ReadCloser, error)
+	Close() error
+}
+
+// ContainerConfig selects the containers to collect the logs of and where to send them. A container is
+// collected by the first config it matches.
+type ContainerConfig struct {
+	// ContainerNamePattern is a regular expression matched against the container name.
+	ContainerNamePattern string `toml:"container_name_pattern"`
+	// ContainerLabels are "key" or "key=value" labels that the container must have.
+	ContainerLabels []string `toml:"container_labels"`
+	LogGroupName    string   `toml:"log_group_name"`
+	LogStreamName   string   `toml:"log_stream_name"`
+	LogGroupClass   string   `toml:"log_group_class"`
+	RetentionInDays int      `toml:"retention_in_days"`
+	Destination     string   `toml:"destination"`
+
+	nameRegex *regexp.Regexp
+}
+
+type DockerLogs struct {
+	Endpoint         string            `toml:"endpoint"`
+	RefreshInterval  internal.Duration `toml:"refresh_interval"`
+	ContainerConfigs []ContainerConfig `toml:"container_config"`
+	Destination      string            `toml:"destination"`
+	Log              telegraf.Logger   `toml:"-"`
+
+	client    dockerClient
+	newClient func(endpoint string) (dockerClient, error)
+	startTime time.Time
+	done      chan struct{}
+	stopOnce  sync.Once
+
+	mu sync.Mutex
+	// active holds the sources of the containers whose logs are being streamed.
+	active map[string]*containerSrc
+	// lastEvent is the time of the last event of each container, so that the logs of a restarted
+	// container continue where they left off.
+	lastEvent map[string]time.Time
+	newSrcs   []logs.LogSrc
+}
+
+var _ logs.LogCollection = (*DockerLogs)(nil)
+
+func NewDockerLogs() *DockerLogs {
+	return &DockerLogs{
+		RefreshInterval: internal.Duration{Duration: defaultRefreshInterval},
+		newClient: func(endpoint string) (dockerClient, error) {
+			return client.NewClientWithOpts(client.WithHost(endpoint), client.WithAPIVersionNegotiation())
+		},
+		done:      make(chan struct{}),
+		active:    make(map[string]*containerSrc),
+		lastEvent: make(map[string]time.Time),
+	}
+}
+
+func (d *DockerLogs) Description() string {
+	return "A plugin to collect the logs of Docker containers through the Docker API"
+}
+
+func (d *DockerLogs) SampleConfig() string {
+	return `
+  ## The Docker daemon endpoint.
+  endpoint = "unix:///var/run/docker.sock"
+  ## How often to look for started containers.
+  refresh_interval = "10s"
+
+  [[inputs.docker_logs.container_config]]
+    container_name_pattern = "^web"
+    container_labels = ["app=shop"]
+    log_group_name = "docker/web"
+    ## {container_name} and {container_id} are replaced with the container name and short id.
+    log_stream_name = "{container_name}"
+    destination = "cloudwatchlogs"
+`
+}
+
+func (d *DockerLogs) Gather(telegraf.Accumulator) error {
+	return nil
+}
+
+// Init validates the container configs.
+func (d *DockerLogs) Init() error {
+	if d.Endpoint == "" {
+		d.Endpoint = defaultEndpoint
+	}
+	if d.RefreshInterval.Duration <= 0 {
+		d.RefreshInterval.Duration = defaultRefreshInterval
+	}
+	for i := range d.ContainerConfigs {
+		config := &d.ContainerConfigs[i]
+		if config.LogGroupName == "" {
+			return fmt.Errorf("log_group_name is required in container_config %d", i)
+		}
+		nameRegex, err := regexp.Compile(config.ContainerNamePattern)
+		if err != nil {
+			return fmt.Errorf("invalid container_name_pattern in container_config %d: %w", i, err)
+		}
+		config.nameRegex = nameRegex
+		if config.LogStreamName == "" {
+			config.LogStreamName = defaultLogStreamName
+		}
+		if config.Destination == "" {
+			config.Destination = d.Destination
+		}
+	}
+	return nil
+}
+
+// Start connects to the Docker daemon and looks for containers to collect the logs of. The logs of the
+// containers that are already running are collected from when the agent started.
+func (d *DockerLogs) Start(telegraf.Accumulator) error {
+	if err := d.Init(); err != nil {
+		return err
+	}
+	var err error
+	if d.client, err = d.newClient(d.Endpoint); err != nil {
+		return fmt.Errorf("unable to create docker client for %v: %w", d.Endpoint, err)
+	}
+	d.startTime = time.Now()
+	d.refresh()
+	go d.run()
+	return nil
+}
+
+func (d *DockerLogs) Stop() {
+	d.stopOnce.Do(func() {
+		close(d.done)
+		d.mu.Lock()
+		for _, src := range d.active {
+			src.Stop()
+		}
+		d.mu.Unlock()
+		if d.client != nil {
+			_ = d.client.Close()
+		}
+	})
+}
+
+func (d *DockerLogs) FindLogSrc() []logs.LogSrc {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	srcs := d.newSrcs
+	d.newSrcs = nil
+	return srcs
+}
+
+func (d *DockerLogs) run() {
+	t := time.NewTicker(d.RefreshInterval.Duration)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			d.refresh()
+		case <-d.done:
+			return
+		}
+	}
+}
+
+// refresh creates a source for every running container that matches a config and is not collected yet.
+func (d *DockerLogs) refresh() {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	containers, err := d.client.ContainerList(ctx, container.ListOptions{})
+	if err != nil {
+		d.Log.Errorf("Unable to list docker containers: %v", err)
+		return
+	}
+	for _, c := range containers {
+		d.mu.Lock()
+		_, ok := d.active[c.ID]
+		d.mu.Unlock()
+		if ok {
+			continue
+		}
+		name := containerName(c)
+		config := d.findConfig(name, c.Labels)
+		if config == nil {
+			continue
+		}
+		inspect, err := d.client.ContainerInspect(ctx, c.ID)
+		if err != nil {
+			d.Log.Errorf("Unable to inspect docker container %v: %v", name, err)
+			continue
+		}
+		tty := inspect.Config != nil && inspect.Config.Tty
+		d.addSrc(newContainerSrc(d, config, c.ID, name, c.Image, tty))
+	}
+}
+
+func (d *DockerLogs) addSrc(src *containerSrc) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	since, ok := d.lastEvent[src.id]
+	if !ok {
+		since = d.startTime
+	}
+	src.since = since
+	d.active[src.id] = src
+	d.newSrcs = append(d.newSrcs, src)
+}
+
+// removeSrc is called when the log stream of a container ends, e.g. because the container stopped.
+func (d *DockerLogs) removeSrc(src *containerSrc, lastEvent time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.active[src.id] == src {
+		delete(d.active, src.id)
+	}
+	if !lastEvent.IsZero() {
+		d.lastEvent[src.id] = lastEvent
+	}
+}
+
+func (d *DockerLogs) findConfig(name string, labels map[string]string) *ContainerConfig {
+	for i := range d.ContainerConfigs {
+		config := &d.ContainerConfigs[i]
+		if config.nameRegex != nil && !config.nameRegex.MatchString(name) {
+			continue
+		}
+		if hasLabels(labels, config.ContainerLabels) {
+			return config
+		}
+	}
+	return nil
+}
+
+func hasLabels(labels map[string]string, selectors []string) bool {
+	for _, selector := range selectors {
+		key, value, hasValue := strings.Cut(selector, "=")
+		actual, ok := labels[key]
+		if !ok || (hasValue && actual != value) {
+			return false
+		}
+	}
+	return true
+}
+
+func containerName(c types.Container) string {
+	if len(c.Names) == 0 {
+		return shortID(c.ID)
+	}
+	return strings.TrimPrefix(c.Names[0], "/")
+}
+
+func shortID(id string) string {
+	if len(id) > shortContainerIDLength {
+		return id[:shortContainerIDLength]
+	}
+	return id
+}
+
+func init() {
+	inputs.Add("docker_logs", func() telegraf.Input { return NewDockerLogs() })
+}
@@ -0,0 +1,312 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package docker_logs
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+const testAPIVersion = "1.43"
+
+type mockLine struct {
+	stream byte
+	t      time.Time
+	msg    string
+}
+
+type mockContainer struct {
+	ID     string            `json:"Id"`
+	Names  []string          `json:"Names"`
+	Image  string            `json:"Image"`
+	Labels map[string]string `json:"Labels"`
+
+	lines []mockLine
+}
+
+// mockDockerAPI serves the container list, inspect and multiplexed logs endpoints of the Docker API.
+// The log streams end after the last line, as if the containers stopped.
+type mockDockerAPI struct {
+	mu         sync.Mutex
+	containers []*mockContainer
+}
+
+var containerPathPattern = regexp.MustCompile(`^/v[\d.]+/containers/([^/]+)/(json|logs)$`)
+
+func (m *mockDockerAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if r.URL.Path == "/v"+testAPIVersion+"/containers/json" {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(m.containers)
+		return
+	}
+	matches := containerPathPattern.FindStringSubmatch(r.URL.Path)
+	if matches == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var c *mockContainer
+	for _, candidate := range m.containers {
+		if candidate.ID == matches[1] {
+			c = candidate
+		}
+	}
+	if c == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if matches[2] == "json" {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = fmt.Fprintf(w, `{"Id":%q,"Name":%q,"Config":{"Tty":false}}`, c.ID, c.Names[0])
+		return
+	}
+	since, err := parseSince(r.URL.Query().Get("since"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "application/vnd.docker.multiplexed-stream")
+	for _, line := range c.lines {
+		if line.t.After(since) {
+			_, _ = w.Write(frame(line.stream, line.t.Format(time.RFC3339Nano)+" "+line.msg+"\n"))
+		}
+	}
+}
+
+func parseSince(since string) (time.Time, error) {
+	if since == "" {
+		return time.Time{}, nil
+	}
+	sec, nsec, _ := strings.Cut(since, ".")
+	s, err := strconv.ParseInt(sec, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+	var ns int64
+	if nsec != "" {
+		if ns, err = strconv.ParseInt(nsec, 10, 64); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return time.Unix(s, ns), nil
+}
+
+func frame(stream byte, payload string) []byte {
+	header := make([]byte, frameHeaderSize)
+	header[0] = stream
+	binary.BigEndian.PutUint32(header[4:], uint32(len(payload)))
+	return append(header, payload...)
+}
+
+func (m *mockDockerAPI) addLine(id string, line mockLine) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, c := range m.containers {
+		if c.ID == id {
+			c.lines = append(c.lines, line)
+		}
+	}
+}
+
+func newTestDockerLogs(t *testing.T, api *mockDockerAPI) *DockerLogs {
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	d := NewDockerLogs()
+	d.Log = testutil.Logger{Name: "docker_logs"}
+	d.Endpoint = "tcp://" + server.Listener.Addr().String()
+	d.RefreshInterval.Duration = time.Hour
+	d.Destination = "cloudwatchlogs"
+	d.newClient = func(endpoint string) (dockerClient, error) {
+		return client.NewClientWithOpts(client.WithHost(endpoint), client.WithVersion(testAPIVersion))
+	}
+	return d
+}
+
+// collect streams the events of the source until its log stream ends.
+func collect(t *testing.T, src logs.LogSrc) []logs.LogEvent {
+	var events []logs.LogEvent
+	done := make(chan struct{})
+	src.SetOutput(func(e logs.LogEvent) {
+		if e == nil {
+			close(done)
+			return
+		}
+		events = append(events, e)
+	})
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the log stream to end")
+	}
+	return events
+}
+
+func decode(t *testing.T, e logs.LogEvent) containerLogMessage {
+	var msg containerLogMessage
+	require.NoError(t, json.Unmarshal([]byte(e.Message()), &msg))
+	return msg
+}
+
+func TestDockerLogs(t *testing.T) {
+	now := time.Now()
+	api := &mockDockerAPI{
+		containers: []*mockContainer{
+			{
+				ID:     "0123456789abcdef0123",
+				Names:  []string{"/web-1"},
+				Image:  "nginx:1.25",
+				Labels: map[string]string{"app": "shop"},
+				lines: []mockLine{
+					{stream: 1, t: now.Add(-time.Hour), msg: "GET /old"},
+					{stream: 1, t: now.Add(time.Second), msg: "GET /index.html"},
+					{stream: 2, t: now.Add(2 * time.Second), msg: "upstream timed out"},
+				},
+			},
+			{
+				ID:     "fedcba9876543210fedc",
+				Names:  []string{"/db-1"},
+				Image:  "postgres:16",
+				Labels: map[string]string{"app": "shop"},
+				lines:  []mockLine{{stream: 1, t: now.Add(time.Second), msg: "checkpoint complete"}},
+			},
+		},
+	}
+	d := newTestDockerLogs(t, api)
+	d.ContainerConfigs = []ContainerConfig{
+		{
+			ContainerNamePattern: "^web",
+			LogGroupName:         "docker/{container_name}",
+			LogStreamName:        "{container_id}",
+			RetentionInDays:      7,
+		},
+	}
+	require.NoError(t, d.Start(nil))
+	defer d.Stop()
+
+	srcs := d.FindLogSrc()
+	require.Len(t, srcs, 1)
+	src := srcs[0]
+	assert.Equal(t, "docker/web-1", src.Group())
+	assert.Equal(t, "0123456789ab", src.Stream())
+	assert.Equal(t, "cloudwatchlogs", src.Destination())
+	assert.Equal(t, 7, src.Retention())
+	assert.Equal(t, "docker container web-1", src.Description())
+
+	events := collect(t, src)
+	require.Len(t, events, 2)
+	assert.Equal(t, containerLogMessage{
+		Log:            "GET /index.html",
+		Stream:         streamStdout,
+		ContainerID:    "0123456789abcdef0123",
+		ContainerName:  "web-1",
+		ContainerImage: "nginx:1.25",
+	}, decode(t, events[0]))
+	assert.Equal(t, "upstream timed out", decode(t, events[1]).Log)
+	assert.Equal(t, streamStderr, decode(t, events[1]).Stream)
+	assert.True(t, events[1].Time().Equal(now.Add(2*time.Second)))
+	assert.Empty(t, d.FindLogSrc())
+
+	// the container is started again with a new log line after the log stream ended
+	api.addLine("0123456789abcdef0123", mockLine{stream: 1, t: now.Add(3 * time.Second), msg: "GET /restarted"})
+	d.refresh()
+	srcs = d.FindLogSrc()
+	require.Len(t, srcs, 1)
+	events = collect(t, srcs[0])
+	require.Len(t, events, 1)
+	assert.Equal(t, "GET /restarted", decode(t, events[0]).Log)
+}
+
+func TestDockerLogsStop(t *testing.T) {
+	d := newTestDockerLogs(t, &mockDockerAPI{})
+	require.NoError(t, d.Start(nil))
+	src := newContainerSrc(d, &ContainerConfig{LogGroupName: "group"}, "id", "name", "image", false)
+	d.addSrc(src)
+	d.Stop()
+	assert.Error(t, src.ctx.Err())
+}
+
+func TestInit(t *testing.T) {
+	testCases := map[string]struct {
+		configs []ContainerConfig
+		wantErr string
+	}{
+		"WithValidConfig": {
+			configs: []ContainerConfig{{LogGroupName: "group"}},
+		},
+		"WithoutLogGroupName": {
+			configs: []ContainerConfig{{ContainerNamePattern: "^web"}},
+			wantErr: "log_group_name is required in container_config 0",
+		},
+		"WithInvalidPattern": {
+			configs: []ContainerConfig{{ContainerNamePattern: "(", LogGroupName: "group"}},
+			wantErr: "invalid container_name_pattern in container_config 0",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			d := NewDockerLogs()
+			d.Destination = "cloudwatchlogs"
+			d.ContainerConfigs = testCase.configs
+			err := d.Init()
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, err, testCase.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, defaultEndpoint, d.Endpoint)
+			assert.Equal(t, defaultLogStreamName, d.ContainerConfigs[0].LogStreamName)
+			assert.Equal(t, "cloudwatchlogs", d.ContainerConfigs[0].Destination)
+		})
+	}
+}
+
+func TestHasLabels(t *testing.T) {
+	labels := map[string]string{"app": "shop", "tier": "web"}
+	assert.True(t, hasLabels(labels, nil))
+	assert.True(t, hasLabels(labels, []string{"app"}))
+	assert.True(t, hasLabels(labels, []string{"app=shop", "tier=web"}))
+	assert.False(t, hasLabels(labels, []string{"app=other"}))
+	assert.False(t, hasLabels(labels, []string{"team"}))
+}
+
+func TestReadFrames(t *testing.T) {
+	var stream bytes.Buffer
+	stream.Write(frame(1, "first "))
+	stream.Write(frame(2, "error\n"))
+	stream.Write(frame(1, "line\nsecond line\n"))
+	var got []string
+	require.NoError(t, readFrames(&stream, func(stream, line string) {
+		got = append(got, stream+": "+line)
+	}))
+	assert.Equal(t, []string{"stderr: error", "stdout: first line", "stdout: second line"}, got)
+
+	assert.ErrorContains(t, readFrames(bytes.NewReader(frame(3, "line\n")), func(string, string) {}), "unexpected stream 3")
+}
+
+func TestParseLine(t *testing.T) {
+	got, msg := parseLine("2024-01-02T03:04:05.123456789Z hello world")
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.UTC), got)
+	assert.Equal(t, "hello world", msg)
+
+	_, msg = parseLine("hello world")
+	assert.Equal(t, "hello world", msg)
+}
@@ -0,0 +1,94 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kubelet_summary
+
+import (
+	_ "embed"
+	"errors"
+	"os"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+	stats "k8s.io/kubelet/pkg/apis/stats/v1alpha1"
+
+	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
+	"github.com/aws/amazon-cloudwatch-agent/internal/containerinsightscommon"
+	"github.com/aws/amazon-cloudwatch-agent/internal/k8sCommon/kubeletutil"
+	"github.com/aws/amazon-cloudwatch-agent/internal/tls"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+var errMissingHostIP = errors.New("host_ip is required when the HOST_IP environment variable is not set")
+
+type summaryProvider interface {
+	Summary() (*stats.Summary, error)
+}
+
+// KubeletSummary polls the kubelet summary API and emits the resource usage of
+// the node and its pods and containers with the Container Insights metric type
+// and dimension schema.
+type KubeletSummary struct {
+	HostIP      string          `toml:"host_ip"`
+	Port        string          `toml:"port"`
+	BearerToken string          `toml:"bearer_token"`
+	Log         telegraf.Logger `toml:"-"`
+	tls.ClientConfig
+
+	client    summaryProvider
+	converter *converter
+	now       func() time.Time
+}
+
+func (*KubeletSummary) SampleConfig() string {
+	return sampleConfig
+}
+
+func (k *KubeletSummary) Description() string {
+	return "Collects node, pod and container resource metrics from the kubelet summary API"
+}
+
+func (k *KubeletSummary) Init() error {
+	if k.HostIP == "" {
+		k.HostIP = os.Getenv(envconfig.HostIP)
+	}
+	if k.HostIP == "" {
+		return errMissingHostIP
+	}
+	if k.Port == "" {
+		k.Port = containerinsightscommon.KubeSecurePort
+	}
+	k.client = &kubeletutil.KubeClient{
+		KubeIP:       k.HostIP,
+		Port:         k.Port,
+		BearerToken:  k.BearerToken,
+		ClientConfig: k.ClientConfig,
+	}
+	k.converter = newConverter()
+	k.now = time.Now
+	return nil
+}
+
+func (k *KubeletSummary) Gather(acc telegraf.Accumulator) error {
+	summary, err := k.client.Summary()
+	if err != nil {
+		return err
+	}
+	for _, m := range k.converter.convert(summary, k.now()) {
+		acc.AddFields(measurement, m.fields, m.tags, m.time)
+	}
+	return nil
+}
+
+func init() {
+	inputs.Add("kubelet_summary", func() telegraf.Input {
+		return &KubeletSummary{
+			Port:         containerinsightscommon.KubeSecurePort,
+			BearerToken:  containerinsightscommon.BearerToken,
+			ClientConfig: tls.ClientConfig{TLSCA: containerinsightscommon.CAFile},
+		}
+	})
+}
@@ -0,0 +1,223 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kubelet_summary
+
+import (
+	"encoding/json"
+	"encoding/pem"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	stats "k8s.io/kubelet/pkg/apis/stats/v1alpha1"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/tls"
+)
+
+func TestInit(t *testing.T) {
+	t.Setenv("HOST_IP", "")
+	plugin := &KubeletSummary{Log: testutil.Logger{}}
+	assert.ErrorIs(t, plugin.Init(), errMissingHostIP)
+
+	t.Setenv("HOST_IP", "10.0.0.1")
+	require.NoError(t, plugin.Init())
+	assert.Equal(t, "10.0.0.1", plugin.HostIP)
+	assert.Equal(t, "10250", plugin.Port)
+}
+
+func TestGather(t *testing.T) {
+	content, err := os.ReadFile(filepath.Join("testdata", "summary.json"))
+	require.NoError(t, err)
+	var mu sync.Mutex
+	summary := content
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/stats/summary" || r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		_, _ = w.Write(summary)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	tokenFile := filepath.Join(dir, "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("test-token\n"), 0600))
+	caFile := filepath.Join(dir, "ca.crt")
+	require.NoError(t, os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0600))
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	host, port, err := net.SplitHostPort(serverURL.Host)
+	require.NoError(t, err)
+
+	plugin := &KubeletSummary{
+		HostIP:       host,
+		Port:         port,
+		BearerToken:  tokenFile,
+		ClientConfig: tls.ClientConfig{TLSCA: caFile},
+		Log:          testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	got := metricsByType(t, &acc)
+	// the network rates need a previous summary
+	assert.ElementsMatch(t, []string{"Node", "NodeFS", "Pod", "Container", "ContainerFS"}, keys(got))
+
+	node := got["Node"][0]
+	assert.Equal(t, map[string]string{"Type": "Node", "NodeName": "ip-192-168-67-127.us-west-2.compute.internal"}, node.Tags)
+	assert.Equal(t, map[string]interface{}{
+		"node_cpu_usage_total":    float64(250),
+		"node_memory_usage":       uint64(2500000000),
+		"node_memory_working_set": uint64(2000000000),
+		"node_memory_rss":         uint64(1500000000),
+		"node_memory_pgfault":     uint64(1000),
+		"node_memory_pgmajfault":  uint64(10),
+	}, node.Fields)
+	assert.Equal(t, map[string]interface{}{
+		"node_filesystem_usage":       uint64(25000000000),
+		"node_filesystem_capacity":    uint64(100000000000),
+		"node_filesystem_available":   uint64(75000000000),
+		"node_filesystem_inodes":      uint64(1000000),
+		"node_filesystem_inodes_free": uint64(900000),
+		"node_filesystem_utilization": float64(25),
+	}, got["NodeFS"][0].Fields)
+
+	podTags := map[string]string{
+		"Type":       "Pod",
+		"NodeName":   "ip-192-168-67-127.us-west-2.compute.internal",
+		"Namespace":  "default",
+		"K8sPodName": "cpu-limit",
+		"PodId":      "764d01e1-2a2f-11e9-95ea-0a695d7ce286",
+	}
+	assert.Equal(t, podTags, got["Pod"][0].Tags)
+	assert.Equal(t, float64(12), got["Pod"][0].Fields["pod_cpu_usage_total"])
+	assert.Equal(t, uint64(42991616), got["Pod"][0].Fields["pod_memory_working_set"])
+
+	container := got["Container"][0]
+	assert.Equal(t, "ubuntu", container.Tags["ContainerName"])
+	assert.Equal(t, "cpu-limit", container.Tags["K8sPodName"])
+	assert.Equal(t, float64(10), container.Fields["container_cpu_usage_total"])
+	assert.Equal(t, uint64(41943040), container.Fields["container_memory_working_set"])
+	assert.Equal(t, uint64(40000), got["ContainerFS"][0].Fields["container_filesystem_usage"])
+	assert.Equal(t, "ubuntu", got["ContainerFS"][0].Tags["ContainerName"])
+
+	// the counters increased over 60 seconds
+	var next stats.Summary
+	require.NoError(t, json.Unmarshal(content, &next))
+	advanceNetwork(next.Node.Network, 600000, 300000)
+	advanceNetwork(next.Pods[0].Network, 6000, 3000)
+	mu.Lock()
+	summary, err = json.Marshal(next)
+	mu.Unlock()
+	require.NoError(t, err)
+
+	acc.ClearMetrics()
+	require.NoError(t, plugin.Gather(&acc))
+	got = metricsByType(t, &acc)
+	assert.ElementsMatch(t, []string{"Node", "NodeNet", "NodeFS", "Pod", "PodNet", "Container", "ContainerFS"}, keys(got))
+	assert.Equal(t, float64(15000), got["Node"][0].Fields["node_network_total_bytes"])
+	assert.Equal(t, "eth0", got["NodeNet"][0].Tags["interface"])
+	assert.Equal(t, map[string]interface{}{
+		"node_interface_network_rx_bytes":  float64(10000),
+		"node_interface_network_tx_bytes":  float64(5000),
+		"node_interface_network_rx_errors": float64(0),
+		"node_interface_network_tx_errors": float64(0),
+	}, got["NodeNet"][0].Fields)
+	assert.Equal(t, float64(100), got["Pod"][0].Fields["pod_network_rx_bytes"])
+	assert.Equal(t, float64(50), got["Pod"][0].Fields["pod_network_tx_bytes"])
+	assert.Equal(t, "cpu-limit", got["PodNet"][0].Tags["K8sPodName"])
+	assert.Equal(t, float64(100), got["PodNet"][0].Fields["pod_interface_network_rx_bytes"])
+}
+
+func TestGatherUnauthorized(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+	serverURL, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	host, port, err := net.SplitHostPort(serverURL.Host)
+	require.NoError(t, err)
+
+	plugin := &KubeletSummary{
+		HostIP:       host,
+		Port:         port,
+		ClientConfig: tls.ClientConfig{InsecureSkipVerify: true},
+		Log:          testutil.Logger{},
+	}
+	require.NoError(t, plugin.Init())
+	var acc testutil.Accumulator
+	assert.Error(t, plugin.Gather(&acc))
+	assert.Empty(t, acc.Metrics)
+}
+
+func TestConvertCounterReset(t *testing.T) {
+	c := newConverter()
+	start := time.Now()
+	rate := func(rx uint64, offset time.Duration) (interface{}, bool) {
+		summary := &stats.Summary{Node: stats.NodeStats{
+			NodeName: "node",
+			Network: &stats.NetworkStats{
+				Time:       metav1.NewTime(start.Add(offset)),
+				Interfaces: []stats.InterfaceStats{{Name: "eth0", RxBytes: &rx}},
+			},
+		}}
+		for _, m := range c.convert(summary, start) {
+			if m.tags["Type"] == "NodeNet" {
+				value, ok := m.fields["node_interface_network_rx_bytes"]
+				return value, ok
+			}
+		}
+		return nil, false
+	}
+	_, ok := rate(100, 0)
+	assert.False(t, ok)
+	got, ok := rate(160, time.Minute)
+	assert.True(t, ok)
+	assert.Equal(t, float64(1), got)
+	// the counter was reset
+	_, ok = rate(10, 2*time.Minute)
+	assert.False(t, ok)
+	got, ok = rate(70, 3*time.Minute)
+	assert.True(t, ok)
+	assert.Equal(t, float64(1), got)
+}
+
+func advanceNetwork(network *stats.NetworkStats, rx, tx uint64) {
+	network.Time.Time = network.Time.Add(time.Minute)
+	for i := range network.Interfaces {
+		*network.Interfaces[i].RxBytes += rx
+		*network.Interfaces[i].TxBytes += tx
+	}
+}
+
+func metricsByType(t *testing.T, acc *testutil.Accumulator) map[string][]*testutil.Metric {
+	got := map[string][]*testutil.Metric{}
+	for _, m := range acc.Metrics {
+		assert.Equal(t, measurement, m.Measurement)
+		got[m.Tags["Type"]] = append(got[m.Tags["Type"]], m)
+	}
+	return got
+}
+
+func keys(m map[string][]*testutil.Metric) []string {
+	var result []string
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
@@ -0,0 +1,205 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kubelet_summary
+
+import (
+	"time"
+
+	stats "k8s.io/kubelet/pkg/apis/stats/v1alpha1"
+
+	. "github.com/aws/amazon-cloudwatch-agent/internal/containerinsightscommon"
+)
+
+const measurement = "kubelet_summary"
+
+type metric struct {
+	fields map[string]interface{}
+	tags   map[string]string
+	time   time.Time
+}
+
+type counterSample struct {
+	value uint64
+	time  time.Time
+}
+
+// converter converts the summary into metrics. The network counters of the
+// summary are cumulative, so they are reported as the per second rate since
+// the previous summary, which requires two summaries before the first rate.
+type converter struct {
+	previous map[string]counterSample
+	current  map[string]counterSample
+}
+
+func newConverter() *converter {
+	return &converter{previous: map[string]counterSample{}}
+}
+
+func (c *converter) convert(summary *stats.Summary, now time.Time) []metric {
+	// only the counters of the series in this summary are kept for the next one
+	c.current = map[string]counterSample{}
+	defer func() { c.previous = c.current }()
+
+	var metrics []metric
+	node := summary.Node
+	nodeTags := map[string]string{NodeNameKey: node.NodeName}
+	nodeMetric := metric{fields: map[string]interface{}{}, tags: withType(nodeTags, TypeNode), time: now}
+	addCPU(nodeMetric.fields, TypeNode, node.CPU)
+	addMemory(nodeMetric.fields, TypeNode, node.Memory)
+	metrics = append(metrics, c.network(&nodeMetric, TypeNodeNet, "node", node.Network, nodeTags, now)...)
+	if len(nodeMetric.fields) != 0 {
+		metrics = append(metrics, nodeMetric)
+	}
+	if m, ok := fsMetric(TypeNodeFS, node.Fs, nodeTags, now); ok {
+		metrics = append(metrics, m)
+	}
+
+	for _, pod := range summary.Pods {
+		podTags := map[string]string{
+			NodeNameKey:   node.NodeName,
+			K8sNamespace:  pod.PodRef.Namespace,
+			K8sPodNameKey: pod.PodRef.Name,
+			PodIdKey:      pod.PodRef.UID,
+		}
+		podMetric := metric{fields: map[string]interface{}{}, tags: withType(podTags, TypePod), time: now}
+		addCPU(podMetric.fields, TypePod, pod.CPU)
+		addMemory(podMetric.fields, TypePod, pod.Memory)
+		metrics = append(metrics, c.network(&podMetric, TypePodNet, pod.PodRef.UID, pod.Network, podTags, now)...)
+		if len(podMetric.fields) != 0 {
+			metrics = append(metrics, podMetric)
+		}
+
+		for _, container := range pod.Containers {
+			containerTags := withTag(podTags, ContainerNamekey, container.Name)
+			containerMetric := metric{fields: map[string]interface{}{}, tags: withType(containerTags, TypeContainer), time: now}
+			addCPU(containerMetric.fields, TypeContainer, container.CPU)
+			addMemory(containerMetric.fields, TypeContainer, container.Memory)
+			if len(containerMetric.fields) != 0 {
+				metrics = append(metrics, containerMetric)
+			}
+			if m, ok := fsMetric(TypeContainerFS, container.Rootfs, containerTags, now); ok {
+				metrics = append(metrics, m)
+			}
+		}
+	}
+	return metrics
+}
+
+// network adds a metric of the network type for each interface, and the sum of
+// the interface rates to the parent metric. The node reports the total of the
+// received and transmitted bytes, while the pod reports each direction.
+func (c *converter) network(parent *metric, netType string, key string, network *stats.NetworkStats, tags map[string]string, now time.Time) []metric {
+	if network == nil {
+		return nil
+	}
+	parentType := parent.tags[MetricType]
+	var metrics []metric
+	var rxTotal, txTotal float64
+	var hasRate bool
+	for _, iface := range network.Interfaces {
+		netTags := withTag(tags, NetIfce, iface.Name)
+		fields := map[string]interface{}{}
+		prefix := key + "/" + iface.Name + "/"
+		t := network.Time.Time
+		if rx, ok := c.rate(prefix+NetRxBytes, iface.RxBytes, t); ok {
+			fields[MetricName(netType, NetRxBytes)] = rx
+			rxTotal += rx
+			hasRate = true
+		}
+		if tx, ok := c.rate(prefix+NetTxBytes, iface.TxBytes, t); ok {
+			fields[MetricName(netType, NetTxBytes)] = tx
+			txTotal += tx
+			hasRate = true
+		}
+		if rxErrors, ok := c.rate(prefix+NetRxErrors, iface.RxErrors, t); ok {
+			fields[MetricName(netType, NetRxErrors)] = rxErrors
+		}
+		if txErrors, ok := c.rate(prefix+NetTxErrors, iface.TxErrors, t); ok {
+			fields[MetricName(netType, NetTxErrors)] = txErrors
+		}
+		if len(fields) != 0 {
+			metrics = append(metrics, metric{fields: fields, tags: withType(netTags, netType), time: now})
+		}
+	}
+	if hasRate {
+		if parentType == TypeNode {
+			parent.fields[MetricName(parentType, NetTotalBytes)] = rxTotal + txTotal
+		} else {
+			parent.fields[MetricName(parentType, NetRxBytes)] = rxTotal
+			parent.fields[MetricName(parentType, NetTxBytes)] = txTotal
+		}
+	}
+	return metrics
+}
+
+// rate returns the per second rate of the counter since the previous summary.
+// There is no rate for the first value of a counter or after it was reset.
+func (c *converter) rate(key string, value *uint64, t time.Time) (float64, bool) {
+	if value == nil {
+		return 0, false
+	}
+	c.current[key] = counterSample{value: *value, time: t}
+	previous, ok := c.previous[key]
+	if !ok || *value < previous.value || !t.After(previous.time) {
+		return 0, false
+	}
+	return float64(*value-previous.value) / t.Sub(previous.time).Seconds(), true
+}
+
+// addCPU adds the CPU usage in millicores.
+func addCPU(fields map[string]interface{}, mType string, cpu *stats.CPUStats) {
+	if cpu == nil || cpu.UsageNanoCores == nil {
+		return
+	}
+	fields[MetricName(mType, CpuTotal)] = float64(*cpu.UsageNanoCores) / float64(time.Millisecond)
+}
+
+func addMemory(fields map[string]interface{}, mType string, memory *stats.MemoryStats) {
+	if memory == nil {
+		return
+	}
+	addUint(fields, MetricName(mType, MemUsage), memory.UsageBytes)
+	addUint(fields, MetricName(mType, MemWorkingset), memory.WorkingSetBytes)
+	addUint(fields, MetricName(mType, MemRss), memory.RSSBytes)
+	addUint(fields, MetricName(mType, MemPgfault), memory.PageFaults)
+	addUint(fields, MetricName(mType, MemPgmajfault), memory.MajorPageFaults)
+}
+
+func fsMetric(mType string, fs *stats.FsStats, tags map[string]string, now time.Time) (metric, bool) {
+	if fs == nil {
+		return metric{}, false
+	}
+	fields := map[string]interface{}{}
+	addUint(fields, MetricName(mType, FSUsage), fs.UsedBytes)
+	addUint(fields, MetricName(mType, FSCapacity), fs.CapacityBytes)
+	addUint(fields, MetricName(mType, FSAvailable), fs.AvailableBytes)
+	addUint(fields, MetricName(mType, FSInodes), fs.Inodes)
+	addUint(fields, MetricName(mType, FSInodesfree), fs.InodesFree)
+	if fs.UsedBytes != nil && fs.CapacityBytes != nil && *fs.CapacityBytes != 0 {
+		fields[MetricName(mType, FSUtilization)] = float64(*fs.UsedBytes) / float64(*fs.CapacityBytes) * 100
+	}
+	if len(fields) == 0 {
+		return metric{}, false
+	}
+	return metric{fields: fields, tags: withType(tags, mType), time: now}, true
+}
+
+func addUint(fields map[string]interface{}, name string, value *uint64) {
+	if value != nil {
+		fields[name] = *value
+	}
+}
+
+func withType(tags map[string]string, mType string) map[string]string {
+	return withTag(tags, MetricType, mType)
+}
+
+func withTag(tags map[string]string, key, value string) map[string]string {
+	result := make(map[string]string, len(tags)+1)
+	for k, v := range tags {
+		result[k] = v
+	}
+	result[key] = value
+	return result
+}
@@ -0,0 +1,213 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kubernetes_logs
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+)
+
+type LogEvent struct {
+	msg string
+	t   time.Time
+}
+
+func (le LogEvent) Message() string {
+	return le.msg
+}
+
+func (le LogEvent) Time() time.Time {
+	return le.t
+}
+
+func (le LogEvent) Done() {}
+
+// containerLogMessage is the message of the events of a container, tagged with the container the logs are from.
+type containerLogMessage struct {
+	Log           string `json:"log"`
+	Namespace     string `json:"namespace"`
+	PodName       string `json:"pod_name"`
+	ContainerName string `json:"container_name"`
+}
+
+// containerSrc is the log source of a single container of a pod. It follows the logs of the container through
+// the log endpoint of the API server until the container stops.
+type containerSrc struct {
+	plugin    *KubernetesLogs
+	key       string
+	namespace string
+	pod       string
+	container string
+	since     time.Time
+	// previous is set to read the logs of the previous instance of the container first.
+	previous bool
+
+	group       string
+	stream      string
+	class       string
+	retention   int
+	destination string
+
+	outputFn func(logs.LogEvent)
+	ctx      context.Context
+	cancel   context.CancelFunc
+	stopOnce sync.Once
+}
+
+var _ logs.LogSrc = (*containerSrc)(nil)
+
+func newContainerSrc(plugin *KubernetesLogs, config *PodConfig, key, namespace, pod, container string) *containerSrc {
+	ctx, cancel := context.WithCancel(context.Background())
+	replacer := strings.NewReplacer(namespacePlaceholder, namespace, podNamePlaceholder, pod, containerNamePlaceholder, container)
+	return &containerSrc{
+		plugin:      plugin,
+		key:         key,
+		namespace:   namespace,
+		pod:         pod,
+		container:   container,
+		group:       replacer.Replace(config.LogGroupName),
+		stream:      replacer.Replace(config.LogStreamName),
+		class:       config.LogGroupClass,
+		retention:   config.RetentionInDays,
+		destination: config.Destination,
+		ctx:         ctx,
+		cancel:      cancel,
+	}
+}
+
+func (s *containerSrc) SetOutput(fn func(logs.LogEvent)) {
+	if fn == nil {
+		return
+	}
+	s.outputFn = fn
+	go s.runLogs()
+}
+
+func (s *containerSrc) Group() string {
+	return s.group
+}
+
+func (s *containerSrc) Stream() string {
+	return s.stream
+}
+
+func (s *containerSrc) Description() string {
+	return "kubernetes container " + s.namespace + "/" + s.pod + "/" + s.container
+}
+
+func (s *containerSrc) Destination() string {
+	return s.destination
+}
+
+func (s *containerSrc) Retention() int {
+	return s.retention
+}
+
+func (s *containerSrc) Class() string {
+	return s.class
+}
+
+func (s *containerSrc) Entity() *cloudwatchlogs.Entity {
+	return nil
+}
+
+func (s *containerSrc) Stop() {
+	s.stopOnce.Do(s.cancel)
+}
+
+// runLogs streams the logs of the container and publishes an event per line. The output is closed when the
+// container stops or the source is stopped.
+func (s *containerSrc) runLogs() {
+	var lastEvent time.Time
+	var err error
+	defer func() {
+		s.plugin.removeSrc(s, lastEvent, err)
+		s.outputFn(nil)
+	}()
+	publish := func(line string) {
+		t, msg := parseLine(line)
+		// the sinceTime parameter only has a precision of seconds
+		if !t.After(s.since) {
+			return
+		}
+		lastEvent = t
+		s.publish(t, msg)
+	}
+	if s.previous {
+		if err = s.readLogs(true, publish); err != nil && s.ctx.Err() == nil {
+			s.plugin.Log.Warnf("Unable to read logs of the previous instance of %v: %v", s.Description(), err)
+		}
+	}
+	err = s.readLogs(false, publish)
+	if err != nil && s.ctx.Err() == nil {
+		s.plugin.Log.Errorf("Stopped reading logs of %v: %v", s.Description(), err)
+	}
+}
+
+// readLogs reads the logs of the container since the time of the source. The logs of the running instance are
+// followed until it stops, while the logs of the previous instance are read until the end.
+func (s *containerSrc) readLogs(previous bool, fn func(line string)) error {
+	options := &corev1.PodLogOptions{
+		Container:  s.container,
+		Follow:     !previous,
+		Previous:   previous,
+		Timestamps: true,
+	}
+	if !s.since.IsZero() {
+		options.SinceTime = &metav1.Time{Time: s.since}
+	}
+	reader, err := s.plugin.client.CoreV1().Pods(s.namespace).GetLogs(s.pod, options).Stream(s.ctx)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return readLines(reader, fn)
+}
+
+func (s *containerSrc) publish(t time.Time, msg string) {
+	message, err := json.Marshal(containerLogMessage{
+		Log:           msg,
+		Namespace:     s.namespace,
+		PodName:       s.pod,
+		ContainerName: s.container,
+	})
+	if err != nil {
+		s.plugin.Log.Errorf("Unable to encode log of %v: %v", s.Description(), err)
+		return
+	}
+	s.outputFn(LogEvent{msg: string(message), t: t})
+}
+
+// readLines calls fn for each line of the log stream of a container.
+func readLines(reader io.Reader, fn func(line string)) error {
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		fn(strings.TrimSuffix(scanner.Text(), "\r"))
+	}
+	return scanner.Err()
+}
+
+// parseLine splits the timestamp added by the API server from the log line. Falls back to the current time if the
+// line has no valid timestamp.
+func parseLine(line string) (time.Time, string) {
+	timestamp, msg, ok := strings.Cut(line, " ")
+	if ok {
+		if t, err := time.Parse(time.RFC3339Nano, timestamp); err == nil {
+			return t, msg
+		}
+	}
+	return time.Now(), line
+}
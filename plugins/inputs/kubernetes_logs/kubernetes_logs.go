@@ -0,0 +1,364 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kubernetes_logs
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
+	"github.com/aws/amazon-cloudwatch-agent/internal"
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+const (
+	defaultRefreshInterval = 10 * time.Second
+	defaultLogStreamName   = "{namespace}_{pod_name}_{container_name}"
+	defaultThrottleDelay   = 30 * time.Second
+	requestTimeout         = 10 * time.Second
+
+	namespacePlaceholder     = "{namespace}"
+	podNamePlaceholder       = "{pod_name}"
+	containerNamePlaceholder = "{container_name}"
+)
+
+// PodConfig selects the containers of the pods to collect the logs of and where to send them. A container is
+// collected by the first config it matches.
+type PodConfig struct {
+	// Namespace is the namespace of the pods. The pods of all namespaces are selected if it is empty.
+	Namespace string `toml:"namespace"`
+	// LabelSelector is a Kubernetes label selector, such as "app=shop,tier!=db", that the pods must match.
+	LabelSelector string `toml:"label_selector"`
+	// PodNamePattern is a regular expression matched against the pod name.
+	PodNamePattern string `toml:"pod_name_pattern"`
+	// ContainerNamePattern is a regular expression matched against the container name.
+	ContainerNamePattern string `toml:"container_name_pattern"`
+	LogGroupName         string `toml:"log_group_name"`
+	LogStreamName        string `toml:"log_stream_name"`
+	LogGroupClass        string `toml:"log_group_class"`
+	RetentionInDays      int    `toml:"retention_in_days"`
+	Destination          string `toml:"destination"`
+
+	podNameRegex       *regexp.Regexp
+	containerNameRegex *regexp.Regexp
+	// resourceVersion is the resource version of the last pod list, so that the next list is never older than it.
+	resourceVersion string
+}
+
+// containerState is what is kept about a container between its log streams.
+type containerState struct {
+	// podUID is the UID of the pod of the container, which changes when the pod is recreated.
+	podUID types.UID
+	// lastEvent is the time of the last event, so that a new log stream continues where the last one left off.
+	lastEvent time.Time
+	// restartCount is the restart count of the container when its last log stream started.
+	restartCount int32
+	// complete is whether the last log stream was read until the container stopped.
+	complete bool
+	// notBefore is set when the API server throttled the requests for the logs of the container.
+	notBefore time.Time
+}
+
+type KubernetesLogs struct {
+	KubeConfigPath  string            `toml:"kubeconfig_path"`
+	NodeName        string            `toml:"node_name"`
+	RefreshInterval internal.Duration `toml:"refresh_interval"`
+	PodConfigs      []PodConfig       `toml:"pod_config"`
+	Destination     string            `toml:"destination"`
+	Log             telegraf.Logger   `toml:"-"`
+
+	client    kubernetes.Interface
+	newClient func(kubeConfigPath string) (kubernetes.Interface, error)
+	startTime time.Time
+	done      chan struct{}
+	stopOnce  sync.Once
+
+	mu sync.Mutex
+	// active holds the sources of the containers whose logs are being streamed.
+	active map[string]*containerSrc
+	// states are the states of the containers that have been collected, keyed like the active sources.
+	states  map[string]*containerState
+	newSrcs []logs.LogSrc
+}
+
+var _ logs.LogCollection = (*KubernetesLogs)(nil)
+
+func NewKubernetesLogs() *KubernetesLogs {
+	return &KubernetesLogs{
+		RefreshInterval: internal.Duration{Duration: defaultRefreshInterval},
+		newClient:       newKubernetesClient,
+		done:            make(chan struct{}),
+		active:          make(map[string]*containerSrc),
+		states:          make(map[string]*containerState),
+	}
+}
+
+// newKubernetesClient creates a client with the in-cluster config, or with the kubeconfig file if it is set.
+func newKubernetesClient(kubeConfigPath string) (kubernetes.Interface, error) {
+	var config *rest.Config
+	var err error
+	if kubeConfigPath != "" {
+		config, err = clientcmd.BuildConfigFromFlags("", kubeConfigPath)
+	} else {
+		config, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(config)
+}
+
+func (k *KubernetesLogs) Description() string {
+	return "A plugin to collect the logs of Kubernetes containers through the Kubernetes API"
+}
+
+func (k *KubernetesLogs) SampleConfig() string {
+	return `
+  ## The kubeconfig file to connect with. The in-cluster config is used if it is not set.
+  # kubeconfig_path = ""
+  ## Only the pods on the node are collected. Defaults to the HOST_NAME environment variable.
+  # node_name = ""
+  ## How often to look for started containers.
+  refresh_interval = "10s"
+
+  [[inputs.kubernetes_logs.pod_config]]
+    namespace = "default"
+    label_selector = "app=shop"
+    pod_name_pattern = "^web"
+    container_name_pattern = "^nginx$"
+    log_group_name = "kubernetes/web"
+    ## {namespace}, {pod_name} and {container_name} are replaced with the names of the container.
+    log_stream_name = "{namespace}_{pod_name}_{container_name}"
+    destination = "cloudwatchlogs"
+`
+}
+
+func (k *KubernetesLogs) Gather(telegraf.Accumulator) error {
+	return nil
+}
+
+// Init validates the pod configs.
+func (k *KubernetesLogs) Init() error {
+	if k.NodeName == "" {
+		k.NodeName = os.Getenv(envconfig.HostName)
+	}
+	if k.RefreshInterval.Duration <= 0 {
+		k.RefreshInterval.Duration = defaultRefreshInterval
+	}
+	for i := range k.PodConfigs {
+		config := &k.PodConfigs[i]
+		if config.LogGroupName == "" {
+			return fmt.Errorf("log_group_name is required in pod_config %d", i)
+		}
+		if _, err := labels.Parse(config.LabelSelector); err != nil {
+			return fmt.Errorf("invalid label_selector in pod_config %d: %w", i, err)
+		}
+		podNameRegex, err := regexp.Compile(config.PodNamePattern)
+		if err != nil {
+			return fmt.Errorf("invalid pod_name_pattern in pod_config %d: %w", i, err)
+		}
+		config.podNameRegex = podNameRegex
+		containerNameRegex, err := regexp.Compile(config.ContainerNamePattern)
+		if err != nil {
+			return fmt.Errorf("invalid container_name_pattern in pod_config %d: %w", i, err)
+		}
+		config.containerNameRegex = containerNameRegex
+		if config.LogStreamName == "" {
+			config.LogStreamName = defaultLogStreamName
+		}
+		if config.Destination == "" {
+			config.Destination = k.Destination
+		}
+	}
+	return nil
+}
+
+// Start connects to the Kubernetes API server and looks for containers to collect the logs of. The logs of the
+// containers that are already running are collected from when the agent started.
+func (k *KubernetesLogs) Start(telegraf.Accumulator) error {
+	if err := k.Init(); err != nil {
+		return err
+	}
+	var err error
+	if k.client, err = k.newClient(k.KubeConfigPath); err != nil {
+		return fmt.Errorf("unable to create kubernetes client: %w", err)
+	}
+	k.startTime = time.Now()
+	k.refresh()
+	go k.run()
+	return nil
+}
+
+func (k *KubernetesLogs) Stop() {
+	k.stopOnce.Do(func() {
+		close(k.done)
+		k.mu.Lock()
+		defer k.mu.Unlock()
+		for _, src := range k.active {
+			src.Stop()
+		}
+	})
+}
+
+func (k *KubernetesLogs) FindLogSrc() []logs.LogSrc {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	srcs := k.newSrcs
+	k.newSrcs = nil
+	return srcs
+}
+
+func (k *KubernetesLogs) run() {
+	t := time.NewTicker(k.RefreshInterval.Duration)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			k.refresh()
+		case <-k.done:
+			return
+		}
+	}
+}
+
+// refresh creates a source for every running container that matches a config and is not collected yet. The state
+// of the containers of the pods that no longer exist is dropped once all of the pods have been listed.
+func (k *KubernetesLogs) refresh() {
+	seen := map[types.UID]bool{}
+	listed := true
+	for i := range k.PodConfigs {
+		config := &k.PodConfigs[i]
+		pods, err := k.listPods(config)
+		if err != nil {
+			k.Log.Errorf("Unable to list kubernetes pods in pod_config %d: %v", i, err)
+			listed = false
+			continue
+		}
+		for j := range pods {
+			pod := &pods[j]
+			seen[pod.UID] = true
+			if pod.Status.Phase != corev1.PodRunning || !config.podNameRegex.MatchString(pod.Name) {
+				continue
+			}
+			for _, status := range pod.Status.ContainerStatuses {
+				if status.State.Running == nil || !config.containerNameRegex.MatchString(status.Name) {
+					continue
+				}
+				k.maybeAddSrc(config, pod, status)
+			}
+		}
+	}
+	if listed {
+		k.pruneStates(seen)
+	}
+}
+
+// listPods lists the pods of the config on the node. The list is served from the cache of the API server and is
+// never older than the previous list, since it is requested with the resource version of the previous list. The
+// resource version is reset when it has expired.
+func (k *KubernetesLogs) listPods(config *PodConfig) ([]corev1.Pod, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	options := metav1.ListOptions{LabelSelector: config.LabelSelector}
+	if k.NodeName != "" {
+		options.FieldSelector = fields.OneTermEqualSelector("spec.nodeName", k.NodeName).String()
+	}
+	if config.resourceVersion != "" {
+		options.ResourceVersion = config.resourceVersion
+		options.ResourceVersionMatch = metav1.ResourceVersionMatchNotOlderThan
+	}
+	pods, err := k.client.CoreV1().Pods(config.Namespace).List(ctx, options)
+	if config.resourceVersion != "" && (apierrors.IsResourceExpired(err) || apierrors.IsGone(err)) {
+		config.resourceVersion = ""
+		return k.listPods(config)
+	}
+	if err != nil {
+		return nil, err
+	}
+	config.resourceVersion = pods.ResourceVersion
+	return pods.Items, nil
+}
+
+// maybeAddSrc adds a source for the container unless its logs are already being streamed or the API server
+// throttled the requests for them.
+func (k *KubernetesLogs) maybeAddSrc(config *PodConfig, pod *corev1.Pod, status corev1.ContainerStatus) {
+	key := string(pod.UID) + "/" + status.Name
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if _, ok := k.active[key]; ok {
+		return
+	}
+	state, ok := k.states[key]
+	if !ok {
+		state = &containerState{podUID: pod.UID, lastEvent: k.startTime, restartCount: status.RestartCount, complete: true}
+		k.states[key] = state
+	}
+	if time.Now().Before(state.notBefore) {
+		return
+	}
+	src := newContainerSrc(k, config, key, pod.Namespace, pod.Name, status.Name)
+	src.since = state.lastEvent
+	// The logs of the previous instance of a container that restarted while its log stream was interrupted are
+	// read before the logs of the running instance.
+	src.previous = status.RestartCount > state.restartCount && !state.complete
+	state.restartCount = status.RestartCount
+	k.active[key] = src
+	k.newSrcs = append(k.newSrcs, src)
+}
+
+// removeSrc is called when the log stream of a container ends, e.g. because the container stopped. A log stream
+// that is throttled by the API server is not requested again until the delay it suggests has passed.
+func (k *KubernetesLogs) removeSrc(src *containerSrc, lastEvent time.Time, err error) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	if k.active[src.key] == src {
+		delete(k.active, src.key)
+	}
+	state, ok := k.states[src.key]
+	if !ok {
+		return
+	}
+	if !lastEvent.IsZero() {
+		state.lastEvent = lastEvent
+	}
+	state.complete = err == nil
+	if apierrors.IsTooManyRequests(err) {
+		delay := defaultThrottleDelay
+		if seconds, ok := apierrors.SuggestsClientDelay(err); ok && seconds > 0 {
+			delay = time.Duration(seconds) * time.Second
+		}
+		state.notBefore = time.Now().Add(delay)
+		k.Log.Warnf("Kubernetes API server throttled the logs of %v, retrying in %v", src.Description(), delay)
+	}
+}
+
+func (k *KubernetesLogs) pruneStates(seen map[types.UID]bool) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	for key, state := range k.states {
+		if _, ok := k.active[key]; !ok && !seen[state.podUID] {
+			delete(k.states, key)
+		}
+	}
+}
+
+func init() {
+	inputs.Add("kubernetes_logs", func() telegraf.Input { return NewKubernetesLogs() })
+}
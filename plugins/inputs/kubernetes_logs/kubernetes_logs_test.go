@@ -0,0 +1,434 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kubernetes_logs
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+type mockLine struct {
+	t   time.Time
+	msg string
+}
+
+type mockPod struct {
+	pod corev1.Pod
+	// lines and previousLines are the logs of the running and the previous instance of each container.
+	lines         map[string][]mockLine
+	previousLines map[string][]mockLine
+}
+
+// mockAPIServer serves the pod list and the pod log endpoints of the Kubernetes API. The log streams end after
+// the last line, as if the containers stopped.
+type mockAPIServer struct {
+	mu              sync.Mutex
+	pods            []*mockPod
+	resourceVersion int
+	listQueries     []url.Values
+	logQueries      []url.Values
+	// throttle is the number of log requests to throttle, and fail the number of log requests to fail.
+	throttle int
+	fail     int
+	// expire is set to expire the resource version of the next list that has one.
+	expire bool
+}
+
+var (
+	listPathPattern = regexp.MustCompile(`^/api/v1(?:/namespaces/([^/]+))?/pods$`)
+	logPathPattern  = regexp.MustCompile(`^/api/v1/namespaces/([^/]+)/pods/([^/]+)/log$`)
+)
+
+func (m *mockAPIServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	query := r.URL.Query()
+	if matches := listPathPattern.FindStringSubmatch(r.URL.Path); matches != nil {
+		m.listQueries = append(m.listQueries, query)
+		if m.expire && query.Get("resourceVersion") != "" {
+			m.expire = false
+			writeStatus(w, http.StatusGone, metav1.StatusReasonExpired, 0)
+			return
+		}
+		m.list(w, matches[1], query)
+		return
+	}
+	matches := logPathPattern.FindStringSubmatch(r.URL.Path)
+	if matches == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	m.logQueries = append(m.logQueries, query)
+	if m.throttle > 0 {
+		m.throttle--
+		writeStatus(w, http.StatusTooManyRequests, metav1.StatusReasonTooManyRequests, 1)
+		return
+	}
+	if m.fail > 0 {
+		m.fail--
+		writeStatus(w, http.StatusInternalServerError, metav1.StatusReasonInternalError, 0)
+		return
+	}
+	pod := m.find(matches[1], matches[2])
+	if pod == nil {
+		writeStatus(w, http.StatusNotFound, metav1.StatusReasonNotFound, 0)
+		return
+	}
+	lines := pod.lines[query.Get("container")]
+	if query.Get("previous") == "true" {
+		lines = pod.previousLines[query.Get("container")]
+	}
+	var since time.Time
+	if sinceTime := query.Get("sinceTime"); sinceTime != "" {
+		var err error
+		if since, err = time.Parse(time.RFC3339, sinceTime); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+	}
+	w.Header().Set("Content-Type", "text/plain")
+	for _, line := range lines {
+		if !line.t.Before(since) {
+			_, _ = w.Write([]byte(line.t.Format(time.RFC3339Nano) + " " + line.msg + "\n"))
+		}
+	}
+}
+
+func (m *mockAPIServer) list(w http.ResponseWriter, namespace string, query url.Values) {
+	labelSelector, err := labels.Parse(query.Get("labelSelector"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	fieldSelector, err := fields.ParseSelector(query.Get("fieldSelector"))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	m.resourceVersion++
+	list := corev1.PodList{
+		TypeMeta: metav1.TypeMeta{Kind: "PodList", APIVersion: "v1"},
+		ListMeta: metav1.ListMeta{ResourceVersion: strconv.Itoa(m.resourceVersion)},
+	}
+	for _, p := range m.pods {
+		if namespace != "" && p.pod.Namespace != namespace {
+			continue
+		}
+		if !labelSelector.Matches(labels.Set(p.pod.Labels)) || !fieldSelector.Matches(fields.Set{"spec.nodeName": p.pod.Spec.NodeName}) {
+			continue
+		}
+		list.Items = append(list.Items, p.pod)
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(list)
+}
+
+func (m *mockAPIServer) find(namespace, name string) *mockPod {
+	for _, p := range m.pods {
+		if p.pod.Namespace == namespace && p.pod.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+func writeStatus(w http.ResponseWriter, code int, reason metav1.StatusReason, retryAfterSeconds int32) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusFailure,
+		Reason:   reason,
+		Message:  string(reason),
+		Code:     int32(code),
+		Details:  &metav1.StatusDetails{RetryAfterSeconds: retryAfterSeconds},
+	})
+}
+
+// update changes a pod of the mock API server while it is serving requests.
+func (m *mockAPIServer) update(fn func()) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	fn()
+}
+
+func newPod(namespace, name, node string, podLabels map[string]string, containers ...string) *mockPod {
+	p := &mockPod{
+		pod: corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name, UID: types.UID(name + "-uid"), Labels: podLabels},
+			Spec:       corev1.PodSpec{NodeName: node},
+			Status:     corev1.PodStatus{Phase: corev1.PodRunning},
+		},
+		lines:         map[string][]mockLine{},
+		previousLines: map[string][]mockLine{},
+	}
+	for _, container := range containers {
+		p.pod.Status.ContainerStatuses = append(p.pod.Status.ContainerStatuses, corev1.ContainerStatus{
+			Name:  container,
+			State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+		})
+	}
+	return p
+}
+
+func newTestKubernetesLogs(t *testing.T, api *mockAPIServer) *KubernetesLogs {
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+	k := NewKubernetesLogs()
+	k.Log = testutil.Logger{Name: "kubernetes_logs"}
+	k.NodeName = "node-1"
+	k.RefreshInterval.Duration = time.Hour
+	k.Destination = "cloudwatchlogs"
+	k.newClient = func(string) (kubernetes.Interface, error) {
+		return kubernetes.NewForConfig(&rest.Config{Host: server.URL})
+	}
+	return k
+}
+
+// collect streams the events of the source until its log stream ends.
+func collect(t *testing.T, src logs.LogSrc) []string {
+	var events []string
+	done := make(chan struct{})
+	src.SetOutput(func(e logs.LogEvent) {
+		if e == nil {
+			close(done)
+			return
+		}
+		var msg containerLogMessage
+		assert.NoError(t, json.Unmarshal([]byte(e.Message()), &msg))
+		events = append(events, msg.Log)
+	})
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the log stream to end")
+	}
+	return events
+}
+
+func TestKubernetesLogs(t *testing.T) {
+	now := time.Now()
+	web := newPod("default", "web-1", "node-1", map[string]string{"app": "shop"}, "nginx", "sidecar")
+	web.lines["nginx"] = []mockLine{
+		{t: now.Add(-time.Hour), msg: "GET /old"},
+		{t: now.Add(time.Second), msg: "GET /index.html"},
+		{t: now.Add(2 * time.Second), msg: "GET /cart"},
+	}
+	api := &mockAPIServer{
+		pods: []*mockPod{
+			web,
+			newPod("default", "db-1", "node-1", map[string]string{"app": "db"}, "nginx"),
+			newPod("default", "web-2", "node-2", map[string]string{"app": "shop"}, "nginx"),
+			newPod("other", "web-3", "node-1", map[string]string{"app": "shop"}, "nginx"),
+		},
+	}
+	k := newTestKubernetesLogs(t, api)
+	k.PodConfigs = []PodConfig{
+		{
+			Namespace:            "default",
+			LabelSelector:        "app=shop",
+			PodNamePattern:       "^web",
+			ContainerNamePattern: "^nginx$",
+			LogGroupName:         "kubernetes/{namespace}",
+			LogStreamName:        "{pod_name}_{container_name}",
+			RetentionInDays:      7,
+		},
+	}
+	require.NoError(t, k.Start(nil))
+	defer k.Stop()
+
+	require.Len(t, api.listQueries, 1)
+	assert.Equal(t, "app=shop", api.listQueries[0].Get("labelSelector"))
+	assert.Equal(t, "spec.nodeName=node-1", api.listQueries[0].Get("fieldSelector"))
+	assert.Empty(t, api.listQueries[0].Get("resourceVersion"))
+
+	srcs := k.FindLogSrc()
+	require.Len(t, srcs, 1)
+	src := srcs[0]
+	assert.Equal(t, "kubernetes/default", src.Group())
+	assert.Equal(t, "web-1_nginx", src.Stream())
+	assert.Equal(t, "cloudwatchlogs", src.Destination())
+	assert.Equal(t, 7, src.Retention())
+	assert.Equal(t, "kubernetes container default/web-1/nginx", src.Description())
+	assert.Equal(t, []string{"GET /index.html", "GET /cart"}, collect(t, src))
+	require.Len(t, api.logQueries, 1)
+	assert.Equal(t, "nginx", api.logQueries[0].Get("container"))
+	assert.Equal(t, "true", api.logQueries[0].Get("follow"))
+	assert.Equal(t, "true", api.logQueries[0].Get("timestamps"))
+
+	// the container restarts and its logs continue after the last event
+	api.update(func() {
+		web.pod.Status.ContainerStatuses[0].RestartCount = 1
+		web.lines["nginx"] = []mockLine{
+			{t: now.Add(2 * time.Second), msg: "GET /cart"},
+			{t: now.Add(3 * time.Second), msg: "GET /restarted"},
+		}
+	})
+	k.refresh()
+	assert.Equal(t, "1", api.listQueries[1].Get("resourceVersion"))
+	assert.Equal(t, string(metav1.ResourceVersionMatchNotOlderThan), api.listQueries[1].Get("resourceVersionMatch"))
+	srcs = k.FindLogSrc()
+	require.Len(t, srcs, 1)
+	assert.Equal(t, []string{"GET /restarted"}, collect(t, srcs[0]))
+	assert.Empty(t, api.logQueries[1].Get("previous"))
+
+	// the log stream fails and the container restarts before it is streamed again, so the logs of the previous
+	// instance are read first
+	api.update(func() { api.fail = 1 })
+	k.refresh()
+	srcs = k.FindLogSrc()
+	require.Len(t, srcs, 1)
+	assert.Empty(t, collect(t, srcs[0]))
+	api.update(func() {
+		web.pod.Status.ContainerStatuses[0].RestartCount = 2
+		web.previousLines["nginx"] = append(web.lines["nginx"], mockLine{t: now.Add(4 * time.Second), msg: "GET /missed"})
+		web.lines["nginx"] = []mockLine{{t: now.Add(5 * time.Second), msg: "GET /again"}}
+	})
+	k.refresh()
+	srcs = k.FindLogSrc()
+	require.Len(t, srcs, 1)
+	assert.Equal(t, []string{"GET /missed", "GET /again"}, collect(t, srcs[0]))
+
+	// the state of the container is dropped once the pod is deleted
+	api.update(func() { api.pods = api.pods[1:] })
+	k.refresh()
+	assert.Empty(t, k.FindLogSrc())
+	assert.Empty(t, k.states)
+}
+
+func TestKubernetesLogsThrottled(t *testing.T) {
+	pod := newPod("default", "web-1", "node-1", nil, "nginx")
+	pod.lines["nginx"] = []mockLine{{t: time.Now().Add(time.Second), msg: "GET /index.html"}}
+	api := &mockAPIServer{pods: []*mockPod{pod}, throttle: 1}
+	k := newTestKubernetesLogs(t, api)
+	k.PodConfigs = []PodConfig{{LogGroupName: "group"}}
+	require.NoError(t, k.Start(nil))
+	defer k.Stop()
+
+	srcs := k.FindLogSrc()
+	require.Len(t, srcs, 1)
+	assert.Empty(t, collect(t, srcs[0]))
+	state := k.states["web-1-uid/nginx"]
+	require.NotNil(t, state)
+	assert.WithinDuration(t, time.Now().Add(time.Second), state.notBefore, time.Second)
+
+	// the logs are not requested again until the suggested delay has passed
+	k.refresh()
+	assert.Empty(t, k.FindLogSrc())
+	assert.Len(t, api.logQueries, 1)
+	k.mu.Lock()
+	state.notBefore = time.Time{}
+	k.mu.Unlock()
+	k.refresh()
+	srcs = k.FindLogSrc()
+	require.Len(t, srcs, 1)
+	assert.Equal(t, []string{"GET /index.html"}, collect(t, srcs[0]))
+}
+
+func TestListPodsExpiredResourceVersion(t *testing.T) {
+	api := &mockAPIServer{pods: []*mockPod{newPod("default", "web-1", "node-1", nil, "nginx")}}
+	k := newTestKubernetesLogs(t, api)
+	k.PodConfigs = []PodConfig{{Namespace: "default", LogGroupName: "group"}}
+	require.NoError(t, k.Init())
+	var err error
+	k.client, err = k.newClient("")
+	require.NoError(t, err)
+
+	config := &k.PodConfigs[0]
+	_, err = k.listPods(config)
+	require.NoError(t, err)
+	assert.Equal(t, "1", config.resourceVersion)
+
+	// the pods are listed again without the resource version once it has expired
+	api.expire = true
+	pods, err := k.listPods(config)
+	require.NoError(t, err)
+	assert.Len(t, pods, 1)
+	require.Len(t, api.listQueries, 3)
+	assert.Equal(t, "1", api.listQueries[1].Get("resourceVersion"))
+	assert.Empty(t, api.listQueries[2].Get("resourceVersion"))
+	assert.Equal(t, "2", config.resourceVersion)
+}
+
+func TestKubernetesLogsStop(t *testing.T) {
+	k := newTestKubernetesLogs(t, &mockAPIServer{})
+	require.NoError(t, k.Start(nil))
+	src := newContainerSrc(k, &PodConfig{LogGroupName: "group"}, "uid/nginx", "default", "web-1", "nginx")
+	k.active[src.key] = src
+	k.Stop()
+	assert.Error(t, src.ctx.Err())
+}
+
+func TestInit(t *testing.T) {
+	testCases := map[string]struct {
+		configs []PodConfig
+		wantErr string
+	}{
+		"WithValidConfig": {
+			configs: []PodConfig{{LabelSelector: "app in (shop, web)", LogGroupName: "group"}},
+		},
+		"WithoutLogGroupName": {
+			configs: []PodConfig{{PodNamePattern: "^web"}},
+			wantErr: "log_group_name is required in pod_config 0",
+		},
+		"WithInvalidLabelSelector": {
+			configs: []PodConfig{{LabelSelector: "app in shop", LogGroupName: "group"}},
+			wantErr: "invalid label_selector in pod_config 0",
+		},
+		"WithInvalidPodNamePattern": {
+			configs: []PodConfig{{PodNamePattern: "(", LogGroupName: "group"}},
+			wantErr: "invalid pod_name_pattern in pod_config 0",
+		},
+		"WithInvalidContainerNamePattern": {
+			configs: []PodConfig{{ContainerNamePattern: "(", LogGroupName: "group"}},
+			wantErr: "invalid container_name_pattern in pod_config 0",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv("HOST_NAME", "node-1")
+			k := NewKubernetesLogs()
+			k.Destination = "cloudwatchlogs"
+			k.PodConfigs = testCase.configs
+			err := k.Init()
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, err, testCase.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "node-1", k.NodeName)
+			assert.Equal(t, defaultLogStreamName, k.PodConfigs[0].LogStreamName)
+			assert.Equal(t, "cloudwatchlogs", k.PodConfigs[0].Destination)
+		})
+	}
+}
+
+func TestParseLine(t *testing.T) {
+	got, msg := parseLine("2024-01-02T03:04:05.123456789Z hello world")
+	assert.Equal(t, time.Date(2024, 1, 2, 3, 4, 5, 123456789, time.UTC), got)
+	assert.Equal(t, "hello world", msg)
+
+	_, msg = parseLine("hello world")
+	assert.Equal(t, "hello world", msg)
+}
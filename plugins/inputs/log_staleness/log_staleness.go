@@ -0,0 +1,89 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package log_staleness
+
+import (
+	_ "embed"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gobwas/glob"
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/logfile"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const (
+	measurement    = "log"
+	stalenessField = "staleness_seconds"
+	fileTag        = "file"
+)
+
+// LogStaleness reports the time since each file tailed by the logs agent last had a new line, so that alarms can fire
+// when an application stops writing its logs. The staleness of a file is reset whenever a line is read from it.
+type LogStaleness struct {
+	Files []string        `toml:"files"`
+	Log   telegraf.Logger `toml:"-"`
+
+	globs []glob.Glob
+	// now is replaced in the tests.
+	now func() time.Time
+}
+
+func (*LogStaleness) SampleConfig() string {
+	return sampleConfig
+}
+
+func (*LogStaleness) Description() string {
+	return "Reports the time since each file tailed by the logs agent last had a new line"
+}
+
+func (l *LogStaleness) Init() error {
+	l.globs = make([]glob.Glob, 0, len(l.Files))
+	for _, pattern := range l.Files {
+		g, err := glob.Compile(pattern, os.PathSeparator)
+		if err != nil {
+			return fmt.Errorf("invalid file pattern %q: %w", pattern, err)
+		}
+		l.globs = append(l.globs, g)
+	}
+	if l.now == nil {
+		l.now = time.Now
+	}
+	return nil
+}
+
+func (l *LogStaleness) Gather(acc telegraf.Accumulator) error {
+	for file, staleness := range logfile.Staleness(l.now()) {
+		if !l.matches(file) {
+			continue
+		}
+		acc.AddGauge(measurement, map[string]interface{}{stalenessField: staleness.Seconds()}, map[string]string{fileTag: file})
+	}
+	return nil
+}
+
+// matches returns whether the file is reported, which is any file when there are no patterns.
+func (l *LogStaleness) matches(file string) bool {
+	if len(l.globs) == 0 {
+		return true
+	}
+	for _, g := range l.globs {
+		if g.Match(file) {
+			return true
+		}
+	}
+	return false
+}
+
+func init() {
+	inputs.Add("log_staleness", func() telegraf.Input {
+		return &LogStaleness{}
+	})
+}
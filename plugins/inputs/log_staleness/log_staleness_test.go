@@ -0,0 +1,113 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package log_staleness
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/logfile"
+)
+
+// tailFile tails the file with the logfile plugin.
+func tailFile(t *testing.T, path string) {
+	plugin := logfile.NewLogFile()
+	plugin.Log = testutil.Logger{}
+	plugin.FileStateFolder = t.TempDir()
+	plugin.FileConfig = []logfile.FileConfig{{FilePath: path, FromBeginning: true}}
+	require.NoError(t, plugin.Start(nil))
+	t.Cleanup(plugin.Stop)
+
+	srcs := plugin.FindLogSrc()
+	require.Len(t, srcs, 1)
+	srcs[0].SetOutput(func(evt logs.LogEvent) {
+		if evt != nil {
+			evt.Done()
+		}
+	})
+	t.Cleanup(srcs[0].Stop)
+	require.Eventually(t, func() bool {
+		_, ok := logfile.Staleness(time.Now())[path]
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func staleness(t *testing.T, plugin *LogStaleness, file string) float64 {
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	for _, m := range acc.Metrics {
+		if m.Tags[fileTag] == file {
+			assert.Equal(t, measurement, m.Measurement)
+			return m.Fields[stalenessField].(float64)
+		}
+	}
+	require.FailNow(t, "no staleness reported", file)
+	return 0
+}
+
+func TestGather(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+	// the file was last written an hour ago
+	modified := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(path, modified, modified))
+	tailFile(t, path)
+
+	now := time.Now()
+	plugin := &LogStaleness{Log: testutil.Logger{}, now: func() time.Time { return now }}
+	require.NoError(t, plugin.Init())
+	initial := staleness(t, plugin, path)
+	assert.InDelta(t, 3600, initial, 1)
+
+	// the staleness grows while no lines are written
+	now = now.Add(time.Minute)
+	assert.InDelta(t, initial+60, staleness(t, plugin, path), 0.001)
+	now = now.Add(time.Hour)
+	assert.InDelta(t, initial+3660, staleness(t, plugin, path), 0.001)
+
+	// and resets when a line is written
+	_, err = fmt.Fprintln(file, "line")
+	require.NoError(t, err)
+	assert.Eventually(t, func() bool {
+		now = time.Now()
+		return staleness(t, plugin, path) < 5
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func TestGatherFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+	tailFile(t, path)
+
+	plugin := &LogStaleness{Files: []string{filepath.Join(dir, "*.txt")}, Log: testutil.Logger{}}
+	require.NoError(t, plugin.Init())
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	for _, m := range acc.Metrics {
+		assert.NotEqual(t, path, m.Tags[fileTag])
+	}
+
+	plugin = &LogStaleness{Files: []string{filepath.Join(dir, "*.txt"), filepath.Join(dir, "*.log")}, Log: testutil.Logger{}}
+	require.NoError(t, plugin.Init())
+	staleness(t, plugin, path)
+}
+
+func TestInitInvalidPattern(t *testing.T) {
+	plugin := &LogStaleness{Files: []string{"/var/log/[a"}}
+	assert.Error(t, plugin.Init())
+}
@@ -23,6 +23,16 @@ import (
 const (
 	defaultMaxEventSize   = 1024 * 256 //256KB
 	defaultTruncateSuffix = "[Truncated...]"
+
+	// Actions for log events larger than the max event size
+	oversizeEventActionTruncate = "truncate"
+	oversizeEventActionSplit    = "split"
+
+	// Format of files that hold a single JSON array whose elements are published as events
+	logFormatJSONArray = "json_array"
+
+	// Placeholder in the event prefix and suffix replaced with the name of the tailed file
+	fileNamePlaceholder = "{file_name}"
 )
 
 // The file config presents the structure of configuration for a file to be tailed.
@@ -48,6 +58,9 @@ type FileConfig struct {
 	TimestampLayout []string `toml:"timestamp_layout"`
 	//The time zone used to parse the timestampFromLogLine in the log entry.
 	Timezone string `toml:"timezone"`
+	//The fields of JSON log entries that the timestamp is read from instead of the timestamp regex.
+	//Nested fields are separated by dots and the first field with a valid timestamp is used.
+	TimestampFields []string `toml:"timestamp_fields"`
 
 	//Indicate whether it is a start of multiline.
 	//If this config is not present, it means the multiline mode is disabled.
@@ -78,11 +91,38 @@ type FileConfig struct {
 	//Suffix to be added to truncated logline to indicate its truncation
 	TruncateSuffix string `toml:"truncate_suffix"`
 
+	//Indicate whether log events larger than the max event size are truncated or split into multiple events.
+	//When not set, lines longer than the max event size are broken up without any marker.
+	OversizeEventAction string `toml:"oversize_event_action"`
+
+	//Indicate the format of the log file. When set to json_array, the file is a single JSON array and each of its
+	//elements is published as an event instead of each line.
+	LogFormat string `toml:"log_format"`
+
+	//Text added to the start and end of every log event. {file_name} is replaced with the name of the tailed file.
+	EventPrefix string `toml:"event_prefix"`
+	EventSuffix string `toml:"event_suffix"`
+
 	//Indicate retention in days for log group
 	RetentionInDays int `toml:"retention_in_days"`
 
 	Filters []*LogFilter `toml:"filters"`
 
+	//The events below the minimum severity are dropped. The severity is a level name, e.g. WARN, or a syslog
+	//severity from 0 (emergency) to 7 (debug).
+	MinSeverity string `toml:"min_severity"`
+	//The fields of JSON log events that the severity is read from. Nested fields are separated by dots.
+	SeverityFields []string `toml:"severity_fields"`
+	//The regex that matches the severity of text log events. The first submatch, if any, is the severity.
+	SeverityPattern string `toml:"severity_pattern"`
+
+	//The rate limit shared by all the files of the config. Events above it are dropped or buffered.
+	RateLimit *RateLimit `toml:"rate_limit"`
+
+	//Publish only the first N events of each file and then stop reading it, to validate the timestamp and multiline
+	//config of a file cheaply. The files are sampled from the beginning on every start, e.g. after a config change.
+	SampleFirstN int `toml:"sample_first_n"`
+
 	//Customer specified service.name
 	ServiceName string `toml:"service_name"`
 	//Customer specified deployment.environment
@@ -97,7 +137,11 @@ type FileConfig struct {
 	//Regexp go type blacklist regex
 	BlacklistRegexP *regexp.Regexp
 	//Decoder object
-	Enc         encoding.Encoding
+	Enc encoding.Encoding
+	//Filter of the events below the minimum severity
+	severityFilter *severityFilter
+	//Limiter of the rate at which the events are published
+	rateLimiter *rateLimiter
 	sampleCount int
 }
 
@@ -130,6 +174,12 @@ func (config *FileConfig) init() error {
 		}
 	}
 
+	for _, field := range config.TimestampFields {
+		if field == "" {
+			return errors.New("timestamp_fields cannot contain an empty field")
+		}
+	}
+
 	if config.MultiLineStartPattern == "" {
 		config.MultiLineStartPattern = "^[\\S]"
 	}
@@ -154,6 +204,22 @@ func (config *FileConfig) init() error {
 	if config.TruncateSuffix == "" {
 		config.TruncateSuffix = defaultTruncateSuffix
 	}
+
+	switch config.OversizeEventAction {
+	case "", oversizeEventActionTruncate, oversizeEventActionSplit:
+	default:
+		return fmt.Errorf("oversize_event_action %q is not supported, use %q or %q", config.OversizeEventAction, oversizeEventActionTruncate, oversizeEventActionSplit)
+	}
+
+	switch config.LogFormat {
+	case "", logFormatJSONArray:
+	default:
+		return fmt.Errorf("log_format %q is not supported, use %q", config.LogFormat, logFormatJSONArray)
+	}
+
+	if len(config.EventPrefix)+len(config.EventSuffix) >= config.MaxEventSize/2 {
+		return fmt.Errorf("event_prefix and event_suffix must be shorter than half of max_event_size %d", config.MaxEventSize)
+	}
 	if config.RetentionInDays == 0 {
 		config.RetentionInDays = -1
 	}
@@ -165,9 +231,34 @@ func (config *FileConfig) init() error {
 		}
 	}
 
+	if config.MinSeverity != "" {
+		if config.severityFilter, err = newSeverityFilter(config.MinSeverity, config.SeverityFields, config.SeverityPattern); err != nil {
+			return err
+		}
+	} else if len(config.SeverityFields) > 0 || config.SeverityPattern != "" {
+		return errors.New("severity_fields and severity_pattern require min_severity")
+	}
+
+	if config.RateLimit != nil {
+		if config.rateLimiter, err = newRateLimiter(config.RateLimit); err != nil {
+			return err
+		}
+	}
+
+	if config.SampleFirstN < 0 {
+		return fmt.Errorf("sample_first_n %d must not be negative", config.SampleFirstN)
+	}
+
 	return nil
 }
 
+// eventDecoration returns the prefix and suffix for the events of the tailed file.
+func (config *FileConfig) eventDecoration(filename string) (string, string) {
+	name := filepath.Base(filename)
+	return strings.ReplaceAll(config.EventPrefix, fileNamePlaceholder, name),
+		strings.ReplaceAll(config.EventSuffix, fileNamePlaceholder, name)
+}
+
 // Try to parse the timestampFromLogLine value from the log entry line.
 // The parser logic will be based on the timestampFromLogLine regex, and time zone info.
 // If the parsing operation encounters any issue, int64(0) is returned.
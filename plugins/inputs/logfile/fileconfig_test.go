@@ -6,6 +6,7 @@ package logfile
 import (
 	"fmt"
 	"regexp"
+	"strings"
 	"testing"
 	"time"
 
@@ -72,6 +73,37 @@ func TestFileConfigInitFailureCase(t *testing.T) {
 	err = fileConfig.init()
 	assert.Error(t, err)
 	assert.Equal(t, "multi_line_start_pattern has issue, regexp: Compile( (\\d{2} \\w{3} \\d{4} \\d{2}:\\d{2}:\\d{2}+) ): error parsing regexp: invalid nested repetition operator: `{2}+`", err.Error())
+
+	fileConfig = &FileConfig{
+		FilePath:            "/tmp/logfile.log",
+		LogGroupName:        "logfile.log",
+		OversizeEventAction: "drop",
+	}
+
+	err = fileConfig.init()
+	assert.Error(t, err)
+	assert.Equal(t, `oversize_event_action "drop" is not supported, use "truncate" or "split"`, err.Error())
+
+	fileConfig = &FileConfig{
+		FilePath:     "/tmp/logfile.log",
+		LogGroupName: "logfile.log",
+		LogFormat:    "xml",
+	}
+
+	err = fileConfig.init()
+	assert.Error(t, err)
+	assert.Equal(t, `log_format "xml" is not supported, use "json_array"`, err.Error())
+
+	fileConfig = &FileConfig{
+		FilePath:     "/tmp/logfile.log",
+		LogGroupName: "logfile.log",
+		MaxEventSize: 100,
+		EventPrefix:  strings.Repeat("p", 50),
+	}
+
+	err = fileConfig.init()
+	assert.Error(t, err)
+	assert.Equal(t, "event_prefix and event_suffix must be shorter than half of max_event_size 100", err.Error())
 }
 
 func TestInfrequent_accessAndEmptyLogGroupClassInit(t *testing.T) {
@@ -233,6 +265,77 @@ func TestTimestampParserWithFracSeconds(t *testing.T) {
 		fmt.Sprintf("The timestampFromLogLine value %v is not the same as expected %v.", timestamp, expectedTimestamp))
 }
 
+func TestTimestampFromFields(t *testing.T) {
+	fileConfig := &FileConfig{
+		FilePath:        "/tmp/test.log",
+		TimestampFields: []string{"@timestamp", "log.time"},
+		Timezone:        "UTC",
+	}
+	require.NoError(t, fileConfig.init())
+
+	expectedTimestamp := time.Date(2024, 3, 8, 14, 25, 18, 234567000, time.UTC)
+	testCases := map[string]struct {
+		logEntry string
+		want     time.Time
+	}{
+		"WithISO8601Field": {
+			logEntry: `{"@timestamp":"2024-03-08T14:25:18.234567Z","message":"test"}`,
+			want:     expectedTimestamp,
+		},
+		"WithOffset": {
+			logEntry: `{"@timestamp":"2024-03-08T15:25:18.234567+01:00","message":"test"}`,
+			want:     expectedTimestamp,
+		},
+		"WithNestedField": {
+			logEntry: `{"log":{"time":"2024-03-08T14:25:18.234567Z"},"message":"test"}`,
+			want:     expectedTimestamp,
+		},
+		"WithDottedKey": {
+			logEntry: `{"log.time":"2024-03-08T14:25:18.234567Z","message":"test"}`,
+			want:     expectedTimestamp,
+		},
+		"WithInvalidFirstField": {
+			logEntry: "{\"@timestamp\":\"yesterday\",\n\"log\":{\"time\":\"2024-03-08T14:25:18.234567Z\"}}",
+			want:     expectedTimestamp,
+		},
+		"WithMissingField": {
+			logEntry: `{"time":"2024-03-08T14:25:18.234567Z","message":"test"}`,
+		},
+		"WithNumericField": {
+			logEntry: `{"@timestamp":1709907918,"message":"test"}`,
+		},
+		"WithNonJSON": {
+			logEntry: `2024-03-08T14:25:18.234567Z [INFO] test`,
+		},
+		"WithInvalidJSON": {
+			logEntry: `{"@timestamp":"2024-03-08T14:25:18.234567Z"`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			timestamp := fileConfig.timestamp(testCase.logEntry)
+			assert.True(t, testCase.want.Equal(timestamp), "got %v, want %v", timestamp, testCase.want)
+		})
+	}
+}
+
+func TestTimestampFromFieldsWithLayout(t *testing.T) {
+	fileConfig := &FileConfig{
+		FilePath:        "/tmp/test.log",
+		TimestampFields: []string{"time"},
+		TimestampLayout: []string{"2006-01-02 15:04:05,.000"},
+		TimestampRegex:  "(\\d{4}-\\d{2}-\\d{2} \\d{2}:\\d{2}:\\d{2},(\\d{1,9}))",
+		Timezone:        "UTC",
+	}
+	require.NoError(t, fileConfig.init())
+
+	timestamp := fileConfig.timestamp(`{"time":"2024-03-08 14:25:18,234567","message":"2020-01-01 00:00:00,000"}`)
+	assert.Equal(t, time.Date(2024, 3, 8, 14, 25, 18, 234567000, time.UTC), timestamp)
+
+	fileConfig.TimestampFields = []string{""}
+	assert.Error(t, fileConfig.init())
+}
+
 func TestNonAllowlistedTimezone(t *testing.T) {
 	fileConfig := &FileConfig{
 		Timezone: "EST",
@@ -605,3 +708,48 @@ func initializeLogFiltersForBenchmarks(b *testing.B, filters []*LogFilter) []*Lo
 	}
 	return filters
 }
+
+func TestFileConfigInitWithSeverity(t *testing.T) {
+	fileConfig := &FileConfig{
+		FilePath:       "/tmp/logfile.log",
+		MinSeverity:    "WARN",
+		SeverityFields: []string{"level"},
+	}
+	assert.NoError(t, fileConfig.init())
+	assert.NotNil(t, fileConfig.severityFilter)
+
+	fileConfig = &FileConfig{
+		FilePath:    "/tmp/logfile.log",
+		MinSeverity: "verbose",
+	}
+	assert.EqualError(t, fileConfig.init(), `min_severity "verbose" is not a level name or a syslog severity from 0 to 7`)
+
+	fileConfig = &FileConfig{
+		FilePath:        "/tmp/logfile.log",
+		SeverityPattern: `level=(\w+)`,
+	}
+	assert.Error(t, fileConfig.init())
+}
+
+func TestFileConfigInitWithRateLimit(t *testing.T) {
+	fileConfig := &FileConfig{
+		FilePath:  "/tmp/logfile.log",
+		RateLimit: &RateLimit{EventsPerSecond: 100, BytesPerSecond: 1024, Action: rateLimitActionBuffer},
+	}
+	assert.NoError(t, fileConfig.init())
+	assert.NotNil(t, fileConfig.rateLimiter)
+
+	fileConfig = &FileConfig{
+		FilePath:  "/tmp/logfile.log",
+		RateLimit: &RateLimit{},
+	}
+	assert.Error(t, fileConfig.init())
+}
+
+func TestFileConfigInitWithSampleFirstN(t *testing.T) {
+	fileConfig := &FileConfig{FilePath: "/tmp/logfile.log", SampleFirstN: 10}
+	assert.NoError(t, fileConfig.init())
+
+	fileConfig = &FileConfig{FilePath: "/tmp/logfile.log", SampleFirstN: -1}
+	assert.ErrorContains(t, fileConfig.init(), "sample_first_n")
+}
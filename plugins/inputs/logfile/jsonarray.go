@@ -0,0 +1,158 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// jsonArrayDecoder incrementally splits a file holding a single JSON array into its elements. The lines of the file
+// are decoded as they are tailed, so an array that grows by appending elements is published one element at a time
+// and an element is only complete once its closing character has been read.
+//
+// Reading can start in the middle of the array, e.g. when resuming from the saved offset of the file, in which case
+// the elements are read without the opening bracket.
+type jsonArrayDecoder struct {
+	// limit is the size of the largest element that is buffered. Larger elements are dropped.
+	limit int
+
+	inArray bool
+	// inElement is set while an element is being read.
+	inElement bool
+	// depth is the nesting of objects and arrays of the current element.
+	depth    int
+	inString bool
+	escaped  bool
+	// scalar is set while a number, true, false or null element is being read.
+	scalar bool
+	// oversize is set when the current element is over the limit and is no longer buffered.
+	oversize bool
+	buf      bytes.Buffer
+}
+
+func newJSONArrayDecoder(limit int) *jsonArrayDecoder {
+	return &jsonArrayDecoder{limit: limit}
+}
+
+// decode reads the text and returns the elements that it completes, compacted to a single line. Elements that are
+// not valid JSON or are over the limit are dropped and returned as errors.
+func (d *jsonArrayDecoder) decode(text string) ([]string, []error) {
+	var elements []string
+	var errs []error
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		if !d.inElement {
+			d.start(c)
+			continue
+		}
+		if d.scalar && (c == ',' || c == ']' || isJSONSpace(c)) {
+			element, err := d.end()
+			elements, errs = appendResult(elements, errs, element, err)
+			// the character that ends a scalar belongs to the array
+			d.start(c)
+			continue
+		}
+		d.write(c)
+		switch {
+		case d.scalar:
+		case d.inString:
+			if d.escaped {
+				d.escaped = false
+			} else if c == '\\' {
+				d.escaped = true
+			} else if c == '"' {
+				d.inString = false
+			}
+		case c == '"':
+			d.inString = true
+		case c == '{' || c == '[':
+			d.depth++
+		case c == '}' || c == ']':
+			d.depth--
+		}
+		if !d.scalar && !d.inString && d.depth == 0 {
+			element, err := d.end()
+			elements, errs = appendResult(elements, errs, element, err)
+		}
+	}
+	return elements, errs
+}
+
+// pending returns true if part of an element has been read.
+func (d *jsonArrayDecoder) pending() bool {
+	return d.inElement
+}
+
+// start handles a character read between the elements of the array.
+func (d *jsonArrayDecoder) start(c byte) {
+	switch {
+	case isJSONSpace(c) || c == ',':
+	case c == '[' && !d.inArray:
+		d.inArray = true
+	case c == ']' && d.inArray:
+		d.inArray = false
+	case c == '}' || c == ']':
+		// the rest of an invalid element
+	default:
+		d.inArray = true
+		d.inElement = true
+		d.write(c)
+		switch c {
+		case '{', '[':
+			d.depth = 1
+		case '"':
+			d.inString = true
+		default:
+			d.scalar = true
+		}
+	}
+}
+
+func (d *jsonArrayDecoder) write(c byte) {
+	if d.oversize {
+		return
+	}
+	if d.buf.Len() >= d.limit {
+		d.oversize = true
+		return
+	}
+	d.buf.WriteByte(c)
+}
+
+// end finishes the current element.
+func (d *jsonArrayDecoder) end() (string, error) {
+	defer d.reset()
+	if d.oversize {
+		return "", fmt.Errorf("dropped JSON array element larger than %d bytes", d.limit)
+	}
+	var compacted bytes.Buffer
+	if err := json.Compact(&compacted, d.buf.Bytes()); err != nil {
+		return "", fmt.Errorf("dropped invalid JSON array element: %w", err)
+	}
+	return compacted.String(), nil
+}
+
+// reset discards the current element.
+func (d *jsonArrayDecoder) reset() {
+	d.inElement = false
+	d.depth = 0
+	d.inString = false
+	d.escaped = false
+	d.scalar = false
+	d.oversize = false
+	d.buf.Reset()
+}
+
+func appendResult(elements []string, errs []error, element string, err error) ([]string, []error) {
+	if err != nil {
+		return elements, append(errs, err)
+	}
+	return append(elements, element), errs
+}
+
+func isJSONSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
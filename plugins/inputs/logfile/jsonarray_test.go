@@ -0,0 +1,64 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONArrayDecoder(t *testing.T) {
+	testCases := map[string]struct {
+		lines       []string
+		want        []string
+		wantErrs    int
+		wantPending bool
+	}{
+		"SingleLine": {
+			lines: []string{`[{"a":1},{"b":[1,2]},"c",1.5,true,null]`},
+			want:  []string{`{"a":1}`, `{"b":[1,2]}`, `"c"`, `1.5`, `true`, `null`},
+		},
+		"MultiLine": {
+			lines: []string{"[", `  {`, `    "a": "x\"}]",`, `    "b": {"c": []}`, `  },`, `  {"d": 2}`, "]"},
+			want:  []string{`{"a":"x\"}]","b":{"c":[]}}`, `{"d":2}`},
+		},
+		"Resume": {
+			// reading from a saved offset in the middle of the array
+			lines: []string{`{"a":1},`, `{"b":2}]`},
+			want:  []string{`{"a":1}`, `{"b":2}`},
+		},
+		"PartialTail": {
+			lines:       []string{`[{"a":1},`, `{"b":`},
+			want:        []string{`{"a":1}`},
+			wantPending: true,
+		},
+		"InvalidElement": {
+			lines:    []string{`[{"a":1]}, {"b":2}, tru, {"c":3}]`},
+			want:     []string{`{"b":2}`, `{"c":3}`},
+			wantErrs: 2,
+		},
+		"Oversize": {
+			lines:    []string{`[{"a":"` + strings.Repeat("x", 100) + `"}, {"b":2}]`},
+			want:     []string{`{"b":2}`},
+			wantErrs: 1,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			d := newJSONArrayDecoder(64)
+			var got []string
+			var errs []error
+			for _, line := range testCase.lines {
+				elements, lineErrs := d.decode(line + "\n")
+				got = append(got, elements...)
+				errs = append(errs, lineErrs...)
+			}
+			assert.Equal(t, testCase.want, got)
+			assert.Len(t, errs, testCase.wantErrs)
+			assert.Equal(t, testCase.wantPending, d.pending())
+		})
+	}
+}
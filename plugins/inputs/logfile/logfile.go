@@ -4,6 +4,7 @@
 package logfile
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -37,8 +38,18 @@ type LogFile struct {
 	done              chan struct{}
 	removeTailerSrcCh chan *tailerSrc
 	started           bool
+	// stateLock is the open lock file of the state folder.
+	stateLock *os.File
 }
 
+const (
+	stateFolderMode = 0755
+	// stateLockFileName is the file in the state folder that is locked by the agent using the folder.
+	stateLockFileName = ".logfile.lock"
+)
+
+var errStateFolderLocked = errors.New("the directory is locked by another process")
+
 func NewLogFile() *LogFile {
 	return &LogFile{
 		configs:           make(map[*FileConfig]map[string]*tailerSrc),
@@ -86,6 +97,11 @@ const sampleConfig = `
       max_event_size = 262144
       ## Suffix to be added to truncated logline to indicate its truncation, defaults to "[Truncated...]"
       truncate_suffix = "[Truncated...]"
+      ## Action for log events larger than max_event_size, either "truncate" or "split" into multiple events
+      # oversize_event_action = "split"
+      ## Text added to the start and end of every log event, {file_name} is replaced with the name of the file
+      # event_prefix = "[{file_name}] "
+      # event_suffix = ""
 
 `
 
@@ -102,10 +118,8 @@ func (t *LogFile) Gather(acc telegraf.Accumulator) error {
 }
 
 func (t *LogFile) Start(acc telegraf.Accumulator) error {
-	// Create the log file state folder.
-	err := os.MkdirAll(t.FileStateFolder, 0755)
-	if err != nil {
-		return fmt.Errorf("failed to create state file directory %s: %v", t.FileStateFolder, err)
+	if err := t.lockStateFolder(); err != nil {
+		return err
 	}
 
 	// Clean state file on init and regularly
@@ -140,6 +154,28 @@ func (t *LogFile) Stop() {
 	// Tailer srcs are stopped by log agent after the output plugin is stopped instead of here
 	// because the tailersrc would like to record an accurate uploaded offset
 	close(t.done)
+	if t.stateLock != nil {
+		t.stateLock.Close()
+	}
+}
+
+// lockStateFolder creates the state folder and takes an advisory lock on it, so that two agents do not overwrite each
+// other's state files. Fails if the folder is not writable or is locked by another agent.
+func (t *LogFile) lockStateFolder() error {
+	if err := os.MkdirAll(t.FileStateFolder, stateFolderMode); err != nil {
+		return fmt.Errorf("failed to create state file directory %s: %v", t.FileStateFolder, err)
+	}
+	lockFilePath := filepath.Join(t.FileStateFolder, stateLockFileName)
+	file, err := os.OpenFile(lockFilePath, os.O_RDWR|os.O_CREATE, stateFileMode)
+	if err != nil {
+		return fmt.Errorf("state file directory %s is not writable, set file_state_folder to a writable directory: %w", t.FileStateFolder, err)
+	}
+	if err = lockFile(file); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to lock state file directory %s, each agent needs its own file_state_folder: %w", t.FileStateFolder, err)
+	}
+	t.stateLock = file
+	return nil
 }
 
 // Try to find if there is any new file needs to be added for monitoring.
@@ -186,8 +222,12 @@ func (t *LogFile) FindLogSrc() []logs.LogSrc {
 			}
 
 			var seekFile *tail.SeekInfo
-			offset, err := t.restoreState(filename)
-			if err == nil { // Missing state file would be an error too
+			stateFilePath := t.getStateFilePath(filename)
+			if fileconfig.SampleFirstN > 0 {
+				// Samples always start at the beginning of the file and do not record their offset, so the same
+				// events are sampled again after a config change.
+				stateFilePath = ""
+			} else if offset, err := t.restoreState(filename); err == nil { // Missing state file would be an error too
 				seekFile = &tail.SeekInfo{Whence: io.SeekStart, Offset: offset}
 			} else if !fileconfig.Pipe && !fileconfig.FromBeginning {
 				seekFile = &tail.SeekInfo{Whence: io.SeekEnd, Offset: 0}
@@ -238,20 +278,28 @@ func (t *LogFile) FindLogSrc() []logs.LogSrc {
 				destination = t.Destination
 			}
 
+			eventPrefix, eventSuffix := fileconfig.eventDecoration(filename)
 			src := NewTailerSrc(
 				groupName, streamName,
 				t.Destination,
-				t.getStateFilePath(filename),
+				stateFilePath,
 				fileconfig.LogGroupClass,
 				fileconfig.FilePath,
 				tailer,
 				fileconfig.AutoRemoval,
 				mlCheck,
 				fileconfig.Filters,
-				fileconfig.timestampFromLogLine,
+				fileconfig.severityFilter,
+				fileconfig.rateLimiter,
+				fileconfig.SampleFirstN,
+				fileconfig.timestamp,
 				fileconfig.Enc,
 				fileconfig.MaxEventSize,
 				fileconfig.TruncateSuffix,
+				fileconfig.OversizeEventAction,
+				fileconfig.LogFormat,
+				eventPrefix,
+				eventSuffix,
 				fileconfig.RetentionInDays,
 			)
 
@@ -372,7 +420,7 @@ func (t *LogFile) cleanupStateFolder() {
 			continue
 		}
 
-		if strings.Contains(file, logscommon.WindowsEventLogPrefix) {
+		if strings.Contains(file, logscommon.WindowsEventLogPrefix) || filepath.Base(file) == stateLockFileName {
 			continue
 		}
 
@@ -399,6 +447,10 @@ func (t *LogFile) cleanUpStoppedTailerSrc() {
 	for {
 		select {
 		case rts := <-t.removeTailerSrcCh:
+			// A file that has been sampled stays in the destinations, so it is not tailed again until the next start.
+			if rts.sampleComplete() {
+				continue
+			}
 			for _, dsts := range t.configs {
 				for n, ts := range dsts {
 					if ts == rts {
@@ -8,7 +8,9 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strconv"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -227,6 +229,50 @@ func TestRestoreState(t *testing.T) {
 	tt.Stop()
 }
 
+func TestStateFolderLock(t *testing.T) {
+	stateDir := filepath.Join(t.TempDir(), "state")
+
+	first := NewLogFile()
+	first.Log = TestLogger{t}
+	first.FileStateFolder = stateDir
+	require.NoError(t, first.Start(nil))
+	info, err := os.Stat(stateDir)
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+	assert.FileExists(t, filepath.Join(stateDir, stateLockFileName))
+
+	// the lock file is not a state file
+	first.cleanupStateFolder()
+	assert.FileExists(t, filepath.Join(stateDir, stateLockFileName))
+
+	second := NewLogFile()
+	second.Log = TestLogger{t}
+	second.FileStateFolder = stateDir
+	err = second.Start(nil)
+	assert.ErrorIs(t, err, errStateFolderLocked)
+	assert.False(t, second.started)
+
+	first.Stop()
+	require.NoError(t, second.Start(nil))
+	second.Stop()
+}
+
+func TestStateFolderNotWritable(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("directory permissions are not enforced")
+	}
+	stateDir := t.TempDir()
+	require.NoError(t, os.Chmod(stateDir, 0555))
+	defer os.Chmod(stateDir, 0755)
+
+	tt := NewLogFile()
+	tt.Log = TestLogger{t}
+	tt.FileStateFolder = stateDir
+	err := tt.Start(nil)
+	assert.ErrorIs(t, err, os.ErrPermission)
+	assert.ErrorContains(t, err, "is not writable")
+}
+
 func TestMultipleFilesForSameConfig(t *testing.T) {
 	multilineWaitPeriod = 10 * time.Millisecond
 	tmpfile1, err := createTempFile("", "tmp1_")
@@ -1111,6 +1157,57 @@ func TestLogFileMultiLogsReadingWithBlacklist(t *testing.T) {
 	tt.Stop()
 }
 
+func TestLogsSampleFirstN(t *testing.T) {
+	multilineWaitPeriod = 10 * time.Millisecond
+	stateDir := t.TempDir()
+	logFile := filepath.Join(t.TempDir(), "app.log")
+	var lines []string
+	for i := 0; i < 10; i++ {
+		lines = append(lines, fmt.Sprintf("line %d", i))
+	}
+	require.NoError(t, os.WriteFile(logFile, []byte(strings.Join(lines, "\n")+"\n"), 0600))
+	// a state file from before sampling was enabled is ignored
+	require.NoError(t, os.WriteFile(filepath.Join(stateDir, escapeFilePath(logFile)), []byte("14\n"+logFile), 0600))
+
+	tt := NewLogFile()
+	tt.Log = TestLogger{t}
+	tt.FileStateFolder = stateDir
+	tt.FileConfig = []FileConfig{{FilePath: logFile, SampleFirstN: 3, AutoRemoval: true}}
+	require.NoError(t, tt.FileConfig[0].init())
+	tt.started = true
+
+	lsrcs := tt.FindLogSrc()
+	require.Len(t, lsrcs, 1)
+	var got []string
+	exited := make(chan struct{})
+	lsrcs[0].SetOutput(func(e logs.LogEvent) {
+		if e == nil {
+			close(exited)
+			return
+		}
+		got = append(got, e.Message())
+		e.Done()
+	})
+	select {
+	case <-exited:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "the tailer did not stop after the sample")
+	}
+	assert.Equal(t, lines[:3], got)
+
+	// the sampled file is not tailed again, removed or given a new offset
+	_, err := os.Stat(logFile)
+	assert.NoError(t, err)
+	assert.Empty(t, tt.FindLogSrc())
+	time.Sleep(200 * time.Millisecond)
+	state, err := os.ReadFile(filepath.Join(stateDir, escapeFilePath(logFile)))
+	require.NoError(t, err)
+	assert.Equal(t, "14\n"+logFile, string(state))
+
+	lsrcs[0].Stop()
+	tt.Stop()
+}
+
 func TestGenerateLogGroupName(t *testing.T) {
 	multilineWaitPeriod = 10 * time.Millisecond
 	fileName := "C:\\tmp\\soak Test\\tmp0.log"
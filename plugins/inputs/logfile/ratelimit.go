@@ -0,0 +1,109 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/aws/amazon-cloudwatch-agent/profiler"
+)
+
+const (
+	// Actions for log events above the rate limit
+	rateLimitActionDrop   = "drop"
+	rateLimitActionBuffer = "buffer"
+)
+
+// RateLimit caps the rate at which the events of a file config are published.
+type RateLimit struct {
+	//The number of events that are published per second. Zero is unlimited.
+	EventsPerSecond float64 `toml:"events_per_second"`
+	//The number of bytes of events that are published per second. Zero is unlimited.
+	BytesPerSecond int `toml:"bytes_per_second"`
+	//Indicate whether events above the rate limit are dropped, or buffered by pausing the tailing of the files.
+	Action string `toml:"action"`
+}
+
+// rateLimiter shapes the events of all the files of a file config with a token bucket per limit, so that a file config
+// that logs too much does not starve the others. The buckets hold a second of tokens.
+type rateLimiter struct {
+	events *rate.Limiter
+	bytes  *rate.Limiter
+	buffer bool
+}
+
+func newRateLimiter(cfg *RateLimit) (*rateLimiter, error) {
+	if cfg.EventsPerSecond < 0 || math.IsNaN(cfg.EventsPerSecond) || math.IsInf(cfg.EventsPerSecond, 0) {
+		return nil, fmt.Errorf("rate_limit events_per_second %v must be a positive number", cfg.EventsPerSecond)
+	}
+	if cfg.BytesPerSecond < 0 {
+		return nil, fmt.Errorf("rate_limit bytes_per_second %v must be a positive number", cfg.BytesPerSecond)
+	}
+	if cfg.EventsPerSecond == 0 && cfg.BytesPerSecond == 0 {
+		return nil, fmt.Errorf("rate_limit requires events_per_second or bytes_per_second")
+	}
+	l := &rateLimiter{}
+	switch cfg.Action {
+	case "", rateLimitActionDrop:
+	case rateLimitActionBuffer:
+		l.buffer = true
+	default:
+		return nil, fmt.Errorf("rate_limit action %q is not supported, use %q or %q", cfg.Action, rateLimitActionDrop, rateLimitActionBuffer)
+	}
+	if cfg.EventsPerSecond > 0 {
+		l.events = rate.NewLimiter(rate.Limit(cfg.EventsPerSecond), int(math.Max(1, math.Ceil(cfg.EventsPerSecond))))
+	}
+	if cfg.BytesPerSecond > 0 {
+		l.bytes = rate.NewLimiter(rate.Limit(cfg.BytesPerSecond), cfg.BytesPerSecond)
+	}
+	return l, nil
+}
+
+// allow returns whether an event of the given size is published, and counts the dropped events of the log stream.
+// Events larger than the bytes bucket take all of its tokens. When buffering, allow waits for the tokens of the event
+// until done is closed, which only blocks the tailer of the event.
+func (l *rateLimiter) allow(logGroupName, logStreamName string, size int, done <-chan struct{}) bool {
+	ret := l.reserve(size, done)
+	droppedCount := 0
+	if !ret {
+		droppedCount = 1
+	}
+	profiler.Profiler.AddStats([]string{"logfile", logGroupName, logStreamName, "messages", "dropped_by_rate_limit"}, float64(droppedCount))
+	return ret
+}
+
+func (l *rateLimiter) reserve(size int, done <-chan struct{}) bool {
+	now := time.Now()
+	var reservations []*rate.Reservation
+	var delay time.Duration
+	if l.events != nil {
+		reservations = append(reservations, l.events.ReserveN(now, 1))
+	}
+	if l.bytes != nil {
+		reservations = append(reservations, l.bytes.ReserveN(now, min(size, l.bytes.Burst())))
+	}
+	for _, r := range reservations {
+		delay = max(delay, r.DelayFrom(now))
+	}
+	if delay == 0 {
+		return true
+	}
+	if l.buffer {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			return true
+		case <-done:
+		}
+	}
+	for _, r := range reservations {
+		r.CancelAt(now)
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/profiler"
+)
+
+// publishFor publishes events of the given size through the limiter for the duration and returns the number that
+// were allowed.
+func publishFor(t *testing.T, l *rateLimiter, size int, duration time.Duration) int {
+	var allowed int
+	done := make(chan struct{})
+	for deadline := time.Now().Add(duration); time.Now().Before(deadline); {
+		if l.allow(t.Name(), t.Name(), size, done) {
+			allowed++
+		}
+	}
+	return allowed
+}
+
+func TestRateLimiterDropsEventsAboveLimit(t *testing.T) {
+	l, err := newRateLimiter(&RateLimit{EventsPerSecond: 100})
+	require.NoError(t, err)
+	// a second of burst and about 100 events per second after it
+	allowed := publishFor(t, l, 10, 500*time.Millisecond)
+	assert.GreaterOrEqual(t, allowed, 100)
+	assert.LessOrEqual(t, allowed, 160)
+
+	stats := profiler.Profiler.GetStats()
+	statKey := fmt.Sprintf("logfile_%s_%s_messages_dropped_by_rate_limit", t.Name(), t.Name())
+	assert.Greater(t, stats[statKey], float64(0))
+	profiler.Profiler.ReportAndClear()
+}
+
+func TestRateLimiterDropsBytesAboveLimit(t *testing.T) {
+	l, err := newRateLimiter(&RateLimit{BytesPerSecond: 1000, Action: rateLimitActionDrop})
+	require.NoError(t, err)
+	allowed := publishFor(t, l, 100, 500*time.Millisecond)
+	// 1000 bytes of burst and about 1000 bytes per second after it
+	assert.GreaterOrEqual(t, allowed, 10)
+	assert.LessOrEqual(t, allowed, 16)
+
+	// events larger than the bucket take all of its tokens instead of being dropped forever
+	l, err = newRateLimiter(&RateLimit{BytesPerSecond: 1000})
+	require.NoError(t, err)
+	assert.True(t, l.allow(t.Name(), t.Name(), 5000, nil))
+	assert.False(t, l.allow(t.Name(), t.Name(), 1, nil))
+	profiler.Profiler.ReportAndClear()
+}
+
+func TestRateLimiterBuffersEventsAboveLimit(t *testing.T) {
+	l, err := newRateLimiter(&RateLimit{EventsPerSecond: 20, Action: rateLimitActionBuffer})
+	require.NoError(t, err)
+	start := time.Now()
+	for i := 0; i < 30; i++ {
+		assert.True(t, l.allow(t.Name(), t.Name(), 10, nil))
+	}
+	// the 10 events after the burst are shaped to 20 per second
+	assert.GreaterOrEqual(t, time.Since(start), 400*time.Millisecond)
+
+	// stopping the tailer releases the buffered event
+	done := make(chan struct{})
+	close(done)
+	l, err = newRateLimiter(&RateLimit{EventsPerSecond: 0.1, Action: rateLimitActionBuffer})
+	require.NoError(t, err)
+	assert.True(t, l.allow(t.Name(), t.Name(), 10, done))
+	assert.False(t, l.allow(t.Name(), t.Name(), 10, done))
+	profiler.Profiler.ReportAndClear()
+}
+
+func TestRateLimiterDoesNotBlockOtherSources(t *testing.T) {
+	shaped, err := newRateLimiter(&RateLimit{EventsPerSecond: 1, Action: rateLimitActionBuffer})
+	require.NoError(t, err)
+	other, err := newRateLimiter(&RateLimit{EventsPerSecond: 1000})
+	require.NoError(t, err)
+
+	done := make(chan struct{})
+	defer close(done)
+	require.True(t, shaped.allow(t.Name(), "shaped", 10, done))
+	blocked := make(chan bool)
+	go func() {
+		blocked <- shaped.allow(t.Name(), "shaped", 10, done)
+	}()
+
+	// the other source publishes at its own rate while the shaped source waits for a token
+	allowed := publishFor(t, other, 10, 200*time.Millisecond)
+	assert.GreaterOrEqual(t, allowed, 1000)
+	select {
+	case <-blocked:
+		assert.Fail(t, "the shaped source should still be waiting")
+	default:
+	}
+	assert.True(t, <-blocked)
+	profiler.Profiler.ReportAndClear()
+}
+
+func TestNewRateLimiter(t *testing.T) {
+	testCases := map[string]struct {
+		cfg     RateLimit
+		wantErr string
+	}{
+		"WithoutLimit": {
+			cfg:     RateLimit{Action: rateLimitActionDrop},
+			wantErr: "rate_limit requires events_per_second or bytes_per_second",
+		},
+		"WithNegativeEvents": {
+			cfg:     RateLimit{EventsPerSecond: -1},
+			wantErr: "rate_limit events_per_second -1 must be a positive number",
+		},
+		"WithNegativeBytes": {
+			cfg:     RateLimit{BytesPerSecond: -1},
+			wantErr: "rate_limit bytes_per_second -1 must be a positive number",
+		},
+		"WithInvalidAction": {
+			cfg:     RateLimit{EventsPerSecond: 1, Action: "block"},
+			wantErr: `rate_limit action "block" is not supported, use "drop" or "buffer"`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := newRateLimiter(&testCase.cfg)
+			assert.EqualError(t, err, testCase.wantErr)
+		})
+	}
+}
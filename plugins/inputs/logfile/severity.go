@@ -0,0 +1,121 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/aws/amazon-cloudwatch-agent/profiler"
+)
+
+// defaultSeverityPattern matches the first level name in a text log event.
+const defaultSeverityPattern = `(?i)\b(emerg|emergency|alert|crit|critical|fatal|panic|err|error|warn|warning|notice|info|debug|trace)\b`
+
+// severities are the levels that are recognized in log events, with the value of the syslog severity. A lower value is
+// more severe. trace is below the syslog levels.
+var severities = map[string]int{
+	"emerg":     0,
+	"emergency": 0,
+	"alert":     1,
+	"crit":      2,
+	"critical":  2,
+	"fatal":     2,
+	"panic":     2,
+	"err":       3,
+	"error":     3,
+	"warn":      4,
+	"warning":   4,
+	"notice":    5,
+	"info":      6,
+	"debug":     7,
+	"trace":     8,
+}
+
+// severityFilter drops the log events whose severity is below the minimum. The severity of an event is read from the
+// first of the fields that is found when the event is a JSON object, otherwise it is the first submatch of the
+// pattern, or the whole match if the pattern has no groups. Events without a recognized severity are published.
+type severityFilter struct {
+	min     int
+	fields  []string
+	pattern *regexp.Regexp
+}
+
+func newSeverityFilter(minSeverity string, fields []string, pattern string) (*severityFilter, error) {
+	min, ok := parseSeverity(minSeverity)
+	if !ok {
+		return nil, fmt.Errorf("min_severity %q is not a level name or a syslog severity from 0 to 7", minSeverity)
+	}
+	for _, field := range fields {
+		if field == "" {
+			return nil, errors.New("severity_fields cannot contain an empty field")
+		}
+	}
+	if pattern == "" {
+		pattern = defaultSeverityPattern
+	}
+	p, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("severity_pattern has issue, regexp: Compile( %v ): %v", pattern, err.Error())
+	}
+	return &severityFilter{min: min, fields: fields, pattern: p}, nil
+}
+
+// shouldPublish returns false if the message is below the minimum severity, and counts the dropped messages of the
+// log stream.
+func (f *severityFilter) shouldPublish(logGroupName, logStreamName, msg string) bool {
+	ret := true
+	if severity, ok := f.severity(msg); ok {
+		ret = severity <= f.min
+	}
+	droppedCount := 0
+	if !ret {
+		droppedCount = 1
+	}
+	profiler.Profiler.AddStats([]string{"logfile", logGroupName, logStreamName, "messages", "dropped_by_severity"}, float64(droppedCount))
+	return ret
+}
+
+func (f *severityFilter) severity(msg string) (int, bool) {
+	if len(f.fields) > 0 {
+		if trimmed := strings.TrimSpace(msg); strings.HasPrefix(trimmed, "{") {
+			var fields map[string]interface{}
+			if err := json.Unmarshal([]byte(trimmed), &fields); err == nil {
+				for _, path := range f.fields {
+					switch value := lookupField(fields, path).(type) {
+					case string:
+						return parseSeverity(value)
+					case float64:
+						return parseSeverity(strconv.FormatFloat(value, 'f', -1, 64))
+					}
+				}
+				return 0, false
+			}
+		}
+	}
+	match := f.pattern.FindStringSubmatch(msg)
+	if len(match) == 0 {
+		return 0, false
+	}
+	if len(match) > 1 {
+		return parseSeverity(match[1])
+	}
+	return parseSeverity(match[0])
+}
+
+// parseSeverity returns the syslog severity of a level name, case-insensitive, or of a syslog severity number.
+func parseSeverity(level string) (int, bool) {
+	level = strings.ToLower(strings.TrimSpace(level))
+	if severity, ok := severities[level]; ok {
+		return severity, true
+	}
+	if severity, err := strconv.Atoi(level); err == nil && severity >= 0 && severity <= 7 {
+		return severity, true
+	}
+	return 0, false
+}
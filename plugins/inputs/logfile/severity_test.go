@@ -0,0 +1,99 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/profiler"
+)
+
+func TestSeverityFilter(t *testing.T) {
+	testCases := map[string]struct {
+		fields  []string
+		pattern string
+		kept    []string
+		dropped []string
+	}{
+		"WithDefaultPattern": {
+			kept: []string{
+				"2024-01-01T00:00:00Z WARN disk is almost full",
+				"2024-01-01T00:00:00Z [error] request failed",
+				"2024-01-01T00:00:00Z CRITICAL out of memory",
+				"2024-01-01T00:00:00Z no level in this event",
+			},
+			dropped: []string{
+				"2024-01-01T00:00:00Z INFO request served",
+				"2024-01-01T00:00:00Z [debug] cache miss",
+				"2024-01-01T00:00:00Z Trace entering handler",
+			},
+		},
+		"WithPattern": {
+			pattern: `<(\d)>`,
+			kept: []string{
+				"<4>Jan  1 00:00:00 host app: disk is almost full",
+				"<3>Jan  1 00:00:00 host app: request failed",
+			},
+			dropped: []string{
+				"<6>Jan  1 00:00:00 host app: request served",
+				"<7>Jan  1 00:00:00 host app: error in debug output",
+			},
+		},
+		"WithFields": {
+			fields: []string{"level", "log.severity"},
+			kept: []string{
+				`{"level":"WARN","message":"disk is almost full"}`,
+				`{"log":{"severity":"error"},"message":"request failed"}`,
+				`{"level":3,"message":"request failed"}`,
+				`{"message":"no level in this event"}`,
+				"ERROR a text event",
+			},
+			dropped: []string{
+				`{"level":"INFO","message":"request failed with error"}`,
+				`{"log":{"severity":"debug"},"message":"cache miss"}`,
+				`{"level":7,"message":"cache miss"}`,
+				"INFO a text event",
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			f, err := newSeverityFilter("WARN", testCase.fields, testCase.pattern)
+			require.NoError(t, err)
+			for _, msg := range testCase.kept {
+				assert.True(t, f.shouldPublish(t.Name(), t.Name(), msg), msg)
+			}
+			for _, msg := range testCase.dropped {
+				assert.False(t, f.shouldPublish(t.Name(), t.Name(), msg), msg)
+			}
+			stats := profiler.Profiler.GetStats()
+			statKey := fmt.Sprintf("logfile_%s_%s_messages_dropped_by_severity", t.Name(), t.Name())
+			assert.EqualValues(t, len(testCase.dropped), stats[statKey])
+			profiler.Profiler.ReportAndClear()
+		})
+	}
+}
+
+func TestSeverityFilterMinSeverity(t *testing.T) {
+	for _, minSeverity := range []string{"warn", "Warning", "4"} {
+		f, err := newSeverityFilter(minSeverity, nil, "")
+		require.NoError(t, err)
+		assert.True(t, f.shouldPublish(t.Name(), t.Name(), "WARN disk is almost full"), minSeverity)
+		assert.False(t, f.shouldPublish(t.Name(), t.Name(), "NOTICE service started"), minSeverity)
+	}
+	profiler.Profiler.ReportAndClear()
+
+	for _, minSeverity := range []string{"verbose", "8", "-1"} {
+		_, err := newSeverityFilter(minSeverity, nil, "")
+		assert.Error(t, err, minSeverity)
+	}
+	_, err := newSeverityFilter("WARN", nil, "(")
+	assert.Error(t, err)
+	_, err = newSeverityFilter("WARN", []string{""}, "")
+	assert.Error(t, err)
+}
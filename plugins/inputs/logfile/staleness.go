@@ -0,0 +1,55 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// tailing holds the sources that are reading a file, so the time since their last line can be reported by the
+// metrics inputs, which do not share the lifecycle of the logs agent.
+var tailing = struct {
+	sync.Mutex
+	srcs map[*tailerSrc]struct{}
+}{srcs: map[*tailerSrc]struct{}{}}
+
+// trackStaleness starts reporting the staleness of the file of the source. The staleness of a file is counted from
+// its last modification until the first line is read, so a file that is already silent when the agent starts is not
+// reported as fresh.
+func trackStaleness(ts *tailerSrc) {
+	last := time.Now()
+	if info, err := os.Stat(ts.tailer.Filename); err == nil {
+		last = info.ModTime()
+	}
+	ts.lastLine.Store(last.UnixNano())
+	tailing.Lock()
+	defer tailing.Unlock()
+	tailing.srcs[ts] = struct{}{}
+}
+
+func untrackStaleness(ts *tailerSrc) {
+	tailing.Lock()
+	defer tailing.Unlock()
+	delete(tailing.srcs, ts)
+}
+
+// Staleness returns the time since the last line of each tailed file at the given time. A file tailed by multiple
+// sources reports the most recent line of any of them.
+func Staleness(now time.Time) map[string]time.Duration {
+	tailing.Lock()
+	defer tailing.Unlock()
+	result := make(map[string]time.Duration, len(tailing.srcs))
+	for ts := range tailing.srcs {
+		age := now.Sub(time.Unix(0, ts.lastLine.Load()))
+		if age < 0 {
+			age = 0
+		}
+		if prev, ok := result[ts.tailer.Filename]; !ok || age < prev {
+			result[ts.tailer.Filename] = age
+		}
+	}
+	return result
+}
@@ -0,0 +1,118 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/inputs/logfile/tail"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
+
+func TestTailerSrcStaleness(t *testing.T) {
+	file, err := createTempFile("", "tailsrctest-*.log")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	statefile, err := os.CreateTemp("", "tailsrctest-state-*.log")
+	require.NoError(t, err)
+	defer os.Remove(statefile.Name())
+
+	// the file was last written an hour ago
+	modified := time.Now().Add(-time.Hour)
+	require.NoError(t, os.Chtimes(file.Name(), modified, modified))
+
+	tailer, err := tail.TailFile(file.Name(),
+		tail.Config{
+			ReOpen:      false,
+			Follow:      true,
+			Location:    &tail.SeekInfo{Whence: io.SeekStart, Offset: 0},
+			MustExist:   true,
+			Poll:        true,
+			MaxLineSize: defaultMaxEventSize,
+		})
+	require.NoError(t, err)
+	ts := NewTailerSrc(
+		"groupName", "streamName",
+		"destination",
+		statefile.Name(),
+		util.InfrequentAccessLogGroupClass,
+		"tailsrctest-*.log",
+		tailer,
+		false, // AutoRemoval
+		nil,
+		nil,
+		nil, // severity
+		nil, // rate limit
+		0,   // sample first n
+		func(string) time.Time { return time.Time{} },
+		nil, // encoding
+		defaultMaxEventSize,
+		defaultTruncateSuffix,
+		"", // oversizeAction
+		"", // logFormat
+		"", // eventPrefix
+		"", // eventSuffix
+		1,
+	)
+
+	done := make(chan struct{})
+	events := make(chan string, 10)
+	ts.SetOutput(func(evt logs.LogEvent) {
+		if evt == nil {
+			close(done)
+			return
+		}
+		events <- evt.Message()
+		evt.Done()
+	})
+
+	// the staleness starts from the last modification of the file
+	require.Eventually(t, func() bool {
+		_, ok := Staleness(time.Now())[file.Name()]
+		return ok
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.InDelta(t, time.Hour.Seconds(), Staleness(time.Now())[file.Name()].Seconds(), 5)
+
+	writeLine := func(msg string) time.Time {
+		before := time.Now()
+		_, err := fmt.Fprintln(file, msg)
+		require.NoError(t, err)
+		select {
+		case got := <-events:
+			require.Equal(t, msg, got)
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "line not published")
+		}
+		return before
+	}
+
+	written := writeLine("first")
+	now := time.Now()
+	staleness := Staleness(now)[file.Name()]
+	assert.LessOrEqual(t, staleness, now.Sub(written))
+
+	// the staleness grows while no lines are written
+	for _, elapsed := range []time.Duration{time.Minute, 10 * time.Minute, time.Hour} {
+		assert.Equal(t, staleness+elapsed, Staleness(now.Add(elapsed))[file.Name()])
+	}
+
+	// and resets when a line is written
+	written = writeLine("second")
+	now = time.Now()
+	assert.LessOrEqual(t, Staleness(now)[file.Name()], now.Sub(written))
+	assert.Equal(t, time.Duration(0), Staleness(written.Add(-time.Minute))[file.Name()])
+
+	// the file is no longer reported once it is no longer tailed
+	require.NoError(t, os.Remove(file.Name()))
+	<-done
+	assert.NotContains(t, Staleness(time.Now()), file.Name())
+}
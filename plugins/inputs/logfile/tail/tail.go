@@ -33,11 +33,14 @@ type Line struct {
 	Time   time.Time
 	Err    error // Error from tail
 	Offset int64 // offset of current reader
+	// Partial is set when the line was longer than the read buffer and the
+	// rest of it follows in the next Line.
+	Partial bool
 }
 
 // NewLine returns a Line with present time.
 func NewLine(text string, offset int64) *Line {
-	return &Line{text, time.Now(), nil, offset}
+	return &Line{text, time.Now(), nil, offset, false}
 }
 
 // SeekInfo represents arguments to `os.Seek`
@@ -217,8 +220,9 @@ func (tail *Tail) reopen() error {
 // If the line is too long for the buffer then partial line will be returned.
 // The rest of the line will be returned from future calls. If error is encountered
 // before finding the end-of-line bytes(often io.EOF), it returns the data read
-// before the error and the error itself.
-func (tail *Tail) readLine() (string, error) {
+// before the error and the error itself. The returned bool reports whether
+// only part of the line was returned.
+func (tail *Tail) readLine() (string, bool, error) {
 	if tail.Config.IsUTF16 {
 		return tail.readlineUtf16()
 	}
@@ -232,7 +236,7 @@ func (tail *Tail) readLine() (string, error) {
 			tail.unreadByte()
 			line = line[:len(line)-1]
 		}
-		return string(line), nil
+		return string(line), true, nil
 	}
 
 	if len(line) > 0 && line[len(line)-1] == '\n' {
@@ -242,10 +246,10 @@ func (tail *Tail) readLine() (string, error) {
 		}
 		line = line[:len(line)-drop]
 	}
-	return string(line), err
+	return string(line), false, err
 }
 
-func (tail *Tail) readlineUtf16() (string, error) {
+func (tail *Tail) readlineUtf16() (string, bool, error) {
 	tail.lk.Lock()
 	defer tail.lk.Unlock()
 
@@ -253,6 +257,7 @@ func (tail *Tail) readlineUtf16() (string, error) {
 	var err error
 	var res [][]byte
 	var resSize int
+	var partial bool
 
 	for {
 		// Check LF
@@ -266,6 +271,7 @@ func (tail *Tail) readlineUtf16() (string, error) {
 				cur = cur[:len(cur)-2]
 			}
 			err = nil
+			partial = true
 			break
 		}
 		if err != nil {
@@ -291,6 +297,7 @@ func (tail *Tail) readlineUtf16() (string, error) {
 		}
 		// 262144 => 256KB
 		if resSize+len(cur) >= 262144 {
+			partial = true
 			break
 		}
 		buf := make([]byte, len(cur))
@@ -308,7 +315,7 @@ func (tail *Tail) readlineUtf16() (string, error) {
 		n += copy(finalRes[n:], res[i])
 	}
 
-	return string(finalRes), err
+	return string(finalRes), partial, err
 }
 
 func (tail *Tail) tailFileSync() {
@@ -350,16 +357,16 @@ func (tail *Tail) tailFileSync() {
 		if !tail.Pipe {
 			backupOffset = tail.curOffset
 		}
-		line, err := tail.readLine()
+		line, partial, err := tail.readLine()
 
 		// Process `line` even if err is EOF.
 		if err == nil {
-			cooloff := !tail.sendLine(line, tail.curOffset)
+			cooloff := !tail.sendLine(line, partial, tail.curOffset)
 			if cooloff {
 				// Wait a second before seeking till the end of
 				// file when rate limit is reached.
 				msg := "Too much log activity; waiting a second before resuming tailing"
-				tail.Lines <- &Line{msg, time.Now(), errors.New(msg), tail.curOffset}
+				tail.Lines <- &Line{msg, time.Now(), errors.New(msg), tail.curOffset, false}
 				select {
 				case <-time.After(time.Second):
 				case <-tail.Dying():
@@ -373,7 +380,7 @@ func (tail *Tail) tailFileSync() {
 		} else if err == io.EOF {
 			if !tail.Follow {
 				if line != "" {
-					tail.sendLine(line, tail.curOffset)
+					tail.sendLine(line, false, tail.curOffset)
 				}
 				return
 			}
@@ -396,9 +403,9 @@ func (tail *Tail) tailFileSync() {
 				if err == ErrDeletedNotReOpen {
 					close(tail.FileDeletedCh)
 					for {
-						line, errReadLine := tail.readLine()
+						line, partial, errReadLine := tail.readLine()
 						if errReadLine == nil {
-							tail.sendLine(line, tail.curOffset)
+							tail.sendLine(line, partial, tail.curOffset)
 						} else {
 							return
 						}
@@ -504,8 +511,9 @@ func (tail *Tail) seekTo(pos SeekInfo) error {
 }
 
 // sendLine sends the line(s) to Lines channel, splitting longer lines
-// if necessary. Return false if rate limit is reached.
-func (tail *Tail) sendLine(line string, offset int64) bool {
+// if necessary. All but the last of the split lines are marked partial.
+// Return false if rate limit is reached.
+func (tail *Tail) sendLine(line string, partial bool, offset int64) bool {
 	now := time.Now()
 	lines := []string{line}
 
@@ -515,13 +523,14 @@ func (tail *Tail) sendLine(line string, offset int64) bool {
 	}
 
 	for i, line := range lines {
+		l := &Line{line, now, nil, offset, partial || i < len(lines)-1}
 		// This select is to avoid blockage on the tail.Lines chan
 		select {
-		case tail.Lines <- &Line{line, now, nil, offset}:
+		case tail.Lines <- l:
 		case <-tail.Dying():
 			if tail.Err() == errStopAtEOF {
 				// Try sending, even if it blocks.
-				tail.Lines <- &Line{line, now, nil, offset}
+				tail.Lines <- l
 			} else {
 				tail.dropCnt += len(lines) - i
 				return true
@@ -5,11 +5,14 @@ package logfile
 
 import (
 	"bytes"
+	"fmt"
 	"log"
 	"os"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
+	"unicode/utf8"
 
 	"golang.org/x/text/encoding"
 
@@ -22,6 +25,17 @@ import (
 const (
 	stateFileMode = 0644
 	bufferLimit   = 50
+
+	// CloudWatch Logs counts the UTF-8 bytes of the message plus a per event
+	// overhead against the 256KB event limit. Reserve the same overhead as the
+	// cloudwatchlogs output so published events are never truncated there.
+	eventOverheadBytes     = 200
+	maxCloudWatchEventSize = 256*1024 - eventOverheadBytes
+
+	// maxSplitEventParts caps the number of events a single oversized log
+	// message is split into, anything beyond that is truncated.
+	maxSplitEventParts = 16
+	splitPartMarker    = "[Part %d/%d]"
 )
 
 var (
@@ -71,15 +85,29 @@ type tailerSrc struct {
 	enc             encoding.Encoding
 	maxEventSize    int
 	truncateSuffix  string
+	oversizeAction  string
+	eventPrefix     string
+	eventSuffix     string
 	retentionInDays int
+	// jsonArray is set when the file is a JSON array whose elements are published as events.
+	jsonArray *jsonArrayDecoder
 
 	outputFn        func(logs.LogEvent)
 	isMLStart       func(string) bool
 	filters         []*LogFilter
+	severity        *severityFilter
+	rateLimiter     *rateLimiter
 	offsetCh        chan fileOffset
 	done            chan struct{}
 	startTailerOnce sync.Once
 	cleanUpFns      []func()
+
+	// sampleFirstN is the number of events published before the file is no longer read, when it is sampled.
+	sampleFirstN int
+	published    int
+
+	// lastLine is the time in unix nanoseconds the last line was read from the file.
+	lastLine atomic.Int64
 }
 
 // Verify tailerSrc implements LogSrc
@@ -91,10 +119,16 @@ func NewTailerSrc(
 	autoRemoval bool,
 	isMultilineStartFn func(string) bool,
 	filters []*LogFilter,
+	severity *severityFilter,
+	rateLimiter *rateLimiter,
+	sampleFirstN int,
 	timestampFn func(string) time.Time,
 	enc encoding.Encoding,
 	maxEventSize int,
 	truncateSuffix string,
+	oversizeAction string,
+	logFormat string,
+	eventPrefix, eventSuffix string,
 	retentionInDays int,
 ) *tailerSrc {
 	ts := &tailerSrc{
@@ -108,15 +142,24 @@ func NewTailerSrc(
 		autoRemoval:     autoRemoval,
 		isMLStart:       isMultilineStartFn,
 		filters:         filters,
+		severity:        severity,
+		rateLimiter:     rateLimiter,
+		sampleFirstN:    sampleFirstN,
 		timestampFn:     timestampFn,
 		enc:             enc,
 		maxEventSize:    maxEventSize,
 		truncateSuffix:  truncateSuffix,
+		oversizeAction:  oversizeAction,
+		eventPrefix:     eventPrefix,
+		eventSuffix:     eventSuffix,
 		retentionInDays: retentionInDays,
 
 		offsetCh: make(chan fileOffset, 2000),
 		done:     make(chan struct{}),
 	}
+	if logFormat == logFormatJSONArray {
+		ts.jsonArray = newJSONArrayDecoder(ts.bufferLimit())
+	}
 	go ts.runSaveState()
 	return ts
 }
@@ -180,6 +223,8 @@ func (ts *tailerSrc) Entity() *cloudwatchlogs.Entity {
 
 func (ts *tailerSrc) runTail() {
 	defer ts.cleanUp()
+	trackStaleness(ts)
+	defer untrackStaleness(ts)
 	t := time.NewTicker(multilineWaitPeriod)
 	defer t.Stop()
 	var init string
@@ -188,23 +233,30 @@ func (ts *tailerSrc) runTail() {
 	fo := &fileOffset{}
 
 	ignoreUntilNextEvent := false
+	// partial is set while the rest of a line longer than the tailer buffer is still to come
+	partial := false
 	for {
+		if ts.sampleComplete() {
+			log.Printf("I! [logfile] Published the first %d events of file %s, stopped reading it\n", ts.sampleFirstN, ts.tailer.Filename)
+			// The lines still sent by the tailer are drained until it closes them, so it cannot block on stopping.
+			go func() {
+				for range ts.tailer.Lines {
+				}
+			}()
+			if err := ts.tailer.Stop(); err != nil {
+				log.Printf("W! [logfile] Error stopping the tailer of file %s: %v\n", ts.tailer.Filename, err)
+			}
+			return
+		}
 
 		select {
 		case line, ok := <-ts.tailer.Lines:
 			if !ok {
 				if msgBuf.Len() > 0 {
-					msg := msgBuf.String()
-					e := &LogEvent{
-						msg:    msg,
-						t:      ts.timestampFn(msg),
-						offset: *fo,
-						src:    ts,
-					}
-
-					if ShouldPublish(ts.group, ts.stream, ts.filters, e) {
-						ts.outputFn(e)
-					}
+					ts.publish(msgBuf.String(), *fo)
+				}
+				if ts.jsonArray != nil && ts.jsonArray.pending() {
+					log.Printf("W! [logfile] Dropped incomplete JSON array element at the end of file %s\n", ts.tailer.Filename)
 				}
 				return
 			}
@@ -213,6 +265,7 @@ func (ts *tailerSrc) runTail() {
 				log.Printf("E! [logfile] Error tailing line in file %s, Error: %s\n", ts.tailer.Filename, line.Err)
 				continue
 			}
+			ts.lastLine.Store(line.Time.UnixNano())
 
 			text := line.Text
 			if ts.enc != nil {
@@ -224,22 +277,47 @@ func (ts *tailerSrc) runTail() {
 				}
 			}
 
+			if ts.jsonArray != nil {
+				ts.publishJSONArray(text, line, fo)
+				continue
+			}
+
+			continued := partial
+			partial = ts.oversizeAction != "" && line.Partial
+			if continued {
+				// The remainder of an oversized line belongs to the same event.
+				if !ignoreUntilNextEvent && msgBuf.Len() < ts.bufferLimit() {
+					msgBuf.WriteString(text)
+				}
+				fo.SetOffset(line.Offset)
+				if partial || ts.isMLStart != nil {
+					continue
+				}
+				ts.publish(msgBuf.String(), *fo)
+				msgBuf.Reset()
+				cnt = 0
+				continue
+			}
+
 			if ts.isMLStart == nil {
 				msgBuf.Reset()
 				msgBuf.WriteString(text)
 				fo.SetOffset(line.Offset)
 				init = ""
+				if partial {
+					continue
+				}
 			} else if ts.isMLStart(text) || (!ignoreUntilNextEvent && msgBuf.Len() == 0) {
 				init = text
 				ignoreUntilNextEvent = false
-			} else if ignoreUntilNextEvent || msgBuf.Len() >= ts.maxEventSize {
+			} else if ignoreUntilNextEvent || msgBuf.Len() >= ts.bufferLimit() {
 				ignoreUntilNextEvent = true
 				fo.SetOffset(line.Offset)
 				continue
 			} else {
 				msgBuf.WriteString("\n")
 				msgBuf.WriteString(text)
-				if msgBuf.Len() > ts.maxEventSize {
+				if ts.oversizeAction == "" && msgBuf.Len() > ts.maxEventSize {
 					msgBuf.Truncate(ts.maxEventSize - len(ts.truncateSuffix))
 					msgBuf.WriteString(ts.truncateSuffix)
 				}
@@ -248,18 +326,7 @@ func (ts *tailerSrc) runTail() {
 			}
 
 			if msgBuf.Len() > 0 {
-				msg := msgBuf.String()
-				e := &LogEvent{
-					msg:    msg,
-					t:      ts.timestampFn(msg),
-					offset: *fo,
-					src:    ts,
-				}
-				// Note: This only checks against the truncated log message, so it is not necessary to load
-				//       the entire log message for filtering.
-				if ShouldPublish(ts.group, ts.stream, ts.filters, e) {
-					ts.outputFn(e)
-				}
+				ts.publish(msgBuf.String(), *fo)
 			}
 
 			msgBuf.Reset()
@@ -275,16 +342,7 @@ func (ts *tailerSrc) runTail() {
 				continue
 			}
 
-			msg := msgBuf.String()
-			e := &LogEvent{
-				msg:    msg,
-				t:      ts.timestampFn(msg),
-				offset: *fo,
-				src:    ts,
-			}
-			if ShouldPublish(ts.group, ts.stream, ts.filters, e) {
-				ts.outputFn(e)
-			}
+			ts.publish(msgBuf.String(), *fo)
 			msgBuf.Reset()
 			cnt = 0
 		case <-ts.done:
@@ -293,8 +351,140 @@ func (ts *tailerSrc) runTail() {
 	}
 }
 
+// publishJSONArray publishes the elements of the JSON array that the line completes. The offset of the events is the
+// end of the last line that did not leave part of an element, so that a restart never resumes in the middle of an
+// element. The elements completed before a partial element can then be sent again after a restart.
+func (ts *tailerSrc) publishJSONArray(text string, line *tail.Line, fo *fileOffset) {
+	if !line.Partial {
+		text += "\n"
+	}
+	elements, errs := ts.jsonArray.decode(text)
+	for _, err := range errs {
+		log.Printf("W! [logfile] %v in file %s\n", err, ts.tailer.Filename)
+	}
+	if !ts.jsonArray.pending() {
+		fo.SetOffset(line.Offset)
+	}
+	for _, element := range elements {
+		ts.publish(element, *fo)
+	}
+}
+
+// publish sends the message to the output if it passes the filters. The event
+// prefix and suffix count against the event size limit. Depending on the
+// oversize action, messages larger than a single event are truncated or split
+// into multiple events that share the timestamp and offset.
+func (ts *tailerSrc) publish(msg string, offset fileOffset) {
+	if ts.sampleComplete() {
+		return
+	}
+	e := &LogEvent{
+		msg:    msg,
+		t:      ts.timestampFn(msg),
+		offset: offset,
+		src:    ts,
+	}
+	// Note: This only checks against the truncated log message, so it is not necessary to load
+	//       the entire log message for filtering.
+	if !ShouldPublish(ts.group, ts.stream, ts.filters, e) {
+		return
+	}
+	if ts.severity != nil && !ts.severity.shouldPublish(ts.group, ts.stream, msg) {
+		return
+	}
+	if ts.rateLimiter != nil && !ts.rateLimiter.allow(ts.group, ts.stream, len(msg), ts.done) {
+		return
+	}
+	if ts.sampleFirstN > 0 {
+		ts.published++
+	}
+	decorated := ts.eventPrefix != "" || ts.eventSuffix != ""
+	limit := ts.eventSizeLimit() - len(ts.eventPrefix) - len(ts.eventSuffix)
+	switch {
+	case len(msg) <= limit || (ts.oversizeAction == "" && !decorated):
+	case ts.oversizeAction == oversizeEventActionSplit:
+		for _, part := range splitEvent(msg, limit, ts.truncateSuffix) {
+			ts.outputFn(&LogEvent{
+				msg:    ts.eventPrefix + part + ts.eventSuffix,
+				t:      e.t,
+				offset: offset,
+				src:    ts,
+			})
+		}
+		return
+	default:
+		msg = truncateUTF8(msg, limit-len(ts.truncateSuffix)) + ts.truncateSuffix
+	}
+	e.msg = ts.eventPrefix + msg + ts.eventSuffix
+	ts.outputFn(e)
+}
+
+// eventSizeLimit is the largest message in bytes that is published as a single event.
+func (ts *tailerSrc) eventSizeLimit() int {
+	return min(ts.maxEventSize, maxCloudWatchEventSize)
+}
+
+// bufferLimit is the size after which more lines are no longer added to the
+// message being built. Split messages can grow up to maxSplitEventParts events.
+func (ts *tailerSrc) bufferLimit() int {
+	switch ts.oversizeAction {
+	case oversizeEventActionSplit:
+		return maxSplitEventParts * ts.eventSizeLimit()
+	case oversizeEventActionTruncate:
+		return ts.eventSizeLimit()
+	default:
+		return ts.maxEventSize
+	}
+}
+
+// splitEvent splits the message into parts of at most limit bytes, each ending
+// with a part marker. Parts are only cut on UTF-8 character boundaries. If more
+// than maxSplitEventParts parts are needed, the last part is truncated.
+func splitEvent(msg string, limit int, truncateSuffix string) []string {
+	size := limit - len(fmt.Sprintf(splitPartMarker, maxSplitEventParts, maxSplitEventParts))
+	var parts []string
+	for len(msg) > 0 && len(parts) < maxSplitEventParts {
+		part := truncateUTF8(msg, size)
+		if part == "" {
+			break
+		}
+		if len(parts) == maxSplitEventParts-1 && len(part) < len(msg) {
+			part = truncateUTF8(msg, size-len(truncateSuffix)) + truncateSuffix
+			msg = ""
+		} else {
+			msg = msg[len(part):]
+		}
+		parts = append(parts, part)
+	}
+	for i := range parts {
+		parts[i] += fmt.Sprintf(splitPartMarker, i+1, len(parts))
+	}
+	return parts
+}
+
+// truncateUTF8 returns the longest prefix of s that is at most size bytes and
+// does not end in the middle of a UTF-8 encoded character.
+func truncateUTF8(s string, size int) string {
+	if len(s) <= size {
+		return s
+	}
+	if size <= 0 {
+		return ""
+	}
+	for size > 0 && !utf8.RuneStart(s[size]) {
+		size--
+	}
+	return s[:size]
+}
+
+// sampleComplete returns whether the first events of a sampled file have all been published.
+func (ts *tailerSrc) sampleComplete() bool {
+	return ts.sampleFirstN > 0 && ts.published >= ts.sampleFirstN
+}
+
 func (ts *tailerSrc) cleanUp() {
-	if ts.autoRemoval {
+	// A sampled file has not been read to the end, so it is never removed.
+	if ts.autoRemoval && !ts.sampleComplete() {
 		if err := os.Remove(ts.tailer.Filename); err != nil {
 			log.Printf("W! [logfile] Failed to auto remove file %v: %v", ts.tailer.Filename, err)
 		} else {
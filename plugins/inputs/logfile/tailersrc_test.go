@@ -15,6 +15,7 @@ import (
 	"sync/atomic"
 	"testing"
 	"time"
+	"unicode/utf8"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -67,10 +68,17 @@ func TestTailerSrc(t *testing.T) {
 		false, // AutoRemoval
 		regexp.MustCompile("^[\\S]").MatchString,
 		nil,
+		nil, // severity
+		nil, // rate limit
+		0,   // sample first n
 		parseRFC3339Timestamp,
 		nil, // encoding
 		defaultMaxEventSize,
 		defaultTruncateSuffix,
+		"", // oversizeAction
+		"", // logFormat
+		"", // eventPrefix
+		"", // eventSuffix
 		1,
 	)
 	multilineWaitPeriod = 100 * time.Millisecond
@@ -179,10 +187,17 @@ func TestOffsetDoneCallBack(t *testing.T) {
 		false, // AutoRemoval
 		regexp.MustCompile("^[\\S]").MatchString,
 		nil,
+		nil, // severity
+		nil, // rate limit
+		0,   // sample first n
 		parseRFC3339Timestamp,
 		nil, // encoding
 		defaultMaxEventSize,
 		defaultTruncateSuffix,
+		"", // oversizeAction
+		"", // logFormat
+		"", // eventPrefix
+		"", // eventSuffix
 		1,
 	)
 	multilineWaitPeriod = 100 * time.Millisecond
@@ -324,6 +339,227 @@ func TestTailerSrcFiltersMultiLineLogs(t *testing.T) {
 	assertExpectedLogsPublished(t, n, int(*resources.consumed))
 }
 
+func TestTailerSrcOversizeEventAction(t *testing.T) {
+	// 300KB single line with multi-byte characters
+	line := time.Now().Format(time.RFC3339) + " " + strings.Repeat("aé", 300*1024/3)
+	limit := maxCloudWatchEventSize
+	testCases := map[string]struct {
+		action string
+		check  func(t *testing.T, msgs []string)
+	}{
+		"Split": {
+			action: oversizeEventActionSplit,
+			check: func(t *testing.T, msgs []string) {
+				require.Len(t, msgs, 3)
+				var joined strings.Builder
+				for i, msg := range msgs[:2] {
+					assert.LessOrEqual(t, len(msg), limit)
+					marker := fmt.Sprintf(splitPartMarker, i+1, 2)
+					require.True(t, strings.HasSuffix(msg, marker), "missing part marker on event %d", i)
+					part := strings.TrimSuffix(msg, marker)
+					assert.True(t, utf8.ValidString(part))
+					joined.WriteString(part)
+				}
+				assert.Equal(t, line, joined.String())
+				assert.Equal(t, "next", msgs[2])
+			},
+		},
+		"Truncate": {
+			action: oversizeEventActionTruncate,
+			check: func(t *testing.T, msgs []string) {
+				require.Len(t, msgs, 2)
+				msg := msgs[0]
+				assert.LessOrEqual(t, len(msg), limit)
+				require.True(t, strings.HasSuffix(msg, defaultTruncateSuffix))
+				msg = strings.TrimSuffix(msg, defaultTruncateSuffix)
+				assert.True(t, utf8.ValidString(msg))
+				assert.True(t, strings.HasPrefix(line, msg))
+				assert.Equal(t, "next", msgs[1])
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			file, err := createTempFile("", "tailsrctest-*.log")
+			require.NoError(t, err)
+			defer os.Remove(file.Name())
+			statefile, err := os.CreateTemp("", "tailsrctest-state-*.log")
+			require.NoError(t, err)
+			defer os.Remove(statefile.Name())
+
+			tailer, err := tail.TailFile(file.Name(),
+				tail.Config{
+					ReOpen:      false,
+					Follow:      true,
+					Location:    &tail.SeekInfo{Whence: io.SeekStart, Offset: 0},
+					MustExist:   true,
+					Poll:        true,
+					MaxLineSize: defaultMaxEventSize,
+				})
+			require.NoError(t, err)
+			ts := NewTailerSrc(
+				"groupName", "streamName",
+				"destination",
+				statefile.Name(),
+				util.InfrequentAccessLogGroupClass,
+				"tailsrctest-*.log",
+				tailer,
+				false, // AutoRemoval
+				regexp.MustCompile("^[\\S]").MatchString,
+				nil,
+				nil, // severity
+				nil, // rate limit
+				0,   // sample first n
+				parseRFC3339Timestamp,
+				nil, // encoding
+				defaultMaxEventSize,
+				defaultTruncateSuffix,
+				testCase.action,
+				"", // logFormat
+				"", // eventPrefix
+				"", // eventSuffix
+				1,
+			)
+
+			done := make(chan struct{})
+			var msgs []string
+			ts.SetOutput(func(evt logs.LogEvent) {
+				if evt == nil {
+					close(done)
+					return
+				}
+				msgs = append(msgs, evt.Message())
+				evt.Done()
+			})
+
+			fmt.Fprintln(file, line)
+			fmt.Fprintln(file, "next")
+			time.Sleep(2 * time.Second)
+			require.NoError(t, os.Remove(file.Name()))
+			<-done
+			testCase.check(t, msgs)
+		})
+	}
+}
+
+func TestTailerSrcJSONArray(t *testing.T) {
+	file, err := createTempFile("", "tailsrctest-*.json")
+	require.NoError(t, err)
+	defer os.Remove(file.Name())
+	statefile, err := os.CreateTemp("", "tailsrctest-state-*.log")
+	require.NoError(t, err)
+	defer os.Remove(statefile.Name())
+
+	tailer, err := tail.TailFile(file.Name(),
+		tail.Config{
+			ReOpen:      false,
+			Follow:      true,
+			Location:    &tail.SeekInfo{Whence: io.SeekStart, Offset: 0},
+			MustExist:   true,
+			Poll:        true,
+			MaxLineSize: defaultMaxEventSize,
+		})
+	require.NoError(t, err)
+	ts := NewTailerSrc(
+		"groupName", "streamName",
+		"destination",
+		statefile.Name(),
+		util.InfrequentAccessLogGroupClass,
+		"tailsrctest-*.json",
+		tailer,
+		false, // AutoRemoval
+		nil,
+		nil,
+		nil, // severity
+		nil, // rate limit
+		0,   // sample first n
+		func(string) time.Time { return time.Time{} },
+		nil, // encoding
+		defaultMaxEventSize,
+		defaultTruncateSuffix,
+		"", // oversizeAction
+		logFormatJSONArray,
+		"", // eventPrefix
+		"", // eventSuffix
+		1,
+	)
+
+	done := make(chan struct{})
+	var msgs []string
+	ts.SetOutput(func(evt logs.LogEvent) {
+		if evt == nil {
+			close(done)
+			return
+		}
+		msgs = append(msgs, evt.Message())
+		evt.Done()
+	})
+
+	// the array grows by appending elements, some of which are written across lines
+	writes := []string{
+		"[\n",
+		`  {"id": 1, "msg": "a"},` + "\n",
+		`  {"id": 2,` + "\n",
+		`   "msg": "b ] }"}, {"id": 3},` + "\n",
+		`  {"id": 4, "msg": [1, 2]` + "\n",
+		"  },\n",
+		`  {"id": 5, "msg": "bad"]},` + "\n",
+		`  "text", 6` + "\n",
+		"]\n",
+	}
+	for _, w := range writes {
+		_, err = file.WriteString(w)
+		require.NoError(t, err)
+		time.Sleep(200 * time.Millisecond)
+	}
+	time.Sleep(time.Second)
+
+	b, err := os.ReadFile(statefile.Name())
+	require.NoError(t, err)
+	offset, err := strconv.Atoi(string(bytes.Split(b, []byte("\n"))[0]))
+	require.NoError(t, err)
+	info, err := file.Stat()
+	require.NoError(t, err)
+	// the closing bracket does not publish an event, so it is read again after a restart
+	assert.EqualValues(t, info.Size()-int64(len("]\n")), offset)
+
+	// an incomplete element at the end of the file is never published
+	_, err = file.WriteString(`[{"id": 7,` + "\n")
+	require.NoError(t, err)
+	time.Sleep(time.Second)
+	require.NoError(t, os.Remove(file.Name()))
+	<-done
+	assert.Equal(t, []string{
+		`{"id":1,"msg":"a"}`,
+		`{"id":2,"msg":"b ] }"}`,
+		`{"id":3}`,
+		`{"id":4,"msg":[1,2]}`,
+		`"text"`,
+		`6`,
+	}, msgs)
+}
+
+func TestSplitEvent(t *testing.T) {
+	parts := splitEvent(strings.Repeat("a", 100), 32, defaultTruncateSuffix)
+	require.Len(t, parts, 5)
+	for i, part := range parts {
+		assert.LessOrEqual(t, len(part), 32)
+		assert.True(t, strings.HasSuffix(part, fmt.Sprintf(splitPartMarker, i+1, 5)))
+	}
+
+	// more than maxSplitEventParts parts are needed
+	parts = splitEvent(strings.Repeat("a", 1000), 32, "...")
+	require.Len(t, parts, maxSplitEventParts)
+	assert.Equal(t, strings.Repeat("a", 17)+"..."+fmt.Sprintf(splitPartMarker, maxSplitEventParts, maxSplitEventParts), parts[maxSplitEventParts-1])
+}
+
+func TestTruncateUTF8(t *testing.T) {
+	assert.Equal(t, "abc", truncateUTF8("abc", 5))
+	assert.Equal(t, "a", truncateUTF8("aé", 2))
+	assert.Equal(t, "aé", truncateUTF8("aé", 3))
+	assert.Equal(t, "", truncateUTF8("é", 1))
+}
+
 func parseRFC3339Timestamp(line string) time.Time {
 	// Use RFC3339 for testing `2006-01-02T15:04:05Z07:00`
 	re := regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}[Z+\-]\d{2}:\d{2}`)
@@ -399,10 +635,17 @@ func setupTailer(t *testing.T, multiLineFn func(string) bool, maxEventSize int)
 		false, // AutoRemoval
 		multiLineFn,
 		config.Filters,
+		nil, // severity
+		nil, // rate limit
+		0,   // sample first n
 		parseRFC3339Timestamp,
 		nil, // encoding
 		maxEventSize,
 		defaultTruncateSuffix,
+		"", // oversizeAction
+		"", // logFormat
+		"", // eventPrefix
+		"", // eventSuffix
 		1,
 	)
 
@@ -467,3 +710,56 @@ func teardown(resources tailerTestResources) {
 	os.Remove(resources.file.Name())
 	os.Remove(resources.statefile.Name())
 }
+
+func TestTailerSrcPublishEventDecoration(t *testing.T) {
+	config := &FileConfig{
+		FilePath:    "/var/log/*.log",
+		EventPrefix: "[host-1 {file_name}] ",
+		EventSuffix: " <end>",
+	}
+	require.NoError(t, config.init())
+	prefix, suffix := config.eventDecoration("/var/log/app.log")
+	require.Equal(t, "[host-1 app.log] ", prefix)
+	require.Equal(t, " <end>", suffix)
+
+	long := strings.Repeat("é", maxCloudWatchEventSize/2)
+	testCases := map[string]struct {
+		action string
+		msg    string
+		want   int
+	}{
+		"Short":    {msg: "hello", want: 1},
+		"Default":  {msg: long, want: 1},
+		"Truncate": {action: oversizeEventActionTruncate, msg: long, want: 1},
+		"Split":    {action: oversizeEventActionSplit, msg: long, want: 2},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			var msgs []string
+			ts := &tailerSrc{
+				timestampFn:    parseRFC3339Timestamp,
+				maxEventSize:   defaultMaxEventSize,
+				truncateSuffix: defaultTruncateSuffix,
+				oversizeAction: testCase.action,
+				eventPrefix:    prefix,
+				eventSuffix:    suffix,
+				outputFn: func(evt logs.LogEvent) {
+					msgs = append(msgs, evt.Message())
+				},
+			}
+			ts.publish(testCase.msg, fileOffset{})
+			require.Len(t, msgs, testCase.want)
+			var joined strings.Builder
+			for _, msg := range msgs {
+				assert.LessOrEqual(t, len(msg), maxCloudWatchEventSize)
+				assert.True(t, utf8.ValidString(msg))
+				require.True(t, strings.HasPrefix(msg, prefix))
+				require.True(t, strings.HasSuffix(msg, suffix))
+				joined.WriteString(strings.TrimSuffix(strings.TrimPrefix(msg, prefix), suffix))
+			}
+			if len(testCase.msg) < maxCloudWatchEventSize {
+				assert.Equal(t, testCase.msg, joined.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logfile
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// fractionalSecondsReplacer replaces the fractional seconds of the layouts, which have 3 digits and their own
+// separator to match the timestamp regex that pads them, with a layout that accepts any number of digits.
+var fractionalSecondsReplacer = strings.NewReplacer("..000", ".999999999", ",.000", ",999999999", ".000", ".999999999")
+
+// timestamp returns the time of the log event. The timestamp fields are used instead of the timestamp regex when
+// they are configured. Returns the zero time if no timestamp is found, in which case the read time is used.
+func (config *FileConfig) timestamp(logValue string) time.Time {
+	if len(config.TimestampFields) > 0 {
+		return config.timestampFromFields(logValue)
+	}
+	return config.timestampFromLogLine(logValue)
+}
+
+// timestampFromFields parses the timestamp from the first of the timestamp fields of a JSON log event that has a
+// valid timestamp. The fields are dotted paths to nested fields, e.g. "log.time". The value is parsed with the
+// timestamp layouts, or as RFC 3339 if there are none.
+func (config *FileConfig) timestampFromFields(logValue string) time.Time {
+	trimmed := strings.TrimSpace(logValue)
+	if !strings.HasPrefix(trimmed, "{") {
+		return time.Time{}
+	}
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(trimmed), &fields); err != nil {
+		return time.Time{}
+	}
+	for _, path := range config.TimestampFields {
+		value, ok := lookupField(fields, path).(string)
+		if !ok {
+			continue
+		}
+		if timestamp, ok := config.parseTimestampField(value); ok {
+			return timestamp
+		}
+	}
+	return time.Time{}
+}
+
+func (config *FileConfig) parseTimestampField(value string) (time.Time, bool) {
+	if len(config.TimestampLayout) == 0 {
+		timestamp, err := time.Parse(time.RFC3339Nano, value)
+		return timestamp, err == nil
+	}
+	for _, layout := range config.TimestampLayout {
+		layout = fractionalSecondsReplacer.Replace(layout)
+		if timestamp, err := time.ParseInLocation(layout, value, config.TimezoneLoc); err == nil {
+			return timestamp, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// lookupField returns the value at the dotted path. A key that contains dots is matched before the nested fields,
+// so "log.time" finds both {"log.time": ...} and {"log": {"time": ...}}.
+func lookupField(fields map[string]interface{}, path string) interface{} {
+	if value, ok := fields[path]; ok {
+		return value
+	}
+	for i := strings.Index(path, "."); i >= 0; i = nextDot(path, i) {
+		if nested, ok := fields[path[:i]].(map[string]interface{}); ok {
+			if value := lookupField(nested, path[i+1:]); value != nil {
+				return value
+			}
+		}
+	}
+	return nil
+}
+
+func nextDot(path string, i int) int {
+	if j := strings.Index(path[i+1:], "."); j >= 0 {
+		return i + 1 + j
+	}
+	return -1
+}
@@ -0,0 +1,203 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pipeline_errors
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/aws/amazon-cloudwatch-agent/logger"
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+)
+
+const (
+	// componentKindKey is the field that the OTel collector adds to the logger of each component, e.g. processor.
+	componentKindKey = "kind"
+	droppedEventsKey = "dropped_events"
+	rateLimitWindow  = time.Minute
+	// bufferSize is the number of events that are buffered until the destination is ready.
+	bufferSize = 100
+)
+
+type LogEvent struct {
+	msg string
+	t   time.Time
+}
+
+func (le LogEvent) Message() string {
+	return le.msg
+}
+
+func (le LogEvent) Time() time.Time {
+	return le.t
+}
+
+func (le LogEvent) Done() {}
+
+// errorSrc publishes the entries logged by the components of the OTel pipelines, such as a processor that fails to
+// process a batch, as JSON log events. The events over the rate limit are dropped and their number is added to the
+// next published event.
+type errorSrc struct {
+	plugin  *PipelineErrors
+	level   zapcore.Level
+	stream  string
+	encoder zapcore.Encoder
+	now     func() time.Time
+
+	mu          sync.Mutex
+	windowStart time.Time
+	count       int
+	dropped     int
+
+	events     chan logs.LogEvent
+	removeHook func()
+	outputFn   func(logs.LogEvent)
+	done       chan struct{}
+	stopOnce   sync.Once
+}
+
+var _ logs.LogSrc = (*errorSrc)(nil)
+
+func newErrorSrc(plugin *PipelineErrors, level zapcore.Level, stream string) *errorSrc {
+	s := &errorSrc{
+		plugin: plugin,
+		level:  level,
+		stream: stream,
+		encoder: zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+			LevelKey:      "level",
+			NameKey:       "logger",
+			CallerKey:     "caller",
+			FunctionKey:   zapcore.OmitKey,
+			MessageKey:    "msg",
+			StacktraceKey: "stacktrace",
+			EncodeLevel:   zapcore.LowercaseLevelEncoder,
+			EncodeCaller:  zapcore.ShortCallerEncoder,
+		}),
+		now:    time.Now,
+		events: make(chan logs.LogEvent, bufferSize),
+		done:   make(chan struct{}),
+	}
+	s.removeHook = logger.AddEntryHook(s.hook)
+	return s
+}
+
+func (s *errorSrc) SetOutput(fn func(logs.LogEvent)) {
+	if fn == nil {
+		return
+	}
+	s.outputFn = fn
+	go s.run()
+}
+
+func (s *errorSrc) Group() string {
+	return s.plugin.LogGroupName
+}
+
+func (s *errorSrc) Stream() string {
+	return s.stream
+}
+
+func (s *errorSrc) Description() string {
+	return "pipeline errors of " + s.stream
+}
+
+func (s *errorSrc) Destination() string {
+	return s.plugin.Destination
+}
+
+func (s *errorSrc) Retention() int {
+	return s.plugin.RetentionInDays
+}
+
+func (s *errorSrc) Class() string {
+	return s.plugin.LogGroupClass
+}
+
+func (s *errorSrc) Entity() *cloudwatchlogs.Entity {
+	return nil
+}
+
+func (s *errorSrc) Stop() {
+	s.stopOnce.Do(func() {
+		s.removeHook()
+		close(s.done)
+	})
+}
+
+func (s *errorSrc) run() {
+	defer s.outputFn(nil)
+	for {
+		select {
+		case e := <-s.events:
+			s.outputFn(e)
+		case <-s.done:
+			return
+		}
+	}
+}
+
+// hook is called by the logger for each warning and error entry. The entries that are not logged by a component of
+// a pipeline are ignored.
+func (s *errorSrc) hook(entry zapcore.Entry, fields []zapcore.Field) {
+	if entry.Level < s.level || !hasField(fields, componentKindKey) {
+		return
+	}
+	dropped, ok := s.allow()
+	if !ok {
+		return
+	}
+	if dropped > 0 {
+		fields = append(fields, zap.Int(droppedEventsKey, dropped))
+	}
+	buf, err := s.encoder.EncodeEntry(entry, fields)
+	if err != nil {
+		return
+	}
+	e := LogEvent{msg: strings.TrimSuffix(buf.String(), zapcore.DefaultLineEnding), t: entry.Time}
+	buf.Free()
+	select {
+	case s.events <- e:
+	default:
+		s.drop()
+	}
+}
+
+// allow returns whether the entry is within the rate limit, and the number of entries dropped before it.
+func (s *errorSrc) allow() (int, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if now.Sub(s.windowStart) >= rateLimitWindow {
+		s.windowStart = now
+		s.count = 0
+	}
+	if s.count >= s.plugin.MaxEventsPerMinute {
+		s.dropped++
+		return 0, false
+	}
+	s.count++
+	dropped := s.dropped
+	s.dropped = 0
+	return dropped, true
+}
+
+func (s *errorSrc) drop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dropped++
+}
+
+func hasField(fields []zapcore.Field, key string) bool {
+	for _, field := range fields {
+		if field.Key == key {
+			return true
+		}
+	}
+	return false
+}
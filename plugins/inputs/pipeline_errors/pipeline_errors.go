@@ -0,0 +1,121 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pipeline_errors
+
+import (
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+const (
+	defaultLevel              = "error"
+	defaultMaxEventsPerMinute = 60
+)
+
+type PipelineErrors struct {
+	LogGroupName    string `toml:"log_group_name"`
+	LogStreamName   string `toml:"log_stream_name"`
+	LogGroupClass   string `toml:"log_group_class"`
+	RetentionInDays int    `toml:"retention_in_days"`
+	Destination     string `toml:"destination"`
+	// Level is the lowest level of the entries that are published, either warn or error.
+	Level string `toml:"level"`
+	// MaxEventsPerMinute limits the events published, so that a failing pipeline does not flood the log group.
+	MaxEventsPerMinute int             `toml:"max_events_per_minute"`
+	Log                telegraf.Logger `toml:"-"`
+
+	mu  sync.Mutex
+	src *errorSrc
+	// found is set once the source has been returned by FindLogSrc.
+	found bool
+}
+
+var _ logs.LogCollection = (*PipelineErrors)(nil)
+
+func NewPipelineErrors() *PipelineErrors {
+	return &PipelineErrors{
+		RetentionInDays:    -1,
+		Level:              defaultLevel,
+		MaxEventsPerMinute: defaultMaxEventsPerMinute,
+	}
+}
+
+func (p *PipelineErrors) Description() string {
+	return "A plugin to publish the errors and warnings of the agent's OTel pipelines as log events"
+}
+
+func (p *PipelineErrors) SampleConfig() string {
+	return `
+  log_group_name = "cloudwatch-agent-errors"
+  ## Defaults to the hostname.
+  log_stream_name = "host"
+  ## The lowest level of the published entries, either "warn" or "error".
+  level = "error"
+  ## Events over the limit are dropped and counted in the next published event.
+  max_events_per_minute = 60
+  destination = "cloudwatchlogs"
+`
+}
+
+func (p *PipelineErrors) Gather(telegraf.Accumulator) error {
+	return nil
+}
+
+// Start hooks into the logger of the OTel collector. The entries logged before the source is returned by FindLogSrc
+// are buffered.
+func (p *PipelineErrors) Start(telegraf.Accumulator) error {
+	if p.LogGroupName == "" {
+		return fmt.Errorf("log_group_name is required")
+	}
+	if p.Level == "" {
+		p.Level = defaultLevel
+	}
+	level, err := zapcore.ParseLevel(p.Level)
+	if err != nil || (level != zapcore.WarnLevel && level != zapcore.ErrorLevel) {
+		return fmt.Errorf("level %q is not warn or error", p.Level)
+	}
+	if p.MaxEventsPerMinute <= 0 {
+		p.MaxEventsPerMinute = defaultMaxEventsPerMinute
+	}
+	stream := p.LogStreamName
+	if stream == "" {
+		if stream, err = os.Hostname(); err != nil {
+			return fmt.Errorf("unable to get the hostname for the log stream name: %w", err)
+		}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.src = newErrorSrc(p, level, stream)
+	return nil
+}
+
+func (p *PipelineErrors) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.src != nil {
+		p.src.Stop()
+	}
+}
+
+// FindLogSrc returns the source of the pipeline errors once.
+func (p *PipelineErrors) FindLogSrc() []logs.LogSrc {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.src == nil || p.found {
+		return nil
+	}
+	p.found = true
+	return []logs.LogSrc{p.src}
+}
+
+func init() {
+	inputs.Add("pipeline_errors", func() telegraf.Input { return NewPipelineErrors() })
+}
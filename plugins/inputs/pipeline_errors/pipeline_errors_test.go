@@ -0,0 +1,138 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pipeline_errors
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	cwaLogger "github.com/aws/amazon-cloudwatch-agent/logger"
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+func newTestPlugin(t *testing.T, level string, maxEventsPerMinute int) (*PipelineErrors, chan logs.LogEvent) {
+	t.Helper()
+	p := NewPipelineErrors()
+	p.Log = testutil.Logger{Name: "pipeline_errors"}
+	p.LogGroupName = "agent-errors"
+	p.LogStreamName = "host"
+	p.Level = level
+	p.MaxEventsPerMinute = maxEventsPerMinute
+	require.NoError(t, p.Start(nil))
+	t.Cleanup(p.Stop)
+
+	srcs := p.FindLogSrc()
+	require.Len(t, srcs, 1)
+	assert.Empty(t, p.FindLogSrc())
+	assert.Equal(t, "agent-errors", srcs[0].Group())
+	assert.Equal(t, "host", srcs[0].Stream())
+	events := make(chan logs.LogEvent, bufferSize)
+	srcs[0].SetOutput(func(e logs.LogEvent) {
+		if e != nil {
+			events <- e
+		}
+	})
+	return p, events
+}
+
+// processorLogger returns a logger with the caller and the fields that the OTel collector adds to the logger of a
+// processor.
+func processorLogger() *zap.Logger {
+	logger, _ := cwaLogger.NewLogger(io.Discard, zap.NewAtomicLevelAt(zapcore.InfoLevel))
+	return logger.WithOptions(zap.AddCaller()).With(
+		zap.String("kind", "processor"),
+		zap.String("name", "awsentity/resource"),
+		zap.String("pipeline", "metrics/host"),
+	)
+}
+
+func receiveEvent(t *testing.T, events chan logs.LogEvent) map[string]interface{} {
+	t.Helper()
+	select {
+	case e := <-events:
+		var fields map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(e.Message()), &fields))
+		return fields
+	case <-time.After(time.Second):
+		require.Fail(t, "no event published")
+		return nil
+	}
+}
+
+func TestProcessorError(t *testing.T) {
+	_, events := newTestPlugin(t, "error", 60)
+	logger := processorLogger()
+
+	logger.Error("Failed to process metrics", zap.Error(errors.New("attribute ec2.tag.Name is missing")))
+	fields := receiveEvent(t, events)
+	assert.Equal(t, "error", fields["level"])
+	assert.Equal(t, "Failed to process metrics", fields["msg"])
+	assert.Equal(t, "attribute ec2.tag.Name is missing", fields["error"])
+	assert.Equal(t, "processor", fields["kind"])
+	assert.Equal(t, "awsentity/resource", fields["name"])
+	assert.Equal(t, "metrics/host", fields["pipeline"])
+	assert.Contains(t, fields["caller"], "pipeline_errors_test.go")
+
+	// below the level or not logged by a component
+	logger.Warn("Slow to process metrics")
+	logger.Info("Processed metrics")
+	l, _ := cwaLogger.NewLogger(io.Discard, zap.NewAtomicLevelAt(zapcore.InfoLevel))
+	l.Error("Failed to load the configuration")
+	select {
+	case e := <-events:
+		assert.Fail(t, "unexpected event", e.Message())
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestProcessorWarning(t *testing.T) {
+	_, events := newTestPlugin(t, "warn", 60)
+	processorLogger().Warn("Dropped data points", zap.Int("count", 2))
+	fields := receiveEvent(t, events)
+	assert.Equal(t, "warn", fields["level"])
+	assert.EqualValues(t, 2, fields["count"])
+}
+
+func TestRateLimit(t *testing.T) {
+	p, events := newTestPlugin(t, "error", 2)
+	now := time.Now()
+	p.src.now = func() time.Time { return now }
+	logger := processorLogger()
+
+	for i := 0; i < 5; i++ {
+		logger.Error("Failed to process metrics", zap.Int("batch", i))
+	}
+	assert.EqualValues(t, 0, receiveEvent(t, events)["batch"])
+	assert.EqualValues(t, 1, receiveEvent(t, events)["batch"])
+	select {
+	case e := <-events:
+		assert.Fail(t, "unexpected event", e.Message())
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// the dropped events are counted in the first event of the next window
+	now = now.Add(rateLimitWindow)
+	logger.Error("Failed to process metrics", zap.Int("batch", 5))
+	fields := receiveEvent(t, events)
+	assert.EqualValues(t, 5, fields["batch"])
+	assert.EqualValues(t, 3, fields[droppedEventsKey])
+}
+
+func TestStartInvalidConfig(t *testing.T) {
+	p := NewPipelineErrors()
+	assert.Error(t, p.Start(nil))
+	p.LogGroupName = "agent-errors"
+	p.Level = "info"
+	assert.Error(t, p.Start(nil))
+	assert.Empty(t, p.FindLogSrc())
+}
@@ -0,0 +1,152 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pressure
+
+import (
+	"bufio"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/containerinsightscommon"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const (
+	measurement = "pressure"
+	defaultPath = "/proc/pressure"
+)
+
+// resources are the files in the pressure directory that are collected.
+var resources = []string{"cpu", "memory", "io"}
+
+// Pressure reads the pressure stall information (PSI) of the kernel and emits
+// the stall averages and the total stall time in microseconds for each
+// resource, e.g. cpu_some_avg10 and io_full_total.
+type Pressure struct {
+	Path string          `toml:"path"`
+	Log  telegraf.Logger `toml:"-"`
+
+	loggedUnsupported bool
+}
+
+func (*Pressure) SampleConfig() string {
+	return sampleConfig
+}
+
+func (*Pressure) Description() string {
+	return "Collects the Linux pressure stall information of the cpu, memory and io resources"
+}
+
+func (p *Pressure) Init() error {
+	if p.Path == "" {
+		p.Path = defaultPath
+		if hostProc := os.Getenv(containerinsightscommon.GoPSUtilProcDirEnv); hostProc != "" {
+			p.Path = filepath.Join(hostProc, "pressure")
+		}
+	}
+	return nil
+}
+
+func (p *Pressure) Gather(acc telegraf.Accumulator) error {
+	fields := map[string]interface{}{}
+	for _, resource := range resources {
+		resourceFields, err := readPressureFile(filepath.Join(p.Path, resource), resource)
+		if isUnsupported(err) {
+			// The kernel is older than 4.20, was built without PSI or has it
+			// disabled with psi=0, so there is nothing to collect.
+			if !p.loggedUnsupported {
+				p.Log.Infof("Pressure stall information is not available in %s, skipping: %v", p.Path, err)
+				p.loggedUnsupported = true
+			}
+			continue
+		}
+		if err != nil {
+			acc.AddError(err)
+			continue
+		}
+		for name, value := range resourceFields {
+			fields[name] = value
+		}
+	}
+	if len(fields) > 0 {
+		acc.AddFields(measurement, fields, map[string]string{})
+	}
+	return nil
+}
+
+func isUnsupported(err error) bool {
+	return errors.Is(err, os.ErrNotExist) || errors.Is(err, syscall.EOPNOTSUPP)
+}
+
+func readPressureFile(path string, resource string) (map[string]interface{}, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parsePressure(f, resource)
+}
+
+// parsePressure parses the lines of a pressure file, which have the format
+//
+//	some avg10=0.00 avg60=0.00 avg300=0.00 total=0
+//	full avg10=0.00 avg60=0.00 avg300=0.00 total=0
+func parsePressure(r io.Reader, resource string) (map[string]interface{}, error) {
+	fields := map[string]interface{}{}
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		parts := strings.Fields(scanner.Text())
+		if len(parts) == 0 {
+			continue
+		}
+		// some is the share of time in which at least one task was stalled on
+		// the resource and full the share in which all non-idle tasks were.
+		lineType := parts[0]
+		if lineType != "some" && lineType != "full" {
+			return nil, fmt.Errorf("unexpected line type %q in %s pressure", lineType, resource)
+		}
+		for _, part := range parts[1:] {
+			key, value, ok := strings.Cut(part, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid field %q in %s pressure", part, resource)
+			}
+			name := resource + "_" + lineType + "_" + key
+			if key == "total" {
+				total, err := strconv.ParseUint(value, 10, 64)
+				if err != nil {
+					return nil, fmt.Errorf("invalid %s in %s pressure: %w", key, resource, err)
+				}
+				fields[name] = total
+				continue
+			}
+			avg, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s in %s pressure: %w", key, resource, err)
+			}
+			fields[name] = avg
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func init() {
+	inputs.Add("pressure", func() telegraf.Input {
+		return &Pressure{}
+	})
+}
@@ -0,0 +1,118 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pressure
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInit(t *testing.T) {
+	t.Setenv("HOST_PROC", "")
+	plugin := &Pressure{}
+	require.NoError(t, plugin.Init())
+	assert.Equal(t, "/proc/pressure", plugin.Path)
+
+	t.Setenv("HOST_PROC", "/rootfs/proc")
+	plugin = &Pressure{}
+	require.NoError(t, plugin.Init())
+	assert.Equal(t, filepath.Join("/rootfs/proc", "pressure"), plugin.Path)
+
+	plugin = &Pressure{Path: "/custom"}
+	require.NoError(t, plugin.Init())
+	assert.Equal(t, "/custom", plugin.Path)
+}
+
+func TestParsePressureCPU(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "pressure", "cpu"))
+	require.NoError(t, err)
+	defer f.Close()
+	fields, err := parsePressure(f, "cpu")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"cpu_some_avg10":  1.53,
+		"cpu_some_avg60":  0.87,
+		"cpu_some_avg300": 0.22,
+		"cpu_some_total":  uint64(8723591),
+		"cpu_full_avg10":  0.0,
+		"cpu_full_avg60":  0.0,
+		"cpu_full_avg300": 0.0,
+		"cpu_full_total":  uint64(0),
+	}, fields)
+}
+
+func TestParsePressureInvalid(t *testing.T) {
+	testCases := map[string]string{
+		"LineType": "partial avg10=0.00 avg60=0.00 avg300=0.00 total=0",
+		"Field":    "some avg10",
+		"Average":  "some avg10=abc",
+		"Total":    "some total=-1",
+	}
+	for name, input := range testCases {
+		t.Run(name, func(t *testing.T) {
+			fields, err := parsePressure(strings.NewReader(input), "cpu")
+			assert.Error(t, err)
+			assert.Nil(t, fields)
+		})
+	}
+}
+
+func TestGather(t *testing.T) {
+	plugin := &Pressure{Path: filepath.Join("testdata", "pressure"), Log: testutil.Logger{}}
+	require.NoError(t, plugin.Init())
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	require.Empty(t, acc.Errors)
+	require.Len(t, acc.Metrics, 1)
+	m := acc.Metrics[0]
+	assert.Equal(t, "pressure", m.Measurement)
+	assert.Len(t, m.Fields, 24)
+	assert.Equal(t, 1.53, m.Fields["cpu_some_avg10"])
+	assert.Equal(t, uint64(98245), m.Fields["memory_full_total"])
+	assert.Equal(t, 10.02, m.Fields["io_full_avg10"])
+}
+
+func TestGatherWithoutPSI(t *testing.T) {
+	dir := t.TempDir()
+	plugin := &Pressure{Path: filepath.Join(dir, "pressure"), Log: testutil.Logger{}}
+	require.NoError(t, plugin.Init())
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	assert.Empty(t, acc.Errors)
+	assert.Empty(t, acc.Metrics)
+	assert.True(t, plugin.loggedUnsupported)
+
+	// the resources with a pressure file are still reported
+	pressureDir := filepath.Join(dir, "pressure")
+	require.NoError(t, os.Mkdir(pressureDir, 0755))
+	require.NoError(t, os.WriteFile(filepath.Join(pressureDir, "cpu"), []byte("some avg10=2.00 avg60=1.00 avg300=0.50 total=100\n"), 0600))
+	require.NoError(t, plugin.Gather(&acc))
+	assert.Empty(t, acc.Errors)
+	require.Len(t, acc.Metrics, 1)
+	assert.Equal(t, map[string]interface{}{
+		"cpu_some_avg10":  2.0,
+		"cpu_some_avg60":  1.0,
+		"cpu_some_avg300": 0.5,
+		"cpu_some_total":  uint64(100),
+	}, acc.Metrics[0].Fields)
+}
+
+func TestGatherInvalidFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "cpu"), []byte("some avg10=x\n"), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "io"), []byte("some avg10=3.00 avg60=2.00 avg300=1.00 total=7\n"), 0600))
+	plugin := &Pressure{Path: dir, Log: testutil.Logger{}}
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	assert.Len(t, acc.Errors, 1)
+	require.Len(t, acc.Metrics, 1)
+	assert.Len(t, acc.Metrics[0].Fields, 4)
+	assert.Equal(t, uint64(7), acc.Metrics[0].Fields["io_some_total"])
+}
@@ -0,0 +1,130 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	MetricNameActionStripPrefix = "strip_prefix"
+	MetricNameActionStripSuffix = "strip_suffix"
+	MetricNameActionReplace     = "replace"
+	MetricNameActionPascalCase  = "pascal_case"
+)
+
+// MetricNameRule transforms the name of the scraped metrics before they are converted to CloudWatch metrics.
+// The rules are applied in order, each to the result of the previous rule.
+type MetricNameRule struct {
+	Action string `toml:"action"`
+	// Value is the prefix or suffix to strip, or the regular expression to replace.
+	Value       string `toml:"value"`
+	Replacement string `toml:"replacement"`
+}
+
+type metricNameMapper interface {
+	Map(name string) string
+}
+
+type metricNameMapperFunc func(name string) string
+
+func (f metricNameMapperFunc) Map(name string) string {
+	return f(name)
+}
+
+// metricNameMapperFactories creates the mapper of each rule action.
+var metricNameMapperFactories = map[string]func(rule MetricNameRule) (metricNameMapper, error){
+	MetricNameActionStripPrefix: func(rule MetricNameRule) (metricNameMapper, error) {
+		if rule.Value == "" {
+			return nil, fmt.Errorf("value is required for %s", rule.Action)
+		}
+		return metricNameMapperFunc(func(name string) string {
+			return strings.TrimPrefix(name, rule.Value)
+		}), nil
+	},
+	MetricNameActionStripSuffix: func(rule MetricNameRule) (metricNameMapper, error) {
+		if rule.Value == "" {
+			return nil, fmt.Errorf("value is required for %s", rule.Action)
+		}
+		return metricNameMapperFunc(func(name string) string {
+			return strings.TrimSuffix(name, rule.Value)
+		}), nil
+	},
+	MetricNameActionReplace: func(rule MetricNameRule) (metricNameMapper, error) {
+		regex, err := regexp.Compile(rule.Value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for %s: %w", rule.Action, err)
+		}
+		return metricNameMapperFunc(func(name string) string {
+			return regex.ReplaceAllString(name, rule.Replacement)
+		}), nil
+	},
+	MetricNameActionPascalCase: func(MetricNameRule) (metricNameMapper, error) {
+		return metricNameMapperFunc(toPascalCase), nil
+	},
+}
+
+// metricNameMapping applies the metric name rules in order.
+type metricNameMapping []metricNameMapper
+
+func newMetricNameMapping(rules []MetricNameRule) (metricNameMapping, error) {
+	var mapping metricNameMapping
+	for i, rule := range rules {
+		factory, ok := metricNameMapperFactories[rule.Action]
+		if !ok {
+			return nil, fmt.Errorf("unsupported action %q in metric_name_rules %d", rule.Action, i)
+		}
+		mapper, err := factory(rule)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metric_name_rules %d: %w", i, err)
+		}
+		mapping = append(mapping, mapper)
+	}
+	return mapping, nil
+}
+
+// Map returns the name of the metric after the rules are applied. The original name is kept if the rules
+// would leave the name empty.
+func (m metricNameMapping) Map(name string) string {
+	mapped := name
+	for _, mapper := range m {
+		mapped = mapper.Map(mapped)
+	}
+	if mapped == "" {
+		return name
+	}
+	return mapped
+}
+
+// Apply renames the metrics in the batch.
+func (m metricNameMapping) Apply(pmb PrometheusMetricBatch) PrometheusMetricBatch {
+	if len(m) == 0 {
+		return pmb
+	}
+	for _, pm := range pmb {
+		pm.metricName = m.Map(pm.metricName)
+	}
+	return pmb
+}
+
+// toPascalCase converts a Prometheus metric name, e.g. node_cpu_seconds, to PascalCase, e.g. NodeCpuSeconds.
+func toPascalCase(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if r == '_' || r == ':' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
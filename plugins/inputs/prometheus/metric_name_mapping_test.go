@@ -0,0 +1,98 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetricNameMapping(t *testing.T) {
+	mapping, err := newMetricNameMapping([]MetricNameRule{
+		{Action: MetricNameActionStripPrefix, Value: "node_"},
+		{Action: MetricNameActionStripSuffix, Value: "_total"},
+		{Action: MetricNameActionReplace, Value: "_seconds$", Replacement: "_time"},
+		{Action: MetricNameActionPascalCase},
+	})
+	require.NoError(t, err)
+	testCases := map[string]string{
+		"node_cpu_seconds_total":             "CpuTime",
+		"http_requests_total":                "HttpRequests",
+		"go_memstats_alloc_bytes":            "GoMemstatsAllocBytes",
+		"node_network_receive_bytes_total":   "NetworkReceiveBytes",
+		"apiserver:request_duration_seconds": "ApiserverRequestDurationTime",
+		"Requests":                           "Requests",
+	}
+	for name, want := range testCases {
+		assert.Equal(t, want, mapping.Map(name), name)
+	}
+}
+
+func TestMetricNameMappingPassthrough(t *testing.T) {
+	mapping, err := newMetricNameMapping(nil)
+	require.NoError(t, err)
+	pmb := PrometheusMetricBatch{
+		&PrometheusMetric{metricName: "node_cpu_seconds_total"},
+		&PrometheusMetric{metricName: "up"},
+	}
+	pmb = mapping.Apply(pmb)
+	assert.Equal(t, "node_cpu_seconds_total", pmb[0].metricName)
+	assert.Equal(t, "up", pmb[1].metricName)
+
+	// names that the rules would leave empty are kept as is
+	mapping, err = newMetricNameMapping([]MetricNameRule{{Action: MetricNameActionStripSuffix, Value: "_total"}})
+	require.NoError(t, err)
+	assert.Equal(t, "_total", mapping.Map("_total"))
+}
+
+func TestMetricNameMappingApply(t *testing.T) {
+	mapping, err := newMetricNameMapping([]MetricNameRule{
+		{Action: MetricNameActionStripSuffix, Value: "_total"},
+		{Action: MetricNameActionPascalCase},
+	})
+	require.NoError(t, err)
+	pmb := mapping.Apply(PrometheusMetricBatch{
+		&PrometheusMetric{metricName: "http_requests_total", metricType: "counter", tags: map[string]string{"job": "web"}},
+		&PrometheusMetric{metricName: "rpc_duration_seconds_sum", metricType: "summary", tags: map[string]string{"job": "web"}},
+	})
+	assert.Equal(t, "HttpRequests", pmb[0].metricName)
+	assert.Equal(t, "RpcDurationSecondsSum", pmb[1].metricName)
+}
+
+func TestNewMetricNameMappingInvalid(t *testing.T) {
+	testCases := map[string]struct {
+		rules   []MetricNameRule
+		wantErr string
+	}{
+		"WithUnsupportedAction": {
+			rules:   []MetricNameRule{{Action: "snake_case"}},
+			wantErr: `unsupported action "snake_case" in metric_name_rules 0`,
+		},
+		"WithoutSuffix": {
+			rules:   []MetricNameRule{{Action: MetricNameActionPascalCase}, {Action: MetricNameActionStripSuffix}},
+			wantErr: "invalid metric_name_rules 1: value is required for strip_suffix",
+		},
+		"WithInvalidRegex": {
+			rules:   []MetricNameRule{{Action: MetricNameActionReplace, Value: "("}},
+			wantErr: "invalid metric_name_rules 0: invalid value for replace",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			_, err := newMetricNameMapping(testCase.rules)
+			assert.ErrorContains(t, err, testCase.wantErr)
+		})
+	}
+}
+
+func TestPrometheusInit(t *testing.T) {
+	p := &Prometheus{MetricNameRules: []MetricNameRule{{Action: MetricNameActionPascalCase}}}
+	require.NoError(t, p.Init())
+	assert.Len(t, p.metricNameMapping, 1)
+
+	p = &Prometheus{MetricNameRules: []MetricNameRule{{Action: "unknown"}}}
+	assert.Error(t, p.Init())
+}
@@ -27,6 +27,7 @@ type metricsHandler struct {
 	filter      *MetricsFilter
 	clusterName string
 	mtHandler   *metricsTypeHandler
+	nameMapping metricNameMapping
 }
 
 func (mh *metricsHandler) start(shutDownChan chan interface{}, wg *sync.WaitGroup) {
@@ -52,6 +53,9 @@ func (mh *metricsHandler) handle(pmb PrometheusMetricBatch) {
 	// do calculation: calculate delta for counter
 	pmb = mh.calculator.Calculate(pmb)
 
+	// rename metrics with the metric name rules
+	pmb = mh.nameMapping.Apply(pmb)
+
 	// do merge: merge metrics which are sharing same tags
 	metricMaterials := mergeMetrics(pmb)
 
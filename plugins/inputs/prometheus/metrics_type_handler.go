@@ -15,6 +15,8 @@ import (
 
 const (
 	prometheusMetricTypeKey = "prom_metric_type"
+	// scrapeHealthMetricName is the metric Prometheus adds for each target, 1 if the scrape succeeded and 0 otherwise
+	scrapeHealthMetricName = "up"
 
 	histogramSummaryCountSuffix = "_count"
 	histogramSummarySumSuffix   = "_sum"
@@ -177,6 +179,10 @@ func (mth *metricsTypeHandler) Handle(pmb PrometheusMetricBatch) (result Prometh
 		if ok {
 			pm.metricType = string(mm.Type)
 			pm.tags[prometheusMetricTypeKey] = pm.metricType
+		} else if pm.metricName == scrapeHealthMetricName {
+			// the health of the target has no metadata, but is kept so that failed scrapes are reported
+			pm.metricType = string(v1.MetricTypeGauge)
+			pm.tags[prometheusMetricTypeKey] = pm.metricType
 		} else {
 			if !isInternalMetric(pm.metricName) {
 				log.Printf("E! metricsHandler NO metaData for %v | %v | %v \n", pm.metricName, instanceId, jobName)
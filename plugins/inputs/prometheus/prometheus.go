@@ -5,7 +5,9 @@ package prometheus
 
 import (
 	_ "embed"
+	"fmt"
 	"sync"
+	"time"
 
 	"github.com/amazon-contributing/opentelemetry-collector-contrib/extension/awsmiddleware"
 	"github.com/influxdata/telegraf"
@@ -24,10 +26,15 @@ type Prometheus struct {
 	PrometheusConfigPath string                                      `toml:"prometheus_config_path"`
 	ClusterName          string                                      `toml:"cluster_name"`
 	ECSSDConfig          *ecsservicediscovery.ServiceDiscoveryConfig `toml:"ecs_service_discovery"`
-	mbCh                 chan PrometheusMetricBatch
-	shutDownChan         chan interface{}
-	wg                   sync.WaitGroup
-	middleware           awsmiddleware.Middleware
+	MetricNameRules      []MetricNameRule                            `toml:"metric_name_rules"`
+	// ScrapeTimeout is the longest that the scrape of a target can take, regardless of the Prometheus config.
+	ScrapeTimeout     string `toml:"scrape_timeout"`
+	metricNameMapping metricNameMapping
+	scrapeTimeout     time.Duration
+	mbCh              chan PrometheusMetricBatch
+	shutDownChan      chan interface{}
+	wg                sync.WaitGroup
+	middleware        awsmiddleware.Middleware
 }
 
 func (p *Prometheus) SampleConfig() string {
@@ -38,6 +45,22 @@ func (p *Prometheus) Description() string {
 	return "Prometheus is used to scrape metrics from prometheus exporter"
 }
 
+// Init validates the metric name rules and the scrape timeout.
+func (p *Prometheus) Init() error {
+	var err error
+	p.metricNameMapping, err = newMetricNameMapping(p.MetricNameRules)
+	if err != nil {
+		return err
+	}
+	if p.ScrapeTimeout != "" {
+		p.scrapeTimeout, err = time.ParseDuration(p.ScrapeTimeout)
+		if err != nil || p.scrapeTimeout <= 0 {
+			return fmt.Errorf("invalid scrape_timeout %q", p.ScrapeTimeout)
+		}
+	}
+	return nil
+}
+
 func (p *Prometheus) Gather(_ telegraf.Accumulator) error {
 	return nil
 }
@@ -53,6 +76,7 @@ func (p *Prometheus) Start(accIn telegraf.Accumulator) error {
 		filter:      NewMetricsFilter(),
 		clusterName: p.ClusterName,
 		mtHandler:   mth,
+		nameMapping: p.metricNameMapping,
 	}
 
 	var configurer *awsmiddleware.Configurer
@@ -76,7 +100,7 @@ func (p *Prometheus) Start(accIn telegraf.Accumulator) error {
 
 	// Start scraping prometheus metrics from prometheus endpoints
 	p.wg.Add(1)
-	go Start(p.PrometheusConfigPath, receiver, p.shutDownChan, &p.wg, mth)
+	go Start(p.PrometheusConfigPath, p.scrapeTimeout, receiver, p.shutDownChan, &p.wg, mth)
 
 	// Start filter our prometheus metrics, calculate delta value if its a Counter or Summary count sum
 	// and convert Prometheus metrics to Telegraf Metrics
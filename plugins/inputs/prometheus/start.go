@@ -27,6 +27,7 @@ import (
 	"runtime"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/go-kit/log"
 	"github.com/go-kit/log/level"
@@ -80,7 +81,7 @@ func init() {
 	prometheus.MustRegister(v.NewCollector("prometheus"))
 }
 
-func Start(configFilePath string, receiver storage.Appendable, shutDownChan chan interface{}, wg *sync.WaitGroup, mth *metricsTypeHandler) {
+func Start(configFilePath string, scrapeTimeout time.Duration, receiver storage.Appendable, shutDownChan chan interface{}, wg *sync.WaitGroup, mth *metricsTypeHandler) {
 	logLevel := &promlog.AllowedLevel{}
 	logLevel.Set("info")
 
@@ -125,12 +126,17 @@ func Start(configFilePath string, receiver storage.Appendable, shutDownChan chan
 	taManager.AttachReloadConfigHandler(
 		func(prometheusConfig *config.Config) {
 			relabelScrapeConfigs(prometheusConfig, logger)
+			limitScrapeTimeout(prometheusConfig, scrapeTimeout, logger)
 		},
 	)
 
 	mth.SetScrapeManager(scrapeManager)
 
 	var reloaders = []func(cfg *config.Config) error{
+		func(cfg *config.Config) error {
+			limitScrapeTimeout(cfg, scrapeTimeout, logger)
+			return nil
+		},
 		// The Scrape and notifier managers need to reload before the Discovery manager as
 		// they need to read the most updated config when receiving the new targets list.
 		scrapeManager.ApplyConfig,
@@ -348,6 +354,21 @@ func relabelScrapeConfigs(prometheusConfig *config.Config, logger log.Logger) {
 		sc.MetricRelabelConfigs = append(metricNameRelabelConfigs, sc.MetricRelabelConfigs...)
 	}
 }
+
+// limitScrapeTimeout lowers the scrape timeout of the scrape configs that are longer than the timeout, so that a slow
+// target gives up on its scrape sooner. Each target is scraped on its own, so the other targets are not delayed.
+func limitScrapeTimeout(prometheusConfig *config.Config, timeout time.Duration, logger log.Logger) {
+	if timeout <= 0 {
+		return
+	}
+	for _, sc := range prometheusConfig.ScrapeConfigs {
+		if time.Duration(sc.ScrapeTimeout) > timeout {
+			level.Debug(logger).Log("msg", "Lower scrape_timeout of the job", "job", sc.JobName, "scrape_timeout", timeout)
+			sc.ScrapeTimeout = model.Duration(timeout)
+		}
+	}
+}
+
 func reloadConfig(filename string, logger log.Logger, taManager *TargetAllocatorManager, rls ...func(*config.Config) error) (err error) {
 	level.Info(logger).Log("msg", "Loading configuration file", "filename", filename)
 	content, _ := os.ReadFile(filename)
@@ -0,0 +1,99 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-kit/log"
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitScrapeTimeout(t *testing.T) {
+	cfg := &config.Config{
+		ScrapeConfigs: []*config.ScrapeConfig{
+			{JobName: "short", ScrapeTimeout: model.Duration(time.Second)},
+			{JobName: "long", ScrapeTimeout: model.Duration(time.Minute)},
+		},
+	}
+	limitScrapeTimeout(cfg, 0, log.NewNopLogger())
+	assert.Equal(t, model.Duration(time.Minute), cfg.ScrapeConfigs[1].ScrapeTimeout)
+
+	limitScrapeTimeout(cfg, 5*time.Second, log.NewNopLogger())
+	assert.Equal(t, model.Duration(time.Second), cfg.ScrapeConfigs[0].ScrapeTimeout)
+	assert.Equal(t, model.Duration(5*time.Second), cfg.ScrapeConfigs[1].ScrapeTimeout)
+}
+
+// TestStartWithSlowTarget scrapes a healthy target and one that is slower than the scrape timeout. The metrics of the
+// healthy target are not held back by the other, which is reported as down.
+func TestStartWithSlowTarget(t *testing.T) {
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintln(w, "# TYPE healthy_gauge gauge")
+		fmt.Fprintln(w, "healthy_gauge 1")
+	}))
+	defer healthy.Close()
+	slow := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+	defer slow.Close()
+
+	healthyURL, err := url.Parse(healthy.URL)
+	require.NoError(t, err)
+	slowURL, err := url.Parse(slow.URL)
+	require.NoError(t, err)
+	configFile := filepath.Join(t.TempDir(), "prometheus.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte(fmt.Sprintf(`global:
+  scrape_interval: 1s
+  scrape_timeout: 1s
+scrape_configs:
+  - job_name: healthy
+    static_configs:
+      - targets: [%q]
+  - job_name: slow
+    static_configs:
+      - targets: [%q]
+`, healthyURL.Host, slowURL.Host)), 0600))
+
+	mbCh := make(chan PrometheusMetricBatch, 100)
+	shutDownChan := make(chan interface{})
+	var wg sync.WaitGroup
+	mth := NewMetricsTypeHandler()
+	wg.Add(1)
+	go Start(configFile, 200*time.Millisecond, &metricsReceiver{pmbCh: mbCh}, shutDownChan, &wg, mth)
+	defer func() {
+		close(shutDownChan)
+		wg.Wait()
+	}()
+
+	filter := NewMetricsFilter()
+	got := map[string]map[string]float64{}
+	deadline := time.After(10 * time.Second)
+	for len(got["healthy"]) < 2 || len(got["slow"]) < 1 {
+		select {
+		case pmb := <-mbCh:
+			for _, pm := range filter.Filter(mth.Handle(pmb)) {
+				job := pm.tags["job"]
+				if got[job] == nil {
+					got[job] = map[string]float64{}
+				}
+				got[job][pm.metricName] = pm.metricValue
+			}
+		case <-deadline:
+			require.Failf(t, "timed out waiting for metrics", "got %v", got)
+		}
+	}
+	assert.Equal(t, map[string]float64{"healthy_gauge": 1, "up": 1}, got["healthy"])
+	assert.Equal(t, map[string]float64{"up": 0}, got["slow"])
+}
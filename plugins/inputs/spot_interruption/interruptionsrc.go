@@ -0,0 +1,187 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package spot_interruption
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+)
+
+// metricName is the name of the metric that is embedded in the event when EmitMetric is set.
+const metricName = "SpotInterruption"
+
+type LogEvent struct {
+	msg string
+	t   time.Time
+}
+
+func (le LogEvent) Message() string {
+	return le.msg
+}
+
+func (le LogEvent) Time() time.Time {
+	return le.t
+}
+
+func (le LogEvent) Done() {}
+
+// instanceAction is the interruption notice returned by the instance metadata service.
+type instanceAction struct {
+	Action string `json:"action"`
+	Time   string `json:"time"`
+}
+
+// interruptionSrc polls the instance metadata for the interruption notice of the instance and publishes an event
+// for each notice.
+type interruptionSrc struct {
+	plugin     *SpotInterruption
+	client     metadataClient
+	instanceID string
+	stream     string
+	now        func() time.Time
+
+	// last is the last notice published, since the instance metadata keeps returning the notice until the action.
+	last     string
+	outputFn func(logs.LogEvent)
+	ctx      context.Context
+	cancel   context.CancelFunc
+	stopOnce sync.Once
+}
+
+var _ logs.LogSrc = (*interruptionSrc)(nil)
+
+func newInterruptionSrc(plugin *SpotInterruption, client metadataClient, instanceID, stream string) *interruptionSrc {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &interruptionSrc{
+		plugin:     plugin,
+		client:     client,
+		instanceID: instanceID,
+		stream:     stream,
+		now:        time.Now,
+		ctx:        ctx,
+		cancel:     cancel,
+	}
+}
+
+func (s *interruptionSrc) SetOutput(fn func(logs.LogEvent)) {
+	if fn == nil {
+		return
+	}
+	s.outputFn = fn
+	go s.run()
+}
+
+func (s *interruptionSrc) Group() string {
+	return s.plugin.LogGroupName
+}
+
+func (s *interruptionSrc) Stream() string {
+	return s.stream
+}
+
+func (s *interruptionSrc) Description() string {
+	return "spot interruption notices of " + s.instanceID
+}
+
+func (s *interruptionSrc) Destination() string {
+	return s.plugin.Destination
+}
+
+func (s *interruptionSrc) Retention() int {
+	return s.plugin.RetentionInDays
+}
+
+func (s *interruptionSrc) Class() string {
+	return s.plugin.LogGroupClass
+}
+
+func (s *interruptionSrc) Entity() *cloudwatchlogs.Entity {
+	return nil
+}
+
+func (s *interruptionSrc) Stop() {
+	s.stopOnce.Do(s.cancel)
+}
+
+func (s *interruptionSrc) run() {
+	defer s.outputFn(nil)
+	t := time.NewTicker(s.plugin.PollInterval.Duration)
+	defer t.Stop()
+	for {
+		s.poll()
+		select {
+		case <-t.C:
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// poll publishes the interruption notice of the instance if there is a new one. The instance metadata returns a 404
+// until an interruption is scheduled.
+func (s *interruptionSrc) poll() {
+	ctx, cancel := context.WithTimeout(s.ctx, requestTimeout)
+	defer cancel()
+	body, err := s.client.GetMetadataWithContext(ctx, instanceActionPath)
+	if err != nil {
+		var reqErr awserr.RequestFailure
+		if !(errors.As(err, &reqErr) && reqErr.StatusCode() == http.StatusNotFound) && s.ctx.Err() == nil {
+			s.plugin.Log.Warnf("Unable to get the spot interruption notice: %v", err)
+		}
+		return
+	}
+	if body == s.last {
+		return
+	}
+	var action instanceAction
+	if err = json.Unmarshal([]byte(body), &action); err != nil {
+		s.plugin.Log.Errorf("Unable to parse the spot interruption notice %q: %v", body, err)
+		return
+	}
+	s.last = body
+	now := s.now()
+	message, err := json.Marshal(s.message(action, now))
+	if err != nil {
+		s.plugin.Log.Errorf("Unable to encode the spot interruption notice: %v", err)
+		return
+	}
+	s.plugin.Log.Warnf("Spot instance %v is interrupted with action %v at %v", s.instanceID, action.Action, action.Time)
+	s.outputFn(LogEvent{msg: string(message), t: now})
+}
+
+// message returns the fields of the event for the notice. With EmitMetric, the fields include the metadata of the
+// embedded metric format so that CloudWatch extracts the metric from the event.
+func (s *interruptionSrc) message(action instanceAction, now time.Time) map[string]interface{} {
+	fields := map[string]interface{}{
+		"InstanceId": s.instanceID,
+		"Action":     action.Action,
+		"ActionTime": action.Time,
+	}
+	if actionTime, err := time.Parse(time.RFC3339, action.Time); err == nil {
+		fields["SecondsUntilAction"] = int64(actionTime.Sub(now).Seconds())
+	}
+	if s.plugin.EmitMetric {
+		fields[metricName] = 1
+		fields["_aws"] = map[string]interface{}{
+			"Timestamp": now.UnixMilli(),
+			"CloudWatchMetrics": []interface{}{
+				map[string]interface{}{
+					"Namespace":  s.plugin.MetricNamespace,
+					"Dimensions": [][]string{{"InstanceId"}},
+					"Metrics":    []interface{}{map[string]string{"Name": metricName, "Unit": "Count"}},
+				},
+			},
+		}
+	}
+	return fields
+}
@@ -0,0 +1,171 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package spot_interruption
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
+	"github.com/aws/amazon-cloudwatch-agent/internal"
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+const (
+	// defaultPollInterval is how often the instance metadata is polled. The notice is given 2 minutes before the
+	// action, and AWS recommends checking for it every 5 seconds.
+	defaultPollInterval = 5 * time.Second
+	defaultNamespace    = "CWAgent"
+	requestTimeout      = 2 * time.Second
+
+	lifeCyclePath      = "instance-life-cycle"
+	instanceIDPath     = "instance-id"
+	instanceActionPath = "spot/instance-action"
+	spotLifeCycle      = "spot"
+)
+
+// metadataClient is the subset of the instance metadata service client used to poll for the interruption notice.
+type metadataClient interface {
+	GetMetadataWithContext(ctx context.Context, p string) (string, error)
+}
+
+type SpotInterruption struct {
+	LogGroupName    string            `toml:"log_group_name"`
+	LogStreamName   string            `toml:"log_stream_name"`
+	LogGroupClass   string            `toml:"log_group_class"`
+	RetentionInDays int               `toml:"retention_in_days"`
+	Destination     string            `toml:"destination"`
+	PollInterval    internal.Duration `toml:"poll_interval"`
+	// EmitMetric embeds a metric in the event in the embedded metric format, so that the interruption can be alarmed
+	// on.
+	EmitMetric      bool            `toml:"emit_metric"`
+	MetricNamespace string          `toml:"metric_namespace"`
+	Log             telegraf.Logger `toml:"-"`
+
+	newClient func() (metadataClient, error)
+
+	mu  sync.Mutex
+	src *interruptionSrc
+	// found is set once the source has been returned by FindLogSrc.
+	found bool
+}
+
+var _ logs.LogCollection = (*SpotInterruption)(nil)
+
+func NewSpotInterruption() *SpotInterruption {
+	return &SpotInterruption{
+		RetentionInDays: -1,
+		PollInterval:    internal.Duration{Duration: defaultPollInterval},
+		MetricNamespace: defaultNamespace,
+		newClient:       newMetadataClient,
+	}
+}
+
+func newMetadataClient() (metadataClient, error) {
+	ses, err := session.NewSession()
+	if err != nil {
+		return nil, err
+	}
+	return ec2metadata.New(ses, &aws.Config{
+		LogLevel:   configaws.SDKLogLevel(),
+		Logger:     configaws.SDKLogger{},
+		HTTPClient: &http.Client{Timeout: requestTimeout},
+	}), nil
+}
+
+func (s *SpotInterruption) Description() string {
+	return "A plugin to publish the EC2 spot instance interruption notice as a log event"
+}
+
+func (s *SpotInterruption) SampleConfig() string {
+	return `
+  log_group_name = "spot-interruptions"
+  ## Defaults to the instance id.
+  log_stream_name = "i-0123456789abcdef0"
+  ## How often to poll the instance metadata for the interruption notice.
+  poll_interval = "5s"
+  ## Embed a SpotInterruption metric in the event with the embedded metric format.
+  emit_metric = false
+  metric_namespace = "CWAgent"
+  destination = "cloudwatchlogs"
+`
+}
+
+func (s *SpotInterruption) Gather(telegraf.Accumulator) error {
+	return nil
+}
+
+// Start checks whether the agent is running on a spot instance. Off EC2 and on other instances the plugin does
+// nothing.
+func (s *SpotInterruption) Start(telegraf.Accumulator) error {
+	if s.LogGroupName == "" {
+		return fmt.Errorf("log_group_name is required")
+	}
+	if s.PollInterval.Duration <= 0 {
+		s.PollInterval.Duration = defaultPollInterval
+	}
+	if s.MetricNamespace == "" {
+		s.MetricNamespace = defaultNamespace
+	}
+	client, err := s.newClient()
+	if err != nil {
+		return fmt.Errorf("unable to create instance metadata client: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 3*requestTimeout)
+	defer cancel()
+	lifeCycle, err := client.GetMetadataWithContext(ctx, lifeCyclePath)
+	if err != nil {
+		s.Log.Infof("Not collecting spot interruption notices, the instance metadata is not available: %v", err)
+		return nil
+	}
+	if lifeCycle != spotLifeCycle {
+		s.Log.Infof("Not collecting spot interruption notices on an instance with the %v life cycle", lifeCycle)
+		return nil
+	}
+	instanceID, err := client.GetMetadataWithContext(ctx, instanceIDPath)
+	if err != nil {
+		s.Log.Infof("Not collecting spot interruption notices, the instance id is not available: %v", err)
+		return nil
+	}
+	stream := s.LogStreamName
+	if stream == "" {
+		stream = instanceID
+	}
+	s.mu.Lock()
+	s.src = newInterruptionSrc(s, client, instanceID, stream)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *SpotInterruption) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.src != nil {
+		s.src.Stop()
+	}
+}
+
+// FindLogSrc returns the source of the interruption notices once, if the agent is running on a spot instance.
+func (s *SpotInterruption) FindLogSrc() []logs.LogSrc {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.src == nil || s.found {
+		return nil
+	}
+	s.found = true
+	return []logs.LogSrc{s.src}
+}
+
+func init() {
+	inputs.Add("spot_interruption", func() telegraf.Input { return NewSpotInterruption() })
+}
@@ -0,0 +1,195 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package spot_interruption
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/ec2metadata"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+// mockIMDS serves the instance metadata of a spot instance, which has no interruption notice until one is set.
+type mockIMDS struct {
+	*httptest.Server
+	lifeCycle string
+
+	mu     sync.Mutex
+	action string
+}
+
+func newMockIMDS(t *testing.T, lifeCycle string) *mockIMDS {
+	m := &mockIMDS{lifeCycle: lifeCycle}
+	m.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut && r.URL.Path == "/latest/api/token" {
+			w.Header().Set("X-Aws-Ec2-Metadata-Token-Ttl-Seconds", "21600")
+			_, _ = w.Write([]byte("token"))
+			return
+		}
+		if r.Header.Get("X-Aws-Ec2-Metadata-Token") != "token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch strings.TrimPrefix(r.URL.Path, "/latest/meta-data/") {
+		case lifeCyclePath:
+			_, _ = w.Write([]byte(m.lifeCycle))
+		case instanceIDPath:
+			_, _ = w.Write([]byte("i-0123456789abcdef0"))
+		case instanceActionPath:
+			m.mu.Lock()
+			defer m.mu.Unlock()
+			if m.action == "" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(m.action))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(m.Close)
+	return m
+}
+
+func (m *mockIMDS) setAction(action string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.action = action
+}
+
+func newTestPlugin(endpoint string) *SpotInterruption {
+	s := NewSpotInterruption()
+	s.Log = testutil.Logger{Name: "spot_interruption"}
+	s.LogGroupName = "spot"
+	s.PollInterval.Duration = 10 * time.Millisecond
+	s.newClient = func() (metadataClient, error) {
+		ses, err := session.NewSession()
+		if err != nil {
+			return nil, err
+		}
+		return ec2metadata.New(ses, &aws.Config{Endpoint: aws.String(endpoint), MaxRetries: aws.Int(0)}), nil
+	}
+	return s
+}
+
+// collect starts the source of the plugin and returns the channel of the events it publishes.
+func collect(t *testing.T, s *SpotInterruption) chan logs.LogEvent {
+	srcs := s.FindLogSrc()
+	require.Len(t, srcs, 1)
+	assert.Empty(t, s.FindLogSrc())
+	events := make(chan logs.LogEvent, 10)
+	srcs[0].SetOutput(func(e logs.LogEvent) {
+		if e != nil {
+			events <- e
+		}
+	})
+	return events
+}
+
+func TestInterruptionNotice(t *testing.T) {
+	imds := newMockIMDS(t, "spot")
+	s := newTestPlugin(imds.URL)
+	require.NoError(t, s.Start(nil))
+	defer s.Stop()
+
+	src := s.src
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	src.now = func() time.Time { return now }
+	assert.Equal(t, "spot", src.Group())
+	assert.Equal(t, "i-0123456789abcdef0", src.Stream())
+	events := collect(t, s)
+
+	// no interruption is scheduled
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, events)
+
+	imds.setAction(`{"action": "terminate", "time": "2024-01-01T12:02:00Z"}`)
+	var event logs.LogEvent
+	require.Eventually(t, func() bool {
+		select {
+		case event = <-events:
+			return true
+		default:
+			return false
+		}
+	}, 5*time.Second, 10*time.Millisecond)
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(event.Message()), &fields))
+	assert.Equal(t, map[string]interface{}{
+		"InstanceId":         "i-0123456789abcdef0",
+		"Action":             "terminate",
+		"ActionTime":         "2024-01-01T12:02:00Z",
+		"SecondsUntilAction": float64(120),
+	}, fields)
+	assert.Equal(t, now, event.Time())
+
+	// the same notice is only published once
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, events)
+}
+
+func TestInterruptionNoticeMetric(t *testing.T) {
+	imds := newMockIMDS(t, "spot")
+	imds.setAction(`{"action": "stop", "time": "2024-01-01T12:02:00Z"}`)
+	s := newTestPlugin(imds.URL)
+	s.LogStreamName = "interruptions"
+	s.EmitMetric = true
+	s.MetricNamespace = "Spot"
+	require.NoError(t, s.Start(nil))
+	defer s.Stop()
+
+	events := collect(t, s)
+	var event logs.LogEvent
+	select {
+	case event = <-events:
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "no interruption notice was published")
+	}
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(event.Message()), &fields))
+	assert.Equal(t, "stop", fields["Action"])
+	assert.Equal(t, float64(1), fields[metricName])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"Namespace":  "Spot",
+			"Dimensions": []interface{}{[]interface{}{"InstanceId"}},
+			"Metrics":    []interface{}{map[string]interface{}{"Name": metricName, "Unit": "Count"}},
+		},
+	}, fields["_aws"].(map[string]interface{})["CloudWatchMetrics"])
+}
+
+func TestNotSpotInstance(t *testing.T) {
+	imds := newMockIMDS(t, "on-demand")
+	s := newTestPlugin(imds.URL)
+	require.NoError(t, s.Start(nil))
+	defer s.Stop()
+	assert.Empty(t, s.FindLogSrc())
+}
+
+func TestOffEC2(t *testing.T) {
+	imds := newMockIMDS(t, "spot")
+	imds.Close()
+	s := newTestPlugin(imds.URL)
+	require.NoError(t, s.Start(nil))
+	defer s.Stop()
+	assert.Empty(t, s.FindLogSrc())
+}
+
+func TestMissingLogGroupName(t *testing.T) {
+	s := newTestPlugin("")
+	s.LogGroupName = ""
+	assert.ErrorContains(t, s.Start(nil), "log_group_name is required")
+}
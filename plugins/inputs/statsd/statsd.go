@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"log"
 	"net"
+	"os"
 	"sort"
 	"strconv"
 	"strings"
@@ -31,6 +32,11 @@ const (
 
 	defaultSeparator           = "_"
 	defaultAllowPendingMessage = 10000
+
+	// unixSocketPrefix is the prefix of a service address that listens on a unix domain socket instead of UDP.
+	unixSocketPrefix = "unix://"
+	// unixSocketMode lets any local process send to the socket, the same as with the UDP listener.
+	unixSocketMode = 0666
 )
 
 var dropwarn = "E! Error: statsd message queue full. " +
@@ -38,7 +44,7 @@ var dropwarn = "E! Error: statsd message queue full. " +
 	"You may want to increase allowed_pending_messages in the config\n"
 
 type Statsd struct {
-	// Address & Port to serve from
+	// Address & Port to serve from, or unix:// and the path of a unix domain socket
 	ServiceAddress string
 
 	// Number of messages allowed to queue up in between calls to Gather. If this
@@ -56,6 +62,10 @@ type Statsd struct {
 	// statsd protocol (http://docs.datadoghq.com/guides/dogstatsd/)
 	ParseDataDogTags bool
 
+	// AggregateByKey combines the datapoints with the same name and tags that are received between calls to Gather,
+	// regardless of their timestamps. Counters are summed and gauges are averaged, instead of reporting the last value.
+	AggregateByKey bool
+
 	// UDPPacketSize is deprecated, it's only here for legacy support
 	// we now always create 1 max size buffer and then copy only what we need
 	// into the in channel
@@ -82,7 +92,9 @@ type Statsd struct {
 	// bucket -> influx templates
 	Templates []string
 
-	listener *net.UDPConn
+	listener net.PacketConn
+	// socketPath is the path of the unix domain socket that is listened on, if any.
+	socketPath string
 
 	graphiteParser *graphite.GraphiteParser
 }
@@ -100,30 +112,44 @@ type metric struct {
 	additive   bool
 	samplerate float64
 	tags       map[string]string
+	// timestamp is the time of the datapoint set on the line, if any
+	timestamp time.Time
 }
 
 type cachedset struct {
-	name   string
-	fields map[string]map[string]bool
-	tags   map[string]string
+	name      string
+	fields    map[string]map[string]bool
+	tags      map[string]string
+	timestamp time.Time
 }
 
 type cachedgauge struct {
-	name   string
-	fields map[string]interface{}
-	tags   map[string]string
+	name      string
+	fields    map[string]interface{}
+	tags      map[string]string
+	timestamp time.Time
+	// samples holds the sum and count of the values of each field since the last call to Gather, which are
+	// averaged when aggregating by key.
+	samples map[string]*gaugeSamples
+}
+
+type gaugeSamples struct {
+	sum   float64
+	count int64
 }
 
 type cachedcounter struct {
-	name   string
-	fields map[string]interface{}
-	tags   map[string]string
+	name      string
+	fields    map[string]interface{}
+	tags      map[string]string
+	timestamp time.Time
 }
 
 type cachedtimings struct {
-	name   string
-	fields map[string]interface{}
-	tags   map[string]string
+	name      string
+	fields    map[string]interface{}
+	tags      map[string]string
+	timestamp time.Time
 }
 
 func (_ *Statsd) Description() string {
@@ -131,7 +157,8 @@ func (_ *Statsd) Description() string {
 }
 
 const sampleConfig = `
-  ## Address and port to host UDP listener on
+  ## Address and port to host UDP listener on, or the path of a unix domain
+  ## socket to listen on instead, such as "unix:///var/run/statsd.sock"
   service_address = ":8125"
 
   ## The following configuration options control when telegraf clears it's cache
@@ -153,6 +180,11 @@ const sampleConfig = `
   ## http://docs.datadoghq.com/guides/dogstatsd/
   parse_data_dog_tags = false
 
+  ## Combine the datapoints with the same name and tags received within an
+  ## interval into one, regardless of their timestamps. Counters are summed
+  ## and gauges are averaged.
+  # aggregate_by_key = false
+
   ## Statsd data translation templates, more info can be read here:
   ## https://github.com/influxdata/telegraf/blob/master/docs/DATA_FORMATS_INPUT.md#graphite
   # templates = [
@@ -177,33 +209,52 @@ func (s *Statsd) Gather(acc telegraf.Accumulator) error {
 	defer s.Unlock()
 	now := time.Now()
 
-	for _, metric := range s.timings {
-		acc.AddHistogram(metric.name, metric.fields, metric.tags, now)
+	for hash, metric := range s.timings {
+		acc.AddHistogram(metric.name, metric.fields, metric.tags, timestampOrNow(metric.timestamp, now))
+		// datapoints with a timestamp are only published once
+		if !metric.timestamp.IsZero() {
+			delete(s.timings, hash)
+		}
 	}
 	if s.DeleteTimings {
 		s.timings = make(map[string]cachedtimings)
 	}
 
-	for _, metric := range s.gauges {
-		acc.AddFields(metric.name, metric.fields, metric.tags, now)
+	for hash, metric := range s.gauges {
+		fields := metric.fields
+		if s.AggregateByKey {
+			fields = metric.averages()
+			metric.samples = nil
+			s.gauges[hash] = metric
+		}
+		acc.AddFields(metric.name, fields, metric.tags, timestampOrNow(metric.timestamp, now))
+		if !metric.timestamp.IsZero() {
+			delete(s.gauges, hash)
+		}
 	}
 	if s.DeleteGauges {
 		s.gauges = make(map[string]cachedgauge)
 	}
 
-	for _, metric := range s.counters {
-		acc.AddFields(metric.name, metric.fields, metric.tags, now)
+	for hash, metric := range s.counters {
+		acc.AddFields(metric.name, metric.fields, metric.tags, timestampOrNow(metric.timestamp, now))
+		if !metric.timestamp.IsZero() {
+			delete(s.counters, hash)
+		}
 	}
 	if s.DeleteCounters {
 		s.counters = make(map[string]cachedcounter)
 	}
 
-	for _, metric := range s.sets {
+	for hash, metric := range s.sets {
 		fields := make(map[string]interface{})
 		for field, set := range metric.fields {
 			fields[field] = int64(len(set))
 		}
-		acc.AddFields(metric.name, fields, metric.tags, now)
+		acc.AddFields(metric.name, fields, metric.tags, timestampOrNow(metric.timestamp, now))
+		if !metric.timestamp.IsZero() {
+			delete(s.sets, hash)
+		}
 	}
 	if s.DeleteSets {
 		s.sets = make(map[string]cachedset)
@@ -226,6 +277,10 @@ func (s *Statsd) Start(_ telegraf.Accumulator) error {
 		s.MetricSeparator = defaultSeparator
 	}
 
+	if err := s.listen(); err != nil {
+		return err
+	}
+
 	s.wg.Add(2)
 	// Start the UDP listener
 	go s.udpListen()
@@ -235,24 +290,66 @@ func (s *Statsd) Start(_ telegraf.Accumulator) error {
 	return nil
 }
 
-// udpListen starts listening for udp packets on the configured port.
-func (s *Statsd) udpListen() error {
-	defer s.wg.Done()
-	var err error
-	address, _ := net.ResolveUDPAddr("udp", s.ServiceAddress)
-	s.listener, err = net.ListenUDP("udp", address)
+// listen creates the listener of the service address. A unix domain socket is created with unixSocketMode, replacing
+// the socket file left behind by an agent that did not stop cleanly.
+func (s *Statsd) listen() error {
+	path, isSocket := strings.CutPrefix(s.ServiceAddress, unixSocketPrefix)
+	if !isSocket {
+		address, _ := net.ResolveUDPAddr("udp", s.ServiceAddress)
+		listener, err := net.ListenUDP("udp", address)
+		if err != nil {
+			return fmt.Errorf("failed to listen on %s: %w", s.ServiceAddress, err)
+		}
+		s.listener = listener
+		log.Println("I! Statsd listener listening on: ", s.listener.LocalAddr().String())
+		return nil
+	}
+	if err := removeStaleSocket(path); err != nil {
+		return err
+	}
+	listener, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
 	if err != nil {
-		log.Fatalf("ERROR: ListenUDP - %s", err)
+		return fmt.Errorf("failed to listen on unix socket %s: %w", path, err)
+	}
+	if err = os.Chmod(path, unixSocketMode); err != nil {
+		listener.Close()
+		os.Remove(path)
+		return fmt.Errorf("failed to set the permissions of unix socket %s: %w", path, err)
+	}
+	s.listener = listener
+	s.socketPath = path
+	log.Println("I! Statsd listener listening on unix socket: ", path)
+	return nil
+}
+
+// removeStaleSocket removes the socket file at the path, if any. Other types of files are not removed.
+func removeStaleSocket(path string) error {
+	info, err := os.Lstat(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to check unix socket %s: %w", path, err)
+	}
+	if info.Mode().Type() != os.ModeSocket {
+		return fmt.Errorf("unix socket path %s is used by a file that is not a socket", path)
 	}
-	log.Println("I! Statsd listener listening on: ", s.listener.LocalAddr().String())
+	log.Printf("I! Removing stale statsd socket %s\n", path)
+	if err = os.Remove(path); err != nil {
+		return fmt.Errorf("failed to remove stale unix socket %s: %w", path, err)
+	}
+	return nil
+}
 
+// udpListen reads the packets of the listener until the service is stopped.
+func (s *Statsd) udpListen() error {
+	defer s.wg.Done()
 	buf := make([]byte, UDP_MAX_PACKET_SIZE)
 	for {
 		select {
 		case <-s.done:
 			return nil
 		default:
-			n, _, err := s.listener.ReadFromUDP(buf)
+			n, _, err := s.listener.ReadFrom(buf)
 			if err != nil && !strings.Contains(err.Error(), "closed network") {
 				log.Printf("E! Error READ: %s\n", err.Error())
 				continue
@@ -298,6 +395,11 @@ func (s *Statsd) parser() error {
 // If the line is valid, it will be cached for the next call to Gather()
 func (s *Statsd) parseStatsdLine(line string) error {
 
+	// timestamps look like this:
+	// users.online:1|c|#ts:1700000000
+	// users.online:1|c|T1700000000
+	line, timestamp := extractTimestamp(line)
+
 	lineTags := make(map[string]string)
 	if s.ParseDataDogTags {
 		recombinedSegments := make([]string, 0)
@@ -324,7 +426,9 @@ func (s *Statsd) parseStatsdLine(line string) error {
 						k = ts[0]
 						v = ts[1]
 					}
-					if k != "" {
+					if k == timestampTagKey && isUnixSeconds(v) {
+						timestamp = v
+					} else if k != "" {
 						lineTags[k] = v
 					}
 				}
@@ -345,11 +449,14 @@ func (s *Statsd) parseStatsdLine(line string) error {
 	// Extract bucket name from individual metric bits
 	bucketName, bits := bits[0], bits[1:]
 
+	metricTime := parseTimestamp(timestamp, time.Now())
+
 	// Add a metric for each bit available
 	for _, bit := range bits {
 		m := metric{}
 
 		m.bucket = bucketName
+		m.timestamp = metricTime
 
 		// Validate splitting the bit on "|"
 		pipesplit := strings.Split(bit, "|")
@@ -447,6 +554,10 @@ func (s *Statsd) parseStatsdLine(line string) error {
 		}
 		sort.Strings(tg)
 		m.hash = fmt.Sprintf("%s%s", strings.Join(tg, ""), m.name)
+		// datapoints with different timestamps are aggregated separately, unless aggregating by key
+		if !m.timestamp.IsZero() && !s.AggregateByKey {
+			m.hash = fmt.Sprintf("%s@%d", m.hash, m.timestamp.Unix())
+		}
 
 		s.aggregate(m)
 	}
@@ -524,9 +635,10 @@ func (s *Statsd) aggregate(m metric) {
 		cached, ok := s.timings[m.hash]
 		if !ok {
 			cached = cachedtimings{
-				name:   m.name,
-				fields: make(map[string]interface{}),
-				tags:   m.tags,
+				name:      m.name,
+				fields:    make(map[string]interface{}),
+				tags:      m.tags,
+				timestamp: m.timestamp,
 			}
 		}
 		// Check if the field exists. If we've not enabled multiple fields per timer
@@ -545,15 +657,17 @@ func (s *Statsd) aggregate(m metric) {
 			log.Printf("W! error: %s, metric: %s, value: %v", err, m.name, m.floatvalue)
 		}
 		cached.fields[m.field] = field
+		cached.timestamp = latest(cached.timestamp, m.timestamp)
 		s.timings[m.hash] = cached
 	case "c":
 		// check if the measurement exists
 		_, ok := s.counters[m.hash]
 		if !ok {
 			s.counters[m.hash] = cachedcounter{
-				name:   m.name,
-				fields: make(map[string]interface{}),
-				tags:   m.tags,
+				name:      m.name,
+				fields:    make(map[string]interface{}),
+				tags:      m.tags,
+				timestamp: m.timestamp,
 			}
 		}
 		// check if the field exists
@@ -563,14 +677,18 @@ func (s *Statsd) aggregate(m metric) {
 		}
 		s.counters[m.hash].fields[m.field] =
 			s.counters[m.hash].fields[m.field].(int64) + m.intvalue
+		cached := s.counters[m.hash]
+		cached.timestamp = latest(cached.timestamp, m.timestamp)
+		s.counters[m.hash] = cached
 	case "g":
 		// check if the measurement exists
 		_, ok := s.gauges[m.hash]
 		if !ok {
 			s.gauges[m.hash] = cachedgauge{
-				name:   m.name,
-				fields: make(map[string]interface{}),
-				tags:   m.tags,
+				name:      m.name,
+				fields:    make(map[string]interface{}),
+				tags:      m.tags,
+				timestamp: m.timestamp,
 			}
 		}
 		// check if the field exists
@@ -584,14 +702,21 @@ func (s *Statsd) aggregate(m metric) {
 		} else {
 			s.gauges[m.hash].fields[m.field] = m.floatvalue
 		}
+		if s.AggregateByKey {
+			cached := s.gauges[m.hash]
+			cached.addSample(m.field, cached.fields[m.field].(float64))
+			cached.timestamp = latest(cached.timestamp, m.timestamp)
+			s.gauges[m.hash] = cached
+		}
 	case "s":
 		// check if the measurement exists
 		_, ok := s.sets[m.hash]
 		if !ok {
 			s.sets[m.hash] = cachedset{
-				name:   m.name,
-				fields: make(map[string]map[string]bool),
-				tags:   m.tags,
+				name:      m.name,
+				fields:    make(map[string]map[string]bool),
+				tags:      m.tags,
+				timestamp: m.timestamp,
 			}
 		}
 		// check if the field exists
@@ -600,7 +725,52 @@ func (s *Statsd) aggregate(m metric) {
 			s.sets[m.hash].fields[m.field] = make(map[string]bool)
 		}
 		s.sets[m.hash].fields[m.field][m.strvalue] = true
+		cached := s.sets[m.hash]
+		cached.timestamp = latest(cached.timestamp, m.timestamp)
+		s.sets[m.hash] = cached
+	}
+}
+
+func (g *cachedgauge) addSample(field string, value float64) {
+	if g.samples == nil {
+		g.samples = make(map[string]*gaugeSamples)
+	}
+	samples, ok := g.samples[field]
+	if !ok {
+		samples = &gaugeSamples{}
+		g.samples[field] = samples
 	}
+	samples.sum += value
+	samples.count++
+}
+
+// averages returns the average of the values of each field since the samples were reset. A field without new values
+// reports its last value, which is the case for gauges that are not deleted on every interval.
+func (g *cachedgauge) averages() map[string]interface{} {
+	fields := make(map[string]interface{}, len(g.fields))
+	for field, value := range g.fields {
+		fields[field] = value
+		if samples, ok := g.samples[field]; ok && samples.count > 0 {
+			fields[field] = samples.sum / float64(samples.count)
+		}
+	}
+	return fields
+}
+
+// latest returns the later of the timestamps of the datapoints combined under a key, so that the datapoint is
+// stamped with the most recent time it was reported.
+func latest(a, b time.Time) time.Time {
+	if b.After(a) {
+		return b
+	}
+	return a
+}
+
+func timestampOrNow(timestamp, now time.Time) time.Time {
+	if timestamp.IsZero() {
+		return now
+	}
+	return timestamp
 }
 
 func (s *Statsd) Stop() {
@@ -609,6 +779,11 @@ func (s *Statsd) Stop() {
 	s.listener.Close()
 	s.wg.Wait()
 	close(s.in)
+	if s.socketPath != "" {
+		if err := os.Remove(s.socketPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			log.Printf("W! Failed to remove statsd socket %s: %v\n", s.socketPath, err)
+		}
+	}
 	log.Println("D! Stopped the statsd service")
 }
 
@@ -7,10 +7,17 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/influxdata/telegraf/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/aws/amazon-cloudwatch-agent/metric/distribution"
 	"github.com/aws/amazon-cloudwatch-agent/metric/distribution/seh1"
@@ -1363,6 +1370,74 @@ func TestParse_Counters_Delete(t *testing.T) {
 	}
 }
 
+func TestParse_AggregateByKey(t *testing.T) {
+	ts := time.Now().Add(-time.Minute).Truncate(time.Second)
+	var packets []string
+	for i := 0; i < 1000; i++ {
+		// the datapoints are spread over 10 seconds
+		stamp := ts.Add(-time.Duration(i%10) * time.Second).Unix()
+		packets = append(packets, fmt.Sprintf("requests:1|c|#route:/a|T%d\nrequests:2|c|#route:/b|T%d", stamp, stamp))
+		packets = append(packets, fmt.Sprintf("queue.depth:%d|g|#route:/a|T%d", i%10, stamp))
+	}
+	parse := func(s *Statsd) {
+		for _, packet := range packets {
+			for _, line := range strings.Split(packet, "\n") {
+				require.NoError(t, s.parseStatsdLine(line))
+			}
+		}
+	}
+
+	// each timestamp is a separate datapoint by default
+	s := NewTestStatsd()
+	s.ParseDataDogTags = true
+	parse(s)
+	acc := &testutil.Accumulator{}
+	require.NoError(t, s.Gather(acc))
+	assert.Len(t, acc.Metrics, 30)
+
+	s = NewTestStatsd()
+	s.ParseDataDogTags = true
+	s.AggregateByKey = true
+	parse(s)
+	acc = &testutil.Accumulator{}
+	require.NoError(t, s.Gather(acc))
+	require.Len(t, acc.Metrics, 3)
+	acc.AssertContainsTaggedFields(t, "requests", map[string]interface{}{"value": int64(1000)},
+		map[string]string{"metric_type": "counter", "route": "/a"})
+	acc.AssertContainsTaggedFields(t, "requests", map[string]interface{}{"value": int64(2000)},
+		map[string]string{"metric_type": "counter", "route": "/b"})
+	acc.AssertContainsTaggedFields(t, "queue_depth", map[string]interface{}{"value": 4.5},
+		map[string]string{"metric_type": "gauge", "route": "/a"})
+	// stamped with the latest timestamp of the datapoints, and published once
+	for _, m := range acc.Metrics {
+		assert.Equal(t, ts, m.Time)
+	}
+	assert.Empty(t, s.counters)
+	assert.Empty(t, s.gauges)
+}
+
+func TestParse_AggregateByKeyGauges(t *testing.T) {
+	s := NewTestStatsd()
+	s.AggregateByKey = true
+	for _, line := range []string{"latency:10|g", "latency:20|g", "latency:+10|g", "other:1|g"} {
+		require.NoError(t, s.parseStatsdLine(line))
+	}
+	acc := &testutil.Accumulator{}
+	require.NoError(t, s.Gather(acc))
+	// the additive value is averaged as the value of the gauge after the change
+	acc.AssertContainsFields(t, "latency", map[string]interface{}{"value": float64(20)})
+
+	// a gauge that is not deleted reports its last value until it gets new values
+	acc.ClearMetrics()
+	require.NoError(t, s.Gather(acc))
+	acc.AssertContainsFields(t, "latency", map[string]interface{}{"value": float64(30)})
+	require.NoError(t, s.parseStatsdLine("latency:2|g"))
+	require.NoError(t, s.parseStatsdLine("latency:4|g"))
+	acc.ClearMetrics()
+	require.NoError(t, s.Gather(acc))
+	acc.AssertContainsFields(t, "latency", map[string]interface{}{"value": float64(3)})
+}
+
 func TestParseKeyValue(t *testing.T) {
 	k, v := parseKeyValue("foo=bar")
 	if k != "foo" {
@@ -1479,6 +1554,69 @@ func test_validate_gauge(
 	return nil
 }
 
+func newUnixSocketStatsd(t *testing.T) (*Statsd, string) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unixgram sockets are not supported on windows")
+	}
+	path := filepath.Join(t.TempDir(), "statsd.sock")
+	s := &Statsd{
+		ServiceAddress:         unixSocketPrefix + path,
+		AllowedPendingMessages: defaultAllowPendingMessage,
+	}
+	return s, path
+}
+
+func TestUnixSocket(t *testing.T) {
+	s, path := newUnixSocketStatsd(t)
+	require.NoError(t, s.Start(nil))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.ModeSocket, info.Mode().Type())
+	assert.Equal(t, os.FileMode(unixSocketMode), info.Mode().Perm())
+
+	conn, err := net.Dial("unixgram", path)
+	require.NoError(t, err)
+	_, err = conn.Write([]byte("unix.socket.requests:3|c\nunix.socket.users:42|g\n"))
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	assert.Eventually(t, func() bool {
+		s.Lock()
+		defer s.Unlock()
+		return len(s.counters) == 1 && len(s.gauges) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	s.Lock()
+	assert.NoError(t, test_validate_counter("unix_socket_requests", 3, s.counters))
+	assert.NoError(t, test_validate_gauge("unix_socket_users", 42, s.gauges))
+	s.Unlock()
+
+	s.Stop()
+	assert.NoFileExists(t, path)
+}
+
+func TestUnixSocketStale(t *testing.T) {
+	s, path := newUnixSocketStatsd(t)
+
+	// the socket of an agent that did not stop cleanly
+	stale, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: path, Net: "unixgram"})
+	require.NoError(t, err)
+	require.NoError(t, stale.Close())
+	require.FileExists(t, path)
+
+	require.NoError(t, s.Start(nil))
+	s.Stop()
+	assert.NoFileExists(t, path)
+}
+
+func TestUnixSocketNotSocket(t *testing.T) {
+	s, path := newUnixSocketStatsd(t)
+	require.NoError(t, os.WriteFile(path, []byte("not a socket"), 0600))
+
+	assert.ErrorContains(t, s.Start(nil), "is used by a file that is not a socket")
+	assert.FileExists(t, path)
+}
+
 func init() {
 	distribution.NewDistribution = seh1.NewSEH1Distribution
 }
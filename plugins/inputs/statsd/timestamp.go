@@ -0,0 +1,70 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package statsd
+
+import (
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// timestampTagKey is the tag that sets the time of the datapoint, e.g. users.online:1|c|#ts:1700000000
+	timestampTagKey = "ts"
+	// timestampSegmentPrefix is the prefix of the dogstatsd timestamp extension, e.g. users.online:1|c|T1700000000
+	timestampSegmentPrefix = "T"
+
+	// CloudWatch rejects datapoints that are more than 2 weeks in the past or 2 hours in the future.
+	maxTimestampAge    = 14 * 24 * time.Hour
+	maxTimestampFuture = 2 * time.Hour
+)
+
+// extractTimestamp removes the timestamp segments from the line and returns the timestamp. Returns an empty
+// timestamp if the line has none.
+func extractTimestamp(line string) (string, string) {
+	segments := strings.Split(line, "|")
+	if len(segments) < 3 {
+		return line, ""
+	}
+	var timestamp string
+	result := []string{segments[0]}
+	for _, segment := range segments[1:] {
+		if value, ok := strings.CutPrefix(segment, timestampSegmentPrefix); ok && isUnixSeconds(value) {
+			timestamp = value
+			continue
+		}
+		if value, ok := strings.CutPrefix(segment, "#"+timestampTagKey+":"); ok && isUnixSeconds(value) {
+			timestamp = value
+			continue
+		}
+		result = append(result, segment)
+	}
+	return strings.Join(result, "|"), timestamp
+}
+
+func isUnixSeconds(value string) bool {
+	_, err := strconv.ParseInt(value, 10, 64)
+	return err == nil
+}
+
+// parseTimestamp returns the time of the datapoint from the unix seconds. Returns the zero time, so that
+// the datapoint is stamped when it is published, if the timestamp is invalid or outside the window that
+// CloudWatch accepts.
+func parseTimestamp(value string, now time.Time) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		log.Printf("W! Ignoring invalid statsd timestamp %q", value)
+		return time.Time{}
+	}
+	t := time.Unix(seconds, 0)
+	if t.Before(now.Add(-maxTimestampAge)) || t.After(now.Add(maxTimestampFuture)) {
+		log.Printf("W! Ignoring statsd timestamp %v outside the window accepted by CloudWatch", t.UTC())
+		return time.Time{}
+	}
+	return t
+}
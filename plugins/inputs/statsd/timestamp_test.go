@@ -0,0 +1,113 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package statsd
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse_Timestamp(t *testing.T) {
+	ts := time.Now().Add(-time.Hour).Truncate(time.Second)
+	testCases := map[string]struct {
+		line     string
+		datadog  bool
+		wantTags map[string]string
+	}{
+		"WithTimestampTag": {
+			line:     fmt.Sprintf("requests:5|c|#ts:%d", ts.Unix()),
+			wantTags: map[string]string{"metric_type": "counter"},
+		},
+		"WithDataDogTimestamp": {
+			line:     fmt.Sprintf("requests:5|c|@0.5|T%d", ts.Unix()),
+			wantTags: map[string]string{"metric_type": "counter"},
+		},
+		"WithDataDogTags": {
+			line:     fmt.Sprintf("requests:5|c|#host:localhost,ts:%d", ts.Unix()),
+			datadog:  true,
+			wantTags: map[string]string{"metric_type": "counter", "host": "localhost"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			s := NewTestStatsd()
+			s.ParseDataDogTags = testCase.datadog
+			require.NoError(t, s.parseStatsdLine(testCase.line))
+			require.Len(t, s.counters, 1)
+
+			acc := &testutil.Accumulator{}
+			require.NoError(t, s.Gather(acc))
+			require.Len(t, acc.Metrics, 1)
+			assert.Equal(t, ts, acc.Metrics[0].Time)
+			assert.Equal(t, testCase.wantTags, acc.Metrics[0].Tags)
+			// datapoints with a timestamp are only published once
+			assert.Empty(t, s.counters)
+		})
+	}
+}
+
+func TestParse_TimestampAggregation(t *testing.T) {
+	s := NewTestStatsd()
+	ts := time.Now().Add(-time.Minute).Truncate(time.Second)
+	lines := []string{
+		fmt.Sprintf("latency:1|g|T%d", ts.Unix()),
+		fmt.Sprintf("latency:2|g|T%d", ts.Unix()),
+		fmt.Sprintf("latency:3|g|T%d", ts.Add(-time.Minute).Unix()),
+		"latency:4|g",
+	}
+	for _, line := range lines {
+		require.NoError(t, s.parseStatsdLine(line))
+	}
+	require.Len(t, s.gauges, 3)
+
+	acc := &testutil.Accumulator{}
+	before := time.Now()
+	require.NoError(t, s.Gather(acc))
+	got := map[time.Time]interface{}{}
+	for _, m := range acc.Metrics {
+		if m.Time.Before(before) {
+			got[m.Time] = m.Fields["value"]
+		} else {
+			assert.Equal(t, float64(4), m.Fields["value"])
+		}
+	}
+	assert.Equal(t, map[time.Time]interface{}{ts: float64(2), ts.Add(-time.Minute): float64(3)}, got)
+}
+
+func TestParse_StaleTimestamp(t *testing.T) {
+	now := time.Now()
+	testCases := map[string]string{
+		"WithStaleTimestamp":   fmt.Sprintf("requests:5|c|#ts:%d", now.Add(-15*24*time.Hour).Unix()),
+		"WithFutureTimestamp":  fmt.Sprintf("requests:5|c|T%d", now.Add(3*time.Hour).Unix()),
+		"WithInvalidTimestamp": "requests:5|c|Tyesterday",
+	}
+	for name, line := range testCases {
+		t.Run(name, func(t *testing.T) {
+			s := NewTestStatsd()
+			require.NoError(t, s.parseStatsdLine(line))
+			require.Len(t, s.counters, 1)
+
+			acc := &testutil.Accumulator{}
+			before := time.Now()
+			require.NoError(t, s.Gather(acc))
+			require.Len(t, acc.Metrics, 1)
+			assert.False(t, acc.Metrics[0].Time.Before(before))
+			assert.Equal(t, int64(5), acc.Metrics[0].Fields["value"])
+		})
+	}
+}
+
+func TestParseTimestamp(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	assert.Equal(t, now.Add(-time.Hour), parseTimestamp("1699996400", now))
+	assert.Equal(t, now.Add(-maxTimestampAge), parseTimestamp(fmt.Sprint(now.Add(-maxTimestampAge).Unix()), now))
+	assert.True(t, parseTimestamp(fmt.Sprint(now.Add(-maxTimestampAge-time.Second).Unix()), now).IsZero())
+	assert.True(t, parseTimestamp(fmt.Sprint(now.Add(maxTimestampFuture+time.Second).Unix()), now).IsZero())
+	assert.True(t, parseTimestamp("", now).IsZero())
+}
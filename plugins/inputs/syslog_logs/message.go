@@ -0,0 +1,131 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package syslog_logs
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/leodido/go-syslog/v4"
+	"github.com/leodido/go-syslog/v4/rfc3164"
+	"github.com/leodido/go-syslog/v4/rfc5424"
+)
+
+var (
+	// rfc5424Header matches the priority and version that RFC 5424 messages start with, e.g. "<34>1 ".
+	rfc5424Header = regexp.MustCompile(`^<\d{1,3}>\d{1,3} `)
+
+	rfc5424Parser = rfc5424.NewParser(rfc5424.WithBestEffort())
+	rfc3164Parser = rfc3164.NewParser(rfc3164.WithBestEffort(), rfc3164.WithYear(rfc3164.CurrentYear{}))
+
+	errInvalidMessage = errors.New("message has no valid priority")
+)
+
+// message holds the fields of a syslog message that are sent as a structured event.
+type message struct {
+	Facility       string                       `json:"facility,omitempty"`
+	Severity       string                       `json:"severity,omitempty"`
+	Hostname       string                       `json:"hostname,omitempty"`
+	AppName        string                       `json:"app_name,omitempty"`
+	ProcID         string                       `json:"proc_id,omitempty"`
+	MsgID          string                       `json:"msg_id,omitempty"`
+	StructuredData map[string]map[string]string `json:"structured_data,omitempty"`
+	Message        string                       `json:"message"`
+
+	timestamp time.Time
+}
+
+// parseMessage parses the RFC 5424 or RFC 3164 message. Messages that are only partially valid are parsed as far as
+// possible, but a message without a valid priority is rejected.
+func parseMessage(raw []byte) (*message, error) {
+	var parsed syslog.Message
+	var err error
+	if rfc5424Header.Match(raw) {
+		parsed, err = rfc5424Parser.Parse(raw)
+	} else {
+		parsed, err = rfc3164Parser.Parse(raw)
+	}
+	if parsed == nil || !parsed.Valid() {
+		if err == nil {
+			err = errInvalidMessage
+		}
+		return nil, err
+	}
+	var base syslog.Base
+	result := &message{}
+	switch m := parsed.(type) {
+	case *rfc5424.SyslogMessage:
+		base = m.Base
+		if m.StructuredData != nil {
+			result.StructuredData = *m.StructuredData
+		}
+	case *rfc3164.SyslogMessage:
+		base = m.Base
+	default:
+		return nil, fmt.Errorf("unexpected message type %T", parsed)
+	}
+	result.Facility = value(parsed.FacilityLevel())
+	result.Severity = value(parsed.SeverityLevel())
+	result.Hostname = value(base.Hostname)
+	result.AppName = value(base.Appname)
+	result.ProcID = value(base.ProcID)
+	result.MsgID = value(base.MsgID)
+	result.Message = value(base.Message)
+	if base.Timestamp != nil {
+		result.timestamp = *base.Timestamp
+	}
+	return result, nil
+}
+
+func value(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+// readFrames calls fn for each message of a TCP stream. A message that starts with a digit is framed with its
+// length in octets followed by a space, and any other message ends with a newline.
+func readFrames(reader *bufio.Reader, fn func([]byte)) error {
+	for {
+		first, err := reader.Peek(1)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if first[0] >= '0' && first[0] <= '9' {
+			prefix, err := reader.ReadString(' ')
+			if err != nil {
+				return err
+			}
+			length, err := strconv.Atoi(prefix[:len(prefix)-1])
+			if err != nil || length <= 0 || length > maxMessageSize {
+				return fmt.Errorf("invalid message length %q", prefix[:len(prefix)-1])
+			}
+			frame := make([]byte, length)
+			if _, err = io.ReadFull(reader, frame); err != nil {
+				return err
+			}
+			fn(frame)
+			continue
+		}
+		line, err := reader.ReadSlice('\n')
+		if err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+		if len(line) > 0 {
+			fn(line)
+		}
+		if err != nil {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,296 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package syslog_logs
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+const (
+	defaultServiceAddress = "udp://127.0.0.1:514"
+	defaultLogStreamName  = "{syslog_hostname}"
+
+	hostnamePlaceholder = "{syslog_hostname}"
+	appNamePlaceholder  = "{syslog_app_name}"
+	// unknownValue replaces the placeholders of the fields that a message does not have.
+	unknownValue = "unknown"
+
+	// maxMessageSize is the largest message that is read, which is the largest UDP datagram.
+	maxMessageSize = 64 * 1024
+	// srcBufferSize is the number of events of a source that are buffered until the logs agent reads them.
+	srcBufferSize = 1000
+)
+
+type Syslog struct {
+	// ServiceAddress is the protocol and address to listen on, e.g. "udp://:514" or "tcp://127.0.0.1:601".
+	ServiceAddress  string          `toml:"service_address"`
+	LogGroupName    string          `toml:"log_group_name"`
+	LogStreamName   string          `toml:"log_stream_name"`
+	LogGroupClass   string          `toml:"log_group_class"`
+	RetentionInDays int             `toml:"retention_in_days"`
+	Destination     string          `toml:"destination"`
+	Log             telegraf.Logger `toml:"-"`
+
+	network string
+	address string
+
+	packetConn net.PacketConn
+	listener   net.Listener
+	done       chan struct{}
+	stopOnce   sync.Once
+	wg         sync.WaitGroup
+
+	mu sync.Mutex
+	// conns are the open TCP connections, which are closed when the plugin stops.
+	conns map[net.Conn]struct{}
+	// srcs are the sources of each log group and stream that the messages were sent to.
+	srcs    map[target]*syslogSrc
+	newSrcs []logs.LogSrc
+}
+
+type target struct {
+	group  string
+	stream string
+}
+
+var _ logs.LogCollection = (*Syslog)(nil)
+
+func NewSyslog() *Syslog {
+	return &Syslog{
+		ServiceAddress: defaultServiceAddress,
+		done:           make(chan struct{}),
+		conns:          make(map[net.Conn]struct{}),
+		srcs:           make(map[target]*syslogSrc),
+	}
+}
+
+func (s *Syslog) Description() string {
+	return "A plugin to receive syslog messages over UDP or TCP and send them to CloudWatch Logs as structured events"
+}
+
+func (s *Syslog) SampleConfig() string {
+	return `
+  ## The protocol and address to listen on: udp, udp4, udp6, tcp, tcp4 or tcp6.
+  service_address = "udp://127.0.0.1:514"
+  log_group_name = "syslog"
+  ## {syslog_hostname} and {syslog_app_name} are replaced with the fields of each message.
+  log_stream_name = "{syslog_hostname}"
+  destination = "cloudwatchlogs"
+`
+}
+
+func (s *Syslog) Gather(telegraf.Accumulator) error {
+	return nil
+}
+
+// Init validates the service address and the log group.
+func (s *Syslog) Init() error {
+	if s.ServiceAddress == "" {
+		s.ServiceAddress = defaultServiceAddress
+	}
+	network, address, ok := strings.Cut(s.ServiceAddress, "://")
+	if !ok {
+		return fmt.Errorf("invalid service_address %q: missing protocol", s.ServiceAddress)
+	}
+	switch network {
+	case "udp", "udp4", "udp6", "tcp", "tcp4", "tcp6":
+	default:
+		return fmt.Errorf("invalid service_address %q: unsupported protocol %q", s.ServiceAddress, network)
+	}
+	s.network, s.address = network, address
+	if s.LogGroupName == "" {
+		return errors.New("log_group_name is required")
+	}
+	if s.LogStreamName == "" {
+		s.LogStreamName = defaultLogStreamName
+	}
+	return nil
+}
+
+// Start listens for syslog messages on the service address.
+func (s *Syslog) Start(telegraf.Accumulator) error {
+	if err := s.Init(); err != nil {
+		return err
+	}
+	var err error
+	if strings.HasPrefix(s.network, "udp") {
+		if s.packetConn, err = net.ListenPacket(s.network, s.address); err != nil {
+			return fmt.Errorf("unable to listen on %v: %w", s.ServiceAddress, err)
+		}
+		s.wg.Add(1)
+		go s.readPackets()
+	} else {
+		if s.listener, err = net.Listen(s.network, s.address); err != nil {
+			return fmt.Errorf("unable to listen on %v: %w", s.ServiceAddress, err)
+		}
+		s.wg.Add(1)
+		go s.acceptConns()
+	}
+	s.Log.Infof("Listening for syslog messages on %v", s.ServiceAddress)
+	return nil
+}
+
+func (s *Syslog) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.done)
+		if s.packetConn != nil {
+			_ = s.packetConn.Close()
+		}
+		if s.listener != nil {
+			_ = s.listener.Close()
+		}
+		s.mu.Lock()
+		for conn := range s.conns {
+			_ = conn.Close()
+		}
+		s.mu.Unlock()
+		s.wg.Wait()
+		s.mu.Lock()
+		for _, src := range s.srcs {
+			src.Stop()
+		}
+		s.mu.Unlock()
+	})
+}
+
+func (s *Syslog) FindLogSrc() []logs.LogSrc {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	srcs := s.newSrcs
+	s.newSrcs = nil
+	return srcs
+}
+
+// addr returns the address that the plugin is listening on.
+func (s *Syslog) addr() net.Addr {
+	if s.packetConn != nil {
+		return s.packetConn.LocalAddr()
+	}
+	if s.listener != nil {
+		return s.listener.Addr()
+	}
+	return nil
+}
+
+// readPackets handles each UDP datagram as a single message.
+func (s *Syslog) readPackets() {
+	defer s.wg.Done()
+	buf := make([]byte, maxMessageSize)
+	for {
+		n, _, err := s.packetConn.ReadFrom(buf)
+		if err != nil {
+			if !s.stopped() {
+				s.Log.Errorf("Stopped reading syslog messages on %v: %v", s.ServiceAddress, err)
+			}
+			return
+		}
+		s.handle(buf[:n])
+	}
+}
+
+func (s *Syslog) acceptConns() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if !s.stopped() {
+				s.Log.Errorf("Stopped accepting syslog connections on %v: %v", s.ServiceAddress, err)
+			}
+			return
+		}
+		s.mu.Lock()
+		s.conns[conn] = struct{}{}
+		s.mu.Unlock()
+		s.wg.Add(1)
+		go s.readConn(conn)
+	}
+}
+
+// readConn reads the messages of a TCP connection until it is closed. The messages are framed with either octet
+// counting or a trailing newline as described in RFC 6587.
+func (s *Syslog) readConn(conn net.Conn) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		_ = conn.Close()
+		s.wg.Done()
+	}()
+	err := readFrames(bufio.NewReaderSize(conn, maxMessageSize), s.handle)
+	if err != nil && !s.stopped() {
+		s.Log.Warnf("Closing syslog connection from %v: %v", conn.RemoteAddr(), err)
+	}
+}
+
+// handle parses the message and publishes it to the source of its log group and stream. Messages that cannot be
+// parsed are sent as is.
+func (s *Syslog) handle(raw []byte) {
+	raw = bytes.TrimRight(raw, "\r\n")
+	if len(raw) == 0 {
+		return
+	}
+	event := LogEvent{msg: string(raw), t: time.Now()}
+	hostname, appName := unknownValue, unknownValue
+	msg, err := parseMessage(raw)
+	if err != nil {
+		s.Log.Debugf("Sending syslog message that cannot be parsed as is: %v", err)
+	} else if encoded, err := json.Marshal(msg); err != nil {
+		s.Log.Errorf("Unable to encode syslog message, sending it as is: %v", err)
+	} else {
+		event.msg = string(encoded)
+		if !msg.timestamp.IsZero() {
+			event.t = msg.timestamp
+		}
+		hostname, appName = orUnknown(msg.Hostname), orUnknown(msg.AppName)
+	}
+	replacer := strings.NewReplacer(hostnamePlaceholder, hostname, appNamePlaceholder, appName)
+	t := target{group: replacer.Replace(s.LogGroupName), stream: replacer.Replace(s.LogStreamName)}
+	s.getSrc(t).publish(event)
+}
+
+// getSrc returns the source of the target, which is created the first time a message is sent to it.
+func (s *Syslog) getSrc(t target) *syslogSrc {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	src, ok := s.srcs[t]
+	if !ok {
+		src = newSyslogSrc(s, t)
+		s.srcs[t] = src
+		s.newSrcs = append(s.newSrcs, src)
+	}
+	return src
+}
+
+func orUnknown(value string) string {
+	if value == "" {
+		return unknownValue
+	}
+	return value
+}
+
+func (s *Syslog) stopped() bool {
+	select {
+	case <-s.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func init() {
+	inputs.Add("syslog_logs", func() telegraf.Input { return NewSyslog() })
+}
@@ -0,0 +1,228 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package syslog_logs
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+const (
+	rfc5424Message   = `<165>1 2024-03-01T12:30:45.123Z web-1 nginx 4321 ID47 [origin ip="10.0.0.1" software="nginx"] GET /index.html 200`
+	rfc3164Message   = `<34>Oct 11 22:14:15 db-1 sshd[1234]: Failed password for root from 10.0.0.2`
+	malformedMessage = `connection reset by peer`
+)
+
+func TestParseMessage(t *testing.T) {
+	msg, err := parseMessage([]byte(rfc5424Message))
+	require.NoError(t, err)
+	assert.Equal(t, &message{
+		Facility:       "local4",
+		Severity:       "notice",
+		Hostname:       "web-1",
+		AppName:        "nginx",
+		ProcID:         "4321",
+		MsgID:          "ID47",
+		StructuredData: map[string]map[string]string{"origin": {"ip": "10.0.0.1", "software": "nginx"}},
+		Message:        "GET /index.html 200",
+		timestamp:      time.Date(2024, 3, 1, 12, 30, 45, 123000000, time.UTC),
+	}, msg)
+
+	msg, err = parseMessage([]byte(rfc3164Message))
+	require.NoError(t, err)
+	assert.Equal(t, "auth", msg.Facility)
+	assert.Equal(t, "critical", msg.Severity)
+	assert.Equal(t, "db-1", msg.Hostname)
+	assert.Equal(t, "sshd", msg.AppName)
+	assert.Equal(t, "1234", msg.ProcID)
+	assert.Empty(t, msg.MsgID)
+	assert.Nil(t, msg.StructuredData)
+	assert.Equal(t, "Failed password for root from 10.0.0.2", msg.Message)
+	// RFC 3164 timestamps do not have a year
+	assert.Equal(t, time.October, msg.timestamp.Month())
+	assert.Equal(t, 11, msg.timestamp.Day())
+	assert.Equal(t, 22, msg.timestamp.Hour())
+	assert.Equal(t, time.Now().Year(), msg.timestamp.Year())
+
+	_, err = parseMessage([]byte(malformedMessage))
+	assert.Error(t, err)
+	_, err = parseMessage([]byte(`<999>1 2024-03-01T12:30:45Z host app - - - invalid priority`))
+	assert.Error(t, err)
+}
+
+func TestInit(t *testing.T) {
+	testCases := map[string]struct {
+		address string
+		group   string
+		wantErr string
+	}{
+		"MissingProtocol":     {address: "127.0.0.1:514", group: "syslog", wantErr: "missing protocol"},
+		"UnsupportedProtocol": {address: "http://127.0.0.1:514", group: "syslog", wantErr: "unsupported protocol"},
+		"MissingLogGroup":     {address: "udp://127.0.0.1:514", wantErr: "log_group_name is required"},
+		"Valid":               {address: "tcp6://[::1]:601", group: "syslog"},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			s := NewSyslog()
+			s.ServiceAddress = testCase.address
+			s.LogGroupName = testCase.group
+			err := s.Init()
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, err, testCase.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "tcp6", s.network)
+			assert.Equal(t, "[::1]:601", s.address)
+			assert.Equal(t, defaultLogStreamName, s.LogStreamName)
+		})
+	}
+}
+
+type testOutput struct {
+	events chan logs.LogEvent
+	closed chan struct{}
+}
+
+// collect sets the output of the sources until there are n of them, and returns them by log group and stream.
+func collect(t *testing.T, s *Syslog, n int) map[string]*testOutput {
+	t.Helper()
+	outputs := map[string]*testOutput{}
+	require.Eventually(t, func() bool {
+		for _, src := range s.FindLogSrc() {
+			output := &testOutput{events: make(chan logs.LogEvent, 10), closed: make(chan struct{})}
+			outputs[src.Group()+"/"+src.Stream()] = output
+			assert.Equal(t, "cloudwatchlogs", src.Destination())
+			src.SetOutput(func(event logs.LogEvent) {
+				if event == nil {
+					close(output.closed)
+					return
+				}
+				output.events <- event
+			})
+		}
+		return len(outputs) == n
+	}, 5*time.Second, 10*time.Millisecond)
+	return outputs
+}
+
+func receive(t *testing.T, output *testOutput) logs.LogEvent {
+	t.Helper()
+	select {
+	case event := <-output.events:
+		return event
+	case <-time.After(5 * time.Second):
+		require.FailNow(t, "event not published")
+		return nil
+	}
+}
+
+func decode(t *testing.T, event logs.LogEvent) map[string]interface{} {
+	t.Helper()
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(event.Message()), &fields))
+	return fields
+}
+
+func startSyslog(t *testing.T, address string) *Syslog {
+	t.Helper()
+	s := NewSyslog()
+	s.ServiceAddress = address
+	s.LogGroupName = "syslog/{syslog_app_name}"
+	s.LogStreamName = "{syslog_hostname}"
+	s.Destination = "cloudwatchlogs"
+	s.Log = testutil.Logger{Name: "syslog_logs"}
+	require.NoError(t, s.Start(nil))
+	return s
+}
+
+func TestSyslogUDP(t *testing.T) {
+	s := startSyslog(t, "udp://127.0.0.1:0")
+	conn, err := net.Dial("udp", s.addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	for _, msg := range []string{rfc5424Message, rfc3164Message + "\n", malformedMessage} {
+		_, err = conn.Write([]byte(msg))
+		require.NoError(t, err)
+		// keep the order of the datagrams
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	outputs := collect(t, s, 3)
+	require.Contains(t, outputs, "syslog/nginx/web-1")
+	event := receive(t, outputs["syslog/nginx/web-1"])
+	assert.Equal(t, time.Date(2024, 3, 1, 12, 30, 45, 123000000, time.UTC), event.Time())
+	assert.Equal(t, map[string]interface{}{
+		"facility":        "local4",
+		"severity":        "notice",
+		"hostname":        "web-1",
+		"app_name":        "nginx",
+		"proc_id":         "4321",
+		"msg_id":          "ID47",
+		"structured_data": map[string]interface{}{"origin": map[string]interface{}{"ip": "10.0.0.1", "software": "nginx"}},
+		"message":         "GET /index.html 200",
+	}, decode(t, event))
+
+	require.Contains(t, outputs, "syslog/sshd/db-1")
+	fields := decode(t, receive(t, outputs["syslog/sshd/db-1"]))
+	assert.Equal(t, "critical", fields["severity"])
+	assert.Equal(t, "Failed password for root from 10.0.0.2", fields["message"])
+
+	// malformed messages are sent as is
+	require.Contains(t, outputs, "syslog/unknown/unknown")
+	event = receive(t, outputs["syslog/unknown/unknown"])
+	assert.Equal(t, malformedMessage, event.Message())
+	assert.WithinDuration(t, time.Now(), event.Time(), time.Minute)
+
+	s.Stop()
+	for _, output := range outputs {
+		select {
+		case <-output.closed:
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "output not closed")
+		}
+	}
+}
+
+func TestSyslogTCP(t *testing.T) {
+	s := startSyslog(t, "tcp://127.0.0.1:0")
+	conn, err := net.Dial("tcp", s.addr().String())
+	require.NoError(t, err)
+	// octet counting and newline framing can be mixed on a connection
+	_, err = fmt.Fprintf(conn, "%d %s", len(rfc5424Message), rfc5424Message)
+	require.NoError(t, err)
+	_, err = fmt.Fprintf(conn, "%s\r\n%s\n", strings.Replace(rfc5424Message, "GET", "POST", 1), malformedMessage)
+	require.NoError(t, err)
+	require.NoError(t, conn.Close())
+
+	outputs := collect(t, s, 2)
+	require.Contains(t, outputs, "syslog/nginx/web-1")
+	assert.Equal(t, "GET /index.html 200", decode(t, receive(t, outputs["syslog/nginx/web-1"]))["message"])
+	assert.Equal(t, "POST /index.html 200", decode(t, receive(t, outputs["syslog/nginx/web-1"]))["message"])
+	require.Contains(t, outputs, "syslog/unknown/unknown")
+	assert.Equal(t, malformedMessage, receive(t, outputs["syslog/unknown/unknown"]).Message())
+	s.Stop()
+}
+
+func TestReadFrames(t *testing.T) {
+	var frames []string
+	input := "11 <13>1 - - -" + "<13>Oct 11 22:14:15 host app: hello\n" + "no trailing newline"
+	require.NoError(t, readFrames(bufio.NewReader(strings.NewReader(input)), func(frame []byte) {
+		frames = append(frames, string(frame))
+	}))
+	assert.Equal(t, []string{"<13>1 - - -", "<13>Oct 11 22:14:15 host app: hello\n", "no trailing newline"}, frames)
+
+	assert.ErrorContains(t, readFrames(bufio.NewReader(strings.NewReader("99999999 message")), func([]byte) {}), "invalid message length")
+}
@@ -0,0 +1,128 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package syslog_logs
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+)
+
+type LogEvent struct {
+	msg string
+	t   time.Time
+}
+
+func (le LogEvent) Message() string {
+	return le.msg
+}
+
+func (le LogEvent) Time() time.Time {
+	return le.t
+}
+
+func (le LogEvent) Done() {}
+
+// syslogSrc is the log source of the messages that are sent to a log group and stream. The messages are buffered
+// until the logs agent sets the output of the source.
+type syslogSrc struct {
+	plugin *Syslog
+	target target
+
+	events   chan logs.LogEvent
+	full     atomic.Bool
+	outputFn func(logs.LogEvent)
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+var _ logs.LogSrc = (*syslogSrc)(nil)
+
+func newSyslogSrc(plugin *Syslog, t target) *syslogSrc {
+	return &syslogSrc{
+		plugin: plugin,
+		target: t,
+		events: make(chan logs.LogEvent, srcBufferSize),
+		done:   make(chan struct{}),
+	}
+}
+
+func (s *syslogSrc) SetOutput(fn func(logs.LogEvent)) {
+	if fn == nil {
+		return
+	}
+	s.outputFn = fn
+	go s.run()
+}
+
+func (s *syslogSrc) Group() string {
+	return s.target.group
+}
+
+func (s *syslogSrc) Stream() string {
+	return s.target.stream
+}
+
+func (s *syslogSrc) Description() string {
+	return "syslog " + s.plugin.ServiceAddress + " " + s.target.group + "/" + s.target.stream
+}
+
+func (s *syslogSrc) Destination() string {
+	return s.plugin.Destination
+}
+
+func (s *syslogSrc) Retention() int {
+	return s.plugin.RetentionInDays
+}
+
+func (s *syslogSrc) Class() string {
+	return s.plugin.LogGroupClass
+}
+
+func (s *syslogSrc) Entity() *cloudwatchlogs.Entity {
+	return nil
+}
+
+func (s *syslogSrc) Stop() {
+	s.stopOnce.Do(func() {
+		close(s.done)
+	})
+}
+
+// publish buffers the event for the output. The event is dropped if the buffer is full, such as when the messages
+// are received faster than they are sent.
+func (s *syslogSrc) publish(event LogEvent) {
+	select {
+	case s.events <- event:
+		s.full.Store(false)
+	default:
+		if s.full.CompareAndSwap(false, true) {
+			s.plugin.Log.Warnf("Dropping syslog messages of %v/%v since they are received faster than they are sent", s.target.group, s.target.stream)
+		}
+	}
+}
+
+// run sends the buffered events to the output until the source is stopped. The events that are still buffered when
+// the source is stopped are sent before the output is closed.
+func (s *syslogSrc) run() {
+	defer s.outputFn(nil)
+	for {
+		select {
+		case event := <-s.events:
+			s.outputFn(event)
+		case <-s.done:
+			for {
+				select {
+				case event := <-s.events:
+					s.outputFn(event)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
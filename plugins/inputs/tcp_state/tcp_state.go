@@ -0,0 +1,252 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package tcp_state
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/containerinsightscommon"
+)
+
+//go:embed sample.conf
+var sampleConfig string
+
+const (
+	measurement     = "tcp_state"
+	defaultProcPath = "/proc"
+	portTag         = "port"
+	listenState     = "listen"
+	totalField      = "total"
+)
+
+var (
+	errInvalidSampleRate = errors.New("sample_rate must be at least 1")
+
+	// tables are the connection tables of IPv4 and IPv6 in the proc filesystem.
+	tables = []string{"net/tcp", "net/tcp6"}
+
+	// stateNames maps the hex state codes of the connection tables to the field names, following the order of the
+	// states in include/net/tcp_states.h.
+	stateNames = map[string]string{
+		"01": "established",
+		"02": "syn_sent",
+		"03": "syn_recv",
+		"04": "fin_wait1",
+		"05": "fin_wait2",
+		"06": "time_wait",
+		"07": "close",
+		"08": "close_wait",
+		"09": "last_ack",
+		"0A": listenState,
+		"0B": "closing",
+	}
+)
+
+// TCPState counts the TCP connections of the host in each state, which helps to find connection leaks such as
+// connections piling up in CLOSE_WAIT. The counts can also be broken down by the listening port the connections
+// were accepted on.
+type TCPState struct {
+	ProcPath   string          `toml:"proc_path"`
+	PerPort    bool            `toml:"per_port"`
+	Ports      []int           `toml:"ports"`
+	SampleRate int             `toml:"sample_rate"`
+	Log        telegraf.Logger `toml:"-"`
+}
+
+// counts holds the number of connections in each state, in total and by the local port.
+type counts struct {
+	states    map[string]int64
+	ports     map[int]map[string]int64
+	listening map[int]bool
+}
+
+func newCounts() *counts {
+	return &counts{
+		states:    map[string]int64{},
+		ports:     map[int]map[string]int64{},
+		listening: map[int]bool{},
+	}
+}
+
+func (*TCPState) SampleConfig() string {
+	return sampleConfig
+}
+
+func (*TCPState) Description() string {
+	return "Collects the number of TCP connections in each state from /proc/net/tcp and /proc/net/tcp6"
+}
+
+func (t *TCPState) Init() error {
+	if t.ProcPath == "" {
+		t.ProcPath = defaultProcPath
+		if hostProc := os.Getenv(containerinsightscommon.GoPSUtilProcDirEnv); hostProc != "" {
+			t.ProcPath = hostProc
+		}
+	}
+	if t.SampleRate == 0 {
+		t.SampleRate = 1
+	}
+	if t.SampleRate < 1 {
+		return errInvalidSampleRate
+	}
+	return nil
+}
+
+func (t *TCPState) Gather(acc telegraf.Accumulator) error {
+	c := newCounts()
+	for _, table := range tables {
+		err := t.readTable(filepath.Join(t.ProcPath, table), c)
+		// The IPv6 table does not exist when IPv6 is disabled.
+		if errors.Is(err, os.ErrNotExist) && table != tables[0] {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+	}
+	acc.AddGauge(measurement, stateFields(c.states, true), map[string]string{})
+	if t.PerPort {
+		for _, port := range t.listeningPorts(c) {
+			acc.AddGauge(measurement, stateFields(c.ports[port], false), map[string]string{portTag: strconv.Itoa(port)})
+		}
+	}
+	return nil
+}
+
+// listeningPorts returns the listening ports that are counted per port, limited to the configured ports if any.
+func (t *TCPState) listeningPorts(c *counts) []int {
+	var ports []int
+	if len(t.Ports) > 0 {
+		for _, port := range t.Ports {
+			if c.listening[port] {
+				ports = append(ports, port)
+			}
+		}
+		return ports
+	}
+	for port := range c.listening {
+		ports = append(ports, port)
+	}
+	return ports
+}
+
+func (t *TCPState) readTable(path string, c *counts) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return parseTable(f, t.SampleRate, t.PerPort, c)
+}
+
+// parseTable counts the connections of a table with the format
+//
+//	sl  local_address rem_address   st tx_queue rx_queue ...
+//	 0: 0100007F:0CEA 0100007F:9C40 08 00000000:00000001 ...
+//
+// Only 1 of every sampleRate connections is counted and its count is scaled by the rate. The listening sockets are
+// always counted, since there are few of them and the per port counts depend on them.
+func parseTable(r io.Reader, sampleRate int, perPort bool, c *counts) error {
+	scanner := bufio.NewScanner(r)
+	// skip the header
+	scanner.Scan()
+	for i := 0; scanner.Scan(); i++ {
+		// The state is looked up before splitting the line, so the connections that are not sampled are skipped
+		// without allocating.
+		line := scanner.Bytes()
+		state, ok := stateNames[string(field(line, 3))]
+		if !ok {
+			continue
+		}
+		if state != listenState && i%sampleRate != 0 {
+			continue
+		}
+		weight := int64(sampleRate)
+		if state == listenState {
+			weight = 1
+		}
+		c.states[state] += weight
+		if !perPort {
+			continue
+		}
+		port, err := localPort(string(field(line, 1)))
+		if err != nil {
+			return fmt.Errorf("invalid local address in %q: %w", line, err)
+		}
+		if state == listenState {
+			c.listening[port] = true
+			continue
+		}
+		if c.ports[port] == nil {
+			c.ports[port] = map[string]int64{}
+		}
+		c.ports[port][state] += weight
+	}
+	return scanner.Err()
+}
+
+// field returns the nth whitespace separated field of the line, or nil if the line has fewer fields.
+func field(line []byte, n int) []byte {
+	for {
+		line = bytes.TrimLeft(line, " \t")
+		if len(line) == 0 {
+			return nil
+		}
+		end := bytes.IndexAny(line, " \t")
+		if end < 0 {
+			end = len(line)
+		}
+		if n == 0 {
+			return line[:end]
+		}
+		line = line[end:]
+		n--
+	}
+}
+
+// localPort returns the port of a hex encoded address, such as 0100007F:0CEA.
+func localPort(address string) (int, error) {
+	_, hexPort, ok := strings.Cut(address, ":")
+	if !ok {
+		return 0, fmt.Errorf("missing port in %q", address)
+	}
+	port, err := strconv.ParseUint(hexPort, 16, 16)
+	if err != nil {
+		return 0, err
+	}
+	return int(port), nil
+}
+
+// stateFields returns a field for every state, so the states without connections are reported as 0, and the total.
+func stateFields(states map[string]int64, withListen bool) map[string]interface{} {
+	fields := map[string]interface{}{}
+	var total int64
+	for _, state := range stateNames {
+		if state == listenState && !withListen {
+			continue
+		}
+		fields[state] = states[state]
+		total += states[state]
+	}
+	fields[totalField] = total
+	return fields
+}
+
+func init() {
+	inputs.Add("tcp_state", func() telegraf.Input {
+		return &TCPState{SampleRate: 1}
+	})
+}
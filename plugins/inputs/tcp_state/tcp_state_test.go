@@ -0,0 +1,154 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package tcp_state
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// zeroStates returns the fields of the states without connections, along with the given ones.
+func zeroStates(withListen bool, fields map[string]interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	for _, state := range stateNames {
+		if state == listenState && !withListen {
+			continue
+		}
+		result[state] = int64(0)
+	}
+	for k, v := range fields {
+		result[k] = v
+	}
+	return result
+}
+
+func TestInit(t *testing.T) {
+	t.Setenv("HOST_PROC", "")
+	plugin := &TCPState{}
+	require.NoError(t, plugin.Init())
+	assert.Equal(t, "/proc", plugin.ProcPath)
+	assert.Equal(t, 1, plugin.SampleRate)
+
+	t.Setenv("HOST_PROC", "/rootfs/proc")
+	plugin = &TCPState{}
+	require.NoError(t, plugin.Init())
+	assert.Equal(t, "/rootfs/proc", plugin.ProcPath)
+
+	plugin = &TCPState{SampleRate: -1}
+	assert.ErrorIs(t, plugin.Init(), errInvalidSampleRate)
+}
+
+func TestParseTable(t *testing.T) {
+	f, err := os.Open(filepath.Join("testdata", "net", "tcp"))
+	require.NoError(t, err)
+	defer f.Close()
+	c := newCounts()
+	require.NoError(t, parseTable(f, 1, true, c))
+	assert.Equal(t, map[string]int64{
+		"listen":      2,
+		"established": 3,
+		"close_wait":  2,
+		"time_wait":   2,
+		"syn_sent":    1,
+	}, c.states)
+	assert.Equal(t, map[int]bool{22: true, 3306: true}, c.listening)
+	assert.Equal(t, map[string]int64{"established": 2}, c.ports[22])
+	assert.Equal(t, map[string]int64{"close_wait": 2, "time_wait": 1}, c.ports[3306])
+}
+
+func TestParseTableWithSampling(t *testing.T) {
+	var sb strings.Builder
+	sb.WriteString("  sl  local_address rem_address   st tx_queue rx_queue tr tm->when retrnsmt   uid  timeout inode\n")
+	sb.WriteString("   0: 00000000:1F90 00000000:0000 0A 00000000:00000000 00:00000000 00000000     0        0 1 1\n")
+	for i := 1; i <= 1000; i++ {
+		fmt.Fprintf(&sb, "%4d: 0A00000F:1F90 0A000001:%04X 01 00000000:00000000 00:00000000 00000000     0        0 %d 1\n", i, 40000+i, i+1)
+	}
+	c := newCounts()
+	require.NoError(t, parseTable(strings.NewReader(sb.String()), 10, true, c))
+	assert.Equal(t, int64(1), c.states["listen"])
+	assert.Equal(t, int64(1000), c.states["established"])
+	assert.Equal(t, map[int]bool{8080: true}, c.listening)
+	assert.Equal(t, int64(1000), c.ports[8080]["established"])
+}
+
+func TestParseTableInvalidAddress(t *testing.T) {
+	input := "  sl  local_address rem_address   st\n   0: 00000000 00000000:0000 0A\n"
+	assert.Error(t, parseTable(strings.NewReader(input), 1, true, newCounts()))
+	// the address is not parsed without per port counts
+	c := newCounts()
+	assert.NoError(t, parseTable(strings.NewReader(input), 1, false, c))
+	assert.Equal(t, map[string]int64{"listen": 1}, c.states)
+}
+
+func TestGather(t *testing.T) {
+	plugin := &TCPState{ProcPath: "testdata", Log: testutil.Logger{}}
+	require.NoError(t, plugin.Init())
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	require.Len(t, acc.Metrics, 1)
+	assert.Equal(t, "tcp_state", acc.Metrics[0].Measurement)
+	assert.Empty(t, acc.Metrics[0].Tags)
+	assert.Equal(t, zeroStates(true, map[string]interface{}{
+		"listen":      int64(3),
+		"established": int64(4),
+		"close_wait":  int64(2),
+		"time_wait":   int64(3),
+		"syn_sent":    int64(1),
+		"total":       int64(13),
+	}), acc.Metrics[0].Fields)
+}
+
+func TestGatherPerPort(t *testing.T) {
+	plugin := &TCPState{ProcPath: "testdata", PerPort: true, Log: testutil.Logger{}}
+	require.NoError(t, plugin.Init())
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	require.Len(t, acc.Metrics, 4)
+	acc.AssertContainsTaggedFields(t, "tcp_state", zeroStates(false, map[string]interface{}{
+		"established": int64(2),
+		"total":       int64(2),
+	}), map[string]string{"port": "22"})
+	acc.AssertContainsTaggedFields(t, "tcp_state", zeroStates(false, map[string]interface{}{
+		"close_wait": int64(2),
+		"time_wait":  int64(1),
+		"total":      int64(3),
+	}), map[string]string{"port": "3306"})
+	acc.AssertContainsTaggedFields(t, "tcp_state", zeroStates(false, map[string]interface{}{
+		"established": int64(1),
+		"time_wait":   int64(1),
+		"total":       int64(2),
+	}), map[string]string{"port": "80"})
+
+	// limited to the configured ports that are listening
+	plugin.Ports = []int{80, 443}
+	acc.ClearMetrics()
+	require.NoError(t, plugin.Gather(&acc))
+	require.Len(t, acc.Metrics, 2)
+	assert.Equal(t, map[string]string{"port": "80"}, acc.Metrics[1].Tags)
+}
+
+func TestGatherWithoutIPv6(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "net"), 0755))
+	content, err := os.ReadFile(filepath.Join("testdata", "net", "tcp"))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "net", "tcp"), content, 0600))
+
+	plugin := &TCPState{ProcPath: dir, Log: testutil.Logger{}}
+	require.NoError(t, plugin.Init())
+	var acc testutil.Accumulator
+	require.NoError(t, plugin.Gather(&acc))
+	require.Len(t, acc.Metrics, 1)
+	assert.Equal(t, int64(10), acc.Metrics[0].Fields["total"])
+
+	require.NoError(t, os.Remove(filepath.Join(dir, "net", "tcp")))
+	assert.ErrorIs(t, plugin.Gather(&acc), os.ErrNotExist)
+}
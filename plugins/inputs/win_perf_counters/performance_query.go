@@ -0,0 +1,97 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package win_perf_counters
+
+import (
+	"strings"
+
+	"github.com/influxdata/telegraf"
+)
+
+const (
+	// noInstance is used for objects that do not have instances, e.g. Memory.
+	noInstance       = "------"
+	wildcardInstance = "*"
+	totalInstance    = "_Total"
+)
+
+// CounterValue is the formatted value of a counter for a single instance.
+type CounterValue struct {
+	InstanceName string
+	Value        float64
+}
+
+// PerformanceQuery collects the current values of a counter. A wildcard
+// counter path returns a value for each instance that exists at the time of
+// collection, so instances that appear or disappear between scrapes are picked
+// up or dropped without re-parsing the config.
+type PerformanceQuery interface {
+	CollectData() error
+	GetFormattedCounterArrayDouble() ([]CounterValue, error)
+}
+
+// counterDefinition is the configured counter a query was created for.
+type counterDefinition struct {
+	objectName   string
+	counter      string
+	instance     string
+	measurement  string
+	includeTotal bool
+}
+
+// gatherCounter collects the query and adds a metric for each selected
+// instance, with the instance as a tag.
+func gatherCounter(acc telegraf.Accumulator, query PerformanceQuery, def counterDefinition, convertName func(string) string) error {
+	if err := query.CollectData(); err != nil {
+		return err
+	}
+	values, err := query.GetFormattedCounterArrayDouble()
+	if err != nil {
+		return err
+	}
+	measurement := convertName(def.measurement)
+	if measurement == "" {
+		measurement = "win_perf_counters"
+	}
+	for _, value := range values {
+		instance, ok := selectInstance(def, value.InstanceName)
+		if !ok {
+			continue
+		}
+		fields := map[string]interface{}{
+			convertName(def.counter): float32(value.Value),
+		}
+		tags := map[string]string{
+			"objectname": def.objectName,
+		}
+		if instance != "" {
+			tags["instance"] = instance
+		}
+		acc.AddFields(measurement, fields, tags)
+	}
+	return nil
+}
+
+// selectInstance returns the instance name to tag the value with and whether
+// the instance returned by PDH matches the configured instance.
+func selectInstance(def counterDefinition, name string) (string, bool) {
+	switch {
+	case def.includeTotal:
+		// If IncludeTotal is set, include all.
+		return name, true
+	case def.instance == wildcardInstance:
+		// Catch if set to * and that it is not a '*_Total*' instance.
+		return name, !strings.Contains(name, totalInstance)
+	case def.instance == name:
+		// Catch if we set it to total or some form of it
+		return name, true
+	case strings.Contains(def.instance, "#") && strings.HasPrefix(def.instance, name):
+		// If you are using a multiple instance identifier such as "w3wp#1"
+		// phd.dll returns only the first 2 characters of the identifier.
+		return def.instance, true
+	case def.instance == noInstance:
+		return name, true
+	}
+	return "", false
+}
@@ -0,0 +1,121 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package win_perf_counters
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockQuery returns the next set of values on each collection to simulate
+// instances changing between scrapes.
+type mockQuery struct {
+	scrapes    [][]CounterValue
+	collectErr error
+	collected  int
+}
+
+var _ PerformanceQuery = (*mockQuery)(nil)
+
+func (q *mockQuery) CollectData() error {
+	if q.collectErr != nil {
+		return q.collectErr
+	}
+	q.collected++
+	return nil
+}
+
+func (q *mockQuery) GetFormattedCounterArrayDouble() ([]CounterValue, error) {
+	return q.scrapes[q.collected-1], nil
+}
+
+func identity(s string) string {
+	return s
+}
+
+func TestGatherCounterWildcardInstances(t *testing.T) {
+	query := &mockQuery{
+		scrapes: [][]CounterValue{
+			{{InstanceName: "0", Value: 10}, {InstanceName: "1", Value: 20}, {InstanceName: "_Total", Value: 15}},
+			// instance 1 disappeared and instance 2 appeared
+			{{InstanceName: "0", Value: 30}, {InstanceName: "2", Value: 40}, {InstanceName: "_Total", Value: 35}},
+		},
+	}
+	def := counterDefinition{
+		objectName:  "Processor",
+		counter:     "% Processor Time",
+		instance:    wildcardInstance,
+		measurement: "Processor",
+	}
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, gatherCounter(acc, query, def, identity))
+	require.Len(t, acc.Metrics, 2)
+	acc.AssertContainsTaggedFields(t, "Processor",
+		map[string]interface{}{"% Processor Time": float32(10)},
+		map[string]string{"instance": "0", "objectname": "Processor"})
+	acc.AssertContainsTaggedFields(t, "Processor",
+		map[string]interface{}{"% Processor Time": float32(20)},
+		map[string]string{"instance": "1", "objectname": "Processor"})
+
+	acc = &testutil.Accumulator{}
+	require.NoError(t, gatherCounter(acc, query, def, identity))
+	require.Len(t, acc.Metrics, 2)
+	acc.AssertContainsTaggedFields(t, "Processor",
+		map[string]interface{}{"% Processor Time": float32(30)},
+		map[string]string{"instance": "0", "objectname": "Processor"})
+	acc.AssertContainsTaggedFields(t, "Processor",
+		map[string]interface{}{"% Processor Time": float32(40)},
+		map[string]string{"instance": "2", "objectname": "Processor"})
+	acc.AssertDoesNotContainsTaggedFields(t, "Processor",
+		map[string]interface{}{"% Processor Time": float32(20)},
+		map[string]string{"instance": "1", "objectname": "Processor"})
+}
+
+func TestGatherCounterNoInstance(t *testing.T) {
+	query := &mockQuery{scrapes: [][]CounterValue{{{Value: 1024}}}}
+	def := counterDefinition{objectName: "Memory", counter: "Available Bytes", instance: noInstance}
+
+	acc := &testutil.Accumulator{}
+	require.NoError(t, gatherCounter(acc, query, def, identity))
+	require.Len(t, acc.Metrics, 1)
+	acc.AssertContainsTaggedFields(t, "win_perf_counters",
+		map[string]interface{}{"Available Bytes": float32(1024)},
+		map[string]string{"objectname": "Memory"})
+}
+
+func TestGatherCounterCollectError(t *testing.T) {
+	query := &mockQuery{collectErr: errors.New("collect failed")}
+	acc := &testutil.Accumulator{}
+	assert.Error(t, gatherCounter(acc, query, counterDefinition{instance: wildcardInstance}, identity))
+	assert.Empty(t, acc.Metrics)
+}
+
+func TestSelectInstance(t *testing.T) {
+	testCases := map[string]struct {
+		def      counterDefinition
+		name     string
+		want     string
+		wantOkay bool
+	}{
+		"Wildcard":          {def: counterDefinition{instance: "*"}, name: "C:", want: "C:", wantOkay: true},
+		"WildcardTotal":     {def: counterDefinition{instance: "*"}, name: "_Total", want: "_Total"},
+		"IncludeTotal":      {def: counterDefinition{instance: "*", includeTotal: true}, name: "_Total", want: "_Total", wantOkay: true},
+		"Exact":             {def: counterDefinition{instance: "C:"}, name: "C:", want: "C:", wantOkay: true},
+		"Mismatch":          {def: counterDefinition{instance: "C:"}, name: "D:"},
+		"MultipleInstances": {def: counterDefinition{instance: "w3wp#1"}, name: "w3", want: "w3wp#1", wantOkay: true},
+		"NoInstance":        {def: counterDefinition{instance: noInstance}, name: "", want: "", wantOkay: true},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := selectInstance(testCase.def, testCase.name)
+			assert.Equal(t, testCase.wantOkay, ok)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
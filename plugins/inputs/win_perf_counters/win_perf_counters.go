@@ -136,6 +136,56 @@ func (item *item) init() error {
 	return nil
 }
 
+func (item *item) definition() counterDefinition {
+	return counterDefinition{
+		objectName:   item.objectName,
+		counter:      item.counter,
+		instance:     item.instance,
+		measurement:  item.measurement,
+		includeTotal: item.include_total,
+	}
+}
+
+// CollectData implements PerformanceQuery.
+func (item *item) CollectData() error {
+	if ret := PdhCollectQueryData(item.handle); ret != ERROR_SUCCESS {
+		return errors.New(PdhFormatError(ret))
+	}
+	return nil
+}
+
+// GetFormattedCounterArrayDouble implements PerformanceQuery.
+func (item *item) GetFormattedCounterArrayDouble() ([]CounterValue, error) {
+	var bufSize uint32
+	var bufCount uint32
+	var size = uint32(unsafe.Sizeof(PDH_FMT_COUNTERVALUE_ITEM_DOUBLE{}))
+	var emptyBuf [1]PDH_FMT_COUNTERVALUE_ITEM_DOUBLE // need at least 1 addressable null ptr.
+
+	ret := PdhGetFormattedCounterArrayDouble(item.counterHandle, &bufSize,
+		&bufCount, &emptyBuf[0]) // uses null ptr here according to MSDN.
+	if ret != PDH_MORE_DATA {
+		return nil, nil
+	}
+	filledBuf := make([]PDH_FMT_COUNTERVALUE_ITEM_DOUBLE, bufCount*size)
+	if len(filledBuf) == 0 {
+		return nil, nil
+	}
+	ret = PdhGetFormattedCounterArrayDouble(item.counterHandle,
+		&bufSize, &bufCount, &filledBuf[0])
+	if ret != ERROR_SUCCESS {
+		return nil, errors.New(PdhFormatError(ret))
+	}
+	values := make([]CounterValue, 0, bufCount)
+	for i := 0; i < int(bufCount); i++ {
+		c := filledBuf[i]
+		values = append(values, CounterValue{
+			InstanceName: UTF16PtrToString(c.SzName),
+			Value:        c.FmtValue.DoubleValue,
+		})
+	}
+	return values, nil
+}
+
 var sanitizedChars = strings.NewReplacer("/sec", "_persec", "/Sec", "_persec",
 	" ", "_", "%", "Percent", `\`, "")
 
@@ -184,7 +234,7 @@ func (m *Win_PerfCounters) ParseConfig(metrics *itemList) error {
 				for _, instance := range PerfObject.Instances {
 					objectname := PerfObject.ObjectName
 
-					if instance == "------" {
+					if instance == noInstance {
 						query = "\\" + objectname + "\\" + counter
 					} else {
 						query = "\\" + objectname + "(" + instance + ")\\" + counter
@@ -256,11 +306,6 @@ func (m *Win_PerfCounters) Gather(acc telegraf.Accumulator) error {
 		}
 	}
 
-	var bufSize uint32
-	var bufCount uint32
-	var size uint32 = uint32(unsafe.Sizeof(PDH_FMT_COUNTERVALUE_ITEM_DOUBLE{}))
-	var emptyBuf [1]PDH_FMT_COUNTERVALUE_ITEM_DOUBLE // need at least 1 addressable null ptr.
-
 	// For iterate over the known metrics and get the samples.
 	for _, metric := range m.gItemList {
 		if !metric.initialized {
@@ -269,66 +314,8 @@ func (m *Win_PerfCounters) Gather(acc telegraf.Accumulator) error {
 				continue
 			}
 		}
-		// collect
-		ret := PdhCollectQueryData(metric.handle)
-		if ret == ERROR_SUCCESS {
-			ret = PdhGetFormattedCounterArrayDouble(metric.counterHandle, &bufSize,
-				&bufCount, &emptyBuf[0]) // uses null ptr here according to MSDN.
-			if ret == PDH_MORE_DATA {
-				filledBuf := make([]PDH_FMT_COUNTERVALUE_ITEM_DOUBLE, bufCount*size)
-				if len(filledBuf) == 0 {
-					continue
-				}
-				ret = PdhGetFormattedCounterArrayDouble(metric.counterHandle,
-					&bufSize, &bufCount, &filledBuf[0])
-				for i := 0; i < int(bufCount); i++ {
-					c := filledBuf[i]
-					var s string = UTF16PtrToString(c.SzName)
-
-					var add bool
-
-					if metric.include_total {
-						// If IncludeTotal is set, include all.
-						add = true
-					} else if metric.instance == "*" && !strings.Contains(s, "_Total") {
-						// Catch if set to * and that it is not a '*_Total*' instance.
-						add = true
-					} else if metric.instance == s {
-						// Catch if we set it to total or some form of it
-						add = true
-					} else if strings.Contains(metric.instance, "#") && strings.HasPrefix(metric.instance, s) {
-						// If you are using a multiple instance identifier such as "w3wp#1"
-						// phd.dll returns only the first 2 characters of the identifier.
-						add = true
-						s = metric.instance
-					} else if metric.instance == "------" {
-						add = true
-					}
-
-					if add {
-						fields := make(map[string]interface{})
-						tags := make(map[string]string)
-						if s != "" {
-							tags["instance"] = s
-						}
-						tags["objectname"] = metric.objectName
-						fields[m.convertName(metric.counter)] =
-							float32(c.FmtValue.DoubleValue)
-
-						measurement := m.convertName(metric.measurement)
-						if measurement == "" {
-							measurement = "win_perf_counters"
-						}
-						acc.AddFields(measurement, fields, tags)
-					}
-				}
-
-				filledBuf = nil
-				// Need to at least set bufSize to zero, because if not, the function will not
-				// return PDH_MORE_DATA and will not set the bufSize.
-				bufCount = 0
-				bufSize = 0
-			}
+		if err := gatherCounter(acc, metric, metric.definition(), m.convertName); err != nil {
+			log.Printf("D! unable to collect %s: %v", metric.query, err)
 		}
 	}
 
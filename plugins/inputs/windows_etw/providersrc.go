@@ -0,0 +1,188 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package windows_etw
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+)
+
+var levelNames = map[uint8]string{
+	0: "LogAlways",
+	1: "Critical",
+	2: "Error",
+	3: "Warning",
+	4: "Information",
+	5: "Verbose",
+}
+
+type LogEvent struct {
+	msg string
+	t   time.Time
+}
+
+func (le LogEvent) Message() string {
+	return le.msg
+}
+
+func (le LogEvent) Time() time.Time {
+	return le.t
+}
+
+func (le LogEvent) Done() {}
+
+// eventMessage is the message of the log event of an ETW event.
+type eventMessage struct {
+	ProviderName string            `json:"provider_name,omitempty"`
+	ProviderGUID string            `json:"provider_guid"`
+	EventID      uint16            `json:"event_id"`
+	Version      uint8             `json:"version"`
+	Level        string            `json:"level"`
+	Opcode       uint8             `json:"opcode"`
+	Task         uint16            `json:"task"`
+	Keywords     string            `json:"keywords"`
+	ProcessID    uint32            `json:"process_id"`
+	ThreadID     uint32            `json:"thread_id"`
+	ActivityID   string            `json:"activity_id,omitempty"`
+	Message      string            `json:"message,omitempty"`
+	Properties   map[string]string `json:"properties,omitempty"`
+}
+
+// providerSrc is the log source of a single provider. The events of the provider are buffered in a
+// bounded queue so that a slow output does not block the trace session; events are dropped when the
+// queue is full.
+type providerSrc struct {
+	plugin   *WindowsETW
+	config   *ProviderConfig
+	eventIDs map[uint16]struct{}
+
+	queue    chan *Event
+	dropped  atomic.Uint64
+	outputFn func(logs.LogEvent)
+	done     chan struct{}
+	runOnce  sync.Once
+	stopOnce sync.Once
+}
+
+var _ logs.LogSrc = (*providerSrc)(nil)
+
+func newProviderSrc(plugin *WindowsETW, config *ProviderConfig, queueSize int) *providerSrc {
+	var eventIDs map[uint16]struct{}
+	if len(config.EventIDs) > 0 {
+		eventIDs = make(map[uint16]struct{}, len(config.EventIDs))
+		for _, id := range config.EventIDs {
+			eventIDs[uint16(id)] = struct{}{}
+		}
+	}
+	return &providerSrc{
+		plugin:   plugin,
+		config:   config,
+		eventIDs: eventIDs,
+		queue:    make(chan *Event, queueSize),
+		done:     make(chan struct{}),
+	}
+}
+
+func (s *providerSrc) SetOutput(fn func(logs.LogEvent)) {
+	if fn == nil {
+		return
+	}
+	s.outputFn = fn
+	s.runOnce.Do(func() { go s.run() })
+}
+
+func (s *providerSrc) Group() string {
+	return s.config.LogGroupName
+}
+
+func (s *providerSrc) Stream() string {
+	return s.config.LogStreamName
+}
+
+func (s *providerSrc) Description() string {
+	return fmt.Sprintf("etw provider %v", s.config.displayName())
+}
+
+func (s *providerSrc) Destination() string {
+	return s.config.Destination
+}
+
+func (s *providerSrc) Retention() int {
+	return s.config.RetentionInDays
+}
+
+func (s *providerSrc) Class() string {
+	return s.config.LogGroupClass
+}
+
+func (s *providerSrc) Entity() *cloudwatchlogs.Entity {
+	return nil
+}
+
+func (s *providerSrc) Stop() {
+	s.stopOnce.Do(func() { close(s.done) })
+}
+
+// enqueue is called from the trace session for each event of the provider. It never blocks.
+func (s *providerSrc) enqueue(event *Event) {
+	if s.eventIDs != nil {
+		if _, ok := s.eventIDs[event.EventID]; !ok {
+			return
+		}
+	}
+	select {
+	case s.queue <- event:
+	default:
+		s.dropped.Add(1)
+	}
+}
+
+func (s *providerSrc) run() {
+	defer s.outputFn(nil)
+	for {
+		select {
+		case event := <-s.queue:
+			s.publish(event)
+		case <-s.done:
+			return
+		}
+	}
+}
+
+func (s *providerSrc) publish(event *Event) {
+	message, err := json.Marshal(s.toMessage(event))
+	if err != nil {
+		s.plugin.Log.Errorf("Unable to encode event %d of etw provider %v: %v", event.EventID, s.config.displayName(), err)
+		return
+	}
+	s.outputFn(LogEvent{msg: string(message), t: event.Timestamp})
+}
+
+func (s *providerSrc) toMessage(event *Event) eventMessage {
+	level, ok := levelNames[event.Level]
+	if !ok {
+		level = fmt.Sprintf("%d", event.Level)
+	}
+	return eventMessage{
+		ProviderName: s.config.ProviderName,
+		ProviderGUID: event.ProviderGUID,
+		EventID:      event.EventID,
+		Version:      event.Version,
+		Level:        level,
+		Opcode:       event.Opcode,
+		Task:         event.Task,
+		Keywords:     fmt.Sprintf("0x%x", event.Keyword),
+		ProcessID:    event.ProcessID,
+		ThreadID:     event.ThreadID,
+		ActivityID:   event.ActivityID,
+		Message:      event.Message,
+		Properties:   event.Properties,
+	}
+}
@@ -0,0 +1,56 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package windows_etw
+
+import (
+	"time"
+)
+
+// sessionConfig is the buffer configuration of the real-time trace session.
+type sessionConfig struct {
+	name           string
+	bufferSizeKB   uint32
+	minimumBuffers uint32
+	maximumBuffers uint32
+	flushInterval  time.Duration
+}
+
+// sessionStats are the counters of the trace session used to report events lost by ETW when the
+// session buffers are full.
+type sessionStats struct {
+	eventsLost          uint32
+	realTimeBuffersLost uint32
+}
+
+// Event is an ETW event decoded from the trace session.
+type Event struct {
+	ProviderGUID string
+	EventID      uint16
+	Version      uint8
+	Level        uint8
+	Opcode       uint8
+	Task         uint16
+	Keyword      uint64
+	ProcessID    uint32
+	ThreadID     uint32
+	ActivityID   string
+	Timestamp    time.Time
+	// Properties are the top level properties of the event formatted as strings.
+	Properties map[string]string
+	// Message is set instead of Properties for events that only carry a string.
+	Message string
+}
+
+// session is a real-time ETW trace session that the providers are enabled on.
+type session interface {
+	// EnableProvider enables the provider on the session with its level and keywords.
+	EnableProvider(provider *ProviderConfig) error
+	// DisableProvider stops the provider from writing events to the session.
+	DisableProvider(provider *ProviderConfig) error
+	// Process delivers the events of the enabled providers to the handler and blocks until the
+	// session is closed.
+	Process(handler func(*Event)) error
+	Stats() (sessionStats, error)
+	Close() error
+}
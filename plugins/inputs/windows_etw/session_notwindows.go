@@ -0,0 +1,13 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build !windows
+// +build !windows
+
+package windows_etw
+
+import "errors"
+
+func newETWSession(sessionConfig) (session, error) {
+	return nil, errors.New("etw is only supported on windows")
+}
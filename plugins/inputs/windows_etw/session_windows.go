@@ -0,0 +1,301 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+// +build windows
+
+package windows_etw
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// windowsEpochOffset is the number of 100ns intervals between the FILETIME and the unix epochs.
+const windowsEpochOffset = 116444736000000000
+
+var (
+	// eventRecordCallback is shared by all the sessions since the number of callbacks that can be
+	// created is limited. The sessions are looked up with the context of the trace.
+	eventRecordCallback = syscall.NewCallback(onEventRecord)
+
+	sessionsMu    sync.RWMutex
+	sessions      = map[uintptr]*etwSession{}
+	lastSessionID uintptr
+)
+
+type etwSession struct {
+	id     uintptr
+	config sessionConfig
+	name   *uint16
+	handle uint64
+
+	mu          sync.RWMutex
+	providers   map[windows.GUID]struct{}
+	handler     func(*Event)
+	traceHandle uint64
+	closed      bool
+}
+
+var _ session = (*etwSession)(nil)
+
+// newETWSession starts a real-time trace session. A session with the same name that was not stopped,
+// e.g. because the agent crashed, is stopped first.
+func newETWSession(config sessionConfig) (session, error) {
+	if len(config.name) >= maxSessionNameLength {
+		return nil, fmt.Errorf("session name is longer than %d characters", maxSessionNameLength-1)
+	}
+	name, err := windows.UTF16PtrFromString(config.name)
+	if err != nil {
+		return nil, err
+	}
+	s := &etwSession{
+		config:      config,
+		name:        name,
+		providers:   map[windows.GUID]struct{}{},
+		traceHandle: invalidProcessTraceHandle,
+	}
+	err = startTrace(&s.handle, name, s.newProperties())
+	if errors.Is(err, windows.ERROR_ALREADY_EXISTS) {
+		if err = controlTrace(0, name, s.newProperties(), eventTraceControlStop); err != nil {
+			return nil, fmt.Errorf("unable to stop existing session: %w", err)
+		}
+		err = startTrace(&s.handle, name, s.newProperties())
+	}
+	if err != nil {
+		return nil, err
+	}
+	sessionsMu.Lock()
+	lastSessionID++
+	s.id = lastSessionID
+	sessions[s.id] = s
+	sessionsMu.Unlock()
+	return s, nil
+}
+
+func (s *etwSession) newProperties() *traceProperties {
+	properties := &traceProperties{}
+	properties.Wnode.BufferSize = uint32(unsafe.Sizeof(*properties))
+	// use the query performance counter for the timestamps of the events
+	properties.Wnode.ClientContext = 1
+	properties.Wnode.Flags = wnodeFlagTracedGUID
+	properties.BufferSize = s.config.bufferSizeKB
+	properties.MinimumBuffers = s.config.minimumBuffers
+	properties.MaximumBuffers = s.config.maximumBuffers
+	properties.LogFileMode = eventTraceRealTimeMode
+	properties.FlushTimer = uint32(s.config.flushInterval / time.Second)
+	properties.LoggerNameOffset = uint32(unsafe.Offsetof(properties.loggerName))
+	return properties
+}
+
+func (s *etwSession) EnableProvider(provider *ProviderConfig) error {
+	guid, err := windows.GUIDFromString(provider.guid)
+	if err != nil {
+		return err
+	}
+	if err = enableTraceEx2(s.handle, &guid, eventControlCodeEnableProvider, provider.Level, provider.matchAny, provider.matchAll); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.providers[guid] = struct{}{}
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *etwSession) DisableProvider(provider *ProviderConfig) error {
+	guid, err := windows.GUIDFromString(provider.guid)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	delete(s.providers, guid)
+	s.mu.Unlock()
+	return enableTraceEx2(s.handle, &guid, eventControlCodeDisableProvider, 0, 0, 0)
+}
+
+// Process consumes the real-time events of the session. It returns when the session is closed.
+func (s *etwSession) Process(handler func(*Event)) error {
+	logfile := &eventTraceLogfile{
+		LoggerName:          s.name,
+		ProcessTraceMode:    processTraceModeRealTime | processTraceModeEventRecord,
+		EventRecordCallback: eventRecordCallback,
+		Context:             s.id,
+	}
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.handler = handler
+	traceHandle, err := openTrace(logfile)
+	if err != nil {
+		s.mu.Unlock()
+		return fmt.Errorf("unable to open trace: %w", err)
+	}
+	s.traceHandle = traceHandle
+	s.mu.Unlock()
+
+	err = processTrace(&traceHandle)
+	if errors.Is(err, windows.ERROR_CANCELLED) {
+		return nil
+	}
+	return err
+}
+
+func (s *etwSession) Stats() (sessionStats, error) {
+	properties := s.newProperties()
+	if err := controlTrace(s.handle, nil, properties, eventTraceControlQuery); err != nil {
+		return sessionStats{}, err
+	}
+	return sessionStats{
+		eventsLost:          properties.EventsLost,
+		realTimeBuffersLost: properties.RealTimeBuffersLost,
+	}, nil
+}
+
+// Close stops consuming the events and stops the session.
+func (s *etwSession) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	traceHandle := s.traceHandle
+	s.mu.Unlock()
+
+	var errs []error
+	if traceHandle != invalidProcessTraceHandle {
+		if err := closeTrace(traceHandle); err != nil {
+			errs = append(errs, fmt.Errorf("unable to close trace: %w", err))
+		}
+	}
+	if err := controlTrace(s.handle, nil, s.newProperties(), eventTraceControlStop); err != nil {
+		errs = append(errs, fmt.Errorf("unable to stop session: %w", err))
+	}
+	sessionsMu.Lock()
+	delete(sessions, s.id)
+	sessionsMu.Unlock()
+	return errors.Join(errs...)
+}
+
+func onEventRecord(record *eventRecord) uintptr {
+	sessionsMu.RLock()
+	s, ok := sessions[record.UserContext]
+	sessionsMu.RUnlock()
+	if !ok {
+		return 0
+	}
+	s.mu.RLock()
+	_, enabled := s.providers[record.EventHeader.ProviderId]
+	handler := s.handler
+	s.mu.RUnlock()
+	// skip the header event of the trace and the events of disabled providers before decoding them
+	if enabled && handler != nil {
+		handler(decodeEvent(record))
+	}
+	return 0
+}
+
+func decodeEvent(record *eventRecord) *Event {
+	header := &record.EventHeader
+	event := &Event{
+		ProviderGUID: formatGUID(header.ProviderId),
+		EventID:      header.EventDescriptor.Id,
+		Version:      header.EventDescriptor.Version,
+		Level:        header.EventDescriptor.Level,
+		Opcode:       header.EventDescriptor.Opcode,
+		Task:         header.EventDescriptor.Task,
+		Keyword:      header.EventDescriptor.Keyword,
+		ProcessID:    header.ProcessId,
+		ThreadID:     header.ThreadId,
+		Timestamp:    time.Unix(0, (header.TimeStamp-windowsEpochOffset)*100),
+	}
+	if header.ActivityId != (windows.GUID{}) {
+		event.ActivityID = formatGUID(header.ActivityId)
+	}
+	if header.Flags&eventHeaderFlagStringOnly != 0 {
+		if record.UserDataLength > 0 {
+			event.Message = windows.UTF16ToString(unsafe.Slice((*uint16)(record.UserData), record.UserDataLength/2))
+		}
+		return event
+	}
+	// the properties that cannot be decoded are left out, the header of the event is still sent
+	event.Properties, _ = decodeProperties(record)
+	return event
+}
+
+// decodeProperties formats the top level properties of the event with the schema of the provider. The
+// decoding stops at the first array or structure property since their size is not known.
+func decodeProperties(record *eventRecord) (map[string]string, error) {
+	var bufferSize uint32
+	if err := tdhGetEventInformation(record, nil, &bufferSize); !errors.Is(err, windows.ERROR_INSUFFICIENT_BUFFER) {
+		return nil, err
+	}
+	// use uint64 to align the TRACE_EVENT_INFO
+	buffer := make([]uint64, (bufferSize+7)/8)
+	info := (*traceEventInfo)(unsafe.Pointer(&buffer[0]))
+	if err := tdhGetEventInformation(record, info, &bufferSize); err != nil {
+		return nil, err
+	}
+	propertyInfos := unsafe.Slice((*eventPropertyInfo)(unsafe.Add(unsafe.Pointer(info), unsafe.Sizeof(*info))), info.PropertyCount)
+
+	pointerSize := uint32(8)
+	if record.EventHeader.Flags&eventHeaderFlag32BitHeader != 0 {
+		pointerSize = 4
+	}
+	properties := make(map[string]string, info.TopLevelPropertyCount)
+	// the integer values of the properties, used for the length of the properties that refer to them
+	values := map[int]uint64{}
+	userData := record.UserData
+	remaining := record.UserDataLength
+	formatted := make([]uint16, 256)
+	for i := 0; i < int(info.TopLevelPropertyCount) && remaining > 0; i++ {
+		property := &propertyInfos[i]
+		if property.Flags&(propertyStruct|propertyParamCount) != 0 || property.Count > 1 {
+			break
+		}
+		length := property.Length
+		if property.Flags&propertyParamLength != 0 {
+			value, ok := values[int(property.Length)]
+			if !ok {
+				break
+			}
+			length = uint16(value)
+		}
+		var consumed uint16
+		for {
+			formattedSize := uint32(len(formatted) * 2)
+			err := tdhFormatProperty(info, pointerSize, property, length, remaining, userData, &formattedSize, &formatted[0], &consumed)
+			if errors.Is(err, windows.ERROR_INSUFFICIENT_BUFFER) {
+				formatted = make([]uint16, formattedSize/2+1)
+				continue
+			}
+			if err != nil {
+				return properties, err
+			}
+			break
+		}
+		name := windows.UTF16PtrToString((*uint16)(unsafe.Add(unsafe.Pointer(info), property.NameOffset)))
+		value := windows.UTF16ToString(formatted)
+		properties[name] = value
+		if v, err := strconv.ParseUint(value, 0, 64); err == nil {
+			values[i] = v
+		}
+		userData = unsafe.Add(userData, consumed)
+		remaining -= consumed
+	}
+	return properties, nil
+}
+
+func formatGUID(guid windows.GUID) string {
+	return fmt.Sprintf("{%08X-%04X-%04X-%02X%02X-%02X%02X%02X%02X%02X%02X}", guid.Data1, guid.Data2, guid.Data3,
+		guid.Data4[0], guid.Data4[1], guid.Data4[2], guid.Data4[3], guid.Data4[4], guid.Data4[5], guid.Data4[6], guid.Data4[7])
+}
@@ -0,0 +1,277 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+//go:build windows
+// +build windows
+
+package windows_etw
+
+import (
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+// https://learn.microsoft.com/en-us/windows/win32/etw/event-trace-properties
+// https://learn.microsoft.com/en-us/windows/win32/api/evntrace/ns-evntrace-event_trace_logfilew
+// https://learn.microsoft.com/en-us/windows/win32/api/evntcons/ns-evntcons-event_record
+const (
+	wnodeFlagTracedGUID         = 0x00020000
+	eventTraceRealTimeMode      = 0x00000100
+	processTraceModeRealTime    = 0x00000100
+	processTraceModeEventRecord = 0x10000000
+
+	eventTraceControlQuery = 0
+	eventTraceControlStop  = 1
+
+	eventControlCodeDisableProvider = 0
+	eventControlCodeEnableProvider  = 1
+
+	eventHeaderFlagStringOnly  = 0x0004
+	eventHeaderFlag32BitHeader = 0x0020
+
+	propertyStruct      = 0x1
+	propertyParamLength = 0x2
+	propertyParamCount  = 0x4
+
+	invalidProcessTraceHandle = ^uint64(0)
+	maxSessionNameLength      = 1024
+)
+
+var (
+	modadvapi32 = windows.NewLazySystemDLL("advapi32.dll")
+	modtdh      = windows.NewLazySystemDLL("tdh.dll")
+
+	procStartTraceW            = modadvapi32.NewProc("StartTraceW")
+	procControlTraceW          = modadvapi32.NewProc("ControlTraceW")
+	procEnableTraceEx2         = modadvapi32.NewProc("EnableTraceEx2")
+	procOpenTraceW             = modadvapi32.NewProc("OpenTraceW")
+	procProcessTrace           = modadvapi32.NewProc("ProcessTrace")
+	procCloseTrace             = modadvapi32.NewProc("CloseTrace")
+	procTdhGetEventInformation = modtdh.NewProc("TdhGetEventInformation")
+	procTdhFormatProperty      = modtdh.NewProc("TdhFormatProperty")
+)
+
+type wnodeHeader struct {
+	BufferSize        uint32
+	ProviderId        uint32
+	HistoricalContext uint64
+	TimeStamp         int64
+	Guid              windows.GUID
+	ClientContext     uint32
+	Flags             uint32
+}
+
+type eventTraceProperties struct {
+	Wnode               wnodeHeader
+	BufferSize          uint32
+	MinimumBuffers      uint32
+	MaximumBuffers      uint32
+	MaximumFileSize     uint32
+	LogFileMode         uint32
+	FlushTimer          uint32
+	EnableFlags         uint32
+	AgeLimit            int32
+	NumberOfBuffers     uint32
+	FreeBuffers         uint32
+	EventsLost          uint32
+	BuffersWritten      uint32
+	LogBuffersLost      uint32
+	RealTimeBuffersLost uint32
+	LoggerThreadId      windows.Handle
+	LogFileNameOffset   uint32
+	LoggerNameOffset    uint32
+}
+
+// traceProperties is an EVENT_TRACE_PROPERTIES followed by the space the session name is copied to.
+type traceProperties struct {
+	eventTraceProperties
+	loggerName [maxSessionNameLength]uint16
+}
+
+type eventTraceHeader struct {
+	Size           uint16
+	FieldTypeFlags uint16
+	Version        uint32
+	ThreadId       uint32
+	ProcessId      uint32
+	TimeStamp      int64
+	Guid           windows.GUID
+	ProcessorTime  uint64
+}
+
+type eventTrace struct {
+	Header           eventTraceHeader
+	InstanceId       uint32
+	ParentInstanceId uint32
+	ParentGuid       windows.GUID
+	MofData          uintptr
+	MofLength        uint32
+	ClientContext    uint32
+}
+
+type traceLogfileHeader struct {
+	BufferSize         uint32
+	Version            uint32
+	ProviderVersion    uint32
+	NumberOfProcessors uint32
+	EndTime            int64
+	TimerResolution    uint32
+	MaximumFileSize    uint32
+	LogFileMode        uint32
+	BuffersWritten     uint32
+	LogInstanceGuid    windows.GUID
+	LoggerName         *uint16
+	LogFileName        *uint16
+	TimeZone           windows.Timezoneinformation
+	BootTime           int64
+	PerfFreq           int64
+	StartTime          int64
+	ReservedFlags      uint32
+	BuffersLost        uint32
+}
+
+type eventTraceLogfile struct {
+	LogFileName         *uint16
+	LoggerName          *uint16
+	CurrentTime         int64
+	BuffersRead         uint32
+	ProcessTraceMode    uint32
+	CurrentEvent        eventTrace
+	LogfileHeader       traceLogfileHeader
+	BufferCallback      uintptr
+	BufferSize          uint32
+	Filled              uint32
+	EventsLost          uint32
+	EventRecordCallback uintptr
+	IsKernelTrace       uint32
+	Context             uintptr
+}
+
+type eventDescriptor struct {
+	Id      uint16
+	Version uint8
+	Channel uint8
+	Level   uint8
+	Opcode  uint8
+	Task    uint16
+	Keyword uint64
+}
+
+type eventHeader struct {
+	Size            uint16
+	HeaderType      uint16
+	Flags           uint16
+	EventProperty   uint16
+	ThreadId        uint32
+	ProcessId       uint32
+	TimeStamp       int64
+	ProviderId      windows.GUID
+	EventDescriptor eventDescriptor
+	ProcessorTime   uint64
+	ActivityId      windows.GUID
+}
+
+type eventRecord struct {
+	EventHeader       eventHeader
+	ProcessorIndex    uint16
+	LoggerId          uint16
+	ExtendedDataCount uint16
+	UserDataLength    uint16
+	ExtendedData      uintptr
+	UserData          unsafe.Pointer
+	UserContext       uintptr
+}
+
+// traceEventInfo is the fixed part of a TRACE_EVENT_INFO. It is followed by PropertyCount
+// eventPropertyInfo and the strings the offsets refer to.
+type traceEventInfo struct {
+	ProviderGuid          windows.GUID
+	EventGuid             windows.GUID
+	EventDescriptor       eventDescriptor
+	DecodingSource        uint32
+	ProviderNameOffset    uint32
+	LevelNameOffset       uint32
+	ChannelNameOffset     uint32
+	KeywordsNameOffset    uint32
+	TaskNameOffset        uint32
+	OpcodeNameOffset      uint32
+	EventMessageOffset    uint32
+	ProviderMessageOffset uint32
+	BinaryXMLOffset       uint32
+	BinaryXMLSize         uint32
+	EventNameOffset       uint32
+	EventAttributesOffset uint32
+	PropertyCount         uint32
+	TopLevelPropertyCount uint32
+	Flags                 uint32
+}
+
+type eventPropertyInfo struct {
+	Flags         uint32
+	NameOffset    uint32
+	InType        uint16
+	OutType       uint16
+	MapNameOffset uint32
+	Count         uint16
+	Length        uint16
+	Reserved      uint32
+}
+
+func startTrace(handle *uint64, name *uint16, properties *traceProperties) error {
+	r0, _, _ := procStartTraceW.Call(uintptr(unsafe.Pointer(handle)), uintptr(unsafe.Pointer(name)), uintptr(unsafe.Pointer(properties)))
+	return errnoErr(r0)
+}
+
+func controlTrace(handle uint64, name *uint16, properties *traceProperties, controlCode uint32) error {
+	r0, _, _ := procControlTraceW.Call(uintptr(handle), uintptr(unsafe.Pointer(name)), uintptr(unsafe.Pointer(properties)), uintptr(controlCode))
+	return errnoErr(r0)
+}
+
+func enableTraceEx2(handle uint64, provider *windows.GUID, controlCode uint32, level uint8, matchAnyKeyword, matchAllKeyword uint64) error {
+	r0, _, _ := procEnableTraceEx2.Call(uintptr(handle), uintptr(unsafe.Pointer(provider)), uintptr(controlCode), uintptr(level),
+		uintptr(matchAnyKeyword), uintptr(matchAllKeyword), 0, 0)
+	return errnoErr(r0)
+}
+
+func openTrace(logfile *eventTraceLogfile) (uint64, error) {
+	r0, _, e1 := procOpenTraceW.Call(uintptr(unsafe.Pointer(logfile)))
+	if uint64(r0) == invalidProcessTraceHandle {
+		return 0, e1
+	}
+	return uint64(r0), nil
+}
+
+func processTrace(handle *uint64) error {
+	r0, _, _ := procProcessTrace.Call(uintptr(unsafe.Pointer(handle)), 1, 0, 0)
+	return errnoErr(r0)
+}
+
+func closeTrace(handle uint64) error {
+	r0, _, _ := procCloseTrace.Call(uintptr(handle))
+	if windows.Errno(r0) == windows.ERROR_CTX_CLOSE_PENDING {
+		return nil
+	}
+	return errnoErr(r0)
+}
+
+func tdhGetEventInformation(record *eventRecord, info *traceEventInfo, bufferSize *uint32) error {
+	r0, _, _ := procTdhGetEventInformation.Call(uintptr(unsafe.Pointer(record)), 0, 0, uintptr(unsafe.Pointer(info)), uintptr(unsafe.Pointer(bufferSize)))
+	return errnoErr(r0)
+}
+
+func tdhFormatProperty(info *traceEventInfo, pointerSize uint32, property *eventPropertyInfo, length uint16, userDataLength uint16,
+	userData unsafe.Pointer, bufferSize *uint32, buffer *uint16, consumed *uint16) error {
+	r0, _, _ := procTdhFormatProperty.Call(uintptr(unsafe.Pointer(info)), 0, uintptr(pointerSize), uintptr(property.InType), uintptr(property.OutType),
+		uintptr(length), uintptr(userDataLength), uintptr(userData), uintptr(unsafe.Pointer(bufferSize)), uintptr(unsafe.Pointer(buffer)),
+		uintptr(unsafe.Pointer(consumed)))
+	return errnoErr(r0)
+}
+
+// errnoErr converts the status code returned by the ETW and TDH functions to an error.
+func errnoErr(status uintptr) error {
+	if status == uintptr(windows.ERROR_SUCCESS) {
+		return nil
+	}
+	return windows.Errno(status)
+}
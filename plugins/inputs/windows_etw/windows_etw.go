@@ -0,0 +1,343 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package windows_etw
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/plugins/inputs"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+const (
+	defaultSessionName    = "AmazonCloudWatchAgent-ETW"
+	defaultBufferSizeKB   = 64
+	defaultMinimumBuffers = 8
+	defaultMaximumBuffers = 64
+	defaultQueueSize      = 10000
+	// defaultLevel is TRACE_LEVEL_VERBOSE, which enables the events of all levels.
+	defaultLevel = 5
+
+	sessionFlushInterval = time.Second
+	// maintenanceInterval is how often the providers that could not be enabled are retried and the
+	// lost events are reported.
+	maintenanceInterval = time.Minute
+)
+
+var guidRegex = regexp.MustCompile(`^\{?([0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12})\}?$`)
+
+// ProviderConfig selects the events of an ETW provider to collect and where to send them.
+type ProviderConfig struct {
+	ProviderGUID string `toml:"provider_guid"`
+	// ProviderName is only used to describe the provider in the events and the agent logs.
+	ProviderName string `toml:"provider_name"`
+	// Level is the most verbose level of the events to enable, from 1 (critical) to 5 (verbose).
+	Level uint8 `toml:"level"`
+	// MatchAnyKeyword and MatchAllKeyword are hexadecimal keyword bitmasks, e.g. "0x10".
+	MatchAnyKeyword string `toml:"match_any_keyword"`
+	MatchAllKeyword string `toml:"match_all_keyword"`
+	// EventIDs restricts the collected events to the given ids. All events are collected if empty.
+	EventIDs        []int  `toml:"event_ids"`
+	LogGroupName    string `toml:"log_group_name"`
+	LogStreamName   string `toml:"log_stream_name"`
+	LogGroupClass   string `toml:"log_group_class"`
+	RetentionInDays int    `toml:"retention_in_days"`
+	Destination     string `toml:"destination"`
+
+	guid     string
+	matchAny uint64
+	matchAll uint64
+}
+
+func (p *ProviderConfig) displayName() string {
+	if p.ProviderName != "" {
+		return p.ProviderName
+	}
+	return p.guid
+}
+
+type WindowsETW struct {
+	SessionName    string           `toml:"session_name"`
+	BufferSizeKB   int              `toml:"buffer_size_kb"`
+	MinimumBuffers int              `toml:"minimum_buffers"`
+	MaximumBuffers int              `toml:"maximum_buffers"`
+	QueueSize      int              `toml:"queue_size"`
+	Providers      []ProviderConfig `toml:"provider_config"`
+	Destination    string           `toml:"destination"`
+	Log            telegraf.Logger  `toml:"-"`
+
+	newSession func(config sessionConfig) (session, error)
+	session    session
+	// srcs are the sources of the providers by GUID. It is not modified after the plugin started.
+	srcs     map[string]*providerSrc
+	newSrcs  []logs.LogSrc
+	done     chan struct{}
+	stopOnce sync.Once
+	wg       sync.WaitGroup
+
+	mu sync.Mutex
+	// pending are the providers that could not be enabled yet, e.g. because they are not registered.
+	pending   []*ProviderConfig
+	enabled   []*ProviderConfig
+	lastStats sessionStats
+}
+
+var _ logs.LogCollection = (*WindowsETW)(nil)
+
+func NewWindowsETW() *WindowsETW {
+	return &WindowsETW{
+		newSession: newETWSession,
+		srcs:       make(map[string]*providerSrc),
+		done:       make(chan struct{}),
+	}
+}
+
+func (w *WindowsETW) Description() string {
+	return "A plugin to collect the events of Windows ETW providers"
+}
+
+func (w *WindowsETW) SampleConfig() string {
+	return `
+  ## The name of the real-time trace session. It is stopped and recreated if it already exists.
+  session_name = "AmazonCloudWatchAgent-ETW"
+  ## The size in KB and the number of the buffers of the trace session.
+  buffer_size_kb = 64
+  minimum_buffers = 8
+  maximum_buffers = 64
+  ## The number of events of each provider buffered by the agent before they are dropped.
+  queue_size = 10000
+
+  [[inputs.windows_etw.provider_config]]
+    provider_guid = "{22FB2CD6-0E7B-422B-A0C7-2FAD1FD0E716}"
+    provider_name = "Microsoft-Windows-Kernel-Process"
+    level = 4
+    match_any_keyword = "0x10"
+    event_ids = [1, 2]
+    log_group_name = "etw/kernel-process"
+    log_stream_name = "STREAM_NAME"
+    destination = "cloudwatchlogs"
+`
+}
+
+func (w *WindowsETW) Gather(telegraf.Accumulator) error {
+	return nil
+}
+
+// Init validates the provider configs.
+func (w *WindowsETW) Init() error {
+	if w.SessionName == "" {
+		w.SessionName = defaultSessionName
+	}
+	if w.BufferSizeKB <= 0 {
+		w.BufferSizeKB = defaultBufferSizeKB
+	}
+	if w.MinimumBuffers <= 0 {
+		w.MinimumBuffers = defaultMinimumBuffers
+	}
+	if w.MaximumBuffers <= 0 {
+		w.MaximumBuffers = defaultMaximumBuffers
+	}
+	if w.MaximumBuffers < w.MinimumBuffers {
+		return fmt.Errorf("maximum_buffers %d is lower than minimum_buffers %d", w.MaximumBuffers, w.MinimumBuffers)
+	}
+	if w.QueueSize <= 0 {
+		w.QueueSize = defaultQueueSize
+	}
+	if len(w.Providers) == 0 {
+		return errors.New("at least one provider_config is required")
+	}
+	guids := map[string]struct{}{}
+	for i := range w.Providers {
+		provider := &w.Providers[i]
+		guid, err := normalizeGUID(provider.ProviderGUID)
+		if err != nil {
+			return fmt.Errorf("invalid provider_guid in provider_config %d: %w", i, err)
+		}
+		if _, ok := guids[guid]; ok {
+			return fmt.Errorf("provider %v is configured more than once", guid)
+		}
+		guids[guid] = struct{}{}
+		provider.guid = guid
+		if provider.matchAny, err = parseKeyword(provider.MatchAnyKeyword); err != nil {
+			return fmt.Errorf("invalid match_any_keyword in provider_config %d: %w", i, err)
+		}
+		if provider.matchAll, err = parseKeyword(provider.MatchAllKeyword); err != nil {
+			return fmt.Errorf("invalid match_all_keyword in provider_config %d: %w", i, err)
+		}
+		if provider.Level == 0 {
+			provider.Level = defaultLevel
+		}
+		if provider.LogGroupName == "" {
+			return fmt.Errorf("log_group_name is required in provider_config %d", i)
+		}
+		if provider.Destination == "" {
+			provider.Destination = w.Destination
+		}
+	}
+	return nil
+}
+
+// Start creates the trace session and enables the providers on it. The providers that cannot be enabled,
+// e.g. because they are not registered yet, are retried until the plugin stops.
+func (w *WindowsETW) Start(telegraf.Accumulator) error {
+	if err := w.Init(); err != nil {
+		return err
+	}
+	s, err := w.newSession(sessionConfig{
+		name:           w.SessionName,
+		bufferSizeKB:   uint32(w.BufferSizeKB),
+		minimumBuffers: uint32(w.MinimumBuffers),
+		maximumBuffers: uint32(w.MaximumBuffers),
+		flushInterval:  sessionFlushInterval,
+	})
+	if err != nil {
+		return fmt.Errorf("unable to start etw session %v: %w", w.SessionName, err)
+	}
+	w.session = s
+	for i := range w.Providers {
+		provider := &w.Providers[i]
+		src := newProviderSrc(w, provider, w.QueueSize)
+		w.srcs[provider.guid] = src
+		w.newSrcs = append(w.newSrcs, src)
+		w.pending = append(w.pending, provider)
+	}
+	w.enablePending()
+	w.wg.Add(2)
+	go w.process()
+	go w.run()
+	return nil
+}
+
+// Stop disables the providers and stops the trace session.
+func (w *WindowsETW) Stop() {
+	w.stopOnce.Do(func() {
+		close(w.done)
+		if w.session == nil {
+			return
+		}
+		w.mu.Lock()
+		for _, provider := range w.enabled {
+			if err := w.session.DisableProvider(provider); err != nil {
+				w.Log.Warnf("Unable to disable etw provider %v: %v", provider.displayName(), err)
+			}
+		}
+		w.enabled = nil
+		w.mu.Unlock()
+		if err := w.session.Close(); err != nil {
+			w.Log.Errorf("Unable to stop etw session %v: %v", w.SessionName, err)
+		}
+		w.wg.Wait()
+		for _, src := range w.srcs {
+			src.Stop()
+		}
+	})
+}
+
+func (w *WindowsETW) FindLogSrc() []logs.LogSrc {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	srcs := w.newSrcs
+	w.newSrcs = nil
+	return srcs
+}
+
+func (w *WindowsETW) process() {
+	defer w.wg.Done()
+	err := w.session.Process(w.handle)
+	select {
+	case <-w.done:
+	default:
+		w.Log.Errorf("Stopped processing events of etw session %v: %v", w.SessionName, err)
+	}
+}
+
+// handle is called by the trace session for every event. It must not block the session.
+func (w *WindowsETW) handle(event *Event) {
+	if src, ok := w.srcs[event.ProviderGUID]; ok {
+		src.enqueue(event)
+	}
+}
+
+func (w *WindowsETW) run() {
+	defer w.wg.Done()
+	t := time.NewTicker(maintenanceInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-t.C:
+			w.enablePending()
+			w.reportLostEvents()
+		case <-w.done:
+			return
+		}
+	}
+}
+
+func (w *WindowsETW) enablePending() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var pending []*ProviderConfig
+	for _, provider := range w.pending {
+		if err := w.session.EnableProvider(provider); err != nil {
+			w.Log.Warnf("Unable to enable etw provider %v, will retry in %v: %v", provider.displayName(), maintenanceInterval, err)
+			pending = append(pending, provider)
+			continue
+		}
+		w.Log.Debugf("Enabled etw provider %v on session %v", provider.displayName(), w.SessionName)
+		w.enabled = append(w.enabled, provider)
+	}
+	w.pending = pending
+}
+
+// reportLostEvents logs the events lost by the trace session because its buffers were full and the
+// events dropped by the agent because the queue of a provider was full.
+func (w *WindowsETW) reportLostEvents() {
+	stats, err := w.session.Stats()
+	if err != nil {
+		w.Log.Debugf("Unable to query etw session %v: %v", w.SessionName, err)
+	} else {
+		w.mu.Lock()
+		last := w.lastStats
+		w.lastStats = stats
+		w.mu.Unlock()
+		if stats.eventsLost > last.eventsLost || stats.realTimeBuffersLost > last.realTimeBuffersLost {
+			w.Log.Warnf("Etw session %v lost %d events and %d buffers, consider increasing buffer_size_kb or maximum_buffers",
+				w.SessionName, stats.eventsLost-last.eventsLost, stats.realTimeBuffersLost-last.realTimeBuffersLost)
+		}
+	}
+	for _, src := range w.srcs {
+		if dropped := src.dropped.Swap(0); dropped > 0 {
+			w.Log.Warnf("Dropped %d events of etw provider %v because its queue is full, consider increasing queue_size",
+				dropped, src.config.displayName())
+		}
+	}
+}
+
+// normalizeGUID returns the GUID in the upper case and braced format used by the trace session.
+func normalizeGUID(guid string) (string, error) {
+	match := guidRegex.FindStringSubmatch(strings.TrimSpace(guid))
+	if match == nil {
+		return "", fmt.Errorf("%q is not a GUID", guid)
+	}
+	return "{" + strings.ToUpper(match[1]) + "}", nil
+}
+
+func parseKeyword(keyword string) (uint64, error) {
+	if keyword == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(keyword, 0, 64)
+}
+
+func init() {
+	inputs.Add("windows_etw", func() telegraf.Input { return NewWindowsETW() })
+}
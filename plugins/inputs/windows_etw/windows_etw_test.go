@@ -0,0 +1,356 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package windows_etw
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+const (
+	kernelProcessGUID = "{22FB2CD6-0E7B-422B-A0C7-2FAD1FD0E716}"
+	dnsClientGUID     = "{1C95126E-7EEA-49A9-A3FE-A378B03DDB4D}"
+)
+
+// mockSession delivers the events emitted by the tests for the providers that are enabled, like a
+// real-time trace session.
+type mockSession struct {
+	mu         sync.Mutex
+	config     sessionConfig
+	enabled    map[string]*ProviderConfig
+	disabled   []string
+	enableErrs map[string]error
+	stats      sessionStats
+	handler    func(*Event)
+	processing chan struct{}
+	closed     chan struct{}
+	closeOnce  sync.Once
+}
+
+func newMockSession() *mockSession {
+	return &mockSession{
+		enabled:    map[string]*ProviderConfig{},
+		enableErrs: map[string]error{},
+		processing: make(chan struct{}),
+		closed:     make(chan struct{}),
+	}
+}
+
+func (m *mockSession) EnableProvider(provider *ProviderConfig) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if err := m.enableErrs[provider.guid]; err != nil {
+		return err
+	}
+	m.enabled[provider.guid] = provider
+	return nil
+}
+
+func (m *mockSession) DisableProvider(provider *ProviderConfig) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.enabled, provider.guid)
+	m.disabled = append(m.disabled, provider.guid)
+	return nil
+}
+
+func (m *mockSession) Process(handler func(*Event)) error {
+	m.mu.Lock()
+	m.handler = handler
+	m.mu.Unlock()
+	close(m.processing)
+	<-m.closed
+	return nil
+}
+
+func (m *mockSession) Stats() (sessionStats, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.stats, nil
+}
+
+func (m *mockSession) Close() error {
+	m.closeOnce.Do(func() { close(m.closed) })
+	return nil
+}
+
+func (m *mockSession) emit(t *testing.T, event *Event) {
+	select {
+	case <-m.processing:
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for the session to be processed")
+	}
+	m.mu.Lock()
+	_, ok := m.enabled[event.ProviderGUID]
+	handler := m.handler
+	m.mu.Unlock()
+	if ok {
+		handler(event)
+	}
+}
+
+func newTestWindowsETW(providers ...ProviderConfig) (*WindowsETW, *mockSession) {
+	w := NewWindowsETW()
+	w.Log = testutil.Logger{Name: "windows_etw"}
+	w.Destination = "cloudwatchlogs"
+	w.Providers = providers
+	s := newMockSession()
+	w.newSession = func(config sessionConfig) (session, error) {
+		s.config = config
+		return s, nil
+	}
+	return w, s
+}
+
+// collect returns the first n events published by the source.
+func collect(t *testing.T, src logs.LogSrc, n int) []logs.LogEvent {
+	eventCh := make(chan logs.LogEvent, n)
+	src.SetOutput(func(e logs.LogEvent) {
+		if e != nil {
+			eventCh <- e
+		}
+	})
+	var events []logs.LogEvent
+	for len(events) < n {
+		select {
+		case e := <-eventCh:
+			events = append(events, e)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("timed out waiting for %d events, got %d", n, len(events))
+		}
+	}
+	return events
+}
+
+func decode(t *testing.T, e logs.LogEvent) eventMessage {
+	var msg eventMessage
+	require.NoError(t, json.Unmarshal([]byte(e.Message()), &msg))
+	return msg
+}
+
+func TestWindowsETW(t *testing.T) {
+	w, s := newTestWindowsETW(
+		ProviderConfig{
+			ProviderGUID:    "22fb2cd6-0e7b-422b-a0c7-2fad1fd0e716",
+			ProviderName:    "Microsoft-Windows-Kernel-Process",
+			Level:           4,
+			MatchAnyKeyword: "0x10",
+			EventIDs:        []int{1, 2},
+			LogGroupName:    "etw/kernel-process",
+			LogStreamName:   "host",
+			RetentionInDays: 7,
+		},
+		ProviderConfig{
+			ProviderGUID: dnsClientGUID,
+			LogGroupName: "etw/dns",
+		},
+	)
+	w.BufferSizeKB = 128
+	require.NoError(t, w.Start(nil))
+
+	assert.Equal(t, sessionConfig{
+		name:           defaultSessionName,
+		bufferSizeKB:   128,
+		minimumBuffers: defaultMinimumBuffers,
+		maximumBuffers: defaultMaximumBuffers,
+		flushInterval:  sessionFlushInterval,
+	}, s.config)
+	require.Contains(t, s.enabled, kernelProcessGUID)
+	assert.EqualValues(t, 4, s.enabled[kernelProcessGUID].Level)
+	assert.EqualValues(t, 0x10, s.enabled[kernelProcessGUID].matchAny)
+	assert.EqualValues(t, defaultLevel, s.enabled[dnsClientGUID].Level)
+
+	srcs := w.FindLogSrc()
+	require.Len(t, srcs, 2)
+	assert.Empty(t, w.FindLogSrc())
+	src := srcs[0]
+	assert.Equal(t, "etw/kernel-process", src.Group())
+	assert.Equal(t, "host", src.Stream())
+	assert.Equal(t, "cloudwatchlogs", src.Destination())
+	assert.Equal(t, 7, src.Retention())
+	assert.Equal(t, "etw provider Microsoft-Windows-Kernel-Process", src.Description())
+	assert.Equal(t, "etw provider "+dnsClientGUID, srcs[1].Description())
+
+	timestamp := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	s.emit(t, &Event{
+		ProviderGUID: kernelProcessGUID,
+		EventID:      1,
+		Version:      3,
+		Level:        4,
+		Opcode:       1,
+		Task:         1,
+		Keyword:      0x8000000000000010,
+		ProcessID:    4,
+		ThreadID:     120,
+		ActivityID:   "{B5D1CB61-8D5A-4A41-9E28-A2AE5D7C0B19}",
+		Timestamp:    timestamp,
+		Properties:   map[string]string{"ProcessID": "1234", "ImageName": `\Device\HarddiskVolume3\Windows\System32\notepad.exe`},
+	})
+	// filtered out by the event ids
+	s.emit(t, &Event{ProviderGUID: kernelProcessGUID, EventID: 5, Level: 4, Timestamp: timestamp})
+	s.emit(t, &Event{ProviderGUID: kernelProcessGUID, EventID: 2, Level: 2, Timestamp: timestamp.Add(time.Second), Message: "process stopped"})
+
+	events := collect(t, src, 2)
+	assert.Equal(t, eventMessage{
+		ProviderName: "Microsoft-Windows-Kernel-Process",
+		ProviderGUID: kernelProcessGUID,
+		EventID:      1,
+		Version:      3,
+		Level:        "Information",
+		Opcode:       1,
+		Task:         1,
+		Keywords:     "0x8000000000000010",
+		ProcessID:    4,
+		ThreadID:     120,
+		ActivityID:   "{B5D1CB61-8D5A-4A41-9E28-A2AE5D7C0B19}",
+		Properties:   map[string]string{"ProcessID": "1234", "ImageName": `\Device\HarddiskVolume3\Windows\System32\notepad.exe`},
+	}, decode(t, events[0]))
+	assert.Equal(t, timestamp, events[0].Time())
+	msg := decode(t, events[1])
+	assert.EqualValues(t, 2, msg.EventID)
+	assert.Equal(t, "Error", msg.Level)
+	assert.Equal(t, "process stopped", msg.Message)
+	assert.Nil(t, msg.Properties)
+
+	w.Stop()
+	assert.Empty(t, s.enabled)
+	assert.ElementsMatch(t, []string{kernelProcessGUID, dnsClientGUID}, s.disabled)
+	select {
+	case <-s.closed:
+	default:
+		t.Fatal("session was not closed")
+	}
+}
+
+func TestWindowsETWEnableRetry(t *testing.T) {
+	w, s := newTestWindowsETW(ProviderConfig{ProviderGUID: kernelProcessGUID, LogGroupName: "group"})
+	s.enableErrs[kernelProcessGUID] = errors.New("provider not registered")
+	require.NoError(t, w.Start(nil))
+	defer w.Stop()
+	assert.Empty(t, s.enabled)
+	assert.Len(t, w.pending, 1)
+
+	s.mu.Lock()
+	delete(s.enableErrs, kernelProcessGUID)
+	s.mu.Unlock()
+	w.enablePending()
+	assert.Contains(t, s.enabled, kernelProcessGUID)
+	assert.Empty(t, w.pending)
+	assert.Len(t, w.enabled, 1)
+}
+
+func TestWindowsETWQueueFull(t *testing.T) {
+	w, s := newTestWindowsETW(ProviderConfig{ProviderGUID: kernelProcessGUID, LogGroupName: "group"})
+	w.QueueSize = 2
+	require.NoError(t, w.Start(nil))
+	defer w.Stop()
+
+	// the events are queued until the output is set
+	for i := 1; i <= 5; i++ {
+		s.emit(t, &Event{ProviderGUID: kernelProcessGUID, EventID: uint16(i)})
+	}
+	src := w.srcs[kernelProcessGUID]
+	assert.EqualValues(t, 3, src.dropped.Load())
+
+	s.mu.Lock()
+	s.stats = sessionStats{eventsLost: 10, realTimeBuffersLost: 1}
+	s.mu.Unlock()
+	w.reportLostEvents()
+	assert.EqualValues(t, 0, src.dropped.Load())
+	assert.Equal(t, sessionStats{eventsLost: 10, realTimeBuffersLost: 1}, w.lastStats)
+
+	events := collect(t, src, 2)
+	assert.EqualValues(t, 1, decode(t, events[0]).EventID)
+	assert.EqualValues(t, 2, decode(t, events[1]).EventID)
+}
+
+func TestWindowsETWSessionError(t *testing.T) {
+	w, _ := newTestWindowsETW(ProviderConfig{ProviderGUID: kernelProcessGUID, LogGroupName: "group"})
+	w.newSession = func(sessionConfig) (session, error) {
+		return nil, errors.New("access denied")
+	}
+	assert.ErrorContains(t, w.Start(nil), "unable to start etw session AmazonCloudWatchAgent-ETW: access denied")
+	w.Stop()
+}
+
+func TestInit(t *testing.T) {
+	testCases := map[string]struct {
+		providers      []ProviderConfig
+		minimumBuffers int
+		wantErr        string
+	}{
+		"WithValidConfig": {
+			providers: []ProviderConfig{{ProviderGUID: kernelProcessGUID, LogGroupName: "group"}},
+		},
+		"WithoutProviders": {
+			wantErr: "at least one provider_config is required",
+		},
+		"WithInvalidGUID": {
+			providers: []ProviderConfig{{ProviderGUID: "Microsoft-Windows-Kernel-Process", LogGroupName: "group"}},
+			wantErr:   "invalid provider_guid in provider_config 0",
+		},
+		"WithDuplicateProvider": {
+			providers: []ProviderConfig{
+				{ProviderGUID: kernelProcessGUID, LogGroupName: "group"},
+				{ProviderGUID: "22fb2cd6-0e7b-422b-a0c7-2fad1fd0e716", LogGroupName: "other"},
+			},
+			wantErr: "provider {22FB2CD6-0E7B-422B-A0C7-2FAD1FD0E716} is configured more than once",
+		},
+		"WithInvalidKeyword": {
+			providers: []ProviderConfig{{ProviderGUID: kernelProcessGUID, MatchAllKeyword: "0xZZ", LogGroupName: "group"}},
+			wantErr:   "invalid match_all_keyword in provider_config 0",
+		},
+		"WithoutLogGroupName": {
+			providers: []ProviderConfig{{ProviderGUID: kernelProcessGUID}},
+			wantErr:   "log_group_name is required in provider_config 0",
+		},
+		"WithInvalidBuffers": {
+			providers:      []ProviderConfig{{ProviderGUID: kernelProcessGUID, LogGroupName: "group"}},
+			minimumBuffers: 128,
+			wantErr:        "maximum_buffers 64 is lower than minimum_buffers 128",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			w := NewWindowsETW()
+			w.Destination = "cloudwatchlogs"
+			w.MinimumBuffers = testCase.minimumBuffers
+			w.Providers = testCase.providers
+			err := w.Init()
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, err, testCase.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, defaultSessionName, w.SessionName)
+			assert.Equal(t, defaultQueueSize, w.QueueSize)
+			assert.Equal(t, kernelProcessGUID, w.Providers[0].guid)
+			assert.EqualValues(t, defaultLevel, w.Providers[0].Level)
+			assert.Equal(t, "cloudwatchlogs", w.Providers[0].Destination)
+		})
+	}
+}
+
+func TestNormalizeGUID(t *testing.T) {
+	for _, guid := range []string{
+		"{22FB2CD6-0E7B-422B-A0C7-2FAD1FD0E716}",
+		"22fb2cd6-0e7b-422b-a0c7-2fad1fd0e716",
+		" {22fb2cd6-0E7B-422b-a0c7-2fad1fd0e716} ",
+	} {
+		actual, err := normalizeGUID(guid)
+		assert.NoError(t, err)
+		assert.Equal(t, kernelProcessGUID, actual)
+	}
+	_, err := normalizeGUID("22fb2cd6-0e7b-422b-a0c7")
+	assert.Error(t, err)
+}
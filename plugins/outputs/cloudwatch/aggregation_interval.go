@@ -0,0 +1,56 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatch
+
+import (
+	"regexp"
+	"time"
+)
+
+// aggregationIntervalRule is an AggregationInterval that applies to the namespace of the exporter.
+type aggregationIntervalRule struct {
+	metricNames []*regexp.Regexp
+	interval    time.Duration
+}
+
+// newAggregationIntervalRules returns the rules that apply to the namespace, in order. The metric names have already
+// been validated.
+func newAggregationIntervalRules(namespace string, intervals []AggregationInterval) []aggregationIntervalRule {
+	var rules []aggregationIntervalRule
+	for _, interval := range intervals {
+		if interval.Namespace != "" && interval.Namespace != namespace {
+			continue
+		}
+		rule := aggregationIntervalRule{interval: interval.Interval}
+		for _, name := range interval.MetricNames {
+			rule.metricNames = append(rule.metricNames, regexp.MustCompile(name))
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+func (r aggregationIntervalRule) matches(metricName string) bool {
+	if len(r.metricNames) == 0 {
+		return true
+	}
+	for _, name := range r.metricNames {
+		if name.MatchString(metricName) {
+			return true
+		}
+	}
+	return false
+}
+
+// setAggregationInterval sets the aggregation interval of the datum from the first rule that matches its metric name.
+// The aggregator keys the statistic sets by the metric name and dimensions, so each attribute set is aggregated on
+// its own.
+func (c *CloudWatch) setAggregationInterval(d *aggregationDatum) {
+	for _, rule := range c.aggregationIntervalRules {
+		if rule.matches(*d.MetricName) {
+			d.aggregationInterval = rule.interval
+			return
+		}
+	}
+}
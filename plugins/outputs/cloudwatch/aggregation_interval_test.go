@@ -0,0 +1,122 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatch
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/aws/amazon-cloudwatch-agent/metric/distribution"
+	"github.com/aws/amazon-cloudwatch-agent/metric/distribution/seh1"
+)
+
+// createIntervalTestMetrics creates a gauge with a data point for each of the values on each of the hosts.
+func createIntervalTestMetrics(name string, timestamp time.Time, hosts []string, values ...float64) pmetric.Metrics {
+	metrics := pmetric.NewMetrics()
+	m := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName(name)
+	m.SetUnit("Percent")
+	dps := m.SetEmptyGauge().DataPoints()
+	for _, host := range hosts {
+		for _, value := range values {
+			dp := dps.AppendEmpty()
+			dp.SetTimestamp(pcommon.NewTimestampFromTime(timestamp))
+			dp.SetDoubleValue(value)
+			dp.Attributes().PutStr("host", host)
+		}
+	}
+	return metrics
+}
+
+func TestSetAggregationInterval(t *testing.T) {
+	cw := &CloudWatch{
+		aggregationIntervalRules: newAggregationIntervalRules("CWAgent", []AggregationInterval{
+			{Namespace: "Other", Interval: 5 * time.Minute},
+			{Namespace: "CWAgent", MetricNames: []string{"^raw_"}},
+			{MetricNames: []string{"^cpu_", "^mem_"}, Interval: time.Minute},
+			{Namespace: "CWAgent", MetricNames: []string{"^disk_"}, Interval: 10 * time.Second},
+		}),
+	}
+	testCases := map[string]struct {
+		pipelineInterval time.Duration
+		want             time.Duration
+	}{
+		"raw_requests":  {pipelineInterval: time.Minute, want: 0},
+		"cpu_usage":     {want: time.Minute},
+		"mem_used":      {pipelineInterval: 30 * time.Second, want: time.Minute},
+		"disk_used":     {want: 10 * time.Second},
+		"net_bytes":     {want: 0},
+		"net_packets":   {pipelineInterval: 30 * time.Second, want: 30 * time.Second},
+		"other_metrics": {want: 0},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			d := makeTestMetric(name, 1, time.Now(), nil, testCase.pipelineInterval, "Count")
+			cw.setAggregationInterval(d)
+			assert.Equal(t, testCase.want, d.aggregationInterval)
+		})
+	}
+}
+
+func TestConsumeMetricsAggregationInterval(t *testing.T) {
+	agg := &captureAggregator{}
+	cw := &CloudWatch{
+		config:                   &Config{},
+		aggregator:               agg,
+		aggregationIntervalRules: newAggregationIntervalRules("CWAgent", []AggregationInterval{{MetricNames: []string{"^cpu_"}, Interval: time.Minute}}),
+	}
+	require.NoError(t, cw.ConsumeMetrics(context.Background(), createIntervalTestMetrics("cpu_usage", time.Now(), []string{"a"}, 1)))
+	require.NoError(t, cw.ConsumeMetrics(context.Background(), createIntervalTestMetrics("mem_used", time.Now(), []string{"a"}, 1)))
+	require.Len(t, agg.datums, 2)
+	assert.Equal(t, time.Minute, agg.datums[0].aggregationInterval)
+	assert.Equal(t, time.Duration(0), agg.datums[1].aggregationInterval)
+}
+
+func TestAggregationIntervalStatisticSets(t *testing.T) {
+	distribution.NewDistribution = seh1.NewSEH1Distribution
+	metricChan := make(chan *aggregationDatum, metricChanBufferSize)
+	shutdownChan := make(chan struct{})
+	cw := &CloudWatch{
+		config:                   &Config{},
+		aggregator:               NewAggregator(metricChan, shutdownChan, &wg),
+		aggregationIntervalRules: newAggregationIntervalRules("CWAgent", []AggregationInterval{{Namespace: "CWAgent", Interval: time.Second}}),
+	}
+	// the data points within the interval collapse to one statistic set for each host
+	timestamp := time.Now()
+	for _, value := range []float64{1, 2, 3} {
+		require.NoError(t, cw.ConsumeMetrics(context.Background(), createIntervalTestMetrics("cpu_usage", timestamp, []string{"a", "b"}, value, 10*value)))
+	}
+
+	got := map[string]*aggregationDatum{}
+	for i := 0; i < 2; i++ {
+		select {
+		case d := <-metricChan:
+			require.Len(t, d.Dimensions, 1)
+			got[*d.Dimensions[0].Value] = d
+		case <-time.After(3 * time.Second):
+			require.FailNow(t, "statistic sets were not flushed")
+		}
+	}
+	assertNoMetricsInChan(t, metricChan)
+	close(shutdownChan)
+	wg.Wait()
+
+	for _, host := range []string{"a", "b"} {
+		require.Contains(t, got, host)
+		d := got[host]
+		assert.Equal(t, "cpu_usage", *d.MetricName)
+		assert.Equal(t, float64(6), d.distribution.SampleCount())
+		assert.Equal(t, float64(66), d.distribution.Sum())
+		assert.Equal(t, float64(1), d.distribution.Minimum())
+		assert.Equal(t, float64(30), d.distribution.Maximum())
+		// sub-minute intervals are sent as high resolution metrics
+		assert.Equal(t, int64(1), *d.StorageResolution)
+	}
+}
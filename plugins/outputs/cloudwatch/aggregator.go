@@ -29,6 +29,8 @@ type aggregationDatum struct {
 	aggregationInterval time.Duration
 	distribution        distribution.Distribution
 	entity              cloudwatch.Entity
+	// pipelineID is the id of the pipeline that the datum is from, which is only a dimension once promoted.
+	pipelineID string
 }
 
 type Aggregator interface {
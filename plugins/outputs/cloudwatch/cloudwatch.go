@@ -5,10 +5,12 @@ package cloudwatch
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"reflect"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/amazon-contributing/opentelemetry-collector-contrib/extension/awsmiddleware"
@@ -25,7 +27,9 @@ import (
 	"golang.org/x/exp/maps"
 
 	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
+	"github.com/aws/amazon-cloudwatch-agent/extension/pipelinehealth"
 	"github.com/aws/amazon-cloudwatch-agent/handlers"
+	"github.com/aws/amazon-cloudwatch-agent/internal/permission"
 	"github.com/aws/amazon-cloudwatch-agent/internal/publisher"
 	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
 	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
@@ -42,7 +46,11 @@ const (
 	datumBatchChanBufferSize              = 50 // the number of requests we buffer
 	maxConcurrentPublisher                = 10 // the number of CloudWatch clients send request concurrently
 	defaultForceFlushInterval             = time.Minute
+	defaultMaxInFlightRequests            = maxConcurrentPublisher
 	highResolutionTagKey                  = "aws:StorageResolution"
+	keepHostTagKey                        = "aws:KeepHost"
+	pipelineIDTagKey                      = "aws:PipelineId"
+	pipelineIDDimension                   = "PipelineId"
 	defaultRetryCount                     = 5 // this is the retry count, the total attempts would be retry count + 1 at most.
 	backoffRetryBase                      = 200 * time.Millisecond
 	MaxDimensions                         = 30
@@ -60,18 +68,38 @@ type CloudWatch struct {
 	// todo: may want to increase the size of the chan since the type changed.
 	// 1 telegraf Metric could have many Fields.
 	// Each field corresponds to a MetricDatum.
-	metricChan             chan *aggregationDatum
-	datumBatchChan         chan map[string][]*cloudwatch.MetricDatum
-	metricDatumBatch       *MetricDatumBatch
-	shutdownChan           chan struct{}
-	retries                int
-	publisher              *publisher.Publisher
-	retryer                *retryer.LogThrottleRetryer
-	droppingOriginMetrics  collections.Set[string]
+	metricChan            chan *aggregationDatum
+	datumBatchChan        chan map[string][]*cloudwatch.MetricDatum
+	metricDatumBatch      *MetricDatumBatch
+	shutdownChan          chan struct{}
+	retries               int
+	publisher             *publisher.Publisher
+	retryer               *retryer.LogThrottleRetryer
+	droppingOriginMetrics collections.Set[string]
+	dimensionKeys         collections.Set[string]
+	// rollupDimensionKeys are the dimensions of the rollups, which are kept first when dimensions are truncated
+	rollupDimensionKeys collections.Set[string]
+	// aggregationIntervalRules are the aggregation intervals configured for the namespace
+	aggregationIntervalRules []aggregationIntervalRule
+	// namespaceDisallowed is set when the namespace is not in the allowed namespaces and the metrics are dropped
+	namespaceDisallowed bool
+	// overLimitMetrics are the names of the metrics that have been reported for having too many dimensions
+	overLimitMetrics       collections.Set[string]
+	overLimitMetricsMu     sync.Mutex
 	aggregator             Aggregator
 	aggregatorShutdownChan chan struct{}
 	aggregatorWaitGroup    sync.WaitGroup
 	lastRequestBytes       int
+	// replicas publish the same batches to the replica regions
+	replicas []*CloudWatch
+	// healthCheckName is the name that the readiness check of the exporter is registered with
+	healthCheckName string
+	// staleDatums counts the data points that were dropped for being older than the MaxMetricAge
+	staleDatums atomic.Uint64
+	// lastFlush is the time in nanoseconds of the last batch that was published
+	lastFlush atomic.Int64
+	// publishErrors counts the batches that could not be published
+	publishErrors atomic.Uint64
 }
 
 // Compile time interface check.
@@ -87,8 +115,31 @@ func (c *CloudWatch) Start(_ context.Context, host component.Host) error {
 		maxConcurrentPublisher,
 		2*time.Second,
 		c.WriteToCloudWatch)
-	credentialConfig := &configaws.CredentialConfig{
-		Region:    c.config.Region,
+	svc, logThrottleRetryer := c.createClient(host, c.config.Region, c.config.EndpointOverride)
+	//Format unique roll up list
+	c.config.RollupDimensions = GetUniqueRollupList(c.config.RollupDimensions)
+	c.svc = svc
+	c.retryer = logThrottleRetryer
+	for _, region := range c.config.ReplicaRegions {
+		// the endpoint override is specific to the region of the exporter
+		replicaSvc, replicaRetryer := c.createClient(host, region, "")
+		c.replicas = append(c.replicas, c.newReplica(region, replicaSvc, replicaRetryer))
+	}
+	if c.config.ValidatePermissions && c.config.isNamespaceAllowed() {
+		if err := c.checkPermissions(permission.NewSimulator(c.credentialConfig(c.config.Region).Credentials())); err != nil {
+			return err
+		}
+	}
+	c.startRoutines()
+	pipelinehealth.RegisterCheck(c.healthCheckName, c.checkQueue)
+	pipelinehealth.RegisterQueue(c.healthCheckName, c.queueUsage)
+	pipelinehealth.RegisterState(c.healthCheckName, c.state)
+	return nil
+}
+
+func (c *CloudWatch) credentialConfig(region string) *configaws.CredentialConfig {
+	return &configaws.CredentialConfig{
+		Region:    region,
 		AccessKey: c.config.AccessKey,
 		SecretKey: c.config.SecretKey,
 		RoleARN:   c.config.RoleARN,
@@ -96,31 +147,46 @@ func (c *CloudWatch) Start(_ context.Context, host component.Host) error {
 		Filename:  c.config.SharedCredentialFilename,
 		Token:     c.config.Token,
 	}
-	configProvider := credentialConfig.Credentials()
+}
+
+// createClient creates the CloudWatch client of the region with its own retryer and limit on the requests in flight.
+func (c *CloudWatch) createClient(host component.Host, region, endpointOverride string) (*cloudwatch.CloudWatch, *retryer.LogThrottleRetryer) {
+	configProvider := c.credentialConfig(region).Credentials()
 	logger := models.NewLogger("outputs", "cloudwatch", "")
 	logThrottleRetryer := retryer.NewLogThrottleRetryer(logger)
 	svc := cloudwatch.New(
 		configProvider,
 		&aws.Config{
-			Endpoint: aws.String(c.config.EndpointOverride),
+			Endpoint: aws.String(endpointOverride),
 			Retryer:  logThrottleRetryer,
 			LogLevel: configaws.SDKLogLevel(),
 			Logger:   configaws.SDKLogger{},
 		})
 	svc.Handlers.Build.PushBackNamed(handlers.NewRequestCompressionHandler([]string{opPutLogEvents, opPutMetricData}))
+	if c.config.MaxInFlightRequests > 0 {
+		handlers.NewInFlightLimiter(c.config.MaxInFlightRequests).Configure(&svc.Handlers)
+	}
 	if c.config.MiddlewareID != nil {
 		awsmiddleware.TryConfigure(c.logger, host, *c.config.MiddlewareID, awsmiddleware.SDKv1(&svc.Handlers))
 	}
-	//Format unique roll up list
-	c.config.RollupDimensions = GetUniqueRollupList(c.config.RollupDimensions)
-	c.svc = svc
-	c.retryer = logThrottleRetryer
-	c.startRoutines()
-	return nil
+	return svc, logThrottleRetryer
 }
 
 func (c *CloudWatch) startRoutines() {
 	setNewDistributionFunc(c.config.MaxValuesPerDatum)
+	if len(c.config.DimensionKeys) > 0 {
+		c.dimensionKeys = collections.NewSet(c.config.DimensionKeys...)
+	}
+	c.rollupDimensionKeys = collections.NewSet[string]()
+	for _, rollup := range c.config.RollupDimensions {
+		c.rollupDimensionKeys.Add(rollup...)
+	}
+	c.overLimitMetrics = collections.NewSet[string]()
+	c.aggregationIntervalRules = newAggregationIntervalRules(c.config.Namespace, c.config.AggregationIntervals)
+	if !c.config.isNamespaceAllowed() {
+		c.namespaceDisallowed = true
+		log.Printf("W! cloudwatch: namespace %s is not in allowed_namespaces %v, dropping all of the metrics sent to it", c.config.Namespace, c.config.AllowedNamespaces)
+	}
 	c.metricChan = make(chan *aggregationDatum, metricChanBufferSize)
 	c.datumBatchChan = make(chan map[string][]*cloudwatch.MetricDatum, datumBatchChanBufferSize)
 	c.shutdownChan = make(chan struct{})
@@ -134,6 +200,9 @@ func (c *CloudWatch) startRoutines() {
 
 func (c *CloudWatch) Shutdown(ctx context.Context) error {
 	log.Println("D! Stopping the CloudWatch output plugin")
+	pipelinehealth.UnregisterCheck(c.healthCheckName)
+	pipelinehealth.UnregisterQueue(c.healthCheckName)
+	pipelinehealth.UnregisterState(c.healthCheckName)
 	for i := 0; i < 5; i++ {
 		if len(c.metricChan) == 0 && len(c.datumBatchChan) == 0 {
 			break
@@ -148,6 +217,10 @@ func (c *CloudWatch) Shutdown(ctx context.Context) error {
 	close(c.shutdownChan)
 	c.publisher.Close()
 	c.retryer.Stop()
+	for _, r := range c.replicas {
+		r.publisher.Close()
+		r.retryer.Stop()
+	}
 	log.Println("D! Stopped the CloudWatch output plugin")
 	return nil
 }
@@ -156,8 +229,19 @@ func (c *CloudWatch) Shutdown(ctx context.Context) error {
 // The actual publishing will occur in a long running goroutine.
 // This method can block when publishing is backed up.
 func (c *CloudWatch) ConsumeMetrics(ctx context.Context, metrics pmetric.Metrics) error {
-	datums := ConvertOtelMetrics(metrics)
+	if c.namespaceDisallowed {
+		log.Printf("D! cloudwatch: dropping %d data points sent to namespace %s, which is not allowed", metrics.DataPointCount(), c.config.Namespace)
+		return nil
+	}
+	datums := ConvertOtelMetrics(metrics, c.dimensionKeys)
 	for _, d := range datums {
+		if c.config.PipelineIDDimension && d.pipelineID != "" {
+			promotePipelineID(d)
+		}
+		if !c.checkDimensionLimit(d) {
+			continue
+		}
+		c.setAggregationInterval(d)
 		c.aggregator.AddMetric(d)
 	}
 	return nil
@@ -325,6 +409,39 @@ func (c *CloudWatch) metricDatumBatchFull() bool {
 	return len(c.datumBatchChan) >= datumBatchChanBufferSize
 }
 
+// checkQueue returns an error if the metrics are buffered faster than they are published.
+func (c *CloudWatch) checkQueue() error {
+	if len(c.metricChan) >= metricChanBufferSize {
+		return fmt.Errorf("%d metrics are waiting to be aggregated", len(c.metricChan))
+	}
+	if c.metricDatumBatchFull() {
+		return fmt.Errorf("%d PutMetricData requests are waiting to be published", len(c.datumBatchChan))
+	}
+	return nil
+}
+
+// queueUsage returns how full the fuller of the queues of metrics to aggregate and of requests to publish is.
+func (c *CloudWatch) queueUsage() float64 {
+	return max(float64(len(c.metricChan))/metricChanBufferSize, float64(len(c.datumBatchChan))/datumBatchChanBufferSize)
+}
+
+// state returns the number of metrics waiting to be aggregated and of requests waiting to be published, with the
+// last publish and the publish failures of the region of the exporter.
+func (c *CloudWatch) state() pipelinehealth.State {
+	state := pipelinehealth.State{
+		Buffers: map[string]int{
+			"metrics":  len(c.metricChan),
+			"requests": len(c.datumBatchChan),
+		},
+		Errors: c.publishErrors.Load(),
+	}
+	if lastFlush := c.lastFlush.Load(); lastFlush != 0 {
+		t := time.Unix(0, lastFlush)
+		state.LastFlush = &t
+	}
+	return state
+}
+
 // pushMetricDatumBatch will try receiving on the channel, and if successful,
 // then it publishes the received batch.
 func (c *CloudWatch) pushMetricDatumBatch() {
@@ -332,6 +449,10 @@ func (c *CloudWatch) pushMetricDatumBatch() {
 		select {
 		case datumBatch := <-c.datumBatchChan:
 			c.publisher.Publish(datumBatch)
+			// the replicas only read the batch, so it is shared instead of copied
+			for _, r := range c.replicas {
+				r.publisher.Publish(datumBatch)
+			}
 			continue
 		default:
 		}
@@ -370,22 +491,14 @@ func createEntityMetricData(entityToMetrics map[string][]*cloudwatch.MetricDatum
 func (c *CloudWatch) WriteToCloudWatch(req interface{}) {
 	entityToMetricDatum := req.(map[string][]*cloudwatch.MetricDatum)
 
-	// PMD requires PutMetricData to have MetricData
-	metricData := entityToMetricDatum[""]
-	if _, ok := entityToMetricDatum[""]; !ok {
-		metricData = []*cloudwatch.MetricDatum{}
-	}
-
-	params := &cloudwatch.PutMetricDataInput{
-		MetricData:             metricData,
-		Namespace:              aws.String(c.config.Namespace),
-		EntityMetricData:       createEntityMetricData(entityToMetricDatum),
-		StrictEntityValidation: aws.Bool(false),
-	}
-
 	var err error
 	for i := 0; i < defaultRetryCount; i++ {
-		_, err = c.svc.PutMetricData(params)
+		// the batch can also go stale while the request is retried
+		var ok bool
+		if entityToMetricDatum, ok = c.dropStaleDatums(entityToMetricDatum, time.Now()); !ok {
+			return
+		}
+		_, err = c.svc.PutMetricData(newPutMetricDataInput(c.config.Namespace, entityToMetricDatum))
 		if err != nil {
 			awsErr, ok := err.(awserr.Error)
 			if !ok {
@@ -407,14 +520,31 @@ func (c *CloudWatch) WriteToCloudWatch(req interface{}) {
 			}
 		} else {
 			c.retries = 0
+			c.lastFlush.Store(time.Now().UnixNano())
 		}
 		break
 	}
 	if err != nil {
+		c.publishErrors.Add(1)
 		log.Println("E! cloudwatch: WriteToCloudWatch failure, err: ", err)
 	}
 }
 
+// newPutMetricDataInput creates the request of a batch of datums, which are grouped by the key of their entity.
+func newPutMetricDataInput(namespace string, entityToMetricDatum map[string][]*cloudwatch.MetricDatum) *cloudwatch.PutMetricDataInput {
+	// PMD requires PutMetricData to have MetricData
+	metricData := entityToMetricDatum[""]
+	if _, ok := entityToMetricDatum[""]; !ok {
+		metricData = []*cloudwatch.MetricDatum{}
+	}
+	return &cloudwatch.PutMetricDataInput{
+		MetricData:             metricData,
+		Namespace:              aws.String(namespace),
+		EntityMetricData:       createEntityMetricData(entityToMetricDatum),
+		StrictEntityValidation: aws.Bool(false),
+	}
+}
+
 // BuildMetricDatum may just return the datum as-is.
 // Or it might expand it into many datums due to dimension aggregation.
 // There may also be more datums due to resize() on a distribution.
@@ -513,11 +643,19 @@ func sortedTagKeys(tagMap map[string]string) []string {
 // This always includes the "host" tag if it exists.
 // See https://github.com/aws/amazon-cloudwatch-agent/issues/398
 func BuildDimensions(tagMap map[string]string) []*cloudwatch.Dimension {
-	if len(tagMap) > MaxDimensions {
+	dimensions := sortedDimensions(tagMap)
+	if len(dimensions) > MaxDimensions {
 		log.Printf("D! cloudwatch: dropping dimensions, max %v, count %v",
-			MaxDimensions, len(tagMap))
+			MaxDimensions, len(dimensions))
+		dimensions = dimensions[:MaxDimensions]
 	}
-	dimensions := make([]*cloudwatch.Dimension, 0, MaxDimensions)
+	return dimensions
+}
+
+// sortedDimensions converts the given map of strings to a list of dimensions, with the "host" dimension first and
+// the others sorted by name. Empty values are skipped.
+func sortedDimensions(tagMap map[string]string) []*cloudwatch.Dimension {
+	dimensions := make([]*cloudwatch.Dimension, 0, len(tagMap))
 	// This is pretty ugly but we always want to include the "host" tag if it exists.
 	if host, ok := tagMap["host"]; ok && host != "" {
 		dimensions = append(dimensions, &cloudwatch.Dimension{
@@ -527,9 +665,6 @@ func BuildDimensions(tagMap map[string]string) []*cloudwatch.Dimension {
 	}
 	sortedKeys := sortedTagKeys(tagMap)
 	for _, k := range sortedKeys {
-		if len(dimensions) >= MaxDimensions {
-			break
-		}
 		if k == "host" {
 			continue
 		}
@@ -11,6 +11,7 @@ import (
 	"net/http/httptest"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -19,6 +20,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/metric"
+	"github.com/influxdata/telegraf/models"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -26,6 +28,8 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/publisher"
+	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
 	"github.com/aws/amazon-cloudwatch-agent/metric/distribution"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch/cloudwatchiface"
@@ -444,6 +448,103 @@ func TestConsumeMetrics(t *testing.T) {
 	cw.Shutdown(ctx)
 }
 
+type captureAggregator struct {
+	datums []*aggregationDatum
+}
+
+func (a *captureAggregator) AddMetric(m *aggregationDatum) {
+	a.datums = append(a.datums, m)
+}
+
+func TestConsumeMetricsDimensionKeys(t *testing.T) {
+	agg := &captureAggregator{}
+	cw := &CloudWatch{
+		config:     &Config{DimensionKeys: []string{keyPrefix + "0", keyPrefix + "2"}},
+		aggregator: agg,
+	}
+	cw.config.DimensionKeys = append(cw.config.DimensionKeys, "InstanceId")
+	cw.dimensionKeys = collections.NewSet(cw.config.DimensionKeys...)
+	metrics := createTestMetrics(2, 1, 4, "s")
+	metrics.ResourceMetrics().At(0).Resource().Attributes().PutStr("InstanceId", "i-123")
+	metrics.ResourceMetrics().At(0).Resource().Attributes().PutStr("cluster", "dropped")
+	require.NoError(t, cw.ConsumeMetrics(context.Background(), metrics))
+	require.Len(t, agg.datums, 2)
+	for _, d := range agg.datums {
+		var names []string
+		for _, dimension := range d.Dimensions {
+			names = append(names, *dimension.Name)
+		}
+		assert.Equal(t, []string{"InstanceId", keyPrefix + "0", keyPrefix + "2"}, names)
+	}
+}
+
+func TestConsumeMetricsPipelineID(t *testing.T) {
+	testCases := map[string]struct {
+		promote        bool
+		dimensionKeys  []string
+		wantDimensions []string
+	}{
+		"Stripped":                  {wantDimensions: []string{keyPrefix + "0"}},
+		"Promoted":                  {promote: true, wantDimensions: []string{"PipelineId", keyPrefix + "0"}},
+		"PromotedWithDimensionKeys": {promote: true, dimensionKeys: []string{"other"}, wantDimensions: []string{"PipelineId"}},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			agg := &captureAggregator{}
+			cw := &CloudWatch{
+				config:     &Config{PipelineIDDimension: testCase.promote, DimensionKeys: testCase.dimensionKeys},
+				aggregator: agg,
+			}
+			if len(testCase.dimensionKeys) > 0 {
+				cw.dimensionKeys = collections.NewSet(testCase.dimensionKeys...)
+			}
+			metrics := createTestMetrics(1, 1, 1, "s")
+			dps := metrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints()
+			dps.At(0).Attributes().PutStr(pipelineIDTagKey, "frontend")
+			require.NoError(t, cw.ConsumeMetrics(context.Background(), metrics))
+			require.Len(t, agg.datums, 1)
+			var names []string
+			for _, dimension := range agg.datums[0].Dimensions {
+				names = append(names, *dimension.Name)
+				if *dimension.Name == "PipelineId" {
+					assert.Equal(t, "frontend", *dimension.Value)
+				}
+			}
+			assert.Equal(t, testCase.wantDimensions, names)
+		})
+	}
+}
+
+func TestConsumeMetricsAllowedNamespaces(t *testing.T) {
+	testCases := map[string]struct {
+		allowedNamespaces []string
+		wantDatums        int
+	}{
+		"WithoutAllowlist": {wantDatums: 2},
+		"Allowed":          {allowedNamespaces: []string{"Other", "CWAgent"}, wantDatums: 2},
+		"NotAllowed":       {allowedNamespaces: []string{"Other"}},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cw := &CloudWatch{
+				config: &Config{
+					Namespace:          "CWAgent",
+					AllowedNamespaces:  testCase.allowedNamespaces,
+					ForceFlushInterval: time.Second,
+					MaxDatumsPerCall:   defaultMaxDatumsPerCall,
+					MaxValuesPerDatum:  defaultMaxValuesPerDatum,
+				},
+			}
+			cw.startRoutines()
+			defer close(cw.shutdownChan)
+			agg := &captureAggregator{}
+			cw.aggregator = agg
+			require.NoError(t, cw.ConsumeMetrics(context.Background(), createTestMetrics(2, 1, 1, "s")))
+			assert.Len(t, agg.datums, testCase.wantDatums)
+		})
+	}
+}
+
 func TestWriteError(t *testing.T) {
 	svc := new(mockCloudWatchClient)
 	res := cloudwatch.PutMetricDataOutput{}
@@ -471,6 +572,75 @@ func TestWriteError(t *testing.T) {
 	cw.Shutdown(ctx)
 }
 
+// recordingCloudWatchClient records the PutMetricData requests and returns the error.
+type recordingCloudWatchClient struct {
+	cloudwatchiface.CloudWatchAPI
+	err    error
+	mu     sync.Mutex
+	inputs []*cloudwatch.PutMetricDataInput
+}
+
+func (svc *recordingCloudWatchClient) PutMetricData(input *cloudwatch.PutMetricDataInput) (*cloudwatch.PutMetricDataOutput, error) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	svc.inputs = append(svc.inputs, input)
+	return &cloudwatch.PutMetricDataOutput{}, svc.err
+}
+
+// datums returns the number of requests and the number of datums in them.
+func (svc *recordingCloudWatchClient) datums() (int, int) {
+	svc.mu.Lock()
+	defer svc.mu.Unlock()
+	var count int
+	for _, input := range svc.inputs {
+		count += len(input.MetricData)
+		for _, entityMetricData := range input.EntityMetricData {
+			count += len(entityMetricData.MetricData)
+		}
+	}
+	return len(svc.inputs), count
+}
+
+// TestReplicaRegions verifies that the replica regions receive the same metrics as the primary region and that a
+// failing replica does not block the primary.
+func TestReplicaRegions(t *testing.T) {
+	svc := &recordingCloudWatchClient{}
+	replicaSvc := &recordingCloudWatchClient{}
+	failingSvc := &recordingCloudWatchClient{err: awserr.New(cloudwatch.ErrCodeInternalServiceFault, "", nil)}
+
+	cw := newCloudWatchClient(svc, time.Second)
+	cw.config.Namespace = "namespace"
+	cw.publisher, _ = publisher.NewPublisher(
+		publisher.NewNonBlockingFifoQueue(10),
+		10,
+		2*time.Second,
+		cw.WriteToCloudWatch)
+	logger := models.NewLogger("outputs", "cloudwatch", "")
+	cw.replicas = []*CloudWatch{
+		cw.newReplica("us-west-2", replicaSvc, retryer.NewLogThrottleRetryer(logger)),
+		cw.newReplica("eu-west-1", failingSvc, retryer.NewLogThrottleRetryer(logger)),
+	}
+	assert.Equal(t, "us-west-2", cw.replicas[0].config.Region)
+	ctx := context.Background()
+	require.NoError(t, cw.ConsumeMetrics(ctx, createTestMetrics(1500, 1, 1, "B/s")))
+	// The primary and the healthy replica send both batches while the failing replica is still retrying.
+	require.Eventually(t, func() bool {
+		calls, _ := svc.datums()
+		replicaCalls, _ := replicaSvc.datums()
+		return calls == 2 && replicaCalls == 2
+	}, 4*time.Second+2*cw.config.ForceFlushInterval, 100*time.Millisecond)
+	_, datums := svc.datums()
+	_, replicaDatums := replicaSvc.datums()
+	assert.Equal(t, 1500, datums)
+	assert.Equal(t, datums, replicaDatums)
+	failingCalls, _ := failingSvc.datums()
+	assert.Less(t, failingCalls, 2*defaultRetryCount)
+	for _, input := range append(svc.inputs, replicaSvc.inputs...) {
+		assert.Equal(t, "namespace", *input.Namespace)
+	}
+	cw.Shutdown(ctx)
+}
+
 // TestPublish verifies metric batches do not get pushed immediately when
 // batch-buffer is full.
 func TestPublish(t *testing.T) {
@@ -592,6 +762,70 @@ func TestCloudWatch_metricDatumBatchFull(t *testing.T) {
 	assert.False(t, c.metricDatumBatchFull())
 }
 
+func TestCloudWatch_checkQueue(t *testing.T) {
+	c := &CloudWatch{
+		metricChan:     make(chan *aggregationDatum, metricChanBufferSize),
+		datumBatchChan: make(chan map[string][]*cloudwatch.MetricDatum, datumBatchChanBufferSize),
+	}
+	assert.NoError(t, c.checkQueue())
+	for i := 0; i < datumBatchChanBufferSize; i++ {
+		c.datumBatchChan <- map[string][]*cloudwatch.MetricDatum{}
+	}
+	assert.EqualError(t, c.checkQueue(), "50 PutMetricData requests are waiting to be published")
+	<-c.datumBatchChan
+	assert.NoError(t, c.checkQueue())
+	for i := 0; i < metricChanBufferSize; i++ {
+		c.metricChan <- &aggregationDatum{}
+	}
+	assert.EqualError(t, c.checkQueue(), "10000 metrics are waiting to be aggregated")
+}
+
+func TestCloudWatch_queueUsage(t *testing.T) {
+	c := &CloudWatch{
+		metricChan:     make(chan *aggregationDatum, metricChanBufferSize),
+		datumBatchChan: make(chan map[string][]*cloudwatch.MetricDatum, datumBatchChanBufferSize),
+	}
+	assert.Equal(t, 0.0, c.queueUsage())
+	for i := 0; i < datumBatchChanBufferSize/2; i++ {
+		c.datumBatchChan <- map[string][]*cloudwatch.MetricDatum{}
+	}
+	assert.Equal(t, 0.5, c.queueUsage())
+	for i := 0; i < metricChanBufferSize; i++ {
+		c.metricChan <- &aggregationDatum{}
+	}
+	assert.Equal(t, 1.0, c.queueUsage())
+}
+
+func TestCloudWatch_state(t *testing.T) {
+	svc := new(mockCloudWatchClient)
+	svc.On("PutMetricData", mock.Anything).Return(&cloudwatch.PutMetricDataOutput{}, nil).Once()
+	svc.On("PutMetricData", mock.Anything).Return(&cloudwatch.PutMetricDataOutput{}, awserr.New("InvalidParameterValue", "", nil))
+	cw := &CloudWatch{
+		config:         &Config{Namespace: "namespace"},
+		svc:            svc,
+		metricChan:     make(chan *aggregationDatum, metricChanBufferSize),
+		datumBatchChan: make(chan map[string][]*cloudwatch.MetricDatum, datumBatchChanBufferSize),
+	}
+	state := cw.state()
+	assert.Equal(t, map[string]int{"metrics": 0, "requests": 0}, state.Buffers)
+	assert.Nil(t, state.LastFlush)
+	assert.Zero(t, state.Errors)
+
+	for i := 0; i < 10; i++ {
+		cw.metricChan <- &aggregationDatum{}
+	}
+	cw.datumBatchChan <- map[string][]*cloudwatch.MetricDatum{}
+	batch := map[string][]*cloudwatch.MetricDatum{"": {{MetricName: aws.String("metric"), Value: aws.Float64(1)}}}
+	before := time.Now()
+	cw.WriteToCloudWatch(batch)
+	cw.WriteToCloudWatch(batch)
+	state = cw.state()
+	assert.Equal(t, map[string]int{"metrics": 10, "requests": 1}, state.Buffers)
+	require.NotNil(t, state.LastFlush)
+	assert.False(t, state.LastFlush.Before(before))
+	assert.EqualValues(t, 1, state.Errors)
+}
+
 func TestCreateEntityMetricData(t *testing.T) {
 	svc := new(mockCloudWatchClient)
 	cw := newCloudWatchClient(svc, time.Second)
@@ -609,7 +843,7 @@ func TestCreateEntityMetricData(t *testing.T) {
 	}
 	metrics := createTestMetrics(1, 1, 1, "s")
 	assert.Equal(t, 7, metrics.ResourceMetrics().At(0).Resource().Attributes().Len())
-	aggregations := ConvertOtelMetrics(metrics)
+	aggregations := ConvertOtelMetrics(metrics, nil)
 	assert.Equal(t, 0, metrics.ResourceMetrics().At(0).Resource().Attributes().Len())
 	entity, metricDatum := cw.BuildMetricDatum(aggregations[0])
 
@@ -700,3 +934,50 @@ func TestWriteToCloudWatchEntity(t *testing.T) {
 
 	assert.Equal(t, expectedPMDInput, input)
 }
+
+func TestWriteToCloudWatchMaxMetricAge(t *testing.T) {
+	now := time.Now()
+	datum := func(name string, age time.Duration) *cloudwatch.MetricDatum {
+		return &cloudwatch.MetricDatum{
+			MetricName: aws.String(name),
+			Value:      aws.Float64(1),
+			Timestamp:  aws.Time(now.Add(-age)),
+		}
+	}
+	batch := map[string][]*cloudwatch.MetricDatum{
+		"": {datum("fresh", 0), datum("old", 2*time.Hour), datum("recent", 30*time.Minute)},
+		"|Environment:Environment;Service:Service": {datum("stale", 3*time.Hour)},
+	}
+
+	var inputs []*cloudwatch.PutMetricDataInput
+	svc := new(mockCloudWatchClient)
+	svc.On("PutMetricData", mock.Anything).Run(func(args mock.Arguments) {
+		inputs = append(inputs, args.Get(0).(*cloudwatch.PutMetricDataInput))
+	}).Return(&cloudwatch.PutMetricDataOutput{}, nil)
+
+	cw := newCloudWatchClient(svc, time.Second)
+	cw.config.MaxMetricAge = time.Hour
+	cw.WriteToCloudWatch(batch)
+	require.Len(t, inputs, 1)
+	var names []string
+	for _, d := range inputs[0].MetricData {
+		names = append(names, *d.MetricName)
+	}
+	assert.Equal(t, []string{"fresh", "recent"}, names)
+	assert.Empty(t, inputs[0].EntityMetricData)
+	assert.EqualValues(t, 2, cw.staleDatums.Load())
+	// the batch is shared with the replicas, so it is not modified
+	assert.Len(t, batch[""], 3)
+
+	// a batch whose data points are all stale is not sent
+	cw.WriteToCloudWatch(map[string][]*cloudwatch.MetricDatum{"": {datum("old", 2*time.Hour)}})
+	assert.Len(t, inputs, 1)
+	assert.EqualValues(t, 3, cw.staleDatums.Load())
+
+	// nothing is dropped for its age without a max age
+	cw.config.MaxMetricAge = 0
+	cw.WriteToCloudWatch(batch)
+	require.Len(t, inputs, 2)
+	assert.Len(t, inputs[1].MetricData, 3)
+	assert.Len(t, inputs[1].EntityMetricData, 1)
+}
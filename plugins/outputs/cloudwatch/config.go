@@ -5,6 +5,9 @@ package cloudwatch
 
 import (
 	"errors"
+	"fmt"
+	"regexp"
+	"slices"
 	"time"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/resourcetotelemetry"
@@ -24,10 +27,49 @@ type Config struct {
 	ForceFlushInterval       time.Duration   `mapstructure:"force_flush_interval"`
 	MaxDatumsPerCall         int             `mapstructure:"max_datums_per_call"`
 	MaxValuesPerDatum        int             `mapstructure:"max_values_per_datum"`
+	MaxInFlightRequests      int             `mapstructure:"max_in_flight_requests"`
 	RollupDimensions         [][]string      `mapstructure:"rollup_dimensions,omitempty"`
 	DropOriginalConfigs      map[string]bool `mapstructure:"drop_original_metrics,omitempty"`
 	Namespace                string          `mapstructure:"namespace"`
 
+	// ReplicaRegions are the regions that the metrics are also sent to, such as the secondary region of a disaster
+	// recovery setup. Each replica region is published to independently of the primary region.
+	ReplicaRegions []string `mapstructure:"replica_regions,omitempty"`
+
+	// DimensionKeys are the data point and resource attribute keys that are promoted to dimensions. A data point
+	// attribute takes precedence over a resource attribute with the same key. If set, all other attributes are
+	// dropped, since PutMetricData has nowhere to store them.
+	DimensionKeys []string `mapstructure:"dimension_keys,omitempty"`
+
+	// DimensionLimitAction is what is done with the data points that have more dimensions than PutMetricData
+	// accepts, which are reported with their metric name. "truncate", the default, drops the dimensions with the
+	// lowest priority, while "drop" drops the data points.
+	DimensionLimitAction string `mapstructure:"dimension_limit_action,omitempty"`
+
+	// AggregationIntervals are the intervals that the data points of the matching metrics are aggregated into
+	// statistic sets over before they are sent. The first matching rule is used and takes precedence over the
+	// aggregation interval set by the pipeline.
+	AggregationIntervals []AggregationInterval `mapstructure:"aggregation_intervals,omitempty"`
+
+	// AllowedNamespaces are the namespaces that the metrics can be published to. Any namespace is allowed if it is
+	// empty. The metrics are dropped with a warning when the namespace of the exporter is not allowed.
+	AllowedNamespaces []string `mapstructure:"allowed_namespaces,omitempty"`
+	// StrictNamespaces makes a namespace that is not allowed invalid, so the agent does not start instead of
+	// dropping the metrics.
+	StrictNamespaces bool `mapstructure:"strict_namespaces,omitempty"`
+
+	// PipelineIDDimension promotes the id of the pipeline that the metrics are from, which the agent sets in the
+	// aws:PipelineId attribute, to the PipelineId dimension. The attribute is dropped otherwise.
+	PipelineIDDimension bool `mapstructure:"pipeline_id_dimension,omitempty"`
+
+	// MaxMetricAge is the age after which the buffered data points are dropped instead of sent, such as the data
+	// points that were buffered during an outage. The data points are not dropped for their age if it is 0.
+	MaxMetricAge time.Duration `mapstructure:"max_metric_age,omitempty"`
+
+	// ValidatePermissions simulates PutMetricData to the namespace of each region on start, so that the exporter fails
+	// to start with the missing permission instead of failing to publish every request.
+	ValidatePermissions bool `mapstructure:"validate_permissions,omitempty"`
+
 	// ResourceToTelemetrySettings is the option for converting resource
 	// attributes to telemetry attributes.
 	// "Enabled" - A boolean field to enable/disable this option. Default is `false`.
@@ -37,6 +79,17 @@ type Config struct {
 	MiddlewareID *component.ID `mapstructure:"middleware,omitempty"`
 }
 
+// AggregationInterval is the aggregation interval of the metrics in a namespace.
+type AggregationInterval struct {
+	// Namespace is the namespace that the rule applies to. The rule applies to any namespace if it is empty.
+	Namespace string `mapstructure:"namespace,omitempty"`
+	// MetricNames are the regular expressions of the metric names that the rule applies to. The rule applies to all
+	// of the metrics if it is empty.
+	MetricNames []string `mapstructure:"metric_names,omitempty"`
+	// Interval is the aggregation interval. Each data point is sent as is when it is 0.
+	Interval time.Duration `mapstructure:"interval"`
+}
+
 var _ component.Config = (*Config)(nil)
 
 // Validate checks if the exporter configuration is valid.
@@ -50,5 +103,42 @@ func (c *Config) Validate() error {
 	if c.ForceFlushInterval < time.Millisecond {
 		return errors.New("'force_flush_interval' must be at least 1 millisecond")
 	}
+	if c.MaxInFlightRequests < 1 {
+		return errors.New("'max_in_flight_requests' must be at least 1")
+	}
+	if c.DimensionLimitAction != "" && c.DimensionLimitAction != DimensionLimitActionTruncate && c.DimensionLimitAction != DimensionLimitActionDrop {
+		return fmt.Errorf("'dimension_limit_action' must be %q or %q", DimensionLimitActionTruncate, DimensionLimitActionDrop)
+	}
+	for i, rule := range c.AggregationIntervals {
+		if rule.Interval != 0 && rule.Interval < time.Second {
+			return fmt.Errorf("'aggregation_intervals' interval at index %d must be 0 or at least 1 second", i)
+		}
+		for _, name := range rule.MetricNames {
+			if _, err := regexp.Compile(name); err != nil {
+				return fmt.Errorf("'aggregation_intervals' metric name %q at index %d is invalid: %w", name, i, err)
+			}
+		}
+	}
+	if c.MaxMetricAge < 0 {
+		return errors.New("'max_metric_age' must not be negative")
+	}
+	if c.StrictNamespaces && !c.isNamespaceAllowed() {
+		return fmt.Errorf("'namespace' %q is not in 'allowed_namespaces'", c.Namespace)
+	}
+	seen := map[string]bool{c.Region: true}
+	for _, region := range c.ReplicaRegions {
+		if region == "" {
+			return errors.New("'replica_regions' must not contain an empty region")
+		}
+		if seen[region] {
+			return fmt.Errorf("'replica_regions' must not repeat %q or contain the exporter region", region)
+		}
+		seen[region] = true
+	}
 	return nil
 }
+
+// isNamespaceAllowed returns whether the namespace of the exporter is one of the allowed namespaces.
+func (c *Config) isNamespaceAllowed() bool {
+	return len(c.AllowedNamespaces) == 0 || slices.Contains(c.AllowedNamespaces, c.Namespace)
+}
@@ -33,6 +33,16 @@ func TestConfig(t *testing.T) {
 	_, err = otelcoltest.LoadConfigAndValidate(fp, factories)
 	assert.Error(t, err)
 
+	// Test max in-flight requests below the minimum.
+	fp = filepath.Join("testdata", "invalid_max_in_flight_requests.yaml")
+	_, err = otelcoltest.LoadConfigAndValidate(fp, factories)
+	assert.Error(t, err)
+
+	// Test an unknown dimension limit action.
+	fp = filepath.Join("testdata", "invalid_dimension_limit_action.yaml")
+	_, err = otelcoltest.LoadConfigAndValidate(fp, factories)
+	assert.Error(t, err)
+
 	// Test missing namespace.
 	// Expect valid because factory has a default value.
 	fp = filepath.Join("testdata", "missing_namespace.yaml")
@@ -64,6 +74,7 @@ func TestConfig(t *testing.T) {
 	assert.Equal(t, "val9", c2.Token)
 	assert.Equal(t, 7, c2.MaxDatumsPerCall)
 	assert.Equal(t, 9, c2.MaxValuesPerDatum)
+	assert.Equal(t, 3, c2.MaxInFlightRequests)
 	assert.Equal(t, 60*time.Second, c2.ForceFlushInterval)
 	// todo: verify MetricDecorations
 }
@@ -111,3 +122,82 @@ func TestConfigDropOriginConfigs(t *testing.T) {
 	assert.True(t, drop["cpu_usage"])
 	assert.True(t, drop["foo_bar"])
 }
+
+func TestConfigReplicaRegions(t *testing.T) {
+	factories, err := otelcoltest.NopFactories()
+	assert.NoError(t, err)
+	factory := NewFactory()
+	factories.Exporters[TypeStr] = factory
+
+	fp := filepath.Join("testdata", "replica_regions.yaml")
+	c, err := otelcoltest.LoadConfigAndValidate(fp, factories)
+	assert.NoError(t, err)
+	c2, ok := c.Exporters[component.NewID(TypeStr)].(*Config)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"us-west-2", "eu-west-1"}, c2.ReplicaRegions)
+
+	// Test a replica region that is the exporter region.
+	fp = filepath.Join("testdata", "invalid_replica_regions.yaml")
+	_, err = otelcoltest.LoadConfigAndValidate(fp, factories)
+	assert.ErrorContains(t, err, `'replica_regions' must not repeat "us-east-1"`)
+}
+
+func TestConfigAggregationIntervals(t *testing.T) {
+	factories, err := otelcoltest.NopFactories()
+	assert.NoError(t, err)
+	factory := NewFactory()
+	factories.Exporters[TypeStr] = factory
+
+	fp := filepath.Join("testdata", "aggregation_intervals.yaml")
+	c, err := otelcoltest.LoadConfigAndValidate(fp, factories)
+	assert.NoError(t, err)
+	c2, ok := c.Exporters[component.NewID(TypeStr)].(*Config)
+	assert.True(t, ok)
+	assert.Equal(t, []AggregationInterval{
+		{Namespace: "val1", MetricNames: []string{"^cpu_", "^mem_"}, Interval: time.Minute},
+		{MetricNames: []string{"^requests_"}},
+	}, c2.AggregationIntervals)
+
+	// Test an invalid metric name pattern.
+	fp = filepath.Join("testdata", "invalid_aggregation_intervals.yaml")
+	_, err = otelcoltest.LoadConfigAndValidate(fp, factories)
+	assert.Error(t, err)
+
+	// Test a sub-second interval.
+	cfg := &Config{Region: "us-east-1", Namespace: "val1", ForceFlushInterval: time.Minute, MaxInFlightRequests: 1}
+	cfg.AggregationIntervals = []AggregationInterval{{Interval: 500 * time.Millisecond}}
+	assert.Error(t, cfg.Validate())
+}
+
+func TestConfigAllowedNamespaces(t *testing.T) {
+	factories, err := otelcoltest.NopFactories()
+	assert.NoError(t, err)
+	factory := NewFactory()
+	factories.Exporters[TypeStr] = factory
+
+	fp := filepath.Join("testdata", "allowed_namespaces.yaml")
+	c, err := otelcoltest.LoadConfigAndValidate(fp, factories)
+	assert.NoError(t, err)
+	c2, ok := c.Exporters[component.NewID(TypeStr)].(*Config)
+	assert.True(t, ok)
+	assert.Equal(t, []string{"val1", "val2"}, c2.AllowedNamespaces)
+	assert.True(t, c2.StrictNamespaces)
+
+	// Test a namespace that is not allowed in strict mode.
+	fp = filepath.Join("testdata", "invalid_allowed_namespaces.yaml")
+	_, err = otelcoltest.LoadConfigAndValidate(fp, factories)
+	assert.ErrorContains(t, err, `'namespace' "val3" is not in 'allowed_namespaces'`)
+
+	// Test a namespace that is not allowed without strict mode, which is dropped when the metrics are sent.
+	c2.Namespace = "val3"
+	c2.StrictNamespaces = false
+	assert.NoError(t, c2.Validate())
+}
+
+func TestConfigMaxMetricAge(t *testing.T) {
+	cfg := &Config{Region: "us-east-1", Namespace: "val1", ForceFlushInterval: time.Minute, MaxInFlightRequests: 1}
+	cfg.MaxMetricAge = time.Hour
+	assert.NoError(t, cfg.Validate())
+	cfg.MaxMetricAge = -time.Second
+	assert.ErrorContains(t, cfg.Validate(), "'max_metric_age' must not be negative")
+}
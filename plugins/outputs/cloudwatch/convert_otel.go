@@ -13,12 +13,14 @@ import (
 	"go.opentelemetry.io/collector/pdata/pmetric"
 
 	cloudwatchutil "github.com/aws/amazon-cloudwatch-agent/internal/cloudwatch"
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
 	"github.com/aws/amazon-cloudwatch-agent/metric/distribution"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsentity/entityattributes"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch"
 )
 
-// ConvertOtelDimensions will returns a sorted list of dimensions.
+// ConvertOtelDimensions will returns a sorted list of dimensions. The dimensions are not limited to MaxDimensions
+// here, the exporter checks the limit of each datum before it is aggregated.
 func ConvertOtelDimensions(attributes pcommon.Map) []*cloudwatch.Dimension {
 	// Loop through map, similar to EMF exporter createLabels().
 	mTags := make(map[string]string, attributes.Len())
@@ -27,10 +29,43 @@ func ConvertOtelDimensions(attributes pcommon.Map) []*cloudwatch.Dimension {
 		if strings.HasPrefix(k, entityattributes.AWSEntityPrefix) {
 			return true
 		}
+		// the ec2tagger removes this special attribute, but it is still set if append_dimensions are not applied
+		if k == keepHostTagKey {
+			return true
+		}
 		mTags[k] = v.AsString()
 		return true
 	})
-	return BuildDimensions(mTags)
+	return sortedDimensions(mTags)
+}
+
+// selectDimensions keeps the dimensions whose names are in the keys and adds the resource attributes in the keys
+// that are not already a dimension, so that the keys select from both the data point and resource attributes.
+func selectDimensions(dimensions []*cloudwatch.Dimension, resourceAttributes pcommon.Map, keys collections.Set[string]) []*cloudwatch.Dimension {
+	tags := make(map[string]string, len(keys))
+	resourceAttributes.Range(func(k string, v pcommon.Value) bool {
+		if keys.Contains(k) && !strings.HasPrefix(k, entityattributes.AWSEntityPrefix) {
+			tags[k] = v.AsString()
+		}
+		return true
+	})
+	// the data point attributes take precedence over the resource attributes
+	for _, dimension := range dimensions {
+		if keys.Contains(*dimension.Name) {
+			tags[*dimension.Name] = *dimension.Value
+		}
+	}
+	return sortedDimensions(tags)
+}
+
+// promotePipelineID adds the id of the pipeline that the datum is from to its dimensions.
+func promotePipelineID(d *aggregationDatum) {
+	tags := make(map[string]string, len(d.Dimensions)+1)
+	for _, dimension := range d.Dimensions {
+		tags[*dimension.Name] = *dimension.Value
+	}
+	tags[pipelineIDDimension] = d.pipelineID
+	d.Dimensions = sortedDimensions(tags)
 }
 
 // NumberDataPointValue converts to float64 since that is what AWS SDK will use.
@@ -74,6 +109,16 @@ func getAggregationInterval(attributes *pcommon.Map) time.Duration {
 	return interval
 }
 
+// getPipelineID removes the special attribute with the id of the pipeline and returns its value.
+func getPipelineID(attributes *pcommon.Map) string {
+	v, ok := attributes.Get(pipelineIDTagKey)
+	if !ok {
+		return ""
+	}
+	attributes.Remove(pipelineIDTagKey)
+	return v.AsString()
+}
+
 // ConvertOtelNumberDataPoints converts each datapoint in the given slice to
 // 1 or more MetricDatums and returns them.
 func ConvertOtelNumberDataPoints(
@@ -90,6 +135,7 @@ func ConvertOtelNumberDataPoints(
 		attrs := dp.Attributes()
 		storageResolution := checkHighResolution(&attrs)
 		aggregationInterval := getAggregationInterval(&attrs)
+		pipelineID := getPipelineID(&attrs)
 		dimensions := ConvertOtelDimensions(attrs)
 		value := NumberDataPointValue(dp) * scale
 		ad := aggregationDatum{
@@ -103,6 +149,7 @@ func ConvertOtelNumberDataPoints(
 			},
 			aggregationInterval: aggregationInterval,
 			entity:              entity,
+			pipelineID:          pipelineID,
 		}
 		datums = append(datums, &ad)
 	}
@@ -124,6 +171,7 @@ func ConvertOtelHistogramDataPoints(
 		attrs := dp.Attributes()
 		storageResolution := checkHighResolution(&attrs)
 		aggregationInterval := getAggregationInterval(&attrs)
+		pipelineID := getPipelineID(&attrs)
 		dimensions := ConvertOtelDimensions(attrs)
 		ad := aggregationDatum{
 			MetricDatum: cloudwatch.MetricDatum{
@@ -135,6 +183,7 @@ func ConvertOtelHistogramDataPoints(
 			},
 			aggregationInterval: aggregationInterval,
 			entity:              entity,
+			pipelineID:          pipelineID,
 		}
 		// Assume function pointer is valid.
 		ad.distribution = distribution.NewDistribution()
@@ -167,16 +216,24 @@ func ConvertOtelMetric(m pmetric.Metric, entity cloudwatch.Entity) []*aggregatio
 	return []*aggregationDatum{}
 }
 
-func ConvertOtelMetrics(m pmetric.Metrics) []*aggregationDatum {
+// ConvertOtelMetrics converts the metrics to datums. If the dimension keys are set, the dimensions of each datum are
+// only the data point and resource attributes in the keys, otherwise they are all of the data point attributes.
+func ConvertOtelMetrics(m pmetric.Metrics, dimensionKeys collections.Set[string]) []*aggregationDatum {
 	datums := make([]*aggregationDatum, 0, m.DataPointCount())
 	for i := 0; i < m.ResourceMetrics().Len(); i++ {
-		entity := entityattributes.CreateCloudWatchEntityFromAttributes(m.ResourceMetrics().At(i).Resource().Attributes())
+		resourceAttributes := m.ResourceMetrics().At(i).Resource().Attributes()
+		entity := entityattributes.CreateCloudWatchEntityFromAttributes(resourceAttributes)
 		scopeMetrics := m.ResourceMetrics().At(i).ScopeMetrics()
 		for j := 0; j < scopeMetrics.Len(); j++ {
 			metrics := scopeMetrics.At(j).Metrics()
 			for k := 0; k < metrics.Len(); k++ {
 				metric := metrics.At(k)
 				newDatums := ConvertOtelMetric(metric, entity)
+				if dimensionKeys != nil {
+					for _, d := range newDatums {
+						d.Dimensions = selectDimensions(d.Dimensions, resourceAttributes, dimensionKeys)
+					}
+				}
 				datums = append(datums, newDatums...)
 
 			}
@@ -14,6 +14,7 @@ import (
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
 	"github.com/aws/amazon-cloudwatch-agent/metric/distribution"
 	"github.com/aws/amazon-cloudwatch-agent/metric/distribution/regular"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsentity/entityattributes"
@@ -168,7 +169,7 @@ func checkDatum(
 func TestConvertOtelMetrics_NoDimensions(t *testing.T) {
 	for i := 0; i < 100; i++ {
 		metrics := createTestMetrics(i, i, 0, "Bytes")
-		datums := ConvertOtelMetrics(metrics)
+		datums := ConvertOtelMetrics(metrics, nil)
 		// Expect nummetrics * numDatapointsPerMetric
 		assert.Equal(t, i*i, len(datums))
 
@@ -189,7 +190,7 @@ func TestConvertOtelMetrics_Histogram(t *testing.T) {
 			distribution.NewDistribution = regular.NewRegularDistribution
 		}
 		metrics := createTestHistogram(i, i, 0, "Bytes")
-		datums := ConvertOtelMetrics(metrics)
+		datums := ConvertOtelMetrics(metrics, nil)
 		// Expect nummetrics * numDatapointsPerMetric
 		assert.Equal(t, i*i, len(datums))
 
@@ -205,17 +206,13 @@ func TestConvertOtelMetrics_Dimensions(t *testing.T) {
 	for i := 0; i < 100; i++ {
 		// 1 data point per metric, but vary the number dimensions.
 		metrics := createTestMetrics(i, 1, i, "s")
-		datums := ConvertOtelMetrics(metrics)
+		datums := ConvertOtelMetrics(metrics, nil)
 		// Expect nummetrics * numDatapointsPerMetric
 		assert.Equal(t, i, len(datums))
 
-		// Verify dimensions per metric.
+		// Verify dimensions per metric. The limit is checked by the exporter after the conversion.
 		for _, d := range datums {
-			expected := i
-			if expected > 30 {
-				expected = 30
-			}
-			assert.Equal(t, expected, len(d.Dimensions))
+			assert.Equal(t, i, len(d.Dimensions))
 			checkDatum(t, d, "Seconds", i)
 		}
 	}
@@ -223,7 +220,7 @@ func TestConvertOtelMetrics_Dimensions(t *testing.T) {
 
 func TestConvertOtelMetrics_Entity(t *testing.T) {
 	metrics := createTestMetrics(1, 1, 1, "s")
-	datums := ConvertOtelMetrics(metrics)
+	datums := ConvertOtelMetrics(metrics, nil)
 	expectedEntity := cloudwatch.Entity{
 		KeyAttributes: map[string]*string{
 			"Type":         aws.String("Service"),
@@ -242,9 +239,38 @@ func TestConvertOtelMetrics_Entity(t *testing.T) {
 
 }
 
+func TestConvertOtelDimensionsWithKeepHost(t *testing.T) {
+	attributes := pcommon.NewMap()
+	attributes.PutStr("host", "web-1")
+	attributes.PutStr(keepHostTagKey, "true")
+	assert.Equal(t, []*cloudwatch.Dimension{
+		{Name: aws.String("host"), Value: aws.String("web-1")},
+	}, ConvertOtelDimensions(attributes))
+}
+
 func TestInvalidMetric(t *testing.T) {
 	m := pmetric.NewMetric()
 	m.SetName("name")
 	m.SetUnit("unit")
 	assert.Empty(t, ConvertOtelMetric(m, cloudwatch.Entity{}))
 }
+
+func TestSelectDimensions(t *testing.T) {
+	dimensions := []*cloudwatch.Dimension{
+		{Name: aws.String("host"), Value: aws.String("a")},
+		{Name: aws.String("pid"), Value: aws.String("1")},
+		{Name: aws.String("path"), Value: aws.String("/")},
+	}
+	resourceAttributes := pcommon.NewMap()
+	resourceAttributes.PutStr("host", "resource-host")
+	resourceAttributes.PutStr("InstanceId", "i-123")
+	resourceAttributes.PutStr("cluster", "dropped")
+	resourceAttributes.PutStr(entityattributes.AttributeEntityServiceName, "entity")
+	got := selectDimensions(dimensions, resourceAttributes, collections.NewSet("host", "path", "InstanceId", "missing", entityattributes.AttributeEntityServiceName))
+	assert.Equal(t, []*cloudwatch.Dimension{
+		{Name: aws.String("host"), Value: aws.String("a")},
+		{Name: aws.String("InstanceId"), Value: aws.String("i-123")},
+		{Name: aws.String("path"), Value: aws.String("/")},
+	}, got)
+	assert.Empty(t, selectDimensions(got, pcommon.NewMap(), collections.NewSet("pid")))
+}
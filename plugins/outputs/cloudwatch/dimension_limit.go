@@ -0,0 +1,89 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatch
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch"
+)
+
+const (
+	DimensionLimitActionTruncate = "truncate"
+	DimensionLimitActionDrop     = "drop"
+)
+
+// checkDimensionLimit checks the number of dimensions of a datum before it is batched, so that a datum with more
+// dimensions than PutMetricData accepts does not fail the whole request. With the truncate action the dimensions
+// with the lowest priority are dropped, otherwise the datum is dropped. Either way, the metric is reported with its
+// name the first time it is over the limit. It returns false if the datum is dropped.
+func (c *CloudWatch) checkDimensionLimit(d *aggregationDatum) bool {
+	if len(d.Dimensions) <= MaxDimensions {
+		return true
+	}
+	if c.config.DimensionLimitAction == DimensionLimitActionDrop {
+		c.reportDimensionLimit(*d.MetricName, fmt.Sprintf("metric %s has %d dimensions, more than the %d that CloudWatch accepts, dropping the data point",
+			*d.MetricName, len(d.Dimensions), MaxDimensions))
+		return false
+	}
+	kept, dropped := truncateDimensions(d.Dimensions, c.rollupDimensionKeys)
+	c.reportDimensionLimit(*d.MetricName, fmt.Sprintf("metric %s has %d dimensions, more than the %d that CloudWatch accepts, dropping dimensions %v",
+		*d.MetricName, len(d.Dimensions), MaxDimensions, dimensionNames(dropped)))
+	d.Dimensions = kept
+	return true
+}
+
+// reportDimensionLimit logs the message the first time a metric is over the limit, and at debug level after that so
+// that a metric sent at every interval does not flood the log.
+func (c *CloudWatch) reportDimensionLimit(metricName string, message string) {
+	c.overLimitMetricsMu.Lock()
+	defer c.overLimitMetricsMu.Unlock()
+	if c.overLimitMetrics == nil {
+		c.overLimitMetrics = collections.NewSet[string]()
+	}
+	if c.overLimitMetrics.Contains(metricName) {
+		log.Printf("D! cloudwatch: %s", message)
+		return
+	}
+	c.overLimitMetrics.Add(metricName)
+	log.Printf("W! cloudwatch: %s", message)
+}
+
+// truncateDimensions keeps MaxDimensions of the dimensions in their order. The "host" dimension and the dimensions
+// of the rollups have priority, so that the rollups of the datum are still published, followed by the others in
+// their order.
+func truncateDimensions(dimensions []*cloudwatch.Dimension, priority collections.Set[string]) (kept, dropped []*cloudwatch.Dimension) {
+	keep := make([]bool, len(dimensions))
+	count := 0
+	for i, dimension := range dimensions {
+		if count < MaxDimensions && (*dimension.Name == "host" || priority.Contains(*dimension.Name)) {
+			keep[i] = true
+			count++
+		}
+	}
+	for i := range dimensions {
+		if count < MaxDimensions && !keep[i] {
+			keep[i] = true
+			count++
+		}
+	}
+	for i, dimension := range dimensions {
+		if keep[i] {
+			kept = append(kept, dimension)
+		} else {
+			dropped = append(dropped, dimension)
+		}
+	}
+	return kept, dropped
+}
+
+func dimensionNames(dimensions []*cloudwatch.Dimension) []string {
+	names := make([]string, 0, len(dimensions))
+	for _, dimension := range dimensions {
+		names = append(names, *dimension.Name)
+	}
+	return names
+}
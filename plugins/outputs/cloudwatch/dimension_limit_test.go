@@ -0,0 +1,92 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatch
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch"
+)
+
+// createDimensionTestMetrics creates a gauge for each of the dimension counts, named after its count.
+func createDimensionTestMetrics(counts ...int) pmetric.Metrics {
+	metrics := pmetric.NewMetrics()
+	ms := metrics.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	for _, count := range counts {
+		m := ms.AppendEmpty()
+		m.SetName("dimensions_" + strconv.Itoa(count))
+		dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+		dp.SetDoubleValue(metricValue)
+		addDimensions(dp.Attributes(), count)
+	}
+	return metrics
+}
+
+func TestConsumeMetricsDimensionLimit(t *testing.T) {
+	testCases := map[string]struct {
+		action    string
+		wantNames []string
+	}{
+		"Truncate": {
+			action:    DimensionLimitActionTruncate,
+			wantNames: []string{"dimensions_2", "dimensions_30", "dimensions_31", "dimensions_40"},
+		},
+		"Default": {
+			wantNames: []string{"dimensions_2", "dimensions_30", "dimensions_31", "dimensions_40"},
+		},
+		"Drop": {
+			action:    DimensionLimitActionDrop,
+			wantNames: []string{"dimensions_2", "dimensions_30"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			agg := &captureAggregator{}
+			cw := &CloudWatch{
+				config:     &Config{DimensionLimitAction: testCase.action},
+				aggregator: agg,
+			}
+			require.NoError(t, cw.ConsumeMetrics(context.Background(), createDimensionTestMetrics(2, 30, 31, 40)))
+			var names []string
+			for _, d := range agg.datums {
+				names = append(names, *d.MetricName)
+				assert.LessOrEqual(t, len(d.Dimensions), MaxDimensions)
+			}
+			assert.Equal(t, testCase.wantNames, names)
+			// the over-dimension metrics are reported by name
+			assert.Equal(t, collections.NewSet("dimensions_31", "dimensions_40"), cw.overLimitMetrics)
+		})
+	}
+}
+
+func TestTruncateDimensions(t *testing.T) {
+	var dimensions []*cloudwatch.Dimension
+	for i := 0; i < MaxDimensions+3; i++ {
+		dimensions = append(dimensions, &cloudwatch.Dimension{
+			Name:  aws.String(keyPrefix + strconv.Itoa(100+i)),
+			Value: aws.String(valPrefix + strconv.Itoa(i)),
+		})
+	}
+	dimensions = append([]*cloudwatch.Dimension{{Name: aws.String("host"), Value: aws.String("example.org")}}, dimensions...)
+
+	// without priority, the last dimensions are dropped
+	kept, dropped := truncateDimensions(dimensions, collections.NewSet[string]())
+	assert.Equal(t, dimensions[:MaxDimensions], kept)
+	assert.Equal(t, []string{"key129", "key130", "key131", "key132"}, dimensionNames(dropped))
+
+	// the dimensions of the rollups are kept along with the host
+	kept, dropped = truncateDimensions(dimensions, collections.NewSet("key131", "key132"))
+	require.Len(t, kept, MaxDimensions)
+	assert.Equal(t, "host", *kept[0].Name)
+	assert.Equal(t, []string{"key131", "key132"}, dimensionNames(kept[MaxDimensions-2:]))
+	assert.Equal(t, []string{"key127", "key128", "key129", "key130"}, dimensionNames(dropped))
+}
@@ -31,10 +31,11 @@ func NewFactory() exporter.Factory {
 
 func createDefaultConfig() component.Config {
 	return &Config{
-		Namespace:          "CWAgent",
-		MaxDatumsPerCall:   defaultMaxDatumsPerCall,
-		MaxValuesPerDatum:  defaultMaxValuesPerDatum,
-		ForceFlushInterval: defaultForceFlushInterval,
+		Namespace:           "CWAgent",
+		MaxDatumsPerCall:    defaultMaxDatumsPerCall,
+		MaxValuesPerDatum:   defaultMaxValuesPerDatum,
+		MaxInFlightRequests: defaultMaxInFlightRequests,
+		ForceFlushInterval:  defaultForceFlushInterval,
 		ResourceToTelemetrySettings: resourcetotelemetry.Settings{
 			Enabled: true,
 		},
@@ -47,8 +48,9 @@ func createMetricsExporter(
 	config component.Config,
 ) (exporter.Metrics, error) {
 	cw := &CloudWatch{
-		config: config.(*Config),
-		logger: settings.Logger,
+		config:          config.(*Config),
+		logger:          settings.Logger,
+		healthCheckName: "exporter/" + settings.ID.String(),
 	}
 	exp, err := exporterhelper.NewMetrics(
 		ctx,
@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatch
+
+import (
+	"log"
+	"time"
+
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch"
+)
+
+// dropStaleDatums returns the datums of the batch whose timestamps are within the MaxMetricAge, since the metrics
+// that were buffered during an outage are misleading once they are finally sent. The batch is shared with the
+// replicas, so it is copied instead of modified when any of its datums are dropped. Returns false if all of the
+// datums were dropped.
+func (c *CloudWatch) dropStaleDatums(batch map[string][]*cloudwatch.MetricDatum, now time.Time) (map[string][]*cloudwatch.MetricDatum, bool) {
+	if c.config.MaxMetricAge <= 0 {
+		return batch, true
+	}
+	cutoff := now.Add(-c.config.MaxMetricAge)
+	isStale := func(datum *cloudwatch.MetricDatum) bool {
+		return datum.Timestamp != nil && datum.Timestamp.Before(cutoff)
+	}
+	var dropped int
+	for _, datums := range batch {
+		for _, datum := range datums {
+			if isStale(datum) {
+				dropped++
+			}
+		}
+	}
+	if dropped == 0 {
+		return batch, true
+	}
+	filtered := make(map[string][]*cloudwatch.MetricDatum, len(batch))
+	for key, datums := range batch {
+		var kept []*cloudwatch.MetricDatum
+		for _, datum := range datums {
+			if !isStale(datum) {
+				kept = append(kept, datum)
+			}
+		}
+		if len(kept) > 0 {
+			filtered[key] = kept
+		}
+	}
+	total := c.staleDatums.Add(uint64(dropped))
+	log.Printf("W! cloudwatch: dropped %d data points older than max_metric_age %v, %d dropped in total", dropped, c.config.MaxMetricAge, total)
+	return filtered, len(filtered) > 0
+}
@@ -0,0 +1,36 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatch
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/permission"
+)
+
+const (
+	permissionCheckAction = "cloudwatch:PutMetricData"
+	namespaceContextKey   = "cloudwatch:namespace"
+)
+
+// checkPermissions simulates PutMetricData to the namespace of the exporter in the region and each replica region, so
+// that the exporter fails to start if the credentials are not allowed to publish to them. The simulation evaluates the
+// policies of the credentials, so no metric is published.
+func (c *CloudWatch) checkPermissions(checker permission.Checker) error {
+	regions := []string{c.config.Region}
+	for _, r := range c.replicas {
+		regions = append(regions, r.config.Region)
+	}
+	resource := fmt.Sprintf("namespace %s", c.config.Namespace)
+	for _, region := range regions {
+		// PutMetricData does not support resource-level permissions, so only the namespace condition key is checked
+		err := checker.Check(region, resource, "", []string{permissionCheckAction}, map[string]string{namespaceContextKey: c.config.Namespace})
+		if err != nil {
+			return err
+		}
+	}
+	log.Printf("I! cloudwatch: validated the permissions to publish to namespace %s", c.config.Namespace)
+	return nil
+}
@@ -0,0 +1,46 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatch
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubChecker struct {
+	regions        []string
+	contextEntries []map[string]string
+	errs           map[string]error
+}
+
+func (s *stubChecker) Check(region, _, _ string, _ []string, contextEntries map[string]string) error {
+	s.regions = append(s.regions, region)
+	s.contextEntries = append(s.contextEntries, contextEntries)
+	return s.errs[region]
+}
+
+func TestCheckPermissions(t *testing.T) {
+	svc := new(mockCloudWatchClient)
+	c := &CloudWatch{svc: svc, config: &Config{Namespace: "CWAgent", Region: "us-east-1"}}
+	checker := &stubChecker{}
+	require.NoError(t, c.checkPermissions(checker))
+	assert.Equal(t, []string{"us-east-1"}, checker.regions)
+	assert.Equal(t, []map[string]string{{"cloudwatch:namespace": "CWAgent"}}, checker.contextEntries)
+	// nothing is published to check the permissions
+	svc.AssertNotCalled(t, "PutMetricData")
+}
+
+func TestCheckPermissionsReplica(t *testing.T) {
+	c := &CloudWatch{svc: new(mockCloudWatchClient), config: &Config{Namespace: "CWAgent", Region: "us-east-1", ReplicaRegions: []string{"us-west-2"}}}
+	c.replicas = append(c.replicas, c.newReplica("us-west-2", new(mockCloudWatchClient), nil))
+	defer c.replicas[0].publisher.Close()
+	notAllowed := errors.New("not allowed")
+	checker := &stubChecker{errs: map[string]error{"us-west-2": notAllowed}}
+
+	assert.ErrorIs(t, c.checkPermissions(checker), notAllowed)
+	assert.Equal(t, []string{"us-east-1", "us-west-2"}, checker.regions)
+}
@@ -0,0 +1,38 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatch
+
+import (
+	"time"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/publisher"
+	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatch/cloudwatchiface"
+)
+
+// replicaQueueSize is the number of batches that a replica buffers. A replica that falls behind drops its oldest
+// batches instead of holding back the publishing of the other regions.
+const replicaQueueSize = datumBatchChanBufferSize
+
+// newReplica creates the publisher of the batches of the exporter to another region, such as the secondary region of
+// a disaster recovery setup. Each replica has its own queue, client and retries, so the failures of a replica region
+// do not affect the primary region or the other replicas. Only the publisher of the replica is used since the
+// batches are built once by the exporter.
+func (c *CloudWatch) newReplica(region string, svc cloudwatchiface.CloudWatchAPI, retryer *retryer.LogThrottleRetryer) *CloudWatch {
+	config := *c.config
+	config.Region = region
+	config.ReplicaRegions = nil
+	r := &CloudWatch{
+		config:  &config,
+		logger:  c.logger,
+		svc:     svc,
+		retryer: retryer,
+	}
+	r.publisher, _ = publisher.NewPublisher(
+		publisher.NewNonBlockingFifoQueue(replicaQueueSize),
+		maxConcurrentPublisher,
+		2*time.Second,
+		r.WriteToCloudWatch)
+	return r
+}
@@ -0,0 +1,58 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatchlogs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
+
+// LogGroupClassRule assigns the log group class to the log groups of structured logs whose resource
+// attribute matches the pattern.
+type LogGroupClassRule struct {
+	Attribute string `toml:"attribute"`
+	Pattern   string `toml:"pattern"`
+	Class     string `toml:"log_group_class"`
+
+	regex *regexp.Regexp
+}
+
+// Init parses the destination and compiles the log group class rules.
+func (c *CloudWatchLogs) Init() error {
+	if err := c.initDestination(); err != nil {
+		return err
+	}
+	c.initDeadLetter()
+	for i := range c.LogGroupClassRules {
+		rule := &c.LogGroupClassRules[i]
+		regex, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern in log_group_class_rules for attribute %q: %w", rule.Attribute, err)
+		}
+		class := strings.ToUpper(rule.Class)
+		if class != util.StandardLogGroupClass && class != util.InfrequentAccessLogGroupClass {
+			return fmt.Errorf("invalid log_group_class %q in log_group_class_rules for attribute %q", rule.Class, rule.Attribute)
+		}
+		rule.regex = regex
+		rule.Class = class
+	}
+	return nil
+}
+
+// logGroupClassFromAttributes returns the class of the first rule that matches the attributes. Returns the
+// standard class if none of the rules match.
+func (c *CloudWatchLogs) logGroupClassFromAttributes(attributes map[string]string) string {
+	for _, rule := range c.LogGroupClassRules {
+		if rule.regex == nil {
+			continue
+		}
+		if value, ok := attributes[rule.Attribute]; ok && rule.regex.MatchString(value) {
+			return rule.Class
+		}
+	}
+	return util.StandardLogGroupClass
+}
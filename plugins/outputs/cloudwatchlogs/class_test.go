@@ -0,0 +1,111 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatchlogs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/metric"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs/internal/pusher"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
+
+func TestInitLogGroupClassRules(t *testing.T) {
+	testCases := map[string]struct {
+		rules   []LogGroupClassRule
+		wantErr string
+	}{
+		"WithValidRules": {
+			rules: []LogGroupClassRule{
+				{Attribute: "Namespace", Pattern: "^debug$", Class: "infrequent_access"},
+				{Attribute: "Namespace", Pattern: "^prod", Class: "STANDARD"},
+			},
+		},
+		"WithInvalidPattern": {
+			rules:   []LogGroupClassRule{{Attribute: "Namespace", Pattern: "(", Class: "STANDARD"}},
+			wantErr: `invalid pattern in log_group_class_rules for attribute "Namespace"`,
+		},
+		"WithInvalidClass": {
+			rules:   []LogGroupClassRule{{Attribute: "Namespace", Pattern: "debug", Class: "archive"}},
+			wantErr: `invalid log_group_class "archive" in log_group_class_rules for attribute "Namespace"`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := &CloudWatchLogs{LogGroupClassRules: testCase.rules}
+			err := c.Init()
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, err, testCase.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestLogGroupClassFromAttributes(t *testing.T) {
+	c := &CloudWatchLogs{
+		LogGroupClassRules: []LogGroupClassRule{
+			{Attribute: "Namespace", Pattern: "^debug$", Class: "infrequent_access"},
+			{Attribute: "ClusterName", Pattern: "-dev$", Class: util.InfrequentAccessLogGroupClass},
+			{Attribute: "Namespace", Pattern: ".*", Class: util.StandardLogGroupClass},
+		},
+	}
+	require.NoError(t, c.Init())
+	testCases := map[string]struct {
+		attributes map[string]string
+		want       string
+	}{
+		"WithMatchingAttribute": {
+			attributes: map[string]string{"Namespace": "debug"},
+			want:       util.InfrequentAccessLogGroupClass,
+		},
+		"WithFirstMatchingRule": {
+			attributes: map[string]string{"Namespace": "kube-system", "ClusterName": "cluster-dev"},
+			want:       util.InfrequentAccessLogGroupClass,
+		},
+		"WithCatchAllRule": {
+			attributes: map[string]string{"Namespace": "debugging"},
+			want:       util.StandardLogGroupClass,
+		},
+		"WithoutMatchingAttribute": {
+			attributes: map[string]string{"PodName": "debug"},
+			want:       util.StandardLogGroupClass,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, testCase.want, c.logGroupClassFromAttributes(testCase.attributes))
+		})
+	}
+	assert.Equal(t, util.StandardLogGroupClass, (&CloudWatchLogs{}).logGroupClassFromAttributes(map[string]string{"Namespace": "debug"}))
+}
+
+func TestWriteAssignsLogGroupClass(t *testing.T) {
+	c := &CloudWatchLogs{
+		Log:            testutil.Logger{Name: "test"},
+		LogStreamName:  "S1",
+		AccessKey:      "access_key",
+		SecretKey:      "secret_key",
+		pusherStopChan: make(chan struct{}),
+		cwDests:        make(map[pusher.Target]*cwDest),
+		LogGroupClassRules: []LogGroupClassRule{
+			{Attribute: "Namespace", Pattern: "^debug$", Class: util.InfrequentAccessLogGroupClass},
+		},
+	}
+	require.NoError(t, c.Init())
+	newMetric := func(group, namespace string) telegraf.Metric {
+		tags := map[string]string{LogGroupNameTag: group, "Namespace": namespace}
+		return metric.New("test", tags, map[string]interface{}{"value": "message"}, time.Now())
+	}
+	require.NoError(t, c.Write([]telegraf.Metric{newMetric("G1", "debug"), newMetric("G2", "shop")}))
+	assert.Contains(t, c.cwDests, pusher.Target{Group: "G1", Stream: "S1", Class: util.InfrequentAccessLogGroupClass, Retention: -1})
+	assert.Contains(t, c.cwDests, pusher.Target{Group: "G2", Stream: "S1", Class: util.StandardLogGroupClass, Retention: -1})
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/amazon-contributing/opentelemetry-collector-contrib/extension/awsmiddleware"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/client"
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/plugins/outputs"
 	"go.uber.org/zap"
@@ -22,13 +23,13 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth"
 	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/stats/agent"
 	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth/handler/useragent"
+	"github.com/aws/amazon-cloudwatch-agent/extension/pipelinehealth"
 	"github.com/aws/amazon-cloudwatch-agent/handlers"
 	"github.com/aws/amazon-cloudwatch-agent/internal"
 	"github.com/aws/amazon-cloudwatch-agent/internal/retryer"
 	"github.com/aws/amazon-cloudwatch-agent/logs"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs/internal/pusher"
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
-	"github.com/aws/amazon-cloudwatch-agent/tool/util"
 )
 
 const (
@@ -40,10 +41,15 @@ const (
 
 	defaultFlushTimeout = 5 * time.Second
 
+	// defaultMaxInFlightRequests is the number of requests that the clients of the output can send at a time
+	defaultMaxInFlightRequests = 50
+
 	maxRetryTimeout    = 14*24*time.Hour + 10*time.Minute
 	metricRetryTimeout = 2 * time.Minute
 
 	attributesInFields = "attributesInFields"
+
+	healthCheckName = "outputs/cloudwatchlogs"
 )
 
 var (
@@ -62,38 +68,84 @@ type CloudWatchLogs struct {
 	Filename         string `toml:"shared_credential_file"`
 	Token            string `toml:"token"`
 
+	// Log group ARN in another account, such as the sink of a central monitoring account, that all of the log events
+	// are sent to. The destination role is assumed with the credentials of the source account to send them.
+	DestinationARN     string `toml:"destination_arn"`
+	DestinationRoleARN string `toml:"destination_role_arn"`
+
+	// Log group that the log events which cannot be sent, such as events that are too large or have a timestamp out
+	// of the accepted range, are sent to with the reason and their original log group and stream.
+	DeadLetterLogGroup string `toml:"deadletter_log_group"`
+	// Number of log events that can be sent to the dead letter log group per minute
+	DeadLetterMaxEventsPerMinute int `toml:"deadletter_max_events_per_minute"`
+
 	//log group and stream names
 	LogStreamName string `toml:"log_stream_name"`
 	LogGroupName  string `toml:"log_group_name"`
 
 	// Retention for log group
 	RetentionInDays int `toml:"retention_in_days"`
-	Concurrency     int `toml:"concurrency"`
+	// Update the retention of existing log groups that differs from the configured retention. Enabled by default.
+	ReconcileRetention bool `toml:"reconcile_retention"`
+	Concurrency        int  `toml:"concurrency"`
+	// Number of batches of each log stream that can be sent at a time when concurrency is not set
+	StreamConcurrency int `toml:"stream_concurrency"`
+	// Number of times a request is retried before its events are sent in smaller requests, down to a single event that
+	// is dropped, so an event that is rejected every time does not block the log stream. 0 retries until the retry
+	// duration is reached.
+	MaxEventRetries int `toml:"max_event_retries"`
+	// Number of requests that can be sent at a time across all of the log groups and streams
+	MaxInFlightRequests int `toml:"max_in_flight_requests"`
+	// Number of CreateLogGroup and CreateLogStream requests that can be sent to the region per second
+	MaxCreateRequestsPerSecond int `toml:"max_create_requests_per_second"`
 
 	ForceFlushInterval internal.Duration `toml:"force_flush_interval"` // unit is second
 
+	// Check the permissions of the credentials with a policy simulation when the agent starts
+	ValidatePermissions bool `toml:"validate_permissions"`
+
+	// Resource attributes used to set the service entity of structured logs. The first key with a value is used.
+	EntityServiceNameKeys []string `toml:"entity_service_name_keys"`
+	EntityEnvironmentKeys []string `toml:"entity_environment_keys"`
+
+	// Rules that assign the log group class of structured logs based on their resource attributes
+	LogGroupClassRules []LogGroupClassRule `toml:"log_group_class_rules"`
+
 	Log telegraf.Logger `toml:"-"`
 
-	pusherStopChan  chan struct{}
-	pusherWaitGroup sync.WaitGroup
-	cwDests         map[pusher.Target]*cwDest
-	workerPool      pusher.WorkerPool
-	targetManager   pusher.TargetManager
-	once            sync.Once
-	middleware      awsmiddleware.Middleware
+	pusherStopChan   chan struct{}
+	pusherWaitGroup  sync.WaitGroup
+	cwDests          map[pusher.Target]*cwDest
+	cwDestsMu        sync.Mutex
+	workerPool       pusher.WorkerPool
+	targetManager    pusher.TargetManager
+	once             sync.Once
+	middleware       awsmiddleware.Middleware
+	inFlightLimiter  *handlers.InFlightLimiter
+	destination      *destination
+	deadLetter       pusher.DeadLetter
+	deadLetterTarget pusher.Target
 }
 
 func (c *CloudWatchLogs) Connect() error {
+	pipelinehealth.RegisterCheck(healthCheckName, c.checkQueues)
+	pipelinehealth.RegisterQueue(healthCheckName, c.queueUsage)
+	pipelinehealth.RegisterState(healthCheckName, c.state)
 	return nil
 }
 
 func (c *CloudWatchLogs) Close() error {
+	pipelinehealth.UnregisterCheck(healthCheckName)
+	pipelinehealth.UnregisterQueue(healthCheckName)
+	pipelinehealth.UnregisterState(healthCheckName)
 	close(c.pusherStopChan)
 	c.pusherWaitGroup.Wait()
 
+	c.cwDestsMu.Lock()
 	for _, d := range c.cwDests {
 		d.Stop()
 	}
+	c.cwDestsMu.Unlock()
 	if c.workerPool != nil {
 		c.workerPool.Stop()
 	}
@@ -128,11 +180,24 @@ func (c *CloudWatchLogs) CreateDest(group, stream string, retention int, logGrou
 	return c.getDest(t, logSrc)
 }
 
-func (c *CloudWatchLogs) getDest(t pusher.Target, logSrc logs.LogSrc) *cwDest {
+func (c *CloudWatchLogs) getDest(t pusher.Target, entityProvider logs.LogEntityProvider) *cwDest {
+	c.cwDestsMu.Lock()
+	defer c.cwDestsMu.Unlock()
 	if cwd, ok := c.cwDests[t]; ok {
 		return cwd
 	}
 
+	var deadLetter pusher.DeadLetter
+	if t != c.deadLetterTarget {
+		deadLetter = c.getDeadLetter()
+	}
+	cwd := c.createDest(t, entityProvider, deadLetter)
+	c.cwDests[t] = cwd
+	return cwd
+}
+
+// createDest creates the pusher of the target. Called with cwDestsMu held.
+func (c *CloudWatchLogs) createDest(t pusher.Target, entityProvider logs.LogEntityProvider, deadLetter pusher.DeadLetter) *cwDest {
 	logThrottleRetryer := retryer.NewLogThrottleRetryer(c.Log)
 	client := c.createClient(logThrottleRetryer)
 	agent.UsageFlags().SetValue(agent.FlagRegionType, c.RegionType)
@@ -144,15 +209,67 @@ func (c *CloudWatchLogs) getDest(t pusher.Target, logSrc logs.LogSrc) *cwDest {
 		if c.Concurrency > 0 {
 			c.workerPool = pusher.NewWorkerPool(c.Concurrency)
 		}
-		c.targetManager = pusher.NewTargetManager(c.Log, client)
+		opts := []pusher.TargetManagerOption{pusher.WithRegion(c.credentialConfig().Region)}
+		if c.MaxCreateRequestsPerSecond > 0 {
+			opts = append(opts, pusher.WithMaxCreateRequestsPerSecond(c.MaxCreateRequestsPerSecond))
+		}
+		if !c.ReconcileRetention {
+			opts = append(opts, pusher.WithoutRetentionReconciliation())
+		}
+		c.targetManager = pusher.NewTargetManager(c.Log, client, opts...)
 	})
-	p := pusher.NewPusher(c.Log, t, client, c.targetManager, logSrc, c.workerPool, c.ForceFlushInterval.Duration, maxRetryTimeout, c.pusherStopChan, &c.pusherWaitGroup)
-	cwd := &cwDest{pusher: p, retryer: logThrottleRetryer}
-	c.cwDests[t] = cwd
-	return cwd
+	p := pusher.NewPusher(c.Log, c.destinationTarget(t), client, c.targetManager, entityProvider, c.workerPool, c.StreamConcurrency, c.ForceFlushInterval.Duration, maxRetryTimeout, c.MaxEventRetries, deadLetter, c.pusherStopChan, &c.pusherWaitGroup)
+	return &cwDest{pusher: p, retryer: logThrottleRetryer}
 }
 
-func (c *CloudWatchLogs) createClient(retryer aws.RequestRetryer) *cloudwatchlogs.CloudWatchLogs {
+// checkQueues returns an error if the queue of any destination is full.
+func (c *CloudWatchLogs) checkQueues() error {
+	c.cwDestsMu.Lock()
+	defer c.cwDestsMu.Unlock()
+	for t, cwd := range c.cwDests {
+		if cwd.pusher.Saturated() {
+			return fmt.Errorf("log events for %v/%v are queued faster than they are sent", t.Group, t.Stream)
+		}
+	}
+	return nil
+}
+
+// queueUsage returns how full the fullest queue of the destinations is.
+func (c *CloudWatchLogs) queueUsage() float64 {
+	c.cwDestsMu.Lock()
+	defer c.cwDestsMu.Unlock()
+	var usage float64
+	for _, cwd := range c.cwDests {
+		usage = max(usage, cwd.pusher.Usage())
+	}
+	return usage
+}
+
+// state returns the number of log events waiting to be batched across the destinations, with the last time that any
+// destination sent a batch.
+func (c *CloudWatchLogs) state() pipelinehealth.State {
+	c.cwDestsMu.Lock()
+	defer c.cwDestsMu.Unlock()
+	var events int
+	var lastSent time.Time
+	for _, cwd := range c.cwDests {
+		events += cwd.pusher.Len()
+		if t := cwd.pusher.LastSent(); t.After(lastSent) {
+			lastSent = t
+		}
+	}
+	state := pipelinehealth.State{
+		Buffers: map[string]int{"events": events},
+	}
+	if !lastSent.IsZero() {
+		state.LastFlush = &lastSent
+	}
+	return state
+}
+
+// credentialConfig returns the credential config of the clients. With a destination, the clients use the region of the
+// destination.
+func (c *CloudWatchLogs) credentialConfig() *configaws.CredentialConfig {
 	credentialConfig := &configaws.CredentialConfig{
 		Region:    c.Region,
 		AccessKey: c.AccessKey,
@@ -162,8 +279,24 @@ func (c *CloudWatchLogs) createClient(retryer aws.RequestRetryer) *cloudwatchlog
 		Filename:  c.Filename,
 		Token:     c.Token,
 	}
+	if c.destination != nil {
+		credentialConfig.Region = c.destination.region
+	}
+	return credentialConfig
+}
+
+// credentials returns the credentials of the clients. With a destination, the destination role is assumed with the
+// credentials of the source account, including its role_arn.
+func (c *CloudWatchLogs) credentials() client.ConfigProvider {
+	if c.destination != nil {
+		return c.credentialConfig().ChainedCredentials(c.DestinationRoleARN)
+	}
+	return c.credentialConfig().Credentials()
+}
+
+func (c *CloudWatchLogs) createClient(retryer aws.RequestRetryer) *cloudwatchlogs.CloudWatchLogs {
 	client := cloudwatchlogs.New(
-		credentialConfig.Credentials(),
+		c.credentials(),
 		&aws.Config{
 			Endpoint: aws.String(c.EndpointOverride),
 			Retryer:  retryer,
@@ -172,6 +305,15 @@ func (c *CloudWatchLogs) createClient(retryer aws.RequestRetryer) *cloudwatchlog
 		},
 	)
 	client.Handlers.Build.PushBackNamed(handlers.NewRequestCompressionHandler([]string{"PutLogEvents"}))
+	// each destination has its own client, so they share the limiter. Called with cwDestsMu held.
+	if c.inFlightLimiter == nil {
+		maxInFlightRequests := c.MaxInFlightRequests
+		if maxInFlightRequests <= 0 {
+			maxInFlightRequests = defaultMaxInFlightRequests
+		}
+		c.inFlightLimiter = handlers.NewInFlightLimiter(maxInFlightRequests)
+	}
+	c.inFlightLimiter.Configure(&client.Handlers)
 	if c.middleware != nil {
 		if err := awsmiddleware.NewConfigurer(c.middleware.Handlers()).Configure(awsmiddleware.SDKv1(&client.Handlers)); err != nil {
 			c.Log.Errorf("Unable to configure middleware on cloudwatch logs client: %v", err)
@@ -195,10 +337,12 @@ func (c *CloudWatchLogs) writeMetricAsStructuredLog(m telegraf.Metric) {
 	cwd.switchToEMF()
 	cwd.pusher.Sender.SetRetryDuration(metricRetryTimeout)
 
+	entity := c.createEntityFromAttributes(m.Tags())
 	e := c.getLogEventFromMetric(m)
 	if e == nil {
 		return
 	}
+	e.entity = entity
 
 	cwd.AddEvent(e)
 }
@@ -219,7 +363,7 @@ func (c *CloudWatchLogs) getTargetFromMetric(m telegraf.Metric) (pusher.Target,
 		logStream = c.LogStreamName
 	}
 
-	return pusher.Target{Group: logGroup, Stream: logStream, Class: util.StandardLogGroupClass, Retention: -1}, nil
+	return pusher.Target{Group: logGroup, Stream: logStream, Class: c.logGroupClassFromAttributes(tags), Retention: -1}, nil
 }
 
 func (c *CloudWatchLogs) getLogEventFromMetric(metric telegraf.Metric) *structuredLogEvent {
@@ -300,8 +444,12 @@ func (c *CloudWatchLogs) getLogEventFromMetric(metric telegraf.Metric) *structur
 type structuredLogEvent struct {
 	msg string
 	t   time.Time
+	// entity is the service of the resource of the event, if any.
+	entity *cloudwatchlogs.Entity
 }
 
+var _ logs.LogEntityProvider = (*structuredLogEvent)(nil)
+
 func (e *structuredLogEvent) Message() string {
 	return e.msg
 }
@@ -312,6 +460,10 @@ func (e *structuredLogEvent) Time() time.Time {
 
 func (e *structuredLogEvent) Done() {}
 
+func (e *structuredLogEvent) Entity() *cloudwatchlogs.Entity {
+	return e.entity
+}
+
 type cwDest struct {
 	pusher *pusher.Pusher
 	sync.Mutex
@@ -386,8 +538,40 @@ var sampleConfig = `
   #profile = ""
   #shared_credential_file = ""
 
+  ## Send all of the log events to a log group in another account, such as the
+  ## sink of a central monitoring account, by assuming a role in that account.
+  #destination_arn = "arn:aws:logs:us-east-1:123456789012:log-group:central"
+  #destination_role_arn = "arn:aws:iam::123456789012:role/CloudWatchAgentLogs"
+
+  ## Send the log events that cannot be sent, such as events that are too large
+  ## or have a timestamp out of the accepted range, to a dead letter log group
+  ## with the reason instead of dropping or truncating them.
+  #deadletter_log_group = "deadletter"
+  #deadletter_max_events_per_minute = 100
+
   # The log stream name.
   log_stream_name = "<log_stream_name>"
+
+  ## Update the retention of existing log groups to the configured retention_in_days.
+  ## Set to false to only set the retention on log groups created by the agent.
+  #reconcile_retention = true
+
+  ## Check the permissions of the credentials with iam:SimulatePrincipalPolicy
+  ## when the agent starts, and fail to start if they are missing.
+  #validate_permissions = false
+
+  ## Resource attributes used to find the service entity of structured logs.
+  ## The first attribute with a value is used.
+  #entity_service_name_keys = ["service.name"]
+  #entity_environment_keys = ["deployment.environment.name", "deployment.environment"]
+
+  ## Assign the log group class of structured logs based on a resource attribute.
+  ## The first rule with a pattern matching the attribute value is used, otherwise
+  ## the log group is created with the STANDARD class.
+  #[[outputs.cloudwatchlogs.log_group_class_rules]]
+  #  attribute = "Namespace"
+  #  pattern = "^debug$"
+  #  log_group_class = "INFREQUENT_ACCESS"
 `
 
 // SampleConfig returns the default configuration of the Output
@@ -399,6 +583,7 @@ func init() {
 	outputs.Add("cloudwatchlogs", func() telegraf.Output {
 		return &CloudWatchLogs{
 			ForceFlushInterval: internal.Duration{Duration: defaultFlushTimeout},
+			ReconcileRetention: true,
 			pusherStopChan:     make(chan struct{}),
 			cwDests:            make(map[pusher.Target]*cwDest),
 			middleware: agenthealth.NewAgentHealth(
@@ -5,10 +5,13 @@ package cloudwatchlogs
 
 import (
 	"testing"
+	"time"
 
 	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/aws/amazon-cloudwatch-agent/extension/pipelinehealth"
 	"github.com/aws/amazon-cloudwatch-agent/logs"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs/internal/pusher"
 	"github.com/aws/amazon-cloudwatch-agent/tool/util"
@@ -102,3 +105,79 @@ func TestDuplicateDestination(t *testing.T) {
 	// Then the destination for cloudwatchlogs endpoint would be the same
 	require.Equal(t, d1, d2)
 }
+
+func TestInFlightLimiter(t *testing.T) {
+	c := &CloudWatchLogs{
+		Log:            testutil.Logger{Name: "test"},
+		AccessKey:      "access_key",
+		SecretKey:      "secret_key",
+		cwDests:        make(map[pusher.Target]*cwDest),
+		pusherStopChan: make(chan struct{}),
+	}
+	c.CreateDest("G", "S1", -1, "", nil)
+	require.NotNil(t, c.inFlightLimiter)
+	assert.Equal(t, defaultMaxInFlightRequests, c.inFlightLimiter.Limit())
+	limiter := c.inFlightLimiter
+	// the destinations share the limiter of the output
+	c.CreateDest("G", "S2", -1, "", nil)
+	assert.Same(t, limiter, c.inFlightLimiter)
+
+	c = &CloudWatchLogs{
+		Log:                 testutil.Logger{Name: "test"},
+		MaxInFlightRequests: 5,
+		cwDests:             make(map[pusher.Target]*cwDest),
+		pusherStopChan:      make(chan struct{}),
+	}
+	c.CreateDest("G", "S1", -1, "", nil)
+	assert.Equal(t, 5, c.inFlightLimiter.Limit())
+}
+
+type stubQueue struct {
+	pusher.Queue
+	saturated bool
+	len       int
+	usage     float64
+	lastSent  time.Time
+}
+
+func (q *stubQueue) Saturated() bool {
+	return q.saturated
+}
+
+func (q *stubQueue) Len() int {
+	return q.len
+}
+
+func (q *stubQueue) Usage() float64 {
+	return q.usage
+}
+
+func (q *stubQueue) LastSent() time.Time {
+	return q.lastSent
+}
+
+func TestCheckQueues(t *testing.T) {
+	full := &stubQueue{}
+	c := &CloudWatchLogs{
+		cwDests: map[pusher.Target]*cwDest{
+			{Group: "G", Stream: "S1"}: {pusher: &pusher.Pusher{Queue: &stubQueue{}}},
+			{Group: "G", Stream: "S2"}: {pusher: &pusher.Pusher{Queue: full}},
+		},
+	}
+	assert.NoError(t, c.checkQueues())
+	full.saturated = true
+	assert.EqualError(t, c.checkQueues(), "log events for G/S2 are queued faster than they are sent")
+}
+
+func TestQueueState(t *testing.T) {
+	c := &CloudWatchLogs{cwDests: map[pusher.Target]*cwDest{}}
+	assert.Zero(t, c.queueUsage())
+	assert.Equal(t, pipelinehealth.State{Buffers: map[string]int{"events": 0}}, c.state())
+
+	lastSent := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c.cwDests[pusher.Target{Group: "G", Stream: "S1"}] = &cwDest{pusher: &pusher.Pusher{Queue: &stubQueue{len: 10, usage: 0.1, lastSent: lastSent.Add(-time.Minute)}}}
+	c.cwDests[pusher.Target{Group: "G", Stream: "S2"}] = &cwDest{pusher: &pusher.Pusher{Queue: &stubQueue{len: 50, usage: 0.5, lastSent: lastSent}}}
+	c.cwDests[pusher.Target{Group: "G", Stream: "S3"}] = &cwDest{pusher: &pusher.Pusher{Queue: &stubQueue{}}}
+	assert.Equal(t, 0.5, c.queueUsage())
+	assert.Equal(t, pipelinehealth.State{Buffers: map[string]int{"events": 60}, LastFlush: &lastSent}, c.state())
+}
@@ -0,0 +1,46 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatchlogs
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs/internal/pusher"
+)
+
+const (
+	defaultDeadLetterLogStream          = "deadletter"
+	defaultDeadLetterMaxEventsPerMinute = 100
+)
+
+// initDeadLetter sets the target of the dead letter log group. The log stream of the output is used so the records
+// of each agent are in their own stream.
+func (c *CloudWatchLogs) initDeadLetter() {
+	if c.DeadLetterLogGroup == "" {
+		return
+	}
+	stream := c.LogStreamName
+	if stream == "" {
+		stream = defaultDeadLetterLogStream
+	}
+	if c.DeadLetterMaxEventsPerMinute <= 0 {
+		c.DeadLetterMaxEventsPerMinute = defaultDeadLetterMaxEventsPerMinute
+	}
+	c.deadLetterTarget = pusher.Target{Group: c.DeadLetterLogGroup, Stream: stream, Retention: -1}
+}
+
+// getDeadLetter returns the dead letter shared by the destinations, which is nil if there is no dead letter log
+// group. The pusher of the dead letter log group does not have a dead letter itself. Called with cwDestsMu held.
+func (c *CloudWatchLogs) getDeadLetter() pusher.DeadLetter {
+	if c.DeadLetterLogGroup == "" {
+		return nil
+	}
+	if c.deadLetter == nil {
+		cwd, ok := c.cwDests[c.deadLetterTarget]
+		if !ok {
+			cwd = c.createDest(c.deadLetterTarget, nil, nil)
+			c.cwDests[c.deadLetterTarget] = cwd
+		}
+		c.deadLetter = pusher.NewDeadLetter(c.Log, cwd.pusher, c.DeadLetterMaxEventsPerMinute)
+	}
+	return c.deadLetter
+}
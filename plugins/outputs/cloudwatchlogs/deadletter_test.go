@@ -0,0 +1,42 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatchlogs
+
+import (
+	"testing"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs/internal/pusher"
+)
+
+func TestCreateDestinationWithDeadLetter(t *testing.T) {
+	c := &CloudWatchLogs{
+		Log:                testutil.Logger{Name: "test"},
+		Region:             "us-west-2",
+		LogGroupName:       "G1",
+		LogStreamName:      "S1",
+		DeadLetterLogGroup: "deadletter",
+		pusherStopChan:     make(chan struct{}),
+		cwDests:            make(map[pusher.Target]*cwDest),
+	}
+	require.NoError(t, c.Init())
+	assert.Equal(t, pusher.Target{Group: "deadletter", Stream: "S1", Retention: -1}, c.deadLetterTarget)
+	assert.Equal(t, defaultDeadLetterMaxEventsPerMinute, c.DeadLetterMaxEventsPerMinute)
+
+	// the pusher of the dead letter log group is created with the first destination and shared
+	c.CreateDest("G2", "S2", -1, "", nil)
+	require.NotNil(t, c.deadLetter)
+	deadLetterDest := c.cwDests[c.deadLetterTarget]
+	require.NotNil(t, deadLetterDest)
+	c.CreateDest("G3", "S3", -1, "", nil)
+	assert.Len(t, c.cwDests, 3)
+	assert.Same(t, deadLetterDest, c.CreateDest("deadletter", "S1", -1, "", nil))
+
+	c = &CloudWatchLogs{Log: testutil.Logger{Name: "test"}, DeadLetterLogGroup: "deadletter"}
+	require.NoError(t, c.Init())
+	assert.Equal(t, defaultDeadLetterLogStream, c.deadLetterTarget.Stream)
+}
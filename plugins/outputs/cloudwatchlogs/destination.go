@@ -0,0 +1,96 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatchlogs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/arn"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs/internal/pusher"
+)
+
+const (
+	logGroupResourcePrefix = "log-group:"
+	logsServiceName        = "logs"
+)
+
+var accountIDRegexp = regexp.MustCompile(`^\d{12}$`)
+
+// destination is the log group in another account, such as the sink of a central monitoring account, that the
+// log events of the output are sent to.
+type destination struct {
+	region    string
+	accountID string
+	logGroup  string
+}
+
+// parseDestinationARN returns the destination of a log group ARN such as
+// arn:aws:logs:us-east-1:123456789012:log-group:central. The ARN returned by DescribeLogGroups, which ends with
+// ":*", is also accepted.
+func parseDestinationARN(s string) (*destination, error) {
+	a, err := arn.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid destination_arn %q: %w", s, err)
+	}
+	if a.Service != logsServiceName {
+		return nil, fmt.Errorf("invalid destination_arn %q: service must be %q", s, logsServiceName)
+	}
+	if a.Region == "" {
+		return nil, fmt.Errorf("invalid destination_arn %q: missing region", s)
+	}
+	if !accountIDRegexp.MatchString(a.AccountID) {
+		return nil, fmt.Errorf("invalid destination_arn %q: account ID must be 12 digits", s)
+	}
+	if !strings.HasPrefix(a.Resource, logGroupResourcePrefix) {
+		return nil, fmt.Errorf("invalid destination_arn %q: resource must be a log group", s)
+	}
+	logGroup := strings.TrimSuffix(strings.TrimPrefix(a.Resource, logGroupResourcePrefix), ":*")
+	if logGroup == "" {
+		return nil, fmt.Errorf("invalid destination_arn %q: missing log group name", s)
+	}
+	return &destination{region: a.Region, accountID: a.AccountID, logGroup: logGroup}, nil
+}
+
+// initDestination parses the destination ARN. The role in the account of the destination is required since the
+// credentials of the source account cannot put log events in another account.
+func (c *CloudWatchLogs) initDestination() error {
+	if c.DestinationARN == "" {
+		return nil
+	}
+	d, err := parseDestinationARN(c.DestinationARN)
+	if err != nil {
+		return err
+	}
+	if c.DestinationRoleARN == "" {
+		return fmt.Errorf("destination_role_arn is required with destination_arn %q", c.DestinationARN)
+	}
+	role, err := arn.Parse(c.DestinationRoleARN)
+	if err != nil || role.Service != "iam" || !strings.HasPrefix(role.Resource, "role/") {
+		return fmt.Errorf("invalid destination_role_arn %q", c.DestinationRoleARN)
+	}
+	if role.AccountID != d.accountID {
+		c.Log.Warnf("destination_role_arn %q is not in the account of destination_arn %q", c.DestinationRoleARN, c.DestinationARN)
+	}
+	c.destination = d
+	return nil
+}
+
+// destinationTarget returns the target that the log events of a target are sent to. With a destination, the log group
+// of the target is kept in the stream name, so the targets of different log groups do not share a stream of the
+// destination log group. The retention of the destination log group is left to its account. The dead letter log group
+// and the log groups identified by their ARN are not replaced by the destination.
+func (c *CloudWatchLogs) destinationTarget(t pusher.Target) pusher.Target {
+	if c.destination == nil || t == c.deadLetterTarget || arn.IsARN(t.Group) {
+		return t
+	}
+	return pusher.Target{
+		Group:     c.destination.logGroup,
+		Stream:    t.Group + "/" + t.Stream,
+		Class:     t.Class,
+		Retention: -1,
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatchlogs
+
+import (
+	"testing"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs/internal/pusher"
+)
+
+func TestParseDestinationARN(t *testing.T) {
+	testCases := map[string]struct {
+		arn     string
+		want    *destination
+		wantErr string
+	}{
+		"LogGroup": {
+			arn:  "arn:aws:logs:us-east-1:123456789012:log-group:central",
+			want: &destination{region: "us-east-1", accountID: "123456789012", logGroup: "central"},
+		},
+		"LogGroupWithSuffix": {
+			arn:  "arn:aws-cn:logs:cn-north-1:123456789012:log-group:/aws/central:*",
+			want: &destination{region: "cn-north-1", accountID: "123456789012", logGroup: "/aws/central"},
+		},
+		"NotARN": {
+			arn:     "central",
+			wantErr: "invalid destination_arn",
+		},
+		"WrongService": {
+			arn:     "arn:aws:s3:us-east-1:123456789012:log-group:central",
+			wantErr: "service must be",
+		},
+		"MissingRegion": {
+			arn:     "arn:aws:logs::123456789012:log-group:central",
+			wantErr: "missing region",
+		},
+		"InvalidAccount": {
+			arn:     "arn:aws:logs:us-east-1:1234:log-group:central",
+			wantErr: "account ID",
+		},
+		"NotLogGroup": {
+			arn:     "arn:aws:logs:us-east-1:123456789012:destination:central",
+			wantErr: "must be a log group",
+		},
+		"MissingLogGroup": {
+			arn:     "arn:aws:logs:us-east-1:123456789012:log-group::*",
+			wantErr: "missing log group name",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := parseDestinationARN(testCase.arn)
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, err, testCase.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestInitDestination(t *testing.T) {
+	c := &CloudWatchLogs{
+		Log:            testutil.Logger{Name: "test"},
+		DestinationARN: "arn:aws:logs:us-east-1:123456789012:log-group:central",
+	}
+	assert.ErrorContains(t, c.Init(), "destination_role_arn is required")
+
+	c.DestinationRoleARN = "arn:aws:s3:::bucket"
+	assert.ErrorContains(t, c.Init(), "invalid destination_role_arn")
+
+	c.DestinationRoleARN = "arn:aws:iam::123456789012:role/CloudWatchAgentLogs"
+	require.NoError(t, c.Init())
+	assert.Equal(t, "central", c.destination.logGroup)
+
+	c.DestinationARN = "arn:aws:logs:us-east-1:123456789012:destination:central"
+	assert.Error(t, c.Init())
+}
+
+func TestCreateDestinationWithDestinationARN(t *testing.T) {
+	c := &CloudWatchLogs{
+		Log:                testutil.Logger{Name: "test"},
+		Region:             "us-west-2",
+		RoleARN:            "arn:aws:iam::111111111111:role/Source",
+		LogGroupName:       "G1",
+		LogStreamName:      "S1",
+		DestinationARN:     "arn:aws:logs:eu-west-1:123456789012:log-group:central",
+		DestinationRoleARN: "arn:aws:iam::123456789012:role/CloudWatchAgentLogs",
+		DeadLetterLogGroup: "deadletter",
+		pusherStopChan:     make(chan struct{}),
+		cwDests:            make(map[pusher.Target]*cwDest),
+	}
+	require.NoError(t, c.Init())
+
+	// the destination role is assumed with the role of the source account
+	credentialConfig := c.credentialConfig()
+	assert.Equal(t, "eu-west-1", credentialConfig.Region)
+	assert.Equal(t, "arn:aws:iam::111111111111:role/Source", credentialConfig.RoleARN)
+
+	// the log groups of the targets are replaced by the destination and kept in the streams
+	d1 := c.CreateDest("", "", 7, "", nil).(*cwDest)
+	assert.Equal(t, "central", d1.pusher.Group)
+	assert.Equal(t, "G1/S1", d1.pusher.Stream)
+	assert.Equal(t, -1, d1.pusher.Retention)
+	d2 := c.CreateDest("Group5", "S1", -1, "", nil).(*cwDest)
+	assert.NotSame(t, d1, d2)
+	assert.Equal(t, "central", d2.pusher.Group)
+	assert.Equal(t, "Group5/S1", d2.pusher.Stream)
+	d3 := c.CreateDest("Group5", "S1", -1, "", nil).(*cwDest)
+	assert.Same(t, d2, d3)
+	// the dead letter log group is kept in the account of the destination
+	deadLetterDest := c.cwDests[c.deadLetterTarget]
+	require.NotNil(t, deadLetterDest)
+	assert.Equal(t, "deadletter", deadLetterDest.pusher.Group)
+	assert.Equal(t, "S1", deadLetterDest.pusher.Stream)
+	d4 := c.CreateDest("arn:aws:logs:eu-west-1:123456789012:log-group:other", "S1", -1, "", nil).(*cwDest)
+	assert.Equal(t, "arn:aws:logs:eu-west-1:123456789012:log-group:other", d4.pusher.Group)
+	assert.Equal(t, "S1", d4.pusher.Stream)
+
+	c = &CloudWatchLogs{
+		Region:  "us-west-2",
+		RoleARN: "arn:aws:iam::111111111111:role/Source",
+	}
+	require.NoError(t, c.Init())
+	credentialConfig = c.credentialConfig()
+	assert.Equal(t, "us-west-2", credentialConfig.Region)
+	assert.Equal(t, "arn:aws:iam::111111111111:role/Source", credentialConfig.RoleARN)
+}
@@ -0,0 +1,87 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatchlogs
+
+import (
+	"github.com/aws/aws-sdk-go/aws"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/entitystore"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsentity/entityattributes"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+)
+
+const (
+	attributeCloudAccountID = "cloud.account.id"
+	// defaultEntityEnvironment is used when none of the environment keys are set on the resource.
+	defaultEntityEnvironment = "generic:default"
+)
+
+var (
+	defaultEntityServiceNameKeys = []string{"service.name"}
+	defaultEntityEnvironmentKeys = []string{"deployment.environment.name", "deployment.environment"}
+
+	// entityAttributeKeys maps the entity Attributes to the resource attribute they are derived from.
+	entityAttributeKeys = map[string]string{
+		entityattributes.InstanceID:     "host.id",
+		entityattributes.NamespaceField: "k8s.namespace.name",
+		entityattributes.Node:           "k8s.node.name",
+	}
+)
+
+// createEntityFromAttributes creates a Service entity from the resource attributes. Returns nil if none of
+// the service name keys are set.
+func (c *CloudWatchLogs) createEntityFromAttributes(attributes map[string]string) *cloudwatchlogs.Entity {
+	serviceName := firstAttribute(attributes, c.entityServiceNameKeys())
+	if serviceName == "" {
+		return nil
+	}
+	environment := firstAttribute(attributes, c.entityEnvironmentKeys())
+	if environment == "" {
+		environment = defaultEntityEnvironment
+	}
+	keyAttributes := map[string]*string{
+		entityattributes.EntityType:            aws.String(entitystore.Service),
+		entityattributes.ServiceName:           aws.String(serviceName),
+		entityattributes.DeploymentEnvironment: aws.String(environment),
+	}
+	if accountID := attributes[attributeCloudAccountID]; accountID != "" {
+		keyAttributes[entityattributes.AwsAccountId] = aws.String(accountID)
+	}
+	attributeMap := map[string]*string{
+		entityattributes.ServiceNameSource: aws.String(entitystore.ServiceNameSourceInstrumentation),
+	}
+	for entityKey, attributeKey := range entityAttributeKeys {
+		if value := attributes[attributeKey]; value != "" {
+			attributeMap[entityKey] = aws.String(value)
+		}
+	}
+	return &cloudwatchlogs.Entity{
+		KeyAttributes: keyAttributes,
+		Attributes:    attributeMap,
+	}
+}
+
+func (c *CloudWatchLogs) entityServiceNameKeys() []string {
+	if len(c.EntityServiceNameKeys) > 0 {
+		return c.EntityServiceNameKeys
+	}
+	return defaultEntityServiceNameKeys
+}
+
+func (c *CloudWatchLogs) entityEnvironmentKeys() []string {
+	if len(c.EntityEnvironmentKeys) > 0 {
+		return c.EntityEnvironmentKeys
+	}
+	return defaultEntityEnvironmentKeys
+}
+
+// firstAttribute returns the value of the first key with a non-empty value.
+func firstAttribute(attributes map[string]string, keys []string) string {
+	for _, key := range keys {
+		if value := attributes[key]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
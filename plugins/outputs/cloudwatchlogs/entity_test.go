@@ -0,0 +1,225 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatchlogs
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/influxdata/telegraf"
+	"github.com/influxdata/telegraf/metric"
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatchlogs/internal/pusher"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+	"github.com/aws/amazon-cloudwatch-agent/tool/util"
+)
+
+type stubLogsService struct {
+	mu     sync.Mutex
+	inputs []*cloudwatchlogs.PutLogEventsInput
+}
+
+func (s *stubLogsService) PutLogEvents(in *cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inputs = append(s.inputs, in)
+	return &cloudwatchlogs.PutLogEventsOutput{}, nil
+}
+
+func (s *stubLogsService) CreateLogStream(*cloudwatchlogs.CreateLogStreamInput) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	return &cloudwatchlogs.CreateLogStreamOutput{}, nil
+}
+
+func (s *stubLogsService) CreateLogGroup(*cloudwatchlogs.CreateLogGroupInput) (*cloudwatchlogs.CreateLogGroupOutput, error) {
+	return &cloudwatchlogs.CreateLogGroupOutput{}, nil
+}
+
+func (s *stubLogsService) PutRetentionPolicy(*cloudwatchlogs.PutRetentionPolicyInput) (*cloudwatchlogs.PutRetentionPolicyOutput, error) {
+	return &cloudwatchlogs.PutRetentionPolicyOutput{}, nil
+}
+
+func (s *stubLogsService) DescribeLogGroups(*cloudwatchlogs.DescribeLogGroupsInput) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+	return &cloudwatchlogs.DescribeLogGroupsOutput{}, nil
+}
+
+func (s *stubLogsService) DescribeLogStreams(*cloudwatchlogs.DescribeLogStreamsInput) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+	return &cloudwatchlogs.DescribeLogStreamsOutput{}, nil
+}
+
+func (s *stubLogsService) putLogEventsInputs() []*cloudwatchlogs.PutLogEventsInput {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.inputs
+}
+
+func TestCreateEntityFromAttributes(t *testing.T) {
+	testCases := map[string]struct {
+		serviceNameKeys []string
+		environmentKeys []string
+		attributes      map[string]string
+		want            *cloudwatchlogs.Entity
+	}{
+		"WithoutServiceName": {
+			attributes: map[string]string{"deployment.environment": "prod"},
+		},
+		"WithDefaultKeys": {
+			attributes: map[string]string{
+				"service.name":           "checkout",
+				"deployment.environment": "prod",
+				"cloud.account.id":       "123456789012",
+				"host.id":                "i-0123456789",
+				"k8s.namespace.name":     "shop",
+				"k8s.node.name":          "node-1",
+				"other":                  "ignored",
+			},
+			want: &cloudwatchlogs.Entity{
+				KeyAttributes: map[string]*string{
+					"Type":         aws.String("Service"),
+					"Name":         aws.String("checkout"),
+					"Environment":  aws.String("prod"),
+					"AwsAccountId": aws.String("123456789012"),
+				},
+				Attributes: map[string]*string{
+					"AWS.ServiceNameSource": aws.String("Instrumentation"),
+					"EC2.InstanceId":        aws.String("i-0123456789"),
+					"K8s.Namespace":         aws.String("shop"),
+					"K8s.Node":              aws.String("node-1"),
+				},
+			},
+		},
+		"WithDefaultEnvironment": {
+			attributes: map[string]string{"service.name": "checkout"},
+			want: &cloudwatchlogs.Entity{
+				KeyAttributes: map[string]*string{
+					"Type":        aws.String("Service"),
+					"Name":        aws.String("checkout"),
+					"Environment": aws.String("generic:default"),
+				},
+				Attributes: map[string]*string{
+					"AWS.ServiceNameSource": aws.String("Instrumentation"),
+				},
+			},
+		},
+		"WithPreferredEnvironmentKey": {
+			attributes: map[string]string{
+				"service.name":                "checkout",
+				"deployment.environment":      "old",
+				"deployment.environment.name": "new",
+			},
+			want: &cloudwatchlogs.Entity{
+				KeyAttributes: map[string]*string{
+					"Type":        aws.String("Service"),
+					"Name":        aws.String("checkout"),
+					"Environment": aws.String("new"),
+				},
+				Attributes: map[string]*string{
+					"AWS.ServiceNameSource": aws.String("Instrumentation"),
+				},
+			},
+		},
+		"WithConfiguredKeys": {
+			serviceNameKeys: []string{"app", "service.name"},
+			environmentKeys: []string{"stage"},
+			attributes: map[string]string{
+				"service.name":           "ignored",
+				"app":                    "checkout",
+				"deployment.environment": "ignored",
+				"stage":                  "beta",
+			},
+			want: &cloudwatchlogs.Entity{
+				KeyAttributes: map[string]*string{
+					"Type":        aws.String("Service"),
+					"Name":        aws.String("checkout"),
+					"Environment": aws.String("beta"),
+				},
+				Attributes: map[string]*string{
+					"AWS.ServiceNameSource": aws.String("Instrumentation"),
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			c := &CloudWatchLogs{
+				EntityServiceNameKeys: testCase.serviceNameKeys,
+				EntityEnvironmentKeys: testCase.environmentKeys,
+			}
+			assert.Equal(t, testCase.want, c.createEntityFromAttributes(testCase.attributes))
+		})
+	}
+}
+
+func TestPutLogEventsWithResourceEntity(t *testing.T) {
+	c := &CloudWatchLogs{}
+	checkout := c.createEntityFromAttributes(map[string]string{
+		"service.name":           "checkout",
+		"deployment.environment": "prod",
+		"cloud.account.id":       "123456789012",
+	})
+	cart := c.createEntityFromAttributes(map[string]string{"service.name": "cart"})
+
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	logger := testutil.Logger{Name: "test"}
+	service := &stubLogsService{}
+	target := pusher.Target{Group: "G", Stream: "S", Class: util.StandardLogGroupClass, Retention: -1}
+	p := pusher.NewPusher(logger, target, service, pusher.NewTargetManager(logger, service), nil, nil, 0, 100*time.Millisecond, time.Minute, 0, nil, stop, &wg)
+	now := time.Now()
+	// the batch is split when the entity of the events changes
+	p.AddEvent(&structuredLogEvent{msg: "{}", t: now, entity: checkout})
+	p.AddEvent(&structuredLogEvent{msg: "{}", t: now, entity: c.createEntityFromAttributes(map[string]string{
+		"service.name":           "checkout",
+		"deployment.environment": "prod",
+		"cloud.account.id":       "123456789012",
+	})})
+	p.AddEvent(&structuredLogEvent{msg: "{}", t: now, entity: cart})
+	p.AddEvent(&structuredLogEvent{msg: "{}", t: now})
+	require.Eventually(t, func() bool {
+		return len(service.putLogEventsInputs()) == 3
+	}, 5*time.Second, 50*time.Millisecond)
+	close(stop)
+	wg.Wait()
+
+	inputs := service.putLogEventsInputs()
+	require.Len(t, inputs, 3)
+	assert.Equal(t, &cloudwatchlogs.Entity{
+		KeyAttributes: map[string]*string{
+			"Type":         aws.String("Service"),
+			"Name":         aws.String("checkout"),
+			"Environment":  aws.String("prod"),
+			"AwsAccountId": aws.String("123456789012"),
+		},
+		Attributes: map[string]*string{
+			"AWS.ServiceNameSource": aws.String("Instrumentation"),
+		},
+	}, inputs[0].Entity)
+	assert.Len(t, inputs[0].LogEvents, 2)
+	assert.Equal(t, cart, inputs[1].Entity)
+	assert.Len(t, inputs[1].LogEvents, 1)
+	assert.Nil(t, inputs[2].Entity)
+	assert.Len(t, inputs[2].LogEvents, 1)
+}
+
+func TestGetLogEventFromMetricEntity(t *testing.T) {
+	c := &CloudWatchLogs{
+		Log:            testutil.Logger{Name: "test"},
+		LogStreamName:  "S1",
+		AccessKey:      "access_key",
+		SecretKey:      "secret_key",
+		pusherStopChan: make(chan struct{}),
+		cwDests:        make(map[pusher.Target]*cwDest),
+	}
+	m := metric.New("test", map[string]string{LogGroupNameTag: "G1", "service.name": "checkout"}, map[string]interface{}{"value": "message"}, time.Now())
+	require.NoError(t, c.Write([]telegraf.Metric{m}))
+	target := pusher.Target{Group: "G1", Stream: "S1", Class: util.StandardLogGroupClass, Retention: -1}
+	cwd := c.cwDests[target]
+	require.NotNil(t, cwd)
+	// the entity is attached to the events instead of the destination
+	assert.Nil(t, cwd.pusher.EntityProvider)
+}
@@ -4,6 +4,7 @@
 package pusher
 
 import (
+	"reflect"
 	"sort"
 	"time"
 
@@ -33,6 +34,9 @@ type logEvent struct {
 	message      string
 	eventBytes   int
 	doneCallback func()
+	// entity is the entity of the event itself, such as the service of a structured log event, which is sent instead
+	// of the entity of the target.
+	entity *cloudwatchlogs.Entity
 }
 
 func newLogEvent(timestamp time.Time, message string, doneCallback func()) *logEvent {
@@ -57,6 +61,8 @@ type logEventBatch struct {
 	Target
 	events         []*cloudwatchlogs.InputLogEvent
 	entityProvider logs.LogEntityProvider
+	// entity is the entity of the events in the batch, which all have the same entity.
+	entity *cloudwatchlogs.Entity
 	// Total size of all events in the batch.
 	bufferedSize int
 	// Whether the events need to be sorted before being sent.
@@ -84,6 +90,12 @@ func (b *logEventBatch) inTimeRange(timestamp time.Time) bool {
 		b.maxT.Sub(timestamp) <= batchTimeRangeLimit
 }
 
+// hasEntity checks if the entity of the event is the entity of the events in the batch, since a request has a single
+// entity.
+func (b *logEventBatch) hasEntity(entity *cloudwatchlogs.Entity) bool {
+	return len(b.events) == 0 || reflect.DeepEqual(b.entity, entity)
+}
+
 // hasSpace checks if adding an event of the given size will exceed the space limits.
 func (b *logEventBatch) hasSpace(size int) bool {
 	return len(b.events) < reqEventsLimit && b.bufferedSize+size <= reqSizeLimit
@@ -92,6 +104,9 @@ func (b *logEventBatch) hasSpace(size int) bool {
 // append adds a log event to the batch.
 func (b *logEventBatch) append(e *logEvent) {
 	event := e.build()
+	if len(b.events) == 0 {
+		b.entity = e.entity
+	}
 	if len(b.events) > 0 && *event.Timestamp < *b.events[len(b.events)-1].Timestamp {
 		b.needSort = true
 	}
@@ -121,6 +136,18 @@ func (b *logEventBatch) done() {
 	}
 }
 
+// slice returns a batch with the events from start to end, which is sent on its own when the batch keeps failing. It
+// does not have the done callbacks of the batch, which are run once all of its events are finished.
+func (b *logEventBatch) slice(start, end int) *logEventBatch {
+	sliced := newLogEventBatch(b.Target, b.entityProvider)
+	sliced.entity = b.entity
+	for _, event := range b.events[start:end] {
+		sliced.events = append(sliced.events, event)
+		sliced.bufferedSize += len(*event.Message) + perEventHeaderBytes
+	}
+	return sliced
+}
+
 // build creates a cloudwatchlogs.PutLogEventsInput from the batch. The log events in the batch must be in
 // chronological order by their timestamp.
 func (b *logEventBatch) build() *cloudwatchlogs.PutLogEventsInput {
@@ -128,11 +155,17 @@ func (b *logEventBatch) build() *cloudwatchlogs.PutLogEventsInput {
 		sort.Stable(byTimestamp(b.events))
 	}
 	input := &cloudwatchlogs.PutLogEventsInput{
-		LogGroupName:  aws.String(b.Group),
 		LogStreamName: aws.String(b.Stream),
 		LogEvents:     b.events,
 	}
-	if b.entityProvider != nil {
+	if b.hasLogGroupARN() {
+		input.LogGroupIdentifier = aws.String(b.logGroupIdentifier())
+	} else {
+		input.LogGroupName = aws.String(b.Group)
+	}
+	if b.entity != nil {
+		input.Entity = b.entity
+	} else if b.entityProvider != nil {
 		input.Entity = b.entityProvider.Entity()
 	}
 	return input
@@ -101,6 +101,34 @@ func TestLogEventBatch(t *testing.T) {
 		assert.Equal(t, 2, len(input.LogEvents), "Input should have 2 log events")
 	})
 
+	t.Run("HasEntity", func(t *testing.T) {
+		entity := &cloudwatchlogs.Entity{KeyAttributes: map[string]*string{"Name": aws.String("checkout")}}
+		batch := newLogEventBatch(Target{Group: "G", Stream: "S"}, newMockEntityProvider(nil))
+		assert.True(t, batch.hasEntity(entity))
+
+		event := newLogEvent(time.Now(), "Test message", nil)
+		event.entity = entity
+		batch.append(event)
+		assert.True(t, batch.hasEntity(&cloudwatchlogs.Entity{KeyAttributes: map[string]*string{"Name": aws.String("checkout")}}))
+		assert.False(t, batch.hasEntity(&cloudwatchlogs.Entity{KeyAttributes: map[string]*string{"Name": aws.String("cart")}}))
+		assert.False(t, batch.hasEntity(nil))
+		// the entity of the events is sent instead of the entity of the target
+		assert.Equal(t, entity, batch.build().Entity)
+		assert.Equal(t, entity, batch.slice(0, 1).build().Entity)
+	})
+
+	t.Run("Build/LogGroupARN", func(t *testing.T) {
+		batch := newLogEventBatch(Target{Group: "arn:aws:logs:us-east-1:123456789012:log-group:central:*", Stream: "S"}, nil)
+		batch.append(newLogEvent(time.Now(), "Test message", nil))
+
+		input := batch.build()
+
+		assert.Nil(t, input.LogGroupName)
+		assert.Equal(t, "arn:aws:logs:us-east-1:123456789012:log-group:central", *input.LogGroupIdentifier)
+		assert.Equal(t, "S", *input.LogStreamName)
+		assert.NoError(t, input.Validate())
+	})
+
 	t.Run("EventSort", func(t *testing.T) {
 		batch := newLogEventBatch(Target{Group: "G", Stream: "S"}, nil)
 
@@ -121,6 +149,26 @@ func TestLogEventBatch(t *testing.T) {
 		assert.True(t, *input.LogEvents[1].Timestamp < *input.LogEvents[2].Timestamp, "Events should be sorted by timestamp")
 	})
 
+	t.Run("EventSort/SameTimestamp", func(t *testing.T) {
+		batch := newLogEventBatch(Target{Group: "G", Stream: "S"}, nil)
+
+		// events from a multiline reassembly and a second reader, with timestamps in the same millisecond
+		now := time.UnixMilli(1700000000000)
+		batch.append(newLogEvent(now.Add(time.Millisecond), "late 1", nil))
+		batch.append(newLogEvent(now, "early 1", nil))
+		batch.append(newLogEvent(now.Add(time.Millisecond+100*time.Microsecond), "late 2", nil))
+		batch.append(newLogEvent(now.Add(500*time.Microsecond), "early 2", nil))
+
+		input := batch.build()
+
+		var messages []string
+		for _, event := range input.LogEvents {
+			messages = append(messages, *event.Message)
+		}
+		// events with the same timestamp keep the order they were added in
+		assert.Equal(t, []string{"early 1", "early 2", "late 1", "late 2"}, messages)
+	})
+
 	t.Run("DoneCallback", func(t *testing.T) {
 		batch := newLogEventBatch(Target{Group: "G", Stream: "S"}, nil)
 
@@ -0,0 +1,92 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pusher
+
+import (
+	"sync"
+	"time"
+)
+
+// concurrentSender wraps a Sender to send up to a limit of batches for the same target at a time. CloudWatch Logs
+// no longer requires sequence tokens, so the batches of a stream do not need to be sent one after the other.
+type concurrentSender struct {
+	sender Sender
+	// slots limits the number of batches being sent.
+	slots chan struct{}
+	wg    *sync.WaitGroup
+
+	mu sync.Mutex
+	// pending are the batches that were sent but whose done callbacks have not run, in the order they were sent.
+	pending []*pendingBatch
+}
+
+// pendingBatch holds the done callbacks of a batch until the batches sent before it are finished.
+type pendingBatch struct {
+	doneCallbacks []func()
+	sent          bool
+	finished      bool
+}
+
+var _ Sender = (*concurrentSender)(nil)
+
+func newConcurrentSender(sender Sender, limit int, wg *sync.WaitGroup) Sender {
+	return &concurrentSender{
+		sender: sender,
+		slots:  make(chan struct{}, limit),
+		wg:     wg,
+	}
+}
+
+// Send sends the batch in the background. Blocks until one of the batches being sent is finished if the limit is
+// reached. The done callbacks of the batch only run once all the batches sent before it are finished, so that the
+// saved file offsets never skip past events that could still be lost.
+func (s *concurrentSender) Send(batch *logEventBatch) {
+	if len(batch.events) == 0 {
+		return
+	}
+	s.slots <- struct{}{}
+	p := &pendingBatch{doneCallbacks: batch.doneCallbacks}
+	batch.doneCallbacks = nil
+	batch.addDoneCallback(func() {
+		p.sent = true
+	})
+	s.mu.Lock()
+	s.pending = append(s.pending, p)
+	s.mu.Unlock()
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.sender.Send(batch)
+		s.finish(p)
+		<-s.slots
+	}()
+}
+
+// finish marks the batch as finished and runs the done callbacks of the successfully sent batches that are no
+// longer waiting on an earlier batch. Batches that were dropped by the sender do not run their callbacks.
+func (s *concurrentSender) finish(p *pendingBatch) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p.finished = true
+	for len(s.pending) > 0 && s.pending[0].finished {
+		next := s.pending[0]
+		s.pending[0] = nil
+		s.pending = s.pending[1:]
+		if next.sent {
+			for i := len(next.doneCallbacks) - 1; i >= 0; i-- {
+				next.doneCallbacks[i]()
+			}
+		}
+	}
+}
+
+// SetRetryDuration sets the retry duration on the wrapped Sender.
+func (s *concurrentSender) SetRetryDuration(duration time.Duration) {
+	s.sender.SetRetryDuration(duration)
+}
+
+// RetryDuration returns the retry duration of the wrapped Sender.
+func (s *concurrentSender) RetryDuration() time.Duration {
+	return s.sender.RetryDuration()
+}
@@ -0,0 +1,127 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pusher
+
+import (
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+// sendBatches sends a batch with a single event per message and returns the messages in the order their done
+// callbacks ran.
+func sendBatches(s Sender, wg *sync.WaitGroup, messages ...string) []string {
+	var mu sync.Mutex
+	var done []string
+	for _, message := range messages {
+		batch := newLogEventBatch(Target{Group: "G", Stream: "S"}, nil)
+		batch.append(newLogEvent(time.Now(), message, func() {
+			mu.Lock()
+			defer mu.Unlock()
+			done = append(done, message)
+		}))
+		s.Send(batch)
+	}
+	wg.Wait()
+	return done
+}
+
+func TestConcurrentSender(t *testing.T) {
+	logger := testutil.NewNopLogger()
+
+	t.Run("Limit", func(t *testing.T) {
+		stop := make(chan struct{})
+		defer close(stop)
+		var inFlight, maxInFlight atomic.Int32
+		service := new(stubLogsService)
+		service.ple = func(*cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+			n := inFlight.Add(1)
+			defer inFlight.Add(-1)
+			for {
+				current := maxInFlight.Load()
+				if n <= current || maxInFlight.CompareAndSwap(current, n) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			return &cloudwatchlogs.PutLogEventsOutput{}, nil
+		}
+		var wg sync.WaitGroup
+		s := newConcurrentSender(newSender(logger, service, nil, time.Second, 0, stop), 3, &wg)
+
+		assert.Equal(t, time.Second, s.RetryDuration())
+		s.SetRetryDuration(time.Minute)
+		assert.Equal(t, time.Minute, s.RetryDuration())
+
+		var messages []string
+		for i := 0; i < 20; i++ {
+			messages = append(messages, strconv.Itoa(i))
+		}
+		assert.Equal(t, messages, sendBatches(s, &wg, messages...))
+		assert.EqualValues(t, 3, maxInFlight.Load())
+	})
+
+	t.Run("RetryOutOfOrder", func(t *testing.T) {
+		stop := make(chan struct{})
+		defer close(stop)
+		var failed atomic.Bool
+		var calls atomic.Int32
+		service := new(stubLogsService)
+		service.ple = func(input *cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+			calls.Add(1)
+			// the second batch fails once and finishes after the ones sent after it
+			if *input.LogEvents[0].Message == "2" && failed.CompareAndSwap(false, true) {
+				return nil, awserr.New("SomeAWSError", "Some AWS error", nil)
+			}
+			return &cloudwatchlogs.PutLogEventsOutput{}, nil
+		}
+		var wg sync.WaitGroup
+		s := newConcurrentSender(newSender(logger, service, nil, time.Minute, 0, stop), 3, &wg)
+
+		// done callbacks wait on the retried batch
+		assert.Equal(t, []string{"1", "2", "3"}, sendBatches(s, &wg, "1", "2", "3"))
+		assert.EqualValues(t, 4, calls.Load())
+	})
+
+	t.Run("Dropped", func(t *testing.T) {
+		stop := make(chan struct{})
+		defer close(stop)
+		service := new(stubLogsService)
+		service.ple = func(input *cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+			if *input.LogEvents[0].Message == "2" {
+				return nil, &cloudwatchlogs.InvalidParameterException{}
+			}
+			return &cloudwatchlogs.PutLogEventsOutput{}, nil
+		}
+		var wg sync.WaitGroup
+		s := newConcurrentSender(newSender(logger, service, nil, time.Minute, 0, stop), 3, &wg)
+
+		// the dropped batch does not block the ones sent after it
+		assert.Equal(t, []string{"1", "3"}, sendBatches(s, &wg, "1", "2", "3"))
+	})
+}
+
+func TestCreateSender(t *testing.T) {
+	logger := testutil.NewNopLogger()
+	stop := make(chan struct{})
+	defer close(stop)
+	var wg sync.WaitGroup
+	service := new(stubLogsService)
+
+	assert.IsType(t, &sender{}, createSender(logger, service, nil, nil, 0, time.Second, 0, stop, &wg))
+	assert.IsType(t, &sender{}, createSender(logger, service, nil, nil, 1, time.Second, 0, stop, &wg))
+	assert.IsType(t, &concurrentSender{}, createSender(logger, service, nil, nil, 2, time.Second, 0, stop, &wg))
+	pool := NewWorkerPool(2)
+	defer pool.Stop()
+	// the shared worker pool takes precedence
+	assert.IsType(t, &senderPool{}, createSender(logger, service, nil, pool, 2, time.Second, 0, stop, &wg))
+}
@@ -28,6 +28,7 @@ type converter struct {
 	lastValidTime   time.Time
 	lastUpdateTime  time.Time
 	lastWarnMessage time.Time
+	deadLetter      DeadLetter
 }
 
 func newConverter(logger telegraf.Logger, target Target) *converter {
@@ -38,11 +39,15 @@ func newConverter(logger telegraf.Logger, target Target) *converter {
 }
 
 // convert handles message truncation to remain within PutLogEvents limits and sets a timestamp if not set in the
-// logs.LogEvent.
+// logs.LogEvent. The entity of events that have their own entity is kept. Returns nil if the message is too large and added to the dead letter instead of being truncated.
 func (c *converter) convert(e logs.LogEvent) *logEvent {
 	message := e.Message()
 
 	if len(message) > msgSizeLimit {
+		if c.deadLetter != nil {
+			c.deadLetter.Add(c.Target, ReasonEventTooLarge, e)
+			return nil
+		}
 		message = message[:msgSizeLimit-len(truncatedSuffix)] + truncatedSuffix
 	}
 	now := time.Now()
@@ -69,5 +74,9 @@ func (c *converter) convert(e logs.LogEvent) *logEvent {
 		c.lastUpdateTime = now
 		c.lastWarnMessage = time.Time{}
 	}
-	return newLogEvent(t, message, e.Done)
+	event := newLogEvent(t, message, e.Done)
+	if entityProvider, ok := e.(logs.LogEntityProvider); ok {
+		event.entity = entityProvider.Entity()
+	}
+	return event
 }
@@ -0,0 +1,151 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pusher
+
+import (
+	"sync"
+	"time"
+
+	"github.com/influxdata/telegraf"
+	"golang.org/x/time/rate"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/pipelinehealth"
+)
+
+const (
+	// The interval at which the creation requests of a region are reported in the agent log.
+	creationReportInterval = time.Minute
+	// creationStatePrefix is the prefix of the name of the creation requests of each region in the pipeline health
+	// debug state.
+	creationStatePrefix = "outputs/cloudwatchlogs/creation/"
+)
+
+// creationStats has the number of CreateLogGroup and CreateLogStream requests sent to a region and their outcome.
+// A request for a log group or stream that already exists is counted as a failure.
+type creationStats struct {
+	LogGroupRequests   uint64
+	LogGroupSuccesses  uint64
+	LogGroupFailures   uint64
+	LogStreamRequests  uint64
+	LogStreamSuccesses uint64
+	LogStreamFailures  uint64
+	// Requests that were delayed by the creation rate limit of the region.
+	Throttled uint64
+}
+
+// regionCreation counts and limits the log group and stream creation requests of a region. It is shared by the
+// target managers that send to the region, so the limit applies across all of them.
+type regionCreation struct {
+	region string
+
+	statsMu    sync.Mutex
+	stats      creationStats
+	reported   creationStats
+	lastReport time.Time
+
+	limiter *rate.Limiter
+}
+
+var (
+	regionCreationsMu sync.Mutex
+	regionCreations   = map[string]*regionCreation{}
+)
+
+func newRegionCreation(region string) *regionCreation {
+	return &regionCreation{region: region, lastReport: time.Now(), limiter: rate.NewLimiter(rate.Inf, 1)}
+}
+
+// getRegionCreation returns the creation requests of the region, which are reported in the pipeline health debug
+// state. A target manager without a region is not shared, so that it is not limited with other regions.
+func getRegionCreation(region string) *regionCreation {
+	if region == "" {
+		return newRegionCreation(region)
+	}
+	regionCreationsMu.Lock()
+	defer regionCreationsMu.Unlock()
+	r, ok := regionCreations[region]
+	if !ok {
+		r = newRegionCreation(region)
+		regionCreations[region] = r
+		pipelinehealth.RegisterState(creationStatePrefix+region, r.state)
+	}
+	return r
+}
+
+// snapshot returns the log group and stream creation requests sent to the region since the agent started.
+func (r *regionCreation) snapshot() creationStats {
+	r.statsMu.Lock()
+	defer r.statsMu.Unlock()
+	return r.stats
+}
+
+func (r *regionCreation) state() pipelinehealth.State {
+	s := r.snapshot()
+	return pipelinehealth.State{
+		Counters: map[string]uint64{
+			"create_log_group_requests":  s.LogGroupRequests,
+			"create_log_group_failures":  s.LogGroupFailures,
+			"create_log_stream_requests": s.LogStreamRequests,
+			"create_log_stream_failures": s.LogStreamFailures,
+			"throttled":                  s.Throttled,
+		},
+		Errors: s.LogGroupFailures + s.LogStreamFailures,
+	}
+}
+
+// setLimit limits the creation requests sent to the region to requestsPerSecond. The last limit set is used.
+func (r *regionCreation) setLimit(requestsPerSecond int) {
+	r.limiter.SetLimit(rate.Limit(requestsPerSecond))
+}
+
+// throttle blocks until the creation request can be sent within the rate limit of the region. The request reserves
+// its time before waiting, so the other requests of the region are not blocked while it waits.
+func (r *regionCreation) throttle() {
+	if wait := r.limiter.Reserve().Delay(); wait > 0 {
+		r.statsMu.Lock()
+		r.stats.Throttled++
+		r.statsMu.Unlock()
+		time.Sleep(wait)
+	}
+}
+
+func (r *regionCreation) recordLogGroup(logger telegraf.Logger, err error) {
+	r.record(logger, func(s *creationStats) {
+		s.LogGroupRequests++
+		if err != nil {
+			s.LogGroupFailures++
+		} else {
+			s.LogGroupSuccesses++
+		}
+	})
+}
+
+func (r *regionCreation) recordLogStream(logger telegraf.Logger, err error) {
+	r.record(logger, func(s *creationStats) {
+		s.LogStreamRequests++
+		if err != nil {
+			s.LogStreamFailures++
+		} else {
+			s.LogStreamSuccesses++
+		}
+	})
+}
+
+// record updates the stats and reports the requests sent since the last report once the report interval has
+// passed.
+func (r *regionCreation) record(logger telegraf.Logger, update func(s *creationStats)) {
+	r.statsMu.Lock()
+	defer r.statsMu.Unlock()
+	update(&r.stats)
+	if time.Since(r.lastReport) < creationReportInterval {
+		return
+	}
+	s, last := r.stats, r.reported
+	logger.Infof("Sent %v CreateLogGroup (%v failed) and %v CreateLogStream (%v failed) requests to %v in the last %v, %v were throttled",
+		s.LogGroupRequests-last.LogGroupRequests, s.LogGroupFailures-last.LogGroupFailures,
+		s.LogStreamRequests-last.LogStreamRequests, s.LogStreamFailures-last.LogStreamFailures,
+		r.region, time.Since(r.lastReport).Round(time.Second), s.Throttled-last.Throttled)
+	r.reported = s
+	r.lastReport = time.Now()
+}
@@ -0,0 +1,86 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pusher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/pipelinehealth"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+func TestCreationStats(t *testing.T) {
+	region := "stats-region"
+	mockService := new(mockLogsService)
+	mockService.On("CreateLogStream", mock.Anything).
+		Return(&cloudwatchlogs.CreateLogStreamOutput{}, awserr.New(cloudwatchlogs.ErrCodeResourceNotFoundException, "Log group not found", nil)).Once()
+	mockService.On("CreateLogGroup", mock.Anything).Return(&cloudwatchlogs.CreateLogGroupOutput{}, nil).Once()
+	mockService.On("CreateLogStream", mock.Anything).Return(&cloudwatchlogs.CreateLogStreamOutput{}, nil)
+
+	manager := NewTargetManager(testutil.NewNopLogger(), mockService, WithRegion(region))
+	require.NoError(t, manager.InitTarget(Target{Group: "G", Stream: "S1"}))
+	require.NoError(t, manager.InitTarget(Target{Group: "G", Stream: "S2"}))
+	// already initialized
+	require.NoError(t, manager.InitTarget(Target{Group: "G", Stream: "S2"}))
+
+	assert.Equal(t, creationStats{
+		LogGroupRequests:   1,
+		LogGroupSuccesses:  1,
+		LogStreamRequests:  3,
+		LogStreamSuccesses: 2,
+		LogStreamFailures:  1,
+	}, getRegionCreation(region).snapshot())
+	// other regions are counted separately
+	assert.Equal(t, creationStats{}, getRegionCreation("other-region").snapshot())
+
+	// reported in the pipeline health debug state
+	assert.Equal(t, pipelinehealth.State{
+		Counters: map[string]uint64{
+			"create_log_group_requests":  1,
+			"create_log_group_failures":  0,
+			"create_log_stream_requests": 3,
+			"create_log_stream_failures": 1,
+			"throttled":                  0,
+		},
+		Errors: 1,
+	}, getRegionCreation(region).state())
+}
+
+func TestCreationRateLimit(t *testing.T) {
+	region := "limited-region"
+	mockService := new(mockLogsService)
+	mockService.On("CreateLogStream", mock.Anything).Return(&cloudwatchlogs.CreateLogStreamOutput{}, nil)
+
+	manager := NewTargetManager(testutil.NewNopLogger(), mockService, WithRegion(region), WithMaxCreateRequestsPerSecond(20))
+	// shares the limit of the region
+	other := NewTargetManager(testutil.NewNopLogger(), mockService, WithRegion(region))
+	start := time.Now()
+	for _, stream := range []string{"S1", "S2", "S3"} {
+		require.NoError(t, manager.InitTarget(Target{Group: "G", Stream: stream}))
+		require.NoError(t, other.InitTarget(Target{Group: "G", Stream: stream}))
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 250*time.Millisecond)
+
+	stats := getRegionCreation(region).snapshot()
+	assert.EqualValues(t, 6, stats.LogStreamRequests)
+	assert.EqualValues(t, 6, stats.LogStreamSuccesses)
+	assert.EqualValues(t, 5, stats.Throttled)
+
+	// without a limit, the requests to another region are not throttled
+	unlimited := NewTargetManager(testutil.NewNopLogger(), mockService, WithRegion("unlimited-region"))
+	for _, stream := range []string{"S1", "S2", "S3"} {
+		require.NoError(t, unlimited.InitTarget(Target{Group: "G", Stream: stream}))
+	}
+	assert.Zero(t, getRegionCreation("unlimited-region").snapshot().Throttled)
+
+	// target managers without a region do not share a limit
+	assert.NotSame(t, getRegionCreation(""), getRegionCreation(""))
+}
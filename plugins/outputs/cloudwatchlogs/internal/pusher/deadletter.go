@@ -0,0 +1,143 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pusher
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/influxdata/telegraf"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+)
+
+const (
+	// ReasonTimestampOutOfRange is the dead letter reason of events with a timestamp that PutLogEvents rejects.
+	ReasonTimestampOutOfRange = "timestamp_out_of_range"
+	// ReasonEventTooLarge is the dead letter reason of events larger than the PutLogEvents event size limit.
+	ReasonEventTooLarge = "event_too_large"
+
+	// The window of the dead letter event limit.
+	deadLetterWindow = time.Minute
+)
+
+// DeadLetter receives the log events that cannot be sent to their target, instead of them being dropped or
+// truncated.
+type DeadLetter interface {
+	Add(target Target, reason string, e logs.LogEvent)
+}
+
+type deadLetter struct {
+	logger telegraf.Logger
+	queue  Queue
+	limit  int
+	now    func() time.Time
+
+	mu          sync.Mutex
+	windowStart time.Time
+	count       int
+	dropped     int
+}
+
+var _ DeadLetter = (*deadLetter)(nil)
+
+// NewDeadLetter creates a DeadLetter that adds a record of each event, with the reason and the original target, to
+// the queue of the dead letter target. Up to maxEventsPerMinute records are added per minute so that a burst of
+// failing events is not amplified, the events over the limit are dropped.
+func NewDeadLetter(logger telegraf.Logger, queue Queue, maxEventsPerMinute int) DeadLetter {
+	return &deadLetter{
+		logger: logger,
+		queue:  queue,
+		limit:  maxEventsPerMinute,
+		now:    time.Now,
+	}
+}
+
+func (d *deadLetter) Add(target Target, reason string, e logs.LogEvent) {
+	if !d.allow() {
+		return
+	}
+	d.queue.AddEvent(newDeadLetterEvent(target, reason, e, d.now()))
+}
+
+func (d *deadLetter) allow() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := d.now()
+	if now.Sub(d.windowStart) >= deadLetterWindow {
+		if d.dropped > 0 {
+			d.logger.Warnf("Dropped %v dead letter log events over the limit of %v per minute", d.dropped, d.limit)
+		}
+		d.windowStart = now
+		d.count = 0
+		d.dropped = 0
+	}
+	if d.count >= d.limit {
+		d.dropped++
+		return false
+	}
+	d.count++
+	return true
+}
+
+type deadLetterRecord struct {
+	Reason    string `json:"reason"`
+	LogGroup  string `json:"log_group"`
+	LogStream string `json:"log_stream"`
+	Timestamp string `json:"timestamp,omitempty"`
+	Size      int    `json:"size"`
+	Truncated bool   `json:"truncated,omitempty"`
+	Message   string `json:"message"`
+}
+
+type deadLetterEvent struct {
+	message string
+	time    time.Time
+	event   logs.LogEvent
+}
+
+var _ logs.LogEvent = (*deadLetterEvent)(nil)
+
+// newDeadLetterEvent creates the JSON record of the event. The message is shortened to keep the record within the
+// event size limit, since a truncated record would not be valid JSON.
+func newDeadLetterEvent(target Target, reason string, e logs.LogEvent, now time.Time) *deadLetterEvent {
+	message := e.Message()
+	record := deadLetterRecord{
+		Reason:    reason,
+		LogGroup:  target.Group,
+		LogStream: target.Stream,
+		Size:      len(message),
+		Message:   message,
+	}
+	if !e.Time().IsZero() {
+		record.Timestamp = e.Time().Format(time.RFC3339Nano)
+	}
+	b, _ := json.Marshal(record)
+	for len(b) > msgSizeLimit {
+		// escaping can make the encoded message longer than the original, so shorten it by the excess until it fits
+		end := len(record.Message) - (len(b) - msgSizeLimit)
+		for end > 0 && !utf8.RuneStart(record.Message[end]) {
+			end--
+		}
+		record.Message = record.Message[:max(end, 0)]
+		record.Truncated = true
+		b, _ = json.Marshal(record)
+	}
+	return &deadLetterEvent{message: string(b), time: now, event: e}
+}
+
+func (e *deadLetterEvent) Message() string {
+	return e.message
+}
+
+func (e *deadLetterEvent) Time() time.Time {
+	return e.time
+}
+
+// Done marks the original event as done once the record is sent.
+func (e *deadLetterEvent) Done() {
+	e.event.Done()
+}
@@ -0,0 +1,149 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pusher
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/logs"
+	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
+	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
+)
+
+type stubQueue struct {
+	mu     sync.Mutex
+	events []logs.LogEvent
+}
+
+var _ Queue = (*stubQueue)(nil)
+
+func (q *stubQueue) AddEvent(e logs.LogEvent) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.events = append(q.events, e)
+}
+
+func (q *stubQueue) AddEventNonBlocking(e logs.LogEvent) {
+	q.AddEvent(e)
+}
+
+func (q *stubQueue) Saturated() bool {
+	return false
+}
+
+func (q *stubQueue) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return len(q.events)
+}
+
+func (q *stubQueue) Usage() float64 {
+	return 0
+}
+
+func (q *stubQueue) LastSent() time.Time {
+	return time.Time{}
+}
+
+func TestDeadLetterEvent(t *testing.T) {
+	target := Target{Group: "G", Stream: "S"}
+	timestamp := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var done atomic.Bool
+	e := &stubLogEvent{message: "MSG", timestamp: timestamp, done: func() { done.Store(true) }}
+	now := time.Now()
+
+	got := newDeadLetterEvent(target, ReasonTimestampOutOfRange, e, now)
+	assert.Equal(t, now, got.Time())
+	assert.JSONEq(t, `{"reason":"timestamp_out_of_range","log_group":"G","log_stream":"S","timestamp":"2024-01-01T00:00:00Z","size":3,"message":"MSG"}`, got.Message())
+	got.Done()
+	assert.True(t, done.Load())
+
+	// the record of a message over the limit, including one that is longer once escaped, is still valid JSON
+	for _, message := range []string{strings.Repeat("a", msgSizeLimit+1), strings.Repeat("\"é", msgSizeLimit/2)} {
+		got = newDeadLetterEvent(target, ReasonEventTooLarge, newStubLogEvent(message, time.Time{}), now)
+		assert.LessOrEqual(t, len(got.Message()), msgSizeLimit)
+		var record deadLetterRecord
+		require.NoError(t, json.Unmarshal([]byte(got.Message()), &record))
+		assert.Equal(t, ReasonEventTooLarge, record.Reason)
+		assert.Equal(t, len(message), record.Size)
+		assert.True(t, record.Truncated)
+		assert.Empty(t, record.Timestamp)
+		assert.True(t, strings.HasPrefix(message, record.Message))
+	}
+}
+
+func TestDeadLetterLimit(t *testing.T) {
+	q := &stubQueue{}
+	now := time.Now()
+	d := NewDeadLetter(testutil.NewNopLogger(), q, 2).(*deadLetter)
+	d.now = func() time.Time { return now }
+	for i := 0; i < 5; i++ {
+		d.Add(Target{Group: "G", Stream: "S"}, ReasonEventTooLarge, newStubLogEvent("MSG", now))
+	}
+	assert.Len(t, q.events, 2)
+	assert.Equal(t, 3, d.dropped)
+
+	now = now.Add(deadLetterWindow)
+	d.Add(Target{Group: "G", Stream: "S"}, ReasonEventTooLarge, newStubLogEvent("MSG", now))
+	assert.Len(t, q.events, 3)
+	assert.Equal(t, 0, d.dropped)
+}
+
+func TestQueueDeadLetter(t *testing.T) {
+	logger := testutil.NewNopLogger()
+	var wg sync.WaitGroup
+	stop := make(chan struct{})
+	var mu sync.Mutex
+	sent := map[string][]string{}
+	service := &stubLogsService{
+		ple: func(in *cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, e := range in.LogEvents {
+				sent[*in.LogGroupName] = append(sent[*in.LogGroupName], *e.Message)
+			}
+			return &cloudwatchlogs.PutLogEventsOutput{}, nil
+		},
+	}
+	tm := NewTargetManager(logger, service)
+	deadLetterPusher := NewPusher(logger, Target{Group: "deadletter", Stream: "S", Retention: -1}, service, tm, nil, nil, 0, 100*time.Millisecond, time.Minute, 0, nil, stop, &wg)
+	p := NewPusher(logger, Target{Group: "G", Stream: "S", Retention: -1}, service, tm, nil, nil, 0, 100*time.Millisecond, time.Minute, 0, NewDeadLetter(logger, deadLetterPusher, 10), stop, &wg)
+
+	var done atomic.Int32
+	largeMessage := strings.Repeat("a", msgSizeLimit+1)
+	p.AddEvent(&stubLogEvent{message: largeMessage, timestamp: time.Now(), done: func() { done.Add(1) }})
+	p.AddEvent(&stubLogEvent{message: "old", timestamp: time.Now().Add(-15 * 24 * time.Hour), done: func() { done.Add(1) }})
+	p.AddEvent(newStubLogEvent("MSG", time.Now()))
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(sent["G"]) == 1 && len(sent["deadletter"]) == 2
+	}, 5*time.Second, 50*time.Millisecond)
+	close(stop)
+	wg.Wait()
+
+	assert.Equal(t, []string{"MSG"}, sent["G"])
+	records := map[string]deadLetterRecord{}
+	for _, message := range sent["deadletter"] {
+		var record deadLetterRecord
+		require.NoError(t, json.Unmarshal([]byte(message), &record))
+		assert.Equal(t, "G", record.LogGroup)
+		assert.Equal(t, "S", record.LogStream)
+		records[record.Reason] = record
+	}
+	assert.Equal(t, len(largeMessage), records[ReasonEventTooLarge].Size)
+	assert.True(t, records[ReasonEventTooLarge].Truncated)
+	assert.Equal(t, "old", records[ReasonTimestampOutOfRange].Message)
+	// the original events are done once their records are sent
+	assert.EqualValues(t, 2, done.Load())
+}
@@ -23,7 +23,9 @@ type Pusher struct {
 }
 
 // NewPusher creates a new Pusher instance with a new Queue and Sender. Calls PutRetentionPolicy using the
-// TargetManager.
+// TargetManager. Up to streamConcurrency batches of the target are sent at a time when no WorkerPool is provided.
+// The events that cannot be sent are added to the DeadLetter if it is not nil. An event is dropped once the request
+// with it has failed maxEventRetries times, unless maxEventRetries is 0.
 func NewPusher(
 	logger telegraf.Logger,
 	target Target,
@@ -31,13 +33,16 @@ func NewPusher(
 	targetManager TargetManager,
 	entityProvider logs.LogEntityProvider,
 	workerPool WorkerPool,
+	streamConcurrency int,
 	flushTimeout time.Duration,
 	retryDuration time.Duration,
+	maxEventRetries int,
+	deadLetter DeadLetter,
 	stop <-chan struct{},
 	wg *sync.WaitGroup,
 ) *Pusher {
-	s := createSender(logger, service, targetManager, workerPool, retryDuration, stop)
-	q := newQueue(logger, target, flushTimeout, entityProvider, s, stop, wg)
+	s := createSender(logger, service, targetManager, workerPool, streamConcurrency, retryDuration, maxEventRetries, stop, wg)
+	q := newQueue(logger, target, flushTimeout, entityProvider, s, deadLetter, stop, wg)
 	targetManager.PutRetentionPolicy(target)
 	return &Pusher{
 		Target:         target,
@@ -49,18 +54,25 @@ func NewPusher(
 	}
 }
 
-// createSender initializes a Sender. Wraps it in a senderPool if a WorkerPool is provided.
+// createSender initializes a Sender. Wraps it in a senderPool if a WorkerPool is provided, otherwise in a
+// concurrentSender if more than one batch of the target can be sent at a time.
 func createSender(
 	logger telegraf.Logger,
 	service cloudWatchLogsService,
 	targetManager TargetManager,
 	workerPool WorkerPool,
+	streamConcurrency int,
 	retryDuration time.Duration,
+	maxEventRetries int,
 	stop <-chan struct{},
+	wg *sync.WaitGroup,
 ) Sender {
-	s := newSender(logger, service, targetManager, retryDuration, stop)
-	if workerPool == nil {
-		return s
+	s := newSender(logger, service, targetManager, retryDuration, maxEventRetries, stop)
+	if workerPool != nil {
+		return newSenderPool(workerPool, s)
+	}
+	if streamConcurrency > 1 {
+		return newConcurrentSender(s, streamConcurrency, wg)
 	}
-	return newSenderPool(workerPool, s)
+	return s
 }
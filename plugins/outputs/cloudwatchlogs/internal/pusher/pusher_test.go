@@ -4,12 +4,16 @@
 package pusher
 
 import (
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
 	"github.com/aws/amazon-cloudwatch-agent/tool/testutil"
@@ -50,6 +54,97 @@ func TestPusher(t *testing.T) {
 	})
 }
 
+func TestPusherSharedGroup(t *testing.T) {
+	logger := testutil.NewNopLogger()
+	streams := []string{"file1", "file2", "file3"}
+	const eventsPerStream = 50
+
+	var mu sync.Mutex
+	var clgCount, dlgCount, prpCount int
+	received := map[string][]string{}
+	existingStreams := map[string]bool{}
+	service := new(stubLogsService)
+	service.ple = func(input *cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !existingStreams[*input.LogStreamName] {
+			return nil, &cloudwatchlogs.ResourceNotFoundException{}
+		}
+		for _, event := range input.LogEvents {
+			received[*input.LogStreamName] = append(received[*input.LogStreamName], *event.Message)
+		}
+		return &cloudwatchlogs.PutLogEventsOutput{}, nil
+	}
+	service.cls = func(input *cloudwatchlogs.CreateLogStreamInput) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if clgCount == 0 {
+			return nil, awserr.New(cloudwatchlogs.ErrCodeResourceNotFoundException, "Log group not found", nil)
+		}
+		existingStreams[*input.LogStreamName] = true
+		return &cloudwatchlogs.CreateLogStreamOutput{}, nil
+	}
+	service.clg = func(*cloudwatchlogs.CreateLogGroupInput) (*cloudwatchlogs.CreateLogGroupOutput, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		clgCount++
+		return &cloudwatchlogs.CreateLogGroupOutput{}, nil
+	}
+	service.dlg = func(*cloudwatchlogs.DescribeLogGroupsInput) (*cloudwatchlogs.DescribeLogGroupsOutput, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		dlgCount++
+		return &cloudwatchlogs.DescribeLogGroupsOutput{
+			LogGroups: []*cloudwatchlogs.LogGroup{{LogGroupName: aws.String("G"), RetentionInDays: aws.Int64(7)}},
+		}, nil
+	}
+	service.prp = func(*cloudwatchlogs.PutRetentionPolicyInput) (*cloudwatchlogs.PutRetentionPolicyOutput, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		prpCount++
+		return &cloudwatchlogs.PutRetentionPolicyOutput{}, nil
+	}
+
+	stop := make(chan struct{})
+	var wg sync.WaitGroup
+	manager := NewTargetManager(logger, service)
+	var pushers []*Pusher
+	for _, stream := range streams {
+		target := Target{Group: "G", Stream: stream, Retention: 7}
+		pushers = append(pushers, NewPusher(logger, target, service, manager, nil, nil, 0, time.Second, time.Minute, 0, nil, stop, &wg))
+	}
+	var completed atomic.Int32
+	now := time.Now()
+	for i := 0; i < eventsPerStream; i++ {
+		for j, p := range pushers {
+			event := newStubLogEvent(fmt.Sprintf("%s-%d", streams[j], i), now)
+			event.done = func() {
+				completed.Add(1)
+			}
+			p.AddEvent(event)
+		}
+	}
+	assert.Eventually(t, func() bool {
+		return completed.Load() == int32(len(streams)*eventsPerStream)
+	}, 10*time.Second, 100*time.Millisecond)
+	close(stop)
+	wg.Wait()
+
+	mu.Lock()
+	defer mu.Unlock()
+	// the log group is only created and has its retention checked once for all of its streams
+	assert.Equal(t, 1, clgCount)
+	assert.Equal(t, 1, prpCount)
+	assert.Equal(t, 1, dlgCount)
+	require.Len(t, received, len(streams))
+	for _, stream := range streams {
+		require.Len(t, received[stream], eventsPerStream)
+		for i, message := range received[stream] {
+			assert.Equal(t, fmt.Sprintf("%s-%d", stream, i), message)
+		}
+	}
+}
+
 func generateEvents(t *testing.T, pusher *Pusher, completed *atomic.Int32) {
 	t.Helper()
 	for i := 0; i < eventCount; i++ {
@@ -83,8 +178,11 @@ func setupPusher(t *testing.T, workerPool WorkerPool, stop chan struct{}, wg *sy
 		mockManager,
 		nil,
 		workerPool,
+		0,
 		time.Second,
 		time.Minute,
+		0,
+		nil,
 		stop,
 		wg,
 	)
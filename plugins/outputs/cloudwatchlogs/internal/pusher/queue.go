@@ -17,6 +17,14 @@ import (
 type Queue interface {
 	AddEvent(e logs.LogEvent)
 	AddEventNonBlocking(e logs.LogEvent)
+	// Saturated returns true if the queue is full, meaning that events are added faster than they are sent.
+	Saturated() bool
+	// Len returns the number of events waiting to be batched.
+	Len() int
+	// Usage returns how full the queue is, from 0 to 1.
+	Usage() float64
+	// LastSent returns the last time that a batch of the queue was sent. Zero if none was sent yet.
+	LastSent() time.Time
 }
 
 type queue struct {
@@ -26,6 +34,7 @@ type queue struct {
 	entityProvider      logs.LogEntityProvider
 	sender              Sender
 	converter           *converter
+	deadLetter          DeadLetter
 	batch               *logEventBatch
 	eventsCh            chan logs.LogEvent
 	nonBlockingEventsCh chan logs.LogEvent
@@ -48,13 +57,16 @@ func newQueue(
 	flushTimeout time.Duration,
 	entityProvider logs.LogEntityProvider,
 	sender Sender,
+	deadLetter DeadLetter,
 	stop <-chan struct{},
 	wg *sync.WaitGroup,
 ) Queue {
 	q := &queue{
 		target:          target,
 		logger:          logger,
+		entityProvider:  entityProvider,
 		converter:       newConverter(logger, target),
+		deadLetter:      deadLetter,
 		batch:           newLogEventBatch(target, entityProvider),
 		sender:          sender,
 		eventsCh:        make(chan logs.LogEvent, 100),
@@ -65,6 +77,7 @@ func newQueue(
 		startNonBlockCh: make(chan struct{}),
 		wg:              wg,
 	}
+	q.converter.deadLetter = deadLetter
 	q.flushTimeout.Store(flushTimeout)
 	q.wg.Add(1)
 	go q.start()
@@ -73,8 +86,7 @@ func newQueue(
 
 // AddEvent adds an event to the queue blocking if full.
 func (q *queue) AddEvent(e logs.LogEvent) {
-	if !hasValidTime(e) {
-		q.logger.Errorf("The log entry in (%v/%v) with timestamp (%v) comparing to the current time (%v) is out of accepted time range. Discard the log entry.", q.target.Group, q.target.Stream, e.Time(), time.Now())
+	if !q.hasValidTime(e) {
 		return
 	}
 	q.eventsCh <- e
@@ -83,8 +95,7 @@ func (q *queue) AddEvent(e logs.LogEvent) {
 // AddEventNonBlocking adds an event to the queue without blocking. If the queue is full, drops the oldest event in
 // the queue.
 func (q *queue) AddEventNonBlocking(e logs.LogEvent) {
-	if !hasValidTime(e) {
-		q.logger.Errorf("The log entry in (%v/%v) with timestamp (%v) comparing to the current time (%v) is out of accepted time range. Discard the log entry.", q.target.Group, q.target.Stream, e.Time(), time.Now())
+	if !q.hasValidTime(e) {
 		return
 	}
 
@@ -105,6 +116,42 @@ func (q *queue) AddEventNonBlocking(e logs.LogEvent) {
 	}
 }
 
+// hasValidTime returns false if the timestamp of the event is out of the accepted time range. The event is added to
+// the dead letter if there is one, otherwise it is discarded.
+func (q *queue) hasValidTime(e logs.LogEvent) bool {
+	if hasValidTime(e) {
+		return true
+	}
+	if q.deadLetter != nil {
+		q.deadLetter.Add(q.target, ReasonTimestampOutOfRange, e)
+		return false
+	}
+	q.logger.Errorf("The log entry in (%v/%v) with timestamp (%v) comparing to the current time (%v) is out of accepted time range. Discard the log entry.", q.target.Group, q.target.Stream, e.Time(), time.Now())
+	return false
+}
+
+// Saturated returns true if AddEvent would block. Events added with AddEventNonBlocking are dropped instead
+// of blocking, so they are not considered.
+func (q *queue) Saturated() bool {
+	return len(q.eventsCh) == cap(q.eventsCh)
+}
+
+// Len returns the number of events added with AddEvent that are waiting to be batched.
+func (q *queue) Len() int {
+	return len(q.eventsCh)
+}
+
+// Usage returns the fraction of the capacity for events added with AddEvent that is in use.
+func (q *queue) Usage() float64 {
+	return float64(len(q.eventsCh)) / float64(cap(q.eventsCh))
+}
+
+// LastSent returns the last time that a batch of the queue was sent successfully.
+func (q *queue) LastSent() time.Time {
+	lastSentTime, _ := q.lastSentTime.Load().(time.Time)
+	return lastSentTime
+}
+
 // start is the main loop for processing events and managing the queue.
 func (q *queue) start() {
 	defer q.wg.Done()
@@ -137,7 +184,10 @@ func (q *queue) start() {
 				q.resetFlushTimer()
 			}
 			event := q.converter.convert(e)
-			if !q.batch.inTimeRange(event.timestamp) || !q.batch.hasSpace(event.eventBytes) {
+			if event == nil {
+				continue
+			}
+			if !q.batch.inTimeRange(event.timestamp) || !q.batch.hasSpace(event.eventBytes) || !q.batch.hasEntity(event.entity) {
 				q.send()
 			}
 			q.batch.append(event)
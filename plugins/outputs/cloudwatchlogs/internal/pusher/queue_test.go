@@ -15,6 +15,7 @@ import (
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/influxdata/telegraf"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"github.com/aws/amazon-cloudwatch-agent/logs"
@@ -29,6 +30,7 @@ type stubLogsService struct {
 	cls func(input *cloudwatchlogs.CreateLogStreamInput) (*cloudwatchlogs.CreateLogStreamOutput, error)
 	prp func(input *cloudwatchlogs.PutRetentionPolicyInput) (*cloudwatchlogs.PutRetentionPolicyOutput, error)
 	dlg func(input *cloudwatchlogs.DescribeLogGroupsInput) (*cloudwatchlogs.DescribeLogGroupsOutput, error)
+	dls func(input *cloudwatchlogs.DescribeLogStreamsInput) (*cloudwatchlogs.DescribeLogStreamsOutput, error)
 }
 
 func (s *stubLogsService) PutLogEvents(in *cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
@@ -66,6 +68,13 @@ func (s *stubLogsService) DescribeLogGroups(in *cloudwatchlogs.DescribeLogGroups
 	return nil, nil
 }
 
+func (s *stubLogsService) DescribeLogStreams(in *cloudwatchlogs.DescribeLogStreamsInput) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+	if s.dls != nil {
+		return s.dls(in)
+	}
+	return nil, nil
+}
+
 func TestAddSingleEvent_WithAccountId(t *testing.T) {
 	t.Parallel()
 	var wg sync.WaitGroup
@@ -114,6 +123,39 @@ func TestAddSingleEvent_WithAccountId(t *testing.T) {
 	wg.Wait()
 }
 
+func TestEntityIsSetOnEverySend(t *testing.T) {
+	t.Parallel()
+	var wg sync.WaitGroup
+	var s stubLogsService
+	var calls atomic.Int32
+	expectedEntity := &cloudwatchlogs.Entity{
+		KeyAttributes: map[string]*string{
+			"Type":        aws.String("Service"),
+			"Name":        aws.String("myService"),
+			"Environment": aws.String("myEnvironment"),
+		},
+	}
+
+	s.ple = func(in *cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+		calls.Add(1)
+		assert.Equal(t, expectedEntity, in.Entity)
+		return &cloudwatchlogs.PutLogEventsOutput{}, nil
+	}
+
+	ep := newMockEntityProvider(expectedEntity)
+	stop, q := testPreparation(t, -1, &s, 1*time.Hour, 2*time.Hour, ep, &wg)
+	for i := 0; i < 2; i++ {
+		q.AddEvent(newStubLogEvent("MSG", time.Now()))
+		time.Sleep(10 * time.Millisecond)
+		triggerSend(t, q)
+		time.Sleep(100 * time.Millisecond)
+	}
+	require.EqualValues(t, 2, calls.Load())
+
+	close(stop)
+	wg.Wait()
+}
+
 func TestAddSingleEvent_WithoutAccountId(t *testing.T) {
 	t.Parallel()
 	var wg sync.WaitGroup
@@ -177,6 +219,48 @@ func TestStopQueueWouldDoFinalSend(t *testing.T) {
 	require.True(t, called.Load(), "PutLogEvents has not been called after FlushTimeout has been reached.")
 }
 
+func TestQueueSaturated(t *testing.T) {
+	t.Parallel()
+	var wg sync.WaitGroup
+	var s stubLogsService
+	sending := make(chan struct{}, 1)
+	release := make(chan struct{})
+	s.ple = func(*cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+		select {
+		case sending <- struct{}{}:
+		default:
+		}
+		<-release
+		return &cloudwatchlogs.PutLogEventsOutput{}, nil
+	}
+
+	stop, q := testPreparation(t, -1, &s, 10*time.Millisecond, time.Hour, nil, &wg)
+	q.AddEvent(newStubLogEvent("MSG", time.Now()))
+	<-sending
+	assert.False(t, q.Saturated())
+	assert.Zero(t, q.Usage())
+	assert.True(t, q.LastSent().IsZero())
+	go func() {
+		// more than the queue can hold while the send is blocked
+		for i := 0; i < cap(q.eventsCh)+2; i++ {
+			q.AddEvent(newStubLogEvent("MSG", time.Now()))
+		}
+	}()
+	assert.Eventually(t, q.Saturated, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, cap(q.eventsCh), q.Len())
+	assert.Equal(t, 1.0, q.Usage())
+
+	close(release)
+	assert.Eventually(t, func() bool {
+		return !q.Saturated()
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return !q.LastSent().IsZero()
+	}, 5*time.Second, 10*time.Millisecond)
+	close(stop)
+	wg.Wait()
+}
+
 func TestStopPusherWouldStopRetries(t *testing.T) {
 	t.Parallel()
 	var wg sync.WaitGroup
@@ -675,13 +759,14 @@ func testPreparationWithLogger(
 	t.Helper()
 	stop := make(chan struct{})
 	tm := NewTargetManager(logger, service)
-	s := newSender(logger, service, tm, retryDuration, stop)
+	s := newSender(logger, service, tm, retryDuration, 0, stop)
 	q := newQueue(
 		logger,
 		Target{"G", "S", util.StandardLogGroupClass, retention},
 		flushTimeout,
 		entityProvider,
 		s,
+		nil,
 		stop,
 		wg,
 	)
@@ -4,10 +4,14 @@
 package pusher
 
 import (
+	"crypto/sha256"
 	"errors"
+	"fmt"
+	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/influxdata/telegraf"
 
@@ -20,6 +24,7 @@ type cloudWatchLogsService interface {
 	CreateLogGroup(input *cloudwatchlogs.CreateLogGroupInput) (*cloudwatchlogs.CreateLogGroupOutput, error)
 	PutRetentionPolicy(input *cloudwatchlogs.PutRetentionPolicyInput) (*cloudwatchlogs.PutRetentionPolicyOutput, error)
 	DescribeLogGroups(input *cloudwatchlogs.DescribeLogGroupsInput) (*cloudwatchlogs.DescribeLogGroupsOutput, error)
+	DescribeLogStreams(input *cloudwatchlogs.DescribeLogStreamsInput) (*cloudwatchlogs.DescribeLogStreamsOutput, error)
 }
 
 type Sender interface {
@@ -29,11 +34,63 @@ type Sender interface {
 }
 
 type sender struct {
-	service       cloudWatchLogsService
-	retryDuration atomic.Value
-	targetManager TargetManager
-	logger        telegraf.Logger
-	stop          <-chan struct{}
+	service         cloudWatchLogsService
+	retryDuration   atomic.Value
+	maxEventRetries int
+	targetManager   TargetManager
+	logger          telegraf.Logger
+	stop            <-chan struct{}
+	sequenceToken   sequenceToken
+}
+
+// sequenceToken tracks the sequence token of the stream for the endpoints that still require one. Newer endpoints
+// accept PutLogEvents without a token, so the token is only tracked once the endpoint rejects a request without it.
+type sequenceToken struct {
+	mu       sync.Mutex
+	required bool
+	token    *string
+	// sendMu serializes the requests of the stream once the token is required, since each request needs the token
+	// returned by the previous one.
+	sendMu sync.Mutex
+}
+
+// lock holds the stream for a request if the token is required, so that concurrent batches of the stream do not
+// send the same token. The returned function releases the stream.
+func (t *sequenceToken) lock() func() {
+	t.mu.Lock()
+	required := t.required
+	t.mu.Unlock()
+	if !required {
+		return func() {}
+	}
+	t.sendMu.Lock()
+	return t.sendMu.Unlock
+}
+
+// get returns the token to send with the next request, which is nil unless the endpoint requires one.
+func (t *sequenceToken) get() *string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.token
+}
+
+// require switches to sending the token with the requests. Returns true if the token was already required.
+func (t *sequenceToken) require(token *string) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	required := t.required
+	t.required = true
+	t.token = token
+	return required
+}
+
+// update sets the token for the next request if the endpoint requires one.
+func (t *sequenceToken) update(token *string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.required {
+		t.token = token
+	}
 }
 
 func newSender(
@@ -41,31 +98,44 @@ func newSender(
 	service cloudWatchLogsService,
 	targetManager TargetManager,
 	retryDuration time.Duration,
+	maxEventRetries int,
 	stop <-chan struct{},
 ) Sender {
 	s := &sender{
-		logger:        logger,
-		service:       service,
-		targetManager: targetManager,
-		stop:          stop,
+		logger:          logger,
+		service:         service,
+		targetManager:   targetManager,
+		maxEventRetries: maxEventRetries,
+		stop:            stop,
 	}
 	s.retryDuration.Store(retryDuration)
 	return s
 }
 
 // Send attempts to send a batch of log events to CloudWatch Logs. Will retry failed attempts until it reaches the
-// RetryDuration or an unretryable error.
+// RetryDuration or an unretryable error. The batch is done once its events are sent, or once the events rejected by
+// the endpoint are dropped after the maxEventRetries.
 func (s *sender) Send(batch *logEventBatch) {
 	if len(batch.events) == 0 {
 		return
 	}
+	if s.send(batch) {
+		batch.done()
+	}
+}
+
+// send returns true if the events of the batch were sent or rejected by the endpoint and dropped after the
+// maxEventRetries, and false if the request was dropped for any other reason.
+func (s *sender) send(batch *logEventBatch) bool {
 	input := batch.build()
 	startTime := time.Now()
 
 	retryCountShort := 0
 	retryCountLong := 0
+	rejections := 0
+	tokenRetries := 0
 	for {
-		output, err := s.service.PutLogEvents(input)
+		output, err := s.putLogEvents(input)
 		if err == nil {
 			if output.RejectedLogEventsInfo != nil {
 				info := output.RejectedLogEventsInfo
@@ -78,16 +148,18 @@ func (s *sender) Send(batch *logEventBatch) {
 				if info.ExpiredLogEventEndIndex != nil {
 					s.logger.Warnf("%d log events for log '%s/%s' are expired", *info.ExpiredLogEventEndIndex, batch.Group, batch.Stream)
 				}
+				if s.maxEventRetries > 0 {
+					s.logRejected(batch, input.LogEvents, info)
+				}
 			}
-			batch.done()
 			s.logger.Debugf("Pusher published %v log events to group: %v stream: %v with size %v KB in %v.", len(batch.events), batch.Group, batch.Stream, batch.bufferedSize/1024, time.Since(startTime))
-			return
+			return true
 		}
 
 		var awsErr awserr.Error
 		if !errors.As(err, &awsErr) {
 			s.logger.Errorf("Non aws error received when sending logs to %v/%v: %v. CloudWatch agent will not retry and logs will be missing!", batch.Group, batch.Stream, err)
-			return
+			return false
 		}
 
 		switch e := awsErr.(type) {
@@ -96,10 +168,34 @@ func (s *sender) Send(batch *logEventBatch) {
 				s.logger.Errorf("Unable to create log stream %v/%v: %v", batch.Group, batch.Stream, targetErr)
 				break
 			}
-		case *cloudwatchlogs.InvalidParameterException,
-			*cloudwatchlogs.DataAlreadyAcceptedException:
+			// a new stream does not expect a token
+			s.sequenceToken.update(nil)
+		case *cloudwatchlogs.InvalidSequenceTokenException:
+			if s.useSequenceToken(batch, input.SequenceToken, e.ExpectedSequenceToken) {
+				// the first retry with the new token is sent right away, but a token that keeps changing, such as
+				// with another writer to the stream, backs off like the other errors
+				tokenRetries++
+				if tokenRetries == 1 {
+					continue
+				}
+			}
+		case *cloudwatchlogs.DataAlreadyAcceptedException:
+			s.sequenceToken.update(e.ExpectedSequenceToken)
 			s.logger.Errorf("%v, will not retry the request", e)
-			return
+			return false
+		case *cloudwatchlogs.InvalidParameterException:
+			// the endpoint rejects the request for its events, so only the failing events are dropped once the
+			// request keeps being rejected
+			if s.maxEventRetries == 0 {
+				s.logger.Errorf("%v, will not retry the request", e)
+				return false
+			}
+			if rejections >= s.maxEventRetries {
+				return s.isolate(batch, err)
+			}
+			rejections++
+			s.logger.Warnf("%v, retrying the request %v/%v times before isolating the rejected events", e, rejections, s.maxEventRetries)
+			continue
 		default:
 			s.logger.Errorf("Aws error received when sending logs to %v/%v: %v", batch.Group, batch.Stream, awsErr)
 		}
@@ -116,7 +212,7 @@ func (s *sender) Send(batch *logEventBatch) {
 
 		if time.Since(startTime)+wait > s.RetryDuration() {
 			s.logger.Errorf("All %v retries to %v/%v failed for PutLogEvents, request dropped.", retryCountShort+retryCountLong-1, batch.Group, batch.Stream)
-			return
+			return false
 		}
 
 		s.logger.Warnf("Retried %v time, going to sleep %v before retrying.", retryCountShort+retryCountLong-1, wait)
@@ -124,12 +220,101 @@ func (s *sender) Send(batch *logEventBatch) {
 		select {
 		case <-s.stop:
 			s.logger.Errorf("Stop requested after %v retries to %v/%v failed for PutLogEvents, request dropped.", retryCountShort+retryCountLong-1, batch.Group, batch.Stream)
-			return
+			return false
 		case <-time.After(wait):
 		}
 	}
 }
 
+// putLogEvents sends the request with the sequence token of the stream, if the endpoint requires one, and keeps the
+// token returned for the next request.
+func (s *sender) putLogEvents(input *cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	unlock := s.sequenceToken.lock()
+	defer unlock()
+	input.SequenceToken = s.sequenceToken.get()
+	output, err := s.service.PutLogEvents(input)
+	if err == nil {
+		s.sequenceToken.update(output.NextSequenceToken)
+	}
+	return output, err
+}
+
+// useSequenceToken switches to sending the sequence token once the endpoint rejects a request for its token. The
+// token expected by the endpoint is used, and the stream is only described when the error does not include it.
+// Returns true if the request can be retried right away with a different token.
+func (s *sender) useSequenceToken(batch *logEventBatch, sent *string, expected *string) bool {
+	token := expected
+	if token == nil {
+		var err error
+		if token, err = s.describeSequenceToken(batch); err != nil {
+			s.logger.Errorf("Unable to get the sequence token of %v/%v: %v", batch.Group, batch.Stream, err)
+			return false
+		}
+	}
+	if !s.sequenceToken.require(token) {
+		s.logger.Infof("Endpoint requires a sequence token for %v/%v, sending it with PutLogEvents", batch.Group, batch.Stream)
+	}
+	return aws.StringValue(token) != aws.StringValue(sent)
+}
+
+// describeSequenceToken gets the upload sequence token of the stream of the batch, which is nil for a stream without
+// any events.
+func (s *sender) describeSequenceToken(batch *logEventBatch) (*string, error) {
+	input := &cloudwatchlogs.DescribeLogStreamsInput{
+		LogStreamNamePrefix: aws.String(batch.Stream),
+	}
+	if batch.hasLogGroupARN() {
+		input.LogGroupIdentifier = aws.String(batch.logGroupIdentifier())
+	} else {
+		input.LogGroupName = aws.String(batch.Group)
+	}
+	output, err := s.service.DescribeLogStreams(input)
+	if err != nil {
+		return nil, err
+	}
+	for _, stream := range output.LogStreams {
+		if aws.StringValue(stream.LogStreamName) == batch.Stream {
+			return stream.UploadSequenceToken, nil
+		}
+	}
+	return nil, nil
+}
+
+// isolate is called once the endpoint has rejected the batch for the maxEventRetries. A single event is dropped and
+// logged with the hash of its message, so an event that is rejected every time does not block the events after it.
+// Otherwise, the batch is split in half and each half is sent with its own retries, which narrows the rejections down
+// to the events causing them while the other events are still sent.
+func (s *sender) isolate(batch *logEventBatch, err error) bool {
+	if len(batch.events) == 1 {
+		s.logDropped(batch, batch.events[0], fmt.Sprintf("rejected %v times: %v", s.maxEventRetries+1, err))
+		return true
+	}
+	s.logger.Warnf("Splitting %v log events for %v/%v after %v retries to isolate the failing events", len(batch.events), batch.Group, batch.Stream, s.maxEventRetries)
+	mid := len(batch.events) / 2
+	first := s.send(batch.slice(0, mid))
+	second := s.send(batch.slice(mid, len(batch.events)))
+	return first && second
+}
+
+// logRejected logs each of the events that the endpoint accepted the request without, since they are dropped.
+func (s *sender) logRejected(batch *logEventBatch, events []*cloudwatchlogs.InputLogEvent, info *cloudwatchlogs.RejectedLogEventsInfo) {
+	for i, event := range events {
+		switch {
+		case info.TooOldLogEventEndIndex != nil && int64(i) < *info.TooOldLogEventEndIndex:
+			s.logDropped(batch, event, "too old")
+		case info.ExpiredLogEventEndIndex != nil && int64(i) < *info.ExpiredLogEventEndIndex:
+			s.logDropped(batch, event, "expired")
+		case info.TooNewLogEventStartIndex != nil && int64(i) >= *info.TooNewLogEventStartIndex:
+			s.logDropped(batch, event, "too new")
+		}
+	}
+}
+
+// logDropped logs the event with the hash of its message, so it can be found in the source without its content.
+func (s *sender) logDropped(batch *logEventBatch, event *cloudwatchlogs.InputLogEvent, reason string) {
+	s.logger.Errorf("Dropped log event for %v/%v, content hash: sha256:%x, reason: %v", batch.Group, batch.Stream, sha256.Sum256([]byte(aws.StringValue(event.Message))), reason)
+}
+
 // SetRetryDuration sets the maximum duration for retrying failed log sends.
 func (s *sender) SetRetryDuration(retryDuration time.Duration) {
 	s.retryDuration.Store(retryDuration)
@@ -4,12 +4,16 @@
 package pusher
 
 import (
+	"crypto/sha256"
 	"errors"
+	"fmt"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/aws/aws-sdk-go/aws"
 	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	"github.com/aws/amazon-cloudwatch-agent/sdk/service/cloudwatchlogs"
@@ -45,6 +49,11 @@ func (m *mockLogsService) DescribeLogGroups(input *cloudwatchlogs.DescribeLogGro
 	return args.Get(0).(*cloudwatchlogs.DescribeLogGroupsOutput), args.Error(1)
 }
 
+func (m *mockLogsService) DescribeLogStreams(input *cloudwatchlogs.DescribeLogStreamsInput) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+	args := m.Called(input)
+	return args.Get(0).(*cloudwatchlogs.DescribeLogStreamsOutput), args.Error(1)
+}
+
 type mockTargetManager struct {
 	mock.Mock
 }
@@ -75,7 +84,7 @@ func TestSender(t *testing.T) {
 		mockManager := new(mockTargetManager)
 		mockService.On("PutLogEvents", mock.Anything).Return(&cloudwatchlogs.PutLogEventsOutput{RejectedLogEventsInfo: rejectedInfo}, nil).Once()
 
-		s := newSender(logger, mockService, mockManager, time.Second, make(chan struct{}))
+		s := newSender(logger, mockService, mockManager, time.Second, 0, make(chan struct{}))
 		s.Send(batch)
 
 		mockService.AssertExpectations(t)
@@ -93,7 +102,7 @@ func TestSender(t *testing.T) {
 		mockManager.On("InitTarget", mock.Anything).Return(nil).Once()
 		mockService.On("PutLogEvents", mock.Anything).Return(&cloudwatchlogs.PutLogEventsOutput{}, nil).Once()
 
-		s := newSender(logger, mockService, mockManager, time.Second, make(chan struct{}))
+		s := newSender(logger, mockService, mockManager, time.Second, 0, make(chan struct{}))
 		s.Send(batch)
 
 		mockService.AssertExpectations(t)
@@ -109,7 +118,7 @@ func TestSender(t *testing.T) {
 		mockService.On("PutLogEvents", mock.Anything).
 			Return(&cloudwatchlogs.PutLogEventsOutput{}, &cloudwatchlogs.InvalidParameterException{}).Once()
 
-		s := newSender(logger, mockService, mockManager, time.Second, make(chan struct{}))
+		s := newSender(logger, mockService, mockManager, time.Second, 0, make(chan struct{}))
 		s.Send(batch)
 
 		mockService.AssertExpectations(t)
@@ -124,7 +133,7 @@ func TestSender(t *testing.T) {
 		mockService.On("PutLogEvents", mock.Anything).
 			Return(&cloudwatchlogs.PutLogEventsOutput{}, &cloudwatchlogs.DataAlreadyAcceptedException{}).Once()
 
-		s := newSender(logger, mockService, mockManager, time.Second, make(chan struct{}))
+		s := newSender(logger, mockService, mockManager, time.Second, 0, make(chan struct{}))
 		s.Send(batch)
 
 		mockService.AssertExpectations(t)
@@ -139,7 +148,7 @@ func TestSender(t *testing.T) {
 		mockService.On("PutLogEvents", mock.Anything).
 			Return(&cloudwatchlogs.PutLogEventsOutput{}, errors.New("test")).Once()
 
-		s := newSender(logger, mockService, mockManager, time.Second, make(chan struct{}))
+		s := newSender(logger, mockService, mockManager, time.Second, 0, make(chan struct{}))
 		s.Send(batch)
 
 		mockService.AssertExpectations(t)
@@ -156,7 +165,7 @@ func TestSender(t *testing.T) {
 		mockService.On("PutLogEvents", mock.Anything).
 			Return(&cloudwatchlogs.PutLogEventsOutput{}, nil).Once()
 
-		s := newSender(logger, mockService, mockManager, time.Second, make(chan struct{}))
+		s := newSender(logger, mockService, mockManager, time.Second, 0, make(chan struct{}))
 		s.Send(batch)
 
 		mockService.AssertExpectations(t)
@@ -171,7 +180,7 @@ func TestSender(t *testing.T) {
 		mockService.On("PutLogEvents", mock.Anything).
 			Return(&cloudwatchlogs.PutLogEventsOutput{}, awserr.New("SomeAWSError", "Some AWS error", nil)).Once()
 
-		s := newSender(logger, mockService, mockManager, 100*time.Millisecond, make(chan struct{}))
+		s := newSender(logger, mockService, mockManager, 100*time.Millisecond, 0, make(chan struct{}))
 		s.Send(batch)
 
 		mockService.AssertExpectations(t)
@@ -187,7 +196,7 @@ func TestSender(t *testing.T) {
 			Return(&cloudwatchlogs.PutLogEventsOutput{}, awserr.New("SomeAWSError", "Some AWS error", nil)).Once()
 
 		stopCh := make(chan struct{})
-		s := newSender(logger, mockService, mockManager, time.Second, stopCh)
+		s := newSender(logger, mockService, mockManager, time.Second, 0, stopCh)
 
 		go func() {
 			time.Sleep(50 * time.Millisecond)
@@ -199,3 +208,293 @@ func TestSender(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 }
+
+func TestSenderWithMaxEventRetries(t *testing.T) {
+	logSink := testutil.NewLogSink()
+	var mu sync.Mutex
+	var sent []string
+	var calls int
+	service := &stubLogsService{ple: func(input *cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		for _, event := range input.LogEvents {
+			if *event.Message == "poison" {
+				return &cloudwatchlogs.PutLogEventsOutput{}, &cloudwatchlogs.InvalidParameterException{Message_: aws.String("invalid event")}
+			}
+		}
+		for _, event := range input.LogEvents {
+			sent = append(sent, *event.Message)
+		}
+		return &cloudwatchlogs.PutLogEventsOutput{}, nil
+	}}
+	s := newSender(logSink, service, new(mockTargetManager), time.Minute, 1, make(chan struct{}))
+
+	var done bool
+	start := time.Now()
+	batch := newLogEventBatch(Target{Group: "G", Stream: "S"}, nil)
+	for i, message := range []string{"first", "second", "poison", "fourth"} {
+		batch.append(newLogEvent(start.Add(time.Duration(i)*time.Millisecond), message, nil))
+	}
+	batch.addDoneCallback(func() { done = true })
+	s.Send(batch)
+
+	// each of the failing batches, with 4, 2 and 1 events, is sent twice
+	assert.Equal(t, 8, calls)
+	assert.Equal(t, []string{"first", "second", "fourth"}, sent)
+	assert.True(t, done, "the batch is done once the poison event is dropped")
+	assert.Contains(t, logSink.String(), fmt.Sprintf("Dropped log event for G/S, content hash: sha256:%x, reason: rejected 2 times: InvalidParameterException: invalid event", sha256.Sum256([]byte("poison"))))
+
+	// the stream continues with the next batch
+	batch = newLogEventBatch(Target{Group: "G", Stream: "S"}, nil)
+	batch.append(newLogEvent(time.Now(), "fifth", nil))
+	s.Send(batch)
+	assert.Equal(t, []string{"first", "second", "fourth", "fifth"}, sent)
+	assert.Equal(t, 9, calls)
+}
+
+func TestSenderWithMaxEventRetriesRejectedEvents(t *testing.T) {
+	logSink := testutil.NewLogSink()
+	mockService := new(mockLogsService)
+	mockService.On("PutLogEvents", mock.Anything).Return(&cloudwatchlogs.PutLogEventsOutput{
+		RejectedLogEventsInfo: &cloudwatchlogs.RejectedLogEventsInfo{
+			TooOldLogEventEndIndex:   aws.Int64(1),
+			TooNewLogEventStartIndex: aws.Int64(2),
+		},
+	}, nil).Once()
+	s := newSender(logSink, mockService, new(mockTargetManager), time.Second, 1, make(chan struct{}))
+
+	var done bool
+	start := time.Now()
+	batch := newLogEventBatch(Target{Group: "G", Stream: "S"}, nil)
+	for i, message := range []string{"old", "accepted", "new"} {
+		batch.append(newLogEvent(start.Add(time.Duration(i)*time.Millisecond), message, nil))
+	}
+	batch.addDoneCallback(func() { done = true })
+	s.Send(batch)
+
+	// the rejected events are not retried
+	assert.True(t, done)
+	mockService.AssertExpectations(t)
+	assert.Contains(t, logSink.String(), fmt.Sprintf("Dropped log event for G/S, content hash: sha256:%x, reason: too old", sha256.Sum256([]byte("old"))))
+	assert.Contains(t, logSink.String(), fmt.Sprintf("Dropped log event for G/S, content hash: sha256:%x, reason: too new", sha256.Sum256([]byte("new"))))
+	assert.NotContains(t, logSink.String(), fmt.Sprintf("%x", sha256.Sum256([]byte("accepted"))))
+}
+
+func TestSenderWithMaxEventRetriesTransientError(t *testing.T) {
+	batch := newLogEventBatch(Target{Group: "G", Stream: "S"}, nil)
+	batch.append(newLogEvent(time.Now(), "first", nil))
+	batch.append(newLogEvent(time.Now(), "second", nil))
+	var done bool
+	batch.addDoneCallback(func() { done = true })
+
+	mockService := new(mockLogsService)
+	mockService.On("PutLogEvents", mock.MatchedBy(func(input *cloudwatchlogs.PutLogEventsInput) bool {
+		return len(input.LogEvents) == 2
+	})).Return(&cloudwatchlogs.PutLogEventsOutput{}, awserr.New("SomeAWSError", "Some AWS error", nil))
+
+	// a transient error is not caused by the events, so the batch is not split and is retried until the retry
+	// duration is reached
+	s := newSender(testutil.NewNopLogger(), mockService, new(mockTargetManager), 500*time.Millisecond, 1, make(chan struct{}))
+	s.Send(batch)
+	assert.False(t, done)
+	mockService.AssertExpectations(t)
+	assert.Greater(t, len(mockService.Calls), 2)
+}
+
+func TestSenderWithoutMaxEventRetries(t *testing.T) {
+	batch := newLogEventBatch(Target{Group: "G", Stream: "S"}, nil)
+	batch.append(newLogEvent(time.Now(), "poison", nil))
+	batch.append(newLogEvent(time.Now(), "other", nil))
+	var done bool
+	batch.addDoneCallback(func() { done = true })
+
+	mockService := new(mockLogsService)
+	mockService.On("PutLogEvents", mock.MatchedBy(func(input *cloudwatchlogs.PutLogEventsInput) bool {
+		return len(input.LogEvents) == 2
+	})).Return(&cloudwatchlogs.PutLogEventsOutput{}, awserr.New("SomeAWSError", "Some AWS error", nil))
+
+	// the batch is retried as a whole until the retry duration is reached
+	s := newSender(testutil.NewNopLogger(), mockService, new(mockTargetManager), 500*time.Millisecond, 0, make(chan struct{}))
+	s.Send(batch)
+	assert.False(t, done)
+	mockService.AssertExpectations(t)
+}
+
+func TestSenderWithoutSequenceToken(t *testing.T) {
+	var tokens []*string
+	service := &stubLogsService{
+		ple: func(input *cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+			tokens = append(tokens, input.SequenceToken)
+			return &cloudwatchlogs.PutLogEventsOutput{}, nil
+		},
+		dls: func(*cloudwatchlogs.DescribeLogStreamsInput) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+			t.Fatal("the stream should not be described when the endpoint does not require a sequence token")
+			return nil, nil
+		},
+	}
+	s := newSender(testutil.NewNopLogger(), service, new(mockTargetManager), time.Second, 0, make(chan struct{}))
+	for i := 0; i < 2; i++ {
+		batch := newLogEventBatch(Target{Group: "G", Stream: "S"}, nil)
+		batch.append(newLogEvent(time.Now(), "Test message", nil))
+		s.Send(batch)
+	}
+	assert.Equal(t, []*string{nil, nil}, tokens)
+}
+
+func TestSenderWithSequenceToken(t *testing.T) {
+	t.Run("ExpectedToken", func(t *testing.T) {
+		var tokens []string
+		var describes int
+		service := &stubLogsService{
+			ple: func(input *cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+				tokens = append(tokens, aws.StringValue(input.SequenceToken))
+				switch aws.StringValue(input.SequenceToken) {
+				case "":
+					return nil, &cloudwatchlogs.InvalidSequenceTokenException{ExpectedSequenceToken: aws.String("1")}
+				case "1":
+					return &cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String("2")}, nil
+				case "2":
+					return &cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String("3")}, nil
+				}
+				return nil, &cloudwatchlogs.InvalidSequenceTokenException{}
+			},
+			dls: func(*cloudwatchlogs.DescribeLogStreamsInput) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+				describes++
+				return &cloudwatchlogs.DescribeLogStreamsOutput{}, nil
+			},
+		}
+		logSink := testutil.NewLogSink()
+		s := newSender(logSink, service, new(mockTargetManager), time.Second, 0, make(chan struct{}))
+		for i := 0; i < 2; i++ {
+			var done bool
+			batch := newLogEventBatch(Target{Group: "G", Stream: "S"}, nil)
+			batch.append(newLogEvent(time.Now(), "Test message", nil))
+			batch.addDoneCallback(func() { done = true })
+			s.Send(batch)
+			assert.True(t, done)
+		}
+		// the rejected request is retried right away with the expected token, without describing the stream
+		assert.Equal(t, []string{"", "1", "2"}, tokens)
+		assert.Equal(t, 0, describes)
+		assert.Contains(t, logSink.String(), "Endpoint requires a sequence token for G/S")
+	})
+
+	t.Run("DescribeLogStreams", func(t *testing.T) {
+		var tokens []string
+		var describeInput *cloudwatchlogs.DescribeLogStreamsInput
+		service := &stubLogsService{
+			ple: func(input *cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+				tokens = append(tokens, aws.StringValue(input.SequenceToken))
+				if aws.StringValue(input.SequenceToken) != "B" {
+					return nil, &cloudwatchlogs.InvalidSequenceTokenException{}
+				}
+				return &cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String("C")}, nil
+			},
+			dls: func(input *cloudwatchlogs.DescribeLogStreamsInput) (*cloudwatchlogs.DescribeLogStreamsOutput, error) {
+				describeInput = input
+				return &cloudwatchlogs.DescribeLogStreamsOutput{LogStreams: []*cloudwatchlogs.LogStream{
+					{LogStreamName: aws.String("S-other"), UploadSequenceToken: aws.String("A")},
+					{LogStreamName: aws.String("S"), UploadSequenceToken: aws.String("B")},
+				}}, nil
+			},
+		}
+		s := newSender(testutil.NewNopLogger(), service, new(mockTargetManager), time.Second, 0, make(chan struct{}))
+		batch := newLogEventBatch(Target{Group: "G", Stream: "S"}, nil)
+		batch.append(newLogEvent(time.Now(), "Test message", nil))
+		s.Send(batch)
+
+		assert.Equal(t, []string{"", "B"}, tokens)
+		assert.Equal(t, &cloudwatchlogs.DescribeLogStreamsInput{LogGroupName: aws.String("G"), LogStreamNamePrefix: aws.String("S")}, describeInput)
+	})
+
+	t.Run("ConcurrentBatches", func(t *testing.T) {
+		var mu sync.Mutex
+		var inFlight, maxInFlight, rejected int
+		next := 0
+		service := &stubLogsService{
+			ple: func(input *cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+				mu.Lock()
+				inFlight++
+				maxInFlight = max(maxInFlight, inFlight)
+				mu.Unlock()
+				time.Sleep(10 * time.Millisecond)
+				mu.Lock()
+				defer mu.Unlock()
+				inFlight--
+				if aws.StringValue(input.SequenceToken) != fmt.Sprint(next) {
+					rejected++
+					return nil, &cloudwatchlogs.InvalidSequenceTokenException{ExpectedSequenceToken: aws.String(fmt.Sprint(next))}
+				}
+				next++
+				return &cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String(fmt.Sprint(next))}, nil
+			},
+		}
+		s := newSender(testutil.NewNopLogger(), service, new(mockTargetManager), time.Second, 0, make(chan struct{}))
+		s.(*sender).sequenceToken.require(aws.String("0"))
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				batch := newLogEventBatch(Target{Group: "G", Stream: "S"}, nil)
+				batch.append(newLogEvent(time.Now(), "Test message", nil))
+				s.Send(batch)
+			}()
+		}
+		wg.Wait()
+		// the batches of the stream are sent one at a time with the token of the previous request
+		assert.Equal(t, 1, maxInFlight)
+		assert.Equal(t, 0, rejected)
+		assert.Equal(t, 5, next)
+	})
+
+	t.Run("ChangingToken", func(t *testing.T) {
+		var requests int
+		service := &stubLogsService{
+			ple: func(*cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+				requests++
+				// another writer to the stream keeps changing the token
+				return nil, &cloudwatchlogs.InvalidSequenceTokenException{ExpectedSequenceToken: aws.String(fmt.Sprint(requests))}
+			},
+		}
+		var done bool
+		s := newSender(testutil.NewNopLogger(), service, new(mockTargetManager), time.Second, 0, make(chan struct{}))
+		batch := newLogEventBatch(Target{Group: "G", Stream: "S"}, nil)
+		batch.append(newLogEvent(time.Now(), "Test message", nil))
+		batch.addDoneCallback(func() { done = true })
+		start := time.Now()
+		s.Send(batch)
+		// the retries back off until the retry duration is reached instead of retrying right away
+		assert.False(t, done)
+		assert.Less(t, requests, 10)
+		assert.Greater(t, time.Since(start), 200*time.Millisecond)
+	})
+
+	t.Run("ResourceNotFound", func(t *testing.T) {
+		var tokens []string
+		service := &stubLogsService{
+			ple: func(input *cloudwatchlogs.PutLogEventsInput) (*cloudwatchlogs.PutLogEventsOutput, error) {
+				tokens = append(tokens, aws.StringValue(input.SequenceToken))
+				switch len(tokens) {
+				case 1:
+					return nil, &cloudwatchlogs.InvalidSequenceTokenException{ExpectedSequenceToken: aws.String("1")}
+				case 3:
+					return nil, &cloudwatchlogs.ResourceNotFoundException{}
+				}
+				return &cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String("2")}, nil
+			},
+		}
+		mockManager := new(mockTargetManager)
+		mockManager.On("InitTarget", mock.Anything).Return(nil).Once()
+		s := newSender(testutil.NewNopLogger(), service, mockManager, time.Second, 0, make(chan struct{}))
+		for i := 0; i < 2; i++ {
+			batch := newLogEventBatch(Target{Group: "G", Stream: "S"}, nil)
+			batch.append(newLogEvent(time.Now(), "Test message", nil))
+			s.Send(batch)
+		}
+		// the recreated stream does not expect a token
+		assert.Equal(t, []string{"", "1", "2", ""}, tokens)
+		mockManager.AssertExpectations(t)
+	})
+}
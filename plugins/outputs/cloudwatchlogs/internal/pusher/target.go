@@ -5,6 +5,7 @@ package pusher
 
 import (
 	"fmt"
+	"strings"
 	"sync"
 	"time"
 
@@ -22,6 +23,10 @@ const (
 	baseRetryDelay      = 1 * time.Second
 	maxRetryDelayTarget = 10 * time.Second
 	numBackoffRetries   = 5
+	// minimum time between PutRetentionPolicy calls to stay under its quota of 5 TPS
+	retentionRequestInterval = 200 * time.Millisecond
+
+	logGroupARNPrefix = "arn:"
 )
 
 type Target struct {
@@ -29,11 +34,49 @@ type Target struct {
 	Retention            int
 }
 
+// hasLogGroupARN returns true if the group of the target is a log group ARN, such as a log group in another account
+// whose resource policy allows the agent to send to it. The ARN is sent as the logGroupIdentifier and only the
+// stream of the target is created.
+func (t Target) hasLogGroupARN() bool {
+	return strings.HasPrefix(t.Group, logGroupARNPrefix)
+}
+
+// logGroupIdentifier returns the ARN of the log group without the ":*" suffix of the ARN returned by
+// DescribeLogGroups.
+func (t Target) logGroupIdentifier() string {
+	return strings.TrimSuffix(t.Group, ":*")
+}
+
 type TargetManager interface {
 	InitTarget(target Target) error
 	PutRetentionPolicy(target Target)
 }
 
+type TargetManagerOption func(*targetManager)
+
+// WithoutRetentionReconciliation disables updating the retention of existing log groups that differs from the
+// configured retention, so the retention is only set on log groups created by the agent.
+func WithoutRetentionReconciliation() TargetManagerOption {
+	return func(m *targetManager) {
+		m.reconcileRetention = false
+	}
+}
+
+// WithRegion sets the region that the log group and stream creation requests are counted and limited for.
+func WithRegion(region string) TargetManagerOption {
+	return func(m *targetManager) {
+		m.region = region
+	}
+}
+
+// WithMaxCreateRequestsPerSecond limits the CreateLogGroup and CreateLogStream requests sent to the region. The limit
+// is shared by the target managers of the region.
+func WithMaxCreateRequestsPerSecond(requestsPerSecond int) TargetManagerOption {
+	return func(m *targetManager) {
+		m.maxCreateRequestsPerSecond = requestsPerSecond
+	}
+}
+
 type targetManager struct {
 	logger  telegraf.Logger
 	service cloudWatchLogsService
@@ -42,15 +85,39 @@ type targetManager struct {
 	mu    sync.Mutex
 	dlg   chan Target
 	prp   chan Target
+	// retention queued per log group. The retention is a property of the log group, so the streams that share
+	// a group only need it to be checked once.
+	groupRetention map[string]int
+	groupMu        sync.Mutex
+
+	reconcileRetention bool
+	// throttles the PutRetentionPolicy calls
+	retentionMu              sync.Mutex
+	retentionRequestInterval time.Duration
+	lastRetentionRequest     time.Time
+
+	region                     string
+	maxCreateRequestsPerSecond int
+	creation                   *regionCreation
 }
 
-func NewTargetManager(logger telegraf.Logger, service cloudWatchLogsService) TargetManager {
+func NewTargetManager(logger telegraf.Logger, service cloudWatchLogsService, opts ...TargetManagerOption) TargetManager {
 	tm := &targetManager{
-		logger:  logger,
-		service: service,
-		cache:   make(map[Target]struct{}),
-		dlg:     make(chan Target, retentionChannelSize),
-		prp:     make(chan Target, retentionChannelSize),
+		logger:                   logger,
+		service:                  service,
+		cache:                    make(map[Target]struct{}),
+		dlg:                      make(chan Target, retentionChannelSize),
+		prp:                      make(chan Target, retentionChannelSize),
+		groupRetention:           make(map[string]int),
+		reconcileRetention:       true,
+		retentionRequestInterval: retentionRequestInterval,
+	}
+	for _, opt := range opts {
+		opt(tm)
+	}
+	tm.creation = getRegionCreation(tm.region)
+	if tm.maxCreateRequestsPerSecond > 0 {
+		tm.creation.setLimit(tm.maxCreateRequestsPerSecond)
 	}
 
 	go tm.processDescribeLogGroup()
@@ -67,11 +134,12 @@ func (m *targetManager) InitTarget(target Target) error {
 		if err != nil {
 			return err
 		}
-		if target.Retention > 0 {
+		if target.Retention > 0 && !target.hasLogGroupARN() {
 			if newGroup {
 				m.logger.Debugf("sending new log group %v to prp channel", target.Group)
+				m.claimRetention(target)
 				m.prp <- target
-			} else {
+			} else if m.reconcileRetention && m.claimRetention(target) {
 				m.logger.Debugf("sending existing log group %v to dlg channel", target.Group)
 				m.dlg <- target
 			}
@@ -83,12 +151,24 @@ func (m *targetManager) InitTarget(target Target) error {
 
 func (m *targetManager) PutRetentionPolicy(target Target) {
 	// new pusher will call this so start with dlg
-	if target.Retention > 0 {
+	if target.Retention > 0 && m.reconcileRetention && !target.hasLogGroupARN() && m.claimRetention(target) {
 		m.logger.Debugf("sending log group %v to dlg channel by pusher", target.Group)
 		m.dlg <- target
 	}
 }
 
+// claimRetention records the retention for the target's log group. Returns false if the same retention has
+// already been queued for the log group by another stream.
+func (m *targetManager) claimRetention(target Target) bool {
+	m.groupMu.Lock()
+	defer m.groupMu.Unlock()
+	if retention, ok := m.groupRetention[target.Group]; ok && retention == target.Retention {
+		return false
+	}
+	m.groupRetention[target.Group] = target.Retention
+	return true
+}
+
 func (m *targetManager) createLogGroupAndStream(t Target) (bool, error) {
 	err := m.createLogStream(t)
 	if err == nil {
@@ -97,7 +177,9 @@ func (m *targetManager) createLogGroupAndStream(t Target) (bool, error) {
 
 	m.logger.Debugf("creating stream fail due to : %v", err)
 	newGroup := false
-	if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cloudwatchlogs.ErrCodeResourceNotFoundException {
+	if t.hasLogGroupARN() {
+		m.logger.Debugf("not creating log group %v identified by its ARN", t.Group)
+	} else if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == cloudwatchlogs.ErrCodeResourceNotFoundException {
 		err = m.createLogGroup(t)
 		newGroup = true
 
@@ -130,7 +212,9 @@ func (m *targetManager) createLogGroup(t Target) error {
 			LogGroupName: &t.Group,
 		}
 	}
+	m.creation.throttle()
 	_, err := m.service.CreateLogGroup(input)
+	m.creation.recordLogGroup(m.logger, err)
 	if err == nil {
 		m.logger.Debugf("successfully created log group %v", t.Group)
 		return nil
@@ -139,10 +223,17 @@ func (m *targetManager) createLogGroup(t Target) error {
 }
 
 func (m *targetManager) createLogStream(t Target) error {
-	_, err := m.service.CreateLogStream(&cloudwatchlogs.CreateLogStreamInput{
-		LogGroupName:  &t.Group,
+	input := &cloudwatchlogs.CreateLogStreamInput{
 		LogStreamName: &t.Stream,
-	})
+	}
+	if t.hasLogGroupARN() {
+		input.LogGroupIdentifier = aws.String(t.logGroupIdentifier())
+	} else {
+		input.LogGroupName = &t.Group
+	}
+	m.creation.throttle()
+	_, err := m.service.CreateLogStream(input)
+	m.creation.recordLogStream(m.logger, err)
 
 	if err == nil {
 		m.logger.Debugf("successfully created log stream %v", t.Stream)
@@ -213,6 +304,7 @@ func (m *targetManager) processPutRetentionPolicy() {
 }
 
 func (m *targetManager) updateRetentionPolicy(target Target) error {
+	m.throttleRetentionRequest()
 	input := &cloudwatchlogs.PutRetentionPolicyInput{
 		LogGroupName:    aws.String(target.Group),
 		RetentionInDays: aws.Int64(int64(target.Retention)),
@@ -226,6 +318,16 @@ func (m *targetManager) updateRetentionPolicy(target Target) error {
 	return nil
 }
 
+// throttleRetentionRequest blocks until the retention request interval has passed since the last retention request.
+func (m *targetManager) throttleRetentionRequest() {
+	m.retentionMu.Lock()
+	defer m.retentionMu.Unlock()
+	if wait := m.retentionRequestInterval - time.Since(m.lastRetentionRequest); wait > 0 {
+		time.Sleep(wait)
+	}
+	m.lastRetentionRequest = time.Now()
+}
+
 func (m *targetManager) calculateBackoff(retryCount int) time.Duration {
 	delay := baseRetryDelay
 	if retryCount < numBackoffRetries {
@@ -66,6 +66,35 @@ func TestTargetManager(t *testing.T) {
 		mockService.AssertExpectations(t)
 	})
 
+	t.Run("CreateLogStream/LogGroupARN", func(t *testing.T) {
+		target := Target{Group: "arn:aws:logs:us-east-1:123456789012:log-group:central", Stream: "S", Retention: 7}
+
+		mockService := new(mockLogsService)
+		mockService.On("CreateLogStream", mock.MatchedBy(func(input *cloudwatchlogs.CreateLogStreamInput) bool {
+			return input.LogGroupName == nil && *input.LogGroupIdentifier == target.Group && *input.LogStreamName == "S"
+		})).Return(&cloudwatchlogs.CreateLogStreamOutput{}, nil).Once()
+
+		manager := NewTargetManager(logger, mockService)
+		assert.NoError(t, manager.InitTarget(target))
+		manager.PutRetentionPolicy(target)
+		time.Sleep(100 * time.Millisecond)
+		mockService.AssertExpectations(t)
+		mockService.AssertNotCalled(t, "DescribeLogGroups", mock.Anything)
+	})
+
+	t.Run("CreateLogStream/LogGroupARN/NotFound", func(t *testing.T) {
+		target := Target{Group: "arn:aws:logs:us-east-1:123456789012:log-group:central", Stream: "S"}
+
+		mockService := new(mockLogsService)
+		mockService.On("CreateLogStream", mock.Anything).
+			Return(&cloudwatchlogs.CreateLogStreamOutput{}, awserr.New(cloudwatchlogs.ErrCodeResourceNotFoundException, "Log group not found", nil)).Once()
+
+		manager := NewTargetManager(logger, mockService)
+		assert.Error(t, manager.InitTarget(target))
+		mockService.AssertExpectations(t)
+		mockService.AssertNotCalled(t, "CreateLogGroup", mock.Anything)
+	})
+
 	t.Run("SetRetentionPolicy", func(t *testing.T) {
 		target := Target{Group: "G", Stream: "S", Retention: 7}
 
@@ -111,6 +140,74 @@ func TestTargetManager(t *testing.T) {
 		mockService.AssertNotCalled(t, "PutRetentionPolicy")
 	})
 
+	t.Run("SetRetentionPolicy/ReconcileDisabled", func(t *testing.T) {
+		target := Target{Group: "G", Stream: "S", Retention: 7}
+
+		mockService := new(mockLogsService)
+		mockService.On("CreateLogStream", mock.Anything).Return(&cloudwatchlogs.CreateLogStreamOutput{}, nil).Once()
+
+		manager := NewTargetManager(logger, mockService, WithoutRetentionReconciliation())
+		manager.PutRetentionPolicy(target)
+		err := manager.InitTarget(target)
+		assert.NoError(t, err)
+		time.Sleep(100 * time.Millisecond)
+		mockService.AssertExpectations(t)
+		mockService.AssertNotCalled(t, "DescribeLogGroups", mock.Anything)
+		mockService.AssertNotCalled(t, "PutRetentionPolicy", mock.Anything)
+	})
+
+	t.Run("SetRetentionPolicy/OnlyMismatched", func(t *testing.T) {
+		targets := []Target{
+			{Group: "G1", Stream: "S", Retention: 7},
+			{Group: "G2", Stream: "S", Retention: 7},
+		}
+
+		mockService := new(mockLogsService)
+		mockService.On("DescribeLogGroups", mock.MatchedBy(func(input *cloudwatchlogs.DescribeLogGroupsInput) bool {
+			return *input.LogGroupNamePrefix == "G1"
+		})).Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+			LogGroups: []*cloudwatchlogs.LogGroup{{LogGroupName: aws.String("G1"), RetentionInDays: aws.Int64(7)}},
+		}, nil).Once()
+		mockService.On("DescribeLogGroups", mock.MatchedBy(func(input *cloudwatchlogs.DescribeLogGroupsInput) bool {
+			return *input.LogGroupNamePrefix == "G2"
+		})).Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+			LogGroups: []*cloudwatchlogs.LogGroup{{LogGroupName: aws.String("G2"), RetentionInDays: aws.Int64(30)}},
+		}, nil).Once()
+		mockService.On("PutRetentionPolicy", mock.MatchedBy(func(input *cloudwatchlogs.PutRetentionPolicyInput) bool {
+			return *input.LogGroupName == "G2" && *input.RetentionInDays == 7
+		})).Return(&cloudwatchlogs.PutRetentionPolicyOutput{}, nil).Once()
+
+		manager := NewTargetManager(logger, mockService)
+		for _, target := range targets {
+			manager.PutRetentionPolicy(target)
+		}
+		time.Sleep(time.Second)
+		mockService.AssertExpectations(t)
+	})
+
+	t.Run("SetRetentionPolicy/SharedGroup", func(t *testing.T) {
+		targets := []Target{
+			{Group: "G", Stream: "S1", Retention: 7},
+			{Group: "G", Stream: "S2", Retention: 7},
+			{Group: "G", Stream: "S3", Retention: 7},
+		}
+
+		mockService := new(mockLogsService)
+		mockService.On("CreateLogStream", mock.Anything).Return(&cloudwatchlogs.CreateLogStreamOutput{}, nil).Times(len(targets))
+		mockService.On("DescribeLogGroups", mock.Anything).Return(&cloudwatchlogs.DescribeLogGroupsOutput{
+			LogGroups: []*cloudwatchlogs.LogGroup{{LogGroupName: aws.String("G"), RetentionInDays: aws.Int64(30)}},
+		}, nil).Once()
+		mockService.On("PutRetentionPolicy", mock.Anything).Return(&cloudwatchlogs.PutRetentionPolicyOutput{}, nil).Once()
+
+		manager := NewTargetManager(logger, mockService)
+		for _, target := range targets {
+			manager.PutRetentionPolicy(target)
+			assert.NoError(t, manager.InitTarget(target))
+		}
+		time.Sleep(500 * time.Millisecond)
+		mockService.AssertExpectations(t)
+	})
+
 	t.Run("SetRetentionPolicy/LogGroupNotFound", func(t *testing.T) {
 		t.Parallel()
 		target := Target{Group: "G", Stream: "S", Retention: 7}
@@ -252,6 +349,15 @@ func TestTargetManager(t *testing.T) {
 	})
 }
 
+func TestThrottleRetentionRequest(t *testing.T) {
+	manager := &targetManager{retentionRequestInterval: 50 * time.Millisecond}
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		manager.throttleRetentionRequest()
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 100*time.Millisecond)
+}
+
 func TestCalculateBackoff(t *testing.T) {
 	manager := &targetManager{}
 	// should never exceed 30sec of total wait time
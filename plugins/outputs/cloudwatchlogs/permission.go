@@ -0,0 +1,39 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatchlogs
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/permission"
+)
+
+// permissionCheckActions are the actions that the output makes for the log streams of existing log groups.
+var permissionCheckActions = []string{"logs:CreateLogStream", "logs:PutLogEvents"}
+
+// CheckPermissions simulates the requests that send the log events when validate_permissions is set, so that the agent
+// fails to start if the credentials of the output are not allowed to send them instead of dropping the log events
+// later. The simulation evaluates the policies of the credentials, so nothing is written to CloudWatch Logs.
+func (c *CloudWatchLogs) CheckPermissions() error {
+	if !c.ValidatePermissions {
+		return nil
+	}
+	return c.checkPermissions(permission.NewSimulator(c.credentials()))
+}
+
+func (c *CloudWatchLogs) checkPermissions(checker permission.Checker) error {
+	region := c.credentialConfig().Region
+	resource := "log groups"
+	// the log groups of the files are only known once they are collected, so any log group of the account is checked
+	resourceARN := fmt.Sprintf("arn:%s:logs:%s:%s:log-group:*", permission.PartitionPlaceholder, region, permission.AccountPlaceholder)
+	if c.destination != nil {
+		resource = fmt.Sprintf("log group %s", c.destination.logGroup)
+		resourceARN = fmt.Sprintf("arn:%s:logs:%s:%s:log-group:%s:*", permission.PartitionPlaceholder, region, c.destination.accountID, c.destination.logGroup)
+	}
+	if err := checker.Check(region, resource, resourceARN, permissionCheckActions, nil); err != nil {
+		return err
+	}
+	c.Log.Infof("Validated the permissions to send log events to %s in %s", resource, region)
+	return nil
+}
@@ -0,0 +1,65 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cloudwatchlogs
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/influxdata/telegraf/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type permissionCheck struct {
+	region      string
+	resource    string
+	resourceARN string
+	actions     []string
+}
+
+type stubChecker struct {
+	checks []permissionCheck
+	err    error
+}
+
+func (s *stubChecker) Check(region, resource, resourceARN string, actions []string, _ map[string]string) error {
+	s.checks = append(s.checks, permissionCheck{region: region, resource: resource, resourceARN: resourceARN, actions: actions})
+	return s.err
+}
+
+func TestCheckPermissions(t *testing.T) {
+	c := &CloudWatchLogs{Region: "us-east-1", Log: testutil.Logger{Name: "test"}}
+	// not validated unless enabled
+	require.NoError(t, c.CheckPermissions())
+
+	checker := &stubChecker{}
+	require.NoError(t, c.checkPermissions(checker))
+	assert.Equal(t, []permissionCheck{{
+		region:      "us-east-1",
+		resource:    "log groups",
+		resourceARN: "arn:{partition}:logs:us-east-1:{account}:log-group:*",
+		actions:     []string{"logs:CreateLogStream", "logs:PutLogEvents"},
+	}}, checker.checks)
+}
+
+func TestCheckPermissionsDestination(t *testing.T) {
+	c := &CloudWatchLogs{
+		Region:             "us-east-1",
+		DestinationARN:     "arn:aws:logs:us-west-2:123456789012:log-group:central",
+		DestinationRoleARN: "arn:aws:iam::123456789012:role/CloudWatchAgentLogs",
+		Log:                testutil.Logger{Name: "test"},
+	}
+	require.NoError(t, c.Init())
+	notAllowed := errors.New("not allowed")
+	checker := &stubChecker{err: notAllowed}
+
+	assert.ErrorIs(t, c.checkPermissions(checker), notAllowed)
+	assert.Equal(t, []permissionCheck{{
+		region:      "us-west-2",
+		resource:    "log group central",
+		resourceARN: "arn:{partition}:logs:us-west-2:123456789012:log-group:central:*",
+		actions:     []string{"logs:CreateLogStream", "logs:PutLogEvents"},
+	}}, checker.checks)
+}
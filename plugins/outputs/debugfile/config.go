@@ -0,0 +1,28 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package debugfile
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Config represent a configuration for the debug file exporter.
+type Config struct {
+	// Path is the file that the batches are appended to. The batches are written to stdout without a path.
+	Path string `mapstructure:"path,omitempty"`
+	// SampleRate writes one of every SampleRate batches, so that a busy pipeline does not flood the output.
+	SampleRate int `mapstructure:"sample_rate"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid.
+func (c *Config) Validate() error {
+	if c.SampleRate < 1 {
+		return errors.New("'sample_rate' must be at least 1")
+	}
+	return nil
+}
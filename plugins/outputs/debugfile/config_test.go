@@ -0,0 +1,52 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package debugfile
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	testCases := []struct {
+		id      component.ID
+		want    component.Config
+		wantErr string
+	}{
+		{
+			id:   component.NewID(TypeStr),
+			want: &Config{SampleRate: 1},
+		},
+		{
+			id:   component.NewIDWithName(TypeStr, "1"),
+			want: &Config{Path: "/tmp/agent-debug.json", SampleRate: 10},
+		},
+		{
+			id:      component.NewIDWithName(TypeStr, "invalid_sample_rate"),
+			wantErr: "'sample_rate' must be at least 1",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.id.String(), func(t *testing.T) {
+			conf, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := conf.Sub(testCase.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, component.ValidateConfig(cfg), testCase.wantErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, testCase.want, cfg)
+		})
+	}
+}
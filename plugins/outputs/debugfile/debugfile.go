@@ -0,0 +1,110 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package debugfile
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+type DebugFile struct {
+	config *Config
+	logger *zap.Logger
+
+	metricsMarshaler pmetric.Marshaler
+	logsMarshaler    plog.Marshaler
+
+	mu     sync.Mutex
+	writer io.Writer
+	file   *os.File
+	// batches is the number of batches consumed, of which one of every SampleRate is written.
+	batches int
+}
+
+func newDebugFile(config *Config, logger *zap.Logger) *DebugFile {
+	return &DebugFile{
+		config:           config,
+		logger:           logger,
+		metricsMarshaler: &pmetric.JSONMarshaler{},
+		logsMarshaler:    &plog.JSONMarshaler{},
+		writer:           os.Stdout,
+	}
+}
+
+func (d *DebugFile) Start(_ context.Context, _ component.Host) error {
+	if d.config.Path == "" {
+		return nil
+	}
+	file, err := os.OpenFile(d.config.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0600)
+	if err != nil {
+		return fmt.Errorf("unable to open the debug output file: %w", err)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.file = file
+	d.writer = file
+	return nil
+}
+
+func (d *DebugFile) Shutdown(context.Context) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.file == nil {
+		return nil
+	}
+	err := d.file.Close()
+	d.file = nil
+	d.writer = io.Discard
+	return err
+}
+
+// ConsumeMetrics writes the batch as a line of OTLP JSON.
+func (d *DebugFile) ConsumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	if !d.sample() {
+		return nil
+	}
+	buf, err := d.metricsMarshaler.MarshalMetrics(md)
+	if err != nil {
+		return err
+	}
+	return d.write(buf)
+}
+
+// ConsumeLogs writes the batch as a line of OTLP JSON.
+func (d *DebugFile) ConsumeLogs(_ context.Context, ld plog.Logs) error {
+	if !d.sample() {
+		return nil
+	}
+	buf, err := d.logsMarshaler.MarshalLogs(ld)
+	if err != nil {
+		return err
+	}
+	return d.write(buf)
+}
+
+// sample returns whether the batch is written. The first batch is always written.
+func (d *DebugFile) sample() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.batches++
+	return (d.batches-1)%d.config.SampleRate == 0
+}
+
+func (d *DebugFile) write(buf []byte) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if _, err := d.writer.Write(append(buf, '\n')); err != nil {
+		d.logger.Warn("Unable to write the debug output", zap.Error(err))
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package debugfile
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+var timestamp = pcommon.NewTimestampFromTime(time.Unix(1700000000, 0))
+
+func newMetrics(value float64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("host.name", "host")
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	m := ms.AppendEmpty()
+	m.SetName("mem_used_percent")
+	m.SetUnit("Percent")
+	dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("InstanceId", "i-0123456789abcdef0")
+	dp.SetTimestamp(timestamp)
+	dp.SetDoubleValue(value)
+	m = ms.AppendEmpty()
+	m.SetName("latency")
+	hdp := m.SetEmptyHistogram().DataPoints().AppendEmpty()
+	hdp.SetTimestamp(timestamp)
+	hdp.SetCount(2)
+	hdp.SetSum(value)
+	hdp.BucketCounts().FromRaw([]uint64{1, 1})
+	hdp.ExplicitBounds().FromRaw([]float64{10})
+	return md
+}
+
+func newLogs(body string) plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("host.name", "host")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetTimestamp(timestamp)
+	lr.Body().SetStr(body)
+	lr.Attributes().PutStr("log.file.name", "app.log")
+	return ld
+}
+
+func readLines(t *testing.T, path string) [][]byte {
+	t.Helper()
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var lines [][]byte
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	scanner.Buffer(nil, len(content)+1)
+	for scanner.Scan() {
+		lines = append(lines, append([]byte(nil), scanner.Bytes()...))
+	}
+	return lines
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "debug.json")
+	exp, err := NewFactory().CreateMetrics(context.Background(), exportertest.NewNopSettings(), &Config{Path: path, SampleRate: 1})
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	batches := []pmetric.Metrics{newMetrics(1), newMetrics(2)}
+	for _, md := range batches {
+		require.NoError(t, exp.ConsumeMetrics(context.Background(), md))
+	}
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	lines := readLines(t, path)
+	require.Len(t, lines, len(batches))
+	unmarshaler := &pmetric.JSONUnmarshaler{}
+	for i, line := range lines {
+		md, err := unmarshaler.UnmarshalMetrics(line)
+		require.NoError(t, err)
+		assert.Equal(t, batches[i], md)
+	}
+}
+
+func TestConsumeLogs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "debug.json")
+	// the file is appended to
+	require.NoError(t, os.WriteFile(path, []byte("{}\n"), 0600))
+	exp, err := NewFactory().CreateLogs(context.Background(), exportertest.NewNopSettings(), &Config{Path: path, SampleRate: 1})
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	ld := newLogs(`{"message":"request failed"}`)
+	require.NoError(t, exp.ConsumeLogs(context.Background(), ld))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	lines := readLines(t, path)
+	require.Len(t, lines, 2)
+	got, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(lines[1])
+	require.NoError(t, err)
+	assert.Equal(t, ld, got)
+}
+
+func TestSampleRate(t *testing.T) {
+	var buf bytes.Buffer
+	df := newDebugFile(&Config{SampleRate: 3}, nil)
+	df.writer = &buf
+	for i := 0; i < 7; i++ {
+		require.NoError(t, df.ConsumeMetrics(context.Background(), newMetrics(float64(i))))
+	}
+	lines := bytes.Split(bytes.TrimSuffix(buf.Bytes(), []byte("\n")), []byte("\n"))
+	require.Len(t, lines, 3)
+	unmarshaler := &pmetric.JSONUnmarshaler{}
+	for i, want := range []float64{0, 3, 6} {
+		md, err := unmarshaler.UnmarshalMetrics(lines[i])
+		require.NoError(t, err)
+		assert.Equal(t, newMetrics(want), md)
+	}
+}
+
+func TestStartWithInvalidPath(t *testing.T) {
+	df := newDebugFile(&Config{Path: filepath.Join(t.TempDir(), "missing", "debug.json"), SampleRate: 1}, nil)
+	assert.Error(t, df.Start(context.Background(), componenttest.NewNopHost()))
+}
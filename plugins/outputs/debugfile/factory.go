@@ -0,0 +1,73 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package debugfile provides an exporter for the OpenTelemetry collector that writes the metrics and logs of a
+// pipeline as JSON to stdout or a file, to see what the agent would send without sending it.
+package debugfile
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	stability         = component.StabilityLevelDevelopment
+	defaultSampleRate = 1
+)
+
+var (
+	TypeStr, _ = component.NewType("debugfile")
+)
+
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		TypeStr,
+		createDefaultConfig,
+		exporter.WithMetrics(createMetricsExporter, stability),
+		exporter.WithLogs(createLogsExporter, stability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		SampleRate: defaultSampleRate,
+	}
+}
+
+func createMetricsExporter(
+	ctx context.Context,
+	settings exporter.Settings,
+	config component.Config,
+) (exporter.Metrics, error) {
+	df := newDebugFile(config.(*Config), settings.Logger)
+	return exporterhelper.NewMetrics(
+		ctx,
+		settings,
+		config,
+		df.ConsumeMetrics,
+		exporterhelper.WithStart(df.Start),
+		exporterhelper.WithShutdown(df.Shutdown),
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+	)
+}
+
+func createLogsExporter(
+	ctx context.Context,
+	settings exporter.Settings,
+	config component.Config,
+) (exporter.Logs, error) {
+	df := newDebugFile(config.(*Config), settings.Logger)
+	return exporterhelper.NewLogs(
+		ctx,
+		settings,
+		config,
+		df.ConsumeLogs,
+		exporterhelper.WithStart(df.Start),
+		exporterhelper.WithShutdown(df.Shutdown),
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+	)
+}
@@ -0,0 +1,54 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package timestream
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configretry"
+)
+
+// Config represent a configuration for the Timestream metrics exporter.
+type Config struct {
+	Region                   string `mapstructure:"region"`
+	EndpointOverride         string `mapstructure:"endpoint_override,omitempty"`
+	AccessKey                string `mapstructure:"access_key,omitempty"`
+	SecretKey                string `mapstructure:"secret_key,omitempty"`
+	RoleARN                  string `mapstructure:"role_arn,omitempty"`
+	Profile                  string `mapstructure:"profile,omitempty"`
+	SharedCredentialFilename string `mapstructure:"shared_credential_file,omitempty"`
+	Token                    string `mapstructure:"token,omitempty"`
+
+	// DatabaseName and TableName are the table that the records are written to.
+	DatabaseName string `mapstructure:"database_name"`
+	TableName    string `mapstructure:"table_name"`
+	// MaxRecordsPerCall is the number of records in each WriteRecords request.
+	MaxRecordsPerCall int `mapstructure:"max_records_per_call"`
+
+	// BackOffConfig retries the requests that fail, except for the records rejected by Timestream.
+	BackOffConfig configretry.BackOffConfig `mapstructure:"retry_on_failure"`
+	// MiddlewareID is an ID for an extension that can be used to configure the AWS client.
+	MiddlewareID *component.ID `mapstructure:"middleware,omitempty"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+// Validate checks if the exporter configuration is valid.
+func (c *Config) Validate() error {
+	if c.Region == "" {
+		return errors.New("'region' must be set")
+	}
+	if c.DatabaseName == "" {
+		return errors.New("'database_name' must be set")
+	}
+	if c.TableName == "" {
+		return errors.New("'table_name' must be set")
+	}
+	if c.MaxRecordsPerCall < 1 || c.MaxRecordsPerCall > maxRecordsPerCall {
+		return fmt.Errorf("'max_records_per_call' must be between 1 and %d", maxRecordsPerCall)
+	}
+	return nil
+}
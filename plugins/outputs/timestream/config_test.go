@@ -0,0 +1,73 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package timestream
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	testCases := []struct {
+		id      component.ID
+		want    component.Config
+		wantErr string
+	}{
+		{
+			id: component.NewID(TypeStr),
+			want: &Config{
+				Region:            "us-west-2",
+				DatabaseName:      "metrics",
+				TableName:         "host",
+				MaxRecordsPerCall: maxRecordsPerCall,
+				BackOffConfig:     configretry.NewDefaultBackOffConfig(),
+			},
+		},
+		{
+			id: component.NewIDWithName(TypeStr, "1"),
+			want: func() component.Config {
+				backOffConfig := configretry.NewDefaultBackOffConfig()
+				backOffConfig.Enabled = false
+				return &Config{
+					Region:            "us-east-1",
+					DatabaseName:      "metrics",
+					TableName:         "containers",
+					MaxRecordsPerCall: 50,
+					BackOffConfig:     backOffConfig,
+				}
+			}(),
+		},
+		{
+			id:      component.NewIDWithName(TypeStr, "missing_table"),
+			wantErr: "'table_name' must be set",
+		},
+		{
+			id:      component.NewIDWithName(TypeStr, "invalid_max_records"),
+			wantErr: "'max_records_per_call' must be between 1 and 100",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.id.String(), func(t *testing.T) {
+			conf, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := conf.Sub(testCase.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, component.ValidateConfig(cfg), testCase.wantErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, testCase.want, cfg)
+		})
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package timestream
+
+import (
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/timestreamwrite"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// maxRecordsPerCall is the number of records that WriteRecords accepts in a request.
+const maxRecordsPerCall = 100
+
+const (
+	measureCount = "count"
+	measureSum   = "sum"
+	measureMin   = "min"
+	measureMax   = "max"
+)
+
+// ConvertOtelMetrics converts the data points into Timestream records. Gauges and sums are single measure records
+// named after the metric. Histograms and summaries are multi-measure records with their count, sum, min, max and
+// quantiles. The resource and data point attributes are the dimensions of the records, where the data point
+// attributes take precedence. Data points without a timestamp are recorded at now.
+func ConvertOtelMetrics(md pmetric.Metrics, now time.Time) []*timestreamwrite.Record {
+	var records []*timestreamwrite.Record
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceAttrs := rm.Resource().Attributes()
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				records = append(records, convertMetric(ms.At(k), resourceAttrs, now)...)
+			}
+		}
+	}
+	return records
+}
+
+func convertMetric(m pmetric.Metric, resourceAttrs pcommon.Map, now time.Time) []*timestreamwrite.Record {
+	var records []*timestreamwrite.Record
+	add := func(record *timestreamwrite.Record, attrs pcommon.Map, timestamp pcommon.Timestamp) {
+		if record == nil {
+			return
+		}
+		record.MeasureName = aws.String(m.Name())
+		record.Dimensions = buildDimensions(resourceAttrs, attrs)
+		record.Time = aws.String(strconv.FormatInt(recordTime(timestamp, now), 10))
+		record.TimeUnit = aws.String(timestreamwrite.TimeUnitNanoseconds)
+		records = append(records, record)
+	}
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			add(numberRecord(dp), dp.Attributes(), dp.Timestamp())
+		}
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			add(numberRecord(dp), dp.Attributes(), dp.Timestamp())
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			values := []*timestreamwrite.MeasureValue{bigintMeasure(measureCount, dp.Count())}
+			if dp.HasSum() {
+				values = appendDoubleMeasure(values, measureSum, dp.Sum())
+			}
+			if dp.HasMin() {
+				values = appendDoubleMeasure(values, measureMin, dp.Min())
+			}
+			if dp.HasMax() {
+				values = appendDoubleMeasure(values, measureMax, dp.Max())
+			}
+			add(multiRecord(values), dp.Attributes(), dp.Timestamp())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			values := []*timestreamwrite.MeasureValue{bigintMeasure(measureCount, dp.Count())}
+			if dp.HasSum() {
+				values = appendDoubleMeasure(values, measureSum, dp.Sum())
+			}
+			if dp.HasMin() {
+				values = appendDoubleMeasure(values, measureMin, dp.Min())
+			}
+			if dp.HasMax() {
+				values = appendDoubleMeasure(values, measureMax, dp.Max())
+			}
+			add(multiRecord(values), dp.Attributes(), dp.Timestamp())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			values := []*timestreamwrite.MeasureValue{bigintMeasure(measureCount, dp.Count())}
+			values = appendDoubleMeasure(values, measureSum, dp.Sum())
+			qvs := dp.QuantileValues()
+			for j := 0; j < qvs.Len(); j++ {
+				values = appendDoubleMeasure(values, quantileName(qvs.At(j).Quantile()), qvs.At(j).Value())
+			}
+			add(multiRecord(values), dp.Attributes(), dp.Timestamp())
+		}
+	}
+	return records
+}
+
+// numberRecord returns nil for the values that Timestream does not accept.
+func numberRecord(dp pmetric.NumberDataPoint) *timestreamwrite.Record {
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		return &timestreamwrite.Record{
+			MeasureValue:     aws.String(strconv.FormatInt(dp.IntValue(), 10)),
+			MeasureValueType: aws.String(timestreamwrite.MeasureValueTypeBigint),
+		}
+	case pmetric.NumberDataPointValueTypeDouble:
+		if !isValidDouble(dp.DoubleValue()) {
+			return nil
+		}
+		return &timestreamwrite.Record{
+			MeasureValue:     aws.String(formatDouble(dp.DoubleValue())),
+			MeasureValueType: aws.String(timestreamwrite.MeasureValueTypeDouble),
+		}
+	}
+	return nil
+}
+
+func multiRecord(values []*timestreamwrite.MeasureValue) *timestreamwrite.Record {
+	return &timestreamwrite.Record{
+		MeasureValues:    values,
+		MeasureValueType: aws.String(timestreamwrite.MeasureValueTypeMulti),
+	}
+}
+
+func bigintMeasure(name string, value uint64) *timestreamwrite.MeasureValue {
+	return &timestreamwrite.MeasureValue{
+		Name:  aws.String(name),
+		Type:  aws.String(timestreamwrite.MeasureValueTypeBigint),
+		Value: aws.String(strconv.FormatUint(value, 10)),
+	}
+}
+
+// appendDoubleMeasure leaves out the values that Timestream does not accept.
+func appendDoubleMeasure(values []*timestreamwrite.MeasureValue, name string, value float64) []*timestreamwrite.MeasureValue {
+	if !isValidDouble(value) {
+		return values
+	}
+	return append(values, &timestreamwrite.MeasureValue{
+		Name:  aws.String(name),
+		Type:  aws.String(timestreamwrite.MeasureValueTypeDouble),
+		Value: aws.String(formatDouble(value)),
+	})
+}
+
+// buildDimensions returns the attributes as dimensions sorted by name. Dimensions cannot have empty values, so the
+// attributes with an empty value are left out.
+func buildDimensions(resourceAttrs, attrs pcommon.Map) []*timestreamwrite.Dimension {
+	values := make(map[string]string, resourceAttrs.Len()+attrs.Len())
+	for _, m := range []pcommon.Map{resourceAttrs, attrs} {
+		m.Range(func(k string, v pcommon.Value) bool {
+			values[k] = v.AsString()
+			return true
+		})
+	}
+	names := make([]string, 0, len(values))
+	for name, value := range values {
+		if value != "" {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	dimensions := make([]*timestreamwrite.Dimension, 0, len(names))
+	for _, name := range names {
+		dimensions = append(dimensions, &timestreamwrite.Dimension{
+			Name:               aws.String(name),
+			Value:              aws.String(values[name]),
+			DimensionValueType: aws.String(timestreamwrite.DimensionValueTypeVarchar),
+		})
+	}
+	return dimensions
+}
+
+// quantileName names the measure of the quantile by its percentile, such as p99 for 0.99.
+func quantileName(quantile float64) string {
+	return "p" + strconv.FormatFloat(quantile*100, 'f', -1, 64)
+}
+
+func recordTime(timestamp pcommon.Timestamp, now time.Time) int64 {
+	if timestamp == 0 {
+		return now.UnixNano()
+	}
+	return int64(timestamp)
+}
+
+func formatDouble(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func isValidDouble(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
@@ -0,0 +1,88 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package timestream
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/timestreamwrite"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func measure(name, valueType, value string) *timestreamwrite.MeasureValue {
+	return &timestreamwrite.MeasureValue{Name: aws.String(name), Type: aws.String(valueType), Value: aws.String(value)}
+}
+
+func TestConvertOtelMetrics(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+
+	m := ms.AppendEmpty()
+	m.SetName("requests")
+	sum := m.SetEmptySum().DataPoints().AppendEmpty()
+	sum.SetIntValue(42)
+	// overrides the resource attribute
+	sum.Attributes().PutStr("service.name", "checkout-v2")
+
+	m = ms.AppendEmpty()
+	m.SetName("invalid")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(math.NaN())
+
+	m = ms.AppendEmpty()
+	m.SetName("latency")
+	histogram := m.SetEmptyHistogram().DataPoints().AppendEmpty()
+	histogram.SetTimestamp(pcommon.NewTimestampFromTime(testTime))
+	histogram.SetCount(3)
+	histogram.SetSum(4.5)
+	histogram.SetMin(0.5)
+	histogram.SetMax(3)
+
+	m = ms.AppendEmpty()
+	m.SetName("response_size")
+	summary := m.SetEmptySummary().DataPoints().AppendEmpty()
+	summary.SetTimestamp(pcommon.NewTimestampFromTime(testTime))
+	summary.SetCount(10)
+	summary.SetSum(2048)
+	qv := summary.QuantileValues().AppendEmpty()
+	qv.SetQuantile(0.5)
+	qv.SetValue(100)
+	qv = summary.QuantileValues().AppendEmpty()
+	qv.SetQuantile(0.999)
+	qv.SetValue(900)
+
+	records := ConvertOtelMetrics(md, testTime)
+	require.Len(t, records, 3)
+
+	assert.Equal(t, "requests", aws.StringValue(records[0].MeasureName))
+	assert.Equal(t, "42", aws.StringValue(records[0].MeasureValue))
+	assert.Equal(t, "BIGINT", aws.StringValue(records[0].MeasureValueType))
+	assert.Equal(t, "checkout-v2", aws.StringValue(records[0].Dimensions[0].Value))
+	// recorded at now without a timestamp
+	assert.Equal(t, "1704164645000000000", aws.StringValue(records[0].Time))
+
+	assert.Equal(t, "latency", aws.StringValue(records[1].MeasureName))
+	assert.Equal(t, "MULTI", aws.StringValue(records[1].MeasureValueType))
+	assert.Nil(t, records[1].MeasureValue)
+	assert.Equal(t, []*timestreamwrite.MeasureValue{
+		measure("count", "BIGINT", "3"),
+		measure("sum", "DOUBLE", "4.5"),
+		measure("min", "DOUBLE", "0.5"),
+		measure("max", "DOUBLE", "3"),
+	}, records[1].MeasureValues)
+
+	assert.Equal(t, "response_size", aws.StringValue(records[2].MeasureName))
+	assert.Equal(t, []*timestreamwrite.MeasureValue{
+		measure("count", "BIGINT", "10"),
+		measure("sum", "DOUBLE", "2048"),
+		measure("p50", "DOUBLE", "100"),
+		measure("p99.9", "DOUBLE", "900"),
+	}, records[2].MeasureValues)
+}
@@ -0,0 +1,57 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+// Package timestream provides a metric exporter for the OpenTelemetry collector that writes to Amazon Timestream.
+package timestream
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	stability = component.StabilityLevelAlpha
+)
+
+var (
+	TypeStr, _ = component.NewType("awstimestream")
+)
+
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		TypeStr,
+		createDefaultConfig,
+		exporter.WithMetrics(createMetricsExporter, stability),
+	)
+}
+
+func createDefaultConfig() component.Config {
+	return &Config{
+		MaxRecordsPerCall: maxRecordsPerCall,
+		BackOffConfig:     configretry.NewDefaultBackOffConfig(),
+	}
+}
+
+func createMetricsExporter(
+	ctx context.Context,
+	settings exporter.Settings,
+	config component.Config,
+) (exporter.Metrics, error) {
+	cfg := config.(*Config)
+	ts := &Timestream{
+		config: cfg,
+		logger: settings.Logger,
+	}
+	return exporterhelper.NewMetrics(
+		ctx,
+		settings,
+		config,
+		ts.ConsumeMetrics,
+		exporterhelper.WithStart(ts.Start),
+		exporterhelper.WithRetry(cfg.BackOffConfig),
+	)
+}
@@ -0,0 +1,119 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package timestream
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/amazon-contributing/opentelemetry-collector-contrib/extension/awsmiddleware"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/service/timestreamwrite"
+	"github.com/aws/aws-sdk-go/service/timestreamwrite/timestreamwriteiface"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	configaws "github.com/aws/amazon-cloudwatch-agent/cfg/aws"
+)
+
+// maxLoggedRejections is the number of rejected records of a request whose reason is logged.
+const maxLoggedRejections = 5
+
+// permanentErrorCodes are the errors that fail every retry of the request, so the records are dropped.
+var permanentErrorCodes = map[string]bool{
+	timestreamwrite.ErrCodeAccessDeniedException:     true,
+	timestreamwrite.ErrCodeResourceNotFoundException: true,
+	timestreamwrite.ErrCodeValidationException:       true,
+}
+
+type Timestream struct {
+	config *Config
+	logger *zap.Logger
+	svc    timestreamwriteiface.TimestreamWriteAPI
+}
+
+func (t *Timestream) Start(_ context.Context, host component.Host) error {
+	credentialConfig := &configaws.CredentialConfig{
+		Region:    t.config.Region,
+		AccessKey: t.config.AccessKey,
+		SecretKey: t.config.SecretKey,
+		RoleARN:   t.config.RoleARN,
+		Profile:   t.config.Profile,
+		Filename:  t.config.SharedCredentialFilename,
+		Token:     t.config.Token,
+	}
+	// the endpoint of the table is discovered by the client unless it is overridden
+	svc := timestreamwrite.New(
+		credentialConfig.Credentials(),
+		&aws.Config{
+			Endpoint: aws.String(t.config.EndpointOverride),
+			LogLevel: configaws.SDKLogLevel(),
+			Logger:   configaws.SDKLogger{},
+		})
+	if t.config.MiddlewareID != nil {
+		awsmiddleware.TryConfigure(t.logger, host, *t.config.MiddlewareID, awsmiddleware.SDKv1(&svc.Handlers))
+	}
+	t.svc = svc
+	return nil
+}
+
+// ConsumeMetrics writes the records of the metrics in batches of MaxRecordsPerCall. The whole request is retried
+// if a batch fails. Timestream ignores the records that are written again with the same value.
+func (t *Timestream) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	records := ConvertOtelMetrics(md, time.Now())
+	var errs []error
+	for start := 0; start < len(records); start += t.config.MaxRecordsPerCall {
+		end := min(start+t.config.MaxRecordsPerCall, len(records))
+		if err := t.writeRecords(ctx, records[start:end]); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// writeRecords writes a batch of records. The records rejected by Timestream, such as records with a timestamp out
+// of the retention of the table, are logged and dropped since they are rejected on every retry.
+func (t *Timestream) writeRecords(ctx context.Context, records []*timestreamwrite.Record) error {
+	_, err := t.svc.WriteRecordsWithContext(ctx, &timestreamwrite.WriteRecordsInput{
+		DatabaseName: aws.String(t.config.DatabaseName),
+		TableName:    aws.String(t.config.TableName),
+		Records:      records,
+	})
+	if err == nil {
+		return nil
+	}
+	var rejected *timestreamwrite.RejectedRecordsException
+	if errors.As(err, &rejected) {
+		t.logRejectedRecords(records, rejected.RejectedRecords)
+		return nil
+	}
+	var awsErr awserr.Error
+	if errors.As(err, &awsErr) && permanentErrorCodes[awsErr.Code()] {
+		t.logger.Error("Dropped records that cannot be written to Timestream", zap.Int("records", len(records)), zap.Error(err))
+		return consumererror.NewPermanent(err)
+	}
+	return err
+}
+
+func (t *Timestream) logRejectedRecords(records []*timestreamwrite.Record, rejected []*timestreamwrite.RejectedRecord) {
+	for i, r := range rejected {
+		if i == maxLoggedRejections {
+			break
+		}
+		var measureName string
+		if index := int(aws.Int64Value(r.RecordIndex)); index >= 0 && index < len(records) {
+			measureName = aws.StringValue(records[index].MeasureName)
+		}
+		t.logger.Warn("Timestream rejected record",
+			zap.String("measure_name", measureName),
+			zap.String("reason", aws.StringValue(r.Reason)))
+	}
+	t.logger.Warn("Dropped records rejected by Timestream",
+		zap.Int("rejected", len(rejected)),
+		zap.Int("records", len(records)))
+}
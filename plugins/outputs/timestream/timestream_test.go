@@ -0,0 +1,142 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package timestream
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/request"
+	"github.com/aws/aws-sdk-go/service/timestreamwrite"
+	"github.com/aws/aws-sdk-go/service/timestreamwrite/timestreamwriteiface"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var testTime = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+type mockTimestreamWrite struct {
+	timestreamwriteiface.TimestreamWriteAPI
+	inputs []*timestreamwrite.WriteRecordsInput
+	errs   []error
+}
+
+func (m *mockTimestreamWrite) WriteRecordsWithContext(_ aws.Context, input *timestreamwrite.WriteRecordsInput, _ ...request.Option) (*timestreamwrite.WriteRecordsOutput, error) {
+	m.inputs = append(m.inputs, input)
+	if len(m.errs) > 0 {
+		err := m.errs[0]
+		m.errs = m.errs[1:]
+		return nil, err
+	}
+	return &timestreamwrite.WriteRecordsOutput{}, nil
+}
+
+func newTestTimestream(svc timestreamwriteiface.TimestreamWriteAPI, logger *zap.Logger) *Timestream {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Region = "us-west-2"
+	cfg.DatabaseName = "metrics"
+	cfg.TableName = "host"
+	return &Timestream{config: cfg, logger: logger, svc: svc}
+}
+
+func newGaugeMetrics(n int) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("host.name", "ip-10-0-0-1")
+	rm.Resource().Attributes().PutStr("cloud.region", "us-west-2")
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("cpu_usage_idle")
+	dps := m.SetEmptyGauge().DataPoints()
+	for i := 0; i < n; i++ {
+		dp := dps.AppendEmpty()
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(testTime))
+		dp.SetDoubleValue(99.5)
+		dp.Attributes().PutStr("cpu", fmt.Sprintf("cpu%d", i))
+		// empty values cannot be dimensions
+		dp.Attributes().PutStr("empty", "")
+	}
+	return md
+}
+
+func TestConsumeMetrics(t *testing.T) {
+	svc := &mockTimestreamWrite{}
+	ts := newTestTimestream(svc, zap.NewNop())
+
+	require.NoError(t, ts.ConsumeMetrics(context.Background(), newGaugeMetrics(1)))
+	require.Len(t, svc.inputs, 1)
+	assert.Equal(t, &timestreamwrite.WriteRecordsInput{
+		DatabaseName: aws.String("metrics"),
+		TableName:    aws.String("host"),
+		Records: []*timestreamwrite.Record{
+			{
+				Dimensions: []*timestreamwrite.Dimension{
+					{Name: aws.String("cloud.region"), Value: aws.String("us-west-2"), DimensionValueType: aws.String("VARCHAR")},
+					{Name: aws.String("cpu"), Value: aws.String("cpu0"), DimensionValueType: aws.String("VARCHAR")},
+					{Name: aws.String("host.name"), Value: aws.String("ip-10-0-0-1"), DimensionValueType: aws.String("VARCHAR")},
+				},
+				MeasureName:      aws.String("cpu_usage_idle"),
+				MeasureValue:     aws.String("99.5"),
+				MeasureValueType: aws.String("DOUBLE"),
+				Time:             aws.String("1704164645000000000"),
+				TimeUnit:         aws.String("NANOSECONDS"),
+			},
+		},
+	}, svc.inputs[0])
+}
+
+func TestConsumeMetricsBatches(t *testing.T) {
+	svc := &mockTimestreamWrite{}
+	ts := newTestTimestream(svc, zap.NewNop())
+
+	require.NoError(t, ts.ConsumeMetrics(context.Background(), newGaugeMetrics(250)))
+	require.Len(t, svc.inputs, 3)
+	assert.Len(t, svc.inputs[0].Records, 100)
+	assert.Len(t, svc.inputs[1].Records, 100)
+	assert.Len(t, svc.inputs[2].Records, 50)
+	assert.Equal(t, "cpu249", aws.StringValue(svc.inputs[2].Records[49].Dimensions[1].Value))
+}
+
+func TestConsumeMetricsRejectedRecords(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	svc := &mockTimestreamWrite{errs: []error{
+		&timestreamwrite.RejectedRecordsException{
+			Message_: aws.String("One or more records have been rejected."),
+			RejectedRecords: []*timestreamwrite.RejectedRecord{
+				{RecordIndex: aws.Int64(1), Reason: aws.String("The record timestamp is outside the time range of the data ingestion window.")},
+			},
+		},
+	}}
+	ts := newTestTimestream(svc, zap.New(core))
+
+	// the rejected records are dropped and the other batches are still written
+	require.NoError(t, ts.ConsumeMetrics(context.Background(), newGaugeMetrics(150)))
+	assert.Len(t, svc.inputs, 2)
+	require.Len(t, logs.All(), 2)
+	assert.Equal(t, "cpu_usage_idle", logs.All()[0].ContextMap()["measure_name"])
+	assert.Equal(t, int64(1), logs.All()[1].ContextMap()["rejected"])
+}
+
+func TestConsumeMetricsError(t *testing.T) {
+	svc := &mockTimestreamWrite{errs: []error{
+		awserr.New(timestreamwrite.ErrCodeThrottlingException, "rate exceeded", nil),
+		awserr.New(timestreamwrite.ErrCodeResourceNotFoundException, "table not found", nil),
+	}}
+	ts := newTestTimestream(svc, zap.NewNop())
+
+	err := ts.ConsumeMetrics(context.Background(), newGaugeMetrics(1))
+	assert.Error(t, err)
+	assert.False(t, consumererror.IsPermanent(err))
+
+	err = ts.ConsumeMetrics(context.Background(), newGaugeMetrics(1))
+	assert.True(t, consumererror.IsPermanent(err))
+}
@@ -9,11 +9,21 @@ import (
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/processors/k8sdecorator"
 
 	// Enabled cloudwatch-agent input plugins
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/docker_logs"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/kubelet_summary"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/kubernetes_logs"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/log_staleness"
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/logfile"
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/nvidia_smi"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/pipeline_errors"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/pressure"
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/prometheus"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/spot_interruption"
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/statsd"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/syslog_logs"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/tcp_state"
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/win_perf_counters"
+	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/windows_etw"
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/windows_event_log"
 
 	// Enabled cloudwatch-agent output plugins
@@ -15,6 +15,9 @@ type Config struct {
 	Resolvers []Resolver     `mapstructure:"resolvers"`
 	Rules     []rules.Rule   `mapstructure:"rules"`
 	Limiter   *LimiterConfig `mapstructure:"limiter"`
+	// DropEmptyMetrics removes the metrics left without data points after the
+	// rules are applied, along with the scopes and resources that are left empty.
+	DropEmptyMetrics bool `mapstructure:"drop_empty_metrics"`
 }
 
 type LimiterConfig struct {
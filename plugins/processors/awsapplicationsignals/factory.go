@@ -38,7 +38,8 @@ func NewFactory() processor.Factory {
 
 func createDefaultConfig() component.Config {
 	return &appsignalsconfig.Config{
-		Resolvers: []appsignalsconfig.Resolver{},
+		Resolvers:        []appsignalsconfig.Resolver{},
+		DropEmptyMetrics: true,
 	}
 }
 
@@ -80,8 +80,9 @@ func TestLoadEKSConfig(t *testing.T) {
 		{
 			name: "awsapplicationsignals",
 			expected: &config.Config{
-				Resolvers: []config.Resolver{config.NewEKSResolver("test")},
-				Rules:     expectedRules,
+				Resolvers:        []config.Resolver{config.NewEKSResolver("test")},
+				Rules:            expectedRules,
+				DropEmptyMetrics: true,
 			},
 		},
 	}
@@ -123,8 +124,9 @@ func TestLoadGenericConfig(t *testing.T) {
 		{
 			name: "awsapplicationsignals",
 			expected: &config.Config{
-				Resolvers: []config.Resolver{config.NewGenericResolver("")},
-				Rules:     expectedRules,
+				Resolvers:        []config.Resolver{config.NewGenericResolver("")},
+				Rules:            expectedRules,
+				DropEmptyMetrics: true,
 			},
 		},
 	}
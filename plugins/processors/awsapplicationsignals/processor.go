@@ -15,6 +15,7 @@ import (
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
 
+	"github.com/aws/amazon-cloudwatch-agent/internal/metric"
 	appsignalsconfig "github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/config"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/cardinalitycontrol"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals/internal/metrichandlers"
@@ -150,6 +151,9 @@ func (ap *awsapplicationsignalsprocessor) processMetrics(ctx context.Context, md
 			}
 		}
 	}
+	if ap.config.DropEmptyMetrics {
+		metric.RemoveEmptyMetrics(md)
+	}
 	return md, nil
 }
 
@@ -104,6 +104,40 @@ func TestProcessMetrics(t *testing.T) {
 	assert.True(t, isMetricNil(dropMetricsByKeep))
 }
 
+func TestProcessMetricsDropEmptyMetrics(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	ap := &awsapplicationsignalsprocessor{
+		logger: logger,
+		config: &config.Config{
+			Resolvers:        []config.Resolver{config.NewGenericResolver("")},
+			Rules:            testRules,
+			DropEmptyMetrics: true,
+		},
+	}
+
+	ctx := context.Background()
+	ap.StartMetrics(ctx, nil)
+
+	dropMetrics := generateMetrics(map[string]string{
+		"dim_action":       "reserved",
+		"dim_drop":         "hc",
+		"Telemetry.Source": "UnitTest",
+	})
+	got, err := ap.processMetrics(ctx, dropMetrics)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, got.ResourceMetrics().Len())
+
+	keepMetrics := generateMetrics(map[string]string{
+		"dim_action":       "reserved",
+		"dim_val":          "test",
+		"Telemetry.Source": "UnitTest",
+	})
+	want := keepMetrics.MetricCount()
+	got, err = ap.processMetrics(ctx, keepMetrics)
+	assert.NoError(t, err)
+	assert.Equal(t, want, got.MetricCount())
+}
+
 func TestProcessMetricsLowercase(t *testing.T) {
 	logger, _ := zap.NewDevelopment()
 	ap := &awsapplicationsignalsprocessor{
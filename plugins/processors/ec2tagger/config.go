@@ -11,6 +11,8 @@ import (
 
 var SupportedAppendDimensions = map[string]string{
 	"AutoScalingGroupName": "${aws:AutoScalingGroupName}",
+	"AvailabilityZone":     "${aws:AvailabilityZone}",
+	"AvailabilityZoneId":   "${aws:AvailabilityZoneId}",
 	"ImageId":              "${aws:ImageId}",
 	"InstanceId":           "${aws:InstanceId}",
 	"InstanceType":         "${aws:InstanceType}",
@@ -19,6 +21,9 @@ var SupportedAppendDimensions = map[string]string{
 const (
 	AttributeVolumeId            = "VolumeId"
 	ValueAppendDimensionVolumeId = "${aws:VolumeId}"
+	// AttributeKeepHost is set by receivers whose host dimension is not the agent host, such as collectd with
+	// use_packet_hostname, so that the host dimension is kept when append_dimensions are applied.
+	AttributeKeepHost = "aws:KeepHost"
 )
 
 type Config struct {
@@ -23,7 +23,7 @@ const sampleConfig = `
   # refresh_interval_seconds = 60
   ##
   ## Add tags for EC2 Metadata fields.
-  ## Supported fields are: "InstanceId", "ImageId" (aka AMI), "InstanceType"
+  ## Supported fields are: "InstanceId", "ImageId" (aka AMI), "InstanceType", "AvailabilityZone", "AvailabilityZoneId"
   ## If the configuration is not provided or it has an empty list, no EC2 Metadata tags are applied.
   # ec2_metadata_tags = ["InstanceId", "ImageId", "InstanceType"]
   ##
@@ -65,6 +65,8 @@ const (
 	mdKeyInstanceId      = "InstanceId"
 	mdKeyImageId         = "ImageId"
 	mdKeyInstanceType    = "InstanceType"
+	mdKeyAZ              = "AvailabilityZone"
+	mdKeyAZID            = "AvailabilityZoneId"
 )
 
 var (
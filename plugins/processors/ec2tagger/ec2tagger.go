@@ -29,12 +29,16 @@ type ec2MetadataLookupType struct {
 	instanceId   bool
 	imageId      bool
 	instanceType bool
+	az           bool
+	azID         bool
 }
 
 type ec2MetadataRespondType struct {
 	instanceId   string
 	imageId      string // aka AMI
 	instanceType string
+	az           string
+	azID         string
 	region       string
 }
 
@@ -147,6 +151,12 @@ func (t *Tagger) updateOtelAttributes(attributes []pcommon.Map) {
 		if t.ec2MetadataLookup.instanceType {
 			attr.PutStr(mdKeyInstanceType, t.ec2MetadataRespond.instanceType)
 		}
+		if t.ec2MetadataLookup.az {
+			attr.PutStr(mdKeyAZ, t.ec2MetadataRespond.az)
+		}
+		if t.ec2MetadataLookup.azID && t.ec2MetadataRespond.azID != "" {
+			attr.PutStr(mdKeyAZID, t.ec2MetadataRespond.azID)
+		}
 		if t.volumeSerialCache != nil {
 			if devName, found := attr.Get(t.DiskDeviceTagKey); found {
 				serial := t.volumeSerialCache.Serial(devName.Str())
@@ -155,8 +165,12 @@ func (t *Tagger) updateOtelAttributes(attributes []pcommon.Map) {
 				}
 			}
 		}
-		// If append_dimensions are applied, then remove the host dimension.
-		attr.Remove("host")
+		// If append_dimensions are applied, then remove the host dimension, unless the receiver asked to keep it.
+		if _, ok := attr.Get(AttributeKeepHost); ok {
+			attr.Remove(AttributeKeepHost)
+		} else {
+			attr.Remove("host")
+		}
 	}
 }
 
@@ -397,7 +411,7 @@ func (t *Tagger) setStarted() {
 
 /*
 Retrieve metadata from IMDS and use these metadata to:
-* Extract InstanceID, ImageID, InstanceType to create custom dimension for collected metrics
+* Extract InstanceID, ImageID, InstanceType, AvailabilityZone and its ID to create custom dimension for collected metrics
 * Extract InstanceID to retrieve Instance's Volume and Tags
 * Extract Region to create aws session with custom configuration
 For more information on IMDS, please follow this document https://docs.aws.amazon.com/AWSEC2/latest/UserGuide/ec2-instance-metadata.html
@@ -411,6 +425,10 @@ func (t *Tagger) deriveEC2MetadataFromIMDS(ctx context.Context) error {
 			t.ec2MetadataLookup.imageId = true
 		case mdKeyInstanceType:
 			t.ec2MetadataLookup.instanceType = true
+		case mdKeyAZ:
+			t.ec2MetadataLookup.az = true
+		case mdKeyAZID:
+			t.ec2MetadataLookup.azID = true
 		default:
 			t.logger.Error("ec2tagger: Unsupported EC2 Metadata key", zap.String("mdKey", tag))
 		}
@@ -434,6 +452,18 @@ func (t *Tagger) deriveEC2MetadataFromIMDS(ctx context.Context) error {
 	if t.ec2MetadataLookup.instanceType {
 		t.ec2MetadataRespond.instanceType = doc.InstanceType
 	}
+	if t.ec2MetadataLookup.az {
+		t.ec2MetadataRespond.az = doc.AvailabilityZone
+	}
+	if t.ec2MetadataLookup.azID {
+		// the AZ ID is not part of the instance identity document
+		azID, err := t.metadataProvider.AvailabilityZoneID(ctx)
+		if err != nil {
+			t.logger.Warn("ec2tagger: Unable to retrieve the availability zone ID from EC2 Metadata", zap.Error(err))
+		} else {
+			t.ec2MetadataRespond.azID = azID
+		}
+	}
 
 	return nil
 }
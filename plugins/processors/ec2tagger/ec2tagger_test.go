@@ -127,6 +127,7 @@ var (
 
 type mockMetadataProvider struct {
 	InstanceIdentityDocument *ec2metadata.EC2InstanceIdentityDocument
+	AZID                     string
 }
 
 func (m *mockMetadataProvider) Get(ctx context.Context) (ec2metadata.EC2InstanceIdentityDocument, error) {
@@ -144,6 +145,13 @@ func (m *mockMetadataProvider) InstanceID(ctx context.Context) (string, error) {
 	return "MockInstanceID", nil
 }
 
+func (m *mockMetadataProvider) AvailabilityZoneID(ctx context.Context) (string, error) {
+	if m.AZID == "" {
+		return "", errors.New("no availability zone id")
+	}
+	return m.AZID, nil
+}
+
 func (m *mockMetadataProvider) InstanceTags(_ context.Context) ([]string, error) {
 	return []string{"MockInstanceTag"}, nil
 }
@@ -157,10 +165,11 @@ func (m *mockMetadataProvider) ClientIAMRole(ctx context.Context) (string, error
 }
 
 var mockedInstanceIdentityDoc = &ec2metadata.EC2InstanceIdentityDocument{
-	InstanceID:   "i-01d2417c27a396e44",
-	Region:       "us-east-1",
-	InstanceType: "m5ad.large",
-	ImageID:      "ami-09edd32d9b0990d49",
+	InstanceID:       "i-01d2417c27a396e44",
+	Region:           "us-east-1",
+	InstanceType:     "m5ad.large",
+	ImageID:          "ami-09edd32d9b0990d49",
+	AvailabilityZone: "us-east-1a",
 }
 
 type mockVolumeCache struct {
@@ -654,3 +663,72 @@ func TestTaggerStartsWithoutTagOrVolume(t *testing.T) {
 	assert.Equal(t, tagger.started, true)
 	close(inited)
 }
+
+func TestApplyWithAvailabilityZone(t *testing.T) {
+	testCases := map[string]struct {
+		azID string
+		want map[string]string
+	}{
+		"WithAZID": {
+			azID: "use1-az1",
+			want: map[string]string{
+				"InstanceId":         "i-01d2417c27a396e44",
+				"AvailabilityZone":   "us-east-1a",
+				"AvailabilityZoneId": "use1-az1",
+			},
+		},
+		// the AZ ID is skipped if it cannot be retrieved
+		"WithoutAZID": {
+			want: map[string]string{
+				"InstanceId":       "i-01d2417c27a396e44",
+				"AvailabilityZone": "us-east-1a",
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.EC2MetadataTags = []string{mdKeyInstanceId, mdKeyAZ, mdKeyAZID}
+			_, cancel := context.WithCancel(context.Background())
+			tagger := &Tagger{
+				Config:     cfg,
+				logger:     processortest.NewNopSettings().Logger,
+				cancelFunc: cancel,
+				metadataProvider: &mockMetadataProvider{
+					InstanceIdentityDocument: mockedInstanceIdentityDoc,
+					AZID:                     testCase.azID,
+				},
+				volumeSerialCache: &mockVolumeCache{cache: make(map[string]string)},
+			}
+			require.NoError(t, tagger.Start(context.Background(), componenttest.NewNopHost()))
+			md := createTestMetrics([]map[string]string{{"host": "example.org"}})
+			output, err := tagger.processMetrics(context.Background(), md)
+			require.NoError(t, err)
+			checkAttributes(t, createTestMetrics([]map[string]string{testCase.want}), output)
+		})
+	}
+}
+
+func TestApplyWithKeepHost(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.EC2MetadataTags = []string{mdKeyInstanceId}
+	_, cancel := context.WithCancel(context.Background())
+	tagger := &Tagger{
+		Config:            cfg,
+		logger:            processortest.NewNopSettings().Logger,
+		cancelFunc:        cancel,
+		metadataProvider:  &mockMetadataProvider{InstanceIdentityDocument: mockedInstanceIdentityDoc},
+		volumeSerialCache: &mockVolumeCache{cache: make(map[string]string)},
+	}
+	require.NoError(t, tagger.Start(context.Background(), componenttest.NewNopHost()))
+	md := createTestMetrics([]map[string]string{
+		{"host": "web-1", AttributeKeepHost: "true"},
+		{"host": "example.org"},
+	})
+	output, err := tagger.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	checkAttributes(t, createTestMetrics([]map[string]string{
+		{"host": "web-1", "InstanceId": "i-01d2417c27a396e44"},
+		{"InstanceId": "i-01d2417c27a396e44"},
+	}), output)
+}
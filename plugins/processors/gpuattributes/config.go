@@ -4,10 +4,38 @@
 package gpuattributes
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
 	"go.opentelemetry.io/collector/component"
 )
 
-type Config struct{}
+type Config struct {
+	// NodeAggregates are the node GPU metrics, e.g. node_gpu_utilization, whose average and maximum across the GPU
+	// devices of the node are added with the node schema.
+	NodeAggregates []string `mapstructure:"node_aggregates,omitempty"`
+	// DropDeviceMetrics drops the per-device datapoints of the NodeAggregates metrics, so only the aggregates are
+	// published.
+	DropDeviceMetrics bool `mapstructure:"drop_device_metrics,omitempty"`
+	// XidEvents adds a node_gpu_xid_event with the description of the error when the XID error of a GPU device
+	// changes, which is sent to CloudWatch Logs as a log event.
+	XidEvents bool `mapstructure:"xid_events,omitempty"`
+	// RepackLabels moves the pod labels that are set as discrete label_<name> attributes into the labels of the
+	// kubernetes attribute, so they have the same shape as the labels of the other pods.
+	RepackLabels bool `mapstructure:"repack_labels,omitempty"`
+	// ParallelProcessing processes the resources of a batch concurrently, which reduces the time to process the large
+	// batches of nodes with many GPU devices.
+	ParallelProcessing bool `mapstructure:"parallel_processing,omitempty"`
+	// Workers is the number of resources processed at the same time when ParallelProcessing is set. Defaults to the
+	// number of CPUs.
+	Workers int `mapstructure:"workers,omitempty"`
+	// SupportedVersions are the exporter or driver versions, from the Version attribute of the GPU metrics, whose
+	// metrics have the expected semantics. A version is also supported if it starts with one of them followed by a
+	// dot, e.g. 535.104.05 when 535 is supported. When set, the GPU metrics are tagged with whether their version is
+	// supported, and a node_gpu_unsupported_version datapoint is added for each node with an unsupported version.
+	SupportedVersions []string `mapstructure:"supported_versions,omitempty"`
+}
 
 // Verify Config implements Processor interface.
 var _ component.Config = (*Config)(nil)
@@ -15,5 +43,18 @@ var _ component.Config = (*Config)(nil)
 // Validate does not check for unsupported dimension key-value pairs, because those
 // get silently dropped and ignored during translation.
 func (cfg *Config) Validate() error {
+	for _, name := range cfg.NodeAggregates {
+		if !strings.HasPrefix(name, nodeMetricPrefix) || !strings.Contains(name, gpuMetricIdentifier) {
+			return fmt.Errorf("node_aggregates metric %q is not a node GPU metric", name)
+		}
+	}
+	for _, version := range cfg.SupportedVersions {
+		if version == "" {
+			return errors.New("supported_versions must not have an empty version")
+		}
+	}
+	if cfg.Workers < 0 {
+		return fmt.Errorf("workers must not be negative: %d", cfg.Workers)
+	}
 	return nil
 }
@@ -16,3 +16,17 @@ func TestUnmarshalDefaultConfig(t *testing.T) {
 	assert.NoError(t, confmap.New().Unmarshal(cfg))
 	assert.Equal(t, factory.CreateDefaultConfig(), cfg)
 }
+
+func TestValidateConfig(t *testing.T) {
+	cfg := &Config{NodeAggregates: []string{"node_gpu_utilization", "node_gpu_memory_utilization"}}
+	assert.NoError(t, cfg.Validate())
+
+	cfg = &Config{NodeAggregates: []string{"pod_gpu_utilization"}}
+	assert.EqualError(t, cfg.Validate(), `node_aggregates metric "pod_gpu_utilization" is not a node GPU metric`)
+
+	cfg = &Config{ParallelProcessing: true, Workers: -1}
+	assert.EqualError(t, cfg.Validate(), "workers must not be negative: -1")
+
+	cfg = &Config{SupportedVersions: []string{"535", ""}}
+	assert.EqualError(t, cfg.Validate(), "supported_versions must not have an empty version")
+}
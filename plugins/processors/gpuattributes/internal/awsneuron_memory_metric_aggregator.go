@@ -26,13 +26,14 @@ type NeuronCoreInfo struct {
 
 type AwsNeuronMemoryMetricsAggregator struct {
 	memoryMetricValuesAggregator     map[NeuronCoreInfo]float64
+	memoryMetricExemplars            map[NeuronCoreInfo]pmetric.ExemplarSlice
 	aggregatedMemoryMetricAttributes pcommon.Map
 	metricTimestamp                  pcommon.Timestamp
 	MemoryMetricsFound               bool
 }
 
 func NewMemoryMemoryAggregator() *AwsNeuronMemoryMetricsAggregator {
-	return &AwsNeuronMemoryMetricsAggregator{memoryMetricValuesAggregator: map[NeuronCoreInfo]float64{}, MemoryMetricsFound: false}
+	return &AwsNeuronMemoryMetricsAggregator{memoryMetricValuesAggregator: map[NeuronCoreInfo]float64{}, memoryMetricExemplars: map[NeuronCoreInfo]pmetric.ExemplarSlice{}, MemoryMetricsFound: false}
 }
 
 func (d *AwsNeuronMemoryMetricsAggregator) AggregateMemoryMetric(originalMetric pmetric.Metric) {
@@ -60,6 +61,15 @@ func (d *AwsNeuronMemoryMetricsAggregator) AggregateMemoryMetric(originalMetric
 		if neuronCoreIndexValueExists && neuronDeviceIndexValueExists && runtimeTagExists {
 			neuronCoreInfo := NeuronCoreInfo{neuronCoreIndex: neuronCoreIndexValue.AsString(), neuronDeviceIndex: neuronDeviceIndexValue.AsString(), runtimeTag: runtimeTagValue.AsString()}
 			d.memoryMetricValuesAggregator[neuronCoreInfo] += datapoint.DoubleValue()
+			// keep the exemplars of each datapoint that is part of the total
+			if datapoint.Exemplars().Len() > 0 {
+				exemplars, ok := d.memoryMetricExemplars[neuronCoreInfo]
+				if !ok {
+					exemplars = pmetric.NewExemplarSlice()
+					d.memoryMetricExemplars[neuronCoreInfo] = exemplars
+				}
+				appendExemplars(exemplars, datapoint.Exemplars())
+			}
 		}
 	}
 
@@ -79,6 +89,9 @@ func (d *AwsNeuronMemoryMetricsAggregator) FlushAggregatedMemoryMetric() pmetric
 		datapoint.Attributes().PutStr(NeuronDeviceAttributeKey, neuronCoreInfo.neuronDeviceIndex)
 		datapoint.Attributes().PutStr(RuntimeTag, neuronCoreInfo.runtimeTag)
 		datapoint.SetTimestamp(d.metricTimestamp)
+		if exemplars, ok := d.memoryMetricExemplars[neuronCoreInfo]; ok {
+			exemplars.MoveAndAppendTo(datapoint.Exemplars())
+		}
 	}
 
 	// Reset the aggregator
@@ -88,5 +101,14 @@ func (d *AwsNeuronMemoryMetricsAggregator) FlushAggregatedMemoryMetric() pmetric
 
 func (d *AwsNeuronMemoryMetricsAggregator) resetMemoryMetricAggregator() {
 	d.memoryMetricValuesAggregator = map[NeuronCoreInfo]float64{}
+	d.memoryMetricExemplars = map[NeuronCoreInfo]pmetric.ExemplarSlice{}
 	d.MemoryMetricsFound = false
 }
+
+// appendExemplars copies the exemplars to the end of dest so that aggregated datapoints can still be correlated
+// with the traces of the datapoints they were aggregated from.
+func appendExemplars(dest, src pmetric.ExemplarSlice) {
+	for i := 0; i < src.Len(); i++ {
+		src.At(i).CopyTo(dest.AppendEmpty())
+	}
+}
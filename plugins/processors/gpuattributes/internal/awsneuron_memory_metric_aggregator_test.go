@@ -98,6 +98,52 @@ func TestMemoryMetricAggregator_FlushAggregatedMemoryMetric(t *testing.T) {
 	}
 }
 
+func TestMemoryMetricAggregator_FlushKeepsExemplars(t *testing.T) {
+	aggregator := NewMemoryMemoryAggregator()
+	tensorsMemoryUsage := createSampleMetric(containerinsightscommon.NeuronCoreMemoryUtilizationTensors)
+	addExemplar(tensorsMemoryUsage.Gauge().DataPoints().At(0), 1)
+	constantsMemoryUsage := createSampleMetric(containerinsightscommon.NeuronCoreMemoryUtilizationConstants)
+	addExemplar(constantsMemoryUsage.Gauge().DataPoints().At(0), 2)
+
+	aggregator.AggregateMemoryMetric(tensorsMemoryUsage)
+	aggregator.AggregateMemoryMetric(constantsMemoryUsage)
+	aggregatedMetric := aggregator.FlushAggregatedMemoryMetric()
+
+	exemplarsByCore := map[string][]pcommon.TraceID{}
+	for i := 0; i < aggregatedMetric.Sum().DataPoints().Len(); i++ {
+		datapoint := aggregatedMetric.Sum().DataPoints().At(i)
+		core, _ := datapoint.Attributes().Get(NeuronCoreAttributeKey)
+		exemplarsByCore[core.Str()] = traceIDs(datapoint.Exemplars())
+	}
+	assert.Equal(t, map[string][]pcommon.TraceID{
+		"0": {{1}, {2}},
+		"2": nil,
+	}, exemplarsByCore)
+
+	// exemplars are not carried over to the next flush
+	aggregator.AggregateMemoryMetric(createSampleMetric(containerinsightscommon.NeuronCoreMemoryUtilizationTensors))
+	aggregatedMetric = aggregator.FlushAggregatedMemoryMetric()
+	for i := 0; i < aggregatedMetric.Sum().DataPoints().Len(); i++ {
+		assert.Equal(t, 0, aggregatedMetric.Sum().DataPoints().At(i).Exemplars().Len())
+	}
+}
+
+func addExemplar(datapoint pmetric.NumberDataPoint, traceID byte) {
+	exemplar := datapoint.Exemplars().AppendEmpty()
+	exemplar.SetTraceID(pcommon.TraceID{traceID})
+	exemplar.SetSpanID(pcommon.SpanID{traceID})
+	exemplar.SetDoubleValue(datapoint.DoubleValue())
+	exemplar.SetTimestamp(staticTimestamp)
+}
+
+func traceIDs(exemplars pmetric.ExemplarSlice) []pcommon.TraceID {
+	var ids []pcommon.TraceID
+	for i := 0; i < exemplars.Len(); i++ {
+		ids = append(ids, exemplars.At(i).TraceID())
+	}
+	return ids
+}
+
 func createSampleMetric(metricName string) pmetric.Metric {
 	metric := pmetric.NewMetric()
 	metric.SetName(metricName)
@@ -208,6 +208,7 @@ func (md *AwsNeuronMetricModifier) extractDatapointsAsMetricsAndAggregate(origin
 	originalMetricDatapoints := originalMetric.Sum().DataPoints()
 
 	aggregatedValuesPerRuntimeTag := map[MetricDatapointAggregationKey]float64{}
+	aggregatedExemplarsPerRuntimeTag := map[MetricDatapointAggregationKey]pmetric.ExemplarSlice{}
 	uniqueAttributeToAggregatedMetricMappings, needsAggregation := uniquesDatapointsToAggregatedMetricMappings[originalMetric.Name()]
 	for i := 0; i < originalMetricDatapoints.Len(); i++ {
 		originalDatapoint := originalMetricDatapoints.At(i)
@@ -218,7 +219,14 @@ func (md *AwsNeuronMetricModifier) extractDatapointsAsMetricsAndAggregate(origin
 		// only add to the aggregation map if the datapoint to aggregated metric mappings are defined for the original metric
 		if needsAggregation {
 			aggregatedMetricName := uniqueAttributeToAggregatedMetricMappings[uniqueAttributeValue.Str()]
-			aggregatedValuesPerRuntimeTag[MetricDatapointAggregationKey{runtimeTag: runtimeTag.Str(), aggregatedMetricName: aggregatedMetricName, deviceId: deviceId.Str()}] += originalDatapoint.DoubleValue()
+			aggregationKey := MetricDatapointAggregationKey{runtimeTag: runtimeTag.Str(), aggregatedMetricName: aggregatedMetricName, deviceId: deviceId.Str()}
+			aggregatedValuesPerRuntimeTag[aggregationKey] += originalDatapoint.DoubleValue()
+			exemplars, ok := aggregatedExemplarsPerRuntimeTag[aggregationKey]
+			if !ok {
+				exemplars = pmetric.NewExemplarSlice()
+				aggregatedExemplarsPerRuntimeTag[aggregationKey] = exemplars
+			}
+			appendExemplars(exemplars, originalDatapoint.Exemplars())
 		}
 
 		// Creating a new metric from the current datapoint and adding it to the new newMetricSlice
@@ -234,6 +242,8 @@ func (md *AwsNeuronMetricModifier) extractDatapointsAsMetricsAndAggregate(origin
 
 		originalMetricDatapoints.At(0).CopyTo(aggregatedMetric.SetEmptySum().DataPoints().AppendEmpty())
 		aggregatedMetric.Sum().DataPoints().At(0).SetDoubleValue(value)
+		// replace the exemplars of the first datapoint with the ones of all the aggregated datapoints
+		aggregatedExemplarsPerRuntimeTag[aggregatedMetricMetadata].CopyTo(aggregatedMetric.Sum().DataPoints().At(0).Exemplars())
 		aggregatedMetric.Sum().DataPoints().At(0).Attributes().PutStr(RuntimeTag, aggregatedMetricMetadata.runtimeTag)
 
 		if aggregatedMetricMetadata.deviceId != "" {
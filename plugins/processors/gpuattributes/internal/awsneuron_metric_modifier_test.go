@@ -100,6 +100,27 @@ func TestMetricModifierForExecutionErrorMetric(t *testing.T) {
 	assertModifiedMetric(t, metricsList, expectedMetrics)
 }
 
+func TestMetricModifierKeepsExemplars(t *testing.T) {
+	metricModifier := setupMetricModifier()
+	metricsList := pmetric.NewMetricSlice()
+	executionErrors := createActualMetricForKey(NeuronExecutionErrors)
+	addExemplar(executionErrors.Sum().DataPoints().At(0), 1)
+	addExemplar(executionErrors.Sum().DataPoints().At(2), 3)
+	executionErrors.CopyTo(metricsList.AppendEmpty())
+	metricModifier.ModifyMetric(metricsList.At(0), metricsList)
+
+	exemplarsByMetric := map[string][]pcommon.TraceID{}
+	for i := 0; i < metricsList.Len(); i++ {
+		metric := metricsList.At(i)
+		exemplarsByMetric[metric.Name()] = traceIDs(metric.Sum().DataPoints().At(0).Exemplars())
+	}
+	assert.Equal(t, []pcommon.TraceID{{1}}, exemplarsByMetric["node_neuron_execution_errors_generic"])
+	assert.Equal(t, []pcommon.TraceID{{3}}, exemplarsByMetric["node_neuron_execution_errors_transient"])
+	assert.Nil(t, exemplarsByMetric["node_neuron_execution_errors_numerical"])
+	// the total has the exemplars of all the datapoints it is aggregated from
+	assert.Equal(t, []pcommon.TraceID{{1}, {3}}, exemplarsByMetric["node_neuron_execution_errors_total"])
+}
+
 func TestMetricModifierForExecutionStatusMetric(t *testing.T) {
 	metricModifier := setupMetricModifier()
 	metricsList := pmetric.NewMetricSlice()
@@ -0,0 +1,190 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/containerinsightscommon"
+)
+
+const (
+	NodeAggregateAvgSuffix = "_avg"
+	NodeAggregateMaxSuffix = "_max"
+)
+
+// deviceAttributes are the attributes that are dropped from the node aggregates, so the aggregates have the node
+// schema.
+var deviceAttributes = map[string]struct{}{
+	containerinsightscommon.GpuDeviceKey: {},
+	containerinsightscommon.MigDeviceKey: {},
+}
+
+// GpuNodeMetricAggregator adds the average and maximum of node GPU metrics across the GPU devices of the node, e.g.
+// node_gpu_utilization_avg and node_gpu_utilization_max from node_gpu_utilization. Each MIG instance is a device of its
+// own. The per-device datapoints are kept unless they are dropped.
+type GpuNodeMetricAggregator struct {
+	metricNames       map[string]struct{}
+	dropDeviceMetrics bool
+}
+
+type nodeAggregate struct {
+	attributes pcommon.Map
+	timestamp  pcommon.Timestamp
+	sum        float64
+	max        float64
+	count      int
+}
+
+func NewGpuNodeMetricAggregator(metricNames []string, dropDeviceMetrics bool) *GpuNodeMetricAggregator {
+	a := &GpuNodeMetricAggregator{metricNames: make(map[string]struct{}, len(metricNames)), dropDeviceMetrics: dropDeviceMetrics}
+	for _, name := range metricNames {
+		a.metricNames[name] = struct{}{}
+	}
+	return a
+}
+
+// AggregateNodeMetrics appends the node aggregates of the configured metrics to the metrics.
+func (a *GpuNodeMetricAggregator) AggregateNodeMetrics(metrics pmetric.MetricSlice) {
+	if len(a.metricNames) == 0 {
+		return
+	}
+	metricsLength := metrics.Len()
+	for i := 0; i < metricsLength; i++ {
+		m := metrics.At(i)
+		if _, ok := a.metricNames[m.Name()]; !ok {
+			continue
+		}
+		var dps pmetric.NumberDataPointSlice
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			dps = m.Gauge().DataPoints()
+		case pmetric.MetricTypeSum:
+			dps = m.Sum().DataPoints()
+		default:
+			continue
+		}
+		aggregates := aggregateDevices(dps)
+		if len(aggregates) == 0 {
+			continue
+		}
+		avgMetric := newNodeAggregateMetric(m, NodeAggregateAvgSuffix)
+		maxMetric := newNodeAggregateMetric(m, NodeAggregateMaxSuffix)
+		for _, agg := range aggregates {
+			agg.copyTo(avgMetric.Gauge().DataPoints().AppendEmpty(), agg.sum/float64(agg.count))
+			agg.copyTo(maxMetric.Gauge().DataPoints().AppendEmpty(), agg.max)
+		}
+		avgMetric.MoveTo(metrics.AppendEmpty())
+		maxMetric.MoveTo(metrics.AppendEmpty())
+		if a.dropDeviceMetrics {
+			dps.RemoveIf(isDeviceDatapoint)
+		}
+	}
+	if a.dropDeviceMetrics {
+		metrics.RemoveIf(func(m pmetric.Metric) bool {
+			_, ok := a.metricNames[m.Name()]
+			return ok && isEmptyNumberMetric(m)
+		})
+	}
+}
+
+// aggregateDevices groups the per-device datapoints by their attributes other than the devices, in the order that the
+// groups are first seen.
+func aggregateDevices(dps pmetric.NumberDataPointSlice) []*nodeAggregate {
+	var aggregates []*nodeAggregate
+	groups := map[string]*nodeAggregate{}
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		if !isDeviceDatapoint(dp) {
+			continue
+		}
+		var value float64
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeDouble:
+			value = dp.DoubleValue()
+		case pmetric.NumberDataPointValueTypeInt:
+			value = float64(dp.IntValue())
+		default:
+			continue
+		}
+		if math.IsNaN(value) {
+			continue
+		}
+		key := nodeKey(dp.Attributes())
+		agg, ok := groups[key]
+		if !ok {
+			agg = &nodeAggregate{attributes: pcommon.NewMap(), max: value}
+			dp.Attributes().CopyTo(agg.attributes)
+			agg.attributes.RemoveIf(func(k string, _ pcommon.Value) bool {
+				_, ok := deviceAttributes[k]
+				return ok
+			})
+			groups[key] = agg
+			aggregates = append(aggregates, agg)
+		}
+		agg.sum += value
+		agg.max = math.Max(agg.max, value)
+		agg.count++
+		if dp.Timestamp() > agg.timestamp {
+			agg.timestamp = dp.Timestamp()
+		}
+	}
+	return aggregates
+}
+
+func (agg *nodeAggregate) copyTo(dp pmetric.NumberDataPoint, value float64) {
+	agg.attributes.CopyTo(dp.Attributes())
+	dp.SetTimestamp(agg.timestamp)
+	dp.SetDoubleValue(value)
+}
+
+func newNodeAggregateMetric(m pmetric.Metric, suffix string) pmetric.Metric {
+	aggregate := pmetric.NewMetric()
+	aggregate.SetName(m.Name() + suffix)
+	aggregate.SetDescription(m.Description())
+	aggregate.SetUnit(m.Unit())
+	aggregate.SetEmptyGauge()
+	return aggregate
+}
+
+// nodeKey is the attributes of the datapoint other than the devices, in key order.
+func nodeKey(attributes pcommon.Map) string {
+	keys := make([]string, 0, attributes.Len())
+	attributes.Range(func(k string, _ pcommon.Value) bool {
+		if _, ok := deviceAttributes[k]; !ok {
+			keys = append(keys, k)
+		}
+		return true
+	})
+	sort.Strings(keys)
+	var sb strings.Builder
+	for _, k := range keys {
+		v, _ := attributes.Get(k)
+		sb.WriteString(k)
+		sb.WriteByte(0)
+		sb.WriteString(v.AsString())
+		sb.WriteByte(0)
+	}
+	return sb.String()
+}
+
+func isDeviceDatapoint(dp pmetric.NumberDataPoint) bool {
+	_, ok := dp.Attributes().Get(containerinsightscommon.GpuDeviceKey)
+	return ok
+}
+
+func isEmptyNumberMetric(m pmetric.Metric) bool {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		return m.Gauge().DataPoints().Len() == 0
+	case pmetric.MetricTypeSum:
+		return m.Sum().DataPoints().Len() == 0
+	}
+	return false
+}
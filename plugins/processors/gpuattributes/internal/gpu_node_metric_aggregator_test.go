@@ -0,0 +1,111 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func createDeviceMetrics(name string, nodes map[string][]float64) pmetric.MetricSlice {
+	metrics := pmetric.NewMetricSlice()
+	m := metrics.AppendEmpty()
+	m.SetName(name)
+	m.SetUnit("Percent")
+	dps := m.SetEmptyGauge().DataPoints()
+	for _, node := range []string{"node-1", "node-2"} {
+		for i, value := range nodes[node] {
+			dp := dps.AppendEmpty()
+			dp.SetTimestamp(pcommon.Timestamp(100 + i))
+			dp.SetDoubleValue(value)
+			dp.Attributes().PutStr("ClusterName", "cluster")
+			dp.Attributes().PutStr("NodeName", node)
+			dp.Attributes().PutStr("GpuDevice", "nvidia"+string(rune('0'+i)))
+		}
+	}
+	return metrics
+}
+
+func datapointsOf(t *testing.T, metrics pmetric.MetricSlice, name string) []map[string]any {
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		if m.Name() != name {
+			continue
+		}
+		var result []map[string]any
+		dps := m.Gauge().DataPoints()
+		for j := 0; j < dps.Len(); j++ {
+			attrs := dps.At(j).Attributes().AsRaw()
+			attrs["value"] = dps.At(j).DoubleValue()
+			attrs["timestamp"] = uint64(dps.At(j).Timestamp())
+			result = append(result, attrs)
+		}
+		return result
+	}
+	require.Failf(t, "metric not found", name)
+	return nil
+}
+
+func TestGpuNodeMetricAggregator(t *testing.T) {
+	metrics := createDeviceMetrics("node_gpu_utilization", map[string][]float64{
+		"node-1": {20, 40, 90},
+		"node-2": {50},
+	})
+	metrics.At(0).CopyTo(metrics.AppendEmpty())
+	metrics.At(1).SetName("node_gpu_memory_used")
+	NewGpuNodeMetricAggregator([]string{"node_gpu_utilization"}, false).AggregateNodeMetrics(metrics)
+
+	require.Equal(t, 4, metrics.Len())
+	assert.Equal(t, "node_gpu_memory_used", metrics.At(1).Name())
+	// the per-device datapoints are kept
+	assert.Equal(t, 4, metrics.At(0).Gauge().DataPoints().Len())
+	assert.Equal(t, []map[string]any{
+		{"ClusterName": "cluster", "NodeName": "node-1", "value": float64(50), "timestamp": uint64(102)},
+		{"ClusterName": "cluster", "NodeName": "node-2", "value": float64(50), "timestamp": uint64(100)},
+	}, datapointsOf(t, metrics, "node_gpu_utilization_avg"))
+	assert.Equal(t, []map[string]any{
+		{"ClusterName": "cluster", "NodeName": "node-1", "value": float64(90), "timestamp": uint64(102)},
+		{"ClusterName": "cluster", "NodeName": "node-2", "value": float64(50), "timestamp": uint64(100)},
+	}, datapointsOf(t, metrics, "node_gpu_utilization_max"))
+	assert.Equal(t, "Percent", metrics.At(2).Unit())
+	assert.Equal(t, pmetric.MetricTypeGauge, metrics.At(3).Type())
+}
+
+func TestGpuNodeMetricAggregatorDropDeviceMetrics(t *testing.T) {
+	metrics := createDeviceMetrics("node_gpu_utilization", map[string][]float64{
+		"node-1": {20, 40},
+	})
+	// datapoints without a device are not aggregated and are kept
+	dp := metrics.At(0).Gauge().DataPoints().AppendEmpty()
+	dp.SetDoubleValue(100)
+	dp.Attributes().PutStr("ClusterName", "cluster")
+	NewGpuNodeMetricAggregator([]string{"node_gpu_utilization"}, true).AggregateNodeMetrics(metrics)
+
+	require.Equal(t, 3, metrics.Len())
+	require.Equal(t, 1, metrics.At(0).Gauge().DataPoints().Len())
+	assert.Equal(t, map[string]any{"ClusterName": "cluster"}, metrics.At(0).Gauge().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, float64(30), datapointsOf(t, metrics, "node_gpu_utilization_avg")[0]["value"])
+
+	// the metric is removed once it has no datapoints left
+	metrics = createDeviceMetrics("node_gpu_utilization", map[string][]float64{
+		"node-1": {20, 40},
+	})
+	NewGpuNodeMetricAggregator([]string{"node_gpu_utilization"}, true).AggregateNodeMetrics(metrics)
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, "node_gpu_utilization_avg", metrics.At(0).Name())
+	assert.Equal(t, "node_gpu_utilization_max", metrics.At(1).Name())
+}
+
+func TestGpuNodeMetricAggregatorWithoutMetrics(t *testing.T) {
+	metrics := createDeviceMetrics("node_gpu_utilization", map[string][]float64{
+		"node-1": {20, 40},
+	})
+	NewGpuNodeMetricAggregator(nil, true).AggregateNodeMetrics(metrics)
+	require.Equal(t, 1, metrics.Len())
+	assert.Equal(t, 2, metrics.At(0).Gauge().DataPoints().Len())
+}
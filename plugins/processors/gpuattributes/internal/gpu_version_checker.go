@@ -0,0 +1,117 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/containerinsightscommon"
+)
+
+const (
+	GpuUnsupportedVersionMetric = "node_gpu_unsupported_version"
+
+	VersionSupportedKey = "VersionSupported"
+
+	gpuMetricIdentifier = "_gpu_"
+)
+
+// nodeAttributes are the attributes of the GPU datapoints that are copied to the node_gpu_unsupported_version
+// datapoints, so they have the node schema.
+var nodeAttributes = []string{
+	containerinsightscommon.ClusterNameKey,
+	containerinsightscommon.InstanceIdKey,
+	containerinsightscommon.InstanceTypeKey,
+	containerinsightscommon.NodeNameKey,
+	containerinsightscommon.VersionKey,
+}
+
+// GpuVersionChecker tags the GPU datapoints with whether the exporter or driver version in their Version attribute is
+// supported. The metrics of other versions may have different semantics, so a node_gpu_unsupported_version datapoint
+// is added for each node and version that is not supported.
+type GpuVersionChecker struct {
+	supportedVersions []string
+}
+
+func NewGpuVersionChecker(supportedVersions []string) *GpuVersionChecker {
+	return &GpuVersionChecker{supportedVersions: supportedVersions}
+}
+
+// IsSupported returns true if the version is one of the supported versions or a more specific version of one of them,
+// e.g. 535.104.05 when 535 is supported.
+func (c *GpuVersionChecker) IsSupported(version string) bool {
+	for _, supported := range c.supportedVersions {
+		if version == supported || strings.HasPrefix(version, supported+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// CheckVersions tags the datapoints of the GPU metrics that have a version and appends the
+// node_gpu_unsupported_version metric to the metrics if any of the versions is not supported.
+func (c *GpuVersionChecker) CheckVersions(metrics pmetric.MetricSlice) {
+	warnings := pmetric.NewMetric()
+	warnings.SetName(GpuUnsupportedVersionMetric)
+	warningDps := warnings.SetEmptyGauge().DataPoints()
+	warned := map[string]struct{}{}
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		if !strings.Contains(m.Name(), gpuMetricIdentifier) {
+			continue
+		}
+		var dps pmetric.NumberDataPointSlice
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			dps = m.Gauge().DataPoints()
+		case pmetric.MetricTypeSum:
+			dps = m.Sum().DataPoints()
+		default:
+			continue
+		}
+		for j := 0; j < dps.Len(); j++ {
+			dp := dps.At(j)
+			version, ok := dp.Attributes().Get(containerinsightscommon.VersionKey)
+			if !ok || version.AsString() == "" {
+				continue
+			}
+			supported := c.IsSupported(version.AsString())
+			dp.Attributes().PutBool(VersionSupportedKey, supported)
+			if supported {
+				continue
+			}
+			key := nodeVersionKey(dp)
+			if _, ok := warned[key]; ok {
+				continue
+			}
+			warned[key] = struct{}{}
+			warning := warningDps.AppendEmpty()
+			for _, k := range nodeAttributes {
+				if v, ok := dp.Attributes().Get(k); ok {
+					v.CopyTo(warning.Attributes().PutEmpty(k))
+				}
+			}
+			warning.Attributes().PutStr(containerinsightscommon.MetricType, containerinsightscommon.TypeGpuNode)
+			warning.SetTimestamp(dp.Timestamp())
+			warning.SetIntValue(1)
+		}
+	}
+	if warningDps.Len() > 0 {
+		warnings.MoveTo(metrics.AppendEmpty())
+	}
+}
+
+// nodeVersionKey identifies the node and version of the datapoint.
+func nodeVersionKey(dp pmetric.NumberDataPoint) string {
+	var sb strings.Builder
+	for _, k := range nodeAttributes {
+		if v, ok := dp.Attributes().Get(k); ok {
+			sb.WriteString(v.AsString())
+		}
+		sb.WriteByte(0)
+	}
+	return sb.String()
+}
@@ -0,0 +1,110 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestGpuVersionCheckerIsSupported(t *testing.T) {
+	c := NewGpuVersionChecker([]string{"535", "550.54.15"})
+	for version, want := range map[string]bool{
+		"535":       true,
+		"535.104":   true,
+		"535.104.5": true,
+		"5351":      false,
+		"550.54.15": true,
+		"550.54.14": false,
+		"550":       false,
+		"470.82.01": false,
+	} {
+		assert.Equal(t, want, c.IsSupported(version), version)
+	}
+}
+
+// createVersionMetrics creates a GPU metric with a datapoint for each node and version.
+func createVersionMetrics(name string, versions map[string]string) pmetric.MetricSlice {
+	metrics := pmetric.NewMetricSlice()
+	m := metrics.AppendEmpty()
+	m.SetName(name)
+	dps := m.SetEmptyGauge().DataPoints()
+	for _, device := range []string{"nvidia0", "nvidia1"} {
+		for node, version := range versions {
+			dp := dps.AppendEmpty()
+			dp.SetTimestamp(pcommon.Timestamp(1))
+			dp.SetDoubleValue(50)
+			dp.Attributes().PutStr("ClusterName", "cluster")
+			dp.Attributes().PutStr("InstanceId", "i-"+node)
+			dp.Attributes().PutStr("NodeName", node)
+			dp.Attributes().PutStr("GpuDevice", device)
+			dp.Attributes().PutStr("Type", "NodeGPU")
+			if version != "" {
+				dp.Attributes().PutStr("Version", version)
+			}
+		}
+	}
+	return metrics
+}
+
+func TestGpuVersionCheckerCheckVersions(t *testing.T) {
+	c := NewGpuVersionChecker([]string{"535"})
+	metrics := createVersionMetrics("node_gpu_utilization", map[string]string{
+		"supported":   "535.104.05",
+		"unsupported": "470.82.01",
+		"unknown":     "",
+	})
+	c.CheckVersions(metrics)
+
+	assert.Equal(t, 2, metrics.Len())
+	dps := metrics.At(0).Gauge().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		attributes := dps.At(i).Attributes().AsRaw()
+		switch attributes["NodeName"] {
+		case "supported":
+			assert.Equal(t, true, attributes["VersionSupported"])
+		case "unsupported":
+			assert.Equal(t, false, attributes["VersionSupported"])
+		default:
+			assert.NotContains(t, attributes, "VersionSupported")
+		}
+	}
+
+	// a single warning for the devices of the node
+	warnings := metrics.At(1)
+	assert.Equal(t, GpuUnsupportedVersionMetric, warnings.Name())
+	assert.Equal(t, 1, warnings.Gauge().DataPoints().Len())
+	warning := warnings.Gauge().DataPoints().At(0)
+	assert.Equal(t, int64(1), warning.IntValue())
+	assert.Equal(t, pcommon.Timestamp(1), warning.Timestamp())
+	assert.Equal(t, map[string]any{
+		"ClusterName": "cluster",
+		"InstanceId":  "i-unsupported",
+		"NodeName":    "unsupported",
+		"Version":     "470.82.01",
+		"Type":        "NodeGPU",
+	}, warning.Attributes().AsRaw())
+}
+
+func TestGpuVersionCheckerAllSupported(t *testing.T) {
+	c := NewGpuVersionChecker([]string{"535", "550"})
+	metrics := createVersionMetrics("container_gpu_utilization", map[string]string{
+		"node-1": "535.104.05",
+		"node-2": "550.54.15",
+	})
+	c.CheckVersions(metrics)
+	assert.Equal(t, 1, metrics.Len())
+}
+
+func TestGpuVersionCheckerIgnoresOtherMetrics(t *testing.T) {
+	c := NewGpuVersionChecker([]string{"535"})
+	metrics := createVersionMetrics("node_neuroncore_utilization", map[string]string{"node": "470.82.01"})
+	c.CheckVersions(metrics)
+	assert.Equal(t, 1, metrics.Len())
+	_, ok := metrics.At(0).Gauge().DataPoints().At(0).Attributes().Get("VersionSupported")
+	assert.False(t, ok)
+}
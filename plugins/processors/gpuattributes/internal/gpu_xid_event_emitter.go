@@ -0,0 +1,138 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"math"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/containerinsightscommon"
+)
+
+const (
+	GpuXidErrorMetric = "node_gpu_xid_error"
+	GpuXidEventMetric = "node_gpu_xid_event"
+
+	XidErrorKey       = "XidError"
+	XidDescriptionKey = "XidDescription"
+
+	unknownXidDescription = "Unknown XID error"
+)
+
+// xidDescriptions are the descriptions of the common XID errors reported by the NVIDIA driver, from
+// https://docs.nvidia.com/deploy/xid-errors/index.html
+var xidDescriptions = map[int64]string{
+	13:  "Graphics Engine Exception",
+	31:  "GPU memory page fault",
+	32:  "Invalid or corrupted push buffer stream",
+	43:  "GPU stopped processing",
+	45:  "Preemptive cleanup, due to previous errors",
+	48:  "Double Bit ECC Error",
+	61:  "Internal micro-controller breakpoint/warning",
+	62:  "Internal micro-controller halt",
+	63:  "ECC page retirement or row remapping recording event",
+	64:  "ECC page retirement or row remapper recording failure",
+	74:  "NVLINK Error",
+	79:  "GPU has fallen off the bus",
+	92:  "High single-bit ECC error rate",
+	94:  "Contained ECC error",
+	95:  "Uncontained ECC error",
+	119: "GSP RPC timeout",
+	120: "GSP error",
+}
+
+// GpuXidEventEmitter adds a node_gpu_xid_event datapoint with the XID error and its description when the last XID
+// error of a GPU device changes. The event has no metric declaration, so the EMF exporter only sends it to CloudWatch
+// Logs as a log event with the node and device of the error.
+type GpuXidEventEmitter struct {
+	mu         sync.Mutex
+	lastErrors map[string]int64
+}
+
+func NewGpuXidEventEmitter() *GpuXidEventEmitter {
+	return &GpuXidEventEmitter{lastErrors: map[string]int64{}}
+}
+
+// EmitXidEvents appends the XID events of the node_gpu_xid_error datapoints to the metrics. The DCGM exporter reports
+// the last XID error of each device on every scrape, so an event is only added when it differs from the previous one.
+func (e *GpuXidEventEmitter) EmitXidEvents(metrics pmetric.MetricSlice) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	events := pmetric.NewMetric()
+	events.SetName(GpuXidEventMetric)
+	eventDps := events.SetEmptyGauge().DataPoints()
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		if m.Name() != GpuXidErrorMetric {
+			continue
+		}
+		var dps pmetric.NumberDataPointSlice
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			dps = m.Gauge().DataPoints()
+		case pmetric.MetricTypeSum:
+			dps = m.Sum().DataPoints()
+		default:
+			continue
+		}
+		for j := 0; j < dps.Len(); j++ {
+			dp := dps.At(j)
+			code, ok := xidCode(dp)
+			if !ok {
+				continue
+			}
+			key := deviceKey(dp)
+			last, seen := e.lastErrors[key]
+			e.lastErrors[key] = code
+			if code == 0 || (seen && last == code) {
+				continue
+			}
+			event := eventDps.AppendEmpty()
+			dp.Attributes().CopyTo(event.Attributes())
+			event.Attributes().PutInt(XidErrorKey, code)
+			event.Attributes().PutStr(XidDescriptionKey, XidDescription(code))
+			event.SetTimestamp(dp.Timestamp())
+			event.SetIntValue(1)
+		}
+	}
+	if eventDps.Len() > 0 {
+		events.MoveTo(metrics.AppendEmpty())
+	}
+}
+
+// XidDescription returns the description of the XID error.
+func XidDescription(code int64) string {
+	if description, ok := xidDescriptions[code]; ok {
+		return description
+	}
+	return unknownXidDescription
+}
+
+func xidCode(dp pmetric.NumberDataPoint) (int64, bool) {
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		return dp.IntValue(), true
+	case pmetric.NumberDataPointValueTypeDouble:
+		if math.IsNaN(dp.DoubleValue()) {
+			return 0, false
+		}
+		return int64(dp.DoubleValue()), true
+	}
+	return 0, false
+}
+
+// deviceKey identifies the GPU device of the datapoint by its node and device.
+func deviceKey(dp pmetric.NumberDataPoint) string {
+	var sb strings.Builder
+	for _, k := range []string{containerinsightscommon.ClusterNameKey, containerinsightscommon.NodeNameKey, containerinsightscommon.GpuDeviceKey, containerinsightscommon.MigDeviceKey} {
+		if v, ok := dp.Attributes().Get(k); ok {
+			sb.WriteString(v.AsString())
+		}
+		sb.WriteByte(0)
+	}
+	return sb.String()
+}
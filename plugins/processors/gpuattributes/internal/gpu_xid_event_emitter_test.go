@@ -0,0 +1,93 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package internal
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// createXidMetrics creates the node_gpu_xid_error metric with the last XID error of each device.
+func createXidMetrics(codes ...int64) pmetric.MetricSlice {
+	metrics := pmetric.NewMetricSlice()
+	m := metrics.AppendEmpty()
+	m.SetName(GpuXidErrorMetric)
+	dps := m.SetEmptyGauge().DataPoints()
+	for i, code := range codes {
+		dp := dps.AppendEmpty()
+		dp.SetIntValue(code)
+		dp.Attributes().PutStr("ClusterName", "cluster")
+		dp.Attributes().PutStr("NodeName", "node")
+		dp.Attributes().PutStr("GpuDevice", "nvidia"+string(rune('0'+i)))
+	}
+	return metrics
+}
+
+func xidEventsOf(metrics pmetric.MetricSlice) []map[string]any {
+	var events []map[string]any
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Name() != GpuXidEventMetric {
+			continue
+		}
+		dps := metrics.At(i).Gauge().DataPoints()
+		for j := 0; j < dps.Len(); j++ {
+			events = append(events, dps.At(j).Attributes().AsRaw())
+		}
+	}
+	return events
+}
+
+func TestEmitXidEvents(t *testing.T) {
+	e := NewGpuXidEventEmitter()
+
+	metrics := createXidMetrics(0, 79)
+	e.EmitXidEvents(metrics)
+	require.Equal(t, 2, metrics.Len())
+	assert.Equal(t, 1, metrics.At(1).Gauge().DataPoints().Len())
+	assert.Equal(t, int64(1), metrics.At(1).Gauge().DataPoints().At(0).IntValue())
+	assert.Equal(t, []map[string]any{
+		{
+			"ClusterName":    "cluster",
+			"NodeName":       "node",
+			"GpuDevice":      "nvidia1",
+			"XidError":       int64(79),
+			"XidDescription": "GPU has fallen off the bus",
+		},
+	}, xidEventsOf(metrics))
+
+	// the same error is reported again on the next scrape without an event
+	metrics = createXidMetrics(0, 79)
+	e.EmitXidEvents(metrics)
+	assert.Equal(t, 1, metrics.Len())
+
+	// a new error of each device is an event
+	metrics = createXidMetrics(48, 1000)
+	e.EmitXidEvents(metrics)
+	assert.Equal(t, []map[string]any{
+		{
+			"ClusterName":    "cluster",
+			"NodeName":       "node",
+			"GpuDevice":      "nvidia0",
+			"XidError":       int64(48),
+			"XidDescription": "Double Bit ECC Error",
+		},
+		{
+			"ClusterName":    "cluster",
+			"NodeName":       "node",
+			"GpuDevice":      "nvidia1",
+			"XidError":       int64(1000),
+			"XidDescription": "Unknown XID error",
+		},
+	}, xidEventsOf(metrics))
+}
+
+func TestEmitXidEventsWithoutXidMetric(t *testing.T) {
+	e := NewGpuXidEventEmitter()
+	metrics := createDeviceMetrics("node_gpu_utilization", map[string][]float64{"node-1": {79}})
+	e.EmitXidEvents(metrics)
+	assert.Equal(t, 1, metrics.Len())
+}
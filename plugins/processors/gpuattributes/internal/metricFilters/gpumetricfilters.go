@@ -24,6 +24,7 @@ var ContainerGpuLabelFilter = map[string]map[string]interface{}{
 	containerinsightscommon.ClusterNameKey:   nil,
 	containerinsightscommon.InstanceIdKey:    nil,
 	containerinsightscommon.GpuDeviceKey:     nil,
+	containerinsightscommon.MigDeviceKey:     nil,
 	containerinsightscommon.MetricType:       nil,
 	containerinsightscommon.NodeNameKey:      nil,
 	containerinsightscommon.K8sNamespace:     nil,
@@ -51,6 +52,7 @@ var PodGpuLabelFilter = map[string]map[string]interface{}{
 	containerinsightscommon.ClusterNameKey:  nil,
 	containerinsightscommon.InstanceIdKey:   nil,
 	containerinsightscommon.GpuDeviceKey:    nil,
+	containerinsightscommon.MigDeviceKey:    nil,
 	containerinsightscommon.MetricType:      nil,
 	containerinsightscommon.NodeNameKey:     nil,
 	containerinsightscommon.K8sNamespace:    nil,
@@ -75,6 +77,7 @@ var NodeGpuLabelFilter = map[string]map[string]interface{}{
 	containerinsightscommon.ClusterNameKey:  nil,
 	containerinsightscommon.InstanceIdKey:   nil,
 	containerinsightscommon.GpuDeviceKey:    nil,
+	containerinsightscommon.MigDeviceKey:    nil,
 	containerinsightscommon.MetricType:      nil,
 	containerinsightscommon.NodeNameKey:     nil,
 	containerinsightscommon.InstanceTypeKey: nil,
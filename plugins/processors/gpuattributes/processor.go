@@ -6,7 +6,11 @@ package gpuattributes
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"runtime"
 	"strings"
+	"sync"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -23,6 +27,9 @@ const (
 	containerMetricPrefix = "container_"
 	podMetricPrefix       = "pod_"
 	nodeMetricPrefix      = "node_"
+	// discreteLabelPrefix is the prefix of the pod labels that some exporters set as their own attributes, e.g.
+	// label_app, instead of in the labels of the kubernetes attribute.
+	discreteLabelPrefix = "label_"
 )
 
 // schemas at each resource level
@@ -31,6 +38,7 @@ const (
 //   - ClusterName, Namespace, PodName, ContainerName
 //   - ClusterName, Namespace, PodName, FullPodName, ContainerName
 //   - ClusterName, Namespace, PodName, FullPodName, ContainerName, GpuDevice
+//   - ClusterName, Namespace, PodName, FullPodName, ContainerName, GpuDevice, MigDevice
 //
 // - Pod
 //   - ClusterName
@@ -39,17 +47,26 @@ const (
 //   - ClusterName, Namespace, PodName
 //   - ClusterName, Namespace, PodName, FullPodName
 //   - ClusterName, Namespace, PodName, FullPodName, GpuDevice
+//   - ClusterName, Namespace, PodName, FullPodName, GpuDevice, MigDevice
 //
 // - Node
 //   - ClusterName
 //   - ClusterName, InstanceIdKey, NodeName
 //   - ClusterName, InstanceIdKey, NodeName, GpuDevice
+//   - ClusterName, InstanceIdKey, NodeName, GpuDevice, MigDevice
+//
+// On nodes with MIG (multi-instance GPU) partitions, each GPU instance has its own datapoint with the MigDevice of the
+// instance and the GpuDevice of the GPU that it belongs to. Both are kept, so the instances are published on their
+// own and also aggregated by the GpuDevice and higher level dimensions.
 type gpuAttributesProcessor struct {
 	*Config
 	logger                          *zap.Logger
 	awsNeuronMetricModifier         *internal.AwsNeuronMetricModifier
 	awsNeuronMemoryMetricAggregator *internal.AwsNeuronMemoryMetricsAggregator
 	awsNeuronMetricChecker          *internal.AwsNeuronMetricChecker
+	gpuNodeMetricAggregator         *internal.GpuNodeMetricAggregator
+	gpuXidEventEmitter              *internal.GpuXidEventEmitter
+	gpuVersionChecker               *internal.GpuVersionChecker
 }
 
 func newGpuAttributesProcessor(config *Config, logger *zap.Logger) *gpuAttributesProcessor {
@@ -59,43 +76,97 @@ func newGpuAttributesProcessor(config *Config, logger *zap.Logger) *gpuAttribute
 		awsNeuronMetricModifier:         internal.NewMetricModifier(logger),
 		awsNeuronMemoryMetricAggregator: internal.NewMemoryMemoryAggregator(),
 		awsNeuronMetricChecker:          internal.NewAwsNeuronMetricChecker(),
+		gpuNodeMetricAggregator:         internal.NewGpuNodeMetricAggregator(config.NodeAggregates, config.DropDeviceMetrics),
+	}
+	if config.XidEvents {
+		d.gpuXidEventEmitter = internal.NewGpuXidEventEmitter()
+	}
+	if len(config.SupportedVersions) > 0 {
+		d.gpuVersionChecker = internal.NewGpuVersionChecker(config.SupportedVersions)
 	}
 	return d
 }
 
 func (d *gpuAttributesProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
 	rms := md.ResourceMetrics()
+	if d.ParallelProcessing && rms.Len() > 1 {
+		d.processResourceMetricsInParallel(rms)
+		return md, nil
+	}
 	for i := 0; i < rms.Len(); i++ {
-		rs := rms.At(i)
-		ilms := rs.ScopeMetrics()
-		for j := 0; j < ilms.Len(); j++ {
-			ils := ilms.At(j)
-			metrics := ils.Metrics()
-
-			d.filterGpuMetricsWithoutPodName(metrics, rs.Resource().Attributes())
-
-			metricsLength := metrics.Len()
-			for k := 0; k < metricsLength; k++ {
-				m := metrics.At(k)
-				d.awsNeuronMemoryMetricAggregator.AggregateMemoryMetric(m)
-				// non neuron metric is returned as a singleton list
-				d.awsNeuronMetricModifier.ModifyMetric(m, metrics)
-			}
-			if d.awsNeuronMemoryMetricAggregator.MemoryMetricsFound {
-				aggregatedMemoryMetric := d.awsNeuronMemoryMetricAggregator.FlushAggregatedMemoryMetric()
-				d.awsNeuronMetricModifier.ModifyMetric(aggregatedMemoryMetric, metrics)
-			}
+		d.processResourceMetrics(rms.At(i), d.awsNeuronMemoryMetricAggregator)
+	}
+	return md, nil
+}
 
-			//loop over all metrics and filter labels
-			for k := 0; k < metrics.Len(); k++ {
-				m := metrics.At(k)
-				d.processMetricAttributes(m)
+// processResourceMetricsInParallel processes each resource on a single worker, so the metrics of a resource are only
+// modified by one goroutine. Each worker has its own neuron memory aggregator, since it holds the memory metrics of
+// the scope that is being processed.
+func (d *gpuAttributesProcessor) processResourceMetricsInParallel(rms pmetric.ResourceMetricsSlice) {
+	workers := d.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	workers = min(workers, rms.Len())
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			memoryMetricAggregator := internal.NewMemoryMemoryAggregator()
+			for i := range indexes {
+				d.processResourceMetrics(rms.At(i), memoryMetricAggregator)
 			}
+		}()
+	}
+	for i := 0; i < rms.Len(); i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+func (d *gpuAttributesProcessor) processResourceMetrics(rs pmetric.ResourceMetrics, memoryMetricAggregator *internal.AwsNeuronMemoryMetricsAggregator) {
+	ilms := rs.ScopeMetrics()
+	for j := 0; j < ilms.Len(); j++ {
+		ils := ilms.At(j)
+		metrics := ils.Metrics()
+
+		d.filterGpuMetricsWithoutPodName(metrics, rs.Resource().Attributes())
+
+		metricsLength := metrics.Len()
+		for k := 0; k < metricsLength; k++ {
+			m := metrics.At(k)
+			memoryMetricAggregator.AggregateMemoryMetric(m)
+			// non neuron metric is returned as a singleton list
+			d.awsNeuronMetricModifier.ModifyMetric(m, metrics)
+		}
+		if memoryMetricAggregator.MemoryMetricsFound {
+			aggregatedMemoryMetric := memoryMetricAggregator.FlushAggregatedMemoryMetric()
+			d.awsNeuronMetricModifier.ModifyMetric(aggregatedMemoryMetric, metrics)
+		}
+
+		//loop over all metrics and filter labels
+		for k := 0; k < metrics.Len(); k++ {
+			m := metrics.At(k)
+			d.processMetricAttributes(m)
 		}
 
-		dropResourceMetricAttributes(rs)
+		// the version is tagged after the filtering so the tag is kept, and before the node aggregates so they have it
+		if d.gpuVersionChecker != nil {
+			d.gpuVersionChecker.CheckVersions(metrics)
+		}
+
+		// the node aggregates are computed from the filtered datapoints, so they have the node schema
+		d.gpuNodeMetricAggregator.AggregateNodeMetrics(metrics)
+		// the XID events copy the filtered attributes of the device, so they have the node schema as well
+		if d.gpuXidEventEmitter != nil {
+			d.gpuXidEventEmitter.EmitXidEvents(metrics)
+		}
 	}
-	return md, nil
+
+	dropResourceMetricAttributes(rs)
 }
 
 func (d *gpuAttributesProcessor) processMetricAttributes(m pmetric.Metric) {
@@ -154,11 +225,20 @@ func (d *gpuAttributesProcessor) filterAttributes(attributes pcommon.Map, labels
 	if len(labels) == 0 {
 		return
 	}
+	// discrete pod labels are kept where the labels of the kubernetes attribute are kept
+	_, keepPodLabels := labels[containerinsightscommon.K8sKey][containerinsightscommon.K8sLabelsKey]
+	podLabels := pcommon.NewMap()
 	// remove labels that are not in the keep list
-	attributes.RemoveIf(func(k string, _ pcommon.Value) bool {
+	attributes.RemoveIf(func(k string, v pcommon.Value) bool {
 		if _, ok := labels[k]; ok {
 			return false
 		}
+		if name, ok := strings.CutPrefix(k, discreteLabelPrefix); ok && name != "" && keepPodLabels {
+			if !d.RepackLabels {
+				return false
+			}
+			v.CopyTo(podLabels.PutEmpty(name))
+		}
 		return true
 	})
 
@@ -168,29 +248,126 @@ func (d *gpuAttributesProcessor) filterAttributes(attributes pcommon.Map, labels
 		if len(ls) == 0 {
 			continue
 		}
-		if av, ok := attributes.Get(lk); ok {
-			// decode json formatted string value into a map then encode again after filtering elements
-			var blob map[string]json.RawMessage
-			strVal := av.Str()
-			err := json.Unmarshal([]byte(strVal), &blob)
-			if err != nil {
-				d.logger.Warn("gpuAttributesProcessor: failed to unmarshal label", zap.String("label", lk))
-				continue
-			}
-			newBlob := make(map[string]json.RawMessage)
-			for bkey, bval := range blob {
-				if _, ok := ls[bkey]; ok {
-					newBlob[bkey] = bval
-				}
-			}
-			bytes, err := json.Marshal(newBlob)
-			if err != nil {
-				d.logger.Warn("gpuAttributesProcessor: failed to marshall label", zap.String("label", lk))
-				continue
+		av, ok := attributes.Get(lk)
+		if !ok {
+			continue
+		}
+		// a map type value is filtered in place
+		if av.Type() == pcommon.ValueTypeMap {
+			av.Map().RemoveIf(func(k string, _ pcommon.Value) bool {
+				_, ok := ls[k]
+				return !ok
+			})
+			continue
+		}
+		// any other shape cannot be filtered down to the keep list, so the label
+		// is removed instead of being published with all of its elements
+		filtered, err := filterJSONObject(av, ls)
+		if err != nil {
+			d.logger.Warn("gpuAttributesProcessor: failed to filter label", zap.String("label", lk), zap.Error(err))
+			attributes.Remove(lk)
+			continue
+		}
+		attributes.PutStr(lk, filtered)
+	}
+
+	if podLabels.Len() > 0 {
+		if err := repackLabels(attributes, podLabels); err != nil {
+			d.logger.Warn("gpuAttributesProcessor: failed to repack discrete labels", zap.Error(err))
+		}
+	}
+}
+
+// repackLabels adds the discrete pod labels to the labels of the kubernetes attribute, which is created as a JSON
+// object if it is not set. Labels that are already in the kubernetes attribute are not replaced.
+func repackLabels(attributes pcommon.Map, podLabels pcommon.Map) error {
+	av, ok := attributes.Get(containerinsightscommon.K8sKey)
+	if !ok {
+		av = attributes.PutEmpty(containerinsightscommon.K8sKey)
+		av.SetStr("{}")
+	}
+	if av.Type() == pcommon.ValueTypeMap {
+		var labels pcommon.Map
+		if lv, ok := av.Map().Get(containerinsightscommon.K8sLabelsKey); ok && lv.Type() == pcommon.ValueTypeMap {
+			labels = lv.Map()
+		} else {
+			labels = av.Map().PutEmptyMap(containerinsightscommon.K8sLabelsKey)
+		}
+		podLabels.Range(func(k string, v pcommon.Value) bool {
+			if _, ok := labels.Get(k); !ok {
+				v.CopyTo(labels.PutEmpty(k))
 			}
-			attributes.PutStr(lk, string(bytes))
+			return true
+		})
+		return nil
+	}
+	if av.Type() != pcommon.ValueTypeStr {
+		return fmt.Errorf("value type %s is not a JSON object", av.Type())
+	}
+	var blob map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(av.Str()), &blob); err != nil {
+		return err
+	}
+	if blob == nil {
+		return errors.New("null is not a JSON object")
+	}
+	var labels map[string]json.RawMessage
+	if raw, ok := blob[containerinsightscommon.K8sLabelsKey]; ok {
+		if err := json.Unmarshal(raw, &labels); err != nil {
+			return fmt.Errorf("labels are not a JSON object: %w", err)
 		}
 	}
+	if labels == nil {
+		labels = make(map[string]json.RawMessage, podLabels.Len())
+	}
+	var err error
+	podLabels.Range(func(k string, v pcommon.Value) bool {
+		if _, ok := labels[k]; ok {
+			return true
+		}
+		labels[k], err = json.Marshal(v.AsRaw())
+		return err == nil
+	})
+	if err != nil {
+		return err
+	}
+	if blob[containerinsightscommon.K8sLabelsKey], err = json.Marshal(labels); err != nil {
+		return err
+	}
+	bytes, err := json.Marshal(blob)
+	if err != nil {
+		return err
+	}
+	av.SetStr(string(bytes))
+	return nil
+}
+
+// filterJSONObject decodes the JSON object in the string value and encodes it
+// again with only the elements in the keep list. The last element wins when a
+// key is duplicated. Values that are JSON arrays, numbers, strings, booleans or
+// null are not objects and return an error.
+func filterJSONObject(value pcommon.Value, keep map[string]interface{}) (string, error) {
+	if value.Type() != pcommon.ValueTypeStr {
+		return "", fmt.Errorf("value type %s is not a JSON object", value.Type())
+	}
+	var blob map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(value.Str()), &blob); err != nil {
+		return "", err
+	}
+	if blob == nil {
+		return "", errors.New("null is not a JSON object")
+	}
+	newBlob := make(map[string]json.RawMessage, len(keep))
+	for bkey, bval := range blob {
+		if _, ok := keep[bkey]; ok {
+			newBlob[bkey] = bval
+		}
+	}
+	bytes, err := json.Marshal(newBlob)
+	if err != nil {
+		return "", err
+	}
+	return string(bytes), nil
 }
 
 // remove dcgm metrics that do not contain PodName attribute which means there is no workload associated to container/pod
@@ -5,10 +5,15 @@ package gpuattributes
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/zap"
 )
@@ -157,6 +162,81 @@ func TestProcessMetricsForGPUMetrics(t *testing.T) {
 				},
 			},
 		},
+		"nodeKeepMigDevice": {
+			metrics: generateGPUMetrics("node", []map[string]string{
+				{
+					"ClusterName": "cluster",
+					"GpuDevice":   "nvidia0",
+					"MigDevice":   "1",
+					"GPU_I_ID":    "1",
+				},
+			}),
+			wantMetricCnt: 1,
+			want: []map[string]string{
+				{
+					"ClusterName": "cluster",
+					"GpuDevice":   "nvidia0",
+					"MigDevice":   "1",
+				},
+			},
+		},
+		"podKeepMigDevice": {
+			metrics: generateGPUMetrics("pod", []map[string]string{
+				{
+					"ClusterName": "cluster",
+					"PodName":     "pod",
+					"GpuDevice":   "nvidia0",
+					"MigDevice":   "1",
+					"GPU_I_ID":    "1",
+				},
+			}),
+			wantMetricCnt: 1,
+			want: []map[string]string{
+				{
+					"ClusterName": "cluster",
+					"PodName":     "pod",
+					"GpuDevice":   "nvidia0",
+					"MigDevice":   "1",
+				},
+			},
+		},
+		"containerKeepMigDevicesOfSameGpu": {
+			metrics: generateGPUMetrics("container", []map[string]string{
+				{
+					"ClusterName":   "cluster",
+					"PodName":       "pod1",
+					"ContainerName": "container1",
+					"GpuDevice":     "nvidia0",
+					"MigDevice":     "1",
+					"GPU_I_ID":      "1",
+				},
+				{
+					"ClusterName":   "cluster",
+					"PodName":       "pod2",
+					"ContainerName": "container2",
+					"GpuDevice":     "nvidia0",
+					"MigDevice":     "2",
+					"GPU_I_ID":      "2",
+				},
+			}),
+			wantMetricCnt: 1,
+			want: []map[string]string{
+				{
+					"ClusterName":   "cluster",
+					"PodName":       "pod1",
+					"ContainerName": "container1",
+					"GpuDevice":     "nvidia0",
+					"MigDevice":     "1",
+				},
+				{
+					"ClusterName":   "cluster",
+					"PodName":       "pod2",
+					"ContainerName": "container2",
+					"GpuDevice":     "nvidia0",
+					"MigDevice":     "2",
+				},
+			},
+		},
 		"keepAllDatapoints": {
 			metrics: generateGPUMetrics("container", []map[string]string{
 				{
@@ -502,3 +582,489 @@ func generateNeuronMetrics(prefix string, dimensions []map[string]string) pmetri
 	}
 	return md
 }
+
+var testLabelFilter = map[string]map[string]interface{}{
+	"ClusterName": nil,
+	"kubernetes": {
+		"host":     nil,
+		"pod_name": nil,
+	},
+}
+
+func TestFilterAttributesValueShapes(t *testing.T) {
+	gp := newGpuAttributesProcessor(createDefaultConfig().(*Config), zap.NewNop())
+	testCases := map[string]struct {
+		value  func(pcommon.Value)
+		want   string
+		wantOk bool
+	}{
+		"Object": {
+			value:  func(v pcommon.Value) { v.SetStr(`{"host":"test","drop":"2"}`) },
+			want:   `{"host":"test"}`,
+			wantOk: true,
+		},
+		"NestedObject": {
+			value:  func(v pcommon.Value) { v.SetStr(`{"host":{"a":[1,{"b":null}]},"drop":{"c":1}}`) },
+			want:   `{"host":{"a":[1,{"b":null}]}}`,
+			wantOk: true,
+		},
+		"DuplicateKeys": {
+			value:  func(v pcommon.Value) { v.SetStr(`{"host":"first","host":"last"}`) },
+			want:   `{"host":"last"}`,
+			wantOk: true,
+		},
+		"NoKeptElements": {
+			value:  func(v pcommon.Value) { v.SetStr(`{"drop":"2"}`) },
+			want:   `{}`,
+			wantOk: true,
+		},
+		"Array":       {value: func(v pcommon.Value) { v.SetStr(`[{"host":"test"}]`) }},
+		"Number":      {value: func(v pcommon.Value) { v.SetStr(`42`) }},
+		"String":      {value: func(v pcommon.Value) { v.SetStr(`"host"`) }},
+		"Null":        {value: func(v pcommon.Value) { v.SetStr(`null`) }},
+		"InvalidJSON": {value: func(v pcommon.Value) { v.SetStr(`{"host":`) }},
+		"IntValue":    {value: func(v pcommon.Value) { v.SetInt(1) }},
+		"SliceValue":  {value: func(v pcommon.Value) { v.SetEmptySlice().AppendEmpty().SetStr("host") }},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			attributes := pcommon.NewMap()
+			attributes.PutStr("ClusterName", "cluster")
+			attributes.PutStr("Drop", "val")
+			testCase.value(attributes.PutEmpty("kubernetes"))
+			gp.filterAttributes(attributes, testLabelFilter)
+
+			got, ok := attributes.Get("kubernetes")
+			assert.Equal(t, testCase.wantOk, ok)
+			if ok {
+				assert.Equal(t, testCase.want, got.Str())
+			}
+			assert.Equal(t, map[string]any{"ClusterName": "cluster"}, removeKey(attributes.AsRaw(), "kubernetes"))
+		})
+	}
+}
+
+func TestFilterAttributesMapValue(t *testing.T) {
+	gp := newGpuAttributesProcessor(createDefaultConfig().(*Config), zap.NewNop())
+	attributes := pcommon.NewMap()
+	kubernetes := attributes.PutEmptyMap("kubernetes")
+	kubernetes.PutStr("host", "test")
+	kubernetes.PutStr("drop", "2")
+	gp.filterAttributes(attributes, testLabelFilter)
+	assert.Equal(t, map[string]any{"kubernetes": map[string]any{"host": "test"}}, attributes.AsRaw())
+}
+
+var testPodLabelFilter = map[string]map[string]interface{}{
+	"ClusterName": nil,
+	"kubernetes": {
+		"host":   nil,
+		"labels": nil,
+	},
+}
+
+func TestFilterAttributesDiscreteLabels(t *testing.T) {
+	testCases := map[string]struct {
+		repack     bool
+		filter     map[string]map[string]interface{}
+		kubernetes func(pcommon.Map)
+		want       map[string]any
+	}{
+		"Kept": {
+			filter: testPodLabelFilter,
+			kubernetes: func(m pcommon.Map) {
+				m.PutStr("kubernetes", `{"host":"test","labels":{"app":"blob"}}`)
+			},
+			want: map[string]any{
+				"ClusterName":   "cluster",
+				"kubernetes":    `{"host":"test","labels":{"app":"blob"}}`,
+				"label_app":     "web",
+				"label_version": "v1",
+			},
+		},
+		"DroppedWithoutLabelsInFilter": {
+			filter: testLabelFilter,
+			kubernetes: func(m pcommon.Map) {
+				m.PutStr("kubernetes", `{"host":"test","labels":{"app":"blob"}}`)
+			},
+			want: map[string]any{
+				"ClusterName": "cluster",
+				"kubernetes":  `{"host":"test"}`,
+			},
+		},
+		"DroppedWithoutLabelsInFilterWithRepack": {
+			repack: true,
+			filter: testLabelFilter,
+			want:   map[string]any{"ClusterName": "cluster"},
+		},
+		"RepackedIntoJSON": {
+			repack: true,
+			filter: testPodLabelFilter,
+			kubernetes: func(m pcommon.Map) {
+				m.PutStr("kubernetes", `{"host":"test","drop":"2","labels":{"app":"blob"}}`)
+			},
+			want: map[string]any{
+				"ClusterName": "cluster",
+				"kubernetes":  `{"host":"test","labels":{"app":"blob","version":"v1"}}`,
+			},
+		},
+		"RepackedIntoMap": {
+			repack: true,
+			filter: testPodLabelFilter,
+			kubernetes: func(m pcommon.Map) {
+				kubernetes := m.PutEmptyMap("kubernetes")
+				kubernetes.PutStr("host", "test")
+				kubernetes.PutEmptyMap("labels").PutStr("app", "blob")
+			},
+			want: map[string]any{
+				"ClusterName": "cluster",
+				"kubernetes": map[string]any{
+					"host":   "test",
+					"labels": map[string]any{"app": "blob", "version": "v1"},
+				},
+			},
+		},
+		"RepackedWithoutKubernetes": {
+			repack: true,
+			filter: testPodLabelFilter,
+			want: map[string]any{
+				"ClusterName": "cluster",
+				"kubernetes":  `{"labels":{"app":"web","version":"v1"}}`,
+			},
+		},
+		"RepackedIntoInvalidJSON": {
+			repack: true,
+			filter: testPodLabelFilter,
+			kubernetes: func(m pcommon.Map) {
+				m.PutStr("kubernetes", `{"host":`)
+			},
+			want: map[string]any{
+				"ClusterName": "cluster",
+				"kubernetes":  `{"labels":{"app":"web","version":"v1"}}`,
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			gp := newGpuAttributesProcessor(&Config{RepackLabels: testCase.repack}, zap.NewNop())
+			attributes := pcommon.NewMap()
+			attributes.PutStr("ClusterName", "cluster")
+			attributes.PutStr("Drop", "val")
+			attributes.PutStr("label_app", "web")
+			attributes.PutStr("label_version", "v1")
+			// not a label without a name
+			attributes.PutStr("label_", "empty")
+			if testCase.kubernetes != nil {
+				testCase.kubernetes(attributes)
+			}
+			gp.filterAttributes(attributes, testCase.filter)
+			assert.Equal(t, testCase.want, attributes.AsRaw())
+		})
+	}
+}
+
+func TestProcessMetricsWithDiscreteLabels(t *testing.T) {
+	gp := newGpuAttributesProcessor(&Config{RepackLabels: true}, zap.NewNop())
+	dimensions := []map[string]string{{
+		"ClusterName": "cluster",
+		"PodName":     "pod",
+		"kubernetes":  `{"host":"test","pod_name":"pod"}`,
+		"label_app":   "web",
+	}}
+	for prefix, want := range map[string]string{
+		"container": `{"host":"test","labels":{"app":"web"},"pod_name":"pod"}`,
+		"pod":       `{"host":"test","labels":{"app":"web"},"pod_name":"pod"}`,
+		// node metrics do not have the labels of the pods
+		"node": `{"host":"test"}`,
+	} {
+		t.Run(prefix, func(t *testing.T) {
+			md, err := gp.processMetrics(context.Background(), generateGPUMetrics(prefix, dimensions))
+			require.NoError(t, err)
+			attributes := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).Attributes()
+			got, ok := attributes.Get("kubernetes")
+			require.True(t, ok)
+			assert.Equal(t, want, got.Str())
+			_, ok = attributes.Get("label_app")
+			assert.False(t, ok)
+		})
+	}
+}
+
+// FuzzFilterAttributes checks that any string value of a map type label either
+// results in a JSON object with only the elements in the keep list or the label
+// being removed, while the other labels are filtered independently of it.
+func FuzzFilterAttributes(f *testing.F) {
+	for _, seed := range []string{
+		`{"host":"test","drop":"2"}`,
+		`{"host":"a","host":"b","pod_name":{"x":[1,2]}}`,
+		`[{"host":"test"}]`,
+		`42`,
+		`-1.5e300`,
+		`"host"`,
+		`null`,
+		`true`,
+		`{`,
+		``,
+		`{"host":` + strings.Repeat(`{"a":`, 1000) + `1` + strings.Repeat(`}`, 1000) + `}`,
+	} {
+		f.Add(seed)
+	}
+	gp := newGpuAttributesProcessor(createDefaultConfig().(*Config), zap.NewNop())
+	f.Fuzz(func(t *testing.T, value string) {
+		attributes := pcommon.NewMap()
+		attributes.PutStr("ClusterName", "cluster")
+		attributes.PutStr("Drop", "val")
+		attributes.PutStr("kubernetes", value)
+		gp.filterAttributes(attributes, testLabelFilter)
+
+		got, ok := attributes.Get("kubernetes")
+		if ok {
+			var blob map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal([]byte(got.Str()), &blob))
+			require.NotNil(t, blob)
+			for key := range blob {
+				assert.Contains(t, testLabelFilter["kubernetes"], key)
+			}
+		}
+		assert.Equal(t, map[string]any{"ClusterName": "cluster"}, removeKey(attributes.AsRaw(), "kubernetes"))
+	})
+}
+
+func removeKey(m map[string]any, key string) map[string]any {
+	delete(m, key)
+	return m
+}
+
+func TestProcessMetricsForGPUNodeAggregates(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	gp := newGpuAttributesProcessor(&Config{NodeAggregates: []string{"node_gpu_utilization"}}, logger)
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	m := ms.AppendEmpty()
+	m.SetName("node_gpu_utilization")
+	dps := m.SetEmptyGauge().DataPoints()
+	for i, value := range []int64{10, 30, 80} {
+		dp := dps.AppendEmpty()
+		dp.SetIntValue(value)
+		dp.Attributes().PutStr("ClusterName", "cluster")
+		dp.Attributes().PutStr("InstanceId", "i-0123456789abcdef0")
+		dp.Attributes().PutStr("NodeName", "node")
+		dp.Attributes().PutStr("Type", "NodeGPU")
+		dp.Attributes().PutStr("GpuDevice", fmt.Sprintf("nvidia%d", i))
+		dp.Attributes().PutStr("UUID", fmt.Sprintf("GPU-%d", i))
+	}
+
+	md, err := gp.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	ms = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 3, ms.Len())
+	assert.Equal(t, 3, ms.At(0).Gauge().DataPoints().Len())
+
+	nodeSchema := map[string]any{
+		"ClusterName": "cluster",
+		"InstanceId":  "i-0123456789abcdef0",
+		"NodeName":    "node",
+		"Type":        "NodeGPU",
+	}
+	for name, want := range map[string]float64{"node_gpu_utilization_avg": 40, "node_gpu_utilization_max": 80} {
+		var found bool
+		for i := 0; i < ms.Len(); i++ {
+			if ms.At(i).Name() != name {
+				continue
+			}
+			found = true
+			aggregates := ms.At(i).Gauge().DataPoints()
+			require.Equal(t, 1, aggregates.Len(), name)
+			assert.Equal(t, want, aggregates.At(0).DoubleValue(), name)
+			assert.Equal(t, nodeSchema, aggregates.At(0).Attributes().AsRaw(), name)
+		}
+		assert.True(t, found, name)
+	}
+}
+
+func TestProcessMetricsForGPUReliabilityMetrics(t *testing.T) {
+	logger, _ := zap.NewDevelopment()
+	gp := newGpuAttributesProcessor(&Config{XidEvents: true}, logger)
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	for name, value := range map[string]int64{"node_gpu_ecc_double_bit_errors": 2, "node_gpu_xid_error": 79} {
+		m := ms.AppendEmpty()
+		m.SetName(name)
+		dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+		dp.SetIntValue(value)
+		dp.Attributes().PutStr("ClusterName", "cluster")
+		dp.Attributes().PutStr("InstanceId", "i-0123456789abcdef0")
+		dp.Attributes().PutStr("NodeName", "node")
+		dp.Attributes().PutStr("Type", "NodeGPU")
+		dp.Attributes().PutStr("GpuDevice", "nvidia0")
+		dp.Attributes().PutStr("UUID", "GPU-0")
+		dp.Attributes().PutStr("Hostname", "node.internal")
+	}
+
+	md, err := gp.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	ms = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 3, ms.Len())
+
+	deviceSchema := map[string]any{
+		"ClusterName": "cluster",
+		"InstanceId":  "i-0123456789abcdef0",
+		"NodeName":    "node",
+		"Type":        "NodeGPU",
+		"GpuDevice":   "nvidia0",
+	}
+	got := map[string]map[string]any{}
+	for i := 0; i < ms.Len(); i++ {
+		require.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+		got[ms.At(i).Name()] = ms.At(i).Gauge().DataPoints().At(0).Attributes().AsRaw()
+	}
+	assert.Equal(t, deviceSchema, got["node_gpu_ecc_double_bit_errors"])
+	assert.Equal(t, deviceSchema, got["node_gpu_xid_error"])
+	deviceSchema["XidError"] = int64(79)
+	deviceSchema["XidDescription"] = "GPU has fallen off the bus"
+	assert.Equal(t, deviceSchema, got["node_gpu_xid_event"])
+}
+
+func TestProcessMetricsWithSupportedVersions(t *testing.T) {
+	gp := newGpuAttributesProcessor(&Config{
+		NodeAggregates:    []string{"node_gpu_utilization"},
+		SupportedVersions: []string{"535"},
+	}, zap.NewNop())
+	for prefix, wantMetrics := range map[string][]string{
+		"container": {"container_gpu_utilization", "node_gpu_unsupported_version"},
+		"pod":       {"pod_gpu_utilization", "node_gpu_unsupported_version"},
+		"node":      {"node_gpu_utilization", "node_gpu_unsupported_version", "node_gpu_utilization_avg", "node_gpu_utilization_max"},
+	} {
+		t.Run(prefix, func(t *testing.T) {
+			md := generateGPUMetrics(prefix, []map[string]string{
+				{"ClusterName": "cluster", "NodeName": "node-1", "PodName": "pod", "GpuDevice": "nvidia0", "Version": "535.104.05"},
+				{"ClusterName": "cluster", "NodeName": "node-2", "PodName": "pod", "GpuDevice": "nvidia0", "Version": "470.82.01"},
+				{"ClusterName": "cluster", "NodeName": "node-2", "PodName": "pod", "GpuDevice": "nvidia1", "Version": "470.82.01"},
+			})
+			md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).SetName(prefix + "_gpu_utilization")
+			md, err := gp.processMetrics(context.Background(), md)
+			require.NoError(t, err)
+			metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+			var names []string
+			for i := 0; i < metrics.Len(); i++ {
+				m := metrics.At(i)
+				names = append(names, m.Name())
+				if m.Name() == "node_gpu_unsupported_version" {
+					require.Equal(t, 1, m.Gauge().DataPoints().Len())
+					assert.Equal(t, map[string]any{
+						"ClusterName": "cluster",
+						"NodeName":    "node-2",
+						"Version":     "470.82.01",
+						"Type":        "NodeGPU",
+					}, m.Gauge().DataPoints().At(0).Attributes().AsRaw())
+					continue
+				}
+				// the node aggregates have the tag of the devices
+				dps := m.Gauge().DataPoints()
+				for j := 0; j < dps.Len(); j++ {
+					attributes := dps.At(j).Attributes().AsRaw()
+					assert.Equal(t, attributes["NodeName"] == "node-1", attributes["VersionSupported"], m.Name())
+				}
+			}
+			assert.Equal(t, wantMetrics, names)
+		})
+	}
+}
+
+// generateNodeBatch generates the metrics of the given number of nodes, each with its own resources for the GPU and
+// Neuron metrics of its devices.
+func generateNodeBatch(nodes, devices int) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	timestamp := pcommon.NewTimestampFromTime(time.Unix(1700000000, 0))
+	for n := 0; n < nodes; n++ {
+		node := fmt.Sprintf("node-%d", n)
+		gpu := md.ResourceMetrics().AppendEmpty()
+		gpu.Resource().Attributes().PutStr("service.name", "containerInsightsDCGMExporterScraper")
+		gpu.Resource().Attributes().PutStr("NodeName", node)
+		ms := gpu.ScopeMetrics().AppendEmpty().Metrics()
+		for _, name := range []string{"container_gpu_utilization", "pod_gpu_utilization", "node_gpu_utilization"} {
+			m := ms.AppendEmpty()
+			m.SetName(name)
+			dps := m.SetEmptyGauge().DataPoints()
+			for d := 0; d < devices; d++ {
+				dp := dps.AppendEmpty()
+				dp.SetTimestamp(timestamp)
+				dp.SetDoubleValue(float64(n + d))
+				dp.Attributes().PutStr("ClusterName", "cluster")
+				dp.Attributes().PutStr("NodeName", node)
+				dp.Attributes().PutStr("PodName", fmt.Sprintf("pod-%d", d))
+				dp.Attributes().PutStr("GpuDevice", fmt.Sprintf("nvidia%d", d))
+				dp.Attributes().PutStr("Drop", "val")
+				dp.Attributes().PutStr("kubernetes", `{"host":"`+node+`","pod_name":"pod","drop":"2"}`)
+			}
+		}
+
+		neuron := md.ResourceMetrics().AppendEmpty()
+		neuron.Resource().Attributes().PutStr("service.name", "containerInsightsNeuronMonitorScraper")
+		ms = neuron.ScopeMetrics().AppendEmpty().Metrics()
+		for _, name := range []string{"neuroncore_memory_usage_constants", "neuroncore_memory_usage_tensors", "neuron_execution_latency"} {
+			m := ms.AppendEmpty()
+			m.SetName(name)
+			dps := m.SetEmptyGauge().DataPoints()
+			// the memory usage is aggregated by neuron core in no particular order, so it only has one
+			cores := devices
+			if strings.HasPrefix(name, "neuroncore_memory_usage") {
+				cores = 1
+			}
+			for c := 0; c < cores; c++ {
+				dp := dps.AppendEmpty()
+				dp.SetTimestamp(timestamp)
+				dp.SetDoubleValue(float64(n + c))
+				dp.Attributes().PutStr("ClusterName", "cluster")
+				dp.Attributes().PutStr("NodeName", node)
+				dp.Attributes().PutStr("PodName", fmt.Sprintf("pod-%d", c))
+				dp.Attributes().PutStr("runtime_tag", "1")
+				dp.Attributes().PutStr("NeuronCore", fmt.Sprintf("%d", c))
+				dp.Attributes().PutStr("NeuronDevice", fmt.Sprintf("%d", c/2))
+				dp.Attributes().PutStr("percentile", "p50")
+				dp.Attributes().PutStr("kubernetes", `{"host":"`+node+`","pod_name":"pod","drop":"2"}`)
+			}
+		}
+	}
+	return md
+}
+
+func TestProcessMetricsInParallel(t *testing.T) {
+	for _, workers := range []int{0, 1, 3, 100} {
+		t.Run(fmt.Sprintf("Workers%d", workers), func(t *testing.T) {
+			serial := newGpuAttributesProcessor(&Config{NodeAggregates: []string{"node_gpu_utilization"}}, zap.NewNop())
+			parallel := newGpuAttributesProcessor(&Config{
+				NodeAggregates:     []string{"node_gpu_utilization"},
+				ParallelProcessing: true,
+				Workers:            workers,
+			}, zap.NewNop())
+			// the processors keep no state between batches, so they are checked more than once
+			for i := 0; i < 3; i++ {
+				want, err := serial.processMetrics(context.Background(), generateNodeBatch(10, 4))
+				require.NoError(t, err)
+				got, err := parallel.processMetrics(context.Background(), generateNodeBatch(10, 4))
+				require.NoError(t, err)
+				assert.Equal(t, want, got)
+			}
+		})
+	}
+}
+
+func BenchmarkProcessMetrics(b *testing.B) {
+	for name, cfg := range map[string]*Config{
+		"Serial":   {},
+		"Parallel": {ParallelProcessing: true},
+	} {
+		b.Run(name, func(b *testing.B) {
+			gp := newGpuAttributesProcessor(cfg, zap.NewNop())
+			batch := generateNodeBatch(50, 16)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				md := pmetric.NewMetrics()
+				batch.CopyTo(md)
+				b.StartTimer()
+				_, _ = gp.processMetrics(context.Background(), md)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package attributedenylistprocessor
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	errNoKeys       = errors.New("keys or patterns must be set")
+	errEmptyKey     = errors.New("keys must not contain an empty key")
+	errEmptyPattern = errors.New("patterns must not contain an empty pattern")
+	errBadPattern   = errors.New("invalid pattern")
+)
+
+type Config struct {
+	// Keys are the attribute keys that are removed.
+	Keys []string `mapstructure:"keys,omitempty"`
+	// Patterns are regular expressions. The attribute keys that they fully match are removed.
+	Patterns []string `mapstructure:"patterns,omitempty"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if len(cfg.Keys) == 0 && len(cfg.Patterns) == 0 {
+		return errNoKeys
+	}
+	for _, key := range cfg.Keys {
+		if key == "" {
+			return errEmptyKey
+		}
+	}
+	for _, pattern := range cfg.Patterns {
+		if pattern == "" {
+			return errEmptyPattern
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("%w %q: %w", errBadPattern, pattern, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package attributedenylistprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	testCases := []struct {
+		id      component.ID
+		want    component.Config
+		wantErr error
+	}{
+		{
+			id:      component.NewID(component.MustNewType(typeStr)),
+			wantErr: errNoKeys,
+		},
+		{
+			id: component.NewIDWithName(component.MustNewType(typeStr), "1"),
+			want: &Config{
+				Keys:     []string{"aws.secret", "Authorization"},
+				Patterns: []string{"(?i).*token.*"},
+			},
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "empty_key"),
+			wantErr: errEmptyKey,
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "empty_pattern"),
+			wantErr: errEmptyPattern,
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "invalid_pattern"),
+			wantErr: errBadPattern,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.id.String(), func(t *testing.T) {
+			conf, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := conf.Sub(testCase.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, component.ValidateConfig(cfg), testCase.wantErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, testCase.want, cfg)
+		})
+	}
+}
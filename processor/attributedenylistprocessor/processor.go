@@ -0,0 +1,128 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package attributedenylistprocessor
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+type denylistProcessor struct {
+	logger  *zap.Logger
+	keys    map[string]struct{}
+	pattern *regexp.Regexp
+	// denied caches whether the keys that were checked against the pattern are denylisted.
+	denied sync.Map
+}
+
+func newProcessor(cfg *Config, logger *zap.Logger) (*denylistProcessor, error) {
+	p := &denylistProcessor{
+		logger: logger,
+		keys:   make(map[string]struct{}, len(cfg.Keys)),
+	}
+	for _, key := range cfg.Keys {
+		p.keys[key] = struct{}{}
+	}
+	if len(cfg.Patterns) > 0 {
+		pattern, err := regexp.Compile("^(?:" + strings.Join(cfg.Patterns, ")$|^(?:") + ")$")
+		if err != nil {
+			return nil, err
+		}
+		p.pattern = pattern
+	}
+	return p, nil
+}
+
+func (p *denylistProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		p.removeAttributes(rm.Resource().Attributes())
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				p.processMetric(ms.At(k))
+			}
+		}
+	}
+	return md, nil
+}
+
+func (p *denylistProcessor) processMetric(m pmetric.Metric) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.removeAttributes(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.removeAttributes(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.removeAttributes(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.removeAttributes(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.removeAttributes(dps.At(i).Attributes())
+		}
+	}
+}
+
+func (p *denylistProcessor) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		p.removeAttributes(rl.Resource().Attributes())
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				p.removeAttributes(lrs.At(k).Attributes())
+			}
+		}
+	}
+	return ld, nil
+}
+
+func (p *denylistProcessor) removeAttributes(attrs pcommon.Map) {
+	attrs.RemoveIf(func(key string, _ pcommon.Value) bool {
+		return p.isDenied(key)
+	})
+}
+
+func (p *denylistProcessor) isDenied(key string) bool {
+	if _, ok := p.keys[key]; ok {
+		return true
+	}
+	if p.pattern == nil {
+		return false
+	}
+	if denied, ok := p.denied.Load(key); ok {
+		return denied.(bool)
+	}
+	denied := p.pattern.MatchString(key)
+	if denied {
+		p.logger.Debug("Removing denylisted attribute", zap.String("key", key))
+	}
+	p.denied.Store(key, denied)
+	return denied
+}
@@ -0,0 +1,126 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package attributedenylistprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+var testConfig = &Config{
+	Keys:     []string{"aws.secret", "Authorization"},
+	Patterns: []string{"(?i).*token.*", "password"},
+}
+
+func newAttributes() map[string]any {
+	return map[string]any{
+		"Authorization":  "Bearer abc",
+		"aws.secret":     "abc",
+		"SessionToken":   "abc",
+		"password":       "abc",
+		"password_reset": "kept",
+		"InstanceId":     "i-123",
+	}
+}
+
+var wantAttributes = map[string]any{
+	"password_reset": "kept",
+	"InstanceId":     "i-123",
+}
+
+func newTestProcessor(t *testing.T) *denylistProcessor {
+	t.Helper()
+	p, err := newProcessor(testConfig, zap.NewNop())
+	require.NoError(t, err)
+	return p
+}
+
+func TestProcessMetrics(t *testing.T) {
+	p := newTestProcessor(t)
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	require.NoError(t, rm.Resource().Attributes().FromRaw(newAttributes()))
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	m := ms.AppendEmpty()
+	m.SetName("gauge")
+	require.NoError(t, m.SetEmptyGauge().DataPoints().AppendEmpty().Attributes().FromRaw(newAttributes()))
+	m = ms.AppendEmpty()
+	m.SetName("sum")
+	require.NoError(t, m.SetEmptySum().DataPoints().AppendEmpty().Attributes().FromRaw(newAttributes()))
+	m = ms.AppendEmpty()
+	m.SetName("histogram")
+	require.NoError(t, m.SetEmptyHistogram().DataPoints().AppendEmpty().Attributes().FromRaw(newAttributes()))
+	m = ms.AppendEmpty()
+	m.SetName("exponential_histogram")
+	require.NoError(t, m.SetEmptyExponentialHistogram().DataPoints().AppendEmpty().Attributes().FromRaw(newAttributes()))
+	m = ms.AppendEmpty()
+	m.SetName("summary")
+	require.NoError(t, m.SetEmptySummary().DataPoints().AppendEmpty().Attributes().FromRaw(newAttributes()))
+
+	md, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	rm = md.ResourceMetrics().At(0)
+	assert.Equal(t, wantAttributes, rm.Resource().Attributes().AsRaw())
+	ms = rm.ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 5, ms.Len())
+	for i := 0; i < ms.Len(); i++ {
+		var attrs pcommon.Map
+		switch m = ms.At(i); m.Type() {
+		case pmetric.MetricTypeGauge:
+			attrs = m.Gauge().DataPoints().At(0).Attributes()
+		case pmetric.MetricTypeSum:
+			attrs = m.Sum().DataPoints().At(0).Attributes()
+		case pmetric.MetricTypeHistogram:
+			attrs = m.Histogram().DataPoints().At(0).Attributes()
+		case pmetric.MetricTypeExponentialHistogram:
+			attrs = m.ExponentialHistogram().DataPoints().At(0).Attributes()
+		case pmetric.MetricTypeSummary:
+			attrs = m.Summary().DataPoints().At(0).Attributes()
+		}
+		assert.Equal(t, wantAttributes, attrs.AsRaw(), m.Name())
+	}
+}
+
+func TestProcessLogs(t *testing.T) {
+	p := newTestProcessor(t)
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	require.NoError(t, rl.Resource().Attributes().FromRaw(newAttributes()))
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Body().SetStr("body is kept")
+	require.NoError(t, lr.Attributes().FromRaw(newAttributes()))
+
+	ld, err := p.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	rl = ld.ResourceLogs().At(0)
+	assert.Equal(t, wantAttributes, rl.Resource().Attributes().AsRaw())
+	lr = rl.ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, wantAttributes, lr.Attributes().AsRaw())
+	assert.Equal(t, "body is kept", lr.Body().Str())
+}
+
+func TestIsDenied(t *testing.T) {
+	p, err := newProcessor(&Config{Keys: []string{"aws.secret"}}, zap.NewNop())
+	require.NoError(t, err)
+	assert.True(t, p.isDenied("aws.secret"))
+	assert.False(t, p.isDenied("aws.secret.name"))
+
+	p = newTestProcessor(t)
+	for _, key := range []string{"SessionToken", "token", "password"} {
+		// checked twice to use the cached result
+		assert.True(t, p.isDenied(key), key)
+		assert.True(t, p.isDenied(key), key)
+	}
+	for _, key := range []string{"passwords", "my_password", "InstanceId"} {
+		assert.False(t, p.isDenied(key), key)
+		assert.False(t, p.isDenied(key), key)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package counterresetprocessor
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	errNegativeMaxStaleness   = errors.New("max_staleness must not be negative")
+	errNegativeStateMaxAge    = errors.New("state_max_age must not be negative")
+	errNegativeMaxStateSeries = errors.New("max_state_series must not be negative")
+)
+
+type Config struct {
+	// MaxStaleness is how long the state of a series is kept after its last
+	// data point. Zero keeps the state until the processor is shut down.
+	MaxStaleness time.Duration `mapstructure:"max_staleness,omitempty"`
+	// DeltaToCumulative are the regular expressions of the names of the monotonic
+	// delta sums that are accumulated into cumulative sums, so they keep the same
+	// temporality as the cumulative metrics they are queried with.
+	DeltaToCumulative []string `mapstructure:"delta_to_cumulative,omitempty"`
+	// StateFile is the file that the state of the series is saved to, so that
+	// it is restored when the agent restarts. The state is not saved if empty.
+	StateFile string `mapstructure:"state_file,omitempty"`
+	// StateMaxAge is how long after its last data point the state of a series
+	// is restored. Zero uses the default of 15 minutes.
+	StateMaxAge time.Duration `mapstructure:"state_max_age,omitempty"`
+	// MaxStateSeries bounds the size of the state file. Only the most recently
+	// seen series are saved. Zero uses the default of 10000.
+	MaxStateSeries int `mapstructure:"max_state_series,omitempty"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if cfg.MaxStaleness < 0 {
+		return errNegativeMaxStaleness
+	}
+	if cfg.StateMaxAge < 0 {
+		return errNegativeStateMaxAge
+	}
+	if cfg.MaxStateSeries < 0 {
+		return errNegativeMaxStateSeries
+	}
+	for _, pattern := range cfg.DeltaToCumulative {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid delta_to_cumulative pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
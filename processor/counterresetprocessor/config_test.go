@@ -0,0 +1,73 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package counterresetprocessor
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	testCases := []struct {
+		id      component.ID
+		want    component.Config
+		wantErr string
+	}{
+		{
+			id:   component.NewID(component.MustNewType(typeStr)),
+			want: NewFactory().CreateDefaultConfig(),
+		},
+		{
+			id:   component.NewIDWithName(component.MustNewType(typeStr), "1"),
+			want: &Config{MaxStaleness: 10 * time.Minute},
+		},
+		{
+			id:   component.NewIDWithName(component.MustNewType(typeStr), "2"),
+			want: &Config{DeltaToCumulative: []string{"^errors_"}},
+		},
+		{
+			id:   component.NewIDWithName(component.MustNewType(typeStr), "3"),
+			want: &Config{StateFile: "/var/lib/counterreset.json", StateMaxAge: 5 * time.Minute, MaxStateSeries: 100},
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "invalid"),
+			wantErr: errNegativeMaxStaleness.Error(),
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "invalid_state_max_age"),
+			wantErr: errNegativeStateMaxAge.Error(),
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "invalid_max_state_series"),
+			wantErr: errNegativeMaxStateSeries.Error(),
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "invalid_pattern"),
+			wantErr: "invalid delta_to_cumulative pattern",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.id.String(), func(t *testing.T) {
+			conf, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := conf.Sub(testCase.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, component.ValidateConfig(cfg), testCase.wantErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, testCase.want, cfg)
+		})
+	}
+}
@@ -0,0 +1,211 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package counterresetprocessor
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+// seriesState tracks a single cumulative series, identified by the metric name
+// and the resource, scope and data point attributes.
+type seriesState struct {
+	// start is the start timestamp of the first data point of the series. It
+	// is kept across resets so the adjusted series stays continuous.
+	start pcommon.Timestamp
+	// rawStart is the start timestamp of the last data point as received.
+	rawStart pcommon.Timestamp
+	// prev and offset hold the last received value and the sum of the values
+	// before each reset, as int or double depending on the data point type.
+	prevInt, offsetInt       int64
+	prevDouble, offsetDouble float64
+	lastSeen                 time.Time
+}
+
+type counterResetProcessor struct {
+	maxStaleness      time.Duration
+	deltaToCumulative []*regexp.Regexp
+	logger            *zap.Logger
+	now               func() time.Time
+
+	mu     sync.Mutex
+	series map[string]*seriesState
+
+	stateFile      string
+	stateMaxAge    time.Duration
+	maxStateSeries int
+	done           chan struct{}
+	wg             sync.WaitGroup
+}
+
+func newProcessor(cfg *Config, logger *zap.Logger) *counterResetProcessor {
+	p := &counterResetProcessor{
+		maxStaleness:   cfg.MaxStaleness,
+		logger:         logger,
+		now:            time.Now,
+		series:         map[string]*seriesState{},
+		stateFile:      cfg.StateFile,
+		stateMaxAge:    cfg.StateMaxAge,
+		maxStateSeries: cfg.MaxStateSeries,
+		done:           make(chan struct{}),
+	}
+	if p.stateMaxAge == 0 {
+		p.stateMaxAge = defaultStateMaxAge
+	}
+	if p.maxStateSeries == 0 {
+		p.maxStateSeries = defaultMaxStateSeries
+	}
+	for _, pattern := range cfg.DeltaToCumulative {
+		// validated with the config
+		if regex, err := regexp.Compile(pattern); err == nil {
+			p.deltaToCumulative = append(p.deltaToCumulative, regex)
+		}
+	}
+	return p
+}
+
+func (p *counterResetProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resourceKey := attributesKey(rm.Resource().Attributes())
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sm := sms.At(j)
+			scopeKey := resourceKey + "/" + sm.Scope().Name() + "/" + sm.Scope().Version()
+			metrics := sm.Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				m := metrics.At(k)
+				if m.Type() != pmetric.MetricTypeSum || !m.Sum().IsMonotonic() {
+					continue
+				}
+				dps := m.Sum().DataPoints()
+				switch m.Sum().AggregationTemporality() {
+				case pmetric.AggregationTemporalityCumulative:
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						p.adjust(scopeKey+"/"+m.Name()+"/"+attributesKey(dp.Attributes()), dp, now)
+					}
+				case pmetric.AggregationTemporalityDelta:
+					if !p.isDeltaToCumulative(m.Name()) {
+						continue
+					}
+					for l := 0; l < dps.Len(); l++ {
+						dp := dps.At(l)
+						p.accumulate(scopeKey+"/"+m.Name()+"/"+attributesKey(dp.Attributes()), dp, now)
+					}
+					m.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				}
+			}
+		}
+	}
+	p.removeStale(now)
+	return md, nil
+}
+
+// adjust detects a reset of the series, either from a decrease in value or a
+// new start timestamp, and offsets the data point by the values seen before
+// the reset. Downstream delta conversion then reports the new value as the
+// delta of a reset instead of a negative delta or a dropped point.
+func (p *counterResetProcessor) adjust(key string, dp pmetric.NumberDataPoint, now time.Time) {
+	state, ok := p.series[key]
+	if !ok {
+		p.series[key] = &seriesState{
+			start:      dp.StartTimestamp(),
+			rawStart:   dp.StartTimestamp(),
+			prevInt:    dp.IntValue(),
+			prevDouble: dp.DoubleValue(),
+			lastSeen:   now,
+		}
+		return
+	}
+	restarted := dp.StartTimestamp() != 0 && dp.StartTimestamp() != state.rawStart
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		value := dp.IntValue()
+		if restarted || value < state.prevInt {
+			state.offsetInt += state.prevInt
+			p.logger.Debug("Counter reset detected", zap.String("series", key))
+		}
+		state.prevInt = value
+		dp.SetIntValue(value + state.offsetInt)
+	case pmetric.NumberDataPointValueTypeDouble:
+		value := dp.DoubleValue()
+		if restarted || value < state.prevDouble {
+			state.offsetDouble += state.prevDouble
+			p.logger.Debug("Counter reset detected", zap.String("series", key))
+		}
+		state.prevDouble = value
+		dp.SetDoubleValue(value + state.offsetDouble)
+	}
+	state.rawStart = dp.StartTimestamp()
+	state.lastSeen = now
+	dp.SetStartTimestamp(state.start)
+}
+
+func (p *counterResetProcessor) isDeltaToCumulative(name string) bool {
+	for _, regex := range p.deltaToCumulative {
+		if regex.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// accumulate adds the delta data point to the total of the series and replaces
+// its value with the total. The start timestamp of the first data point of the
+// series is kept, so the data points form a single cumulative series.
+func (p *counterResetProcessor) accumulate(key string, dp pmetric.NumberDataPoint, now time.Time) {
+	state, ok := p.series[key]
+	if !ok {
+		start := dp.StartTimestamp()
+		if start == 0 {
+			start = dp.Timestamp()
+		}
+		state = &seriesState{start: start}
+		p.series[key] = state
+	}
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		state.offsetInt += dp.IntValue()
+		dp.SetIntValue(state.offsetInt)
+	case pmetric.NumberDataPointValueTypeDouble:
+		state.offsetDouble += dp.DoubleValue()
+		dp.SetDoubleValue(state.offsetDouble)
+	}
+	state.lastSeen = now
+	dp.SetStartTimestamp(state.start)
+}
+
+func (p *counterResetProcessor) removeStale(now time.Time) {
+	if p.maxStaleness <= 0 {
+		return
+	}
+	for key, state := range p.series {
+		if now.Sub(state.lastSeen) > p.maxStaleness {
+			delete(p.series, key)
+		}
+	}
+}
+
+func attributesKey(attrs pcommon.Map) string {
+	pairs := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		pairs = append(pairs, k+":"+v.AsString())
+		return true
+	})
+	sort.Strings(pairs)
+	return strings.Join(pairs, "|")
+}
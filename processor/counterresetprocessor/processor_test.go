@@ -0,0 +1,213 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package counterresetprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+type sample struct {
+	host  string
+	value float64
+	start pcommon.Timestamp
+}
+
+func TestProcessMetrics(t *testing.T) {
+	testCases := map[string]struct {
+		batches [][]sample
+		want    []map[string]float64
+	}{
+		"FirstSample": {
+			batches: [][]sample{{{host: "a", value: 42, start: 1}}},
+			want:    []map[string]float64{{"a": 42}},
+		},
+		"Increase": {
+			batches: [][]sample{
+				{{host: "a", value: 10, start: 1}},
+				{{host: "a", value: 15, start: 1}},
+				{{host: "a", value: 15, start: 1}},
+			},
+			want: []map[string]float64{{"a": 10}, {"a": 15}, {"a": 15}},
+		},
+		"Reset": {
+			batches: [][]sample{
+				{{host: "a", value: 10, start: 1}},
+				{{host: "a", value: 15, start: 1}},
+				// process restarted, the delta after the reset is 3
+				{{host: "a", value: 3, start: 1}},
+				{{host: "a", value: 5, start: 1}},
+				// second reset
+				{{host: "a", value: 1, start: 1}},
+			},
+			want: []map[string]float64{{"a": 10}, {"a": 15}, {"a": 18}, {"a": 20}, {"a": 21}},
+		},
+		"RestartWithNewStartTimestamp": {
+			batches: [][]sample{
+				{{host: "a", value: 10, start: 1}},
+				// value increased but the series was restarted
+				{{host: "a", value: 12, start: 5}},
+				{{host: "a", value: 13, start: 5}},
+			},
+			want: []map[string]float64{{"a": 10}, {"a": 22}, {"a": 23}},
+		},
+		"PerAttributeSet": {
+			batches: [][]sample{
+				{{host: "a", value: 10, start: 1}, {host: "b", value: 100, start: 1}},
+				// only host a resets
+				{{host: "a", value: 2, start: 1}, {host: "b", value: 110, start: 1}},
+			},
+			want: []map[string]float64{{"a": 10, "b": 100}, {"a": 12, "b": 110}},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p := newProcessor(&Config{}, zap.NewNop())
+			for i, batch := range testCase.batches {
+				got, err := p.processMetrics(context.Background(), buildSum(batch))
+				require.NoError(t, err)
+				dps := got.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+				values := map[string]float64{}
+				for j := 0; j < dps.Len(); j++ {
+					host, _ := dps.At(j).Attributes().Get("host")
+					values[host.Str()] = dps.At(j).DoubleValue()
+					// the adjusted series keeps the original start timestamp
+					assert.Equal(t, testCase.batches[0][0].start, dps.At(j).StartTimestamp())
+				}
+				assert.Equal(t, testCase.want[i], values, "batch %d", i)
+			}
+		})
+	}
+}
+
+func TestProcessMetricsIntValues(t *testing.T) {
+	p := newProcessor(&Config{}, zap.NewNop())
+	for _, tc := range []struct {
+		value int64
+		want  int64
+	}{{100, 100}, {150, 150}, {20, 170}} {
+		md := pmetric.NewMetrics()
+		m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName("net_bytes_sent")
+		sum := m.SetEmptySum()
+		sum.SetIsMonotonic(true)
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		sum.DataPoints().AppendEmpty().SetIntValue(tc.value)
+		got, err := p.processMetrics(context.Background(), md)
+		require.NoError(t, err)
+		assert.Equal(t, tc.want, got.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).IntValue())
+	}
+}
+
+func TestProcessMetricsIgnoresOtherMetrics(t *testing.T) {
+	p := newProcessor(&Config{}, zap.NewNop())
+	for _, value := range []float64{10, 5} {
+		md := pmetric.NewMetrics()
+		ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+		ms.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(value)
+		nonMonotonic := ms.AppendEmpty().SetEmptySum()
+		nonMonotonic.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		nonMonotonic.DataPoints().AppendEmpty().SetDoubleValue(value)
+		delta := ms.AppendEmpty().SetEmptySum()
+		delta.SetIsMonotonic(true)
+		delta.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		delta.DataPoints().AppendEmpty().SetDoubleValue(value)
+
+		got, err := p.processMetrics(context.Background(), md)
+		require.NoError(t, err)
+		gotMs := got.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+		assert.Equal(t, value, gotMs.At(0).Gauge().DataPoints().At(0).DoubleValue())
+		assert.Equal(t, value, gotMs.At(1).Sum().DataPoints().At(0).DoubleValue())
+		assert.Equal(t, value, gotMs.At(2).Sum().DataPoints().At(0).DoubleValue())
+	}
+	assert.Empty(t, p.series)
+}
+
+// TestProcessMetricsDeltaToCumulative pins one delta sum to cumulative while the
+// cumulative sums of the batch are still adjusted and other delta sums are passed
+// through. The series of both share the state of the processor.
+func TestProcessMetricsDeltaToCumulative(t *testing.T) {
+	p := newProcessor(&Config{DeltaToCumulative: []string{"^errors_"}}, zap.NewNop())
+	for i, tc := range []struct {
+		cumulative, delta    float64
+		wantCumulative, want float64
+	}{
+		{cumulative: 10, delta: 2, wantCumulative: 10, want: 2},
+		{cumulative: 15, delta: 0, wantCumulative: 15, want: 2},
+		{cumulative: 3, delta: 5, wantCumulative: 18, want: 7},
+	} {
+		md := buildSum([]sample{{host: "a", value: tc.cumulative, start: 1}})
+		ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+		for _, name := range []string{"errors_total", "latency_count"} {
+			m := ms.AppendEmpty()
+			m.SetName(name)
+			sum := m.SetEmptySum()
+			sum.SetIsMonotonic(true)
+			sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+			dp := sum.DataPoints().AppendEmpty()
+			dp.SetDoubleValue(tc.delta)
+			dp.SetTimestamp(pcommon.Timestamp(10 + i))
+			dp.Attributes().PutStr("host", "a")
+		}
+
+		got, err := p.processMetrics(context.Background(), md)
+		require.NoError(t, err)
+		gotMs := got.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+		assert.Equal(t, tc.wantCumulative, gotMs.At(0).Sum().DataPoints().At(0).DoubleValue())
+
+		pinned := gotMs.At(1).Sum()
+		assert.Equal(t, pmetric.AggregationTemporalityCumulative, pinned.AggregationTemporality())
+		assert.Equal(t, tc.want, pinned.DataPoints().At(0).DoubleValue())
+		// the start timestamp is the timestamp of the first delta
+		assert.Equal(t, pcommon.Timestamp(10), pinned.DataPoints().At(0).StartTimestamp())
+
+		other := gotMs.At(2).Sum()
+		assert.Equal(t, pmetric.AggregationTemporalityDelta, other.AggregationTemporality())
+		assert.Equal(t, tc.delta, other.DataPoints().At(0).DoubleValue())
+	}
+	assert.Len(t, p.series, 2)
+}
+
+func TestRemoveStale(t *testing.T) {
+	now := time.Now()
+	p := newProcessor(&Config{MaxStaleness: time.Minute}, zap.NewNop())
+	p.now = func() time.Time { return now }
+	_, err := p.processMetrics(context.Background(), buildSum([]sample{{host: "a", value: 10}}))
+	require.NoError(t, err)
+	require.Len(t, p.series, 1)
+
+	now = now.Add(2 * time.Minute)
+	got, err := p.processMetrics(context.Background(), buildSum([]sample{{host: "b", value: 1}}))
+	require.NoError(t, err)
+	assert.Equal(t, float64(1), got.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).DoubleValue())
+	assert.Len(t, p.series, 1)
+
+	// state of host a was removed, so a lower value is treated as a first sample
+	got, err = p.processMetrics(context.Background(), buildSum([]sample{{host: "a", value: 3}}))
+	require.NoError(t, err)
+	assert.Equal(t, float64(3), got.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).DoubleValue())
+}
+
+func buildSum(samples []sample) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("requests_total")
+	sum := m.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	for _, s := range samples {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.SetDoubleValue(s.value)
+		dp.SetStartTimestamp(s.start)
+		dp.Attributes().PutStr("host", s.host)
+	}
+	return md
+}
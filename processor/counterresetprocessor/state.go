@@ -0,0 +1,150 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package counterresetprocessor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultStateMaxAge    = 15 * time.Minute
+	defaultMaxStateSeries = 10000
+	stateSaveInterval     = time.Minute
+)
+
+// persistedSeries is the state of a series as it is saved in the state file.
+type persistedSeries struct {
+	Key          string    `json:"key"`
+	Start        uint64    `json:"start,omitempty"`
+	RawStart     uint64    `json:"raw_start,omitempty"`
+	PrevInt      int64     `json:"prev_int,omitempty"`
+	OffsetInt    int64     `json:"offset_int,omitempty"`
+	PrevDouble   float64   `json:"prev_double,omitempty"`
+	OffsetDouble float64   `json:"offset_double,omitempty"`
+	LastSeen     time.Time `json:"last_seen"`
+}
+
+// start restores the state saved by the previous run of the agent, so the series continue where they left off
+// instead of starting over, and saves the state periodically in case the agent does not shut down cleanly.
+func (p *counterResetProcessor) start(context.Context, component.Host) error {
+	if p.stateFile == "" {
+		return nil
+	}
+	p.loadState()
+	p.wg.Add(1)
+	go p.saveStatePeriodically()
+	return nil
+}
+
+func (p *counterResetProcessor) shutdown(context.Context) error {
+	if p.stateFile == "" {
+		return nil
+	}
+	close(p.done)
+	p.wg.Wait()
+	return p.saveState()
+}
+
+func (p *counterResetProcessor) saveStatePeriodically() {
+	defer p.wg.Done()
+	ticker := time.NewTicker(stateSaveInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.done:
+			return
+		case <-ticker.C:
+			if err := p.saveState(); err != nil {
+				p.logger.Warn("Unable to save counter state", zap.String("file", p.stateFile), zap.Error(err))
+			}
+		}
+	}
+}
+
+// loadState restores the series that were seen within the state max age. A missing file is expected on the first
+// start, while a file that cannot be read or parsed is logged and the processor starts without state.
+func (p *counterResetProcessor) loadState() {
+	content, err := os.ReadFile(p.stateFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return
+	}
+	if err != nil {
+		p.logger.Warn("Unable to read counter state", zap.String("file", p.stateFile), zap.Error(err))
+		return
+	}
+	var saved []persistedSeries
+	if err = json.Unmarshal(content, &saved); err != nil {
+		p.logger.Warn("Unable to parse counter state", zap.String("file", p.stateFile), zap.Error(err))
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	for _, s := range saved {
+		if now.Sub(s.LastSeen) > p.stateMaxAge {
+			continue
+		}
+		p.series[s.Key] = &seriesState{
+			start:        pcommon.Timestamp(s.Start),
+			rawStart:     pcommon.Timestamp(s.RawStart),
+			prevInt:      s.PrevInt,
+			offsetInt:    s.OffsetInt,
+			prevDouble:   s.PrevDouble,
+			offsetDouble: s.OffsetDouble,
+			lastSeen:     s.LastSeen,
+		}
+	}
+	p.logger.Debug("Restored counter state", zap.String("file", p.stateFile), zap.Int("series", len(p.series)))
+}
+
+// saveState writes the most recently seen series, up to the max state series, to a temporary file that replaces the
+// state file, so the state file is never partially written.
+func (p *counterResetProcessor) saveState() error {
+	p.mu.Lock()
+	saved := make([]persistedSeries, 0, len(p.series))
+	for key, state := range p.series {
+		saved = append(saved, persistedSeries{
+			Key:          key,
+			Start:        uint64(state.start),
+			RawStart:     uint64(state.rawStart),
+			PrevInt:      state.prevInt,
+			OffsetInt:    state.offsetInt,
+			PrevDouble:   state.prevDouble,
+			OffsetDouble: state.offsetDouble,
+			LastSeen:     state.lastSeen,
+		})
+	}
+	p.mu.Unlock()
+	sort.Slice(saved, func(i, j int) bool {
+		return saved[i].LastSeen.After(saved[j].LastSeen)
+	})
+	if len(saved) > p.maxStateSeries {
+		p.logger.Debug("Dropping the least recently seen series from the counter state",
+			zap.Int("series", len(saved)), zap.Int("max_state_series", p.maxStateSeries))
+		saved = saved[:p.maxStateSeries]
+	}
+	content, err := json.Marshal(saved)
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(p.stateFile), 0755); err != nil {
+		return err
+	}
+	tmp := p.stateFile + ".tmp"
+	if err = os.WriteFile(tmp, content, 0600); err != nil {
+		return fmt.Errorf("unable to write counter state: %w", err)
+	}
+	return os.Rename(tmp, p.stateFile)
+}
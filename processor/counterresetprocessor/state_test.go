@@ -0,0 +1,132 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package counterresetprocessor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/zap"
+)
+
+// processValues sends a batch of samples and returns the output values by host.
+func processValues(t *testing.T, p *counterResetProcessor, samples []sample) map[string]float64 {
+	got, err := p.processMetrics(context.Background(), buildSum(samples))
+	require.NoError(t, err)
+	dps := got.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	values := map[string]float64{}
+	for i := 0; i < dps.Len(); i++ {
+		host, _ := dps.At(i).Attributes().Get("host")
+		values[host.Str()] = dps.At(i).DoubleValue()
+	}
+	return values
+}
+
+func startWithState(t *testing.T, cfg *Config, now time.Time) *counterResetProcessor {
+	p := newProcessor(cfg, zap.NewNop())
+	p.now = func() time.Time { return now }
+	require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+	return p
+}
+
+func TestStateRestoredAfterRestart(t *testing.T) {
+	now := time.Now()
+	cfg := &Config{StateFile: filepath.Join(t.TempDir(), "state", "counterreset.json")}
+
+	p := startWithState(t, cfg, now)
+	assert.Equal(t, map[string]float64{"a": 10}, processValues(t, p, []sample{{host: "a", value: 10, start: 1}}))
+	assert.Equal(t, map[string]float64{"a": 15}, processValues(t, p, []sample{{host: "a", value: 15, start: 1}}))
+	// reset before the restart, so the series has an offset to restore
+	assert.Equal(t, map[string]float64{"a": 18}, processValues(t, p, []sample{{host: "a", value: 3, start: 1}}))
+	require.NoError(t, p.shutdown(context.Background()))
+	require.FileExists(t, cfg.StateFile)
+
+	// the process reporting the counter restarted while the agent was down, and the series continues from 18 instead
+	// of dropping to 1, which would be converted into a reset
+	restarted := startWithState(t, cfg, now.Add(time.Minute))
+	defer func() {
+		assert.NoError(t, restarted.shutdown(context.Background()))
+	}()
+	assert.Equal(t, map[string]float64{"a": 19}, processValues(t, restarted, []sample{{host: "a", value: 1, start: 1}}))
+	// the counter keeps increasing without another reset
+	assert.Equal(t, map[string]float64{"a": 24}, processValues(t, restarted, []sample{{host: "a", value: 6, start: 1}}))
+}
+
+func TestStateWithoutRestart(t *testing.T) {
+	now := time.Now()
+	cfg := &Config{StateFile: filepath.Join(t.TempDir(), "counterreset.json")}
+	p := startWithState(t, cfg, now)
+	processValues(t, p, []sample{{host: "a", value: 100, start: 1}})
+	require.NoError(t, p.shutdown(context.Background()))
+
+	// a counter that kept increasing while the agent was down is not treated as a reset
+	restarted := startWithState(t, cfg, now.Add(time.Minute))
+	defer func() {
+		assert.NoError(t, restarted.shutdown(context.Background()))
+	}()
+	assert.Equal(t, map[string]float64{"a": 130}, processValues(t, restarted, []sample{{host: "a", value: 130, start: 1}}))
+	for _, state := range restarted.series {
+		assert.Equal(t, float64(0), state.offsetDouble)
+	}
+}
+
+func TestStateMaxAge(t *testing.T) {
+	now := time.Now()
+	cfg := &Config{StateFile: filepath.Join(t.TempDir(), "counterreset.json"), StateMaxAge: 5 * time.Minute}
+	p := startWithState(t, cfg, now)
+	processValues(t, p, []sample{{host: "a", value: 100, start: 1}})
+	require.NoError(t, p.shutdown(context.Background()))
+
+	// the state is too old to be restored, so the series starts over
+	restarted := startWithState(t, cfg, now.Add(10*time.Minute))
+	defer func() {
+		assert.NoError(t, restarted.shutdown(context.Background()))
+	}()
+	assert.Empty(t, restarted.series)
+	assert.Equal(t, map[string]float64{"a": 1}, processValues(t, restarted, []sample{{host: "a", value: 1, start: 1}}))
+}
+
+func TestStateMaxSeries(t *testing.T) {
+	now := time.Now()
+	cfg := &Config{StateFile: filepath.Join(t.TempDir(), "counterreset.json"), MaxStateSeries: 2}
+	p := startWithState(t, cfg, now)
+	for i, host := range []string{"a", "b", "c"} {
+		p.now = func() time.Time { return now.Add(time.Duration(i) * time.Second) }
+		processValues(t, p, []sample{{host: host, value: 10, start: 1}})
+	}
+	require.NoError(t, p.shutdown(context.Background()))
+
+	// only the most recently seen series are saved
+	restarted := startWithState(t, cfg, now.Add(time.Minute))
+	defer func() {
+		assert.NoError(t, restarted.shutdown(context.Background()))
+	}()
+	assert.Len(t, restarted.series, 2)
+	assert.Equal(t, map[string]float64{"a": 1, "b": 11, "c": 11}, processValues(t, restarted, []sample{
+		{host: "a", value: 1, start: 1},
+		{host: "b", value: 1, start: 1},
+		{host: "c", value: 1, start: 1},
+	}))
+}
+
+func TestStateInvalidFile(t *testing.T) {
+	cfg := &Config{StateFile: filepath.Join(t.TempDir(), "counterreset.json")}
+	require.NoError(t, os.WriteFile(cfg.StateFile, []byte(`[{"key": `), 0600))
+	p := startWithState(t, cfg, time.Now())
+	assert.Empty(t, p.series)
+	processValues(t, p, []sample{{host: "a", value: 10, start: 1}})
+	// the invalid file is replaced on shutdown
+	require.NoError(t, p.shutdown(context.Background()))
+	restarted := startWithState(t, cfg, time.Now())
+	defer func() {
+		assert.NoError(t, restarted.shutdown(context.Background()))
+	}()
+	assert.Len(t, restarted.series, 1)
+}
@@ -0,0 +1,72 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cwlogsprocessor
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	defaultLogGroupNameAttribute  = "aws.log.group.names"
+	defaultLogStreamNameAttribute = "aws.log.stream.names"
+	defaultMaxLogStreams          = 100
+	defaultOverflowLogStreamName  = "overflow"
+)
+
+var (
+	errMissingLogGroupNameAttribute  = errors.New("log_group_name_attribute must be set")
+	errMissingLogStreamNameAttribute = errors.New("log_stream_name_attribute must be set")
+	errInvalidMaxLogStreams          = errors.New("max_log_streams must be at least 1")
+	errMissingOverflowLogStreamName  = errors.New("overflow_log_stream_name must be set")
+	errEmptyStructuredAttribute      = errors.New("structured_attributes must not contain an empty attribute")
+)
+
+type Config struct {
+	// LogGroupNameAttribute is the resource attribute that holds the log group the
+	// records of the resource are sent to.
+	LogGroupNameAttribute string `mapstructure:"log_group_name_attribute"`
+	// LogStreamNameAttribute is the resource attribute that holds the log stream
+	// the records of the resource are sent to.
+	LogStreamNameAttribute string `mapstructure:"log_stream_name_attribute"`
+	// LogStreamPartitionAttributes are the resource attributes whose values name
+	// the log stream of the records, such as one stream per pod. They take
+	// precedence over LogStreamNameAttribute.
+	LogStreamPartitionAttributes []string `mapstructure:"log_stream_partition_attributes,omitempty"`
+	// MaxLogStreams is the number of partitioned log streams of a log group. The
+	// records of new partitions over the limit are sent to OverflowLogStreamName.
+	MaxLogStreams int `mapstructure:"max_log_streams"`
+	// OverflowLogStreamName is the log stream shared by the partitions over the limit.
+	OverflowLogStreamName string `mapstructure:"overflow_log_stream_name"`
+	// StructuredAttributes are the log record attributes that are set as top level
+	// fields of the event, such as level, so they can be queried like the body
+	// instead of being nested in the attributes.
+	StructuredAttributes []string `mapstructure:"structured_attributes,omitempty"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if cfg.LogGroupNameAttribute == "" {
+		return errMissingLogGroupNameAttribute
+	}
+	if cfg.LogStreamNameAttribute == "" {
+		return errMissingLogStreamNameAttribute
+	}
+	if len(cfg.LogStreamPartitionAttributes) > 0 {
+		if cfg.MaxLogStreams < 1 {
+			return errInvalidMaxLogStreams
+		}
+		if cfg.OverflowLogStreamName == "" {
+			return errMissingOverflowLogStreamName
+		}
+	}
+	for _, attribute := range cfg.StructuredAttributes {
+		if attribute == "" {
+			return errEmptyStructuredAttribute
+		}
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cwlogsprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	testCases := []struct {
+		id      component.ID
+		want    component.Config
+		wantErr string
+	}{
+		{
+			id:   component.NewID(component.MustNewType(typeStr)),
+			want: NewFactory().CreateDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(component.MustNewType(typeStr), "1"),
+			want: &Config{
+				LogGroupNameAttribute:  "log.group",
+				LogStreamNameAttribute: "log.stream",
+				MaxLogStreams:          defaultMaxLogStreams,
+				OverflowLogStreamName:  defaultOverflowLogStreamName,
+			},
+		},
+		{
+			id: component.NewIDWithName(component.MustNewType(typeStr), "partition"),
+			want: &Config{
+				LogGroupNameAttribute:        defaultLogGroupNameAttribute,
+				LogStreamNameAttribute:       defaultLogStreamNameAttribute,
+				LogStreamPartitionAttributes: []string{"k8s.namespace.name", "k8s.pod.name"},
+				MaxLogStreams:                50,
+				OverflowLogStreamName:        "other-pods",
+			},
+		},
+		{
+			id: component.NewIDWithName(component.MustNewType(typeStr), "structured"),
+			want: &Config{
+				LogGroupNameAttribute:  defaultLogGroupNameAttribute,
+				LogStreamNameAttribute: defaultLogStreamNameAttribute,
+				MaxLogStreams:          defaultMaxLogStreams,
+				OverflowLogStreamName:  defaultOverflowLogStreamName,
+				StructuredAttributes:   []string{"level", "http.status_code"},
+			},
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "invalid"),
+			wantErr: errMissingLogGroupNameAttribute.Error(),
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "invalid_max_log_streams"),
+			wantErr: errInvalidMaxLogStreams.Error(),
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "empty_structured_attribute"),
+			wantErr: errEmptyStructuredAttribute.Error(),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.id.String(), func(t *testing.T) {
+			conf, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := conf.Sub(testCase.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, component.ValidateConfig(cfg), testCase.wantErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, testCase.want, cfg)
+		})
+	}
+}
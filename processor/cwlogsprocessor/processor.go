@@ -0,0 +1,263 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cwlogsprocessor
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.uber.org/zap"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
+)
+
+// partitionSeparator joins the values of the partition attributes in the log stream name.
+const partitionSeparator = "/"
+
+// awsMetadata is read by the awscloudwatchlogs exporter in raw log mode to
+// override the log group and stream configured on the exporter.
+type awsMetadata struct {
+	LogGroupName  string `json:"logGroupName,omitempty"`
+	LogStreamName string `json:"logStreamName,omitempty"`
+}
+
+type scopeBody struct {
+	Name       string         `json:"name,omitempty"`
+	Version    string         `json:"version,omitempty"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+// logEvent is the JSON message of the CloudWatch Logs event created for a log
+// record. The trace_id and span_id are kept to correlate the event with the
+// trace the record was emitted in.
+type logEvent struct {
+	AWS            *awsMetadata   `json:"_aws,omitempty"`
+	Body           any            `json:"body,omitempty"`
+	SeverityNumber int32          `json:"severity_number,omitempty"`
+	SeverityText   string         `json:"severity_text,omitempty"`
+	TraceID        string         `json:"trace_id,omitempty"`
+	SpanID         string         `json:"span_id,omitempty"`
+	Attributes     map[string]any `json:"attributes,omitempty"`
+	Scope          *scopeBody     `json:"scope,omitempty"`
+	Resource       map[string]any `json:"resource,omitempty"`
+}
+
+// reservedFields are the fields of the event that structured attributes cannot
+// replace. These attributes are kept in the attributes of the event.
+var reservedFields = collections.NewSet[string](
+	"_aws", "body", "severity_number", "severity_text", "trace_id", "span_id", "attributes", "scope", "resource",
+)
+
+type cwlogsProcessor struct {
+	logGroupNameAttribute  string
+	logStreamNameAttribute string
+	partitionAttributes    []string
+	maxLogStreams          int
+	overflowLogStreamName  string
+	structuredAttributes   []string
+	logger                 *zap.Logger
+	now                    func() time.Time
+
+	mu sync.Mutex
+	// partitions are the partitioned log streams of each log group.
+	partitions map[string]collections.Set[string]
+	// overflowed are the log groups whose partitions reached the limit.
+	overflowed collections.Set[string]
+}
+
+func newProcessor(cfg *Config, logger *zap.Logger) *cwlogsProcessor {
+	return &cwlogsProcessor{
+		logGroupNameAttribute:  cfg.LogGroupNameAttribute,
+		logStreamNameAttribute: cfg.LogStreamNameAttribute,
+		partitionAttributes:    cfg.LogStreamPartitionAttributes,
+		maxLogStreams:          cfg.MaxLogStreams,
+		overflowLogStreamName:  cfg.OverflowLogStreamName,
+		structuredAttributes:   cfg.StructuredAttributes,
+		logger:                 logger,
+		now:                    time.Now,
+		partitions:             map[string]collections.Set[string]{},
+		overflowed:             collections.NewSet[string](),
+	}
+}
+
+// processLogs replaces the body of every log record with the JSON message of
+// its CloudWatch Logs event. Records are routed to the log group and stream
+// set on their resource, otherwise the ones configured on the exporter are used.
+func (p *cwlogsProcessor) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resourceAttrs := rl.Resource().Attributes()
+		resource := attributesValue(resourceAttrs)
+		var metadata *awsMetadata
+		if logGroupName := firstValue(resourceAttrs, p.logGroupNameAttribute); logGroupName != "" {
+			metadata = &awsMetadata{
+				LogGroupName:  logGroupName,
+				LogStreamName: p.logStreamName(logGroupName, resourceAttrs),
+			}
+		}
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			sl := sls.At(j)
+			var scope *scopeBody
+			if sl.Scope().Name() != "" {
+				scope = &scopeBody{
+					Name:       sl.Scope().Name(),
+					Version:    sl.Scope().Version(),
+					Attributes: attributesValue(sl.Scope().Attributes()),
+				}
+			}
+			lrs := sl.LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				p.processLogRecord(lrs.At(k), metadata, scope, resource)
+			}
+		}
+	}
+	return ld, nil
+}
+
+func (p *cwlogsProcessor) processLogRecord(lr plog.LogRecord, metadata *awsMetadata, scope *scopeBody, resource map[string]any) {
+	attributes := attributesValue(lr.Attributes())
+	fields := p.structuredFields(attributes)
+	if len(attributes) == 0 {
+		attributes = nil
+	}
+	event := logEvent{
+		AWS:            metadata,
+		Body:           lr.Body().AsRaw(),
+		SeverityNumber: int32(lr.SeverityNumber()),
+		SeverityText:   lr.SeverityText(),
+		Attributes:     attributes,
+		Scope:          scope,
+		Resource:       resource,
+	}
+	if traceID := lr.TraceID(); !traceID.IsEmpty() {
+		event.TraceID = hex.EncodeToString(traceID[:])
+	}
+	if spanID := lr.SpanID(); !spanID.IsEmpty() {
+		event.SpanID = hex.EncodeToString(spanID[:])
+	}
+	message, err := json.Marshal(event)
+	if err == nil && len(fields) > 0 {
+		message, err = appendFields(message, fields)
+	}
+	if err != nil {
+		p.logger.Debug("Failed to convert log record to CloudWatch Logs event", zap.Error(err))
+		return
+	}
+	lr.Body().SetStr(string(message))
+	// the event timestamp is required by CloudWatch Logs, so fall back to when
+	// the record was observed
+	if lr.Timestamp() == 0 {
+		if lr.ObservedTimestamp() != 0 {
+			lr.SetTimestamp(lr.ObservedTimestamp())
+		} else {
+			lr.SetTimestamp(pcommon.NewTimestampFromTime(p.now()))
+		}
+	}
+}
+
+// structuredFields moves the structured attributes of the record out of its
+// attributes. CloudWatch Logs has no metadata on events, so the attributes are
+// set as top level fields of the JSON message, which Logs Insights discovers.
+func (p *cwlogsProcessor) structuredFields(attributes map[string]any) map[string]any {
+	var fields map[string]any
+	for _, attribute := range p.structuredAttributes {
+		value, ok := attributes[attribute]
+		if !ok || reservedFields.Contains(attribute) {
+			continue
+		}
+		if fields == nil {
+			fields = map[string]any{}
+		}
+		fields[attribute] = value
+		delete(attributes, attribute)
+	}
+	return fields
+}
+
+// appendFields adds the fields to the JSON object of the message.
+func appendFields(message []byte, fields map[string]any) ([]byte, error) {
+	encoded, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	if len(message) <= len("{}") {
+		return encoded, nil
+	}
+	message = append(message[:len(message)-1], ',')
+	return append(message, encoded[1:]...), nil
+}
+
+// logStreamName returns the partitioned log stream of the resource if it has any
+// of the partition attributes, otherwise the log stream set on the resource.
+func (p *cwlogsProcessor) logStreamName(logGroupName string, attrs pcommon.Map) string {
+	var values []string
+	for _, attribute := range p.partitionAttributes {
+		if value := firstValue(attrs, attribute); value != "" {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return firstValue(attrs, p.logStreamNameAttribute)
+	}
+	return p.partition(logGroupName, strings.Join(values, partitionSeparator))
+}
+
+// partition keeps the number of log streams of the log group within the limit.
+// Once the limit is reached, the new log streams are folded into the overflow one.
+func (p *cwlogsProcessor) partition(logGroupName, logStreamName string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	streams, ok := p.partitions[logGroupName]
+	if !ok {
+		streams = collections.NewSet[string]()
+		p.partitions[logGroupName] = streams
+	}
+	if streams.Contains(logStreamName) {
+		return logStreamName
+	}
+	if len(streams) < p.maxLogStreams {
+		streams.Add(logStreamName)
+		return logStreamName
+	}
+	// the overflow is logged once per log group, when the limit is first reached
+	if !p.overflowed.Contains(logGroupName) {
+		p.overflowed.Add(logGroupName)
+		p.logger.Warn("Log stream partitions reached the limit, sending new partitions to the overflow log stream",
+			zap.String("log_group_name", logGroupName),
+			zap.Int("max_log_streams", p.maxLogStreams),
+			zap.String("overflow_log_stream_name", p.overflowLogStreamName))
+	}
+	return p.overflowLogStreamName
+}
+
+// firstValue returns the value of the attribute. If the attribute is a slice,
+// as with the aws.log.group.names semantic convention, the first value is used.
+func firstValue(attrs pcommon.Map, key string) string {
+	value, ok := attrs.Get(key)
+	if !ok {
+		return ""
+	}
+	if value.Type() == pcommon.ValueTypeSlice {
+		if value.Slice().Len() == 0 {
+			return ""
+		}
+		return value.Slice().At(0).AsString()
+	}
+	return value.AsString()
+}
+
+func attributesValue(attrs pcommon.Map) map[string]any {
+	if attrs.Len() == 0 {
+		return nil
+	}
+	return attrs.AsRaw()
+}
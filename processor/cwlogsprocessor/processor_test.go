@@ -0,0 +1,300 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cwlogsprocessor
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/awscloudwatchlogsexporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+var (
+	testTraceID = pcommon.TraceID([16]byte{0x5b, 0x8e, 0xff, 0xf7, 0x98, 0x03, 0x81, 0x03, 0xd2, 0x69, 0xb6, 0x33, 0x81, 0x3f, 0xc6, 0x0c})
+	testSpanID  = pcommon.SpanID([8]byte{0xee, 0xe1, 0x9b, 0x7e, 0xc3, 0xc1, 0xb1, 0x74})
+	testTime    = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+)
+
+func newTestLogs(t *testing.T, resourceAttrs map[string]any) (plog.Logs, plog.LogRecord) {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	require.NoError(t, rl.Resource().Attributes().FromRaw(resourceAttrs))
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("checkout-logger")
+	sl.Scope().SetVersion("1.0.0")
+	lr := sl.LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(testTime))
+	lr.SetSeverityNumber(plog.SeverityNumberError)
+	lr.SetSeverityText("ERROR")
+	lr.Body().SetStr("payment failed")
+	lr.Attributes().PutStr("order.id", "1234")
+	lr.SetTraceID(testTraceID)
+	lr.SetSpanID(testSpanID)
+	return ld, lr
+}
+
+func TestProcessLogs(t *testing.T) {
+	testCases := map[string]struct {
+		resourceAttrs map[string]any
+		want          string
+	}{
+		"WithoutLogGroup": {
+			resourceAttrs: map[string]any{"service.name": "checkout"},
+			want: `{"body":"payment failed","severity_number":17,"severity_text":"ERROR",` +
+				`"trace_id":"5b8efff798038103d269b633813fc60c","span_id":"eee19b7ec3c1b174",` +
+				`"attributes":{"order.id":"1234"},"scope":{"name":"checkout-logger","version":"1.0.0"},` +
+				`"resource":{"service.name":"checkout"}}`,
+		},
+		"WithLogGroupAndStream": {
+			resourceAttrs: map[string]any{
+				"aws.log.group.names":  []any{"/app/checkout", "/app/other"},
+				"aws.log.stream.names": []any{"host-1"},
+			},
+			want: `{"_aws":{"logGroupName":"/app/checkout","logStreamName":"host-1"},"body":"payment failed",` +
+				`"severity_number":17,"severity_text":"ERROR",` +
+				`"trace_id":"5b8efff798038103d269b633813fc60c","span_id":"eee19b7ec3c1b174",` +
+				`"attributes":{"order.id":"1234"},"scope":{"name":"checkout-logger","version":"1.0.0"},` +
+				`"resource":{"aws.log.group.names":["/app/checkout","/app/other"],"aws.log.stream.names":["host-1"]}}`,
+		},
+		"WithStringLogGroup": {
+			resourceAttrs: map[string]any{"aws.log.group.names": "/app/checkout"},
+			want: `{"_aws":{"logGroupName":"/app/checkout"},"body":"payment failed",` +
+				`"severity_number":17,"severity_text":"ERROR",` +
+				`"trace_id":"5b8efff798038103d269b633813fc60c","span_id":"eee19b7ec3c1b174",` +
+				`"attributes":{"order.id":"1234"},"scope":{"name":"checkout-logger","version":"1.0.0"},` +
+				`"resource":{"aws.log.group.names":"/app/checkout"}}`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p := newProcessor(createDefaultConfig().(*Config), zap.NewNop())
+			ld, lr := newTestLogs(t, testCase.resourceAttrs)
+			_, err := p.processLogs(context.Background(), ld)
+			require.NoError(t, err)
+			assert.JSONEq(t, testCase.want, lr.Body().Str())
+			assert.Equal(t, pcommon.NewTimestampFromTime(testTime), lr.Timestamp())
+		})
+	}
+}
+
+func TestProcessLogsTimestamp(t *testing.T) {
+	p := newProcessor(createDefaultConfig().(*Config), zap.NewNop())
+	p.now = func() time.Time { return testTime }
+
+	ld, lr := newTestLogs(t, nil)
+	lr.SetTimestamp(0)
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(testTime.Add(time.Second)))
+	_, err := p.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	assert.Equal(t, pcommon.NewTimestampFromTime(testTime.Add(time.Second)), lr.Timestamp())
+
+	ld, lr = newTestLogs(t, nil)
+	lr.SetTimestamp(0)
+	_, err = p.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	assert.Equal(t, pcommon.NewTimestampFromTime(testTime), lr.Timestamp())
+}
+
+func TestProcessLogsStructuredAttributes(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.StructuredAttributes = []string{"level", "http.status_code", "trace_id", "missing"}
+	p := newProcessor(cfg, zap.NewNop())
+
+	ld, lr := newTestLogs(t, nil)
+	lr.Attributes().PutStr("level", "error")
+	lr.Attributes().PutInt("http.status_code", 502)
+	// reserved by the event, so it stays in the attributes
+	lr.Attributes().PutStr("trace_id", "from-attribute")
+	_, err := p.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"body":"payment failed","severity_number":17,"severity_text":"ERROR",`+
+		`"trace_id":"5b8efff798038103d269b633813fc60c","span_id":"eee19b7ec3c1b174",`+
+		`"attributes":{"order.id":"1234","trace_id":"from-attribute"},"scope":{"name":"checkout-logger","version":"1.0.0"},`+
+		`"level":"error","http.status_code":502}`, lr.Body().Str())
+
+	// the attributes are left out once all of them are structured
+	ld = plog.NewLogs()
+	lr = ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(testTime))
+	lr.Attributes().PutStr("level", "info")
+	_, err = p.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"level":"info"}`, lr.Body().Str())
+}
+
+func TestProcessLogsPartition(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	cfg := createDefaultConfig().(*Config)
+	cfg.LogStreamPartitionAttributes = []string{"k8s.namespace.name", "k8s.pod.name"}
+	cfg.MaxLogStreams = 3
+	p := newProcessor(cfg, zap.New(core))
+
+	logStreamName := func(resourceAttrs map[string]any) string {
+		ld, lr := newTestLogs(t, resourceAttrs)
+		_, err := p.processLogs(context.Background(), ld)
+		require.NoError(t, err)
+		var event logEvent
+		require.NoError(t, json.Unmarshal([]byte(lr.Body().Str()), &event))
+		require.NotNil(t, event.AWS)
+		return event.AWS.LogStreamName
+	}
+
+	// more distinct pods than the limit
+	var got []string
+	for _, pod := range []string{"pod-1", "pod-2", "pod-3", "pod-4", "pod-5", "pod-1"} {
+		got = append(got, logStreamName(map[string]any{
+			"aws.log.group.names": "/app/checkout",
+			"k8s.namespace.name":  "default",
+			"k8s.pod.name":        pod,
+		}))
+	}
+	assert.Equal(t, []string{
+		"default/pod-1", "default/pod-2", "default/pod-3", "overflow", "overflow", "default/pod-1",
+	}, got)
+	require.Equal(t, 1, logs.Len())
+	assert.Equal(t, "/app/checkout", logs.All()[0].ContextMap()["log_group_name"])
+
+	// the limit is per log group
+	assert.Equal(t, "default/pod-4", logStreamName(map[string]any{
+		"aws.log.group.names": "/app/other",
+		"k8s.namespace.name":  "default",
+		"k8s.pod.name":        "pod-4",
+	}))
+	// resources without the partition attributes use their log stream
+	assert.Equal(t, "host-1", logStreamName(map[string]any{
+		"aws.log.group.names":  "/app/checkout",
+		"aws.log.stream.names": "host-1",
+	}))
+	assert.Equal(t, 1, logs.Len())
+}
+
+type putLogEventsInput struct {
+	LogGroupName  string `json:"logGroupName"`
+	LogStreamName string `json:"logStreamName"`
+	LogEvents     []struct {
+		Message   string `json:"message"`
+		Timestamp int64  `json:"timestamp"`
+	} `json:"logEvents"`
+}
+
+// fakeCloudWatchLogs records the PutLogEvents requests and accepts every other call.
+type fakeCloudWatchLogs struct {
+	mu     sync.Mutex
+	inputs []putLogEventsInput
+}
+
+func (f *fakeCloudWatchLogs) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasSuffix(r.Header.Get("X-Amz-Target"), ".PutLogEvents") {
+		var reader io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			defer gz.Close()
+			reader = gz
+		}
+		var input putLogEventsInput
+		if err := json.NewDecoder(reader).Decode(&input); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		f.mu.Lock()
+		f.inputs = append(f.inputs, input)
+		f.mu.Unlock()
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		_, _ = w.Write([]byte(`{"nextSequenceToken":"token"}`))
+		return
+	}
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	_, _ = w.Write([]byte(`{}`))
+}
+
+func (f *fakeCloudWatchLogs) putLogEventsInputs() []putLogEventsInput {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.inputs
+}
+
+func TestExportLogs(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "access_key")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret_key")
+	t.Setenv("AWS_CA_BUNDLE", "")
+	service := &fakeCloudWatchLogs{}
+	server := httptest.NewServer(service)
+	defer server.Close()
+
+	exporterFactory := awscloudwatchlogsexporter.NewFactory()
+	exporterCfg := exporterFactory.CreateDefaultConfig().(*awscloudwatchlogsexporter.Config)
+	exporterCfg.Region = "us-east-1"
+	exporterCfg.Endpoint = server.URL
+	exporterCfg.AWSSessionSettings.Endpoint = server.URL
+	exporterCfg.LogGroupName = "/otlp/default"
+	exporterCfg.LogStreamName = "default"
+	exporterCfg.RawLog = true
+	exporterCfg.QueueSettings.Enabled = false
+	exporterCfg.BackOffConfig.Enabled = false
+	exporter, err := exporterFactory.CreateLogs(context.Background(), exportertest.NewNopSettings(), exporterCfg)
+	require.NoError(t, err)
+	require.NoError(t, exporter.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, exporter.Shutdown(context.Background())) }()
+
+	processor, err := NewFactory().CreateLogs(context.Background(), processortest.NewNopSettings(), createDefaultConfig(), exporter)
+	require.NoError(t, err)
+	require.NoError(t, processor.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, processor.Shutdown(context.Background())) }()
+
+	// CloudWatch Logs rejects events older than 14 days
+	now := time.Now().Truncate(time.Millisecond)
+	ld, lr := newTestLogs(t, map[string]any{
+		"service.name":         "checkout",
+		"aws.log.group.names":  []any{"/app/checkout"},
+		"aws.log.stream.names": []any{"host-1"},
+	})
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(now))
+	otherLogs, lr := newTestLogs(t, map[string]any{"service.name": "other"})
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(now))
+	otherLogs.ResourceLogs().MoveAndAppendTo(ld.ResourceLogs())
+	require.NoError(t, processor.ConsumeLogs(context.Background(), ld))
+
+	inputs := service.putLogEventsInputs()
+	require.Len(t, inputs, 2)
+	got := map[string]putLogEventsInput{}
+	for _, input := range inputs {
+		got[input.LogGroupName+":"+input.LogStreamName] = input
+	}
+	for _, target := range []string{"/app/checkout:host-1", "/otlp/default:default"} {
+		input, ok := got[target]
+		require.True(t, ok, target)
+		require.Len(t, input.LogEvents, 1)
+		assert.Equal(t, now.UnixMilli(), input.LogEvents[0].Timestamp)
+
+		var event map[string]any
+		require.NoError(t, json.Unmarshal([]byte(input.LogEvents[0].Message), &event))
+		assert.Equal(t, "payment failed", event["body"])
+		assert.Equal(t, "ERROR", event["severity_text"])
+		assert.EqualValues(t, plog.SeverityNumberError, event["severity_number"])
+		assert.Equal(t, map[string]any{"order.id": "1234"}, event["attributes"])
+		assert.Equal(t, "5b8efff798038103d269b633813fc60c", event["trace_id"])
+		assert.Equal(t, "eee19b7ec3c1b174", event["span_id"])
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dedupprocessor
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	defaultWindow     = time.Minute
+	defaultMaxEntries = 100000
+)
+
+var (
+	errInvalidWindow     = errors.New("window must be greater than 0")
+	errInvalidMaxEntries = errors.New("max_entries must be at least 1")
+)
+
+type Config struct {
+	// Window is how long a data point is remembered after it is first seen. The same data point seen again within the
+	// window is dropped.
+	Window time.Duration `mapstructure:"window"`
+	// MaxEntries is the number of data points remembered. The least recently seen data points are forgotten first.
+	MaxEntries int `mapstructure:"max_entries"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if cfg.Window <= 0 {
+		return errInvalidWindow
+	}
+	if cfg.MaxEntries < 1 {
+		return errInvalidMaxEntries
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dedupprocessor
+
+import (
+	"context"
+	"encoding/binary"
+	"hash"
+	"hash/fnv"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/golang-lru/simplelru"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+)
+
+type dedupProcessor struct {
+	window time.Duration
+	logger *zap.Logger
+	now    func() time.Time
+
+	mu sync.Mutex
+	// seen maps the key of a data point to the time it was first seen within the window.
+	seen *simplelru.LRU
+}
+
+func newProcessor(cfg *Config, logger *zap.Logger) (*dedupProcessor, error) {
+	seen, err := simplelru.NewLRU(cfg.MaxEntries, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &dedupProcessor{
+		window: cfg.Window,
+		logger: logger,
+		now:    time.Now,
+		seen:   seen,
+	}, nil
+}
+
+func (p *dedupProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	now := p.now()
+	dropped := 0
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		h := newKeyHasher()
+		h.writeMap(rm.Resource().Attributes())
+		resourceKey := h.Sum64()
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				remaining, n := p.removeDuplicates(resourceKey, m, now)
+				dropped += n
+				return remaining == 0
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	if dropped > 0 {
+		p.logger.Debug("Dropped duplicate data points", zap.Int("count", dropped))
+	}
+	if md.ResourceMetrics().Len() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
+
+// removeDuplicates removes the data points of the metric that were already seen within the window. Returns the
+// number of remaining and removed data points.
+func (p *dedupProcessor) removeDuplicates(resourceKey uint64, m pmetric.Metric, now time.Time) (int, int) {
+	dropped := 0
+	isDuplicate := func(writeValue func(h *keyHasher), attrs pcommon.Map, timestamp pcommon.Timestamp) bool {
+		h := newKeyHasher()
+		h.writeUint64(resourceKey)
+		h.writeString(m.Name())
+		h.writeUint64(uint64(m.Type()))
+		h.writeMap(attrs)
+		h.writeUint64(uint64(timestamp))
+		writeValue(h)
+		if p.isDuplicate(h.Sum64(), now) {
+			dropped++
+			return true
+		}
+		return false
+	}
+	var remaining int
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+			return isDuplicate(func(h *keyHasher) { h.writeNumber(dp) }, dp.Attributes(), dp.Timestamp())
+		})
+		remaining = dps.Len()
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+			return isDuplicate(func(h *keyHasher) { h.writeNumber(dp) }, dp.Attributes(), dp.Timestamp())
+		})
+		remaining = dps.Len()
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		dps.RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+			return isDuplicate(func(h *keyHasher) { h.writeHistogram(dp) }, dp.Attributes(), dp.Timestamp())
+		})
+		remaining = dps.Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+			return isDuplicate(func(h *keyHasher) { h.writeExponentialHistogram(dp) }, dp.Attributes(), dp.Timestamp())
+		})
+		remaining = dps.Len()
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		dps.RemoveIf(func(dp pmetric.SummaryDataPoint) bool {
+			return isDuplicate(func(h *keyHasher) { h.writeSummary(dp) }, dp.Attributes(), dp.Timestamp())
+		})
+		remaining = dps.Len()
+	default:
+		// keep the metrics without data points
+		return 1, 0
+	}
+	return remaining, dropped
+}
+
+// isDuplicate returns true if the key was first seen within the window. Otherwise, the key is seen now.
+func (p *dedupProcessor) isDuplicate(key uint64, now time.Time) bool {
+	if firstSeen, ok := p.seen.Get(key); ok && now.Sub(firstSeen.(time.Time)) < p.window {
+		return true
+	}
+	p.seen.Add(key, now)
+	return false
+}
+
+// keyHasher hashes the content of a data point into its key.
+type keyHasher struct {
+	hash.Hash64
+	buf [8]byte
+}
+
+func newKeyHasher() *keyHasher {
+	return &keyHasher{Hash64: fnv.New64a()}
+}
+
+func (h *keyHasher) writeString(s string) {
+	h.writeUint64(uint64(len(s)))
+	_, _ = h.Write([]byte(s))
+}
+
+func (h *keyHasher) writeUint64(v uint64) {
+	binary.LittleEndian.PutUint64(h.buf[:], v)
+	_, _ = h.Write(h.buf[:])
+}
+
+func (h *keyHasher) writeFloat64(v float64) {
+	h.writeUint64(math.Float64bits(v))
+}
+
+// writeMap writes the attributes in key order so that the same attributes in any order have the same key.
+func (h *keyHasher) writeMap(attrs pcommon.Map) {
+	keys := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Strings(keys)
+	h.writeUint64(uint64(len(keys)))
+	for _, k := range keys {
+		v, _ := attrs.Get(k)
+		h.writeString(k)
+		h.writeUint64(uint64(v.Type()))
+		h.writeString(v.AsString())
+	}
+}
+
+func (h *keyHasher) writeNumber(dp pmetric.NumberDataPoint) {
+	h.writeUint64(uint64(dp.ValueType()))
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		h.writeUint64(uint64(dp.IntValue()))
+	case pmetric.NumberDataPointValueTypeDouble:
+		h.writeFloat64(dp.DoubleValue())
+	}
+}
+
+func (h *keyHasher) writeHistogram(dp pmetric.HistogramDataPoint) {
+	h.writeUint64(dp.Count())
+	h.writeFloat64(dp.Sum())
+	h.writeFloat64(dp.Min())
+	h.writeFloat64(dp.Max())
+	h.writeUint64(uint64(dp.BucketCounts().Len()))
+	for _, count := range dp.BucketCounts().AsRaw() {
+		h.writeUint64(count)
+	}
+	h.writeUint64(uint64(dp.ExplicitBounds().Len()))
+	for _, bound := range dp.ExplicitBounds().AsRaw() {
+		h.writeFloat64(bound)
+	}
+}
+
+func (h *keyHasher) writeExponentialHistogram(dp pmetric.ExponentialHistogramDataPoint) {
+	h.writeUint64(dp.Count())
+	h.writeFloat64(dp.Sum())
+	h.writeFloat64(dp.Min())
+	h.writeFloat64(dp.Max())
+	h.writeUint64(uint64(dp.Scale()))
+	h.writeUint64(dp.ZeroCount())
+	for _, buckets := range []pmetric.ExponentialHistogramDataPointBuckets{dp.Positive(), dp.Negative()} {
+		h.writeUint64(uint64(buckets.Offset()))
+		h.writeUint64(uint64(buckets.BucketCounts().Len()))
+		for _, count := range buckets.BucketCounts().AsRaw() {
+			h.writeUint64(count)
+		}
+	}
+}
+
+func (h *keyHasher) writeSummary(dp pmetric.SummaryDataPoint) {
+	h.writeUint64(dp.Count())
+	h.writeFloat64(dp.Sum())
+	qvs := dp.QuantileValues()
+	h.writeUint64(uint64(qvs.Len()))
+	for i := 0; i < qvs.Len(); i++ {
+		h.writeFloat64(qvs.At(i).Quantile())
+		h.writeFloat64(qvs.At(i).Value())
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dedupprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+)
+
+var timestamp = pcommon.NewTimestampFromTime(time.Unix(1700000000, 0))
+
+type dataPoint struct {
+	name      string
+	attrs     map[string]any
+	timestamp pcommon.Timestamp
+	value     float64
+}
+
+func newMetrics(dps ...dataPoint) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("ClusterName", "cluster")
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	for _, d := range dps {
+		m := ms.AppendEmpty()
+		m.SetName(d.name)
+		dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+		_ = dp.Attributes().FromRaw(d.attrs)
+		dp.SetTimestamp(d.timestamp)
+		dp.SetDoubleValue(d.value)
+	}
+	return md
+}
+
+func names(md pmetric.Metrics) []string {
+	var result []string
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				result = append(result, ms.At(k).Name())
+			}
+		}
+	}
+	return result
+}
+
+func newTestProcessor(t *testing.T, cfg *Config) (*dedupProcessor, *time.Time) {
+	t.Helper()
+	p, err := newProcessor(cfg, zap.NewNop())
+	require.NoError(t, err)
+	now := time.Now()
+	p.now = func() time.Time { return now }
+	return p, &now
+}
+
+func TestProcessMetrics(t *testing.T) {
+	p, _ := newTestProcessor(t, &Config{Window: time.Minute, MaxEntries: defaultMaxEntries})
+
+	md, err := p.processMetrics(context.Background(), newMetrics(
+		dataPoint{name: "cpu", attrs: map[string]any{"Pod": "a", "Namespace": "ns"}, timestamp: timestamp, value: 1},
+		// duplicate in the same batch
+		dataPoint{name: "cpu", attrs: map[string]any{"Namespace": "ns", "Pod": "a"}, timestamp: timestamp, value: 1},
+		dataPoint{name: "memory", attrs: map[string]any{"Pod": "a", "Namespace": "ns"}, timestamp: timestamp, value: 1},
+	))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cpu", "memory"}, names(md))
+
+	// from an overlapping pipeline, where only the data points that differ are kept
+	md, err = p.processMetrics(context.Background(), newMetrics(
+		dataPoint{name: "cpu", attrs: map[string]any{"Pod": "a", "Namespace": "ns"}, timestamp: timestamp, value: 1},
+		dataPoint{name: "cpu", attrs: map[string]any{"Pod": "b", "Namespace": "ns"}, timestamp: timestamp, value: 1},
+		dataPoint{name: "memory", attrs: map[string]any{"Pod": "a", "Namespace": "ns"}, timestamp: timestamp + 1, value: 1},
+		dataPoint{name: "memory", attrs: map[string]any{"Pod": "a", "Namespace": "ns"}, timestamp: timestamp, value: 2},
+	))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cpu", "memory", "memory"}, names(md))
+
+	// the same data point from another resource is not a duplicate
+	md = newMetrics(dataPoint{name: "cpu", attrs: map[string]any{"Pod": "a", "Namespace": "ns"}, timestamp: timestamp, value: 1})
+	md.ResourceMetrics().At(0).Resource().Attributes().PutStr("ClusterName", "other")
+	md, err = p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cpu"}, names(md))
+}
+
+func TestProcessMetricsWindow(t *testing.T) {
+	p, now := newTestProcessor(t, &Config{Window: time.Minute, MaxEntries: defaultMaxEntries})
+	dp := dataPoint{name: "cpu", attrs: map[string]any{"Pod": "a"}, timestamp: timestamp, value: 1}
+
+	md, err := p.processMetrics(context.Background(), newMetrics(dp))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cpu"}, names(md))
+
+	*now = now.Add(30 * time.Second)
+	md, err = p.processMetrics(context.Background(), newMetrics(dp))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+
+	// the window starts when the data point is first seen
+	*now = now.Add(30 * time.Second)
+	md, err = p.processMetrics(context.Background(), newMetrics(dp))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cpu"}, names(md))
+
+	*now = now.Add(59 * time.Second)
+	_, err = p.processMetrics(context.Background(), newMetrics(dp))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+}
+
+func TestProcessMetricsMaxEntries(t *testing.T) {
+	p, _ := newTestProcessor(t, &Config{Window: time.Minute, MaxEntries: 2})
+	a := dataPoint{name: "a", timestamp: timestamp, value: 1}
+	b := dataPoint{name: "b", timestamp: timestamp, value: 1}
+	c := dataPoint{name: "c", timestamp: timestamp, value: 1}
+
+	md, err := p.processMetrics(context.Background(), newMetrics(a, b, c))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, names(md))
+	assert.Equal(t, 2, p.seen.Len())
+
+	// a was evicted by c
+	md, err = p.processMetrics(context.Background(), newMetrics(c, b, a))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, names(md))
+}
+
+func TestProcessMetricsTypes(t *testing.T) {
+	p, _ := newTestProcessor(t, &Config{Window: time.Minute, MaxEntries: defaultMaxEntries})
+	newHistogramMetrics := func(count uint64) pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+		m := ms.AppendEmpty()
+		m.SetName("latency")
+		dp := m.SetEmptyHistogram().DataPoints().AppendEmpty()
+		dp.SetTimestamp(timestamp)
+		dp.SetCount(count)
+		dp.BucketCounts().FromRaw([]uint64{count})
+		m = ms.AppendEmpty()
+		m.SetName("quantiles")
+		sdp := m.SetEmptySummary().DataPoints().AppendEmpty()
+		sdp.SetTimestamp(timestamp)
+		sdp.SetCount(count)
+		return md
+	}
+
+	md, err := p.processMetrics(context.Background(), newHistogramMetrics(1))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"latency", "quantiles"}, names(md))
+	_, err = p.processMetrics(context.Background(), newHistogramMetrics(1))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	md, err = p.processMetrics(context.Background(), newHistogramMetrics(2))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"latency", "quantiles"}, names(md))
+}
@@ -0,0 +1,67 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package derivedmetricsprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type Operation string
+
+const (
+	OperationAdd      Operation = "add"
+	OperationSubtract Operation = "subtract"
+	OperationMultiply Operation = "multiply"
+	OperationDivide   Operation = "divide"
+	// OperationPercent divides the first metric by the second and multiplies by 100.
+	OperationPercent Operation = "percent"
+)
+
+var (
+	errMissingName          = errors.New("derived metric name must be set")
+	errMissingOperands      = errors.New("derived metric requires both metric1 and metric2")
+	errUnsupportedOperation = errors.New("unsupported operation")
+)
+
+type Config struct {
+	// Rules are the derived metrics to compute.
+	Rules []Rule `mapstructure:"rules,omitempty"`
+}
+
+// Rule computes a new metric from the data points of two existing metrics
+// that share the same attribute set.
+type Rule struct {
+	// Name of the derived metric.
+	Name string `mapstructure:"name"`
+	// Unit of the derived metric.
+	Unit string `mapstructure:"unit,omitempty"`
+	// Metric1 is the name of the first operand (the numerator for divide/percent).
+	Metric1 string `mapstructure:"metric1"`
+	// Metric2 is the name of the second operand (the denominator for divide/percent).
+	Metric2 string `mapstructure:"metric2"`
+	// Operation applied to the operands.
+	Operation Operation `mapstructure:"operation"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	for _, rule := range cfg.Rules {
+		if rule.Name == "" {
+			return errMissingName
+		}
+		if rule.Metric1 == "" || rule.Metric2 == "" {
+			return fmt.Errorf("%w: %s", errMissingOperands, rule.Name)
+		}
+		switch rule.Operation {
+		case OperationAdd, OperationSubtract, OperationMultiply, OperationDivide, OperationPercent:
+		default:
+			return fmt.Errorf("%w %q for derived metric %q", errUnsupportedOperation, rule.Operation, rule.Name)
+		}
+	}
+	return nil
+}
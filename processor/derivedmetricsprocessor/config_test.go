@@ -0,0 +1,63 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package derivedmetricsprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	testCases := []struct {
+		id      component.ID
+		want    component.Config
+		wantErr string
+	}{
+		{
+			id:   component.NewID(component.MustNewType(typeStr)),
+			want: NewFactory().CreateDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(component.MustNewType(typeStr), "1"),
+			want: &Config{Rules: []Rule{
+				{Name: "mem_used_percent", Unit: "Percent", Metric1: "mem_used", Metric2: "mem_total", Operation: OperationPercent},
+			}},
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "invalid_operation"),
+			wantErr: `unsupported operation "modulo" for derived metric "mem_used_percent"`,
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "missing_operand"),
+			wantErr: errMissingOperands.Error(),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.id.String(), func(t *testing.T) {
+			conf, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := conf.Sub(testCase.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, component.ValidateConfig(cfg), testCase.wantErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, testCase.want, cfg)
+		})
+	}
+}
+
+func TestValidateUnsupportedOperation(t *testing.T) {
+	cfg := &Config{Rules: []Rule{{Name: "mem_used_mod", Metric1: "mem_used", Metric2: "mem_total", Operation: "modulo"}}}
+	assert.ErrorIs(t, cfg.Validate(), errUnsupportedOperation)
+}
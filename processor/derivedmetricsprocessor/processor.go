@@ -0,0 +1,142 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package derivedmetricsprocessor
+
+import (
+	"context"
+	"math"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+type derivedMetricsProcessor struct {
+	rules  []Rule
+	logger *zap.Logger
+}
+
+func newProcessor(cfg *Config, logger *zap.Logger) *derivedMetricsProcessor {
+	return &derivedMetricsProcessor{
+		rules:  cfg.Rules,
+		logger: logger,
+	}
+}
+
+func (p *derivedMetricsProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	if len(p.rules) == 0 {
+		return md, nil
+	}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			p.processScopeMetrics(sms.At(j).Metrics())
+		}
+	}
+	return md, nil
+}
+
+// processScopeMetrics evaluates each rule against the metrics within a single
+// scope and appends the derived metrics to the same scope.
+func (p *derivedMetricsProcessor) processScopeMetrics(metrics pmetric.MetricSlice) {
+	byName := make(map[string]pmetric.NumberDataPointSlice, metrics.Len())
+	for k := 0; k < metrics.Len(); k++ {
+		m := metrics.At(k)
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			byName[m.Name()] = m.Gauge().DataPoints()
+		case pmetric.MetricTypeSum:
+			byName[m.Name()] = m.Sum().DataPoints()
+		}
+	}
+	for _, rule := range p.rules {
+		dps1, ok1 := byName[rule.Metric1]
+		dps2, ok2 := byName[rule.Metric2]
+		if !ok1 || !ok2 {
+			continue
+		}
+		derived := pmetric.NewMetric()
+		derived.SetName(rule.Name)
+		derived.SetUnit(rule.Unit)
+		dps := derived.SetEmptyGauge().DataPoints()
+
+		operands := make(map[string]float64, dps2.Len())
+		for i := 0; i < dps2.Len(); i++ {
+			dp := dps2.At(i)
+			operands[attributesKey(dp.Attributes())] = numberValue(dp)
+		}
+		for i := 0; i < dps1.Len(); i++ {
+			dp1 := dps1.At(i)
+			value2, ok := operands[attributesKey(dp1.Attributes())]
+			if !ok {
+				continue
+			}
+			value, ok := apply(rule.Operation, numberValue(dp1), value2)
+			if !ok {
+				p.logger.Debug("Skipping derived metric data point",
+					zap.String("metric", rule.Name),
+					zap.String("operation", string(rule.Operation)))
+				continue
+			}
+			dp := dps.AppendEmpty()
+			dp1.Attributes().CopyTo(dp.Attributes())
+			dp.SetStartTimestamp(dp1.StartTimestamp())
+			dp.SetTimestamp(dp1.Timestamp())
+			dp.SetDoubleValue(value)
+			// keep the exemplars of the first operand to correlate the derived metric with traces
+			dp1.Exemplars().CopyTo(dp.Exemplars())
+		}
+		if dps.Len() > 0 {
+			derived.MoveTo(metrics.AppendEmpty())
+		}
+	}
+}
+
+// apply returns the result of the operation and false if the result cannot be
+// represented, e.g. dividing by zero.
+func apply(op Operation, value1, value2 float64) (float64, bool) {
+	var result float64
+	switch op {
+	case OperationAdd:
+		result = value1 + value2
+	case OperationSubtract:
+		result = value1 - value2
+	case OperationMultiply:
+		result = value1 * value2
+	case OperationDivide, OperationPercent:
+		if value2 == 0 {
+			return 0, false
+		}
+		result = value1 / value2
+		if op == OperationPercent {
+			result *= 100
+		}
+	default:
+		return 0, false
+	}
+	if math.IsNaN(result) || math.IsInf(result, 0) {
+		return 0, false
+	}
+	return result, true
+}
+
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
+
+func attributesKey(attrs pcommon.Map) string {
+	pairs := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		pairs = append(pairs, k+":"+v.AsString())
+		return true
+	})
+	sort.Strings(pairs)
+	return strings.Join(pairs, "|")
+}
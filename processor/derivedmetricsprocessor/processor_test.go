@@ -0,0 +1,109 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package derivedmetricsprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+func TestProcessMetrics(t *testing.T) {
+	cfg := &Config{Rules: []Rule{
+		{Name: "mem_used_percent", Unit: "Percent", Metric1: "mem_used", Metric2: "mem_total", Operation: OperationPercent},
+	}}
+	p := newProcessor(cfg, zap.NewNop())
+
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	used := ms.AppendEmpty()
+	used.SetName("mem_used")
+	usedDps := used.SetEmptyGauge().DataPoints()
+	addDataPoint(usedDps, 25, map[string]string{"host": "a"})
+	usedDps.At(0).Exemplars().AppendEmpty().SetTraceID(pcommon.TraceID{1})
+	addDataPoint(usedDps, 10, map[string]string{"host": "b"})
+	addDataPoint(usedDps, 5, map[string]string{"host": "c"})
+	total := ms.AppendEmpty()
+	total.SetName("mem_total")
+	totalDps := total.SetEmptySum().DataPoints()
+	totalDps.AppendEmpty().SetIntValue(100)
+	totalDps.At(0).Attributes().PutStr("host", "a")
+	// zero denominator is skipped
+	addDataPoint(totalDps, 0, map[string]string{"host": "b"})
+	// host c has no matching attribute set
+
+	got, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	gotMetrics := got.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 3, gotMetrics.Len())
+	derived := gotMetrics.At(2)
+	assert.Equal(t, "mem_used_percent", derived.Name())
+	assert.Equal(t, "Percent", derived.Unit())
+	require.Equal(t, 1, derived.Gauge().DataPoints().Len())
+	dp := derived.Gauge().DataPoints().At(0)
+	assert.Equal(t, 25.0, dp.DoubleValue())
+	host, ok := dp.Attributes().Get("host")
+	assert.True(t, ok)
+	assert.Equal(t, "a", host.Str())
+	require.Equal(t, 1, dp.Exemplars().Len())
+	assert.Equal(t, pcommon.TraceID{1}, dp.Exemplars().At(0).TraceID())
+}
+
+func TestProcessMetricsZeroDenominator(t *testing.T) {
+	cfg := &Config{Rules: []Rule{
+		{Name: "ratio", Metric1: "a", Metric2: "b", Operation: OperationDivide},
+	}}
+	p := newProcessor(cfg, zap.NewNop())
+
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	a := ms.AppendEmpty()
+	a.SetName("a")
+	addDataPoint(a.SetEmptyGauge().DataPoints(), 1, nil)
+	b := ms.AppendEmpty()
+	b.SetName("b")
+	addDataPoint(b.SetEmptyGauge().DataPoints(), 0, nil)
+
+	got, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, 2, got.MetricCount())
+}
+
+func TestApply(t *testing.T) {
+	testCases := map[string]struct {
+		op     Operation
+		value1 float64
+		value2 float64
+		want   float64
+		wantOk bool
+	}{
+		"Add":          {op: OperationAdd, value1: 1, value2: 2, want: 3, wantOk: true},
+		"Subtract":     {op: OperationSubtract, value1: 1, value2: 2, want: -1, wantOk: true},
+		"Multiply":     {op: OperationMultiply, value1: 3, value2: 2, want: 6, wantOk: true},
+		"Divide":       {op: OperationDivide, value1: 3, value2: 2, want: 1.5, wantOk: true},
+		"Percent":      {op: OperationPercent, value1: 1, value2: 4, want: 25, wantOk: true},
+		"DivideByZero": {op: OperationDivide, value1: 1, value2: 0},
+		"Unsupported":  {op: "modulo", value1: 1, value2: 2},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, ok := apply(testCase.op, testCase.value1, testCase.value2)
+			assert.Equal(t, testCase.wantOk, ok)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func addDataPoint(dps pmetric.NumberDataPointSlice, value float64, attrs map[string]string) {
+	dp := dps.AppendEmpty()
+	dp.SetDoubleValue(value)
+	for k, v := range attrs {
+		dp.Attributes().PutStr(k, v)
+	}
+}
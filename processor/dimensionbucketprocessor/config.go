@@ -0,0 +1,52 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dimensionbucketprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	errNoDimensions  = errors.New("dimensions must be set")
+	errEmptyKey      = errors.New("dimensions must not contain an empty key")
+	errDuplicateKey  = errors.New("duplicate dimension key")
+	errInvalidBucket = errors.New("buckets must be greater than 0")
+)
+
+type Config struct {
+	// Dimensions are the dimensions whose values are replaced with the id of a bucket.
+	Dimensions []Dimension `mapstructure:"dimensions,omitempty"`
+}
+
+type Dimension struct {
+	// Key is the attribute key of the dimension.
+	Key string `mapstructure:"key"`
+	// Buckets is the number of buckets that the values of the dimension are hashed into.
+	Buckets int `mapstructure:"buckets"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if len(cfg.Dimensions) == 0 {
+		return errNoDimensions
+	}
+	keys := make(map[string]struct{}, len(cfg.Dimensions))
+	for _, d := range cfg.Dimensions {
+		if d.Key == "" {
+			return errEmptyKey
+		}
+		if _, ok := keys[d.Key]; ok {
+			return fmt.Errorf("%w %q", errDuplicateKey, d.Key)
+		}
+		keys[d.Key] = struct{}{}
+		if d.Buckets <= 0 {
+			return fmt.Errorf("%w for dimension %q", errInvalidBucket, d.Key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dimensionbucketprocessor
+
+import (
+	"context"
+	"hash/fnv"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// bucketPrefix is the prefix of the bucket ids that replace the dimension values.
+const bucketPrefix = "bucket-"
+
+type bucketProcessor struct {
+	// buckets maps the key of each dimension to its number of buckets.
+	buckets map[string]uint32
+}
+
+func newProcessor(cfg *Config) *bucketProcessor {
+	p := &bucketProcessor{buckets: make(map[string]uint32, len(cfg.Dimensions))}
+	for _, d := range cfg.Dimensions {
+		p.buckets[d.Key] = uint32(d.Buckets)
+	}
+	return p
+}
+
+func (p *bucketProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		p.bucketAttributes(rm.Resource().Attributes())
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				p.processMetric(ms.At(k))
+			}
+		}
+	}
+	return md, nil
+}
+
+func (p *bucketProcessor) processMetric(m pmetric.Metric) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.bucketAttributes(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.bucketAttributes(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.bucketAttributes(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.bucketAttributes(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.bucketAttributes(dps.At(i).Attributes())
+		}
+	}
+}
+
+// bucketAttributes replaces the values of the configured dimensions with the id of their bucket.
+func (p *bucketProcessor) bucketAttributes(attrs pcommon.Map) {
+	for key, buckets := range p.buckets {
+		if v, ok := attrs.Get(key); ok {
+			v.SetStr(bucketID(v.AsString(), buckets))
+		}
+	}
+}
+
+// bucketID hashes the value into one of the buckets. The hash does not depend on the process, so a value is in the
+// same bucket on every host and after a restart.
+func bucketID(value string, buckets uint32) string {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(value))
+	return bucketPrefix + strconv.FormatUint(uint64(h.Sum32()%buckets), 10)
+}
@@ -0,0 +1,95 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dimensionbucketprocessor
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func newGaugeMetrics(attrs ...map[string]any) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("latency")
+	dps := m.SetEmptyGauge().DataPoints()
+	for _, a := range attrs {
+		_ = dps.AppendEmpty().Attributes().FromRaw(a)
+	}
+	return md
+}
+
+func gaugeAttributes(md pmetric.Metrics) []map[string]any {
+	var result []map[string]any
+	dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		result = append(result, dps.At(i).Attributes().AsRaw())
+	}
+	return result
+}
+
+func TestProcessMetrics(t *testing.T) {
+	p := newProcessor(&Config{Dimensions: []Dimension{
+		{Key: "request_id", Buckets: 16},
+		{Key: "user_id", Buckets: 100},
+	}})
+	md, err := p.processMetrics(context.Background(), newGaugeMetrics(
+		map[string]any{"request_id": "3f2a9c1e", "user_id": int64(42), "Operation": "GET /orders"},
+		map[string]any{"request_id": "3f2a9c1e", "Operation": "GET /orders"},
+		map[string]any{"Operation": "GET /orders"},
+	))
+	require.NoError(t, err)
+	assert.Equal(t, []map[string]any{
+		{"request_id": bucketID("3f2a9c1e", 16), "user_id": bucketID("42", 100), "Operation": "GET /orders"},
+		{"request_id": bucketID("3f2a9c1e", 16), "Operation": "GET /orders"},
+		{"Operation": "GET /orders"},
+	}, gaugeAttributes(md))
+}
+
+func TestProcessMetricsResourceAndTypes(t *testing.T) {
+	p := newProcessor(&Config{Dimensions: []Dimension{{Key: "request_id", Buckets: 4}}})
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("request_id", "a")
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	attrs := []pcommon.Map{
+		ms.AppendEmpty().SetEmptySum().DataPoints().AppendEmpty().Attributes(),
+		ms.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty().Attributes(),
+		ms.AppendEmpty().SetEmptyExponentialHistogram().DataPoints().AppendEmpty().Attributes(),
+		ms.AppendEmpty().SetEmptySummary().DataPoints().AppendEmpty().Attributes(),
+	}
+	for _, a := range attrs {
+		a.PutStr("request_id", "b")
+	}
+
+	_, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"request_id": bucketID("a", 4)}, rm.Resource().Attributes().AsRaw())
+	for _, a := range attrs {
+		assert.Equal(t, map[string]any{"request_id": bucketID("b", 4)}, a.AsRaw())
+	}
+}
+
+func TestBucketID(t *testing.T) {
+	// the bucket of a value does not change between runs
+	assert.Equal(t, "bucket-15", bucketID("3f2a9c1e", 16))
+	assert.Equal(t, bucketID("3f2a9c1e", 16), bucketID("3f2a9c1e", 16))
+	assert.Equal(t, "bucket-0", bucketID("3f2a9c1e", 1))
+
+	for _, buckets := range []uint32{1, 8, 100} {
+		ids := map[string]struct{}{}
+		for i := 0; i < 10000; i++ {
+			ids[bucketID(fmt.Sprintf("request-%d", i), buckets)] = struct{}{}
+		}
+		assert.Len(t, ids, int(buckets))
+		for i := uint32(0); i < buckets; i++ {
+			assert.Contains(t, ids, fmt.Sprintf("bucket-%d", i))
+		}
+	}
+}
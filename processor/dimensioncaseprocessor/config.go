@@ -0,0 +1,45 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dimensioncaseprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	CasePascal = "PascalCase"
+	CaseSnake  = "snake_case"
+	CaseLower  = "lowercase"
+)
+
+var (
+	errInvalidCase  = fmt.Errorf("case must be one of %q, %q or %q", CasePascal, CaseSnake, CaseLower)
+	errEmptyExclude = errors.New("exclude must not contain an empty attribute")
+)
+
+type Config struct {
+	// Case is the casing that the attribute keys of data points are converted to.
+	Case string `mapstructure:"case"`
+	// Exclude are the attribute keys that are kept as they are.
+	Exclude []string `mapstructure:"exclude,omitempty"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	switch cfg.Case {
+	case CasePascal, CaseSnake, CaseLower:
+	default:
+		return errInvalidCase
+	}
+	for _, attribute := range cfg.Exclude {
+		if attribute == "" {
+			return errEmptyExclude
+		}
+	}
+	return nil
+}
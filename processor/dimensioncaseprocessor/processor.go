@@ -0,0 +1,195 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dimensioncaseprocessor
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+type dimensionCaseProcessor struct {
+	convert func(key string) string
+	exclude map[string]struct{}
+	logger  *zap.Logger
+
+	mu sync.Mutex
+	// converted caches the converted attribute keys, since the same few keys are on every data point.
+	converted map[string]string
+}
+
+func newProcessor(cfg *Config, logger *zap.Logger) *dimensionCaseProcessor {
+	exclude := make(map[string]struct{}, len(cfg.Exclude))
+	for _, attribute := range cfg.Exclude {
+		exclude[attribute] = struct{}{}
+	}
+	var convert func(string) string
+	switch cfg.Case {
+	case CaseSnake:
+		convert = toSnakeCase
+	case CaseLower:
+		convert = strings.ToLower
+	default:
+		convert = toPascalCase
+	}
+	return &dimensionCaseProcessor{
+		convert:   convert,
+		exclude:   exclude,
+		logger:    logger,
+		converted: map[string]string{},
+	}
+}
+
+func (p *dimensionCaseProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				p.processMetric(ms.At(k))
+			}
+		}
+	}
+	return md, nil
+}
+
+func (p *dimensionCaseProcessor) processMetric(m pmetric.Metric) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.normalize(m.Name(), dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.normalize(m.Name(), dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.normalize(m.Name(), dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.normalize(m.Name(), dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.normalize(m.Name(), dps.At(i).Attributes())
+		}
+	}
+}
+
+// normalize converts the keys of the attributes to the configured case. When two keys convert to the same one, the
+// key that is already in the configured case is kept, otherwise the first one in key order.
+func (p *dimensionCaseProcessor) normalize(metricName string, attrs pcommon.Map) {
+	renamed := map[string]string{}
+	attrs.Range(func(k string, _ pcommon.Value) bool {
+		if converted := p.key(k); converted != k {
+			renamed[k] = converted
+		}
+		return true
+	})
+	if len(renamed) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(renamed))
+	for k := range renamed {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		converted := renamed[k]
+		if _, ok := attrs.Get(converted); !ok {
+			v, _ := attrs.Get(k)
+			v.CopyTo(attrs.PutEmpty(converted))
+		} else {
+			p.logger.Debug("Dropped a dimension that has the same normalized key as another",
+				zap.String("metric", metricName),
+				zap.String("dimension", k),
+				zap.String("normalized", converted))
+		}
+		attrs.Remove(k)
+	}
+}
+
+// key returns the attribute key in the configured case.
+func (p *dimensionCaseProcessor) key(k string) string {
+	if _, ok := p.exclude[k]; ok {
+		return k
+	}
+	converted, ok := p.converted[k]
+	if !ok {
+		converted = p.convert(k)
+		// keys without any letters or digits are kept
+		if converted == "" {
+			converted = k
+		}
+		p.converted[k] = converted
+	}
+	return converted
+}
+
+func toPascalCase(s string) string {
+	var b strings.Builder
+	for _, word := range splitWords(s) {
+		runes := []rune(strings.ToLower(word))
+		runes[0] = unicode.ToUpper(runes[0])
+		b.WriteString(string(runes))
+	}
+	return b.String()
+}
+
+func toSnakeCase(s string) string {
+	words := splitWords(s)
+	for i, word := range words {
+		words[i] = strings.ToLower(word)
+	}
+	return strings.Join(words, "_")
+}
+
+// splitWords splits the key into words at the characters that are not letters or digits and at the changes of case,
+// e.g. "PodName", "pod_name" and "pod.name" are all split into "Pod" and "Name", and "HTTPStatus" into "HTTP" and
+// "Status".
+func splitWords(s string) []string {
+	var words []string
+	runes := []rune(s)
+	start := -1
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			if start >= 0 {
+				words = append(words, string(runes[start:i]))
+				start = -1
+			}
+			continue
+		}
+		if start >= 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if !unicode.IsUpper(prev) || nextIsLower {
+				words = append(words, string(runes[start:i]))
+				start = i
+			}
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		words = append(words, string(runes[start:]))
+	}
+	return words
+}
@@ -0,0 +1,158 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dimensioncaseprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+func newMetrics(attrs map[string]any) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	gauge := ms.AppendEmpty()
+	gauge.SetName("gauge")
+	_ = gauge.SetEmptyGauge().DataPoints().AppendEmpty().Attributes().FromRaw(attrs)
+	histogram := ms.AppendEmpty()
+	histogram.SetName("histogram")
+	_ = histogram.SetEmptyHistogram().DataPoints().AppendEmpty().Attributes().FromRaw(attrs)
+	return md
+}
+
+func attributes(md pmetric.Metrics) []map[string]any {
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	return []map[string]any{
+		ms.At(0).Gauge().DataPoints().At(0).Attributes().AsRaw(),
+		ms.At(1).Histogram().DataPoints().At(0).Attributes().AsRaw(),
+	}
+}
+
+func TestProcessMetrics(t *testing.T) {
+	attrs := map[string]any{
+		"ClusterName":     "cluster",
+		"namespace":       "ns",
+		"pod_name":        "pod",
+		"k8s.node.name":   "node",
+		"HTTPStatusCode":  int64(200),
+		"container-ID":    "id",
+		"instanceId":      "i-0123",
+		"AutoScalingName": "asg",
+	}
+	testCases := map[string]struct {
+		cfg  *Config
+		want map[string]any
+	}{
+		"PascalCase": {
+			cfg: &Config{Case: CasePascal},
+			want: map[string]any{
+				"ClusterName":     "cluster",
+				"Namespace":       "ns",
+				"PodName":         "pod",
+				"K8sNodeName":     "node",
+				"HttpStatusCode":  int64(200),
+				"ContainerId":     "id",
+				"InstanceId":      "i-0123",
+				"AutoScalingName": "asg",
+			},
+		},
+		"snake_case": {
+			cfg: &Config{Case: CaseSnake},
+			want: map[string]any{
+				"cluster_name":      "cluster",
+				"namespace":         "ns",
+				"pod_name":          "pod",
+				"k8s_node_name":     "node",
+				"http_status_code":  int64(200),
+				"container_id":      "id",
+				"instance_id":       "i-0123",
+				"auto_scaling_name": "asg",
+			},
+		},
+		"lowercase": {
+			cfg: &Config{Case: CaseLower},
+			want: map[string]any{
+				"clustername":     "cluster",
+				"namespace":       "ns",
+				"pod_name":        "pod",
+				"k8s.node.name":   "node",
+				"httpstatuscode":  int64(200),
+				"container-id":    "id",
+				"instanceid":      "i-0123",
+				"autoscalingname": "asg",
+			},
+		},
+		"exclude": {
+			cfg: &Config{Case: CaseSnake, Exclude: []string{"ClusterName", "k8s.node.name", "missing"}},
+			want: map[string]any{
+				"ClusterName":       "cluster",
+				"namespace":         "ns",
+				"pod_name":          "pod",
+				"k8s.node.name":     "node",
+				"http_status_code":  int64(200),
+				"container_id":      "id",
+				"instance_id":       "i-0123",
+				"auto_scaling_name": "asg",
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p := newProcessor(testCase.cfg, zap.NewNop())
+			md, err := p.processMetrics(context.Background(), newMetrics(attrs))
+			require.NoError(t, err)
+			assert.Equal(t, []map[string]any{testCase.want, testCase.want}, attributes(md))
+
+			// the converted keys are cached and converted the same way again
+			md, err = p.processMetrics(context.Background(), newMetrics(attrs))
+			require.NoError(t, err)
+			assert.Equal(t, []map[string]any{testCase.want, testCase.want}, attributes(md))
+		})
+	}
+}
+
+func TestProcessMetricsConflict(t *testing.T) {
+	p := newProcessor(&Config{Case: CasePascal}, zap.NewNop())
+	md, err := p.processMetrics(context.Background(), newMetrics(map[string]any{
+		"pod_name": "a",
+		"PodName":  "b",
+		"pod.name": "c",
+		"podName":  "d",
+		"---":      "e",
+	}))
+	require.NoError(t, err)
+	// the key already in the case is kept over the keys converted to it, and keys without words are left as is
+	want := map[string]any{"PodName": "b", "---": "e"}
+	assert.Equal(t, []map[string]any{want, want}, attributes(md))
+
+	md, err = p.processMetrics(context.Background(), newMetrics(map[string]any{
+		"pod_name": "a",
+		"pod.name": "c",
+	}))
+	require.NoError(t, err)
+	// otherwise the first key in order is kept
+	want = map[string]any{"PodName": "c"}
+	assert.Equal(t, []map[string]any{want, want}, attributes(md))
+}
+
+func TestSplitWords(t *testing.T) {
+	testCases := map[string][]string{
+		"PodName":        {"Pod", "Name"},
+		"pod_name":       {"pod", "name"},
+		"k8s.pod.name":   {"k8s", "pod", "name"},
+		"HTTPStatusCode": {"HTTP", "Status", "Code"},
+		"instanceID":     {"instance", "ID"},
+		"__pod__Name__":  {"pod", "Name"},
+		"podname":        {"podname"},
+		"Ärger-Größe":    {"Ärger", "Größe"},
+		"":               nil,
+	}
+	for input, want := range testCases {
+		assert.Equal(t, want, splitWords(input), input)
+	}
+}
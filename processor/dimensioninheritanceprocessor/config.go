@@ -0,0 +1,56 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dimensioninheritanceprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Direction is the direction that the attributes are copied in.
+type Direction string
+
+const (
+	// DirectionResourceToDataPoint copies the resource attributes to every data point of the resource.
+	DirectionResourceToDataPoint Direction = "resource_to_datapoint"
+	// DirectionDataPointToResource copies the data point attributes to the resource when all of its data points
+	// have the same value.
+	DirectionDataPointToResource Direction = "datapoint_to_resource"
+)
+
+var (
+	errMissingAttributes = errors.New("attributes must be set")
+	errEmptyAttribute    = errors.New("attributes must not contain an empty name")
+	errInvalidDirection  = errors.New("invalid direction")
+)
+
+type Config struct {
+	// Attributes are the names of the attributes to copy.
+	Attributes []string `mapstructure:"attributes"`
+	// Direction is the direction that the attributes are copied in. Defaults to resource_to_datapoint.
+	Direction Direction `mapstructure:"direction"`
+	// Overwrite replaces the values that the destination already has. By default, existing values are kept.
+	Overwrite bool `mapstructure:"overwrite"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if len(cfg.Attributes) == 0 {
+		return errMissingAttributes
+	}
+	for _, attribute := range cfg.Attributes {
+		if attribute == "" {
+			return errEmptyAttribute
+		}
+	}
+	switch cfg.Direction {
+	case DirectionResourceToDataPoint, DirectionDataPointToResource:
+	default:
+		return fmt.Errorf("%w %q, must be %q or %q", errInvalidDirection, cfg.Direction, DirectionResourceToDataPoint, DirectionDataPointToResource)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dimensioninheritanceprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	testCases := []struct {
+		id      component.ID
+		want    component.Config
+		wantErr string
+	}{
+		{
+			id:   component.NewID(component.MustNewType(typeStr)),
+			want: &Config{Attributes: []string{"ClusterName"}, Direction: DirectionResourceToDataPoint},
+		},
+		{
+			id: component.NewIDWithName(component.MustNewType(typeStr), "1"),
+			want: &Config{
+				Attributes: []string{"ClusterName", "Namespace"},
+				Direction:  DirectionDataPointToResource,
+				Overwrite:  true,
+			},
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "missing_attributes"),
+			wantErr: "attributes must be set",
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "invalid_direction"),
+			wantErr: `invalid direction "both"`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.id.String(), func(t *testing.T) {
+			conf, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := conf.Sub(testCase.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, component.ValidateConfig(cfg), testCase.wantErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, testCase.want, cfg)
+		})
+	}
+}
+
+func TestValidateInvalidDirection(t *testing.T) {
+	cfg := &Config{Attributes: []string{"ClusterName"}, Direction: "both"}
+	assert.ErrorIs(t, cfg.Validate(), errInvalidDirection)
+}
+
+func TestValidateEmptyAttribute(t *testing.T) {
+	cfg := &Config{Attributes: []string{"ClusterName", ""}, Direction: DirectionResourceToDataPoint}
+	assert.ErrorIs(t, cfg.Validate(), errEmptyAttribute)
+}
@@ -0,0 +1,135 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dimensioninheritanceprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+type dimensionInheritanceProcessor struct {
+	attributes []string
+	direction  Direction
+	overwrite  bool
+	logger     *zap.Logger
+}
+
+func newProcessor(cfg *Config, logger *zap.Logger) *dimensionInheritanceProcessor {
+	return &dimensionInheritanceProcessor{
+		attributes: cfg.Attributes,
+		direction:  cfg.Direction,
+		overwrite:  cfg.Overwrite,
+		logger:     logger,
+	}
+}
+
+func (p *dimensionInheritanceProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		if p.direction == DirectionDataPointToResource {
+			p.copyToResource(rm)
+		} else {
+			p.copyToDataPoints(rm)
+		}
+	}
+	return md, nil
+}
+
+// copyToDataPoints copies the resource attributes to the data points of the resource.
+func (p *dimensionInheritanceProcessor) copyToDataPoints(rm pmetric.ResourceMetrics) {
+	resourceAttrs := rm.Resource().Attributes()
+	values := make(map[string]pcommon.Value, len(p.attributes))
+	for _, attribute := range p.attributes {
+		if value, ok := resourceAttrs.Get(attribute); ok {
+			values[attribute] = value
+		}
+	}
+	if len(values) == 0 {
+		return
+	}
+	forEachDataPointAttributes(rm, func(attrs pcommon.Map) {
+		for attribute, value := range values {
+			p.put(attrs, attribute, value)
+		}
+	})
+}
+
+// copyToResource copies the data point attributes to the resource. An attribute is only copied when every data point
+// of the resource has the same value for it, since the resource cannot hold more than one.
+func (p *dimensionInheritanceProcessor) copyToResource(rm pmetric.ResourceMetrics) {
+	resourceAttrs := rm.Resource().Attributes()
+	for _, attribute := range p.attributes {
+		var value pcommon.Value
+		found, conflict := false, false
+		forEachDataPointAttributes(rm, func(attrs pcommon.Map) {
+			v, ok := attrs.Get(attribute)
+			if !ok || conflict {
+				return
+			}
+			if !found {
+				value, found = v, true
+			} else if v.Type() != value.Type() || v.AsString() != value.AsString() {
+				conflict = true
+			}
+		})
+		if conflict {
+			p.logger.Debug("Skipping attribute with different values on the data points of a resource",
+				zap.String("attribute", attribute))
+			continue
+		}
+		if found {
+			p.put(resourceAttrs, attribute, value)
+		}
+	}
+}
+
+// put sets the attribute unless it already has a value and overwrite is disabled.
+func (p *dimensionInheritanceProcessor) put(attrs pcommon.Map, attribute string, value pcommon.Value) {
+	if _, ok := attrs.Get(attribute); ok && !p.overwrite {
+		return
+	}
+	value.CopyTo(attrs.PutEmpty(attribute))
+}
+
+// forEachDataPointAttributes calls fn with the attributes of every data point of the resource.
+func forEachDataPointAttributes(rm pmetric.ResourceMetrics, fn func(attrs pcommon.Map)) {
+	sms := rm.ScopeMetrics()
+	for i := 0; i < sms.Len(); i++ {
+		ms := sms.At(i).Metrics()
+		for j := 0; j < ms.Len(); j++ {
+			m := ms.At(j)
+			switch m.Type() {
+			case pmetric.MetricTypeGauge:
+				dps := m.Gauge().DataPoints()
+				for k := 0; k < dps.Len(); k++ {
+					fn(dps.At(k).Attributes())
+				}
+			case pmetric.MetricTypeSum:
+				dps := m.Sum().DataPoints()
+				for k := 0; k < dps.Len(); k++ {
+					fn(dps.At(k).Attributes())
+				}
+			case pmetric.MetricTypeHistogram:
+				dps := m.Histogram().DataPoints()
+				for k := 0; k < dps.Len(); k++ {
+					fn(dps.At(k).Attributes())
+				}
+			case pmetric.MetricTypeExponentialHistogram:
+				dps := m.ExponentialHistogram().DataPoints()
+				for k := 0; k < dps.Len(); k++ {
+					fn(dps.At(k).Attributes())
+				}
+			case pmetric.MetricTypeSummary:
+				dps := m.Summary().DataPoints()
+				for k := 0; k < dps.Len(); k++ {
+					fn(dps.At(k).Attributes())
+				}
+			}
+		}
+	}
+}
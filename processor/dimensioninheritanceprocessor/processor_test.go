@@ -0,0 +1,146 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dimensioninheritanceprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+// newTestMetrics creates a resource with the attributes and a gauge, a sum and a histogram with a data point for each
+// of the data point attributes.
+func newTestMetrics(resourceAttrs map[string]any, dpAttrs ...map[string]any) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	_ = rm.Resource().Attributes().FromRaw(resourceAttrs)
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	gauge := ms.AppendEmpty()
+	gauge.SetName("gauge")
+	sum := ms.AppendEmpty()
+	sum.SetName("sum")
+	histogram := ms.AppendEmpty()
+	histogram.SetName("histogram")
+	gaugeDps := gauge.SetEmptyGauge().DataPoints()
+	sumDps := sum.SetEmptySum().DataPoints()
+	histogramDps := histogram.SetEmptyHistogram().DataPoints()
+	for _, attrs := range dpAttrs {
+		_ = gaugeDps.AppendEmpty().Attributes().FromRaw(attrs)
+		_ = sumDps.AppendEmpty().Attributes().FromRaw(attrs)
+		_ = histogramDps.AppendEmpty().Attributes().FromRaw(attrs)
+	}
+	return md
+}
+
+func dataPointAttributes(rm pmetric.ResourceMetrics) []map[string]any {
+	var result []map[string]any
+	forEachDataPointAttributes(rm, func(attrs pcommon.Map) {
+		result = append(result, attrs.AsRaw())
+	})
+	return result
+}
+
+func TestProcessMetricsResourceToDataPoint(t *testing.T) {
+	testCases := map[string]struct {
+		overwrite bool
+		want      []map[string]any
+	}{
+		"KeepExisting": {
+			want: []map[string]any{
+				{"ClusterName": "cluster", "Namespace": "default", "pod": "a"},
+				{"ClusterName": "cluster", "Namespace": "kube-system", "pod": "b"},
+			},
+		},
+		"Overwrite": {
+			overwrite: true,
+			want: []map[string]any{
+				{"ClusterName": "cluster", "Namespace": "resource", "pod": "a"},
+				{"ClusterName": "cluster", "Namespace": "resource", "pod": "b"},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p := newProcessor(&Config{
+				Attributes: []string{"ClusterName", "Namespace", "missing"},
+				Direction:  DirectionResourceToDataPoint,
+				Overwrite:  testCase.overwrite,
+			}, zap.NewNop())
+			md := newTestMetrics(
+				map[string]any{"ClusterName": "cluster", "Namespace": "resource", "host": "h"},
+				map[string]any{"pod": "a"},
+				map[string]any{"pod": "b", "Namespace": "kube-system"},
+			)
+			if !testCase.overwrite {
+				md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints().At(0).Attributes().PutStr("Namespace", "default")
+				md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(1).Sum().DataPoints().At(0).Attributes().PutStr("Namespace", "default")
+				md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(2).Histogram().DataPoints().At(0).Attributes().PutStr("Namespace", "default")
+			}
+
+			got, err := p.processMetrics(context.Background(), md)
+			require.NoError(t, err)
+			rm := got.ResourceMetrics().At(0)
+			// the attributes that are not configured are not copied and the resource is left as is
+			assert.Equal(t, map[string]any{"ClusterName": "cluster", "Namespace": "resource", "host": "h"}, rm.Resource().Attributes().AsRaw())
+			gotAttrs := dataPointAttributes(rm)
+			require.Len(t, gotAttrs, 6)
+			for i, attrs := range gotAttrs {
+				assert.Equal(t, testCase.want[i%2], attrs)
+			}
+		})
+	}
+}
+
+func TestProcessMetricsDataPointToResource(t *testing.T) {
+	testCases := map[string]struct {
+		resourceAttrs map[string]any
+		overwrite     bool
+		want          map[string]any
+	}{
+		"Copy": {
+			resourceAttrs: map[string]any{"host": "h"},
+			want:          map[string]any{"host": "h", "ClusterName": "cluster"},
+		},
+		"KeepExisting": {
+			resourceAttrs: map[string]any{"ClusterName": "resource"},
+			want:          map[string]any{"ClusterName": "resource"},
+		},
+		"Overwrite": {
+			resourceAttrs: map[string]any{"ClusterName": "resource"},
+			overwrite:     true,
+			want:          map[string]any{"ClusterName": "cluster"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p := newProcessor(&Config{
+				Attributes: []string{"ClusterName", "pod"},
+				Direction:  DirectionDataPointToResource,
+				Overwrite:  testCase.overwrite,
+			}, zap.NewNop())
+			md := newTestMetrics(
+				testCase.resourceAttrs,
+				map[string]any{"ClusterName": "cluster", "pod": "a"},
+				// a data point without the attribute does not prevent the copy
+				map[string]any{"pod": "b"},
+			)
+
+			got, err := p.processMetrics(context.Background(), md)
+			require.NoError(t, err)
+			rm := got.ResourceMetrics().At(0)
+			// pod has different values on the data points so it is not copied
+			assert.Equal(t, testCase.want, rm.Resource().Attributes().AsRaw())
+			// the data points are left as is
+			gotAttrs := dataPointAttributes(rm)
+			require.Len(t, gotAttrs, 6)
+			assert.Equal(t, map[string]any{"ClusterName": "cluster", "pod": "a"}, gotAttrs[0])
+			assert.Equal(t, map[string]any{"pod": "b"}, gotAttrs[1])
+		})
+	}
+}
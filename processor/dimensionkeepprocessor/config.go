@@ -0,0 +1,54 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dimensionkeepprocessor
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	errNoRules        = errors.New("at least one rule must be specified")
+	errEmptyPattern   = errors.New("rule pattern must not be empty")
+	errEmptyDimension = errors.New("rule dimensions must not contain an empty dimension")
+)
+
+// Rule keeps only the listed dimensions on the metrics whose names match the pattern.
+type Rule struct {
+	// Pattern is the regular expression of the names of the metrics that the rule applies to.
+	Pattern string `mapstructure:"pattern"`
+	// Dimensions are the attributes that are kept on the data points of the matching metrics. All of the other
+	// attributes are dropped, so an empty list drops all of them.
+	Dimensions []string `mapstructure:"dimensions"`
+}
+
+type Config struct {
+	// Rules are checked in order and the first rule that matches the name of a metric is used.
+	Rules []Rule `mapstructure:"rules"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if len(cfg.Rules) == 0 {
+		return errNoRules
+	}
+	for _, rule := range cfg.Rules {
+		if rule.Pattern == "" {
+			return errEmptyPattern
+		}
+		if _, err := regexp.Compile(rule.Pattern); err != nil {
+			return fmt.Errorf("invalid rule pattern %q: %w", rule.Pattern, err)
+		}
+		for _, dimension := range rule.Dimensions {
+			if dimension == "" {
+				return fmt.Errorf("%w for pattern %q", errEmptyDimension, rule.Pattern)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dimensionkeepprocessor
+
+import (
+	"context"
+	"regexp"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+type rule struct {
+	pattern    *regexp.Regexp
+	dimensions map[string]struct{}
+}
+
+type dimensionKeepProcessor struct {
+	rules []rule
+}
+
+func newProcessor(cfg *Config) *dimensionKeepProcessor {
+	p := &dimensionKeepProcessor{}
+	for _, r := range cfg.Rules {
+		// validated with the config
+		pattern, err := regexp.Compile(r.Pattern)
+		if err != nil {
+			continue
+		}
+		dimensions := make(map[string]struct{}, len(r.Dimensions))
+		for _, dimension := range r.Dimensions {
+			dimensions[dimension] = struct{}{}
+		}
+		p.rules = append(p.rules, rule{pattern: pattern, dimensions: dimensions})
+	}
+	return p
+}
+
+func (p *dimensionKeepProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				if r, ok := p.match(m.Name()); ok {
+					r.processMetric(m)
+				}
+			}
+		}
+	}
+	return md, nil
+}
+
+// match returns the first rule that matches the metric name.
+func (p *dimensionKeepProcessor) match(name string) (rule, bool) {
+	for _, r := range p.rules {
+		if r.pattern.MatchString(name) {
+			return r, true
+		}
+	}
+	return rule{}, false
+}
+
+func (r rule) processMetric(m pmetric.Metric) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			r.keep(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			r.keep(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			r.keep(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			r.keep(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			r.keep(dps.At(i).Attributes())
+		}
+	}
+}
+
+// keep removes the attributes that are not dimensions of the rule.
+func (r rule) keep(attrs pcommon.Map) {
+	attrs.RemoveIf(func(key string, _ pcommon.Value) bool {
+		_, ok := r.dimensions[key]
+		return !ok
+	})
+}
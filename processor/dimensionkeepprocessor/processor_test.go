@@ -0,0 +1,80 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dimensionkeepprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestProcessMetrics(t *testing.T) {
+	p := newProcessor(&Config{Rules: []Rule{
+		{Pattern: "^cpu_usage_idle$", Dimensions: []string{"host"}},
+		{Pattern: "^cpu_", Dimensions: []string{"host", "cpu"}},
+		{Pattern: "^disk_", Dimensions: []string{"host", "path", "fstype"}},
+		{Pattern: "^requests$"},
+	}})
+
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "agent")
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	attributes := map[string]any{
+		"host":   "host-1",
+		"cpu":    "cpu0",
+		"path":   "/",
+		"fstype": "xfs",
+		"device": "nvme0n1p1",
+		"pid":    "1234",
+	}
+	addGauge := func(name string) {
+		m := ms.AppendEmpty()
+		m.SetName(name)
+		require.NoError(t, m.SetEmptyGauge().DataPoints().AppendEmpty().Attributes().FromRaw(attributes))
+	}
+	addGauge("cpu_usage_user")
+	// the first matching rule is used
+	addGauge("cpu_usage_idle")
+	addGauge("mem_used_percent")
+	sum := ms.AppendEmpty()
+	sum.SetName("disk_used")
+	require.NoError(t, sum.SetEmptySum().DataPoints().AppendEmpty().Attributes().FromRaw(attributes))
+	histogram := ms.AppendEmpty()
+	histogram.SetName("disk_latency")
+	require.NoError(t, histogram.SetEmptyHistogram().DataPoints().AppendEmpty().Attributes().FromRaw(attributes))
+	summary := ms.AppendEmpty()
+	summary.SetName("requests")
+	require.NoError(t, summary.SetEmptySummary().DataPoints().AppendEmpty().Attributes().FromRaw(attributes))
+
+	md, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	diskAttributes := map[string]any{"host": "host-1", "path": "/", "fstype": "xfs"}
+	assert.Equal(t, map[string]any{"host": "host-1", "cpu": "cpu0"}, ms.At(0).Gauge().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"host": "host-1"}, ms.At(1).Gauge().DataPoints().At(0).Attributes().AsRaw())
+	// unmatched metrics are left intact
+	assert.Equal(t, attributes, ms.At(2).Gauge().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, diskAttributes, ms.At(3).Sum().DataPoints().At(0).Attributes().AsRaw())
+	assert.Equal(t, diskAttributes, ms.At(4).Histogram().DataPoints().At(0).Attributes().AsRaw())
+	// a rule without dimensions drops all of them
+	assert.Equal(t, 0, ms.At(5).Summary().DataPoints().At(0).Attributes().Len())
+	// resource attributes are not dimensions of the data points
+	assert.Equal(t, map[string]any{"service.name": "agent"}, md.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+}
+
+func TestProcessMetricsMissingDimension(t *testing.T) {
+	p := newProcessor(&Config{Rules: []Rule{{Pattern: "^cpu_", Dimensions: []string{"host", "cpu"}}}})
+	attrs := pcommon.NewMap()
+	attrs.PutStr("cpu", "cpu-total")
+	attrs.PutStr("mode", "idle")
+	r, ok := p.match("cpu_usage_idle")
+	require.True(t, ok)
+	r.keep(attrs)
+	assert.Equal(t, map[string]any{"cpu": "cpu-total"}, attrs.AsRaw())
+}
@@ -0,0 +1,40 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dimensionlimitprocessor
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// defaultMaxDimensions is the number of dimensions that CloudWatch accepts on a metric.
+const defaultMaxDimensions = 30
+
+var (
+	errInvalidMaxDimensions = errors.New("max_dimensions must be at least 1")
+	errEmptyPriority        = errors.New("priority must not contain an empty attribute")
+)
+
+type Config struct {
+	// MaxDimensions is the maximum number of attributes that a data point can have.
+	MaxDimensions int `mapstructure:"max_dimensions"`
+	// Priority are the attributes to keep, from the highest priority to the lowest, when a data point has more than
+	// MaxDimensions attributes. The attributes that are not in the list have the lowest priority.
+	Priority []string `mapstructure:"priority,omitempty"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if cfg.MaxDimensions < 1 {
+		return errInvalidMaxDimensions
+	}
+	for _, attribute := range cfg.Priority {
+		if attribute == "" {
+			return errEmptyPriority
+		}
+	}
+	return nil
+}
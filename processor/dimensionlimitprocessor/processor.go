@@ -0,0 +1,129 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dimensionlimitprocessor
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+type dimensionLimitProcessor struct {
+	maxDimensions int
+	// priorities are the indexes of the attributes in the priority list.
+	priorities map[string]int
+	logger     *zap.Logger
+
+	mu sync.Mutex
+	// logged are the names of the metrics that a drop was logged for.
+	logged map[string]struct{}
+}
+
+func newProcessor(cfg *Config, logger *zap.Logger) *dimensionLimitProcessor {
+	priorities := make(map[string]int, len(cfg.Priority))
+	for i, attribute := range cfg.Priority {
+		// the first occurrence of an attribute wins
+		if _, ok := priorities[attribute]; !ok {
+			priorities[attribute] = i
+		}
+	}
+	return &dimensionLimitProcessor{
+		maxDimensions: cfg.MaxDimensions,
+		priorities:    priorities,
+		logger:        logger,
+		logged:        map[string]struct{}{},
+	}
+}
+
+func (p *dimensionLimitProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				p.processMetric(ms.At(k))
+			}
+		}
+	}
+	return md, nil
+}
+
+func (p *dimensionLimitProcessor) processMetric(m pmetric.Metric) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.limit(m.Name(), dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.limit(m.Name(), dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.limit(m.Name(), dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.limit(m.Name(), dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.limit(m.Name(), dps.At(i).Attributes())
+		}
+	}
+}
+
+// limit removes the attributes with the lowest priority until there are at most maxDimensions left.
+func (p *dimensionLimitProcessor) limit(metricName string, attrs pcommon.Map) {
+	if attrs.Len() <= p.maxDimensions {
+		return
+	}
+	keys := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	sort.Slice(keys, func(i, j int) bool {
+		pi, iok := p.priorities[keys[i]]
+		pj, jok := p.priorities[keys[j]]
+		switch {
+		case iok && jok:
+			return pi < pj
+		case iok != jok:
+			return iok
+		default:
+			// the attributes without a priority are kept in name order so the same ones are kept on every data point
+			return keys[i] < keys[j]
+		}
+	})
+	dropped := keys[p.maxDimensions:]
+	for _, key := range dropped {
+		attrs.Remove(key)
+	}
+	p.logDrop(metricName, dropped)
+}
+
+// logDrop logs the first drop of each metric.
+func (p *dimensionLimitProcessor) logDrop(metricName string, dropped []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.logged[metricName]; ok {
+		return
+	}
+	p.logged[metricName] = struct{}{}
+	p.logger.Warn("Dropped the lowest priority dimensions of a metric over the dimension limit",
+		zap.String("metric", metricName),
+		zap.Int("max_dimensions", p.maxDimensions),
+		zap.Strings("dropped", dropped))
+}
@@ -0,0 +1,98 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dimensionlimitprocessor
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func putAttributes(attrs pcommon.Map, n int) {
+	for i := 0; i < n; i++ {
+		attrs.PutStr(fmt.Sprintf("attr%02d", i), "value")
+	}
+}
+
+func keys(attrs pcommon.Map) []string {
+	var result []string
+	attrs.Range(func(k string, _ pcommon.Value) bool {
+		result = append(result, k)
+		return true
+	})
+	return result
+}
+
+func TestProcessMetrics(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	p := newProcessor(&Config{
+		MaxDimensions: defaultMaxDimensions,
+		// attr34 has the highest priority, followed by attr33 and attr32, and the unknown attribute is ignored
+		Priority: []string{"attr34", "attr33", "missing", "attr32"},
+	}, zap.New(core))
+
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	gauge := ms.AppendEmpty()
+	gauge.SetName("gauge")
+	gaugeDps := gauge.SetEmptyGauge().DataPoints()
+	putAttributes(gaugeDps.AppendEmpty().Attributes(), 35)
+	putAttributes(gaugeDps.AppendEmpty().Attributes(), 35)
+	// data points within the limit are left as is
+	putAttributes(gaugeDps.AppendEmpty().Attributes(), 30)
+	histogram := ms.AppendEmpty()
+	histogram.SetName("histogram")
+	putAttributes(histogram.SetEmptyHistogram().DataPoints().AppendEmpty().Attributes(), 35)
+
+	got, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	// the 3 attributes in the priority list and the first 27 of the others by name survive
+	var want []string
+	for i := 0; i < 27; i++ {
+		want = append(want, fmt.Sprintf("attr%02d", i))
+	}
+	want = append(want, "attr32", "attr33", "attr34")
+	gotMetrics := got.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	gotGaugeDps := gotMetrics.At(0).Gauge().DataPoints()
+	assert.ElementsMatch(t, want, keys(gotGaugeDps.At(0).Attributes()))
+	assert.ElementsMatch(t, want, keys(gotGaugeDps.At(1).Attributes()))
+	assert.Equal(t, 30, gotGaugeDps.At(2).Attributes().Len())
+	_, ok := gotGaugeDps.At(2).Attributes().Get("attr29")
+	assert.True(t, ok)
+	assert.ElementsMatch(t, want, keys(gotMetrics.At(1).Histogram().DataPoints().At(0).Attributes()))
+
+	// the drop is logged once per metric
+	require.Equal(t, 2, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, "gauge", entry.ContextMap()["metric"])
+	assert.ElementsMatch(t, []any{"attr27", "attr28", "attr29", "attr30", "attr31"}, entry.ContextMap()["dropped"])
+	assert.Equal(t, "histogram", logs.All()[1].ContextMap()["metric"])
+
+	_, err = p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, 2, logs.Len())
+}
+
+func TestProcessMetricsWithoutPriority(t *testing.T) {
+	p := newProcessor(&Config{MaxDimensions: 2}, zap.NewNop())
+	md := pmetric.NewMetrics()
+	sum := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	sum.SetName("sum")
+	dp := sum.SetEmptySum().DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("c", "3")
+	dp.Attributes().PutStr("a", "1")
+	dp.Attributes().PutStr("b", "2")
+
+	_, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"a": "1", "b": "2"}, dp.Attributes().AsRaw())
+}
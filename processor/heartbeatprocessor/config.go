@@ -0,0 +1,60 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package heartbeatprocessor
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	defaultMetricName      = "Heartbeat"
+	defaultSourceDimension = "Source"
+	defaultInterval        = time.Minute
+	defaultExpiration      = 3 * time.Minute
+)
+
+var (
+	errMissingMetricName      = errors.New("metric_name must be set")
+	errMissingSourceDimension = errors.New("source_dimension must be set")
+	errInvalidInterval        = errors.New("interval must be positive")
+	errInvalidExpiration      = errors.New("expiration must not be less than the interval")
+)
+
+type Config struct {
+	// MetricName is the name of the heartbeat metric.
+	MetricName string `mapstructure:"metric_name"`
+	// SourceDimension is the dimension that holds the source of the heartbeat, which is the instrumentation scope of
+	// its metrics.
+	SourceDimension string `mapstructure:"source_dimension"`
+	// Dimensions are added to every heartbeat.
+	Dimensions map[string]string `mapstructure:"dimensions,omitempty"`
+	// Interval is how often the heartbeats are emitted.
+	Interval time.Duration `mapstructure:"interval"`
+	// Expiration is how long a source is active after its last metrics. Heartbeats of the source stop once it expires.
+	Expiration time.Duration `mapstructure:"expiration"`
+	// DropMetrics drops the metrics of the sources so that only the heartbeats are passed on, such as in a pipeline
+	// that publishes the heartbeats to their own namespace.
+	DropMetrics bool `mapstructure:"drop_metrics"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if cfg.MetricName == "" {
+		return errMissingMetricName
+	}
+	if cfg.SourceDimension == "" {
+		return errMissingSourceDimension
+	}
+	if cfg.Interval <= 0 {
+		return errInvalidInterval
+	}
+	if cfg.Expiration < cfg.Interval {
+		return errInvalidExpiration
+	}
+	return nil
+}
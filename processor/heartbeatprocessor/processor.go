@@ -0,0 +1,130 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package heartbeatprocessor
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+)
+
+type heartbeatProcessor struct {
+	cfg          *Config
+	nextConsumer consumer.Metrics
+	logger       *zap.Logger
+	now          func() time.Time
+
+	mu sync.Mutex
+	// lastSeen is when the metrics of each source were last processed.
+	lastSeen map[string]time.Time
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+func newProcessor(cfg *Config, nextConsumer consumer.Metrics, logger *zap.Logger) *heartbeatProcessor {
+	return &heartbeatProcessor{
+		cfg:          cfg,
+		nextConsumer: nextConsumer,
+		logger:       logger,
+		now:          time.Now,
+		lastSeen:     map[string]time.Time{},
+		done:         make(chan struct{}),
+	}
+}
+
+func (p *heartbeatProcessor) start(context.Context, component.Host) error {
+	p.wg.Add(1)
+	go func() {
+		defer p.wg.Done()
+		ticker := time.NewTicker(p.cfg.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.emit(context.Background())
+			case <-p.done:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (p *heartbeatProcessor) shutdown(context.Context) error {
+	close(p.done)
+	p.wg.Wait()
+	return nil
+}
+
+// processMetrics marks the instrumentation scopes of the metrics as active sources.
+func (p *heartbeatProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	now := p.now()
+	p.mu.Lock()
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			if source := sms.At(j).Scope().Name(); source != "" && sms.At(j).Metrics().Len() > 0 {
+				p.lastSeen[source] = now
+			}
+		}
+	}
+	p.mu.Unlock()
+	if p.cfg.DropMetrics {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
+
+// emit sends a heartbeat for each active source and forgets the sources that expired.
+func (p *heartbeatProcessor) emit(ctx context.Context) {
+	sources := p.activeSources()
+	if len(sources) == 0 {
+		return
+	}
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName(p.cfg.MetricName)
+	m.SetUnit("Count")
+	dps := m.SetEmptyGauge().DataPoints()
+	timestamp := pcommon.NewTimestampFromTime(p.now())
+	for _, source := range sources {
+		dp := dps.AppendEmpty()
+		for key, value := range p.cfg.Dimensions {
+			dp.Attributes().PutStr(key, value)
+		}
+		dp.Attributes().PutStr(p.cfg.SourceDimension, source)
+		dp.SetTimestamp(timestamp)
+		dp.SetIntValue(1)
+	}
+	if err := p.nextConsumer.ConsumeMetrics(ctx, md); err != nil {
+		p.logger.Warn("Failed to send heartbeats", zap.Error(err))
+	}
+}
+
+func (p *heartbeatProcessor) activeSources() []string {
+	now := p.now()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	sources := make([]string, 0, len(p.lastSeen))
+	for source, lastSeen := range p.lastSeen {
+		if now.Sub(lastSeen) > p.cfg.Expiration {
+			p.logger.Debug("Source expired, stopping its heartbeat", zap.String("source", source))
+			delete(p.lastSeen, source)
+			continue
+		}
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+	return sources
+}
@@ -0,0 +1,97 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package heartbeatprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+)
+
+func newTestMetrics(sources ...string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	for _, source := range sources {
+		sm := rm.ScopeMetrics().AppendEmpty()
+		sm.Scope().SetName(source)
+		m := sm.Metrics().AppendEmpty()
+		m.SetName("metric")
+		m.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(1)
+	}
+	return md
+}
+
+// heartbeats returns the sources and dimensions of the heartbeats sent to the sink.
+func heartbeats(t *testing.T, sink *consumertest.MetricsSink) []map[string]any {
+	t.Helper()
+	all := sink.AllMetrics()
+	require.Len(t, all, 1)
+	sink.Reset()
+	m := all[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "Heartbeat", m.Name())
+	var got []map[string]any
+	for i := 0; i < m.Gauge().DataPoints().Len(); i++ {
+		dp := m.Gauge().DataPoints().At(i)
+		assert.EqualValues(t, 1, dp.IntValue())
+		got = append(got, dp.Attributes().AsRaw())
+	}
+	return got
+}
+
+func TestProcessor(t *testing.T) {
+	sink := &consumertest.MetricsSink{}
+	cfg := createDefaultConfig().(*Config)
+	cfg.Dimensions = map[string]string{"Host": "host-1"}
+	p := newProcessor(cfg, sink, zap.NewNop())
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	p.now = func() time.Time { return now }
+
+	// no heartbeats before any source is active
+	p.emit(context.Background())
+	assert.Empty(t, sink.AllMetrics())
+
+	md := newTestMetrics("telegraf_cpu", "telegraf_mem", "")
+	got, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, md, got)
+
+	p.emit(context.Background())
+	assert.Equal(t, []map[string]any{
+		{"Host": "host-1", "Source": "telegraf_cpu"},
+		{"Host": "host-1", "Source": "telegraf_mem"},
+	}, heartbeats(t, sink))
+
+	// the mem source is removed, so its heartbeat stops once it expires
+	now = now.Add(2 * time.Minute)
+	_, err = p.processMetrics(context.Background(), newTestMetrics("telegraf_cpu"))
+	require.NoError(t, err)
+	p.emit(context.Background())
+	assert.Len(t, heartbeats(t, sink), 2)
+
+	now = now.Add(2 * time.Minute)
+	p.emit(context.Background())
+	assert.Equal(t, []map[string]any{
+		{"Host": "host-1", "Source": "telegraf_cpu"},
+	}, heartbeats(t, sink))
+
+	now = now.Add(2 * time.Minute)
+	p.emit(context.Background())
+	assert.Empty(t, sink.AllMetrics())
+}
+
+func TestProcessorDropMetrics(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.DropMetrics = true
+	p := newProcessor(cfg, &consumertest.MetricsSink{}, zap.NewNop())
+	_, err := p.processMetrics(context.Background(), newTestMetrics("telegraf_cpu"))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	assert.Equal(t, []string{"telegraf_cpu"}, p.activeSources())
+}
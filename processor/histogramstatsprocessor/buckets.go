@@ -0,0 +1,149 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package histogramstatsprocessor
+
+import (
+	"math"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// bucket is the number of values between the lower and upper bound.
+type bucket struct {
+	lower float64
+	upper float64
+	count uint64
+}
+
+// distribution is a histogram data point with its buckets in ascending order, regardless of the histogram type.
+type distribution struct {
+	count   uint64
+	sum     float64
+	min     float64
+	max     float64
+	buckets []bucket
+}
+
+func fromHistogram(dp pmetric.HistogramDataPoint) distribution {
+	counts := dp.BucketCounts()
+	bounds := dp.ExplicitBounds()
+	buckets := make([]bucket, 0, counts.Len())
+	for i := 0; i < counts.Len(); i++ {
+		b := bucket{lower: math.Inf(-1), upper: math.Inf(1), count: counts.At(i)}
+		if i > 0 && i-1 < bounds.Len() {
+			b.lower = bounds.At(i - 1)
+		}
+		if i < bounds.Len() {
+			b.upper = bounds.At(i)
+		}
+		buckets = append(buckets, b)
+	}
+	d := distribution{count: dp.Count(), buckets: buckets}
+	d.setStats(dp.HasSum(), dp.Sum(), dp.HasMin(), dp.Min(), dp.HasMax(), dp.Max())
+	return d
+}
+
+// fromExponentialHistogram converts the exponential buckets into explicit ones. The bucket at index i covers
+// (base^i, base^(i+1)], where base is 2^(2^-scale), and the negative buckets mirror the positive ones.
+func fromExponentialHistogram(dp pmetric.ExponentialHistogramDataPoint) distribution {
+	base := math.Exp2(math.Exp2(-float64(dp.Scale())))
+	negative := dp.Negative()
+	positive := dp.Positive()
+	buckets := make([]bucket, 0, negative.BucketCounts().Len()+positive.BucketCounts().Len()+1)
+	for i := negative.BucketCounts().Len() - 1; i >= 0; i-- {
+		index := float64(negative.Offset()) + float64(i)
+		buckets = append(buckets, bucket{
+			lower: -math.Pow(base, index+1),
+			upper: -math.Pow(base, index),
+			count: negative.BucketCounts().At(i),
+		})
+	}
+	buckets = append(buckets, bucket{
+		lower: -dp.ZeroThreshold(),
+		upper: dp.ZeroThreshold(),
+		count: dp.ZeroCount(),
+	})
+	for i := 0; i < positive.BucketCounts().Len(); i++ {
+		index := float64(positive.Offset()) + float64(i)
+		buckets = append(buckets, bucket{
+			lower: math.Pow(base, index),
+			upper: math.Pow(base, index+1),
+			count: positive.BucketCounts().At(i),
+		})
+	}
+	d := distribution{count: dp.Count(), buckets: buckets}
+	d.setStats(dp.HasSum(), dp.Sum(), dp.HasMin(), dp.Min(), dp.HasMax(), dp.Max())
+	return d
+}
+
+// setStats uses the recorded statistics and estimates the missing ones from the buckets.
+func (d *distribution) setStats(hasSum bool, sum float64, hasMin bool, min float64, hasMax bool, max float64) {
+	first, last := -1, -1
+	for i, b := range d.buckets {
+		if b.count > 0 {
+			if first < 0 {
+				first = i
+			}
+			last = i
+		}
+	}
+	d.min, d.max = min, max
+	if !hasMin && first >= 0 {
+		d.min = finite(d.buckets[first].lower, d.buckets[first].upper)
+	}
+	if !hasMax && last >= 0 {
+		d.max = finite(d.buckets[last].upper, d.buckets[last].lower)
+	}
+	// a histogram without finite bounds only knows its mean
+	if math.IsInf(d.min, 0) || math.IsInf(d.max, 0) {
+		var mean float64
+		if hasSum {
+			mean = sum / float64(d.count)
+		}
+		d.min, d.max = finite(d.min, mean), finite(d.max, mean)
+	}
+	d.sum = sum
+	if !hasSum {
+		d.sum = 0
+		for _, b := range d.buckets {
+			d.sum += float64(b.count) * (d.lower(b) + d.upper(b)) / 2
+		}
+	}
+}
+
+// percentile estimates the value at the percentile by interpolating linearly within the bucket that holds it.
+func (d *distribution) percentile(p float64) float64 {
+	rank := p / 100 * float64(d.count)
+	var cumulative uint64
+	for _, b := range d.buckets {
+		if b.count == 0 {
+			continue
+		}
+		cumulative += b.count
+		if float64(cumulative) >= rank {
+			fraction := (rank - float64(cumulative-b.count)) / float64(b.count)
+			lower, upper := d.lower(b), d.upper(b)
+			return lower + (upper-lower)*math.Max(fraction, 0)
+		}
+	}
+	return d.max
+}
+
+// lower is the lower bound of the bucket within the minimum and maximum.
+func (d *distribution) lower(b bucket) float64 {
+	return math.Min(math.Max(b.lower, d.min), d.max)
+}
+
+// upper is the upper bound of the bucket within the minimum and maximum.
+func (d *distribution) upper(b bucket) float64 {
+	return math.Max(math.Min(b.upper, d.max), d.min)
+}
+
+// finite returns the bound if it is finite, or else the other bound.
+func finite(bound, other float64) float64 {
+	if math.IsInf(bound, 0) {
+		return other
+	}
+	return bound
+}
@@ -0,0 +1,52 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package histogramstatsprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+type Mode string
+
+const (
+	// ModeStatisticSet converts each data point into a summary with the sample count, sum, minimum (quantile 0) and
+	// maximum (quantile 1), which is exported as a CloudWatch StatisticSet.
+	ModeStatisticSet Mode = "statistic_set"
+	// ModePercentiles converts each data point into a gauge per percentile that is estimated from the buckets.
+	ModePercentiles Mode = "percentiles"
+)
+
+var errEmptyPercentiles = errors.New("percentiles must not be empty in percentiles mode")
+
+type Config struct {
+	// Mode is how the histograms are converted.
+	Mode Mode `mapstructure:"mode"`
+	// Percentiles are the percentiles to estimate in percentiles mode, such as 99.9.
+	Percentiles []float64 `mapstructure:"percentiles,omitempty"`
+	// MetricNames are the names of the histograms to convert. All of the histograms are converted if empty.
+	MetricNames []string `mapstructure:"metric_names,omitempty"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	switch cfg.Mode {
+	case ModeStatisticSet:
+	case ModePercentiles:
+		if len(cfg.Percentiles) == 0 {
+			return errEmptyPercentiles
+		}
+		for _, percentile := range cfg.Percentiles {
+			if percentile < 0 || percentile > 100 {
+				return fmt.Errorf("percentile %v must be between 0 and 100", percentile)
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported mode %q", cfg.Mode)
+	}
+	return nil
+}
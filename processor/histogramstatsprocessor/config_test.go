@@ -0,0 +1,64 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package histogramstatsprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	testCases := []struct {
+		id      component.ID
+		want    component.Config
+		wantErr string
+	}{
+		{
+			id:   component.NewID(component.MustNewType(typeStr)),
+			want: NewFactory().CreateDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(component.MustNewType(typeStr), "percentiles"),
+			want: &Config{
+				Mode:        ModePercentiles,
+				Percentiles: []float64{50, 99.9},
+				MetricNames: []string{"latency"},
+			},
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "invalid_mode"),
+			wantErr: `unsupported mode "average"`,
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "empty_percentiles"),
+			wantErr: errEmptyPercentiles.Error(),
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "invalid_percentile"),
+			wantErr: "percentile 101 must be between 0 and 100",
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.id.String(), func(t *testing.T) {
+			conf, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := conf.Sub(testCase.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, component.ValidateConfig(cfg), testCase.wantErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, testCase.want, cfg)
+		})
+	}
+}
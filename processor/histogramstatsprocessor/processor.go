@@ -0,0 +1,125 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package histogramstatsprocessor
+
+import (
+	"context"
+	"strconv"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
+)
+
+type histogramStatsProcessor struct {
+	mode        Mode
+	percentiles []float64
+	metricNames collections.Set[string]
+}
+
+func newProcessor(cfg *Config) *histogramStatsProcessor {
+	return &histogramStatsProcessor{
+		mode:        cfg.Mode,
+		percentiles: cfg.Percentiles,
+		metricNames: collections.NewSet(cfg.MetricNames...),
+	}
+}
+
+func (p *histogramStatsProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			// the converted metrics are appended, so only the original metrics are visited
+			n := ms.Len()
+			for k := 0; k < n; k++ {
+				if m := ms.At(k); p.shouldConvert(m) {
+					p.convert(m, ms)
+				}
+			}
+			ms.RemoveIf(p.shouldConvert)
+		}
+	}
+	return md, nil
+}
+
+func (p *histogramStatsProcessor) shouldConvert(m pmetric.Metric) bool {
+	if m.Type() != pmetric.MetricTypeHistogram && m.Type() != pmetric.MetricTypeExponentialHistogram {
+		return false
+	}
+	return len(p.metricNames) == 0 || p.metricNames.Contains(m.Name())
+}
+
+// convert appends the metrics that replace the histogram. Data points without values are dropped.
+func (p *histogramStatsProcessor) convert(m pmetric.Metric, ms pmetric.MetricSlice) {
+	var ds []distribution
+	var dps []dataPoint
+	switch m.Type() {
+	case pmetric.MetricTypeHistogram:
+		hdps := m.Histogram().DataPoints()
+		for i := 0; i < hdps.Len(); i++ {
+			if dp := hdps.At(i); dp.Count() > 0 {
+				ds = append(ds, fromHistogram(dp))
+				dps = append(dps, dp)
+			}
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		edps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < edps.Len(); i++ {
+			if dp := edps.At(i); dp.Count() > 0 {
+				ds = append(ds, fromExponentialHistogram(dp))
+				dps = append(dps, dp)
+			}
+		}
+	}
+	if p.mode == ModePercentiles {
+		for _, percentile := range p.percentiles {
+			gauge := newMetric(m, ms, m.Name()+"_p"+strconv.FormatFloat(percentile, 'f', -1, 64))
+			gaugeDps := gauge.SetEmptyGauge().DataPoints()
+			for i, d := range ds {
+				gaugeDp := gaugeDps.AppendEmpty()
+				copyDataPoint(dps[i], gaugeDp.Attributes(), gaugeDp.SetStartTimestamp, gaugeDp.SetTimestamp)
+				gaugeDp.SetDoubleValue(d.percentile(percentile))
+			}
+		}
+		return
+	}
+	summary := newMetric(m, ms, m.Name())
+	summaryDps := summary.SetEmptySummary().DataPoints()
+	for i, d := range ds {
+		summaryDp := summaryDps.AppendEmpty()
+		copyDataPoint(dps[i], summaryDp.Attributes(), summaryDp.SetStartTimestamp, summaryDp.SetTimestamp)
+		summaryDp.SetCount(d.count)
+		summaryDp.SetSum(d.sum)
+		minimum := summaryDp.QuantileValues().AppendEmpty()
+		minimum.SetQuantile(0)
+		minimum.SetValue(d.min)
+		maximum := summaryDp.QuantileValues().AppendEmpty()
+		maximum.SetQuantile(1)
+		maximum.SetValue(d.max)
+	}
+}
+
+// dataPoint is the part of a histogram data point that is kept on the converted data points.
+type dataPoint interface {
+	Attributes() pcommon.Map
+	StartTimestamp() pcommon.Timestamp
+	Timestamp() pcommon.Timestamp
+}
+
+func copyDataPoint(dp dataPoint, attrs pcommon.Map, setStartTimestamp, setTimestamp func(pcommon.Timestamp)) {
+	dp.Attributes().CopyTo(attrs)
+	setStartTimestamp(dp.StartTimestamp())
+	setTimestamp(dp.Timestamp())
+}
+
+func newMetric(m pmetric.Metric, ms pmetric.MetricSlice, name string) pmetric.Metric {
+	metric := ms.AppendEmpty()
+	metric.SetName(name)
+	metric.SetUnit(m.Unit())
+	metric.SetDescription(m.Description())
+	return metric
+}
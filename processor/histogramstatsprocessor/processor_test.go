@@ -0,0 +1,134 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package histogramstatsprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func newTestMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+
+	histogram := ms.AppendEmpty()
+	histogram.SetName("latency")
+	histogram.SetUnit("ms")
+	dp := histogram.SetEmptyHistogram().DataPoints().AppendEmpty()
+	dp.Attributes().PutStr("Service", "checkout")
+	dp.SetTimestamp(pcommon.Timestamp(1000))
+	dp.ExplicitBounds().FromRaw([]float64{1, 2, 5})
+	dp.BucketCounts().FromRaw([]uint64{2, 3, 4, 1})
+	dp.SetCount(10)
+	dp.SetSum(30)
+	dp.SetMin(0.5)
+	dp.SetMax(8)
+	// data points without values are dropped
+	histogram.Histogram().DataPoints().AppendEmpty()
+
+	exponential := ms.AppendEmpty()
+	exponential.SetName("size")
+	edp := exponential.SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	// scale 0 has a base of 2
+	edp.SetScale(0)
+	edp.SetCount(5)
+	edp.SetZeroCount(1)
+	edp.Negative().BucketCounts().FromRaw([]uint64{1})
+	edp.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+
+	gauge := ms.AppendEmpty()
+	gauge.SetName("gauge")
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(1)
+	return md
+}
+
+func metricsByName(md pmetric.Metrics) map[string]pmetric.Metric {
+	result := map[string]pmetric.Metric{}
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < ms.Len(); i++ {
+		result[ms.At(i).Name()] = ms.At(i)
+	}
+	return result
+}
+
+func TestProcessMetricsStatisticSet(t *testing.T) {
+	p := newProcessor(&Config{Mode: ModeStatisticSet})
+	md, err := p.processMetrics(context.Background(), newTestMetrics())
+	require.NoError(t, err)
+
+	got := metricsByName(md)
+	require.Len(t, got, 3)
+	assert.Equal(t, pmetric.MetricTypeGauge, got["gauge"].Type())
+
+	latency := got["latency"]
+	require.Equal(t, pmetric.MetricTypeSummary, latency.Type())
+	assert.Equal(t, "ms", latency.Unit())
+	require.Equal(t, 1, latency.Summary().DataPoints().Len())
+	dp := latency.Summary().DataPoints().At(0)
+	assert.Equal(t, map[string]any{"Service": "checkout"}, dp.Attributes().AsRaw())
+	assert.Equal(t, pcommon.Timestamp(1000), dp.Timestamp())
+	assert.EqualValues(t, 10, dp.Count())
+	assert.Equal(t, 30.0, dp.Sum())
+	require.Equal(t, 2, dp.QuantileValues().Len())
+	assert.Equal(t, 0.0, dp.QuantileValues().At(0).Quantile())
+	assert.Equal(t, 0.5, dp.QuantileValues().At(0).Value())
+	assert.Equal(t, 1.0, dp.QuantileValues().At(1).Quantile())
+	assert.Equal(t, 8.0, dp.QuantileValues().At(1).Value())
+
+	// the missing statistics of the exponential histogram are estimated from the buckets
+	size := got["size"]
+	require.Equal(t, pmetric.MetricTypeSummary, size.Type())
+	dp = size.Summary().DataPoints().At(0)
+	assert.EqualValues(t, 5, dp.Count())
+	assert.Equal(t, 6.0, dp.Sum())
+	assert.Equal(t, -2.0, dp.QuantileValues().At(0).Value())
+	assert.Equal(t, 4.0, dp.QuantileValues().At(1).Value())
+}
+
+func TestProcessMetricsPercentiles(t *testing.T) {
+	p := newProcessor(&Config{Mode: ModePercentiles, Percentiles: []float64{0, 50, 90, 99, 100}})
+	md, err := p.processMetrics(context.Background(), newTestMetrics())
+	require.NoError(t, err)
+
+	got := metricsByName(md)
+	require.Len(t, got, 11)
+	want := map[string]float64{
+		"latency_p0":   0.5,
+		"latency_p50":  2,
+		"latency_p90":  5,
+		"latency_p99":  7.7,
+		"latency_p100": 8,
+		"size_p0":      -2,
+		"size_p50":     1.5,
+		"size_p90":     3.5,
+		"size_p99":     3.95,
+		"size_p100":    4,
+	}
+	for name, value := range want {
+		m, ok := got[name]
+		require.True(t, ok, name)
+		require.Equal(t, pmetric.MetricTypeGauge, m.Type())
+		require.Equal(t, 1, m.Gauge().DataPoints().Len())
+		assert.InDelta(t, value, m.Gauge().DataPoints().At(0).DoubleValue(), 1e-9, name)
+	}
+	assert.Equal(t, "ms", got["latency_p99"].Unit())
+	assert.Equal(t, map[string]any{"Service": "checkout"}, got["latency_p99"].Gauge().DataPoints().At(0).Attributes().AsRaw())
+}
+
+func TestProcessMetricsMetricNames(t *testing.T) {
+	p := newProcessor(&Config{Mode: ModeStatisticSet, MetricNames: []string{"size"}})
+	md, err := p.processMetrics(context.Background(), newTestMetrics())
+	require.NoError(t, err)
+
+	got := metricsByName(md)
+	require.Len(t, got, 3)
+	assert.Equal(t, pmetric.MetricTypeHistogram, got["latency"].Type())
+	assert.Equal(t, 2, got["latency"].Histogram().DataPoints().Len())
+	assert.Equal(t, pmetric.MetricTypeSummary, got["size"].Type())
+}
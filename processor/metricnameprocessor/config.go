@@ -0,0 +1,53 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package metricnameprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	// defaultMaxLength is the length of the longest metric name that CloudWatch accepts.
+	defaultMaxLength = 255
+	// minMaxLength leaves room for part of the name before the hash suffix of a truncated name.
+	minMaxLength       = 2 * hashSuffixLength
+	defaultReplacement = "_"
+)
+
+var (
+	errInvalidMaxLength   = fmt.Errorf("max_length must be between %d and %d", minMaxLength, defaultMaxLength)
+	errEmptyReplacement   = errors.New("replacement must not be empty")
+	errInvalidReplacement = errors.New("replacement must only contain printable ASCII characters")
+)
+
+type Config struct {
+	// MaxLength is the maximum length of a metric name. Longer names are truncated with a hash suffix.
+	MaxLength int `mapstructure:"max_length"`
+	// Replacement replaces each invalid character of a metric name.
+	Replacement string `mapstructure:"replacement"`
+	// DropInvalid drops the metrics with names that are too long or have invalid characters instead of normalizing
+	// them.
+	DropInvalid bool `mapstructure:"drop_invalid"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if cfg.MaxLength < minMaxLength || cfg.MaxLength > defaultMaxLength {
+		return errInvalidMaxLength
+	}
+	// an empty replacement could normalize a name to an empty name
+	if cfg.Replacement == "" {
+		return errEmptyReplacement
+	}
+	for i := 0; i < len(cfg.Replacement); i++ {
+		if !isValidChar(cfg.Replacement[i]) {
+			return errInvalidReplacement
+		}
+	}
+	return nil
+}
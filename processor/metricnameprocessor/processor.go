@@ -0,0 +1,151 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package metricnameprocessor
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+)
+
+// hashSuffixLength is the length of the suffix of a truncated name, an underscore and the 8 hex digits of the hash of
+// the original name.
+const hashSuffixLength = 9
+
+// maxLoggedNames is the number of names whose first change is logged. The changes of other names are only logged
+// periodically with the counts, so a source of always new invalid names does not grow the memory or the logs.
+const maxLoggedNames = 1000
+
+type metricNameProcessor struct {
+	maxLength   int
+	replacement string
+	dropInvalid bool
+	logger      *zap.Logger
+
+	// normalized and dropped count the metrics whose names were normalized or that were dropped.
+	normalized atomic.Uint64
+	dropped    atomic.Uint64
+
+	mu sync.Mutex
+	// logged are the names of the metrics that a change was logged for.
+	logged map[string]struct{}
+	// summary logs the counts once logged is full.
+	summary rate.Sometimes
+}
+
+func newProcessor(cfg *Config, logger *zap.Logger) *metricNameProcessor {
+	return &metricNameProcessor{
+		maxLength:   cfg.MaxLength,
+		replacement: cfg.Replacement,
+		dropInvalid: cfg.DropInvalid,
+		logger:      logger,
+		logged:      map[string]struct{}{},
+		summary:     rate.Sometimes{Interval: time.Minute},
+	}
+}
+
+func (p *metricNameProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				return !p.processName(m)
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	if md.ResourceMetrics().Len() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
+
+// processName normalizes the name of the metric if CloudWatch does not accept it. Returns false if the metric should
+// be dropped instead.
+func (p *metricNameProcessor) processName(m pmetric.Metric) bool {
+	name := m.Name()
+	if p.isValidName(name) {
+		return true
+	}
+	if p.dropInvalid {
+		p.dropped.Add(1)
+		p.logOnce(name, "Dropped a metric with a name that CloudWatch does not accept", zap.String("metric", name))
+		return false
+	}
+	normalized := p.normalize(name)
+	m.SetName(normalized)
+	p.normalized.Add(1)
+	p.logOnce(name, "Normalized a metric name that CloudWatch does not accept",
+		zap.String("metric", name),
+		zap.String("normalized", normalized))
+	return true
+}
+
+func (p *metricNameProcessor) isValidName(name string) bool {
+	if len(name) > p.maxLength {
+		return false
+	}
+	for i := 0; i < len(name); i++ {
+		if !isValidChar(name[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// normalize replaces the invalid characters of the name and truncates it to the max length. A truncated name ends
+// with the hash of the original name so that the names that only differ after the max length do not collide.
+func (p *metricNameProcessor) normalize(name string) string {
+	var sb strings.Builder
+	for _, r := range name {
+		if r < utf8.RuneSelf && isValidChar(byte(r)) {
+			sb.WriteRune(r)
+		} else {
+			sb.WriteString(p.replacement)
+		}
+	}
+	normalized := sb.String()
+	if len(normalized) <= p.maxLength {
+		return normalized
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	return fmt.Sprintf("%s_%08x", normalized[:p.maxLength-hashSuffixLength], h.Sum32())
+}
+
+// logOnce logs the first change of each metric name, up to maxLoggedNames names.
+func (p *metricNameProcessor) logOnce(name string, msg string, fields ...zap.Field) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.logged[name]; ok {
+		return
+	}
+	if len(p.logged) >= maxLoggedNames {
+		p.summary.Do(func() {
+			p.logger.Warn("Changed more metric names that CloudWatch does not accept",
+				zap.Uint64("normalized", p.normalized.Load()),
+				zap.Uint64("dropped", p.dropped.Load()))
+		})
+		return
+	}
+	p.logged[name] = struct{}{}
+	p.logger.Warn(msg, append(fields,
+		zap.Uint64("normalized", p.normalized.Load()),
+		zap.Uint64("dropped", p.dropped.Load()))...)
+}
+
+// isValidChar returns true if the character is printable ASCII, which is what CloudWatch accepts in metric names.
+func isValidChar(c byte) bool {
+	return c >= ' ' && c <= '~'
+}
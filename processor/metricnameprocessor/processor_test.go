@@ -0,0 +1,112 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package metricnameprocessor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newMetrics(names ...string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	for _, name := range names {
+		m := ms.AppendEmpty()
+		m.SetName(name)
+		m.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(1)
+	}
+	return md
+}
+
+func names(md pmetric.Metrics) []string {
+	var result []string
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				result = append(result, ms.At(k).Name())
+			}
+		}
+	}
+	return result
+}
+
+func TestProcessMetrics(t *testing.T) {
+	longName := strings.Repeat("a", 300)
+	otherLongName := strings.Repeat("a", 299) + "b"
+
+	p := newProcessor(&Config{MaxLength: defaultMaxLength, Replacement: defaultReplacement}, zap.NewNop())
+	md, err := p.processMetrics(context.Background(), newMetrics("cpu_usage", longName, otherLongName, "disk\tused/é", longName))
+	require.NoError(t, err)
+
+	got := names(md)
+	require.Len(t, got, 5)
+	assert.Equal(t, "cpu_usage", got[0])
+	assert.Len(t, got[1], defaultMaxLength)
+	assert.True(t, strings.HasPrefix(got[1], strings.Repeat("a", defaultMaxLength-hashSuffixLength)+"_"))
+	// names that only differ after the max length do not collide
+	assert.Len(t, got[2], defaultMaxLength)
+	assert.NotEqual(t, got[1], got[2])
+	// the multi-byte character is replaced once
+	assert.Equal(t, "disk_used/_", got[3])
+	// the same name is always truncated to the same name
+	assert.Equal(t, got[1], got[4])
+	assert.EqualValues(t, 4, p.normalized.Load())
+	assert.EqualValues(t, 0, p.dropped.Load())
+}
+
+func TestProcessMetricsReplacement(t *testing.T) {
+	p := newProcessor(&Config{MaxLength: 20, Replacement: "--"}, zap.NewNop())
+	md, err := p.processMetrics(context.Background(), newMetrics("disk\tused", "a_very_long_metric_name_over_twenty"))
+	require.NoError(t, err)
+	got := names(md)
+	assert.Equal(t, "disk--used", got[0])
+	assert.Len(t, got[1], 20)
+	assert.True(t, strings.HasPrefix(got[1], "a_very_long"))
+}
+
+func TestProcessMetricsDropInvalid(t *testing.T) {
+	p := newProcessor(&Config{MaxLength: defaultMaxLength, Replacement: defaultReplacement, DropInvalid: true}, zap.NewNop())
+	md, err := p.processMetrics(context.Background(), newMetrics("cpu_usage", strings.Repeat("a", 300), "disk\tused"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cpu_usage"}, names(md))
+	assert.EqualValues(t, 0, p.normalized.Load())
+	assert.EqualValues(t, 2, p.dropped.Load())
+
+	md, err = p.processMetrics(context.Background(), newMetrics("disk\tused"))
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+	assert.Equal(t, 0, md.ResourceMetrics().Len())
+	assert.EqualValues(t, 3, p.dropped.Load())
+}
+
+func TestProcessMetricsLogsBounded(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	p := newProcessor(&Config{MaxLength: defaultMaxLength, Replacement: defaultReplacement}, zap.New(core))
+	for i := 0; i < maxLoggedNames+10; i++ {
+		_, err := p.processMetrics(context.Background(), newMetrics(fmt.Sprintf("invalid\t%d", i)))
+		require.NoError(t, err)
+	}
+	// the same name is only logged once
+	_, err := p.processMetrics(context.Background(), newMetrics("invalid\t0"))
+	require.NoError(t, err)
+
+	assert.Len(t, p.logged, maxLoggedNames)
+	assert.EqualValues(t, maxLoggedNames+11, p.normalized.Load())
+	// the names over the limit are summarized at most once a minute
+	assert.Equal(t, maxLoggedNames+1, logs.Len())
+	summary := logs.All()[maxLoggedNames]
+	assert.Equal(t, "Changed more metric names that CloudWatch does not accept", summary.Message)
+	assert.EqualValues(t, maxLoggedNames+1, summary.ContextMap()["normalized"])
+}
@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package metricsplitprocessor
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// ValuePlaceholder is replaced with the attribute value in the name of the split metrics.
+const ValuePlaceholder = "{value}"
+
+var errMissingMetricName = errors.New("split metric_name must be set")
+
+type Config struct {
+	// Rules are the metrics to split.
+	Rules []Rule `mapstructure:"rules,omitempty"`
+}
+
+// Rule splits the data points of a metric into one metric per value of an
+// attribute.
+type Rule struct {
+	// MetricName is the name of the metric to split.
+	MetricName string `mapstructure:"metric_name"`
+	// Attribute is the data point attribute to split on. It is dropped from the
+	// data points of the split metrics.
+	Attribute string `mapstructure:"attribute"`
+	// NewName is the name of the split metrics. Defaults to <metric_name>_{value}.
+	NewName string `mapstructure:"new_name,omitempty"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	for _, rule := range cfg.Rules {
+		if rule.MetricName == "" {
+			return errMissingMetricName
+		}
+		if rule.Attribute == "" {
+			return fmt.Errorf("split attribute must be set for metric %q", rule.MetricName)
+		}
+		if rule.NewName != "" && !strings.Contains(rule.NewName, ValuePlaceholder) {
+			return fmt.Errorf("split new_name must contain %s for metric %q", ValuePlaceholder, rule.MetricName)
+		}
+	}
+	return nil
+}
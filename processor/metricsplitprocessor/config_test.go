@@ -0,0 +1,64 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package metricsplitprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	testCases := []struct {
+		id      component.ID
+		want    component.Config
+		wantErr string
+	}{
+		{
+			id:   component.NewID(component.MustNewType(typeStr)),
+			want: NewFactory().CreateDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(component.MustNewType(typeStr), "1"),
+			want: &Config{Rules: []Rule{
+				{MetricName: "pods", Attribute: "state"},
+				{MetricName: "nodes", Attribute: "condition", NewName: "nodes_{value}_count"},
+			}},
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "missing_attribute"),
+			wantErr: `split attribute must be set for metric "pods"`,
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "missing_value"),
+			wantErr: `split new_name must contain {value} for metric "pods"`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.id.String(), func(t *testing.T) {
+			conf, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := conf.Sub(testCase.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, component.ValidateConfig(cfg), testCase.wantErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, testCase.want, cfg)
+		})
+	}
+}
+
+func TestValidateMissingMetricName(t *testing.T) {
+	cfg := &Config{Rules: []Rule{{Attribute: "state"}}}
+	assert.ErrorIs(t, cfg.Validate(), errMissingMetricName)
+}
@@ -0,0 +1,128 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package metricsplitprocessor
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+type metricSplitProcessor struct {
+	// rules are the rules keyed by the name of the metric they split.
+	rules  map[string]Rule
+	logger *zap.Logger
+}
+
+func newProcessor(cfg *Config, logger *zap.Logger) *metricSplitProcessor {
+	rules := make(map[string]Rule, len(cfg.Rules))
+	for _, rule := range cfg.Rules {
+		// the first rule for a metric wins
+		if _, ok := rules[rule.MetricName]; ok {
+			continue
+		}
+		if rule.NewName == "" {
+			rule.NewName = rule.MetricName + "_" + ValuePlaceholder
+		}
+		rules[rule.MetricName] = rule
+	}
+	return &metricSplitProcessor{
+		rules:  rules,
+		logger: logger,
+	}
+}
+
+func (p *metricSplitProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	if len(p.rules) == 0 {
+		return md, nil
+	}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			p.processScopeMetrics(sms.At(j).Metrics())
+		}
+	}
+	return md, nil
+}
+
+// processScopeMetrics moves the data points of the metrics that match a rule
+// into a metric per attribute value. The split metrics are appended to the same
+// scope and the original metric is removed once all of its data points are moved.
+func (p *metricSplitProcessor) processScopeMetrics(metrics pmetric.MetricSlice) {
+	// capture the length so that the split metrics are not processed again
+	n := metrics.Len()
+	for k := 0; k < n; k++ {
+		m := metrics.At(k)
+		rule, ok := p.rules[m.Name()]
+		if !ok {
+			continue
+		}
+		var dps pmetric.NumberDataPointSlice
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			dps = m.Gauge().DataPoints()
+		case pmetric.MetricTypeSum:
+			dps = m.Sum().DataPoints()
+		default:
+			p.logger.Debug("Skipping split of unsupported metric type",
+				zap.String("metric", m.Name()),
+				zap.String("type", m.Type().String()))
+			continue
+		}
+		split := map[string]pmetric.NumberDataPointSlice{}
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool {
+			value, ok := dp.Attributes().Get(rule.Attribute)
+			if !ok {
+				return false
+			}
+			name := strings.ReplaceAll(rule.NewName, ValuePlaceholder, nameSuffix(value.AsString()))
+			splitDps, ok := split[name]
+			if !ok {
+				splitDps = newSplitMetric(metrics.AppendEmpty(), m, name)
+				split[name] = splitDps
+			}
+			newDp := splitDps.AppendEmpty()
+			dp.CopyTo(newDp)
+			newDp.Attributes().Remove(rule.Attribute)
+			return true
+		})
+	}
+	metrics.RemoveIf(func(m pmetric.Metric) bool {
+		if _, ok := p.rules[m.Name()]; !ok {
+			return false
+		}
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			return m.Gauge().DataPoints().Len() == 0
+		case pmetric.MetricTypeSum:
+			return m.Sum().DataPoints().Len() == 0
+		}
+		return false
+	})
+}
+
+// newSplitMetric initializes the split metric with the metadata of the original
+// metric and returns its data points.
+func newSplitMetric(dest, orig pmetric.Metric, name string) pmetric.NumberDataPointSlice {
+	dest.SetName(name)
+	dest.SetDescription(orig.Description())
+	dest.SetUnit(orig.Unit())
+	orig.Metadata().CopyTo(dest.Metadata())
+	if orig.Type() == pmetric.MetricTypeSum {
+		sum := dest.SetEmptySum()
+		sum.SetAggregationTemporality(orig.Sum().AggregationTemporality())
+		sum.SetIsMonotonic(orig.Sum().IsMonotonic())
+		return sum.DataPoints()
+	}
+	return dest.SetEmptyGauge().DataPoints()
+}
+
+// nameSuffix converts the attribute value, e.g. "Running", to the suffix of the
+// split metric name, e.g. "running".
+func nameSuffix(value string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(value)), " ", "_")
+}
@@ -0,0 +1,142 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package metricsplitprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+func addDataPoint(dps pmetric.NumberDataPointSlice, value int64, attrs map[string]string) {
+	dp := dps.AppendEmpty()
+	dp.SetIntValue(value)
+	dp.SetTimestamp(pcommon.Timestamp(1000))
+	for k, v := range attrs {
+		dp.Attributes().PutStr(k, v)
+	}
+}
+
+func metricsByName(ms pmetric.MetricSlice) map[string]pmetric.Metric {
+	result := map[string]pmetric.Metric{}
+	for i := 0; i < ms.Len(); i++ {
+		result[ms.At(i).Name()] = ms.At(i)
+	}
+	return result
+}
+
+func TestProcessMetrics(t *testing.T) {
+	p := newProcessor(&Config{Rules: []Rule{{MetricName: "pods", Attribute: "state"}}}, zap.NewNop())
+
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	pods := ms.AppendEmpty()
+	pods.SetName("pods")
+	pods.SetUnit("Count")
+	pods.SetDescription("Number of pods by state")
+	podsDps := pods.SetEmptyGauge().DataPoints()
+	addDataPoint(podsDps, 5, map[string]string{"state": "Running", "namespace": "default"})
+	addDataPoint(podsDps, 2, map[string]string{"state": "Pending", "namespace": "default"})
+	addDataPoint(podsDps, 3, map[string]string{"state": "Running", "namespace": "kube-system"})
+	podsDps.At(2).Exemplars().AppendEmpty().SetTraceID(pcommon.TraceID{1})
+	other := ms.AppendEmpty()
+	other.SetName("nodes")
+	addDataPoint(other.SetEmptyGauge().DataPoints(), 4, map[string]string{"state": "Ready"})
+
+	got, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	gotMetrics := metricsByName(got.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics())
+	require.Len(t, gotMetrics, 3)
+	assert.NotContains(t, gotMetrics, "pods")
+	// metrics without a rule are left as is
+	assert.Equal(t, 1, gotMetrics["nodes"].Gauge().DataPoints().Len())
+
+	running := gotMetrics["pods_running"]
+	assert.Equal(t, "Count", running.Unit())
+	assert.Equal(t, "Number of pods by state", running.Description())
+	require.Equal(t, pmetric.MetricTypeGauge, running.Type())
+	require.Equal(t, 2, running.Gauge().DataPoints().Len())
+	for i, want := range []struct {
+		value     int64
+		namespace string
+	}{{5, "default"}, {3, "kube-system"}} {
+		dp := running.Gauge().DataPoints().At(i)
+		assert.Equal(t, want.value, dp.IntValue())
+		assert.Equal(t, pcommon.Timestamp(1000), dp.Timestamp())
+		assert.Equal(t, map[string]any{"namespace": want.namespace}, dp.Attributes().AsRaw())
+	}
+	// exemplars are moved with the data points
+	require.Equal(t, 1, running.Gauge().DataPoints().At(1).Exemplars().Len())
+	assert.Equal(t, pcommon.TraceID{1}, running.Gauge().DataPoints().At(1).Exemplars().At(0).TraceID())
+
+	pending := gotMetrics["pods_pending"]
+	require.Equal(t, 1, pending.Gauge().DataPoints().Len())
+	assert.Equal(t, int64(2), pending.Gauge().DataPoints().At(0).IntValue())
+	assert.Equal(t, map[string]any{"namespace": "default"}, pending.Gauge().DataPoints().At(0).Attributes().AsRaw())
+}
+
+func TestProcessMetricsSum(t *testing.T) {
+	p := newProcessor(&Config{Rules: []Rule{
+		{MetricName: "requests", Attribute: "status", NewName: "requests_{value}_count"},
+	}}, zap.NewNop())
+
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	requests := ms.AppendEmpty()
+	requests.SetName("requests")
+	sum := requests.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sum.SetIsMonotonic(true)
+	addDataPoint(sum.DataPoints(), 10, map[string]string{"status": "Server Error"})
+	// data points without the attribute stay in the original metric
+	addDataPoint(sum.DataPoints(), 7, nil)
+
+	got, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	gotMetrics := metricsByName(got.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics())
+	require.Len(t, gotMetrics, 2)
+	require.Equal(t, 1, gotMetrics["requests"].Sum().DataPoints().Len())
+	assert.Equal(t, int64(7), gotMetrics["requests"].Sum().DataPoints().At(0).IntValue())
+
+	split := gotMetrics["requests_server_error_count"]
+	require.Equal(t, pmetric.MetricTypeSum, split.Type())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, split.Sum().AggregationTemporality())
+	assert.True(t, split.Sum().IsMonotonic())
+	require.Equal(t, 1, split.Sum().DataPoints().Len())
+	assert.Equal(t, int64(10), split.Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, 0, split.Sum().DataPoints().At(0).Attributes().Len())
+}
+
+func TestProcessMetricsUnsupportedType(t *testing.T) {
+	p := newProcessor(&Config{Rules: []Rule{{MetricName: "latency", Attribute: "state"}}}, zap.NewNop())
+
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	latency := ms.AppendEmpty()
+	latency.SetName("latency")
+	latency.SetEmptyHistogram().DataPoints().AppendEmpty().Attributes().PutStr("state", "Running")
+
+	got, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	gotMetrics := got.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, gotMetrics.Len())
+	assert.Equal(t, "latency", gotMetrics.At(0).Name())
+}
+
+func TestProcessMetricsNoRules(t *testing.T) {
+	p := newProcessor(&Config{}, zap.NewNop())
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("pods")
+	addDataPoint(m.SetEmptyGauge().DataPoints(), 1, map[string]string{"state": "Running"})
+
+	got, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, "pods", got.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+}
@@ -0,0 +1,59 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package nonfiniteprocessor
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	// actionDrop drops the datapoints with a NaN or infinite value.
+	actionDrop = "drop"
+	// actionReplace replaces the NaN and infinite values with the replacement.
+	actionReplace = "replace"
+	// actionClamp replaces the infinite values with the max value of their sign and drops the datapoints with a NaN
+	// value.
+	actionClamp = "clamp"
+
+	// defaultMaxValue is the largest value that CloudWatch accepts, 2^360.
+	defaultMaxValue = 1.174271e+108
+)
+
+var (
+	errInvalidAction      = fmt.Errorf("action must be one of %q, %q or %q", actionDrop, actionReplace, actionClamp)
+	errInvalidReplacement = errors.New("replacement must be a finite number")
+	errInvalidMaxValue    = errors.New("max_value must be a finite number greater than 0")
+)
+
+type Config struct {
+	// Action is what is done with the NaN and infinite values.
+	Action string `mapstructure:"action"`
+	// Replacement replaces the NaN and infinite values with the replace action.
+	Replacement float64 `mapstructure:"replacement"`
+	// MaxValue replaces the infinite values with the clamp action. Negative infinity is replaced with -MaxValue.
+	MaxValue float64 `mapstructure:"max_value"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	switch cfg.Action {
+	case actionDrop:
+	case actionReplace:
+		if math.IsNaN(cfg.Replacement) || math.IsInf(cfg.Replacement, 0) {
+			return errInvalidReplacement
+		}
+	case actionClamp:
+		if math.IsNaN(cfg.MaxValue) || math.IsInf(cfg.MaxValue, 0) || cfg.MaxValue <= 0 {
+			return errInvalidMaxValue
+		}
+	default:
+		return errInvalidAction
+	}
+	return nil
+}
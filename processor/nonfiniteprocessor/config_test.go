@@ -0,0 +1,68 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package nonfiniteprocessor
+
+import (
+	"math"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	testCases := []struct {
+		id      component.ID
+		want    component.Config
+		wantErr error
+	}{
+		{
+			id:   component.NewID(component.MustNewType(typeStr)),
+			want: &Config{Action: actionDrop, MaxValue: defaultMaxValue},
+		},
+		{
+			id:   component.NewIDWithName(component.MustNewType(typeStr), "replace"),
+			want: &Config{Action: actionReplace, Replacement: -1, MaxValue: defaultMaxValue},
+		},
+		{
+			id:   component.NewIDWithName(component.MustNewType(typeStr), "clamp"),
+			want: &Config{Action: actionClamp, MaxValue: 1e9},
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "invalid_action"),
+			wantErr: errInvalidAction,
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "invalid_max_value"),
+			wantErr: errInvalidMaxValue,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.id.String(), func(t *testing.T) {
+			conf, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := conf.Sub(testCase.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, component.ValidateConfig(cfg), testCase.wantErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, testCase.want, cfg)
+		})
+	}
+}
+
+func TestValidateReplacement(t *testing.T) {
+	cfg := &Config{Action: actionReplace, Replacement: math.Inf(1)}
+	assert.ErrorIs(t, cfg.Validate(), errInvalidReplacement)
+	cfg.Replacement = math.NaN()
+	assert.ErrorIs(t, cfg.Validate(), errInvalidReplacement)
+}
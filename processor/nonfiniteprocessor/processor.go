@@ -0,0 +1,201 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package nonfiniteprocessor
+
+import (
+	"context"
+	"math"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+)
+
+// result is what was done with the values of a datapoint.
+type result int
+
+const (
+	resultFinite result = iota
+	resultReplaced
+	resultDropped
+)
+
+type nonFiniteProcessor struct {
+	action      string
+	replacement float64
+	maxValue    float64
+	logger      *zap.Logger
+
+	// dropped and replaced count the datapoints that were dropped or that had values replaced.
+	dropped  atomic.Uint64
+	replaced atomic.Uint64
+
+	mu sync.Mutex
+	// logged are the names of the metrics that a non-finite value was logged for.
+	logged map[string]struct{}
+}
+
+func newProcessor(cfg *Config, logger *zap.Logger) *nonFiniteProcessor {
+	return &nonFiniteProcessor{
+		action:      cfg.Action,
+		replacement: cfg.Replacement,
+		maxValue:    cfg.MaxValue,
+		logger:      logger,
+		logged:      map[string]struct{}{},
+	}
+}
+
+func (p *nonFiniteProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				return !p.processMetric(m)
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	if md.ResourceMetrics().Len() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
+
+// processMetric handles the non-finite values of the datapoints of the metric. Returns false if the metric should be
+// dropped because all of its datapoints were.
+func (p *nonFiniteProcessor) processMetric(m pmetric.Metric) bool {
+	var dropped, replaced int
+	count := func(r result) bool {
+		switch r {
+		case resultDropped:
+			dropped++
+			return true
+		case resultReplaced:
+			replaced++
+		}
+		return false
+	}
+	var remaining int
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool { return count(p.processNumber(dp)) })
+		remaining = dps.Len()
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		dps.RemoveIf(func(dp pmetric.NumberDataPoint) bool { return count(p.processNumber(dp)) })
+		remaining = dps.Len()
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		dps.RemoveIf(func(dp pmetric.HistogramDataPoint) bool {
+			return count(p.processValues(
+				optionalValue{dp.HasSum(), dp.Sum, dp.SetSum},
+				optionalValue{dp.HasMin(), dp.Min, dp.SetMin},
+				optionalValue{dp.HasMax(), dp.Max, dp.SetMax},
+			))
+		})
+		remaining = dps.Len()
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+			return count(p.processValues(
+				optionalValue{dp.HasSum(), dp.Sum, dp.SetSum},
+				optionalValue{dp.HasMin(), dp.Min, dp.SetMin},
+				optionalValue{dp.HasMax(), dp.Max, dp.SetMax},
+			))
+		})
+		remaining = dps.Len()
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		dps.RemoveIf(func(dp pmetric.SummaryDataPoint) bool {
+			values := []optionalValue{{true, dp.Sum, dp.SetSum}}
+			qs := dp.QuantileValues()
+			for i := 0; i < qs.Len(); i++ {
+				values = append(values, optionalValue{true, qs.At(i).Value, qs.At(i).SetValue})
+			}
+			return count(p.processValues(values...))
+		})
+		remaining = dps.Len()
+	}
+	if dropped > 0 || replaced > 0 {
+		p.dropped.Add(uint64(dropped))
+		p.replaced.Add(uint64(replaced))
+		p.logOnce(m.Name())
+	}
+	return dropped == 0 || remaining > 0
+}
+
+func (p *nonFiniteProcessor) processNumber(dp pmetric.NumberDataPoint) result {
+	if dp.ValueType() != pmetric.NumberDataPointValueTypeDouble {
+		return resultFinite
+	}
+	return p.processValues(optionalValue{true, dp.DoubleValue, dp.SetDoubleValue})
+}
+
+// optionalValue is a value of a datapoint that is only checked when it is set.
+type optionalValue struct {
+	set   bool
+	value func() float64
+	store func(float64)
+}
+
+// processValues replaces the non-finite values, unless the datapoint is dropped because one of them cannot be
+// replaced.
+func (p *nonFiniteProcessor) processValues(values ...optionalValue) result {
+	r := resultFinite
+	for _, v := range values {
+		if v.set && !isFinite(v.value()) {
+			if _, ok := p.replace(v.value()); !ok {
+				return resultDropped
+			}
+			r = resultReplaced
+		}
+	}
+	if r == resultReplaced {
+		for _, v := range values {
+			if v.set && !isFinite(v.value()) {
+				replacement, _ := p.replace(v.value())
+				v.store(replacement)
+			}
+		}
+	}
+	return r
+}
+
+// replace returns the value that replaces the non-finite value, or false if the datapoint is dropped instead.
+func (p *nonFiniteProcessor) replace(value float64) (float64, bool) {
+	switch p.action {
+	case actionReplace:
+		return p.replacement, true
+	case actionClamp:
+		if math.IsInf(value, 1) {
+			return p.maxValue, true
+		}
+		if math.IsInf(value, -1) {
+			return -p.maxValue, true
+		}
+	}
+	return 0, false
+}
+
+// logOnce logs the first non-finite value of each metric.
+func (p *nonFiniteProcessor) logOnce(name string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.logged[name]; ok {
+		return
+	}
+	p.logged[name] = struct{}{}
+	p.logger.Warn("Handled a metric with a NaN or infinite value that CloudWatch does not accept",
+		zap.String("metric", name),
+		zap.String("action", p.action),
+		zap.Uint64("dropped", p.dropped.Load()),
+		zap.Uint64("replaced", p.replaced.Load()))
+}
+
+func isFinite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
@@ -0,0 +1,138 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package nonfiniteprocessor
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+)
+
+var inputValues = []float64{1.5, math.NaN(), math.Inf(1), math.Inf(-1), -2}
+
+func newGaugeMetrics(values ...float64) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("latency")
+	dps := m.SetEmptyGauge().DataPoints()
+	for _, v := range values {
+		dps.AppendEmpty().SetDoubleValue(v)
+	}
+	// integer values are always finite
+	dps.AppendEmpty().SetIntValue(7)
+	return md
+}
+
+func gaugeValues(md pmetric.Metrics) []float64 {
+	var result []float64
+	dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Gauge().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		if dps.At(i).ValueType() == pmetric.NumberDataPointValueTypeInt {
+			result = append(result, float64(dps.At(i).IntValue()))
+		} else {
+			result = append(result, dps.At(i).DoubleValue())
+		}
+	}
+	return result
+}
+
+func TestProcessMetrics(t *testing.T) {
+	testCases := map[string]struct {
+		cfg          Config
+		want         []float64
+		wantDropped  uint64
+		wantReplaced uint64
+	}{
+		"WithDrop": {
+			cfg:         Config{Action: actionDrop},
+			want:        []float64{1.5, -2, 7},
+			wantDropped: 3,
+		},
+		"WithReplace": {
+			cfg:          Config{Action: actionReplace, Replacement: -1},
+			want:         []float64{1.5, -1, -1, -1, -2, 7},
+			wantReplaced: 3,
+		},
+		"WithClamp": {
+			cfg:          Config{Action: actionClamp, MaxValue: defaultMaxValue},
+			want:         []float64{1.5, defaultMaxValue, -defaultMaxValue, -2, 7},
+			wantDropped:  1,
+			wantReplaced: 2,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p := newProcessor(&testCase.cfg, zap.NewNop())
+			md, err := p.processMetrics(context.Background(), newGaugeMetrics(inputValues...))
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, gaugeValues(md))
+			assert.Equal(t, testCase.wantDropped, p.dropped.Load())
+			assert.Equal(t, testCase.wantReplaced, p.replaced.Load())
+		})
+	}
+}
+
+func TestProcessMetricsDropsEmptyMetrics(t *testing.T) {
+	p := newProcessor(&Config{Action: actionDrop}, zap.NewNop())
+	md := pmetric.NewMetrics()
+	dps := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptySum().DataPoints()
+	dps.AppendEmpty().SetDoubleValue(math.NaN())
+	dps.AppendEmpty().SetDoubleValue(math.Inf(1))
+	_, err := p.processMetrics(context.Background(), md)
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+
+	// metrics that had no datapoints to begin with are kept
+	md = pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge()
+	md, err = p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, 1, md.MetricCount())
+}
+
+func TestProcessMetricsHistogramsAndSummaries(t *testing.T) {
+	newMetrics := func() pmetric.Metrics {
+		md := pmetric.NewMetrics()
+		ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+		hdps := ms.AppendEmpty().SetEmptyHistogram().DataPoints()
+		dp := hdps.AppendEmpty()
+		dp.SetSum(10)
+		dp.SetMin(1)
+		dp.SetMax(math.Inf(1))
+		hdps.AppendEmpty().SetSum(math.NaN())
+		sdp := ms.AppendEmpty().SetEmptySummary().DataPoints().AppendEmpty()
+		sdp.SetSum(math.Inf(-1))
+		sdp.QuantileValues().AppendEmpty().SetValue(3)
+		sdp.QuantileValues().AppendEmpty().SetValue(math.Inf(1))
+		return md
+	}
+
+	p := newProcessor(&Config{Action: actionClamp, MaxValue: 100}, zap.NewNop())
+	md, err := p.processMetrics(context.Background(), newMetrics())
+	require.NoError(t, err)
+	ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	hdps := ms.At(0).Histogram().DataPoints()
+	require.Equal(t, 1, hdps.Len())
+	assert.Equal(t, float64(10), hdps.At(0).Sum())
+	assert.Equal(t, float64(1), hdps.At(0).Min())
+	assert.Equal(t, float64(100), hdps.At(0).Max())
+	sdp := ms.At(1).Summary().DataPoints().At(0)
+	assert.Equal(t, float64(-100), sdp.Sum())
+	assert.Equal(t, float64(3), sdp.QuantileValues().At(0).Value())
+	assert.Equal(t, float64(100), sdp.QuantileValues().At(1).Value())
+	assert.Equal(t, uint64(1), p.dropped.Load())
+	assert.Equal(t, uint64(2), p.replaced.Load())
+
+	p = newProcessor(&Config{Action: actionReplace, Replacement: 0}, zap.NewNop())
+	md, err = p.processMetrics(context.Background(), newMetrics())
+	require.NoError(t, err)
+	ms = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, ms.At(0).Histogram().DataPoints().Len())
+	assert.Equal(t, float64(0), ms.At(0).Histogram().DataPoints().At(1).Sum())
+}
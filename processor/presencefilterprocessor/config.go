@@ -0,0 +1,33 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package presencefilterprocessor
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// defaultPresenceMetric is the metric that the prometheus receiver adds to the resource of each scrape target.
+const defaultPresenceMetric = "up"
+
+var errEmptyPresenceMetric = errors.New("presence_metric must not be empty")
+
+type Config struct {
+	// PresenceMetric is the metric of a resource that indicates whether the resource is present. When its value is 0,
+	// the other metrics of the resource are dropped.
+	PresenceMetric string `mapstructure:"presence_metric"`
+	// DropPresenceMetric drops the presence metric as well when it indicates absence. Otherwise, it is kept so that
+	// the absence can be alarmed on.
+	DropPresenceMetric bool `mapstructure:"drop_presence_metric"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if cfg.PresenceMetric == "" {
+		return errEmptyPresenceMetric
+	}
+	return nil
+}
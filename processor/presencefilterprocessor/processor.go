@@ -0,0 +1,96 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package presencefilterprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+)
+
+type presenceFilterProcessor struct {
+	presenceMetric     string
+	dropPresenceMetric bool
+	logger             *zap.Logger
+}
+
+func newProcessor(cfg *Config, logger *zap.Logger) *presenceFilterProcessor {
+	return &presenceFilterProcessor{
+		presenceMetric:     cfg.PresenceMetric,
+		dropPresenceMetric: cfg.DropPresenceMetric,
+		logger:             logger,
+	}
+}
+
+func (p *presenceFilterProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	dropped := 0
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		if p.isPresent(rm) {
+			return false
+		}
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				if m.Name() == p.presenceMetric && !p.dropPresenceMetric {
+					return false
+				}
+				dropped++
+				return true
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	if dropped > 0 {
+		p.logger.Debug("Dropped the metrics of absent resources", zap.String("presence_metric", p.presenceMetric), zap.Int("count", dropped))
+	}
+	if md.ResourceMetrics().Len() == 0 {
+		return md, processorhelper.ErrSkipProcessingData
+	}
+	return md, nil
+}
+
+// isPresent returns false if the latest data point of the presence metric of the resource is 0. Resources without the
+// presence metric are always present.
+func (p *presenceFilterProcessor) isPresent(rm pmetric.ResourceMetrics) bool {
+	present := true
+	found := false
+	var latest pcommon.Timestamp
+	sms := rm.ScopeMetrics()
+	for i := 0; i < sms.Len(); i++ {
+		ms := sms.At(i).Metrics()
+		for j := 0; j < ms.Len(); j++ {
+			m := ms.At(j)
+			if m.Name() != p.presenceMetric {
+				continue
+			}
+			var dps pmetric.NumberDataPointSlice
+			switch m.Type() {
+			case pmetric.MetricTypeGauge:
+				dps = m.Gauge().DataPoints()
+			case pmetric.MetricTypeSum:
+				dps = m.Sum().DataPoints()
+			default:
+				continue
+			}
+			for k := 0; k < dps.Len(); k++ {
+				dp := dps.At(k)
+				if found && dp.Timestamp() < latest {
+					continue
+				}
+				found = true
+				latest = dp.Timestamp()
+				switch dp.ValueType() {
+				case pmetric.NumberDataPointValueTypeInt:
+					present = dp.IntValue() != 0
+				case pmetric.NumberDataPointValueTypeDouble:
+					present = dp.DoubleValue() != 0
+				}
+			}
+		}
+	}
+	return present
+}
@@ -0,0 +1,114 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package presencefilterprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.uber.org/zap"
+)
+
+// addTarget adds the metrics of a scrape target with the up metric set to the values, in timestamp order.
+func addTarget(md pmetric.Metrics, instance string, up ...float64) {
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.instance.id", instance)
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	m := ms.AppendEmpty()
+	m.SetName("http_requests_total")
+	m.SetEmptySum().DataPoints().AppendEmpty().SetIntValue(10)
+	if len(up) > 0 {
+		m = ms.AppendEmpty()
+		m.SetName("up")
+		dps := m.SetEmptyGauge().DataPoints()
+		for i, v := range up {
+			dp := dps.AppendEmpty()
+			dp.SetTimestamp(pcommon.Timestamp(i + 1))
+			dp.SetDoubleValue(v)
+		}
+		// the data points are not in timestamp order
+		dps.Sort(func(a, b pmetric.NumberDataPoint) bool { return a.Timestamp() > b.Timestamp() })
+	}
+	m = rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("process_cpu_seconds_total")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(1.5)
+}
+
+// metricNames returns the names of the metrics of each resource by instance.
+func metricNames(md pmetric.Metrics) map[string][]string {
+	result := map[string][]string{}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		instance, _ := rms.At(i).Resource().Attributes().Get("service.instance.id")
+		names := []string{}
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				names = append(names, ms.At(k).Name())
+			}
+		}
+		result[instance.Str()] = names
+	}
+	return result
+}
+
+func TestProcessMetrics(t *testing.T) {
+	p := newProcessor(&Config{PresenceMetric: defaultPresenceMetric}, zap.NewNop())
+	md := pmetric.NewMetrics()
+	addTarget(md, "up", 1)
+	addTarget(md, "down", 0)
+	// the latest data point of the presence metric is used
+	addTarget(md, "recovered", 0, 1)
+	addTarget(md, "lost", 1, 0)
+	addTarget(md, "unknown")
+
+	got, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"up":        {"http_requests_total", "up", "process_cpu_seconds_total"},
+		"down":      {"up"},
+		"recovered": {"http_requests_total", "up", "process_cpu_seconds_total"},
+		"lost":      {"up"},
+		"unknown":   {"http_requests_total", "process_cpu_seconds_total"},
+	}, metricNames(got))
+}
+
+func TestProcessMetricsDropPresenceMetric(t *testing.T) {
+	p := newProcessor(&Config{PresenceMetric: defaultPresenceMetric, DropPresenceMetric: true}, zap.NewNop())
+	md := pmetric.NewMetrics()
+	addTarget(md, "up", 1)
+	addTarget(md, "down", 0)
+
+	got, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"up": {"http_requests_total", "up", "process_cpu_seconds_total"},
+	}, metricNames(got))
+
+	md = pmetric.NewMetrics()
+	addTarget(md, "down", 0)
+	_, err = p.processMetrics(context.Background(), md)
+	assert.ErrorIs(t, err, processorhelper.ErrSkipProcessingData)
+}
+
+func TestProcessMetricsIntPresenceMetric(t *testing.T) {
+	p := newProcessor(&Config{PresenceMetric: "http_requests_total"}, zap.NewNop())
+	md := pmetric.NewMetrics()
+	addTarget(md, "present")
+	addTarget(md, "absent")
+	md.ResourceMetrics().At(1).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(0).SetIntValue(0)
+
+	got, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"present": {"http_requests_total", "process_cpu_seconds_total"},
+		"absent":  {"http_requests_total"},
+	}, metricNames(got))
+}
@@ -0,0 +1,44 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package resourcemappingprocessor
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	defaultKeyAttribute = "host.id"
+)
+
+var (
+	errMissingFile         = errors.New("file must be set")
+	errMissingKeyAttribute = errors.New("key_attribute must be set")
+)
+
+type Config struct {
+	// File is the path of the YAML or JSON file that maps the values of the key attribute to the attributes added
+	// to the resource. The file is watched and the mapping is reloaded when it changes.
+	File string `mapstructure:"file"`
+	// KeyAttribute is the resource attribute whose value is looked up in the mapping, such as host.id or host.name.
+	KeyAttribute string `mapstructure:"key_attribute"`
+	// Default is the attributes added to the resources without the key attribute or whose value is not in the
+	// mapping. The resources are left as-is when it is not set.
+	Default map[string]string `mapstructure:"default,omitempty"`
+	// Override replaces the attributes that the resources already have.
+	Override bool `mapstructure:"override"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if cfg.File == "" {
+		return errMissingFile
+	}
+	if cfg.KeyAttribute == "" {
+		return errMissingKeyAttribute
+	}
+	return nil
+}
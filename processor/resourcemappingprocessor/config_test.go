@@ -0,0 +1,61 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package resourcemappingprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	testCases := []struct {
+		id      component.ID
+		want    component.Config
+		wantErr string
+	}{
+		{
+			id:   component.NewID(component.MustNewType(typeStr)),
+			want: &Config{File: "/etc/cwagent/mapping.yaml", KeyAttribute: defaultKeyAttribute},
+		},
+		{
+			id: component.NewIDWithName(component.MustNewType(typeStr), "default"),
+			want: &Config{
+				File:         "/etc/cwagent/mapping.json",
+				KeyAttribute: "host.name",
+				Default:      map[string]string{"team": "unknown"},
+				Override:     true,
+			},
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "missing_file"),
+			wantErr: errMissingFile.Error(),
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "missing_key_attribute"),
+			wantErr: errMissingKeyAttribute.Error(),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.id.String(), func(t *testing.T) {
+			conf, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := conf.Sub(testCase.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, component.ValidateConfig(cfg), testCase.wantErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, testCase.want, cfg)
+		})
+	}
+}
@@ -0,0 +1,173 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package resourcemappingprocessor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+)
+
+// mapping is the content of the mapping file, the attributes to add keyed by the value of the key attribute.
+type mapping map[string]map[string]string
+
+type resourceMappingProcessor struct {
+	file         string
+	keyAttribute string
+	defaults     map[string]string
+	override     bool
+	logger       *zap.Logger
+
+	mu      sync.RWMutex
+	mapping mapping
+
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+func newProcessor(cfg *Config, logger *zap.Logger) *resourceMappingProcessor {
+	return &resourceMappingProcessor{
+		file:         cfg.File,
+		keyAttribute: cfg.KeyAttribute,
+		defaults:     cfg.Default,
+		override:     cfg.Override,
+		logger:       logger,
+		done:         make(chan struct{}),
+	}
+}
+
+// start loads the mapping and watches the directory of the file, so the mapping is reloaded when the file is
+// written, replaced or created after the agent started. A directory that cannot be watched is logged and the
+// mapping loaded on start is kept.
+func (p *resourceMappingProcessor) start(_ context.Context, _ component.Host) error {
+	p.load()
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		p.logger.Warn("Unable to watch mapping file", zap.String("file", p.file), zap.Error(err))
+		return nil
+	}
+	if err = watcher.Add(filepath.Dir(p.file)); err != nil {
+		p.logger.Warn("Unable to watch mapping file", zap.String("file", p.file), zap.Error(err))
+		_ = watcher.Close()
+		return nil
+	}
+	p.watcher = watcher
+	p.wg.Add(1)
+	go p.watch()
+	return nil
+}
+
+func (p *resourceMappingProcessor) shutdown(context.Context) error {
+	if p.watcher == nil {
+		return nil
+	}
+	close(p.done)
+	err := p.watcher.Close()
+	p.wg.Wait()
+	return err
+}
+
+// watch reloads the mapping on every event in the directory of the file. Events for other names are not ignored
+// since files mounted from a Kubernetes ConfigMap are replaced by swapping a symlink in the directory.
+func (p *resourceMappingProcessor) watch() {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.done:
+			return
+		case _, ok := <-p.watcher.Events:
+			if !ok {
+				return
+			}
+			p.load()
+		case err, ok := <-p.watcher.Errors:
+			if !ok {
+				return
+			}
+			p.logger.Debug("Error watching mapping file", zap.String("file", p.file), zap.Error(err))
+		}
+	}
+}
+
+// load reads the mapping from the file. A missing file clears the mapping, while a file that cannot be read or
+// parsed, such as one that is only partially written, is logged and the previous mapping is kept. An empty file is
+// kept as well, since a file that is written in place is truncated before the new content is written.
+func (p *resourceMappingProcessor) load() {
+	var m mapping
+	content, err := os.ReadFile(p.file)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		p.logger.Warn("Unable to read mapping file", zap.String("file", p.file), zap.Error(err))
+		return
+	}
+	if err == nil && len(bytes.TrimSpace(content)) == 0 {
+		return
+	}
+	if err = yaml.Unmarshal(content, &m); err != nil {
+		p.logger.Warn("Unable to parse mapping file", zap.String("file", p.file), zap.Error(err))
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.logger.Debug("Loaded mapping file", zap.String("file", p.file), zap.Int("keys", len(m)))
+	p.mapping = m
+}
+
+// attributesFor returns the attributes to add to a resource with the key attribute value.
+func (p *resourceMappingProcessor) attributesFor(attrs pcommon.Map) map[string]string {
+	if value, ok := attrs.Get(p.keyAttribute); ok {
+		p.mu.RLock()
+		mapped, found := p.mapping[value.AsString()]
+		p.mu.RUnlock()
+		if found {
+			return mapped
+		}
+	}
+	return p.defaults
+}
+
+func (p *resourceMappingProcessor) enrich(attrs pcommon.Map) {
+	for k, v := range p.attributesFor(attrs) {
+		if _, ok := attrs.Get(k); ok && !p.override {
+			continue
+		}
+		attrs.PutStr(k, v)
+	}
+}
+
+func (p *resourceMappingProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		p.enrich(rms.At(i).Resource().Attributes())
+	}
+	return md, nil
+}
+
+func (p *resourceMappingProcessor) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		p.enrich(rls.At(i).Resource().Attributes())
+	}
+	return ld, nil
+}
+
+func (p *resourceMappingProcessor) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		p.enrich(rss.At(i).Resource().Attributes())
+	}
+	return td, nil
+}
@@ -0,0 +1,154 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package resourcemappingprocessor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func newMetrics(attrs map[string]string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	for k, v := range attrs {
+		rm.Resource().Attributes().PutStr(k, v)
+	}
+	rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("test")
+	return md
+}
+
+// attributesOf returns the resource attributes of a metric after it is processed. It is called from
+// assert.Eventually, so it does not use require.
+func attributesOf(t *testing.T, p *resourceMappingProcessor, attrs map[string]string) map[string]any {
+	md, err := p.processMetrics(context.Background(), newMetrics(attrs))
+	assert.NoError(t, err)
+	return md.ResourceMetrics().At(0).Resource().Attributes().AsRaw()
+}
+
+func startProcessor(t *testing.T, cfg *Config) *resourceMappingProcessor {
+	p := newProcessor(cfg, zap.NewNop())
+	require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, p.shutdown(context.Background()))
+	})
+	return p
+}
+
+func TestProcessWithFixture(t *testing.T) {
+	p := startProcessor(t, &Config{File: filepath.Join("testdata", "mapping.yaml"), KeyAttribute: "host.id"})
+
+	assert.Equal(t, map[string]any{
+		"host.id":     "i-0123456789abcdef0",
+		"team":        "payments",
+		"cost_center": "1234",
+	}, attributesOf(t, p, map[string]string{"host.id": "i-0123456789abcdef0"}))
+
+	// existing attributes are kept without override
+	assert.Equal(t, map[string]any{
+		"host.id":     "i-0fedcba9876543210",
+		"team":        "platform",
+		"cost_center": "5678",
+	}, attributesOf(t, p, map[string]string{"host.id": "i-0fedcba9876543210", "team": "platform"}))
+
+	// unmatched and missing keys are left as-is without a default
+	assert.Equal(t, map[string]any{"host.id": "i-unknown"}, attributesOf(t, p, map[string]string{"host.id": "i-unknown"}))
+	assert.Equal(t, map[string]any{}, attributesOf(t, p, nil))
+}
+
+func TestProcessDefaultAndOverride(t *testing.T) {
+	p := startProcessor(t, &Config{
+		File:         filepath.Join("testdata", "mapping.yaml"),
+		KeyAttribute: "host.id",
+		Default:      map[string]string{"team": "unassigned"},
+		Override:     true,
+	})
+
+	assert.Equal(t, map[string]any{
+		"host.id":     "i-0fedcba9876543210",
+		"team":        "search",
+		"cost_center": "5678",
+	}, attributesOf(t, p, map[string]string{"host.id": "i-0fedcba9876543210", "team": "platform"}))
+	assert.Equal(t, map[string]any{
+		"host.id": "i-unknown",
+		"team":    "unassigned",
+	}, attributesOf(t, p, map[string]string{"host.id": "i-unknown"}))
+	assert.Equal(t, map[string]any{"team": "unassigned"}, attributesOf(t, p, nil))
+}
+
+func TestProcessWithFileReload(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "mapping.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"web-1": {"team": "frontend"}}`), 0600))
+	p := startProcessor(t, &Config{File: file, KeyAttribute: "host.name"})
+	host := map[string]string{"host.name": "web-1"}
+
+	assert.Equal(t, "frontend", attributesOf(t, p, host)["team"])
+
+	// written in place
+	require.NoError(t, os.WriteFile(file, []byte(`{"web-1": {"team": "storefront"}}`), 0600))
+	assert.Eventually(t, func() bool {
+		return attributesOf(t, p, host)["team"] == "storefront"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// replaced by a rename
+	tmp := filepath.Join(filepath.Dir(file), "mapping.json.tmp")
+	require.NoError(t, os.WriteFile(tmp, []byte(`{"web-1": {"team": "checkout"}}`), 0600))
+	require.NoError(t, os.Rename(tmp, file))
+	assert.Eventually(t, func() bool {
+		return attributesOf(t, p, host)["team"] == "checkout"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// an invalid file keeps the previous mapping
+	require.NoError(t, os.WriteFile(file, []byte(`{"web-1": {"team": `), 0600))
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, "checkout", attributesOf(t, p, host)["team"])
+
+	// the mapping is cleared once the file is removed
+	require.NoError(t, os.Remove(file))
+	assert.Eventually(t, func() bool {
+		_, ok := attributesOf(t, p, host)["team"]
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestProcessWithMissingFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "mapping.yaml")
+	p := startProcessor(t, &Config{File: file, KeyAttribute: "host.id"})
+	host := map[string]string{"host.id": "i-0123456789abcdef0"}
+	assert.Equal(t, map[string]any{"host.id": "i-0123456789abcdef0"}, attributesOf(t, p, host))
+
+	// the mapping is used once the file is created
+	require.NoError(t, os.WriteFile(file, []byte("i-0123456789abcdef0:\n  team: payments\n"), 0600))
+	assert.Eventually(t, func() bool {
+		return attributesOf(t, p, host)["team"] == "payments"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestProcessLogsAndTraces(t *testing.T) {
+	p := startProcessor(t, &Config{File: filepath.Join("testdata", "mapping.yaml"), KeyAttribute: "host.id"})
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().Resource().Attributes().PutStr("host.id", "i-0123456789abcdef0")
+	ld, err := p.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	got, _ := ld.ResourceLogs().At(0).Resource().Attributes().Get("team")
+	assert.Equal(t, "payments", got.Str())
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().Resource().Attributes().PutStr("host.id", "i-0fedcba9876543210")
+	td, err = p.processTraces(context.Background(), td)
+	require.NoError(t, err)
+	got, _ = td.ResourceSpans().At(0).Resource().Attributes().Get("team")
+	assert.Equal(t, "search", got.Str())
+}
@@ -0,0 +1,52 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package roundingprocessor
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	errNoRules          = errors.New("at least one rule must be specified")
+	errEmptyPattern     = errors.New("rule pattern must not be empty")
+	errInvalidPrecision = errors.New("rule precision must be greater than 0")
+)
+
+// Rule rounds the values of the metrics whose names match the pattern.
+type Rule struct {
+	// Pattern is the regular expression of the names of the metrics that the rule applies to.
+	Pattern string `mapstructure:"pattern"`
+	// Precision is the step that the values are rounded to the nearest multiple of, e.g. 0.01 keeps two decimal
+	// places and 5 rounds to the nearest multiple of 5.
+	Precision float64 `mapstructure:"precision"`
+}
+
+type Config struct {
+	// Rules are checked in order and the first rule that matches the name of a metric is used.
+	Rules []Rule `mapstructure:"rules"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if len(cfg.Rules) == 0 {
+		return errNoRules
+	}
+	for _, rule := range cfg.Rules {
+		if rule.Pattern == "" {
+			return errEmptyPattern
+		}
+		if _, err := regexp.Compile(rule.Pattern); err != nil {
+			return fmt.Errorf("invalid rule pattern %q: %w", rule.Pattern, err)
+		}
+		if !(rule.Precision > 0) {
+			return errInvalidPrecision
+		}
+	}
+	return nil
+}
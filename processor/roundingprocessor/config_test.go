@@ -0,0 +1,67 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package roundingprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	testCases := []struct {
+		id         component.ID
+		want       component.Config
+		wantErr    error
+		wantErrMsg string
+	}{
+		{
+			id: component.NewID(component.MustNewType(typeStr)),
+			want: &Config{Rules: []Rule{
+				{Pattern: "^cpu_", Precision: 0.01},
+				{Pattern: "_bytes$", Precision: 1024},
+			}},
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "no_rules"),
+			wantErr: errNoRules,
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "empty_pattern"),
+			wantErr: errEmptyPattern,
+		},
+		{
+			id:         component.NewIDWithName(component.MustNewType(typeStr), "invalid_pattern"),
+			wantErrMsg: `invalid rule pattern "("`,
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "invalid_precision"),
+			wantErr: errInvalidPrecision,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.id.String(), func(t *testing.T) {
+			conf, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := conf.Sub(testCase.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			switch {
+			case testCase.wantErr != nil:
+				assert.ErrorIs(t, component.ValidateConfig(cfg), testCase.wantErr)
+			case testCase.wantErrMsg != "":
+				assert.ErrorContains(t, component.ValidateConfig(cfg), testCase.wantErrMsg)
+			default:
+				assert.NoError(t, component.ValidateConfig(cfg))
+				assert.Equal(t, testCase.want, cfg)
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package roundingprocessor
+
+import (
+	"context"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+type rule struct {
+	pattern   *regexp.Regexp
+	precision float64
+	// decimals is the number of decimal places of the precision, which the rounded values are cleaned up to so that
+	// they do not carry floating point errors such as 0.30000000000000004.
+	decimals int
+}
+
+type roundingProcessor struct {
+	rules []rule
+}
+
+func newProcessor(cfg *Config) *roundingProcessor {
+	p := &roundingProcessor{}
+	for _, r := range cfg.Rules {
+		// validated with the config
+		if pattern, err := regexp.Compile(r.Pattern); err == nil {
+			p.rules = append(p.rules, rule{
+				pattern:   pattern,
+				precision: r.Precision,
+				decimals:  decimalPlaces(r.Precision),
+			})
+		}
+	}
+	return p
+}
+
+func (p *roundingProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				if r, ok := p.match(m.Name()); ok {
+					r.roundMetric(m)
+				}
+			}
+		}
+	}
+	return md, nil
+}
+
+// match returns the first rule that matches the metric name.
+func (p *roundingProcessor) match(name string) (rule, bool) {
+	for _, r := range p.rules {
+		if r.pattern.MatchString(name) {
+			return r, true
+		}
+	}
+	return rule{}, false
+}
+
+func (r rule) roundMetric(m pmetric.Metric) {
+	var dps pmetric.NumberDataPointSlice
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps = m.Gauge().DataPoints()
+	case pmetric.MetricTypeSum:
+		dps = m.Sum().DataPoints()
+	default:
+		return
+	}
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		if dp.ValueType() == pmetric.NumberDataPointValueTypeDouble {
+			dp.SetDoubleValue(r.round(dp.DoubleValue()))
+		}
+	}
+}
+
+// round rounds the value to the nearest multiple of the precision.
+func (r rule) round(value float64) float64 {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return value
+	}
+	rounded := math.Round(value/r.precision) * r.precision
+	if r.decimals > 0 {
+		if cleaned, err := strconv.ParseFloat(strconv.FormatFloat(rounded, 'f', r.decimals, 64), 64); err == nil {
+			return cleaned
+		}
+	}
+	return rounded
+}
+
+// decimalPlaces returns the number of digits after the decimal point of the shortest representation of the value.
+func decimalPlaces(value float64) int {
+	s := strconv.FormatFloat(value, 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		return len(s) - i - 1
+	}
+	return 0
+}
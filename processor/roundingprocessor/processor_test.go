@@ -0,0 +1,95 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package roundingprocessor
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestProcessMetrics(t *testing.T) {
+	p := newProcessor(&Config{Rules: []Rule{
+		{Pattern: "^cpu_", Precision: 0.01},
+		{Pattern: "^cpu_usage_idle$", Precision: 10},
+		{Pattern: "_bytes$", Precision: 1024},
+		{Pattern: "^latency$", Precision: 0.1},
+	}})
+
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	addGauge := func(name string, values ...float64) {
+		m := ms.AppendEmpty()
+		m.SetName(name)
+		dps := m.SetEmptyGauge().DataPoints()
+		for _, v := range values {
+			dps.AppendEmpty().SetDoubleValue(v)
+		}
+	}
+	addGauge("cpu_usage_user", 12.3456789, -0.004999)
+	// the first matching rule is used
+	addGauge("cpu_usage_idle", 87.654321)
+	addGauge("latency", 0.25, 0.29999999, math.NaN(), math.Inf(1))
+	addGauge("memory_used_percent", 42.123456)
+	sum := ms.AppendEmpty()
+	sum.SetName("mem_used_bytes")
+	sum.SetEmptySum().DataPoints().AppendEmpty().SetDoubleValue(4000)
+	// int values are already exact
+	sum.Sum().DataPoints().AppendEmpty().SetIntValue(4000)
+
+	md, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+
+	values := func(i int) []float64 {
+		var result []float64
+		m := ms.At(i)
+		var dps pmetric.NumberDataPointSlice
+		if m.Type() == pmetric.MetricTypeSum {
+			dps = m.Sum().DataPoints()
+		} else {
+			dps = m.Gauge().DataPoints()
+		}
+		for j := 0; j < dps.Len(); j++ {
+			if dps.At(j).ValueType() == pmetric.NumberDataPointValueTypeInt {
+				result = append(result, float64(dps.At(j).IntValue()))
+			} else {
+				result = append(result, dps.At(j).DoubleValue())
+			}
+		}
+		return result
+	}
+	assert.Equal(t, []float64{12.35, -0}, values(0))
+	assert.Equal(t, []float64{87.65}, values(1))
+	latency := values(2)
+	assert.Equal(t, []float64{0.3, 0.3}, latency[:2])
+	assert.True(t, math.IsNaN(latency[2]))
+	assert.True(t, math.IsInf(latency[3], 1))
+	// unmatched metrics are left intact
+	assert.Equal(t, []float64{42.123456}, values(3))
+	assert.Equal(t, []float64{4096, 4000}, values(4))
+}
+
+func TestRound(t *testing.T) {
+	testCases := []struct {
+		precision float64
+		value     float64
+		want      float64
+	}{
+		{precision: 0.01, value: 1.005, want: 1},
+		{precision: 0.01, value: 1.016, want: 1.02},
+		{precision: 0.1, value: 0.1 + 0.2, want: 0.3},
+		{precision: 0.5, value: 2.74, want: 2.5},
+		{precision: 0.5, value: 2.75, want: 3},
+		{precision: 5, value: 12.5, want: 15},
+		{precision: 1, value: -2.5, want: -3},
+	}
+	for _, testCase := range testCases {
+		r := rule{precision: testCase.precision, decimals: decimalPlaces(testCase.precision)}
+		assert.Equal(t, testCase.want, r.round(testCase.value), "round(%v) with precision %v", testCase.value, testCase.precision)
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package semconvprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var errEmptyRename = errors.New("renames must not contain an empty attribute")
+
+type Config struct {
+	// Renames maps the attribute keys of other semantic convention versions to the canonical keys. They take
+	// precedence over the default renames.
+	Renames map[string]string `mapstructure:"renames,omitempty"`
+	// IncludeDefaultRenames adds the renames of the common attributes changed by the semantic conventions.
+	IncludeDefaultRenames bool `mapstructure:"include_default_renames"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	renames := cfg.renames()
+	for from, to := range renames {
+		if from == "" || to == "" {
+			return errEmptyRename
+		}
+		// the attributes are renamed once, so the canonical key cannot be renamed again
+		if next, ok := renames[to]; ok {
+			return fmt.Errorf("rename of %q to %q is chained with the rename of %q", from, to, next)
+		}
+	}
+	return nil
+}
+
+// renames returns the configured renames merged over the default renames, if included.
+func (cfg *Config) renames() map[string]string {
+	renames := map[string]string{}
+	if cfg.IncludeDefaultRenames {
+		for from, to := range defaultRenames {
+			renames[from] = to
+		}
+	}
+	for from, to := range cfg.Renames {
+		renames[from] = to
+	}
+	return renames
+}
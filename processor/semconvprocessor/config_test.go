@@ -0,0 +1,76 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package semconvprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	testCases := []struct {
+		id      component.ID
+		want    component.Config
+		wantErr string
+	}{
+		{
+			id:   component.NewID(component.MustNewType(typeStr)),
+			want: NewFactory().CreateDefaultConfig(),
+		},
+		{
+			id: component.NewIDWithName(component.MustNewType(typeStr), "1"),
+			want: &Config{
+				Renames: map[string]string{"hostname": "host.name", "k8s.cluster": "k8s.cluster.name"},
+			},
+		},
+		{
+			id: component.NewIDWithName(component.MustNewType(typeStr), "override"),
+			want: &Config{
+				Renames:               map[string]string{"net.peer.name": "client.address"},
+				IncludeDefaultRenames: true,
+			},
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "empty_rename"),
+			wantErr: errEmptyRename.Error(),
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "chained_rename"),
+			wantErr: `rename of "host.hostname" to "host.name" is chained with the rename of "hostname"`,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.id.String(), func(t *testing.T) {
+			conf, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := conf.Sub(testCase.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, component.ValidateConfig(cfg), testCase.wantErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, testCase.want, cfg)
+		})
+	}
+}
+
+func TestRenames(t *testing.T) {
+	cfg := &Config{Renames: map[string]string{"net.peer.name": "client.address", "hostname": "host.name"}}
+	assert.Equal(t, map[string]string{"net.peer.name": "client.address", "hostname": "host.name"}, cfg.renames())
+
+	cfg.IncludeDefaultRenames = true
+	renames := cfg.renames()
+	assert.Len(t, renames, len(defaultRenames)+1)
+	assert.Equal(t, "client.address", renames["net.peer.name"])
+	assert.Equal(t, "host.name", renames["host.hostname"])
+}
@@ -0,0 +1,96 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package semconvprocessor
+
+import (
+	"context"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+type rename struct {
+	from string
+	to   string
+}
+
+type semconvProcessor struct {
+	// renames are sorted by the key they rename from, so the same value is kept when several keys have the same
+	// canonical key.
+	renames []rename
+}
+
+func newProcessor(cfg *Config) *semconvProcessor {
+	configured := cfg.renames()
+	renames := make([]rename, 0, len(configured))
+	for from, to := range configured {
+		renames = append(renames, rename{from: from, to: to})
+	}
+	sort.Slice(renames, func(i, j int) bool {
+		return renames[i].from < renames[j].from
+	})
+	return &semconvProcessor{renames: renames}
+}
+
+func (p *semconvProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		p.rename(rm.Resource().Attributes())
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				p.processMetric(ms.At(k))
+			}
+		}
+	}
+	return md, nil
+}
+
+func (p *semconvProcessor) processMetric(m pmetric.Metric) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.rename(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.rename(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.rename(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.rename(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.rename(dps.At(i).Attributes())
+		}
+	}
+}
+
+// rename replaces the keys of the attributes with their canonical keys. If the attributes already have the canonical
+// key, its value is kept and the other key is removed so the attributes have a single dimension.
+func (p *semconvProcessor) rename(attrs pcommon.Map) {
+	for _, r := range p.renames {
+		value, ok := attrs.Get(r.from)
+		if !ok {
+			continue
+		}
+		if _, exists := attrs.Get(r.to); !exists {
+			value.CopyTo(attrs.PutEmpty(r.to))
+		}
+		attrs.Remove(r.from)
+	}
+}
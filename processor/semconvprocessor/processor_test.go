@@ -0,0 +1,81 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package semconvprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func TestProcessMetrics(t *testing.T) {
+	testCases := map[string]struct {
+		cfg               *Config
+		resourceAttrs     map[string]any
+		attrs             map[string]any
+		wantResourceAttrs map[string]any
+		wantAttrs         map[string]any
+	}{
+		"WithSemconv1.7": {
+			cfg:               createDefaultConfig().(*Config),
+			resourceAttrs:     map[string]any{"host.hostname": "ip-10-0-0-1", "deployment.environment": "prod"},
+			attrs:             map[string]any{"http.method": "GET", "http.status_code": int64(200), "net.host.name": "example.com"},
+			wantResourceAttrs: map[string]any{"host.name": "ip-10-0-0-1", "deployment.environment.name": "prod"},
+			wantAttrs:         map[string]any{"http.request.method": "GET", "http.response.status_code": int64(200), "server.address": "example.com"},
+		},
+		"WithSemconv1.20": {
+			cfg:       createDefaultConfig().(*Config),
+			attrs:     map[string]any{"net.sock.peer.addr": "10.0.0.2", "net.sock.peer.port": int64(443), "net.protocol.name": "http"},
+			wantAttrs: map[string]any{"network.peer.address": "10.0.0.2", "network.peer.port": int64(443), "network.protocol.name": "http"},
+		},
+		"WithCanonicalKeys": {
+			cfg:               createDefaultConfig().(*Config),
+			resourceAttrs:     map[string]any{"host.name": "ip-10-0-0-1", "service.name": "checkout"},
+			attrs:             map[string]any{"http.request.method": "GET"},
+			wantResourceAttrs: map[string]any{"host.name": "ip-10-0-0-1", "service.name": "checkout"},
+			wantAttrs:         map[string]any{"http.request.method": "GET"},
+		},
+		"WithBothKeys": {
+			cfg: createDefaultConfig().(*Config),
+			// the canonical key is kept, and net.host.name is sorted before net.peer.name
+			resourceAttrs:     map[string]any{"host.name": "canonical", "host.hostname": "legacy"},
+			attrs:             map[string]any{"net.peer.name": "peer", "net.host.name": "host"},
+			wantResourceAttrs: map[string]any{"host.name": "canonical"},
+			wantAttrs:         map[string]any{"server.address": "host"},
+		},
+		"WithConfiguredRenames": {
+			cfg:               &Config{Renames: map[string]string{"hostname": "host.name"}},
+			resourceAttrs:     map[string]any{"hostname": "ip-10-0-0-1", "host.hostname": "ip-10-0-0-1"},
+			attrs:             map[string]any{"http.method": "GET"},
+			wantResourceAttrs: map[string]any{"host.name": "ip-10-0-0-1", "host.hostname": "ip-10-0-0-1"},
+			wantAttrs:         map[string]any{"http.method": "GET"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			p := newProcessor(testCase.cfg)
+			md := pmetric.NewMetrics()
+			rm := md.ResourceMetrics().AppendEmpty()
+			require.NoError(t, rm.Resource().Attributes().FromRaw(testCase.resourceAttrs))
+			ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+			gauge := ms.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+			require.NoError(t, gauge.Attributes().FromRaw(testCase.attrs))
+			histogram := ms.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty()
+			require.NoError(t, histogram.Attributes().FromRaw(testCase.attrs))
+
+			_, err := p.processMetrics(context.Background(), md)
+			require.NoError(t, err)
+			wantResourceAttrs := testCase.wantResourceAttrs
+			if wantResourceAttrs == nil {
+				wantResourceAttrs = map[string]any{}
+			}
+			assert.Equal(t, wantResourceAttrs, rm.Resource().Attributes().AsRaw())
+			assert.Equal(t, testCase.wantAttrs, gauge.Attributes().AsRaw())
+			assert.Equal(t, testCase.wantAttrs, histogram.Attributes().AsRaw())
+		})
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package semconvprocessor
+
+// defaultRenames maps the keys of the attributes that were renamed across the semantic convention versions to the
+// keys of the latest version.
+var defaultRenames = map[string]string{
+	// host
+	"host.hostname": "host.name",
+	// deployment
+	"deployment.environment": "deployment.environment.name",
+	// http
+	"http.method":      "http.request.method",
+	"http.status_code": "http.response.status_code",
+	"http.url":         "url.full",
+	"http.scheme":      "url.scheme",
+	"http.user_agent":  "user_agent.original",
+	"http.client_ip":   "client.address",
+	// network
+	"net.host.name":        "server.address",
+	"net.host.port":        "server.port",
+	"net.peer.name":        "server.address",
+	"net.peer.port":        "server.port",
+	"net.protocol.name":    "network.protocol.name",
+	"net.protocol.version": "network.protocol.version",
+	"net.sock.peer.addr":   "network.peer.address",
+	"net.sock.peer.port":   "network.peer.port",
+	"net.sock.host.addr":   "network.local.address",
+	"net.sock.host.port":   "network.local.port",
+	"net.transport":        "network.transport",
+	// messaging
+	"messaging.destination": "messaging.destination.name",
+	// faas
+	"faas.execution": "faas.invocation_id",
+}
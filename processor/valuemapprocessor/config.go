@@ -0,0 +1,54 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package valuemapprocessor
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	errNoAttributes = errors.New("attributes must be set")
+	errEmptyKey     = errors.New("attributes must not contain an empty key")
+	errDuplicateKey = errors.New("duplicate attribute key")
+	errNoValues     = errors.New("values must be set")
+)
+
+type Config struct {
+	// Attributes are the attributes whose values are mapped.
+	Attributes []Attribute `mapstructure:"attributes,omitempty"`
+}
+
+type Attribute struct {
+	// Key is the attribute key whose values are mapped.
+	Key string `mapstructure:"key"`
+	// Values maps the raw values of the attribute to the values that replace them.
+	Values map[string]string `mapstructure:"values"`
+	// Default replaces the values that are not in Values. Unmapped values are left unchanged without a default.
+	Default *string `mapstructure:"default,omitempty"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if len(cfg.Attributes) == 0 {
+		return errNoAttributes
+	}
+	keys := make(map[string]struct{}, len(cfg.Attributes))
+	for _, a := range cfg.Attributes {
+		if a.Key == "" {
+			return errEmptyKey
+		}
+		if _, ok := keys[a.Key]; ok {
+			return fmt.Errorf("%w %q", errDuplicateKey, a.Key)
+		}
+		keys[a.Key] = struct{}{}
+		if len(a.Values) == 0 {
+			return fmt.Errorf("%w for attribute %q", errNoValues, a.Key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package valuemapprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	other := "Other"
+	testCases := []struct {
+		id      component.ID
+		want    component.Config
+		wantErr error
+	}{
+		{
+			id:      component.NewID(component.MustNewType(typeStr)),
+			wantErr: errNoAttributes,
+		},
+		{
+			id: component.NewIDWithName(component.MustNewType(typeStr), "1"),
+			want: &Config{
+				Attributes: []Attribute{
+					{
+						Key:     "instance_type_code",
+						Values:  map[string]string{"c5": "Compute Optimized", "r5": "Memory Optimized"},
+						Default: &other,
+					},
+					{
+						Key:    "http.status_code",
+						Values: map[string]string{"200": "OK", "404": "Not Found"},
+					},
+				},
+			},
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "empty_key"),
+			wantErr: errEmptyKey,
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "duplicate_key"),
+			wantErr: errDuplicateKey,
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "no_values"),
+			wantErr: errNoValues,
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.id.String(), func(t *testing.T) {
+			conf, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := conf.Sub(testCase.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if testCase.wantErr != nil {
+				assert.ErrorIs(t, component.ValidateConfig(cfg), testCase.wantErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, testCase.want, cfg)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package valuemapprocessor
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// valueTable is the mapping of the values of an attribute.
+type valueTable struct {
+	values       map[string]string
+	defaultValue *string
+}
+
+// lookup returns the value that replaces the raw value, if any.
+func (t valueTable) lookup(value string) (string, bool) {
+	if mapped, ok := t.values[value]; ok {
+		return mapped, true
+	}
+	if t.defaultValue != nil {
+		return *t.defaultValue, true
+	}
+	return "", false
+}
+
+type valueMapProcessor struct {
+	// tables maps the key of each attribute to the mapping of its values.
+	tables map[string]valueTable
+}
+
+func newProcessor(cfg *Config) *valueMapProcessor {
+	p := &valueMapProcessor{tables: make(map[string]valueTable, len(cfg.Attributes))}
+	for _, a := range cfg.Attributes {
+		p.tables[a.Key] = valueTable{values: a.Values, defaultValue: a.Default}
+	}
+	return p
+}
+
+func (p *valueMapProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		p.mapAttributes(rm.Resource().Attributes())
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				p.processMetric(ms.At(k))
+			}
+		}
+	}
+	return md, nil
+}
+
+func (p *valueMapProcessor) processMetric(m pmetric.Metric) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.mapAttributes(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.mapAttributes(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.mapAttributes(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.mapAttributes(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p.mapAttributes(dps.At(i).Attributes())
+		}
+	}
+}
+
+func (p *valueMapProcessor) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		p.mapAttributes(rl.Resource().Attributes())
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				p.mapAttributes(lrs.At(k).Attributes())
+			}
+		}
+	}
+	return ld, nil
+}
+
+// mapAttributes replaces the values of the configured attributes with their mapped values.
+func (p *valueMapProcessor) mapAttributes(attrs pcommon.Map) {
+	for key, table := range p.tables {
+		if v, ok := attrs.Get(key); ok {
+			if mapped, ok := table.lookup(v.AsString()); ok {
+				v.SetStr(mapped)
+			}
+		}
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package valuemapprocessor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+func newTestProcessor() *valueMapProcessor {
+	other := "Other"
+	return newProcessor(&Config{Attributes: []Attribute{
+		{
+			Key:     "instance_type_code",
+			Values:  map[string]string{"c5": "Compute Optimized", "r5": "Memory Optimized"},
+			Default: &other,
+		},
+		{
+			Key:    "http.status_code",
+			Values: map[string]string{"200": "OK", "404": "Not Found"},
+		},
+	}})
+}
+
+func TestProcessMetrics(t *testing.T) {
+	p := newTestProcessor()
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("instance_type_code", "r5")
+	dps := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptySum().DataPoints()
+	inputs := []map[string]any{
+		// known values
+		{"instance_type_code": "c5", "http.status_code": int64(404), "Operation": "GET /orders"},
+		// unmapped values fall back to the default, or are left unchanged without one
+		{"instance_type_code": "m5", "http.status_code": "500"},
+		// unconfigured attributes are left unchanged
+		{"Operation": "GET /orders", "Status": "200"},
+	}
+	for _, input := range inputs {
+		require.NoError(t, dps.AppendEmpty().Attributes().FromRaw(input))
+	}
+
+	md, err := p.processMetrics(context.Background(), md)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"instance_type_code": "Memory Optimized"}, md.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+	dps = md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+	var got []map[string]any
+	for i := 0; i < dps.Len(); i++ {
+		got = append(got, dps.At(i).Attributes().AsRaw())
+	}
+	assert.Equal(t, []map[string]any{
+		{"instance_type_code": "Compute Optimized", "http.status_code": "Not Found", "Operation": "GET /orders"},
+		{"instance_type_code": "Other", "http.status_code": "500"},
+		{"Operation": "GET /orders", "Status": "200"},
+	}, got)
+}
+
+func TestProcessLogs(t *testing.T) {
+	p := newTestProcessor()
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("instance_type_code", "x1")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Body().SetStr("instance_type_code=c5")
+	lr.Attributes().PutStr("http.status_code", "200")
+	lr.Attributes().PutStr("path", "/orders")
+
+	ld, err := p.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"instance_type_code": "Other"}, ld.ResourceLogs().At(0).Resource().Attributes().AsRaw())
+	lr = ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+	assert.Equal(t, map[string]any{"http.status_code": "OK", "path": "/orders"}, lr.Attributes().AsRaw())
+	assert.Equal(t, "instance_type_code=c5", lr.Body().Str())
+}
@@ -0,0 +1,44 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package versionprocessor
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+const (
+	defaultAttribute = "service.version"
+)
+
+var (
+	errMissingAttribute = errors.New("attribute must be set")
+	errMissingSource    = errors.New("file or env must be set")
+)
+
+type Config struct {
+	// Attribute is the resource attribute that is set to the version.
+	Attribute string `mapstructure:"attribute"`
+	// File is the path of the file that holds the version, such as a VERSION file. The file is watched and the
+	// attribute is updated when it changes.
+	File string `mapstructure:"file,omitempty"`
+	// Env is the environment variable that holds the version. It is used when the file is not set or cannot be
+	// read.
+	Env string `mapstructure:"env,omitempty"`
+	// Override replaces the attribute on resources that already have it.
+	Override bool `mapstructure:"override"`
+}
+
+var _ component.Config = (*Config)(nil)
+
+func (cfg *Config) Validate() error {
+	if cfg.Attribute == "" {
+		return errMissingAttribute
+	}
+	if cfg.File == "" && cfg.Env == "" {
+		return errMissingSource
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package versionprocessor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+)
+
+func TestLoadConfig(t *testing.T) {
+	testCases := []struct {
+		id      component.ID
+		want    component.Config
+		wantErr string
+	}{
+		{
+			id:   component.NewID(component.MustNewType(typeStr)),
+			want: &Config{Attribute: defaultAttribute, Env: "APP_VERSION"},
+		},
+		{
+			id: component.NewIDWithName(component.MustNewType(typeStr), "file"),
+			want: &Config{
+				Attribute: "deployment.version",
+				File:      "/opt/app/VERSION",
+				Env:       "APP_VERSION",
+				Override:  true,
+			},
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "missing_source"),
+			wantErr: errMissingSource.Error(),
+		},
+		{
+			id:      component.NewIDWithName(component.MustNewType(typeStr), "missing_attribute"),
+			wantErr: errMissingAttribute.Error(),
+		},
+	}
+	for _, testCase := range testCases {
+		t.Run(testCase.id.String(), func(t *testing.T) {
+			conf, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+			require.NoError(t, err)
+			cfg := NewFactory().CreateDefaultConfig()
+			sub, err := conf.Sub(testCase.id.String())
+			require.NoError(t, err)
+			require.NoError(t, sub.Unmarshal(cfg))
+
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, component.ValidateConfig(cfg), testCase.wantErr)
+				return
+			}
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, testCase.want, cfg)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package versionprocessor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/fsnotify/fsnotify"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+type versionProcessor struct {
+	attribute string
+	file      string
+	env       string
+	override  bool
+	logger    *zap.Logger
+
+	mu      sync.RWMutex
+	version string
+
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+func newProcessor(cfg *Config, logger *zap.Logger) *versionProcessor {
+	return &versionProcessor{
+		attribute: cfg.Attribute,
+		file:      cfg.File,
+		env:       cfg.Env,
+		override:  cfg.Override,
+		logger:    logger,
+		done:      make(chan struct{}),
+	}
+}
+
+// start loads the version and watches the directory of the file, so the version is updated when the file is
+// written, replaced or created after the agent started. A directory that cannot be watched is logged and the
+// version loaded on start is kept.
+func (p *versionProcessor) start(_ context.Context, _ component.Host) error {
+	p.load()
+	if p.file == "" {
+		return nil
+	}
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		p.logger.Warn("Unable to watch version file", zap.String("file", p.file), zap.Error(err))
+		return nil
+	}
+	if err = watcher.Add(filepath.Dir(p.file)); err != nil {
+		p.logger.Warn("Unable to watch version file", zap.String("file", p.file), zap.Error(err))
+		_ = watcher.Close()
+		return nil
+	}
+	p.watcher = watcher
+	p.wg.Add(1)
+	go p.watch()
+	return nil
+}
+
+func (p *versionProcessor) shutdown(context.Context) error {
+	if p.watcher == nil {
+		return nil
+	}
+	close(p.done)
+	err := p.watcher.Close()
+	p.wg.Wait()
+	return err
+}
+
+// watch reloads the version on every event in the directory of the file. Events for other names are not ignored
+// since files mounted from a Kubernetes ConfigMap are replaced by swapping a symlink in the directory.
+func (p *versionProcessor) watch() {
+	defer p.wg.Done()
+	for {
+		select {
+		case <-p.done:
+			return
+		case _, ok := <-p.watcher.Events:
+			if !ok {
+				return
+			}
+			p.load()
+		case err, ok := <-p.watcher.Errors:
+			if !ok {
+				return
+			}
+			p.logger.Debug("Error watching version file", zap.String("file", p.file), zap.Error(err))
+		}
+	}
+}
+
+// load reads the version from the file and falls back on the environment variable. A missing or empty file is not
+// an error, in which case the attribute is left unset if there is no environment variable either.
+func (p *versionProcessor) load() {
+	var version string
+	if p.file != "" {
+		content, err := os.ReadFile(p.file)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			p.logger.Debug("Unable to read version file", zap.String("file", p.file), zap.Error(err))
+		}
+		version = strings.TrimSpace(string(content))
+	}
+	if version == "" && p.env != "" {
+		version = strings.TrimSpace(os.Getenv(p.env))
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if version != p.version {
+		p.logger.Info("Version changed", zap.String("attribute", p.attribute), zap.String("version", version))
+		p.version = version
+	}
+}
+
+func (p *versionProcessor) currentVersion() string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.version
+}
+
+func (p *versionProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	version := p.currentVersion()
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		p.setVersion(rms.At(i).Resource().Attributes(), version)
+	}
+	return md, nil
+}
+
+func (p *versionProcessor) processLogs(_ context.Context, ld plog.Logs) (plog.Logs, error) {
+	version := p.currentVersion()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		p.setVersion(rls.At(i).Resource().Attributes(), version)
+	}
+	return ld, nil
+}
+
+func (p *versionProcessor) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	version := p.currentVersion()
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		p.setVersion(rss.At(i).Resource().Attributes(), version)
+	}
+	return td, nil
+}
+
+func (p *versionProcessor) setVersion(attrs pcommon.Map, version string) {
+	if version == "" {
+		return
+	}
+	if _, ok := attrs.Get(p.attribute); ok && !p.override {
+		return
+	}
+	attrs.PutStr(p.attribute, version)
+}
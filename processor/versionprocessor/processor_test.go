@@ -0,0 +1,153 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package versionprocessor
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+func newMetrics(attrs map[string]string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	for k, v := range attrs {
+		rm.Resource().Attributes().PutStr(k, v)
+	}
+	rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("test")
+	return md
+}
+
+// versionOf returns the version set on a metric by the processor. It is called from assert.Eventually, so it does
+// not use require.
+func versionOf(t *testing.T, p *versionProcessor) (string, bool) {
+	md, err := p.processMetrics(context.Background(), newMetrics(nil))
+	assert.NoError(t, err)
+	value, ok := md.ResourceMetrics().At(0).Resource().Attributes().Get(defaultAttribute)
+	if !ok {
+		return "", false
+	}
+	return value.Str(), true
+}
+
+func TestProcessWithFileUpdates(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "VERSION")
+	require.NoError(t, os.WriteFile(file, []byte("1.0.0\n"), 0600))
+	p := newProcessor(&Config{Attribute: defaultAttribute, File: file}, zap.NewNop())
+	require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, p.shutdown(context.Background()))
+	}()
+
+	version, ok := versionOf(t, p)
+	assert.True(t, ok)
+	assert.Equal(t, "1.0.0", version)
+
+	// written in place
+	require.NoError(t, os.WriteFile(file, []byte("1.1.0\n"), 0600))
+	assert.Eventually(t, func() bool {
+		version, _ = versionOf(t, p)
+		return version == "1.1.0"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// replaced by a rename
+	tmp := filepath.Join(filepath.Dir(file), "VERSION.tmp")
+	require.NoError(t, os.WriteFile(tmp, []byte("2.0.0"), 0600))
+	require.NoError(t, os.Rename(tmp, file))
+	assert.Eventually(t, func() bool {
+		version, _ = versionOf(t, p)
+		return version == "2.0.0"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the last version is not kept once the file is removed
+	require.NoError(t, os.Remove(file))
+	assert.Eventually(t, func() bool {
+		_, ok = versionOf(t, p)
+		return !ok
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestProcessWithMissingFile(t *testing.T) {
+	t.Setenv("TEST_APP_VERSION", " 3.2.1 ")
+	dir := t.TempDir()
+	file := filepath.Join(dir, "VERSION")
+	p := newProcessor(&Config{Attribute: defaultAttribute, File: file, Env: "TEST_APP_VERSION"}, zap.NewNop())
+	require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, p.shutdown(context.Background()))
+	}()
+
+	// falls back on the environment variable
+	version, ok := versionOf(t, p)
+	assert.True(t, ok)
+	assert.Equal(t, "3.2.1", version)
+
+	// the file is used once it is created
+	require.NoError(t, os.WriteFile(file, []byte("4.0.0"), 0600))
+	assert.Eventually(t, func() bool {
+		version, _ = versionOf(t, p)
+		return version == "4.0.0"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestProcessWithMissingDirectory(t *testing.T) {
+	p := newProcessor(&Config{Attribute: defaultAttribute, File: "/does/not/exist/VERSION"}, zap.NewNop())
+	require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+	_, ok := versionOf(t, p)
+	assert.False(t, ok)
+	assert.NoError(t, p.shutdown(context.Background()))
+}
+
+func TestProcessOverride(t *testing.T) {
+	t.Setenv("TEST_APP_VERSION", "1.0.0")
+	p := newProcessor(&Config{Attribute: defaultAttribute, Env: "TEST_APP_VERSION"}, zap.NewNop())
+	require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, p.shutdown(context.Background()))
+	}()
+
+	md, err := p.processMetrics(context.Background(), newMetrics(map[string]string{defaultAttribute: "0.9.0"}))
+	require.NoError(t, err)
+	got, _ := md.ResourceMetrics().At(0).Resource().Attributes().Get(defaultAttribute)
+	assert.Equal(t, "0.9.0", got.Str())
+
+	p.override = true
+	md, err = p.processMetrics(context.Background(), newMetrics(map[string]string{defaultAttribute: "0.9.0"}))
+	require.NoError(t, err)
+	got, _ = md.ResourceMetrics().At(0).Resource().Attributes().Get(defaultAttribute)
+	assert.Equal(t, "1.0.0", got.Str())
+}
+
+func TestProcessLogsAndTraces(t *testing.T) {
+	t.Setenv("TEST_APP_VERSION", "1.0.0")
+	p := newProcessor(&Config{Attribute: "deployment.version", Env: "TEST_APP_VERSION"}, zap.NewNop())
+	require.NoError(t, p.start(context.Background(), componenttest.NewNopHost()))
+	defer func() {
+		assert.NoError(t, p.shutdown(context.Background()))
+	}()
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty()
+	ld, err := p.processLogs(context.Background(), ld)
+	require.NoError(t, err)
+	got, _ := ld.ResourceLogs().At(0).Resource().Attributes().Get("deployment.version")
+	assert.Equal(t, "1.0.0", got.Str())
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty()
+	td, err = p.processTraces(context.Background(), td)
+	require.NoError(t, err)
+	got, _ = td.ResourceSpans().At(0).Resource().Attributes().Get("deployment.version")
+	assert.Equal(t, "1.0.0", got.Str())
+}
@@ -45,13 +45,18 @@ type otelAccumulator struct {
 	logger         *zap.Logger
 	precision      time.Duration
 	metrics        pmetric.Metrics
+	unitRules      []UnitRule
+	scopeName      string
+
+	collectionInterval time.Duration
+	metricIntervals    map[string]time.Duration
 
 	mutex sync.Mutex
 }
 
-func NewAccumulator(input *models.RunningInput, ctx context.Context, consumer consumer.Metrics, logger *zap.Logger) OtelAccumulator {
+func NewAccumulator(input *models.RunningInput, ctx context.Context, consumer consumer.Metrics, logger *zap.Logger, opts ...Option) OtelAccumulator {
 	_, isServiceInput := input.Input.(telegraf.ServiceInput)
-	return &otelAccumulator{
+	o := &otelAccumulator{
 		input:          input,
 		isServiceInput: isServiceInput,
 		ctx:            ctx,
@@ -60,6 +65,10 @@ func NewAccumulator(input *models.RunningInput, ctx context.Context, consumer co
 		precision:      time.Nanosecond,
 		metrics:        pmetric.NewMetrics(),
 	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
 }
 
 func (o *otelAccumulator) AddGauge(measurement string, fields map[string]interface{}, tags map[string]string, t ...time.Time) {
@@ -143,6 +152,8 @@ func (o *otelAccumulator) convertToOtelMetricsAndAddMetric(m telegraf.Metric) {
 			zap.Error(err))
 		return
 	}
+	setUnits(oMetric, o.unitRules)
+	setScopeName(oMetric, o.scopeName)
 
 	// Gather and Start can add metrics concurrently. Therefore, a mutex ensures thread-safe access to the resource metrics
 	o.mutex.Lock()
@@ -159,6 +170,9 @@ func (o *otelAccumulator) convertToOtelMetricsAndAddMetric(m telegraf.Metric) {
 
 // GetOtelMetrics return the final OTEL metric that were gathered by scrape controller for each plugin
 func (o *otelAccumulator) GetOtelMetrics() pmetric.Metrics {
+	// a Gather that timed out can still add metrics
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
 	finalMetrics := o.metrics
 	o.metrics = pmetric.NewMetrics()
 	return finalMetrics
@@ -178,6 +192,10 @@ func (o *otelAccumulator) modifyMetricAndConvertToOtelValue(m telegraf.Metric) (
 	if mMetric == nil {
 		return nil, nil
 	}
+	o.removeFieldsNotDue(mMetric)
+	if len(mMetric.Fields()) == 0 {
+		return nil, nil
+	}
 
 	if m.Type() == telegraf.Histogram {
 		return mMetric, nil
@@ -0,0 +1,37 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package accumulator
+
+import (
+	"time"
+
+	"github.com/influxdata/telegraf"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/metric"
+)
+
+// WithMetricIntervals sets the intervals of the metrics that are collected less often than the collection interval
+// of the plugin. The intervals are keyed by the metric name and must be multiples of the collection interval.
+func WithMetricIntervals(collectionInterval time.Duration, intervals map[string]time.Duration) Option {
+	return func(o *otelAccumulator) {
+		o.collectionInterval = collectionInterval
+		o.metricIntervals = intervals
+	}
+}
+
+// removeFieldsNotDue removes the fields of the metric whose interval has not elapsed. A field is due when the time
+// of the metric, rounded to the collection interval to absorb the jitter of the scrapes, is a multiple of its
+// interval. This keeps the fields aligned to their interval without tracking when each series was last sent.
+func (o *otelAccumulator) removeFieldsNotDue(m telegraf.Metric) {
+	if len(o.metricIntervals) == 0 || o.collectionInterval <= 0 {
+		return
+	}
+	ts := m.Time().Round(o.collectionInterval).UnixNano()
+	for field := range m.Fields() {
+		interval, ok := o.metricIntervals[metric.DecorateMetricName(m.Name(), field)]
+		if ok && interval > 0 && ts%interval.Nanoseconds() != 0 {
+			m.RemoveField(field)
+		}
+	}
+}
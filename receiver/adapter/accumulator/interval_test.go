@@ -0,0 +1,61 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package accumulator
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/influxdata/telegraf/models"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestAccumulatorWithMetricIntervals(t *testing.T) {
+	ri := models.NewRunningInput(&TestRunningInput{}, &models.InputConfig{})
+	acc := NewAccumulator(ri, context.Background(), nil, zap.NewNop(), WithMetricIntervals(10*time.Second, map[string]time.Duration{
+		"nvidia_smi_power_draw":  time.Minute,
+		"nvidia_smi_fan_speed":   30 * time.Second,
+		"nvidia_smi_clocks_core": 10 * time.Second,
+	}))
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	counts := map[string]int{}
+	// scrape for 2 minutes with some jitter on the scrape times
+	for tick := 0; tick < 12; tick++ {
+		ts := start.Add(time.Duration(tick)*10*time.Second + time.Duration(tick%3)*100*time.Millisecond)
+		acc.AddGauge("nvidia_smi", map[string]interface{}{
+			"temperature_gpu": 50,
+			"power_draw":      100,
+			"fan_speed":       30,
+			"clocks_core":     1000,
+		}, map[string]string{"index": "0"}, ts)
+		md := acc.GetOtelMetrics()
+		for i := 0; i < md.ResourceMetrics().Len(); i++ {
+			ms := md.ResourceMetrics().At(i).ScopeMetrics().At(0).Metrics()
+			for j := 0; j < ms.Len(); j++ {
+				counts[ms.At(j).Name()]++
+			}
+		}
+	}
+	assert.Equal(t, map[string]int{
+		"nvidia_smi_temperature_gpu": 12,
+		"nvidia_smi_clocks_core":     12,
+		"nvidia_smi_fan_speed":       4,
+		"nvidia_smi_power_draw":      2,
+	}, counts)
+}
+
+func TestAccumulatorWithMetricIntervalsNotDue(t *testing.T) {
+	ri := models.NewRunningInput(&TestRunningInput{}, &models.InputConfig{})
+	acc := NewAccumulator(ri, context.Background(), nil, zap.NewNop(), WithMetricIntervals(10*time.Second, map[string]time.Duration{
+		"mem_used_percent": time.Minute,
+	}))
+	// metrics without any field due are dropped
+	acc.AddGauge("mem", map[string]interface{}{"used_percent": 50}, nil, time.Date(2024, 1, 1, 0, 0, 10, 0, time.UTC))
+	assert.Equal(t, 0, acc.GetOtelMetrics().ResourceMetrics().Len())
+	acc.AddGauge("mem", map[string]interface{}{"used_percent": 50}, nil, time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC))
+	assert.Equal(t, 1, acc.GetOtelMetrics().ResourceMetrics().Len())
+}
@@ -0,0 +1,30 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package accumulator
+
+import (
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// WithScopeName sets the instrumentation scope name of the metrics, which identifies the plugin that collected them
+// to the processors, e.g. for its heartbeat.
+func WithScopeName(name string) Option {
+	return func(o *otelAccumulator) {
+		o.scopeName = name
+	}
+}
+
+// setScopeName sets the instrumentation scope name of all of the metrics.
+func setScopeName(md pmetric.Metrics, name string) {
+	if name == "" {
+		return
+	}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			sms.At(j).Scope().SetName(name)
+		}
+	}
+}
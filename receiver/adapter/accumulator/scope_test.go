@@ -0,0 +1,28 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package accumulator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/telegraf/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestAccumulatorWithScopeName(t *testing.T) {
+	ri := models.NewRunningInput(&TestRunningInput{}, &models.InputConfig{})
+	acc := NewAccumulator(ri, context.Background(), nil, zap.NewNop(), WithScopeName("telegraf_cpu"))
+
+	acc.AddGauge("cpu", map[string]interface{}{"usage_idle": 1}, nil)
+	acc.AddCounter("cpu", map[string]interface{}{"time_idle": 1}, nil)
+
+	md := acc.GetOtelMetrics()
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		assert.Equal(t, "telegraf_cpu", md.ResourceMetrics().At(i).ScopeMetrics().At(0).Scope().Name())
+	}
+}
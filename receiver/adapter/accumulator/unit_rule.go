@@ -0,0 +1,48 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package accumulator
+
+import (
+	"path"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/metric"
+)
+
+// UnitRule assigns the unit to the metrics without one whose name matches the pattern. The pattern uses the
+// path.Match syntax, e.g. "*_bytes".
+type UnitRule struct {
+	Pattern string `mapstructure:"pattern"`
+	Unit    string `mapstructure:"unit"`
+}
+
+type Option func(*otelAccumulator)
+
+// WithUnitRules sets the rules used to assign units to the metrics without a default unit. The first rule that
+// matches the name of the metric is used.
+func WithUnitRules(rules []UnitRule) Option {
+	return func(o *otelAccumulator) {
+		o.unitRules = rules
+	}
+}
+
+// setUnits assigns the unit of the first matching rule to the metrics without a unit. Metrics that do not match a
+// rule are left without a unit.
+func setUnits(md pmetric.Metrics, rules []UnitRule) {
+	if len(rules) == 0 {
+		return
+	}
+	metric.RangeMetrics(md, func(m pmetric.Metric) {
+		if m.Unit() != "" {
+			return
+		}
+		for _, rule := range rules {
+			if ok, _ := path.Match(rule.Pattern, m.Name()); ok {
+				m.SetUnit(rule.Unit)
+				return
+			}
+		}
+	})
+}
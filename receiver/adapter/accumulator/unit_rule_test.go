@@ -0,0 +1,60 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package accumulator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/influxdata/telegraf/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+)
+
+func TestAccumulatorWithUnitRules(t *testing.T) {
+	rules := []UnitRule{
+		{Pattern: "*_bytes", Unit: "Bytes"},
+		{Pattern: "*_seconds", Unit: "Seconds"},
+		{Pattern: "request_*", Unit: "Count"},
+	}
+	ri := models.NewRunningInput(&TestRunningInput{}, &models.InputConfig{})
+	acc := NewAccumulator(ri, context.Background(), nil, zap.NewNop(), WithUnitRules(rules))
+
+	acc.AddGauge("memory_bytes", map[string]interface{}{"value": 1}, nil)
+	acc.AddGauge("latency_seconds", map[string]interface{}{"value": 1}, nil)
+	// the first matching rule is used
+	acc.AddCounter("request_bytes", map[string]interface{}{"value": 1}, nil)
+	acc.AddGauge("request_count", map[string]interface{}{"value": 1}, nil)
+	// metrics without a matching rule are left without a unit and sent as None
+	acc.AddGauge("queue_depth", map[string]interface{}{"value": 1}, nil)
+	// default units are kept
+	acc.AddGauge("cpu", map[string]interface{}{"usage_idle": 1}, nil)
+
+	units := map[string]string{}
+	md := acc.GetOtelMetrics()
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		ms := md.ResourceMetrics().At(i).ScopeMetrics().At(0).Metrics()
+		for j := 0; j < ms.Len(); j++ {
+			units[ms.At(j).Name()] = ms.At(j).Unit()
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"memory_bytes":    "Bytes",
+		"latency_seconds": "Seconds",
+		"request_bytes":   "Bytes",
+		"request_count":   "Count",
+		"queue_depth":     "",
+		"cpu_usage_idle":  "Percent",
+	}, units)
+}
+
+func TestSetUnitsWithoutRules(t *testing.T) {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("memory_bytes")
+	setUnits(md, nil)
+	require.Equal(t, "", m.Unit())
+}
@@ -0,0 +1,85 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package adapter
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// recoveryRatio is the share of the queue threshold that the output queues have to drain below before the
+// collection interval is restored, so that the interval does not flap around the threshold.
+const recoveryRatio = 0.5
+
+// AdaptiveIntervalConfig lengthens the collection interval of the plugin while the output queues are backed up.
+type AdaptiveIntervalConfig struct {
+	// QueueThreshold is how full the fullest output queue can be, from 0 to 1, before the interval is lengthened.
+	QueueThreshold float64 `mapstructure:"queue_threshold"`
+	// MaxInterval is the longest that the interval is lengthened to. It must be a multiple of the collection
+	// interval.
+	MaxInterval time.Duration `mapstructure:"max_interval"`
+}
+
+// adaptiveInterval skips scrapes while the output queues are backed up. The scrapes are driven by the ticker of the
+// scraper controller, so the interval is lengthened by only gathering on every nth scrape. The interval doubles
+// each time the queues are still over the threshold when the plugin gathers, up to the max interval, and is
+// restored as soon as the queues drain.
+type adaptiveInterval struct {
+	name               string
+	logger             *zap.Logger
+	collectionInterval time.Duration
+	threshold          float64
+	maxMultiplier      int
+	queueUsage         func() float64
+
+	// multiplier is the current interval in collection intervals.
+	multiplier int
+	// skipped is the number of scrapes skipped since the last gather.
+	skipped int
+}
+
+func newAdaptiveInterval(name string, logger *zap.Logger, collectionInterval time.Duration, cfg *AdaptiveIntervalConfig, queueUsage func() float64) *adaptiveInterval {
+	return &adaptiveInterval{
+		name:               name,
+		logger:             logger,
+		collectionInterval: collectionInterval,
+		threshold:          cfg.QueueThreshold,
+		maxMultiplier:      max(1, int(cfg.MaxInterval/collectionInterval)),
+		queueUsage:         queueUsage,
+		multiplier:         1,
+	}
+}
+
+// shouldGather returns whether the plugin gathers on this scrape.
+func (a *adaptiveInterval) shouldGather() bool {
+	usage := a.queueUsage()
+	if a.multiplier > 1 && usage < a.threshold*recoveryRatio {
+		a.logger.Info("Output queues recovered, restoring collection interval",
+			zap.String("receiver", a.name),
+			zap.Duration("interval", a.collectionInterval),
+			zap.Float64("queue_usage", usage))
+		a.multiplier = 1
+		a.skipped = 0
+		return true
+	}
+	a.skipped++
+	if a.skipped < a.multiplier {
+		return false
+	}
+	a.skipped = 0
+	if usage >= a.threshold && a.multiplier < a.maxMultiplier {
+		a.multiplier = min(2*a.multiplier, a.maxMultiplier)
+		a.logger.Warn("Output queues are backed up, lengthening collection interval",
+			zap.String("receiver", a.name),
+			zap.Duration("interval", a.interval()),
+			zap.Float64("queue_usage", usage))
+	}
+	return true
+}
+
+// interval returns the current collection interval.
+func (a *adaptiveInterval) interval() time.Duration {
+	return time.Duration(a.multiplier) * a.collectionInterval
+}
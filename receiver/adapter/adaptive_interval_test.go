@@ -0,0 +1,99 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package adapter
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/pipelinehealth"
+)
+
+// scrapes returns which of the next n scrapes gather.
+func scrapes(a *adaptiveInterval, n int) []bool {
+	var result []bool
+	for i := 0; i < n; i++ {
+		result = append(result, a.shouldGather())
+	}
+	return result
+}
+
+func TestAdaptiveInterval(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	usage := 0.0
+	a := newAdaptiveInterval("telegraf_cpu", zap.New(core), 10*time.Second, &AdaptiveIntervalConfig{
+		QueueThreshold: 0.8,
+		MaxInterval:    40 * time.Second,
+	}, func() float64 { return usage })
+
+	assert.Equal(t, []bool{true, true, true}, scrapes(a, 3))
+	assert.Equal(t, 10*time.Second, a.interval())
+	assert.Zero(t, logs.Len())
+
+	// the interval doubles each time the plugin gathers while the queues are backed up
+	usage = 0.9
+	assert.Equal(t, []bool{true}, scrapes(a, 1))
+	assert.Equal(t, 20*time.Second, a.interval())
+	assert.Equal(t, []bool{false, true}, scrapes(a, 2))
+	assert.Equal(t, 40*time.Second, a.interval())
+	// up to the max interval
+	assert.Equal(t, []bool{false, false, false, true, false, false, false, true}, scrapes(a, 8))
+	assert.Equal(t, 40*time.Second, a.interval())
+
+	// the interval is kept until the queues drain below half of the threshold
+	usage = 0.5
+	assert.Equal(t, []bool{false, false, false, true}, scrapes(a, 4))
+	assert.Equal(t, 40*time.Second, a.interval())
+	usage = 0.1
+	assert.Equal(t, []bool{true, true}, scrapes(a, 2))
+	assert.Equal(t, 10*time.Second, a.interval())
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 3)
+	assert.Equal(t, zapcore.WarnLevel, entries[0].Level)
+	assert.Equal(t, 20*time.Second, entries[0].ContextMap()["interval"])
+	assert.Equal(t, 40*time.Second, entries[1].ContextMap()["interval"])
+	assert.Equal(t, zapcore.InfoLevel, entries[2].Level)
+	assert.Equal(t, "Output queues recovered, restoring collection interval", entries[2].Message)
+}
+
+func Test_AdaptedReceiver_AdaptiveInterval(t *testing.T) {
+	usage := 0.0
+	pipelinehealth.RegisterQueue("outputs/test", func() float64 { return usage })
+	defer pipelinehealth.UnregisterQueue("outputs/test")
+
+	ctx := context.Background()
+	r := newTestAdaptedReceiver(t, &gaugeInput{}, time.Second)
+	r.adaptive = newAdaptiveInterval("telegraf_gauge", zap.NewNop(), time.Minute, &AdaptiveIntervalConfig{
+		QueueThreshold: 0.5,
+		MaxInterval:    4 * time.Minute,
+	}, pipelinehealth.MaxQueueUsage)
+
+	// simulate the output queue filling up, so the receiver gathers on fewer scrapes
+	usage = 0.75
+	var counts []int
+	for i := 0; i < 8; i++ {
+		md, err := r.scrape(ctx)
+		require.NoError(t, err)
+		counts = append(counts, md.MetricCount())
+	}
+	assert.Equal(t, []int{1, 0, 1, 0, 0, 0, 1, 0}, counts)
+	assert.Equal(t, 4*time.Minute, r.adaptive.interval())
+
+	// the metrics are gathered on every scrape once the queue drains
+	usage = 0
+	for i := 0; i < 3; i++ {
+		md, err := r.scrape(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, md.MetricCount())
+	}
+	assert.Equal(t, time.Minute, r.adaptive.interval())
+}
@@ -4,8 +4,15 @@
 package adapter
 
 import (
+	"fmt"
+	"path"
+	"time"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/cloudwatch"
+	"github.com/aws/amazon-cloudwatch-agent/receiver/adapter/accumulator"
 )
 
 type Config struct {
@@ -13,10 +20,64 @@ type Config struct {
 
 	// The different name of the plugin, share the similar structure with https://github.com/influxdata/telegraf/pull/6207
 	AliasName string `mapstructure:"alias_name,omitempty"`
+
+	// MetricUnits assigns units by metric name pattern to the metrics of the plugin that do not have a unit.
+	MetricUnits []accumulator.UnitRule `mapstructure:"metric_units,omitempty"`
+
+	// MetricIntervals collects the metrics of the plugin less often than the collection interval. The intervals are
+	// keyed by metric name and must be multiples of the collection interval.
+	MetricIntervals map[string]time.Duration `mapstructure:"metric_intervals,omitempty"`
+
+	// AdaptiveInterval lengthens the collection interval of the plugin while the output queues are backed up.
+	AdaptiveInterval *AdaptiveIntervalConfig `mapstructure:"adaptive_interval,omitempty"`
 }
 
 var _ component.Config = (*Config)(nil)
 
 func (cfg *Config) Validate() error {
+	for _, rule := range cfg.MetricUnits {
+		if _, err := path.Match(rule.Pattern, ""); err != nil || rule.Pattern == "" {
+			return fmt.Errorf("invalid metric unit pattern: %q", rule.Pattern)
+		}
+		if _, _, err := cloudwatch.ToStandardUnit(rule.Unit); err != nil || rule.Unit == "" {
+			return fmt.Errorf("invalid metric unit for pattern %q: %q", rule.Pattern, rule.Unit)
+		}
+	}
+	for name, interval := range cfg.MetricIntervals {
+		if interval <= 0 || cfg.CollectionInterval <= 0 || interval%cfg.CollectionInterval != 0 {
+			return fmt.Errorf("interval %v of metric %q is not a multiple of the collection interval %v", interval, name, cfg.CollectionInterval)
+		}
+	}
+	if cfg.AdaptiveInterval != nil {
+		if cfg.AdaptiveInterval.QueueThreshold <= 0 || cfg.AdaptiveInterval.QueueThreshold > 1 {
+			return fmt.Errorf("adaptive interval queue threshold %v must be greater than 0 and at most 1", cfg.AdaptiveInterval.QueueThreshold)
+		}
+		maxInterval := cfg.AdaptiveInterval.MaxInterval
+		if maxInterval < cfg.CollectionInterval || cfg.CollectionInterval <= 0 || maxInterval%cfg.CollectionInterval != 0 {
+			return fmt.Errorf("adaptive interval max interval %v is not a multiple of the collection interval %v", maxInterval, cfg.CollectionInterval)
+		}
+	}
 	return nil
 }
+
+// gatherTimeout returns how long the plugin can take to gather its metrics. Defaults to the collection interval, so a
+// plugin that blocks does not delay its next collection.
+func (cfg *Config) gatherTimeout() time.Duration {
+	if cfg.Timeout > 0 {
+		return cfg.Timeout
+	}
+	return cfg.CollectionInterval
+}
+
+// standardUnitRules returns the metric unit rules with the units converted to the CloudWatch standard units.
+// Units that require the values to be scaled are converted by the exporter instead.
+func (cfg *Config) standardUnitRules() []accumulator.UnitRule {
+	rules := make([]accumulator.UnitRule, 0, len(cfg.MetricUnits))
+	for _, rule := range cfg.MetricUnits {
+		if unit, scale, err := cloudwatch.ToStandardUnit(rule.Unit); err == nil && scale == 1 {
+			rule.Unit = unit
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
@@ -0,0 +1,156 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package adapter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+
+	"github.com/aws/amazon-cloudwatch-agent/receiver/adapter/accumulator"
+)
+
+func TestConfigValidate(t *testing.T) {
+	testCases := map[string]struct {
+		rules   []accumulator.UnitRule
+		wantErr bool
+	}{
+		"WithNoRules": {},
+		"WithValidRules": {
+			rules: []accumulator.UnitRule{{Pattern: "*_bytes", Unit: "Bytes"}, {Pattern: "*_ms", Unit: "ms"}},
+		},
+		"WithEmptyPattern": {
+			rules:   []accumulator.UnitRule{{Unit: "Bytes"}},
+			wantErr: true,
+		},
+		"WithInvalidPattern": {
+			rules:   []accumulator.UnitRule{{Pattern: "[*_bytes", Unit: "Bytes"}},
+			wantErr: true,
+		},
+		"WithEmptyUnit": {
+			rules:   []accumulator.UnitRule{{Pattern: "*_bytes"}},
+			wantErr: true,
+		},
+		"WithUnsupportedUnit": {
+			rules:   []accumulator.UnitRule{{Pattern: "*_bytes", Unit: "Gallons"}},
+			wantErr: true,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Config{MetricUnits: testCase.rules}
+			if testCase.wantErr {
+				assert.Error(t, cfg.Validate())
+			} else {
+				assert.NoError(t, cfg.Validate())
+			}
+		})
+	}
+}
+
+func TestConfigValidateMetricIntervals(t *testing.T) {
+	testCases := map[string]struct {
+		intervals map[string]time.Duration
+		wantErr   bool
+	}{
+		"WithNoIntervals": {},
+		"WithMultiples": {
+			intervals: map[string]time.Duration{"nvidia_smi_power_draw": time.Minute, "nvidia_smi_fan_speed": 10 * time.Second},
+		},
+		"WithNotMultiple": {
+			intervals: map[string]time.Duration{"nvidia_smi_power_draw": 15 * time.Second},
+			wantErr:   true,
+		},
+		"WithShorterInterval": {
+			intervals: map[string]time.Duration{"nvidia_smi_power_draw": 5 * time.Second},
+			wantErr:   true,
+		},
+		"WithZeroInterval": {
+			intervals: map[string]time.Duration{"nvidia_smi_power_draw": 0},
+			wantErr:   true,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Config{
+				ControllerConfig: scraperhelper.ControllerConfig{CollectionInterval: 10 * time.Second},
+				MetricIntervals:  testCase.intervals,
+			}
+			if testCase.wantErr {
+				assert.Error(t, cfg.Validate())
+			} else {
+				assert.NoError(t, cfg.Validate())
+			}
+		})
+	}
+}
+
+func TestConfigValidateAdaptiveInterval(t *testing.T) {
+	testCases := map[string]struct {
+		adaptive *AdaptiveIntervalConfig
+		wantErr  bool
+	}{
+		"WithoutAdaptiveInterval": {},
+		"WithValidConfig": {
+			adaptive: &AdaptiveIntervalConfig{QueueThreshold: 0.8, MaxInterval: time.Minute},
+		},
+		"WithCollectionInterval": {
+			adaptive: &AdaptiveIntervalConfig{QueueThreshold: 1, MaxInterval: 10 * time.Second},
+		},
+		"WithZeroThreshold": {
+			adaptive: &AdaptiveIntervalConfig{MaxInterval: time.Minute},
+			wantErr:  true,
+		},
+		"WithThresholdOverOne": {
+			adaptive: &AdaptiveIntervalConfig{QueueThreshold: 1.5, MaxInterval: time.Minute},
+			wantErr:  true,
+		},
+		"WithNotMultiple": {
+			adaptive: &AdaptiveIntervalConfig{QueueThreshold: 0.8, MaxInterval: 45 * time.Second},
+			wantErr:  true,
+		},
+		"WithShorterMaxInterval": {
+			adaptive: &AdaptiveIntervalConfig{QueueThreshold: 0.8, MaxInterval: 5 * time.Second},
+			wantErr:  true,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			cfg := &Config{
+				ControllerConfig: scraperhelper.ControllerConfig{CollectionInterval: 10 * time.Second},
+				AdaptiveInterval: testCase.adaptive,
+			}
+			if testCase.wantErr {
+				assert.Error(t, cfg.Validate())
+			} else {
+				assert.NoError(t, cfg.Validate())
+			}
+		})
+	}
+}
+
+func TestConfigStandardUnitRules(t *testing.T) {
+	cfg := &Config{MetricUnits: []accumulator.UnitRule{
+		{Pattern: "*_bytes", Unit: "bytes"},
+		{Pattern: "*_ms", Unit: "ms"},
+		{Pattern: "*_kib", Unit: "KiBy"},
+		{Pattern: "*_percent", Unit: "%"},
+	}}
+	assert.Equal(t, []accumulator.UnitRule{
+		{Pattern: "*_bytes", Unit: "Bytes"},
+		{Pattern: "*_ms", Unit: "Milliseconds"},
+		// scaled units are converted by the exporter
+		{Pattern: "*_kib", Unit: "KiBy"},
+		{Pattern: "*_percent", Unit: "Percent"},
+	}, cfg.standardUnitRules())
+}
+
+func TestConfigGatherTimeout(t *testing.T) {
+	cfg := &Config{ControllerConfig: scraperhelper.ControllerConfig{CollectionInterval: time.Minute}}
+	assert.Equal(t, time.Minute, cfg.gatherTimeout())
+	cfg.Timeout = 10 * time.Second
+	assert.Equal(t, 10*time.Second, cfg.gatherTimeout())
+}
@@ -15,6 +15,8 @@ import (
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
 	otelscraper "go.opentelemetry.io/collector/scraper"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/pipelinehealth"
 )
 
 const (
@@ -62,6 +64,14 @@ func (a Adapter) createMetricsReceiver(ctx context.Context, settings receiver.Se
 	}
 
 	rcvr := newAdaptedReceiver(input, ctx, consumer, settings.Logger)
+	rcvr.unitRules = cfg.standardUnitRules()
+	rcvr.collectionInterval = cfg.CollectionInterval
+	rcvr.metricIntervals = cfg.MetricIntervals
+	rcvr.gatherTimeout = cfg.gatherTimeout()
+	rcvr.scopeName = settings.ID.String()
+	if cfg.AdaptiveInterval != nil {
+		rcvr.adaptive = newAdaptiveInterval(settings.ID.String(), settings.Logger, cfg.CollectionInterval, cfg.AdaptiveInterval, pipelinehealth.MaxQueueUsage)
+	}
 
 	scraper, err := otelscraper.NewMetrics(
 		rcvr.scrape,
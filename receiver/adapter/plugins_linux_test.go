@@ -7,7 +7,13 @@
 package adapter
 
 import (
+	"context"
+	"net"
 	"testing"
+	"time"
+
+	"collectd.org/api"
+	"collectd.org/network"
 
 	_ "github.com/influxdata/telegraf/plugins/inputs/disk"
 	_ "github.com/influxdata/telegraf/plugins/inputs/diskio"
@@ -18,6 +24,7 @@ import (
 	_ "github.com/influxdata/telegraf/plugins/inputs/socket_listener"
 	_ "github.com/influxdata/telegraf/plugins/inputs/swap"
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/consumer/consumertest"
 
 	_ "github.com/aws/amazon-cloudwatch-agent/plugins/inputs/statsd"
 )
@@ -179,3 +186,49 @@ func Test_SocketListenerPlugin(t *testing.T) {
 		numMetricsComparator: assert.Equal,
 	})
 }
+
+func Test_SocketListenerCollectdHostname(t *testing.T) {
+	as := assert.New(t)
+	ctx := context.TODO()
+	sink := new(consumertest.MetricsSink)
+	receiver := getInitializedReceiver(as, "socket_listener", "./testdata/collectd_plugin.toml", ctx, sink)
+	as.NoError(receiver.start(ctx, nil))
+	defer func() {
+		as.NoError(receiver.shutdown(ctx))
+	}()
+
+	conn, err := net.Dial("udp", "127.0.0.1:25827")
+	as.NoError(err)
+	defer conn.Close()
+	// the packet hostname is the host dimension, and the agent host is used for packets without one
+	for _, host := range []string{"web-1", ""} {
+		buf := network.NewBuffer(0)
+		as.NoError(buf.Write(ctx, &api.ValueList{
+			Identifier: api.Identifier{Host: host, Plugin: "load", Type: "gauge"},
+			Time:       time.Now(),
+			Interval:   10 * time.Second,
+			Values:     []api.Value{api.Gauge(1)},
+		}))
+		packet, err := buf.Bytes()
+		as.NoError(err)
+		_, err = conn.Write(packet)
+		as.NoError(err)
+	}
+
+	as.Eventually(func() bool {
+		return sink.DataPointCount() >= 2
+	}, 5*time.Second, 10*time.Millisecond)
+	hosts := map[string]bool{}
+	for _, md := range sink.AllMetrics() {
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			metrics := rms.At(i).ScopeMetrics().At(0).Metrics()
+			for j := 0; j < metrics.Len(); j++ {
+				as.Equal("collectd_load_value", metrics.At(j).Name())
+				host, _ := metrics.At(j).Gauge().DataPoints().At(0).Attributes().Get("host")
+				hosts[host.Str()] = true
+			}
+		}
+	}
+	as.Equal(map[string]bool{"web-1": true, "agent-host": true}, hosts)
+}
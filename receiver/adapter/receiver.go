@@ -5,6 +5,11 @@ package adapter
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sync/atomic"
+	"time"
 
 	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/models"
@@ -16,6 +21,8 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/receiver/adapter/accumulator"
 )
 
+var errGatherInProgress = errors.New("previous gather has not returned")
+
 // AdaptedReceiver uses an OTel Scrape Controller to scrape metrics and has three phases:
 // Start: Start the accumulator to initialize the logger and resources metrics
 // Scrape: Gather metrics using the accumulator (e.g CPU https://github.com/influxdata/telegraf/blob/6e924fcd5cc2ce79a024b7275d865d7a19c455ed/plugins/inputs/cpu/cpu.go)
@@ -26,6 +33,20 @@ type AdaptedReceiver struct {
 	ctx         context.Context
 	consumer    consumer.Metrics
 	accumulator accumulator.OtelAccumulator
+	unitRules   []accumulator.UnitRule
+	scopeName   string
+
+	collectionInterval time.Duration
+	metricIntervals    map[string]time.Duration
+	// gatherTimeout is how long Gather can take before the scrape fails. There is no timeout if it is not set.
+	gatherTimeout time.Duration
+	// adaptive skips scrapes while the output queues are backed up. It is not set if the interval is fixed.
+	adaptive *adaptiveInterval
+
+	// gathering is set while Gather has not returned, including after it timed out.
+	gathering atomic.Bool
+	// failures is the number of scrapes that failed.
+	failures atomic.Uint64
 }
 
 func newAdaptedReceiver(input *models.RunningInput, ctx context.Context, consumer consumer.Metrics, logger *zap.Logger) *AdaptedReceiver {
@@ -43,7 +64,11 @@ func (r *AdaptedReceiver) start(_ context.Context, _ component.Host) error {
 	// TODO: Add Set Precision based on agent precision and agent interval
 	// https://github.com/influxdata/telegraf/blob/3b3584b40b7c9ea10ae9cb02137fc072da202704/agent/agent.go#L316-L317
 
-	r.accumulator = accumulator.NewAccumulator(r.input, r.ctx, r.consumer, r.logger)
+	r.accumulator = accumulator.NewAccumulator(r.input, r.ctx, r.consumer, r.logger,
+		accumulator.WithUnitRules(r.unitRules),
+		accumulator.WithMetricIntervals(r.collectionInterval, r.metricIntervals),
+		accumulator.WithScopeName(r.scopeName),
+	)
 
 	// Service Input differs from a regular plugin in that it operates a background service while Telegraf/CWAgent is running
 	// https://github.com/influxdata/telegraf/blob/d67f75e55765d364ad0aabe99382656cb5b51014/docs/INPUTS.md#service-input-plugins
@@ -57,21 +82,65 @@ func (r *AdaptedReceiver) start(_ context.Context, _ component.Host) error {
 	return nil
 }
 
-func (r *AdaptedReceiver) scrape(_ context.Context) (pmetric.Metrics, error) {
+func (r *AdaptedReceiver) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	if r.adaptive != nil && !r.adaptive.shouldGather() {
+		r.logger.Debug("Skipped scraping metrics while the output queues are backed up", zap.String("receiver", r.input.Config.Name))
+		return pmetric.NewMetrics(), nil
+	}
 	r.logger.Debug("Begin scraping metrics with adapter", zap.String("receiver", r.input.Config.Name))
 
 	// Depending on the type of input, Gather may conditionally add metrics to the accumulator. For most service inputs,
 	// the background process is the one sending the metrics further along the pipeline but there are cases where the
 	// background process can buffer the metrics and calling Gather is what flushes the buffer. An example of this is
 	// our statsd plugin: https://github.com/aws/amazon-cloudwatch-agent/blob/2e468dfd96cf9084ab76c2420262e1bbe1eca483/plugins/inputs/statsd/statsd.go
-	if err := r.input.Input.Gather(r.accumulator); err != nil {
-		r.accumulator.AddError(err)
+	if err := r.gather(ctx); err != nil {
+		r.logger.Error("Failed to gather metrics with adapter",
+			zap.String("receiver", r.input.Config.Name),
+			zap.Uint64("failures", r.failures.Add(1)),
+			zap.Error(err))
 		return pmetric.Metrics{}, err
 	}
 
 	return r.accumulator.GetOtelMetrics(), nil
 }
 
+// gather calls Gather in its own goroutine so that an input that panics or blocks fails its own scrape instead of
+// crashing or stalling the agent. A Gather that timed out is not called again until it returns, so a hung input does
+// not pile up goroutines. The metrics it adds once it returns are sent with the next scrape.
+func (r *AdaptedReceiver) gather(ctx context.Context) error {
+	if !r.gathering.CompareAndSwap(false, true) {
+		return errGatherInProgress
+	}
+	done := make(chan error, 1)
+	go func() {
+		defer r.gathering.Store(false)
+		defer func() {
+			if p := recover(); p != nil {
+				r.logger.Error("Recovered from panic in gather",
+					zap.String("receiver", r.input.Config.Name),
+					zap.ByteString("stack", debug.Stack()))
+				done <- fmt.Errorf("gather panicked: %v", p)
+			}
+		}()
+		done <- r.input.Input.Gather(r.accumulator)
+	}()
+
+	var timeout <-chan time.Time
+	if r.gatherTimeout > 0 {
+		timer := time.NewTimer(r.gatherTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+	select {
+	case err := <-done:
+		return err
+	case <-timeout:
+		return fmt.Errorf("gather did not return within %v", r.gatherTimeout)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func (r *AdaptedReceiver) shutdown(_ context.Context) error {
 	r.logger.Debug("Shutdown adapter", zap.String("receiver", r.input.Config.Name))
 	if serviceInput, ok := r.input.Input.(telegraf.ServiceInput); ok {
@@ -6,9 +6,12 @@ package adapter
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/influxdata/telegraf"
 	"github.com/influxdata/telegraf/models"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.uber.org/zap"
@@ -49,3 +52,79 @@ func Test_AdaptedReceiver_WithEmptyMetrics_ServiceInput(t *testing.T) {
 	err = adaptedReceiver.shutdown(ctx)
 	as.NoError(err)
 }
+
+type panicInput struct {
+	accumulator.TestRunningInput
+}
+
+func (p *panicInput) Gather(_ telegraf.Accumulator) error {
+	panic("procstat exploded")
+}
+
+type blockingInput struct {
+	accumulator.TestRunningInput
+	unblock chan struct{}
+}
+
+func (b *blockingInput) Gather(acc telegraf.Accumulator) error {
+	<-b.unblock
+	acc.AddFields("blocking", map[string]interface{}{"value": 1}, nil)
+	return nil
+}
+
+type gaugeInput struct {
+	accumulator.TestRunningInput
+}
+
+func (g *gaugeInput) Gather(acc telegraf.Accumulator) error {
+	acc.AddFields("gauge", map[string]interface{}{"value": 1}, nil)
+	return nil
+}
+
+func newTestAdaptedReceiver(t *testing.T, input telegraf.Input, gatherTimeout time.Duration) *AdaptedReceiver {
+	t.Helper()
+	ri := models.NewRunningInput(input, &models.InputConfig{})
+	require.NoError(t, ri.Config.Filter.Compile())
+	r := newAdaptedReceiver(ri, context.Background(), nil, zap.NewNop())
+	r.gatherTimeout = gatherTimeout
+	require.NoError(t, r.start(context.Background(), componenttest.NewNopHost()))
+	return r
+}
+
+func Test_AdaptedReceiver_IsolatesFailingInputs(t *testing.T) {
+	ctx := context.Background()
+	healthy := newTestAdaptedReceiver(t, &gaugeInput{}, time.Second)
+	panicking := newTestAdaptedReceiver(t, &panicInput{}, time.Second)
+	blocking := &blockingInput{unblock: make(chan struct{})}
+	blocked := newTestAdaptedReceiver(t, blocking, 50*time.Millisecond)
+
+	_, err := panicking.scrape(ctx)
+	assert.ErrorContains(t, err, "gather panicked: procstat exploded")
+	assert.EqualValues(t, 1, panicking.failures.Load())
+
+	_, err = blocked.scrape(ctx)
+	assert.ErrorContains(t, err, "gather did not return within 50ms")
+	// the input is not called again while it is blocked
+	_, err = blocked.scrape(ctx)
+	assert.ErrorIs(t, err, errGatherInProgress)
+	assert.EqualValues(t, 2, blocked.failures.Load())
+
+	md, err := healthy.scrape(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 1, md.MetricCount())
+	assert.EqualValues(t, 0, healthy.failures.Load())
+
+	// the panicking input is still scraped
+	_, err = panicking.scrape(ctx)
+	assert.Error(t, err)
+	assert.EqualValues(t, 2, panicking.failures.Load())
+
+	// the metrics of the blocked input are sent once it returns
+	close(blocking.unblock)
+	assert.Eventually(t, func() bool {
+		return !blocked.gathering.Load()
+	}, time.Second, 10*time.Millisecond)
+	md, err = blocked.scrape(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 2, md.MetricCount())
+}
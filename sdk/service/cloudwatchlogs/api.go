@@ -9661,10 +9661,17 @@ func (s CreateLogGroupOutput) GoString() string {
 type CreateLogStreamInput struct {
 	_ struct{} `type:"structure"`
 
+	// Specify either the name or ARN of the log group. If the log group is in
+	// a source account and you are using a monitoring account, you must use the
+	// log group ARN.
+	//
+	// You must include either logGroupIdentifier or logGroupName, but not both.
+	LogGroupIdentifier *string `locationName:"logGroupIdentifier" min:"1" type:"string"`
+
 	// The name of the log group.
 	//
-	// LogGroupName is a required field
-	LogGroupName *string `locationName:"logGroupName" min:"1" type:"string" required:"true"`
+	// You must include either logGroupIdentifier or logGroupName, but not both.
+	LogGroupName *string `locationName:"logGroupName" min:"1" type:"string"`
 
 	// The name of the log stream.
 	//
@@ -9693,9 +9700,12 @@ func (s CreateLogStreamInput) GoString() string {
 // Validate inspects the fields of the type to determine if they are valid.
 func (s *CreateLogStreamInput) Validate() error {
 	invalidParams := request.ErrInvalidParams{Context: "CreateLogStreamInput"}
-	if s.LogGroupName == nil {
+	if s.LogGroupName == nil && s.LogGroupIdentifier == nil {
 		invalidParams.Add(request.NewErrParamRequired("LogGroupName"))
 	}
+	if s.LogGroupIdentifier != nil && len(*s.LogGroupIdentifier) < 1 {
+		invalidParams.Add(request.NewErrParamMinLen("LogGroupIdentifier", 1))
+	}
 	if s.LogGroupName != nil && len(*s.LogGroupName) < 1 {
 		invalidParams.Add(request.NewErrParamMinLen("LogGroupName", 1))
 	}
@@ -9712,6 +9722,12 @@ func (s *CreateLogStreamInput) Validate() error {
 	return nil
 }
 
+// SetLogGroupIdentifier sets the LogGroupIdentifier field's value.
+func (s *CreateLogStreamInput) SetLogGroupIdentifier(v string) *CreateLogStreamInput {
+	s.LogGroupIdentifier = &v
+	return s
+}
+
 // SetLogGroupName sets the LogGroupName field's value.
 func (s *CreateLogStreamInput) SetLogGroupName(v string) *CreateLogStreamInput {
 	s.LogGroupName = &v
@@ -17591,10 +17607,17 @@ type PutLogEventsInput struct {
 	// LogEvents is a required field
 	LogEvents []*InputLogEvent `locationName:"logEvents" min:"1" type:"list" required:"true"`
 
+	// Specify either the name or ARN of the log group. If the log group is in
+	// a source account and you are using a monitoring account, you must use the
+	// log group ARN.
+	//
+	// You must include either logGroupIdentifier or logGroupName, but not both.
+	LogGroupIdentifier *string `locationName:"logGroupIdentifier" min:"1" type:"string"`
+
 	// The name of the log group.
 	//
-	// LogGroupName is a required field
-	LogGroupName *string `locationName:"logGroupName" min:"1" type:"string" required:"true"`
+	// You must include either logGroupIdentifier or logGroupName, but not both.
+	LogGroupName *string `locationName:"logGroupName" min:"1" type:"string"`
 
 	// The name of the log stream.
 	//
@@ -17637,9 +17660,12 @@ func (s *PutLogEventsInput) Validate() error {
 	if s.LogEvents != nil && len(s.LogEvents) < 1 {
 		invalidParams.Add(request.NewErrParamMinLen("LogEvents", 1))
 	}
-	if s.LogGroupName == nil {
+	if s.LogGroupName == nil && s.LogGroupIdentifier == nil {
 		invalidParams.Add(request.NewErrParamRequired("LogGroupName"))
 	}
+	if s.LogGroupIdentifier != nil && len(*s.LogGroupIdentifier) < 1 {
+		invalidParams.Add(request.NewErrParamMinLen("LogGroupIdentifier", 1))
+	}
 	if s.LogGroupName != nil && len(*s.LogGroupName) < 1 {
 		invalidParams.Add(request.NewErrParamMinLen("LogGroupName", 1))
 	}
@@ -17686,6 +17712,12 @@ func (s *PutLogEventsInput) SetLogEvents(v []*InputLogEvent) *PutLogEventsInput
 	return s
 }
 
+// SetLogGroupIdentifier sets the LogGroupIdentifier field's value.
+func (s *PutLogEventsInput) SetLogGroupIdentifier(v string) *PutLogEventsInput {
+	s.LogGroupIdentifier = &v
+	return s
+}
+
 // SetLogGroupName sets the LogGroupName field's value.
 func (s *PutLogEventsInput) SetLogGroupName(v string) *PutLogEventsInput {
 	s.LogGroupName = &v
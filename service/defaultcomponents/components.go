@@ -44,6 +44,7 @@ import (
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/debugexporter"
 	"go.opentelemetry.io/collector/exporter/nopexporter"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
 	"go.opentelemetry.io/collector/extension"
 	"go.opentelemetry.io/collector/extension/zpagesextension"
 	"go.opentelemetry.io/collector/otelcol"
@@ -56,14 +57,38 @@ import (
 
 	"github.com/aws/amazon-cloudwatch-agent/extension/agenthealth"
 	"github.com/aws/amazon-cloudwatch-agent/extension/entitystore"
+	"github.com/aws/amazon-cloudwatch-agent/extension/pipelinehealth"
 	"github.com/aws/amazon-cloudwatch-agent/extension/server"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/cloudwatch"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/debugfile"
+	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/timestream"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsapplicationsignals"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsentity"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/ec2tagger"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/gpuattributes"
 	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/kueueattributes"
+	"github.com/aws/amazon-cloudwatch-agent/processor/attributedenylistprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/processor/counterresetprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/processor/cwlogsprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/processor/dedupprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/processor/derivedmetricsprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/processor/dimensionbucketprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/processor/dimensioncaseprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/processor/dimensioninheritanceprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/processor/dimensionkeepprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/processor/dimensionlimitprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/processor/heartbeatprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/processor/histogramstatsprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/processor/metricnameprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/processor/metricsplitprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/processor/nonfiniteprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/processor/presencefilterprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/processor/resourcemappingprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/processor/rollupprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/processor/roundingprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/processor/semconvprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/processor/valuemapprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/processor/versionprocessor"
 )
 
 func Factories() (otelcol.Factories, error) {
@@ -91,28 +116,49 @@ func Factories() (otelcol.Factories, error) {
 	}
 
 	if factories.Processors, err = processor.MakeFactoryMap(
+		attributedenylistprocessor.NewFactory(),
 		attributesprocessor.NewFactory(),
 		awsapplicationsignals.NewFactory(),
 		awsentity.NewFactory(),
 		batchprocessor.NewFactory(),
+		counterresetprocessor.NewFactory(),
 		cumulativetodeltaprocessor.NewFactory(),
+		cwlogsprocessor.NewFactory(),
+		dedupprocessor.NewFactory(),
 		deltatorateprocessor.NewFactory(),
+		derivedmetricsprocessor.NewFactory(),
+		dimensionbucketprocessor.NewFactory(),
+		dimensioncaseprocessor.NewFactory(),
+		dimensioninheritanceprocessor.NewFactory(),
+		dimensionkeepprocessor.NewFactory(),
+		dimensionlimitprocessor.NewFactory(),
 		ec2tagger.NewFactory(),
 		filterprocessor.NewFactory(),
 		gpuattributes.NewFactory(),
 		kueueattributes.NewFactory(),
 		groupbytraceprocessor.NewFactory(),
+		heartbeatprocessor.NewFactory(),
+		histogramstatsprocessor.NewFactory(),
 		k8sattributesprocessor.NewFactory(),
 		memorylimiterprocessor.NewFactory(),
 		metricsgenerationprocessor.NewFactory(),
+		metricnameprocessor.NewFactory(),
+		metricsplitprocessor.NewFactory(),
 		metricstransformprocessor.NewFactory(),
+		nonfiniteprocessor.NewFactory(),
 		probabilisticsamplerprocessor.NewFactory(),
+		presencefilterprocessor.NewFactory(),
 		resourceprocessor.NewFactory(),
 		resourcedetectionprocessor.NewFactory(),
+		resourcemappingprocessor.NewFactory(),
 		rollupprocessor.NewFactory(),
+		roundingprocessor.NewFactory(),
+		semconvprocessor.NewFactory(),
 		spanprocessor.NewFactory(),
 		tailsamplingprocessor.NewFactory(),
 		transformprocessor.NewFactory(),
+		valuemapprocessor.NewFactory(),
+		versionprocessor.NewFactory(),
 	); err != nil {
 		return otelcol.Factories{}, err
 	}
@@ -123,8 +169,11 @@ func Factories() (otelcol.Factories, error) {
 		awsxrayexporter.NewFactory(),
 		cloudwatch.NewFactory(),
 		debugexporter.NewFactory(),
+		debugfile.NewFactory(),
 		nopexporter.NewFactory(),
+		otlphttpexporter.NewFactory(),
 		prometheusremotewriteexporter.NewFactory(),
+		timestream.NewFactory(),
 	); err != nil {
 		return otelcol.Factories{}, err
 	}
@@ -133,6 +182,7 @@ func Factories() (otelcol.Factories, error) {
 		agenthealth.NewFactory(),
 		awsproxy.NewFactory(),
 		entitystore.NewFactory(),
+		pipelinehealth.NewFactory(),
 		server.NewFactory(),
 		ecsobserver.NewFactory(),
 		filestorage.NewFactory(),
@@ -42,26 +42,47 @@ func TestComponents(t *testing.T) {
 	wantProcessors := []string{
 		"awsapplicationsignals",
 		"awsentity",
+		"attributedenylist",
 		"attributes",
 		"batch",
+		"counterreset",
 		"cumulativetodelta",
+		"cwlogs",
+		"dedup",
 		"deltatorate",
+		"derivedmetrics",
+		"dimensionbucket",
+		"dimensioncase",
+		"dimensioninheritance",
+		"dimensionkeep",
+		"dimensionlimit",
 		"ec2tagger",
 		"metricsgeneration",
 		"filter",
 		"gpuattributes",
 		"kueueattributes",
 		"groupbytrace",
+		"heartbeat",
+		"histogramstats",
 		"k8sattributes",
 		"memory_limiter",
+		"metricname",
+		"metricsplit",
 		"metricstransform",
+		"nonfinite",
+		"presencefilter",
 		"resourcedetection",
+		"resourcemapping",
 		"resource",
 		"rollup",
+		"rounding",
+		"semconv",
 		"probabilistic_sampler",
 		"span",
 		"tail_sampling",
 		"transform",
+		"valuemap",
+		"version",
 	}
 	gotProcessors := collections.MapSlice(maps.Keys(factories.Processors), component.Type.String)
 	assert.Equal(t, len(wantProcessors), len(gotProcessors))
@@ -73,9 +94,12 @@ func TestComponents(t *testing.T) {
 		"awscloudwatchlogs",
 		"awsemf",
 		"awscloudwatch",
+		"awstimestream",
 		"awsxray",
 		"debug",
+		"debugfile",
 		"nop",
+		"otlphttp",
 		"prometheusremotewrite",
 	}
 	gotExporters := collections.MapSlice(maps.Keys(factories.Exporters), component.Type.String)
@@ -91,6 +115,7 @@ func TestComponents(t *testing.T) {
 		"entitystore",
 		"file_storage",
 		"health_check",
+		"pipelinehealth",
 		"pprof",
 		"server",
 		"sigv4auth",
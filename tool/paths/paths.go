@@ -9,6 +9,8 @@ const (
 	TOML           = "amazon-cloudwatch-agent.toml"
 	YAML           = "amazon-cloudwatch-agent.yaml"
 	ENV            = "env-config.json"
+	CONFIG_SOURCE  = "config-source"
+	FRAGMENT_DIR   = "conf.d"
 	AGENT_LOG_FILE = "amazon-cloudwatch-agent.log"
 	JMXJarName     = "opentelemetry-jmx-metrics.jar"
 )
@@ -16,7 +18,9 @@ const (
 var (
 	JsonConfigPath       string
 	ConfigDirPath        string
+	FragmentDirPath      string
 	EnvConfigPath        string
+	ConfigSourcePath     string
 	TomlConfigPath       string
 	CommonConfigPath     string
 	YamlConfigPath       string
@@ -23,7 +23,9 @@ const (
 func init() {
 	JsonConfigPath = filepath.Join(AgentDir, "etc", JSON)
 	ConfigDirPath = filepath.Join(AgentDir, "etc", ConfigDir)
+	FragmentDirPath = filepath.Join(AgentDir, "etc", FRAGMENT_DIR)
 	EnvConfigPath = filepath.Join(AgentDir, "etc", ENV)
+	ConfigSourcePath = filepath.Join(AgentDir, "etc", CONFIG_SOURCE)
 	TomlConfigPath = filepath.Join(AgentDir, "etc", TOML)
 	CommonConfigPath = filepath.Join(AgentDir, "etc", COMMON_CONFIG)
 	YamlConfigPath = filepath.Join(AgentDir, "etc", YAML)
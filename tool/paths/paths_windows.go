@@ -45,7 +45,9 @@ func init() {
 	AgentConfigDir := filepath.Join(programData, AgentDir)
 	JsonConfigPath = filepath.Join(AgentConfigDir, JSON)
 	ConfigDirPath = filepath.Join(AgentConfigDir, ConfigDir)
+	FragmentDirPath = filepath.Join(AgentConfigDir, FRAGMENT_DIR)
 	EnvConfigPath = filepath.Join(AgentConfigDir, ENV)
+	ConfigSourcePath = filepath.Join(AgentConfigDir, CONFIG_SOURCE)
 	TomlConfigPath = filepath.Join(AgentConfigDir, TOML)
 	YamlConfigPath = filepath.Join(AgentConfigDir, YAML)
 	CommonConfigPath = filepath.Join(AgentConfigDir, COMMON_CONFIG)
@@ -4,7 +4,10 @@
 package cmdutil
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
 	"log"
 	"os"
 	"path/filepath"
@@ -41,12 +44,28 @@ func TranslateJsonMapToEnvConfigFile(jsonConfigValue map[string]interface{}, env
 	if envConfigPath == "" {
 		return
 	}
-	bytes := toenvconfig.ToEnvConfig(jsonConfigValue)
+	bytes := withConfigRuleStats(toenvconfig.ToEnvConfig(jsonConfigValue))
 	if err := os.WriteFile(envConfigPath, bytes, 0644); err != nil {
 		log.Panicf("E! Failed to create env config. Reason: %s", err.Error())
 	}
 }
 
+// withConfigRuleStats adds the totals of the translated rules to the env config, so that the agent reports them in
+// its agent health stats.
+func withConfigRuleStats(envConfig []byte) []byte {
+	envVars := map[string]string{}
+	if err := json.Unmarshal(envConfig, &envVars); err != nil {
+		return envConfig
+	}
+	total, _ := translator.GetRuleStats()
+	envVars[envconfig.CWAGENT_CONFIG_RULES] = fmt.Sprintf("%d,%d,%d", total.Applied, total.Warnings, total.Errors)
+	bytes, err := json.MarshalIndent(envVars, "", "\t")
+	if err != nil {
+		return envConfig
+	}
+	return bytes
+}
+
 func getCurBinaryPath() string {
 	ex, err := os.Executable()
 	if err != nil {
@@ -106,6 +125,21 @@ func checkSchema(inputJsonMap map[string]interface{}) {
 }
 
 func GenerateMergedJsonConfigMap(ctx *context.Context) (map[string]interface{}, error) {
+	jsonConfigMapMap, err := loadJsonConfigMaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mergedJsonConfigMap, err := mergeJsonConfigMaps(ctx, jsonConfigMapMap)
+	if err != nil {
+		return nil, err
+	}
+	// Json Schema Validation by gojsonschema
+	checkSchema(mergedJsonConfigMap)
+	return mergedJsonConfigMap, nil
+}
+
+// loadJsonConfigMaps reads the json configs of the context, keyed by their path.
+func loadJsonConfigMaps(ctx *context.Context) (map[string]map[string]interface{}, error) {
 	// we use a map instead of an array here because we need to override the config value
 	// for the append operation when the existing file name and new .tmp file name have diff
 	// only for the ".tmp" suffix, i.e. it is override operation even it says append.
@@ -193,6 +227,22 @@ func GenerateMergedJsonConfigMap(ctx *context.Context) (map[string]interface{},
 		log.Printf("unable to scan config dir %v with error: %v", ctx.InputJsonDirPath(), err)
 	}
 
+	if fragmentDir := ctx.InputFragmentDirPath(); fragmentDir != "" {
+		fragmentConfigMap, overrides, err := jsonconfig.LoadFragmentDir(fragmentDir)
+		// the agent passes its default fragment dir on every start, which only exists when fragments are used
+		if errors.Is(err, fs.ErrNotExist) {
+			log.Printf("D! No config fragment dir %v", fragmentDir)
+		} else if err != nil {
+			return nil, err
+		}
+		for _, override := range overrides {
+			log.Printf("W! Config fragment override %v", override)
+		}
+		if len(fragmentConfigMap) > 0 {
+			jsonConfigMapMap[fragmentDir] = fragmentConfigMap
+		}
+	}
+
 	if len(jsonConfigMapMap) == 0 {
 		// For containerized agent, try to read env variable only when json configuration file is absent
 		if jsonConfigContent, ok := os.LookupEnv(config.CWConfigContent); ok && os.Getenv(config.RUN_IN_CONTAINER) == config.RUN_IN_CONTAINER_TRUE {
@@ -205,33 +255,48 @@ func GenerateMergedJsonConfigMap(ctx *context.Context) (map[string]interface{},
 		}
 	}
 
-	defaultConfig, err := translatorUtil.GetDefaultJsonConfigMap(ctx.Os(), ctx.Mode())
-	if err != nil {
-		return nil, err
+	for path, jsonConfigMap := range jsonConfigMapMap {
+		jsonConfigMap, err = jsonconfig.ApplyPlatformConfig(jsonConfigMap, ctx.Os())
+		if err != nil {
+			return nil, fmt.Errorf("unable to apply the %v config of %v: %w", ctx.Os(), path, err)
+		}
+		jsonConfigMapMap[path] = jsonConfigMap
 	}
-	mergedJsonConfigMap, err := jsonconfig.MergeJsonConfigMaps(jsonConfigMapMap, defaultConfig, ctx.MultiConfig())
+	return jsonConfigMapMap, nil
+}
+
+// mergeJsonConfigMaps merges the json configs with the default config. The sections that have no merge rule are
+// dropped.
+func mergeJsonConfigMaps(ctx *context.Context, jsonConfigMapMap map[string]map[string]interface{}) (map[string]interface{}, error) {
+	defaultConfig, err := translatorUtil.GetDefaultJsonConfigMap(ctx.Os(), ctx.Mode())
 	if err != nil {
 		return nil, err
 	}
-
-	// Json Schema Validation by gojsonschema
-	checkSchema(mergedJsonConfigMap)
-	return mergedJsonConfigMap, nil
+	return jsonconfig.MergeJsonConfigMaps(jsonConfigMapMap, defaultConfig, ctx.MultiConfig())
 }
 
 func TranslateJsonMapToTomlConfig(jsonConfigValue interface{}) (interface{}, error) {
 	r := new(translate.Translator)
 	_, val := r.ApplyRule(jsonConfigValue)
+	reportRuleStats()
 	if !translator.IsTranslateSuccess() {
 		return nil, fmt.Errorf("%v", translator.ErrorMessages)
 	}
 	// Translation is valid, log info messages and continue to convert/write to toml
+	for _, warningMessage := range translator.WarningMessages {
+		log.Printf("W! %s", warningMessage)
+	}
 	for _, infoMessage := range translator.InfoMessages {
 		log.Println(infoMessage)
 	}
 	return val, nil
 }
 
+// reportRuleStats logs a summary of the translated rules, which is part of the output of the config translation.
+func reportRuleStats() {
+	log.Printf("I! Config translation summary: %s", translator.RuleStatsSummary())
+}
+
 func TranslateJsonMapToYamlConfig(jsonConfigValue interface{}) (interface{}, error) {
 	cfg, err := otel.Translate(jsonConfigValue, context.CurrentContext().Os())
 	if err != nil {
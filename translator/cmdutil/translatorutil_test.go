@@ -7,11 +7,14 @@ import (
 	"encoding/json"
 	"os"
 	"path"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/aws/amazon-cloudwatch-agent/cfg/envconfig"
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/context"
 )
 
 func TestTranslateJsonMapToEnvConfigFile(t *testing.T) {
@@ -38,3 +41,42 @@ func TestTranslateJsonMapToEnvConfigFile(t *testing.T) {
 	assert.Equal(t, expectedJson[envconfig.CWAGENT_LOG_LEVEL], actualJson[envconfig.CWAGENT_LOG_LEVEL])
 	assert.Equal(t, expectedJson[envconfig.AWS_SDK_LOG_LEVEL], actualJson[envconfig.AWS_SDK_LOG_LEVEL])
 }
+
+func TestTranslateJsonMapToEnvConfigFileWithRuleStats(t *testing.T) {
+	translator.ResetRuleStats()
+	t.Cleanup(translator.ResetRuleStats)
+	translator.TrackRule(&stubRule{}).ApplyRule(nil)
+
+	envConfigPath := path.Join(t.TempDir(), "env-config.json")
+	TranslateJsonMapToEnvConfigFile(map[string]interface{}{}, envConfigPath)
+
+	var actualJson map[string]string
+	actual, err := os.ReadFile(envConfigPath)
+	assert.NoError(t, err)
+	assert.NoError(t, json.Unmarshal(actual, &actualJson))
+	assert.Equal(t, "1,0,0", actualJson[envconfig.CWAGENT_CONFIG_RULES])
+}
+
+type stubRule struct{}
+
+func (r *stubRule) ApplyRule(interface{}) (string, interface{}) {
+	return "stub", nil
+}
+
+func TestLoadJsonConfigMapsWithMissingFragmentDir(t *testing.T) {
+	context.ResetContext()
+	t.Cleanup(context.ResetContext)
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.json")
+	assert.NoError(t, os.WriteFile(configPath, []byte(`{"agent": {"region": "us-west-2"}}`), 0644))
+	ctx := context.CurrentContext()
+	ctx.SetOs("linux")
+	ctx.SetMultiConfig("remove")
+	ctx.SetInputJsonDirPath(dir)
+	ctx.SetInputFragmentDirPath(filepath.Join(dir, "conf.d"))
+
+	got, err := loadJsonConfigMaps(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.Contains(t, got, configPath)
+}
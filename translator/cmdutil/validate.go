@@ -0,0 +1,145 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cmdutil
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+	"github.com/aws/amazon-cloudwatch-agent/translator/context"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline"
+)
+
+// keyChange is a key that was removed from or deprecated in the json config.
+type keyChange struct {
+	// path is the path of the key, such as /agent/debug.
+	path string
+	// removed keys are reported as errors since they no longer have any effect, while the deprecated keys are still
+	// supported and reported as warnings.
+	removed bool
+	message string
+}
+
+var keyChanges = []keyChange{
+	{path: "/csm", message: "CSM is deprecated and will be removed in a future version"},
+}
+
+// ValidationReport is the result of validating a json config against the current agent version.
+type ValidationReport struct {
+	Errors   []string
+	Warnings []string
+}
+
+// Valid returns whether the config had no errors. Warnings do not make the config invalid.
+func (r *ValidationReport) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// ValidateJsonConfig reports the keys of the json configs of the context that are unknown, removed or deprecated in
+// the current agent version and the errors of the schema validation of the merged config. When the schema is valid,
+// the merged config is also translated to find the errors of the translator rules, but the translated configs are not
+// written.
+func ValidateJsonConfig(ctx *context.Context) (*ValidationReport, error) {
+	jsonConfigMapMap, err := loadJsonConfigMaps(ctx)
+	if err != nil {
+		return nil, err
+	}
+	knownKeys, err := schemaRootKeys()
+	if err != nil {
+		return nil, err
+	}
+	translator.ResetMessages()
+	report := &ValidationReport{}
+	// the keys are checked before the merge, which drops the unknown sections and fails if there are error messages
+	for _, path := range sortedKeys(jsonConfigMapMap) {
+		report.checkKeys(path, jsonConfigMapMap[path], knownKeys)
+	}
+
+	mergedJsonConfigMap, err := mergeJsonConfigMaps(ctx, jsonConfigMapMap)
+	if err != nil {
+		return nil, err
+	}
+	result, err := RunSchemaValidation(mergedJsonConfigMap)
+	if err != nil {
+		return nil, fmt.Errorf("failed to run schema validation: %w", err)
+	}
+	for _, errorDetail := range result.Errors() {
+		translator.AddErrorMessages(config.GetFormattedPath(errorDetail.Context().String()), errorDetail.Description())
+	}
+	if result.Valid() {
+		// the errors of the translator rules are added to the error messages, so the returned error is not needed
+		_, _ = TranslateJsonMapToTomlConfig(mergedJsonConfigMap)
+		if _, err = TranslateJsonMapToYamlConfig(mergedJsonConfigMap); err != nil && !errors.Is(err, pipeline.ErrNoPipelines) {
+			translator.AddErrorMessages("", err.Error())
+		}
+	}
+	report.Errors = append(report.Errors, translator.ErrorMessages...)
+	return report, nil
+}
+
+// checkKeys adds the removed and unknown keys of the json config in the file to the errors and the deprecated keys to
+// the warnings of the report.
+func (r *ValidationReport) checkKeys(file string, jsonConfigMap map[string]interface{}, knownKeys map[string]bool) {
+	changed := map[string]bool{}
+	for _, change := range keyChanges {
+		if !hasPath(jsonConfigMap, change.path) {
+			continue
+		}
+		changed[change.path] = true
+		if change.removed {
+			r.Errors = append(r.Errors, fmt.Sprintf("Under path : %s | Error : removed key in %s: %s", change.path, file, change.message))
+		} else {
+			r.Warnings = append(r.Warnings, fmt.Sprintf("Under path : %s | Warning : deprecated key in %s: %s", change.path, file, change.message))
+		}
+	}
+	for _, key := range sortedKeys(jsonConfigMap) {
+		// the root of the schema allows additional properties, so the unknown sections are only found here
+		if !knownKeys[key] && !changed["/"+key] {
+			r.Errors = append(r.Errors, fmt.Sprintf("Under path : /%s | Error : unknown key in %s", key, file))
+		}
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// hasPath returns whether the json config has the key at the path.
+func hasPath(jsonConfigMap map[string]interface{}, path string) bool {
+	var current interface{} = jsonConfigMap
+	for _, key := range strings.Split(strings.TrimPrefix(path, "/"), "/") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		if current, ok = m[key]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func schemaRootKeys() (map[string]bool, error) {
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+	}
+	if err := json.Unmarshal([]byte(config.GetJsonSchema()), &schema); err != nil {
+		return nil, fmt.Errorf("failed to parse schema: %w", err)
+	}
+	keys := make(map[string]bool, len(schema.Properties))
+	for key := range schema.Properties {
+		keys[key] = true
+	}
+	return keys, nil
+}
@@ -34,6 +34,7 @@ type Context struct {
 	os                  string
 	inputJsonFilePath   string
 	inputJsonDirPath    string
+	inputFragmentDir    string
 	multiConfig         string
 	outputTomlFilePath  string
 	mode                string
@@ -72,6 +73,14 @@ func (ctx *Context) SetInputJsonDirPath(inputJsonDirPath string) {
 	ctx.inputJsonDirPath = inputJsonDirPath
 }
 
+func (ctx *Context) InputFragmentDirPath() string {
+	return ctx.inputFragmentDir
+}
+
+func (ctx *Context) SetInputFragmentDirPath(inputFragmentDir string) {
+	ctx.inputFragmentDir = inputFragmentDir
+}
+
 func (ctx *Context) MultiConfig() string {
 	return ctx.multiConfig
 }
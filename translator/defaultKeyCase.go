@@ -93,7 +93,7 @@ func DefaultLogGroupClassCase(key string, defaultVal, input interface{}) (return
 		//CreateLogGroup API only accepts values STANDARD or INFREQUENT_ACCESS
 		returnVal = strings.ToUpper(classVal)
 	} else {
-		AddInfoMessages(
+		AddWarningMessages(
 			fmt.Sprintf("LogGroupClass key: %s", key),
 			fmt.Sprintf("%s value (%v) in is not a valid Log Group Class field. Agent will not set the LogGroupClass parameter.", key, returnVal))
 		returnVal = ""
@@ -16,6 +16,9 @@ import (
 var ErrorMessages = []string{}
 var InfoMessages = []string{}
 
+// WarningMessages are the problems in the config that do not fail the translation, e.g. a value that is ignored.
+var WarningMessages = []string{}
+
 // ValidRetentionInDays is based on what's supported by PutRetentionPolicy. See https://docs.aws.amazon.com/AmazonCloudWatch/latest/monitoring/CloudWatch-Agent-Configuration-File-Details.html#CloudWatch-Agent-Configuration-File-Logssection.
 var ValidRetentionInDays = []string{"-1", "1", "3", "5", "7", "14", "30", "60", "90", "120", "150", "180", "365", "400", "545", "731", "1096", "1827", "2192", "2557", "2922", "3288", "3653"}
 
@@ -64,6 +67,16 @@ func AddInfoMessages(path, message string) {
 	InfoMessages = append(InfoMessages, infoMessage)
 }
 
+func AddWarningMessages(path, message string) {
+	var warningMessage string
+	if path == "" {
+		warningMessage = message
+	} else {
+		warningMessage = fmt.Sprintf("Under path : %s | Warning : %s", path, message)
+	}
+	WarningMessages = append(WarningMessages, warningMessage)
+}
+
 func IsTranslateSuccess() bool {
 	return len(ErrorMessages) == 0
 }
@@ -72,6 +85,7 @@ func IsTranslateSuccess() bool {
 func ResetMessages() {
 	ErrorMessages = make([]string, 0)
 	InfoMessages = make([]string, 0)
+	WarningMessages = make([]string, 0)
 }
 
 // ValidDays represents the valid possible values for retentionInDays.
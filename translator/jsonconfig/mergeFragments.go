@@ -0,0 +1,195 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package jsonconfig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/util"
+)
+
+const fragmentSuffix = ".json"
+
+// FragmentOverride records a scalar value in one fragment replacing the value
+// set by an earlier fragment.
+type FragmentOverride struct {
+	Path          string
+	PreviousFile  string
+	PreviousValue interface{}
+	File          string
+	Value         interface{}
+}
+
+func (o FragmentOverride) String() string {
+	return fmt.Sprintf("%s: value %v from %s overridden by %v from %s", o.Path, o.PreviousValue, o.PreviousFile, o.Value, o.File)
+}
+
+// LoadFragmentDir reads every .json file in the directory, in lexical filename
+// order, and merges them with MergeFragments. Sub-directories are ignored.
+func LoadFragmentDir(dir string) (map[string]interface{}, []FragmentOverride, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to read config fragment dir %v: %w", dir, err)
+	}
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != fragmentSuffix {
+			continue
+		}
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	fragments := make([]Fragment, 0, len(names))
+	for _, name := range names {
+		path := filepath.Join(dir, name)
+		content, err := util.GetJsonMapFromFile(path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to load config fragment %v: %w", path, err)
+		}
+		fragments = append(fragments, Fragment{Name: name, Content: content})
+	}
+	return MergeFragments(fragments)
+}
+
+// Fragment is a single JSON config file to be merged.
+type Fragment struct {
+	Name    string
+	Content map[string]interface{}
+}
+
+/**
+ * MergeFragments merges the fragments in the given order, follow below rules
+ * 1. Objects are merged key by key.
+ * 2. Arrays (e.g. collect_list) are concatenated in fragment order.
+ * 3. Scalars are last-wins; every override is returned so it can be reported.
+ * 4. A key that is an object/array in one fragment and a different kind in another is a conflict and fails the merge.
+ */
+func MergeFragments(fragments []Fragment) (map[string]interface{}, []FragmentOverride, error) {
+	m := fragmentMerger{
+		result:  map[string]interface{}{},
+		sources: map[string]string{},
+	}
+	for _, fragment := range fragments {
+		if err := m.mergeMap(fragment.Name, "", fragment.Content, m.result); err != nil {
+			return nil, m.overrides, err
+		}
+	}
+	return m.result, m.overrides, nil
+}
+
+type fragmentMerger struct {
+	result    map[string]interface{}
+	overrides []FragmentOverride
+	// sources tracks which fragment last set each path
+	sources map[string]string
+}
+
+func (m *fragmentMerger) mergeMap(file, path string, source, dest map[string]interface{}) error {
+	keys := make([]string, 0, len(source))
+	for key := range source {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := source[key]
+		keyPath := joinFragmentPath(path, key)
+		existing, ok := dest[key]
+		if !ok {
+			if v, isMap := value.(map[string]interface{}); isMap {
+				// merge into an empty object so the source of every nested key is tracked
+				existing = map[string]interface{}{}
+				dest[key] = existing
+				if err := m.mergeMap(file, keyPath, v, existing.(map[string]interface{})); err != nil {
+					return err
+				}
+			} else {
+				dest[key] = copyFragmentValue(value)
+			}
+			m.sources[keyPath] = file
+			continue
+		}
+		switch v := value.(type) {
+		case map[string]interface{}:
+			existingMap, ok := existing.(map[string]interface{})
+			if !ok {
+				return m.conflict(file, keyPath, existing, value)
+			}
+			if err := m.mergeMap(file, keyPath, v, existingMap); err != nil {
+				return err
+			}
+		case []interface{}:
+			existingList, ok := existing.([]interface{})
+			if !ok {
+				return m.conflict(file, keyPath, existing, value)
+			}
+			dest[key] = append(existingList, copyFragmentValue(v).([]interface{})...)
+		default:
+			switch existing.(type) {
+			case map[string]interface{}, []interface{}:
+				return m.conflict(file, keyPath, existing, value)
+			}
+			if !reflect.DeepEqual(existing, value) {
+				m.overrides = append(m.overrides, FragmentOverride{
+					Path:          keyPath,
+					PreviousFile:  m.sources[keyPath],
+					PreviousValue: existing,
+					File:          file,
+					Value:         value,
+				})
+			}
+			dest[key] = value
+		}
+		m.sources[keyPath] = file
+	}
+	return nil
+}
+
+func (m *fragmentMerger) conflict(file, path string, existing, value interface{}) error {
+	return fmt.Errorf("conflicting types for %s: %s defined in %s but %s defined in %s",
+		path, fragmentKind(existing), m.sources[path], fragmentKind(value), file)
+}
+
+func fragmentKind(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	default:
+		return "scalar"
+	}
+}
+
+func joinFragmentPath(path, key string) string {
+	if path == "" {
+		return "/" + key
+	}
+	return strings.Join([]string{path, key}, "/")
+}
+
+// copyFragmentValue deep copies objects and arrays so later merges do not
+// modify the source fragments.
+func copyFragmentValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(v))
+		for key, val := range v {
+			result[key] = copyFragmentValue(val)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(v))
+		for i, val := range v {
+			result[i] = copyFragmentValue(val)
+		}
+		return result
+	default:
+		return v
+	}
+}
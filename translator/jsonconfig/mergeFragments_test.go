@@ -0,0 +1,81 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package jsonconfig
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadFragmentDir(t *testing.T) {
+	got, overrides, err := LoadFragmentDir("./sampleJsonConfig/fragments")
+	require.NoError(t, err)
+	want := map[string]interface{}{
+		"agent": map[string]interface{}{
+			"metrics_collection_interval": float64(10),
+			"region":                      "us-west-2",
+		},
+		"logs": map[string]interface{}{
+			"logs_collected": map[string]interface{}{
+				"files": map[string]interface{}{
+					"collect_list": []interface{}{
+						map[string]interface{}{
+							"file_path":      "/var/log/messages",
+							"log_group_name": "messages",
+						},
+						map[string]interface{}{
+							"file_path":      "/var/log/app.log",
+							"log_group_name": "app",
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, want, got)
+	require.Len(t, overrides, 1)
+	assert.Equal(t, FragmentOverride{
+		Path:          "/agent/metrics_collection_interval",
+		PreviousFile:  "10-base.json",
+		PreviousValue: float64(60),
+		File:          "20-app.json",
+		Value:         float64(10),
+	}, overrides[0])
+}
+
+func TestLoadFragmentDirIgnoresOtherFiles(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.json"), []byte(`{"agent": {"region": "us-east-1"}}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.yaml"), []byte(`not json`), 0600))
+	require.NoError(t, os.Mkdir(filepath.Join(dir, "c.json"), 0700))
+	got, overrides, err := LoadFragmentDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, overrides)
+	assert.Equal(t, map[string]interface{}{"agent": map[string]interface{}{"region": "us-east-1"}}, got)
+}
+
+func TestMergeFragmentsConflict(t *testing.T) {
+	fragments := []Fragment{
+		{Name: "a.json", Content: map[string]interface{}{"agent": map[string]interface{}{"region": "us-east-1"}}},
+		{Name: "b.json", Content: map[string]interface{}{"agent": "us-west-2"}},
+	}
+	_, _, err := MergeFragments(fragments)
+	assert.EqualError(t, err, "conflicting types for /agent: object defined in a.json but scalar defined in b.json")
+}
+
+func TestMergeFragmentsDoesNotModifyInput(t *testing.T) {
+	list := []interface{}{"a"}
+	fragments := []Fragment{
+		{Name: "a.json", Content: map[string]interface{}{"list": list}},
+		{Name: "b.json", Content: map[string]interface{}{"list": []interface{}{"b"}}},
+	}
+	got, _, err := MergeFragments(fragments)
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"a", "b"}, got["list"])
+	assert.Equal(t, []interface{}{"a"}, list)
+}
@@ -0,0 +1,49 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package jsonconfig
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+)
+
+/**
+ * ApplyPlatformConfig selects the block of the json config for the os, e.g. "linux": {...}, and merges it into the
+ * common config, so that a single config can be shipped to a mixed fleet. The block is merged like a later fragment
+ * in MergeFragments, so its objects are merged key by key, its arrays are appended and its scalars take precedence.
+ * The blocks of the other platforms are dropped.
+ */
+func ApplyPlatformConfig(jsonConfigMap map[string]interface{}, osType string) (map[string]interface{}, error) {
+	common := make(map[string]interface{}, len(jsonConfigMap))
+	var platform map[string]interface{}
+	for key, value := range jsonConfigMap {
+		if !isPlatformKey(key) {
+			common[key] = value
+			continue
+		}
+		block, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("platform config /%s must be an object but is a %s", key, fragmentKind(value))
+		}
+		if key == osType {
+			platform = block
+		}
+	}
+	if len(common) == len(jsonConfigMap) {
+		return jsonConfigMap, nil
+	}
+	if platform == nil {
+		return common, nil
+	}
+	result, _, err := MergeFragments([]Fragment{
+		{Name: "common config", Content: common},
+		{Name: osType + " config", Content: platform},
+	})
+	return result, err
+}
+
+func isPlatformKey(key string) bool {
+	return key == config.OS_TYPE_LINUX || key == config.OS_TYPE_WINDOWS || key == config.OS_TYPE_DARWIN
+}
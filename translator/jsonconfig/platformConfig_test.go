@@ -0,0 +1,107 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package jsonconfig
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+	"github.com/aws/amazon-cloudwatch-agent/translator/util"
+)
+
+const platformJsonConfig = `{
+	"agent": {"metrics_collection_interval": 60, "region": "us-west-2"},
+	"metrics": {"metrics_collected": {"mem": {"measurement": ["used_percent"]}}},
+	"logs": {"logs_collected": {"files": {"collect_list": [{"file_path": "/opt/app/app.log", "log_group_name": "app"}]}}},
+	"linux": {
+		"metrics": {"metrics_collected": {"disk": {"measurement": ["used_percent"], "resources": ["/"]}}},
+		"logs": {"logs_collected": {"files": {"collect_list": [{"file_path": "/var/log/messages", "log_group_name": "messages"}]}}}
+	},
+	"windows": {
+		"agent": {"metrics_collection_interval": 30},
+		"metrics": {"metrics_collected": {"LogicalDisk": {"measurement": ["% Free Space"], "resources": ["*"]}}},
+		"logs": {"logs_collected": {"windows_events": {"collect_list": [{"event_name": "System", "event_levels": ["ERROR"], "log_group_name": "system"}]}}}
+	}
+}`
+
+func TestApplyPlatformConfig(t *testing.T) {
+	appLog := map[string]interface{}{"file_path": "/opt/app/app.log", "log_group_name": "app"}
+	mem := map[string]interface{}{"measurement": []interface{}{"used_percent"}}
+	testCases := map[string]map[string]interface{}{
+		config.OS_TYPE_LINUX: {
+			"agent": map[string]interface{}{"metrics_collection_interval": float64(60), "region": "us-west-2"},
+			"metrics": map[string]interface{}{"metrics_collected": map[string]interface{}{
+				"mem":  mem,
+				"disk": map[string]interface{}{"measurement": []interface{}{"used_percent"}, "resources": []interface{}{"/"}},
+			}},
+			"logs": map[string]interface{}{"logs_collected": map[string]interface{}{"files": map[string]interface{}{"collect_list": []interface{}{
+				appLog,
+				map[string]interface{}{"file_path": "/var/log/messages", "log_group_name": "messages"},
+			}}}},
+		},
+		config.OS_TYPE_WINDOWS: {
+			"agent": map[string]interface{}{"metrics_collection_interval": float64(30), "region": "us-west-2"},
+			"metrics": map[string]interface{}{"metrics_collected": map[string]interface{}{
+				"mem":         mem,
+				"LogicalDisk": map[string]interface{}{"measurement": []interface{}{"% Free Space"}, "resources": []interface{}{"*"}},
+			}},
+			"logs": map[string]interface{}{"logs_collected": map[string]interface{}{
+				"files": map[string]interface{}{"collect_list": []interface{}{appLog}},
+				"windows_events": map[string]interface{}{"collect_list": []interface{}{
+					map[string]interface{}{"event_name": "System", "event_levels": []interface{}{"ERROR"}, "log_group_name": "system"},
+				}},
+			}},
+		},
+		// only the common config applies without a block for the platform
+		config.OS_TYPE_DARWIN: {
+			"agent":   map[string]interface{}{"metrics_collection_interval": float64(60), "region": "us-west-2"},
+			"metrics": map[string]interface{}{"metrics_collected": map[string]interface{}{"mem": mem}},
+			"logs":    map[string]interface{}{"logs_collected": map[string]interface{}{"files": map[string]interface{}{"collect_list": []interface{}{appLog}}}},
+		},
+	}
+	for osType, want := range testCases {
+		t.Run(osType, func(t *testing.T) {
+			input, err := util.GetJsonMapFromJsonBytes([]byte(platformJsonConfig))
+			require.NoError(t, err)
+			got, err := ApplyPlatformConfig(input, osType)
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+
+	t.Run("GOOS", func(t *testing.T) {
+		input, err := util.GetJsonMapFromJsonBytes([]byte(platformJsonConfig))
+		require.NoError(t, err)
+		osType := config.ToValidOs(runtime.GOOS)
+		got, err := ApplyPlatformConfig(input, osType)
+		require.NoError(t, err)
+		assert.Equal(t, testCases[osType], got)
+	})
+}
+
+func TestApplyPlatformConfigWithoutBlocks(t *testing.T) {
+	input := map[string]interface{}{"agent": map[string]interface{}{"region": "us-west-2"}}
+	got, err := ApplyPlatformConfig(input, config.OS_TYPE_LINUX)
+	require.NoError(t, err)
+	assert.Equal(t, input, got)
+}
+
+func TestApplyPlatformConfigErrors(t *testing.T) {
+	_, err := ApplyPlatformConfig(map[string]interface{}{"linux": []interface{}{}}, config.OS_TYPE_LINUX)
+	assert.EqualError(t, err, "platform config /linux must be an object but is a array")
+
+	// a block of another platform must also be an object
+	_, err = ApplyPlatformConfig(map[string]interface{}{"windows": "agent"}, config.OS_TYPE_LINUX)
+	assert.Error(t, err)
+
+	_, err = ApplyPlatformConfig(map[string]interface{}{
+		"logs":  map[string]interface{}{"log_stream_name": "stream"},
+		"linux": map[string]interface{}{"logs": map[string]interface{}{"log_stream_name": map[string]interface{}{}}},
+	}, config.OS_TYPE_LINUX)
+	assert.EqualError(t, err, "conflicting types for /logs/log_stream_name: scalar defined in common config but object defined in linux config")
+}
@@ -0,0 +1,125 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package translator
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// RuleStats counts the outcome of applying a rule type while translating the config. Warnings are the warning
+// messages added by the rule, e.g. an invalid log_group_class that is ignored.
+type RuleStats struct {
+	Applied  int
+	Warnings int
+	Errors   int
+}
+
+func (s RuleStats) String() string {
+	return fmt.Sprintf("%d applied, %d warnings, %d errors", s.Applied, s.Warnings, s.Errors)
+}
+
+func (s *RuleStats) add(other RuleStats) {
+	s.Applied += other.Applied
+	s.Warnings += other.Warnings
+	s.Errors += other.Errors
+}
+
+var (
+	ruleStats = map[string]*RuleStats{}
+	// ruleStack holds the messages added by the nested rules of each rule being applied, so that messages
+	// are only counted against the rule that added them.
+	ruleStack []*RuleStats
+)
+
+// TrackRule wraps the rule so that its outcome is counted in the rule stats under the name of its type.
+func TrackRule(r Rule) Rule {
+	if r == nil {
+		return r
+	}
+	if _, ok := r.(*trackedRule); ok {
+		return r
+	}
+	return &trackedRule{Rule: r, name: ruleTypeName(r)}
+}
+
+type trackedRule struct {
+	Rule
+	name string
+}
+
+func (t *trackedRule) ApplyRule(input interface{}) (string, interface{}) {
+	errorsBefore, warningsBefore := len(ErrorMessages), len(WarningMessages)
+	nested := &RuleStats{}
+	ruleStack = append(ruleStack, nested)
+	key, val := t.Rule.ApplyRule(input)
+	ruleStack = ruleStack[:len(ruleStack)-1]
+
+	added := RuleStats{
+		Warnings: max(len(WarningMessages)-warningsBefore, 0),
+		Errors:   max(len(ErrorMessages)-errorsBefore, 0),
+	}
+	if len(ruleStack) > 0 {
+		ruleStack[len(ruleStack)-1].add(added)
+	}
+	stats, ok := ruleStats[t.name]
+	if !ok {
+		stats = &RuleStats{}
+		ruleStats[t.name] = stats
+	}
+	own := RuleStats{
+		Warnings: max(added.Warnings-nested.Warnings, 0),
+		Errors:   max(added.Errors-nested.Errors, 0),
+	}
+	if own.Errors == 0 && key != "" {
+		own.Applied = 1
+	}
+	stats.add(own)
+	return key, val
+}
+
+// GetRuleStats returns the totals across all rule types along with the counts per rule type.
+func GetRuleStats() (RuleStats, map[string]RuleStats) {
+	var total RuleStats
+	byType := make(map[string]RuleStats, len(ruleStats))
+	for name, stats := range ruleStats {
+		total.add(*stats)
+		byType[name] = *stats
+	}
+	return total, byType
+}
+
+// RuleStatsSummary returns the totals across all rule types followed by the counts of each rule type.
+func RuleStatsSummary() string {
+	total, byType := GetRuleStats()
+	if len(byType) == 0 {
+		return total.String()
+	}
+	names := make([]string, 0, len(byType))
+	for name := range byType {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	counts := make([]string, 0, len(names))
+	for _, name := range names {
+		counts = append(counts, fmt.Sprintf("%s (%v)", name, byType[name]))
+	}
+	return fmt.Sprintf("%v; %s", total, strings.Join(counts, ", "))
+}
+
+// ResetRuleStats clears the rule stats. Used for testing purpose.
+func ResetRuleStats() {
+	ruleStats = map[string]*RuleStats{}
+	ruleStack = nil
+}
+
+func ruleTypeName(r Rule) string {
+	t := reflect.TypeOf(r)
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Name()
+}
@@ -0,0 +1,113 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package translator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stubRule struct {
+	key      string
+	warnings int
+	errors   int
+	nested   []Rule
+}
+
+func (r *stubRule) ApplyRule(input interface{}) (string, interface{}) {
+	for _, nested := range r.nested {
+		nested.ApplyRule(input)
+	}
+	for i := 0; i < r.warnings; i++ {
+		AddWarningMessages("", "warning")
+	}
+	for i := 0; i < r.errors; i++ {
+		AddErrorMessages("", "error")
+	}
+	return r.key, nil
+}
+
+type otherStubRule struct {
+	stubRule
+}
+
+type infoStubRule struct{}
+
+func (r *infoStubRule) ApplyRule(interface{}) (string, interface{}) {
+	AddInfoMessages("", "info")
+	return "info", nil
+}
+
+func TestTrackRule(t *testing.T) {
+	ResetMessages()
+	ResetRuleStats()
+	t.Cleanup(func() {
+		ResetMessages()
+		ResetRuleStats()
+	})
+
+	parent := TrackRule(&stubRule{
+		key:    "parent",
+		errors: 1,
+		nested: []Rule{
+			TrackRule(&otherStubRule{stubRule{key: "valid"}}),
+			TrackRule(&otherStubRule{stubRule{key: "warning", warnings: 2}}),
+			TrackRule(&otherStubRule{stubRule{key: "invalid", errors: 1}}),
+			TrackRule(&otherStubRule{stubRule{key: ""}}),
+		},
+	})
+	parent.ApplyRule(nil)
+	TrackRule(&stubRule{key: "other"}).ApplyRule(nil)
+
+	total, byType := GetRuleStats()
+	assert.Equal(t, RuleStats{Applied: 3, Warnings: 2, Errors: 2}, total)
+	assert.Equal(t, map[string]RuleStats{
+		"stubRule":      {Applied: 1, Errors: 1},
+		"otherStubRule": {Applied: 2, Warnings: 2, Errors: 1},
+	}, byType)
+	assert.Equal(t, "3 applied, 2 warnings, 2 errors", total.String())
+	assert.Equal(t, "3 applied, 2 warnings, 2 errors; otherStubRule (2 applied, 2 warnings, 1 errors), stubRule (1 applied, 0 warnings, 1 errors)", RuleStatsSummary())
+}
+
+func TestRuleStatsSummaryWithoutIssues(t *testing.T) {
+	ResetMessages()
+	ResetRuleStats()
+	t.Cleanup(ResetRuleStats)
+
+	assert.Equal(t, "0 applied, 0 warnings, 0 errors", RuleStatsSummary())
+	TrackRule(&stubRule{key: "valid"}).ApplyRule(nil)
+	TrackRule(&otherStubRule{stubRule{key: "valid"}}).ApplyRule(nil)
+	assert.Equal(t, "2 applied, 0 warnings, 0 errors; otherStubRule (1 applied, 0 warnings, 0 errors), stubRule (1 applied, 0 warnings, 0 errors)", RuleStatsSummary())
+}
+
+func TestTrackRuleIgnoresInfoMessages(t *testing.T) {
+	ResetMessages()
+	ResetRuleStats()
+	t.Cleanup(func() {
+		ResetMessages()
+		ResetRuleStats()
+	})
+
+	TrackRule(&infoStubRule{}).ApplyRule(nil)
+	total, _ := GetRuleStats()
+	assert.Equal(t, RuleStats{Applied: 1}, total)
+}
+
+func TestDefaultLogGroupClassCaseWarning(t *testing.T) {
+	ResetMessages()
+	t.Cleanup(ResetMessages)
+
+	_, val := DefaultLogGroupClassCase("log_group_class", "", map[string]interface{}{"log_group_class": "invalid"})
+	assert.Equal(t, "", val)
+	assert.Len(t, WarningMessages, 1)
+	assert.Empty(t, InfoMessages)
+	assert.Empty(t, ErrorMessages)
+}
+
+func TestTrackRuleIsIdempotent(t *testing.T) {
+	r := TrackRule(&stubRule{})
+	assert.Same(t, r, TrackRule(r))
+	assert.Nil(t, TrackRule(nil))
+}
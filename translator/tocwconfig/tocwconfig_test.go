@@ -294,6 +294,14 @@ func TestOtlpMetricsConfig(t *testing.T) {
 	checkTranslation(t, "otlp_metrics_config", "windows", nil, "")
 }
 
+func TestOtlpLogsConfig(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "otlp_logs_config", "linux", nil, "")
+	checkTranslation(t, "otlp_logs_config", "darwin", nil, "")
+	checkTranslation(t, "otlp_logs_config", "windows", nil, "")
+}
+
 func TestOtlpMetricsConfigKubernetes(t *testing.T) {
 	resetContext(t)
 	context.CurrentContext().SetMode(config.ModeEC2)
@@ -339,6 +347,30 @@ func TestWindowsEventOnlyConfig(t *testing.T) {
 	checkTranslation(t, "windows_eventlog_only_config", "windows", expectedEnvVars, "")
 }
 
+func TestWindowsETWOnlyConfig(t *testing.T) {
+	resetContext(t)
+	expectedEnvVars := map[string]string{}
+	checkTranslation(t, "windows_etw_only_config", "windows", expectedEnvVars, "")
+}
+
+func TestDockerLogsOnlyConfig(t *testing.T) {
+	resetContext(t)
+	expectedEnvVars := map[string]string{}
+	checkTranslation(t, "docker_logs_only_config", "linux", expectedEnvVars, "")
+}
+
+func TestSyslogOnlyConfig(t *testing.T) {
+	resetContext(t)
+	expectedEnvVars := map[string]string{}
+	checkTranslation(t, "syslog_only_config", "linux", expectedEnvVars, "")
+}
+
+func TestKubernetesLogsOnlyConfig(t *testing.T) {
+	resetContext(t)
+	expectedEnvVars := map[string]string{}
+	checkTranslation(t, "kubernetes_logs_only_config", "linux", expectedEnvVars, "")
+}
+
 func TestStatsDConfig(t *testing.T) {
 	testCases := map[string]testCase{
 		"linux": {
@@ -738,6 +770,74 @@ func TestDeltaNetConfigLinux(t *testing.T) {
 	checkTranslation(t, "delta_net_config_linux", "darwin", nil, "")
 }
 
+func TestAdaptiveIntervalConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "adaptive_interval_config_linux", "linux", nil, "")
+	checkTranslation(t, "adaptive_interval_config_linux", "darwin", nil, "")
+}
+
+func TestKubeletSummaryConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "kubelet_summary_config_linux", "linux", nil, "")
+}
+
+func TestDerivedMetricsConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "derived_metrics_config_linux", "linux", nil, "")
+}
+
+func TestMetricSplitConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "metric_split_config_linux", "linux", nil, "")
+}
+
+func TestApplicationVersionConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "application_version_config_linux", "linux", nil, "")
+}
+
+func TestDimensionInheritanceConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "dimension_inheritance_config_linux", "linux", nil, "")
+}
+
+func TestDimensionLimitConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "dimension_limit_config_linux", "linux", nil, "")
+}
+
+func TestHistogramStatsConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "histogram_stats_config_linux", "linux", nil, "")
+}
+
+func TestSemanticConventionsConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "semantic_conventions_config_linux", "linux", nil, "")
+}
+
+func TestTimestreamConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "timestream_config_linux", "linux", nil, "")
+}
+
+func TestHeartbeatConfigLinux(t *testing.T) {
+	resetContext(t)
+	context.CurrentContext().SetMode(config.ModeEC2)
+	checkTranslation(t, "heartbeat_config_linux", "linux", nil, "")
+	checkTranslation(t, "heartbeat_config_linux", "darwin", nil, "")
+}
+
 func TestECSNodeMetricConfig(t *testing.T) {
 	resetContext(t)
 	context.CurrentContext().SetRunInContainer(true)
@@ -37,6 +37,7 @@ type (
 		DiskIo          []diskioConfig
 		Ethtool         []ethtoolConfig
 		K8sapiserver    []k8sApiServerConfig
+		KubeletSummary  []kubeletSummaryConfig `toml:"kubelet_summary"`
 		Logfile         []logFileConfig
 		Mem             []memConfig
 		Net             []netConfig
@@ -49,6 +50,7 @@ type (
 		Statsd          []statsdConfig
 		Swap            []swapConfig
 		WindowsEventLog []windowsEventLogConfig `toml:"windows_event_log"`
+		WindowsETW      []windowsETWConfig      `toml:"windows_etw"`
 	}
 
 	outputConfig struct {
@@ -140,6 +142,13 @@ type (
 		Tags     map[string]string
 	}
 
+	kubeletSummaryConfig struct {
+		HostIP   string `toml:"host_ip"`
+		Interval string
+		Port     string
+		Tags     map[string]string
+	}
+
 	memConfig struct {
 		FieldPass []string
 		Interval  string
@@ -242,6 +251,28 @@ type (
 		Tags            map[string]string
 	}
 
+	windowsETWConfig struct {
+		Destination    string
+		BufferSizeKB   int                 `toml:"buffer_size_kb"`
+		MinimumBuffers int                 `toml:"minimum_buffers"`
+		MaximumBuffers int                 `toml:"maximum_buffers"`
+		QueueSize      int                 `toml:"queue_size"`
+		ProviderConfig []etwProviderConfig `toml:"provider_config"`
+	}
+
+	etwProviderConfig struct {
+		EventIDs        []int  `toml:"event_ids"`
+		Level           int    `toml:"level"`
+		LogGroupClass   string `toml:"log_group_class"`
+		LogGroupName    string `toml:"log_group_name"`
+		LogStreamName   string `toml:"log_stream_name"`
+		MatchAllKeyword string `toml:"match_all_keyword"`
+		MatchAnyKeyword string `toml:"match_any_keyword"`
+		ProviderGUID    string `toml:"provider_guid"`
+		ProviderName    string `toml:"provider_name"`
+		RetentionInDays int    `toml:"retention_in_days"`
+	}
+
 	// Output plugins
 
 	cloudWatchOutputConfig struct {
@@ -4,7 +4,10 @@
 package agent
 
 import (
+	"fmt"
+
 	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/context"
 	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonRule"
 	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonUtil"
 )
@@ -21,7 +24,7 @@ func GetCurPath() string {
 }
 
 func RegisterRule(fieldname string, r translator.Rule) {
-	ChildRule[fieldname] = r
+	ChildRule[fieldname] = translator.TrackRule(r)
 }
 
 type Agent struct {
@@ -56,6 +59,11 @@ func (a *Agent) ApplyRule(input interface{}) (returnKey string, returnVal interf
 		agentMap = map[string]interface{}{}
 	}
 	result = translator.ProcessRuleToApply(agentMap, ChildRule, result)
+	// the endpoint of every output is needed when the region cannot be resolved
+	if Global_Config.Region == "" && !hasEndpointOverride(m) {
+		translator.AddErrorMessages(GetCurPath()+"ruleRegion/", fmt.Sprintf("Region info is missing for mode: %s. "+
+			"Set the region in the agent section or the endpoint_override of the outputs", context.CurrentContext().Mode()))
+	}
 
 	returnKey = SectionKey
 	returnVal = result
@@ -68,6 +76,18 @@ func (a *Agent) Merge(source map[string]interface{}, result map[string]interface
 	mergeJsonUtil.MergeMap(source, result, SectionKey, MergeRuleMap, GetCurPath())
 }
 
+// hasEndpointOverride returns true if any section of the config sets an endpoint_override.
+func hasEndpointOverride(m map[string]interface{}) bool {
+	for _, section := range m {
+		if sectionMap, ok := section.(map[string]interface{}); ok {
+			if endpoint, ok := sectionMap["endpoint_override"].(string); ok && endpoint != "" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func init() {
 	// Not registering agent translator rule, we handle it differently in translate.go
 
@@ -5,6 +5,10 @@ package agent
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/aws-sdk-go/aws/endpoints"
 
 	"github.com/aws/amazon-cloudwatch-agent/translator"
 	"github.com/aws/amazon-cloudwatch-agent/translator/config"
@@ -20,21 +24,34 @@ const (
 	RegionType = "region_type"
 )
 
+// regionPattern matches region names such as us-east-1, us-gov-west-1 or eusc-de-east-1.
+var regionPattern = regexp.MustCompile(`^[a-z]{2,4}(-[a-z]+)+-\d+$`)
+
+var regionSources = map[string]string{
+	config.RegionTypeAgentConfigJson: "agent config",
+	config.RegionTypeCredsMap:        "credentials profile",
+	config.RegionTypeEnv:             "environment",
+	config.RegionTypeEC2Metadata:     "EC2 metadata",
+	config.RegionTypeECSMetadata:     "ECS metadata",
+}
+
 // This region will be provided to the corresponding input and output plugins
 // This should be applied before interpreting other component.
 func (r *Region) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
 	ctx := context.CurrentContext()
-	_, inputRegion := translator.DefaultCase(RegionKey, "", input)
-	if inputRegion != "" {
-		Global_Config.Region = inputRegion.(string)
-		Global_Config.RegionType = config.RegionTypeAgentConfigJson
-		return
+	var region, regionType string
+	if _, inputRegion := translator.DefaultCase(RegionKey, "", input); inputRegion != "" {
+		region, regionType = inputRegion.(string), config.RegionTypeAgentConfigJson
+	} else {
+		region, regionType = util.DetectRegion(ctx.Mode(), ctx.Credentials())
 	}
-	region, regionType := util.DetectRegion(ctx.Mode(), ctx.Credentials())
 
-	if region == "" {
-		translator.AddErrorMessages(GetCurPath()+"ruleRegion/", fmt.Sprintf("Region info is missing for mode: %s",
-			ctx.Mode()))
+	// a missing region is reported by the agent rule since it depends on the rest of the config
+	if region != "" {
+		fmt.Printf("I! Using region %s from %s\n", region, regionSources[regionType])
+		if err := validateRegion(region); err != nil {
+			translator.AddErrorMessages(GetCurPath()+"ruleRegion/", err.Error())
+		}
 	}
 
 	Global_Config.Region = region
@@ -42,6 +59,25 @@ func (r *Region) ApplyRule(input interface{}) (returnKey string, returnVal inter
 	return
 }
 
+// validateRegion returns an error if the region is not a region name. Regions that are not known to the SDK yet
+// are allowed since the agent can run in regions launched after it was built.
+func validateRegion(region string) error {
+	// placeholders for environment variables are resolved after the translation
+	if strings.Contains(region, "${") {
+		return nil
+	}
+	if !regionPattern.MatchString(region) {
+		return fmt.Errorf("region %q is not a valid region name", region)
+	}
+	for _, p := range endpoints.DefaultPartitions() {
+		if _, ok := p.Regions()[region]; ok {
+			return nil
+		}
+	}
+	fmt.Printf("W! Region %s is not a known region\n", region)
+	return nil
+}
+
 func init() {
 	r := new(Region)
 	RegisterRule(RegionKey, r)
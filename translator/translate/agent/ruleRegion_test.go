@@ -0,0 +1,113 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package agent
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+	"github.com/aws/amazon-cloudwatch-agent/translator/context"
+	"github.com/aws/amazon-cloudwatch-agent/translator/util"
+)
+
+func TestRegion(t *testing.T) {
+	detectRegion := util.DetectRegion
+	t.Cleanup(func() {
+		util.DetectRegion = detectRegion
+		translator.ResetMessages()
+	})
+
+	testCases := map[string]struct {
+		input          string
+		detectedRegion string
+		detectedType   string
+		wantRegion     string
+		wantRegionType string
+		wantErr        bool
+	}{
+		"WithConfig": {
+			input:          `{"agent":{"region":"us-west-2"}}`,
+			detectedRegion: "us-east-1",
+			detectedType:   config.RegionTypeEC2Metadata,
+			wantRegion:     "us-west-2",
+			wantRegionType: config.RegionTypeAgentConfigJson,
+		},
+		"WithCredsMap": {
+			input:          `{"agent":{}}`,
+			detectedRegion: "eu-west-1",
+			detectedType:   config.RegionTypeCredsMap,
+			wantRegion:     "eu-west-1",
+			wantRegionType: config.RegionTypeCredsMap,
+		},
+		"WithEnv": {
+			input:          `{"agent":{}}`,
+			detectedRegion: "ap-southeast-2",
+			detectedType:   config.RegionTypeEnv,
+			wantRegion:     "ap-southeast-2",
+			wantRegionType: config.RegionTypeEnv,
+		},
+		"WithEC2Metadata": {
+			input:          `{"agent":{}}`,
+			detectedRegion: "us-gov-west-1",
+			detectedType:   config.RegionTypeEC2Metadata,
+			wantRegion:     "us-gov-west-1",
+			wantRegionType: config.RegionTypeEC2Metadata,
+		},
+		"WithUnknownRegion": {
+			input:          `{"agent":{"region":"xx-central-9"}}`,
+			wantRegion:     "xx-central-9",
+			wantRegionType: config.RegionTypeAgentConfigJson,
+		},
+		"WithSovereignCloudRegion": {
+			input:          `{"agent":{"region":"eusc-de-east-1"}}`,
+			wantRegion:     "eusc-de-east-1",
+			wantRegionType: config.RegionTypeAgentConfigJson,
+		},
+		"WithPlaceholder": {
+			input:          `{"agent":{"region":"${ENV_REGION}"}}`,
+			wantRegion:     "${ENV_REGION}",
+			wantRegionType: config.RegionTypeAgentConfigJson,
+		},
+		"WithInvalidRegion": {
+			input:          `{"agent":{"region":"US East"}}`,
+			wantRegion:     "US East",
+			wantRegionType: config.RegionTypeAgentConfigJson,
+			wantErr:        true,
+		},
+		"WithUnresolvable": {
+			input:          `{"agent":{}}`,
+			detectedType:   config.RegionTypeNotFound,
+			wantRegionType: config.RegionTypeNotFound,
+			wantErr:        true,
+		},
+		"WithUnresolvableAndEndpointOverride": {
+			input:          `{"agent":{},"logs":{"endpoint_override":"https://logs.example.com"}}`,
+			detectedType:   config.RegionTypeNotFound,
+			wantRegionType: config.RegionTypeNotFound,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			translator.ResetMessages()
+			context.ResetContext()
+			context.CurrentContext().SetMode(config.ModeEC2)
+			translator.SetTargetPlatform(config.OS_TYPE_LINUX)
+			util.DetectRegion = func(string, map[string]string) (string, string) {
+				return testCase.detectedRegion, testCase.detectedType
+			}
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+
+			new(Agent).ApplyRule(input)
+			assert.Equal(t, testCase.wantRegion, Global_Config.Region)
+			assert.Equal(t, testCase.wantRegionType, Global_Config.RegionType)
+			assert.Equal(t, !testCase.wantErr, translator.IsTranslateSuccess(), translator.ErrorMessages)
+		})
+	}
+}
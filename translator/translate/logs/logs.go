@@ -26,7 +26,7 @@ func GetCurPath() string {
 }
 
 func RegisterRule(fieldname string, r Rule) {
-	ChildRule[fieldname] = r
+	ChildRule[fieldname] = translator.TrackRule(r)
 }
 
 type Logs struct {
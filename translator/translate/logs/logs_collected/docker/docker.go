@@ -0,0 +1,100 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package docker
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonRule"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonUtil"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected"
+	logUtil "github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/util"
+	translateUtil "github.com/aws/amazon-cloudwatch-agent/translator/translate/util"
+	"github.com/aws/amazon-cloudwatch-agent/translator/util"
+)
+
+const (
+	SectionKey       = "docker"
+	SectionMappedKey = "docker_logs"
+
+	CollectListKey          = "collect_list"
+	ContainerConfigTomlKey  = "container_config"
+	EndpointKey             = "endpoint"
+	RefreshIntervalKey      = "refresh_interval"
+	LogGroupNameKey         = "log_group_name"
+	LogStreamNameKey        = "log_stream_name"
+	LogGroupClassKey        = "log_group_class"
+	RetentionInDaysKey      = "retention_in_days"
+	ContainerNamePatternKey = "container_name_pattern"
+	ContainerLabelsKey      = "container_labels"
+
+	defaultEndpoint           = "unix:///var/run/docker.sock"
+	defaultRefreshIntervalSec = float64(10)
+)
+
+type Docker struct {
+}
+
+func GetCurPath() string {
+	return parent.GetCurPath() + SectionKey + "/"
+}
+
+func (d *Docker) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	im := input.(map[string]interface{})
+	section, ok := im[SectionKey].(map[string]interface{})
+	if !ok {
+		return "", ""
+	}
+	result := map[string]interface{}{
+		"destination": "cloudwatchlogs",
+	}
+	_, result[EndpointKey] = translator.DefaultCase(EndpointKey, defaultEndpoint, section)
+	_, result[RefreshIntervalKey] = translator.DefaultTimeIntervalCase(RefreshIntervalKey, defaultRefreshIntervalSec, section)
+
+	containerConfigs := []interface{}{}
+	if translator.IsValid(section, CollectListKey, GetCurPath()) {
+		for _, config := range section[CollectListKey].([]interface{}) {
+			containerConfigs = append(containerConfigs, getContainerConfig(config))
+		}
+	}
+	logUtil.ValidateLogGroupFields(containerConfigs, GetCurPath()+CollectListKey+"/")
+	result[ContainerConfigTomlKey] = containerConfigs
+
+	return "inputs", map[string]interface{}{
+		SectionMappedKey: []interface{}{result},
+	}
+}
+
+func getContainerConfig(input interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	util.SetWithSameKeyIfFound(input, []string{ContainerNamePatternKey, ContainerLabelsKey}, result)
+	for _, key := range []string{LogGroupNameKey, LogStreamNameKey} {
+		if _, val := translator.DefaultCase(key, "", input); val != "" {
+			result[key] = translateUtil.ResolvePlaceholder(val.(string), logs.GlobalLogConfig.MetadataInfo)
+		}
+	}
+	_, result[LogGroupClassKey] = translator.DefaultLogGroupClassCase(LogGroupClassKey, "", input)
+	_, result[RetentionInDaysKey] = translator.DefaultRetentionInDaysCase(RetentionInDaysKey, float64(-1), input)
+	return result
+}
+
+var MergeRuleMap = map[string]mergeJsonRule.MergeRule{}
+
+func (d *Docker) Merge(source map[string]interface{}, result map[string]interface{}) {
+	mergeJsonUtil.MergeMap(source, result, SectionKey, MergeRuleMap, GetCurPath())
+}
+
+type CollectList struct {
+}
+
+func (c *CollectList) Merge(source map[string]interface{}, result map[string]interface{}) {
+	mergeJsonUtil.MergeList(source, result, CollectListKey)
+}
+
+func init() {
+	obj := new(Docker)
+	parent.RegisterLinuxRule(SectionKey, obj)
+	parent.MergeRuleMap[SectionKey] = obj
+	MergeRuleMap[CollectListKey] = new(CollectList)
+}
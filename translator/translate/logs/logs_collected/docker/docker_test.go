@@ -0,0 +1,78 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package docker
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestApplyRule(t *testing.T) {
+	translator.ResetMessages()
+	d := new(Docker)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"docker": {
+			"refresh_interval": 30,
+			"collect_list": [
+				{
+					"container_name_pattern": "^web",
+					"container_labels": ["app=shop"],
+					"log_group_name": "docker/web",
+					"log_stream_name": "{container_name}",
+					"log_group_class": "infrequent_access",
+					"retention_in_days": 7
+				},
+				{
+					"log_group_name": "docker/other"
+				}
+			]
+		}
+	}`), &input))
+
+	key, actual := d.ApplyRule(input)
+	assert.Equal(t, "inputs", key)
+	assert.Equal(t, map[string]interface{}{
+		"docker_logs": []interface{}{
+			map[string]interface{}{
+				"destination":      "cloudwatchlogs",
+				"endpoint":         "unix:///var/run/docker.sock",
+				"refresh_interval": "30s",
+				"container_config": []interface{}{
+					map[string]interface{}{
+						"container_name_pattern": "^web",
+						"container_labels":       []interface{}{"app=shop"},
+						"log_group_name":         "docker/web",
+						"log_stream_name":        "{container_name}",
+						"log_group_class":        "INFREQUENT_ACCESS",
+						"retention_in_days":      7,
+					},
+					map[string]interface{}{
+						"log_group_name":    "docker/other",
+						"log_group_class":   "",
+						"retention_in_days": -1,
+					},
+				},
+			},
+		},
+	}, actual)
+	assert.Empty(t, translator.ErrorMessages)
+}
+
+func TestApplyRuleWithoutSection(t *testing.T) {
+	key, _ := new(Docker).ApplyRule(map[string]interface{}{})
+	assert.Equal(t, "", key)
+}
+
+func TestApplyRuleWithoutCollectList(t *testing.T) {
+	translator.ResetMessages()
+	_, actual := new(Docker).ApplyRule(map[string]interface{}{"docker": map[string]interface{}{}})
+	assert.Equal(t, []interface{}{}, actual.(map[string]interface{})["docker_logs"].([]interface{})[0].(map[string]interface{})["container_config"])
+	assert.Len(t, translator.ErrorMessages, 1)
+}
@@ -34,6 +34,9 @@ var Index = 0
 var ChildRule = map[string][]Rule{}
 
 func RegisterRule(fieldname string, r []Rule) {
+	for i := range r {
+		r[i] = translator.TrackRule(r[i])
+	}
 	ChildRule[fieldname] = r
 }
 
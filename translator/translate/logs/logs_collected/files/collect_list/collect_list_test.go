@@ -66,6 +66,35 @@ func TestFileConfigOverride(t *testing.T) {
 	assert.Equal(t, expectVal, val)
 }
 
+func TestFileConfigRuleStats(t *testing.T) {
+	translator.ResetMessages()
+	translator.ResetRuleStats()
+	t.Cleanup(func() {
+		translator.ResetMessages()
+		translator.ResetRuleStats()
+	})
+	f := translator.TrackRule(new(FileConfig))
+	var input interface{}
+	e := json.Unmarshal([]byte(`{"collect_list":[
+		{"file_path":"path1","log_group_name":"group1","log_group_class":"STANDARD","retention_in_days":7},
+		{"file_path":"path2","log_group_name":"group2","log_group_class":"INVALID"},
+		{"file_path":"path3","log_group_name":"group3","retention_in_days":2}
+	]}`), &input)
+	if e != nil {
+		assert.Fail(t, e.Error())
+	}
+	f.ApplyRule(input)
+
+	total, byType := translator.GetRuleStats()
+	assert.Equal(t, translator.RuleStats{Applied: 3, Warnings: 1}, byType["LogGroupClass"])
+	assert.Equal(t, translator.RuleStats{Applied: 2, Errors: 1}, byType["RetentionInDays"])
+	assert.Equal(t, translator.RuleStats{Applied: 3}, byType["FilePath"])
+	assert.Equal(t, translator.RuleStats{Applied: 1}, byType["FileConfig"])
+	assert.Equal(t, 1, total.Warnings)
+	assert.Equal(t, 1, total.Errors)
+	assert.Len(t, translator.ErrorMessages, 1)
+}
+
 func TestTimestampFormat(t *testing.T) {
 	f := new(FileConfig)
 	var input interface{}
@@ -1060,6 +1089,44 @@ func TestConflictingLogGroupClass(t *testing.T) {
 	assert.Equal(t, expectVal, val)
 }
 
+func TestLogGroupARN(t *testing.T) {
+	translator.ResetMessages()
+	f := new(FileConfig)
+	var input interface{}
+	e := json.Unmarshal([]byte(`{
+		"collect_list":[
+			{
+				"file_path":"path1",
+				"log_group_name":"arn:aws:logs:us-east-1:123456789012:log-group:central"
+			},
+			{
+				"file_path":"path2",
+				"log_group_name":"arn:aws:logs:us-east-1:123456789012:log-group:central:*"
+			}
+		]
+	}`), &input)
+	if e != nil {
+		assert.Fail(t, e.Error())
+	}
+	_, val := f.ApplyRule(input)
+	assert.Empty(t, translator.ErrorMessages)
+	assert.Equal(t, "arn:aws:logs:us-east-1:123456789012:log-group:central", val.([]interface{})[0].(map[string]interface{})["log_group_name"])
+
+	e = json.Unmarshal([]byte(`{
+		"collect_list":[
+			{
+				"file_path":"path1",
+				"log_group_name":"arn:aws:logs:us-east-1:1234:log-group:central"
+			}
+		]
+	}`), &input)
+	if e != nil {
+		assert.Fail(t, e.Error())
+	}
+	f.ApplyRule(input)
+	assert.Equal(t, "Under path : /logs/logs_collected/files/collect_list/ | Error : Invalid log group ARN, expected arn:<partition>:logs:<region>:<account-id>:log-group:<name>: arn:aws:logs:us-east-1:1234:log-group:central", translator.ErrorMessages[len(translator.ErrorMessages)-1])
+}
+
 func TestServiceAndEnvironment(t *testing.T) {
 	logs.GlobalLogConfig.DeploymentEnvironment = "ec2:default"
 
@@ -0,0 +1,37 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/util"
+)
+
+const (
+	EventPrefixSectionKey = "event_prefix"
+	EventSuffixSectionKey = "event_suffix"
+)
+
+// EventDecoration resolves the metadata placeholders (e.g. {hostname}) in the
+// text added to each log event. {file_name} is left for the logfile plugin to
+// resolve since it depends on the file being tailed.
+type EventDecoration struct {
+	key string
+}
+
+func (e *EventDecoration) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	key, val := translator.DefaultCase(e.key, "", input)
+	if val == "" {
+		return
+	}
+	returnKey = key
+	returnVal = util.ResolvePlaceholder(val.(string), logs.GlobalLogConfig.MetadataInfo)
+	return
+}
+
+func init() {
+	RegisterRule(EventPrefixSectionKey, []Rule{&EventDecoration{key: EventPrefixSectionKey}})
+	RegisterRule(EventSuffixSectionKey, []Rule{&EventDecoration{key: EventSuffixSectionKey}})
+}
@@ -0,0 +1,37 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
+)
+
+func TestApplyEventDecorationRule(t *testing.T) {
+	original := logs.GlobalLogConfig.MetadataInfo
+	defer func() { logs.GlobalLogConfig.MetadataInfo = original }()
+	logs.GlobalLogConfig.MetadataInfo = map[string]string{"{hostname}": "host-1"}
+
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"event_prefix": "[{hostname} {file_name}] ",
+		"event_suffix": " from {hostname}"
+	}`), &input))
+
+	key, val := (&EventDecoration{key: EventPrefixSectionKey}).ApplyRule(input)
+	assert.Equal(t, "event_prefix", key)
+	assert.Equal(t, "[host-1 {file_name}] ", val)
+	key, val = (&EventDecoration{key: EventSuffixSectionKey}).ApplyRule(input)
+	assert.Equal(t, "event_suffix", key)
+	assert.Equal(t, " from host-1", val)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"file_path": "/var/log/app.log"}`), &input))
+	key, _ = (&EventDecoration{key: EventPrefixSectionKey}).ApplyRule(input)
+	assert.Equal(t, "", key)
+}
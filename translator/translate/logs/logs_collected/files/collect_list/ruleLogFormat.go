@@ -0,0 +1,28 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const LogFormatSectionKey = "log_format"
+
+type LogFormat struct {
+}
+
+func (l *LogFormat) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	_, returnVal = translator.DefaultCase(LogFormatSectionKey, "", input)
+	if returnVal == "" {
+		return
+	}
+	returnKey = LogFormatSectionKey
+	return
+}
+
+func init() {
+	l := new(LogFormat)
+	r := []Rule{l}
+	RegisterRule(LogFormatSectionKey, r)
+}
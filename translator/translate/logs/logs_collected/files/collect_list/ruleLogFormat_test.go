@@ -0,0 +1,29 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyLogFormatRule(t *testing.T) {
+	r := new(LogFormat)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"log_format": "json_array"}`), &input))
+	actualReturnKey, actualReturnVal := r.ApplyRule(input)
+	assert.Equal(t, "log_format", actualReturnKey)
+	assert.Equal(t, "json_array", actualReturnVal)
+}
+
+func TestApplyLogFormatRuleNotSet(t *testing.T) {
+	r := new(LogFormat)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"file_path": "/var/log/app.json"}`), &input))
+	actualReturnKey, _ := r.ApplyRule(input)
+	assert.Equal(t, "", actualReturnKey)
+}
@@ -0,0 +1,28 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const OversizeEventActionSectionKey = "oversize_event_action"
+
+type OversizeEventAction struct {
+}
+
+func (o *OversizeEventAction) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	_, returnVal = translator.DefaultCase(OversizeEventActionSectionKey, "", input)
+	if returnVal == "" {
+		return
+	}
+	returnKey = OversizeEventActionSectionKey
+	return
+}
+
+func init() {
+	o := new(OversizeEventAction)
+	r := []Rule{o}
+	RegisterRule(OversizeEventActionSectionKey, r)
+}
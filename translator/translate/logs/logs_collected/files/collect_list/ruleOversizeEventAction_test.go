@@ -0,0 +1,29 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyOversizeEventActionRule(t *testing.T) {
+	r := new(OversizeEventAction)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"oversize_event_action": "split"}`), &input))
+	actualReturnKey, actualReturnVal := r.ApplyRule(input)
+	assert.Equal(t, "oversize_event_action", actualReturnKey)
+	assert.Equal(t, "split", actualReturnVal)
+}
+
+func TestApplyOversizeEventActionRuleNotSet(t *testing.T) {
+	r := new(OversizeEventAction)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"file_path": "/var/log/app.log"}`), &input))
+	actualReturnKey, _ := r.ApplyRule(input)
+	assert.Equal(t, "", actualReturnKey)
+}
@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const (
+	RateLimitSectionKey                = "rate_limit"
+	RateLimitEventsPerSecondSectionKey = "events_per_second"
+	RateLimitBytesPerSecondSectionKey  = "bytes_per_second"
+	RateLimitActionSectionKey          = "action"
+)
+
+// RateLimit caps the events per second and/or bytes per second that are published for the files of the entry. The
+// events above the limit are dropped, or buffered by pausing the tailing of the files.
+type RateLimit struct {
+}
+
+func (r *RateLimit) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	val, ok := input.(map[string]interface{})[RateLimitSectionKey]
+	if !ok {
+		return
+	}
+	rateLimit, ok := val.(map[string]interface{})
+	if !ok {
+		translator.AddErrorMessages(GetCurPath()+RateLimitSectionKey, "rate limit is not an object")
+		return
+	}
+	res := map[string]interface{}{}
+	if eventsPerSecond, ok := rateLimit[RateLimitEventsPerSecondSectionKey].(float64); ok && eventsPerSecond > 0 {
+		res[RateLimitEventsPerSecondSectionKey] = eventsPerSecond
+	}
+	if bytesPerSecond, ok := rateLimit[RateLimitBytesPerSecondSectionKey].(float64); ok && bytesPerSecond > 0 {
+		res[RateLimitBytesPerSecondSectionKey] = int(bytesPerSecond)
+	}
+	if len(res) == 0 {
+		translator.AddErrorMessages(GetCurPath()+RateLimitSectionKey, "rate limit requires events_per_second or bytes_per_second")
+		return
+	}
+	if _, action := translator.DefaultCase(RateLimitActionSectionKey, "", rateLimit); action != "" {
+		res[RateLimitActionSectionKey] = action
+	}
+	return RateLimitSectionKey, res
+}
+
+func init() {
+	RegisterRule(RateLimitSectionKey, []Rule{new(RateLimit)})
+}
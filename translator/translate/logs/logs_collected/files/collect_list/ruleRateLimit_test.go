@@ -0,0 +1,56 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestApplyRateLimitRule(t *testing.T) {
+	translator.ResetMessages()
+	defer translator.ResetMessages()
+	testCases := map[string]struct {
+		input   string
+		wantKey string
+		wantVal interface{}
+		wantErr bool
+	}{
+		"WithEventsPerSecond": {
+			input:   `{"rate_limit": {"events_per_second": 0.5}}`,
+			wantKey: "rate_limit",
+			wantVal: map[string]interface{}{"events_per_second": 0.5},
+		},
+		"WithAllLimits": {
+			input:   `{"rate_limit": {"events_per_second": 100, "bytes_per_second": 1048576, "action": "buffer"}}`,
+			wantKey: "rate_limit",
+			wantVal: map[string]interface{}{"events_per_second": float64(100), "bytes_per_second": 1048576, "action": "buffer"},
+		},
+		"WithoutLimit": {
+			input:   `{"rate_limit": {"action": "drop"}}`,
+			wantErr: true,
+		},
+		"WithoutRateLimit": {
+			input: `{"file_path": "/var/log/app.log"}`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			translator.ResetMessages()
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			key, val := new(RateLimit).ApplyRule(input)
+			assert.Equal(t, testCase.wantKey, key)
+			if testCase.wantKey != "" {
+				assert.Equal(t, testCase.wantVal, val)
+			}
+			assert.Equal(t, testCase.wantErr, len(translator.ErrorMessages) > 0)
+		})
+	}
+}
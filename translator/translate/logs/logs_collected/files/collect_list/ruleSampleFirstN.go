@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list
+
+const SampleFirstNSectionKey = "sample_first_n"
+
+// SampleFirstN publishes only the first N events of each file of the entry, read from the beginning of the file,
+// after which the file is no longer read.
+type SampleFirstN struct {
+}
+
+func (s *SampleFirstN) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	val, ok := input.(map[string]interface{})[SampleFirstNSectionKey].(float64)
+	if !ok || val < 1 {
+		return
+	}
+	return SampleFirstNSectionKey, int(val)
+}
+
+func init() {
+	RegisterRule(SampleFirstNSectionKey, []Rule{new(SampleFirstN)})
+}
@@ -0,0 +1,29 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplySampleFirstNRule(t *testing.T) {
+	r := new(SampleFirstN)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"sample_first_n": 100}`), &input))
+	actualReturnKey, actualReturnVal := r.ApplyRule(input)
+	assert.Equal(t, "sample_first_n", actualReturnKey)
+	assert.Equal(t, 100, actualReturnVal)
+}
+
+func TestApplySampleFirstNRuleNotSet(t *testing.T) {
+	r := new(SampleFirstN)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"file_path": "/var/log/app.log"}`), &input))
+	actualReturnKey, _ := r.ApplyRule(input)
+	assert.Equal(t, "", actualReturnKey)
+}
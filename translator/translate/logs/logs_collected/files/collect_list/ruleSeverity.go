@@ -0,0 +1,100 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const (
+	MinSeveritySectionKey     = "min_severity"
+	SeverityFieldSectionKey   = "severity_field"
+	SeverityFieldsMappedKey   = "severity_fields"
+	SeverityPatternSectionKey = "severity_pattern"
+)
+
+// MinSeverity sets the severity below which log events are dropped. It is a level name, e.g. "WARN", or a syslog
+// severity from 0 (emergency) to 7 (debug).
+type MinSeverity struct {
+}
+
+func (m *MinSeverity) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	val, ok := input.(map[string]interface{})[MinSeveritySectionKey]
+	if !ok {
+		return
+	}
+	switch v := val.(type) {
+	case string:
+		if v == "" {
+			return
+		}
+		return MinSeveritySectionKey, v
+	case float64:
+		if v != float64(int(v)) || v < 0 || v > 7 {
+			translator.AddErrorMessages(GetCurPath()+MinSeveritySectionKey, fmt.Sprintf("min severity %v is not a syslog severity from 0 to 7", v))
+			return
+		}
+		return MinSeveritySectionKey, strconv.Itoa(int(v))
+	default:
+		translator.AddErrorMessages(GetCurPath()+MinSeveritySectionKey, fmt.Sprintf("min severity %v is not a string or a number", v))
+		return
+	}
+}
+
+// SeverityFields sets the fields of JSON log events that the severity is read from. The field can be a single field
+// or a list of candidate fields, the first of which is found is used.
+type SeverityFields struct {
+}
+
+func (s *SeverityFields) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	val, ok := input.(map[string]interface{})[SeverityFieldSectionKey]
+	if !ok {
+		return
+	}
+	var fields []string
+	switch v := val.(type) {
+	case string:
+		fields = []string{v}
+	case []interface{}:
+		for _, field := range v {
+			f, ok := field.(string)
+			if !ok {
+				translator.AddErrorMessages(GetCurPath()+SeverityFieldSectionKey, fmt.Sprintf("severity field %v is not a string", field))
+				return
+			}
+			fields = append(fields, f)
+		}
+	}
+	for _, field := range fields {
+		if field == "" {
+			translator.AddErrorMessages(GetCurPath()+SeverityFieldSectionKey, "severity field cannot be empty")
+			return
+		}
+	}
+	if len(fields) == 0 {
+		return
+	}
+	return SeverityFieldsMappedKey, fields
+}
+
+// SeverityPattern sets the regex that matches the severity of text log events.
+type SeverityPattern struct {
+}
+
+func (s *SeverityPattern) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	key, val := translator.DefaultCase(SeverityPatternSectionKey, "", input)
+	if val == "" {
+		return
+	}
+	return key, val
+}
+
+func init() {
+	RegisterRule(MinSeveritySectionKey, []Rule{new(MinSeverity)})
+	RegisterRule(SeverityFieldSectionKey, []Rule{new(SeverityFields)})
+	RegisterRule(SeverityPatternSectionKey, []Rule{new(SeverityPattern)})
+}
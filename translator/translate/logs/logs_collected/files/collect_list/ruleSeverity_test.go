@@ -0,0 +1,88 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestApplySeverityRules(t *testing.T) {
+	translator.ResetMessages()
+	defer translator.ResetMessages()
+	testCases := map[string]struct {
+		rule    Rule
+		input   string
+		wantKey string
+		wantVal interface{}
+		wantErr bool
+	}{
+		"WithLevelName": {
+			rule:    new(MinSeverity),
+			input:   `{"min_severity": "WARN"}`,
+			wantKey: "min_severity",
+			wantVal: "WARN",
+		},
+		"WithSyslogSeverity": {
+			rule:    new(MinSeverity),
+			input:   `{"min_severity": 4}`,
+			wantKey: "min_severity",
+			wantVal: "4",
+		},
+		"WithInvalidSyslogSeverity": {
+			rule:    new(MinSeverity),
+			input:   `{"min_severity": 8}`,
+			wantErr: true,
+		},
+		"WithoutMinSeverity": {
+			rule:  new(MinSeverity),
+			input: `{"file_path": "/var/log/app.log"}`,
+		},
+		"WithField": {
+			rule:    new(SeverityFields),
+			input:   `{"severity_field": "level"}`,
+			wantKey: "severity_fields",
+			wantVal: []string{"level"},
+		},
+		"WithCandidateFields": {
+			rule:    new(SeverityFields),
+			input:   `{"severity_field": ["level", "log.severity"]}`,
+			wantKey: "severity_fields",
+			wantVal: []string{"level", "log.severity"},
+		},
+		"WithEmptyField": {
+			rule:    new(SeverityFields),
+			input:   `{"severity_field": ["level", ""]}`,
+			wantErr: true,
+		},
+		"WithPattern": {
+			rule:    new(SeverityPattern),
+			input:   `{"severity_pattern": "level=(\\w+)"}`,
+			wantKey: "severity_pattern",
+			wantVal: `level=(\w+)`,
+		},
+		"WithoutPattern": {
+			rule:  new(SeverityPattern),
+			input: `{"file_path": "/var/log/app.log"}`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			translator.ResetMessages()
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			key, val := testCase.rule.ApplyRule(input)
+			assert.Equal(t, testCase.wantKey, key)
+			if testCase.wantKey != "" {
+				assert.Equal(t, testCase.wantVal, val)
+			}
+			assert.Equal(t, testCase.wantErr, len(translator.ErrorMessages) > 0)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const (
+	TimestampFieldSectionKey = "timestamp_field"
+	TimestampFieldsMappedKey = "timestamp_fields"
+)
+
+// TimestampFields sets the fields of JSON log events that the timestamp is read from. The field can be a single
+// field or a list of candidate fields, the first of which with a valid timestamp is used. The timestamp_format, if
+// any, is used to parse the field.
+type TimestampFields struct {
+}
+
+func (t *TimestampFields) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	val, ok := m[TimestampFieldSectionKey]
+	if !ok {
+		return
+	}
+	var fields []string
+	switch v := val.(type) {
+	case string:
+		fields = []string{v}
+	case []interface{}:
+		for _, field := range v {
+			s, ok := field.(string)
+			if !ok {
+				translator.AddErrorMessages(GetCurPath()+TimestampFieldSectionKey, fmt.Sprintf("timestamp field %v is not a string", field))
+				return
+			}
+			fields = append(fields, s)
+		}
+	}
+	for _, field := range fields {
+		if field == "" {
+			translator.AddErrorMessages(GetCurPath()+TimestampFieldSectionKey, "timestamp field cannot be empty")
+			return
+		}
+	}
+	if len(fields) == 0 {
+		return
+	}
+	return TimestampFieldsMappedKey, fields
+}
+
+func init() {
+	RegisterRule(TimestampFieldSectionKey, []Rule{new(TimestampFields)})
+}
@@ -0,0 +1,61 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collect_list
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestApplyTimestampFieldsRule(t *testing.T) {
+	translator.ResetMessages()
+	defer translator.ResetMessages()
+	r := new(TimestampFields)
+	testCases := map[string]struct {
+		input   string
+		wantKey string
+		wantVal interface{}
+		wantErr bool
+	}{
+		"WithField": {
+			input:   `{"timestamp_field": "@timestamp"}`,
+			wantKey: "timestamp_fields",
+			wantVal: []string{"@timestamp"},
+		},
+		"WithCandidateFields": {
+			input:   `{"timestamp_field": ["@timestamp", "log.time"]}`,
+			wantKey: "timestamp_fields",
+			wantVal: []string{"@timestamp", "log.time"},
+		},
+		"WithEmptyField": {
+			input:   `{"timestamp_field": ["@timestamp", ""]}`,
+			wantErr: true,
+		},
+		"WithNonStringField": {
+			input:   `{"timestamp_field": [1]}`,
+			wantErr: true,
+		},
+		"WithoutField": {
+			input: `{"file_path": "/var/log/app.log"}`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			translator.ResetMessages()
+			var input interface{}
+			require.NoError(t, json.Unmarshal([]byte(testCase.input), &input))
+			key, val := r.ApplyRule(input)
+			assert.Equal(t, testCase.wantKey, key)
+			if testCase.wantKey != "" {
+				assert.Equal(t, testCase.wantVal, val)
+			}
+			assert.Equal(t, testCase.wantErr, len(translator.ErrorMessages) > 0)
+		})
+	}
+}
@@ -4,17 +4,21 @@
 package files
 
 import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/util"
 )
 
+const FileStateFolderSectionKey = "file_state_folder"
+
 type FileStateFolder struct {
 }
 
-// FileStateFolder is internal value, not exposing to customer
+// FileStateFolder defaults to the state folder of the agent, but can be moved when that folder is not writable,
+// e.g. on read-only or SELinux confined hosts, or to keep the state of two agents on one host apart.
 func (f *FileStateFolder) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
-	return "file_state_folder", util.GetFileStateFolder()
+	return translator.DefaultCase(FileStateFolderSectionKey, util.GetFileStateFolder(), input)
 }
 func init() {
 	f := new(FileStateFolder)
-	RegisterRule("file_state_folder", f)
+	RegisterRule(FileStateFolderSectionKey, f)
 }
@@ -0,0 +1,32 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package files
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/util"
+)
+
+func TestApplyFileStateFolderRule(t *testing.T) {
+	r := new(FileStateFolder)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"file_state_folder": "/var/lib/amazon-cloudwatch-agent/state"}`), &input))
+	actualReturnKey, actualReturnVal := r.ApplyRule(input)
+	assert.Equal(t, "file_state_folder", actualReturnKey)
+	assert.Equal(t, "/var/lib/amazon-cloudwatch-agent/state", actualReturnVal)
+}
+
+func TestApplyFileStateFolderRuleNotSet(t *testing.T) {
+	r := new(FileStateFolder)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"collect_list": []}`), &input))
+	actualReturnKey, actualReturnVal := r.ApplyRule(input)
+	assert.Equal(t, "file_state_folder", actualReturnKey)
+	assert.Equal(t, util.GetFileStateFolder(), actualReturnVal)
+}
@@ -0,0 +1,102 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kubernetes
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonRule"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonUtil"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected"
+	logUtil "github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/util"
+	translateUtil "github.com/aws/amazon-cloudwatch-agent/translator/translate/util"
+	"github.com/aws/amazon-cloudwatch-agent/translator/util"
+)
+
+const (
+	SectionKey       = "kubernetes"
+	SectionMappedKey = "kubernetes_logs"
+
+	CollectListKey          = "collect_list"
+	PodConfigTomlKey        = "pod_config"
+	KubeConfigPathKey       = "kubeconfig_path"
+	NodeNameKey             = "node_name"
+	RefreshIntervalKey      = "refresh_interval"
+	LogGroupNameKey         = "log_group_name"
+	LogStreamNameKey        = "log_stream_name"
+	LogGroupClassKey        = "log_group_class"
+	RetentionInDaysKey      = "retention_in_days"
+	NamespaceKey            = "namespace"
+	LabelSelectorKey        = "label_selector"
+	PodNamePatternKey       = "pod_name_pattern"
+	ContainerNamePatternKey = "container_name_pattern"
+
+	defaultRefreshIntervalSec = float64(10)
+)
+
+type Kubernetes struct {
+}
+
+func GetCurPath() string {
+	return parent.GetCurPath() + SectionKey + "/"
+}
+
+func (k *Kubernetes) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	im := input.(map[string]interface{})
+	section, ok := im[SectionKey].(map[string]interface{})
+	if !ok {
+		return "", ""
+	}
+	result := map[string]interface{}{
+		"destination": "cloudwatchlogs",
+	}
+	util.SetWithSameKeyIfFound(section, []string{KubeConfigPathKey, NodeNameKey}, result)
+	_, result[RefreshIntervalKey] = translator.DefaultTimeIntervalCase(RefreshIntervalKey, defaultRefreshIntervalSec, section)
+
+	podConfigs := []interface{}{}
+	if translator.IsValid(section, CollectListKey, GetCurPath()) {
+		for _, config := range section[CollectListKey].([]interface{}) {
+			podConfigs = append(podConfigs, getPodConfig(config))
+		}
+	}
+	logUtil.ValidateLogGroupFields(podConfigs, GetCurPath()+CollectListKey+"/")
+	result[PodConfigTomlKey] = podConfigs
+
+	return "inputs", map[string]interface{}{
+		SectionMappedKey: []interface{}{result},
+	}
+}
+
+func getPodConfig(input interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	util.SetWithSameKeyIfFound(input, []string{NamespaceKey, LabelSelectorKey, PodNamePatternKey, ContainerNamePatternKey}, result)
+	for _, key := range []string{LogGroupNameKey, LogStreamNameKey} {
+		if _, val := translator.DefaultCase(key, "", input); val != "" {
+			result[key] = translateUtil.ResolvePlaceholder(val.(string), logs.GlobalLogConfig.MetadataInfo)
+		}
+	}
+	_, result[LogGroupClassKey] = translator.DefaultLogGroupClassCase(LogGroupClassKey, "", input)
+	_, result[RetentionInDaysKey] = translator.DefaultRetentionInDaysCase(RetentionInDaysKey, float64(-1), input)
+	return result
+}
+
+var MergeRuleMap = map[string]mergeJsonRule.MergeRule{}
+
+func (k *Kubernetes) Merge(source map[string]interface{}, result map[string]interface{}) {
+	mergeJsonUtil.MergeMap(source, result, SectionKey, MergeRuleMap, GetCurPath())
+}
+
+type CollectList struct {
+}
+
+func (c *CollectList) Merge(source map[string]interface{}, result map[string]interface{}) {
+	mergeJsonUtil.MergeList(source, result, CollectListKey)
+}
+
+func init() {
+	obj := new(Kubernetes)
+	parent.RegisterLinuxRule(SectionKey, obj)
+	parent.MergeRuleMap[SectionKey] = obj
+	MergeRuleMap[CollectListKey] = new(CollectList)
+}
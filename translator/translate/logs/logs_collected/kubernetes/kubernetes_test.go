@@ -0,0 +1,83 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kubernetes
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestApplyRule(t *testing.T) {
+	translator.ResetMessages()
+	k := new(Kubernetes)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"kubernetes": {
+			"node_name": "node-1",
+			"refresh_interval": 30,
+			"collect_list": [
+				{
+					"namespace": "default",
+					"label_selector": "app=shop",
+					"pod_name_pattern": "^web",
+					"container_name_pattern": "^nginx$",
+					"log_group_name": "kubernetes/web",
+					"log_stream_name": "{pod_name}_{container_name}",
+					"log_group_class": "infrequent_access",
+					"retention_in_days": 7
+				},
+				{
+					"log_group_name": "kubernetes/other"
+				}
+			]
+		}
+	}`), &input))
+
+	key, actual := k.ApplyRule(input)
+	assert.Equal(t, "inputs", key)
+	assert.Equal(t, map[string]interface{}{
+		"kubernetes_logs": []interface{}{
+			map[string]interface{}{
+				"destination":      "cloudwatchlogs",
+				"node_name":        "node-1",
+				"refresh_interval": "30s",
+				"pod_config": []interface{}{
+					map[string]interface{}{
+						"namespace":              "default",
+						"label_selector":         "app=shop",
+						"pod_name_pattern":       "^web",
+						"container_name_pattern": "^nginx$",
+						"log_group_name":         "kubernetes/web",
+						"log_stream_name":        "{pod_name}_{container_name}",
+						"log_group_class":        "INFREQUENT_ACCESS",
+						"retention_in_days":      7,
+					},
+					map[string]interface{}{
+						"log_group_name":    "kubernetes/other",
+						"log_group_class":   "",
+						"retention_in_days": -1,
+					},
+				},
+			},
+		},
+	}, actual)
+	assert.Empty(t, translator.ErrorMessages)
+}
+
+func TestApplyRuleWithoutSection(t *testing.T) {
+	key, _ := new(Kubernetes).ApplyRule(map[string]interface{}{})
+	assert.Equal(t, "", key)
+}
+
+func TestApplyRuleWithoutCollectList(t *testing.T) {
+	translator.ResetMessages()
+	_, actual := new(Kubernetes).ApplyRule(map[string]interface{}{"kubernetes": map[string]interface{}{}})
+	assert.Equal(t, []interface{}{}, actual.(map[string]interface{})["kubernetes_logs"].([]interface{})[0].(map[string]interface{})["pod_config"])
+	assert.Len(t, translator.ErrorMessages, 1)
+}
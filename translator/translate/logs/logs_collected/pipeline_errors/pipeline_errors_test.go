@@ -0,0 +1,72 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pipeline_errors
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestApplyRule(t *testing.T) {
+	translator.ResetMessages()
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"pipeline_errors": {
+			"log_group_name": "cloudwatch-agent-errors",
+			"log_stream_name": "fleet",
+			"log_group_class": "infrequent_access",
+			"retention_in_days": 7,
+			"level": "warn",
+			"max_events_per_minute": 10
+		}
+	}`), &input))
+
+	key, actual := new(PipelineErrors).ApplyRule(input)
+	assert.Equal(t, "inputs", key)
+	assert.Equal(t, map[string]interface{}{
+		"pipeline_errors": []interface{}{
+			map[string]interface{}{
+				"destination":           "cloudwatchlogs",
+				"log_group_name":        "cloudwatch-agent-errors",
+				"log_stream_name":       "fleet",
+				"log_group_class":       "INFREQUENT_ACCESS",
+				"retention_in_days":     7,
+				"level":                 "warn",
+				"max_events_per_minute": 10,
+			},
+		},
+	}, actual)
+	assert.Empty(t, translator.ErrorMessages)
+}
+
+func TestApplyRuleDefaults(t *testing.T) {
+	translator.ResetMessages()
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"pipeline_errors": {"log_group_name": "cloudwatch-agent-errors"}}`), &input))
+
+	_, actual := new(PipelineErrors).ApplyRule(input)
+	assert.Equal(t, map[string]interface{}{
+		"pipeline_errors": []interface{}{
+			map[string]interface{}{
+				"destination":           "cloudwatchlogs",
+				"log_group_name":        "cloudwatch-agent-errors",
+				"log_group_class":       "",
+				"retention_in_days":     -1,
+				"level":                 "error",
+				"max_events_per_minute": 60,
+			},
+		},
+	}, actual)
+	assert.Empty(t, translator.ErrorMessages)
+}
+
+func TestApplyRuleWithoutSection(t *testing.T) {
+	key, _ := new(PipelineErrors).ApplyRule(map[string]interface{}{})
+	assert.Equal(t, "", key)
+}
@@ -0,0 +1,76 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package spot_interruption
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonRule"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonUtil"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected"
+	logUtil "github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/util"
+	translateUtil "github.com/aws/amazon-cloudwatch-agent/translator/translate/util"
+)
+
+const (
+	SectionKey       = "spot_interruption"
+	SectionMappedKey = "spot_interruption"
+
+	LogGroupNameKey    = "log_group_name"
+	LogStreamNameKey   = "log_stream_name"
+	LogGroupClassKey   = "log_group_class"
+	RetentionInDaysKey = "retention_in_days"
+	PollIntervalKey    = "poll_interval"
+	EmitMetricKey      = "emit_metric"
+	MetricNamespaceKey = "metric_namespace"
+
+	defaultPollIntervalSec = float64(5)
+	defaultMetricNamespace = "CWAgent"
+)
+
+type SpotInterruption struct {
+}
+
+func GetCurPath() string {
+	return parent.GetCurPath() + SectionKey + "/"
+}
+
+func (s *SpotInterruption) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	im := input.(map[string]interface{})
+	section, ok := im[SectionKey].(map[string]interface{})
+	if !ok {
+		return "", ""
+	}
+	result := map[string]interface{}{
+		"destination": "cloudwatchlogs",
+	}
+	for _, key := range []string{LogGroupNameKey, LogStreamNameKey} {
+		if _, val := translator.DefaultCase(key, "", section); val != "" {
+			result[key] = translateUtil.ResolvePlaceholder(val.(string), logs.GlobalLogConfig.MetadataInfo)
+		}
+	}
+	_, result[LogGroupClassKey] = translator.DefaultLogGroupClassCase(LogGroupClassKey, "", section)
+	_, result[RetentionInDaysKey] = translator.DefaultRetentionInDaysCase(RetentionInDaysKey, float64(-1), section)
+	_, result[PollIntervalKey] = translator.DefaultTimeIntervalCase(PollIntervalKey, defaultPollIntervalSec, section)
+	_, result[EmitMetricKey] = translator.DefaultCase(EmitMetricKey, false, section)
+	_, result[MetricNamespaceKey] = translator.DefaultCase(MetricNamespaceKey, defaultMetricNamespace, section)
+	logUtil.ValidateLogGroupFields([]interface{}{result}, GetCurPath())
+
+	return "inputs", map[string]interface{}{
+		SectionMappedKey: []interface{}{result},
+	}
+}
+
+var MergeRuleMap = map[string]mergeJsonRule.MergeRule{}
+
+func (s *SpotInterruption) Merge(source map[string]interface{}, result map[string]interface{}) {
+	mergeJsonUtil.MergeMap(source, result, SectionKey, MergeRuleMap, GetCurPath())
+}
+
+func init() {
+	obj := new(SpotInterruption)
+	parent.RegisterLinuxRule(SectionKey, obj)
+	parent.RegisterWindowsRule(SectionKey, obj)
+	parent.MergeRuleMap[SectionKey] = obj
+}
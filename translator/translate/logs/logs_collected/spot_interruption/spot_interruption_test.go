@@ -0,0 +1,75 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package spot_interruption
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestApplyRule(t *testing.T) {
+	translator.ResetMessages()
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"spot_interruption": {
+			"log_group_name": "spot-interruptions",
+			"log_stream_name": "fleet",
+			"log_group_class": "infrequent_access",
+			"retention_in_days": 7,
+			"poll_interval": 10,
+			"emit_metric": true,
+			"metric_namespace": "Spot"
+		}
+	}`), &input))
+
+	key, actual := new(SpotInterruption).ApplyRule(input)
+	assert.Equal(t, "inputs", key)
+	assert.Equal(t, map[string]interface{}{
+		"spot_interruption": []interface{}{
+			map[string]interface{}{
+				"destination":       "cloudwatchlogs",
+				"log_group_name":    "spot-interruptions",
+				"log_stream_name":   "fleet",
+				"log_group_class":   "INFREQUENT_ACCESS",
+				"retention_in_days": 7,
+				"poll_interval":     "10s",
+				"emit_metric":       true,
+				"metric_namespace":  "Spot",
+			},
+		},
+	}, actual)
+	assert.Empty(t, translator.ErrorMessages)
+}
+
+func TestApplyRuleDefaults(t *testing.T) {
+	translator.ResetMessages()
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"spot_interruption": {"log_group_name": "spot-interruptions"}}`), &input))
+
+	_, actual := new(SpotInterruption).ApplyRule(input)
+	assert.Equal(t, map[string]interface{}{
+		"spot_interruption": []interface{}{
+			map[string]interface{}{
+				"destination":       "cloudwatchlogs",
+				"log_group_name":    "spot-interruptions",
+				"log_group_class":   "",
+				"retention_in_days": -1,
+				"poll_interval":     "5s",
+				"emit_metric":       false,
+				"metric_namespace":  "CWAgent",
+			},
+		},
+	}, actual)
+	assert.Empty(t, translator.ErrorMessages)
+}
+
+func TestApplyRuleWithoutSection(t *testing.T) {
+	key, _ := new(SpotInterruption).ApplyRule(map[string]interface{}{})
+	assert.Equal(t, "", key)
+}
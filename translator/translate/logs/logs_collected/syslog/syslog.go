@@ -0,0 +1,92 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package syslog
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonRule"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonUtil"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected"
+	logUtil "github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/util"
+	translateUtil "github.com/aws/amazon-cloudwatch-agent/translator/translate/util"
+)
+
+const (
+	SectionKey       = "syslog"
+	SectionMappedKey = "syslog_logs"
+
+	CollectListKey     = "collect_list"
+	ServiceAddressKey  = "service_address"
+	LogGroupNameKey    = "log_group_name"
+	LogStreamNameKey   = "log_stream_name"
+	LogGroupClassKey   = "log_group_class"
+	RetentionInDaysKey = "retention_in_days"
+
+	defaultServiceAddress = "udp://127.0.0.1:514"
+)
+
+type Syslog struct {
+}
+
+func GetCurPath() string {
+	return parent.GetCurPath() + SectionKey + "/"
+}
+
+// ApplyRule creates a syslog_logs input for each entry of the collect list, since each of them listens on its own
+// address.
+func (s *Syslog) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	im := input.(map[string]interface{})
+	section, ok := im[SectionKey].(map[string]interface{})
+	if !ok {
+		return "", ""
+	}
+	listeners := []interface{}{}
+	if translator.IsValid(section, CollectListKey, GetCurPath()) {
+		for _, config := range section[CollectListKey].([]interface{}) {
+			listeners = append(listeners, getListenerConfig(config))
+		}
+	}
+	logUtil.ValidateLogGroupFields(listeners, GetCurPath()+CollectListKey+"/")
+	return "inputs", map[string]interface{}{
+		SectionMappedKey: listeners,
+	}
+}
+
+func getListenerConfig(input interface{}) map[string]interface{} {
+	result := map[string]interface{}{
+		"destination": "cloudwatchlogs",
+	}
+	_, result[ServiceAddressKey] = translator.DefaultCase(ServiceAddressKey, defaultServiceAddress, input)
+	for _, key := range []string{LogGroupNameKey, LogStreamNameKey} {
+		if _, val := translator.DefaultCase(key, "", input); val != "" {
+			result[key] = translateUtil.ResolvePlaceholder(val.(string), logs.GlobalLogConfig.MetadataInfo)
+		}
+	}
+	_, result[LogGroupClassKey] = translator.DefaultLogGroupClassCase(LogGroupClassKey, "", input)
+	_, result[RetentionInDaysKey] = translator.DefaultRetentionInDaysCase(RetentionInDaysKey, float64(-1), input)
+	return result
+}
+
+var MergeRuleMap = map[string]mergeJsonRule.MergeRule{}
+
+func (s *Syslog) Merge(source map[string]interface{}, result map[string]interface{}) {
+	mergeJsonUtil.MergeMap(source, result, SectionKey, MergeRuleMap, GetCurPath())
+}
+
+type CollectList struct {
+}
+
+func (c *CollectList) Merge(source map[string]interface{}, result map[string]interface{}) {
+	mergeJsonUtil.MergeList(source, result, CollectListKey)
+}
+
+func init() {
+	obj := new(Syslog)
+	parent.RegisterLinuxRule(SectionKey, obj)
+	parent.RegisterDarwinRule(SectionKey, obj)
+	parent.RegisterWindowsRule(SectionKey, obj)
+	parent.MergeRuleMap[SectionKey] = obj
+	MergeRuleMap[CollectListKey] = new(CollectList)
+}
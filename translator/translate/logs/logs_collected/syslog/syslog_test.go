@@ -0,0 +1,72 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package syslog
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestApplyRule(t *testing.T) {
+	translator.ResetMessages()
+	s := new(Syslog)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"syslog": {
+			"collect_list": [
+				{
+					"service_address": "udp://:514",
+					"log_group_name": "syslog/{syslog_app_name}",
+					"log_stream_name": "{syslog_hostname}",
+					"log_group_class": "infrequent_access",
+					"retention_in_days": 7
+				},
+				{
+					"service_address": "tcp://127.0.0.1:601",
+					"log_group_name": "syslog/tcp"
+				}
+			]
+		}
+	}`), &input))
+
+	key, actual := s.ApplyRule(input)
+	assert.Equal(t, "inputs", key)
+	assert.Equal(t, map[string]interface{}{
+		"syslog_logs": []interface{}{
+			map[string]interface{}{
+				"destination":       "cloudwatchlogs",
+				"service_address":   "udp://:514",
+				"log_group_name":    "syslog/{syslog_app_name}",
+				"log_stream_name":   "{syslog_hostname}",
+				"log_group_class":   "INFREQUENT_ACCESS",
+				"retention_in_days": 7,
+			},
+			map[string]interface{}{
+				"destination":       "cloudwatchlogs",
+				"service_address":   "tcp://127.0.0.1:601",
+				"log_group_name":    "syslog/tcp",
+				"log_group_class":   "",
+				"retention_in_days": -1,
+			},
+		},
+	}, actual)
+	assert.Empty(t, translator.ErrorMessages)
+}
+
+func TestApplyRuleWithoutSection(t *testing.T) {
+	key, _ := new(Syslog).ApplyRule(map[string]interface{}{})
+	assert.Equal(t, "", key)
+}
+
+func TestApplyRuleWithoutCollectList(t *testing.T) {
+	translator.ResetMessages()
+	_, actual := new(Syslog).ApplyRule(map[string]interface{}{"syslog": map[string]interface{}{}})
+	assert.Equal(t, []interface{}{}, actual.(map[string]interface{})["syslog_logs"])
+	assert.Len(t, translator.ErrorMessages, 1)
+}
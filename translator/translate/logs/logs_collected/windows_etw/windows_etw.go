@@ -0,0 +1,133 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package windows_etw
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonRule"
+	"github.com/aws/amazon-cloudwatch-agent/translator/jsonconfig/mergeJsonUtil"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected"
+	logUtil "github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/util"
+	translateUtil "github.com/aws/amazon-cloudwatch-agent/translator/translate/util"
+	"github.com/aws/amazon-cloudwatch-agent/translator/util"
+)
+
+const (
+	SectionKey       = "windows_etw"
+	SectionMappedKey = "windows_etw"
+
+	CollectListKey        = "collect_list"
+	ProviderConfigTomlKey = "provider_config"
+	BufferSizeKBKey       = "buffer_size_kb"
+	MinimumBuffersKey     = "minimum_buffers"
+	MaximumBuffersKey     = "maximum_buffers"
+	QueueSizeKey          = "queue_size"
+	ProviderGUIDKey       = "provider_guid"
+	ProviderNameKey       = "provider_name"
+	LevelKey              = "level"
+	MatchAnyKeywordKey    = "match_any_keyword"
+	MatchAllKeywordKey    = "match_all_keyword"
+	EventIDsKey           = "event_ids"
+	LogGroupNameKey       = "log_group_name"
+	LogStreamNameKey      = "log_stream_name"
+	LogGroupClassKey      = "log_group_class"
+	RetentionInDaysKey    = "retention_in_days"
+)
+
+// levelMapping maps the level names used by windows_events to the ETW trace levels.
+var levelMapping = map[string]int{
+	"CRITICAL":    1,
+	"ERROR":       2,
+	"WARNING":     3,
+	"INFORMATION": 4,
+	"VERBOSE":     5,
+}
+
+type WindowsETW struct {
+}
+
+func GetCurPath() string {
+	return parent.GetCurPath() + SectionKey + "/"
+}
+
+func (w *WindowsETW) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	im := input.(map[string]interface{})
+	section, ok := im[SectionKey].(map[string]interface{})
+	if !ok {
+		return "", ""
+	}
+	result := map[string]interface{}{
+		"destination": "cloudwatchlogs",
+	}
+	for _, key := range []string{BufferSizeKBKey, MinimumBuffersKey, MaximumBuffersKey, QueueSizeKey} {
+		if _, ok := section[key]; ok {
+			_, result[key] = translator.DefaultIntegralCase(key, nil, section)
+		}
+	}
+
+	providerConfigs := []interface{}{}
+	if translator.IsValid(section, CollectListKey, GetCurPath()) {
+		for _, config := range section[CollectListKey].([]interface{}) {
+			providerConfigs = append(providerConfigs, getProviderConfig(config))
+		}
+	}
+	logUtil.ValidateLogGroupFields(providerConfigs, GetCurPath()+CollectListKey+"/")
+	result[ProviderConfigTomlKey] = providerConfigs
+
+	return "inputs", map[string]interface{}{
+		SectionMappedKey: []interface{}{result},
+	}
+}
+
+func getProviderConfig(input interface{}) map[string]interface{} {
+	result := map[string]interface{}{}
+	util.SetWithSameKeyIfFound(input, []string{ProviderGUIDKey, ProviderNameKey, MatchAnyKeywordKey, MatchAllKeywordKey}, result)
+	if _, val := translator.DefaultCase(LevelKey, "", input); val != "" {
+		if level, ok := levelMapping[fmt.Sprint(val)]; ok {
+			result[LevelKey] = level
+		} else {
+			translator.AddErrorMessages(GetCurPath()+CollectListKey+"/"+LevelKey, fmt.Sprintf("Cannot find the mapping for ETW level %v.", val))
+		}
+	}
+	if eventIDs, ok := input.(map[string]interface{})[EventIDsKey].([]interface{}); ok {
+		ids := make([]int, 0, len(eventIDs))
+		for _, id := range eventIDs {
+			if floatVal, ok := id.(float64); ok {
+				ids = append(ids, int(floatVal))
+			}
+		}
+		result[EventIDsKey] = ids
+	}
+	for _, key := range []string{LogGroupNameKey, LogStreamNameKey} {
+		if _, val := translator.DefaultCase(key, "", input); val != "" {
+			result[key] = translateUtil.ResolvePlaceholder(val.(string), logs.GlobalLogConfig.MetadataInfo)
+		}
+	}
+	_, result[LogGroupClassKey] = translator.DefaultLogGroupClassCase(LogGroupClassKey, "", input)
+	_, result[RetentionInDaysKey] = translator.DefaultRetentionInDaysCase(RetentionInDaysKey, float64(-1), input)
+	return result
+}
+
+var MergeRuleMap = map[string]mergeJsonRule.MergeRule{}
+
+func (w *WindowsETW) Merge(source map[string]interface{}, result map[string]interface{}) {
+	mergeJsonUtil.MergeMap(source, result, SectionKey, MergeRuleMap, GetCurPath())
+}
+
+type CollectList struct {
+}
+
+func (c *CollectList) Merge(source map[string]interface{}, result map[string]interface{}) {
+	mergeJsonUtil.MergeList(source, result, CollectListKey)
+}
+
+func init() {
+	obj := new(WindowsETW)
+	parent.RegisterWindowsRule(SectionKey, obj)
+	parent.MergeRuleMap[SectionKey] = obj
+	MergeRuleMap[CollectListKey] = new(CollectList)
+}
@@ -0,0 +1,94 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package windows_etw
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestApplyRule(t *testing.T) {
+	translator.ResetMessages()
+	w := new(WindowsETW)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"windows_etw": {
+			"buffer_size_kb": 128,
+			"maximum_buffers": 32,
+			"collect_list": [
+				{
+					"provider_guid": "{22FB2CD6-0E7B-422B-A0C7-2FAD1FD0E716}",
+					"provider_name": "Microsoft-Windows-Kernel-Process",
+					"level": "INFORMATION",
+					"match_any_keyword": "0x10",
+					"event_ids": [1, 2],
+					"log_group_name": "etw/kernel-process",
+					"log_stream_name": "kernel-process",
+					"log_group_class": "infrequent_access",
+					"retention_in_days": 7
+				},
+				{
+					"provider_guid": "{1C95126E-7EEA-49A9-A3FE-A378B03DDB4D}",
+					"log_group_name": "etw/dns"
+				}
+			]
+		}
+	}`), &input))
+
+	key, actual := w.ApplyRule(input)
+	assert.Equal(t, "inputs", key)
+	assert.Equal(t, map[string]interface{}{
+		"windows_etw": []interface{}{
+			map[string]interface{}{
+				"destination":     "cloudwatchlogs",
+				"buffer_size_kb":  128,
+				"maximum_buffers": 32,
+				"provider_config": []interface{}{
+					map[string]interface{}{
+						"provider_guid":     "{22FB2CD6-0E7B-422B-A0C7-2FAD1FD0E716}",
+						"provider_name":     "Microsoft-Windows-Kernel-Process",
+						"level":             4,
+						"match_any_keyword": "0x10",
+						"event_ids":         []int{1, 2},
+						"log_group_name":    "etw/kernel-process",
+						"log_stream_name":   "kernel-process",
+						"log_group_class":   "INFREQUENT_ACCESS",
+						"retention_in_days": 7,
+					},
+					map[string]interface{}{
+						"provider_guid":     "{1C95126E-7EEA-49A9-A3FE-A378B03DDB4D}",
+						"log_group_name":    "etw/dns",
+						"log_group_class":   "",
+						"retention_in_days": -1,
+					},
+				},
+			},
+		},
+	}, actual)
+	assert.Empty(t, translator.ErrorMessages)
+}
+
+func TestApplyRuleWithInvalidLevel(t *testing.T) {
+	translator.ResetMessages()
+	_, actual := new(WindowsETW).ApplyRule(map[string]interface{}{
+		"windows_etw": map[string]interface{}{
+			"collect_list": []interface{}{
+				map[string]interface{}{"provider_guid": "{1C95126E-7EEA-49A9-A3FE-A378B03DDB4D}", "level": "SUCCESS", "log_group_name": "etw/dns"},
+			},
+		},
+	})
+	providerConfig := actual.(map[string]interface{})["windows_etw"].([]interface{})[0].(map[string]interface{})["provider_config"].([]interface{})[0]
+	assert.NotContains(t, providerConfig, "level")
+	assert.Len(t, translator.ErrorMessages, 1)
+}
+
+func TestApplyRuleWithoutSection(t *testing.T) {
+	key, _ := new(WindowsETW).ApplyRule(map[string]interface{}{})
+	assert.Equal(t, "", key)
+}
@@ -28,7 +28,7 @@ const (
 var ChildRule = map[string]Rule{}
 
 func RegisterRule(fieldname string, r Rule) {
-	ChildRule[fieldname] = r
+	ChildRule[fieldname] = translator.TrackRule(r)
 }
 
 type CollectList struct {
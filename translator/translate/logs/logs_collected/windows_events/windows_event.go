@@ -25,7 +25,7 @@ func GetCurPath() string {
 }
 
 func RegisterRule(ruleName string, r translator.Rule) {
-	ChildRule[ruleName] = r
+	ChildRule[ruleName] = translator.TrackRule(r)
 }
 
 func (w *WindowsEvent) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/aws/amazon-cloudwatch-agent/translator"
 	"github.com/aws/amazon-cloudwatch-agent/translator/config"
 	"github.com/aws/amazon-cloudwatch-agent/translator/context"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
@@ -201,6 +202,336 @@ func TestLogs_EndpointOverride(t *testing.T) {
 	ctx.SetMode(config.ModeEC2) //reset back to default mode
 }
 
+func TestLogs_EntityKeys(t *testing.T) {
+	l := new(Logs)
+	agent.Global_Config.Region = "us-east-1"
+	agent.Global_Config.RegionType = "any"
+
+	var input interface{}
+	err := json.Unmarshal([]byte(`{"logs":{"log_stream_name":"LOG_STREAM_NAME","entity_service_name_keys":["app","service.name"],"entity_environment_keys":["stage"]}}`), &input)
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+
+	context.CurrentContext().SetMode(config.ModeEC2)
+	_, actual := l.ApplyRule(input)
+	expected := map[string]interface{}{
+		"outputs": map[string]interface{}{
+			"cloudwatchlogs": []interface{}{
+				map[string]interface{}{
+					"region":                   "us-east-1",
+					"region_type":              "any",
+					"mode":                     "EC2",
+					"log_stream_name":          "LOG_STREAM_NAME",
+					"force_flush_interval":     "5s",
+					"entity_service_name_keys": []interface{}{"app", "service.name"},
+					"entity_environment_keys":  []interface{}{"stage"},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual, "Expected to be equal")
+}
+
+func TestLogs_ReconcileRetention(t *testing.T) {
+	l := new(Logs)
+	agent.Global_Config.Region = "us-east-1"
+	agent.Global_Config.RegionType = "any"
+
+	var input interface{}
+	err := json.Unmarshal([]byte(`{"logs":{"log_stream_name":"LOG_STREAM_NAME","reconcile_retention":false}}`), &input)
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+
+	context.CurrentContext().SetMode(config.ModeEC2)
+	_, actual := l.ApplyRule(input)
+	expected := map[string]interface{}{
+		"outputs": map[string]interface{}{
+			"cloudwatchlogs": []interface{}{
+				map[string]interface{}{
+					"region":               "us-east-1",
+					"region_type":          "any",
+					"mode":                 "EC2",
+					"log_stream_name":      "LOG_STREAM_NAME",
+					"force_flush_interval": "5s",
+					"reconcile_retention":  false,
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual, "Expected to be equal")
+}
+
+func TestLogs_ValidatePermissions(t *testing.T) {
+	l := new(Logs)
+	agent.Global_Config.Region = "us-east-1"
+	agent.Global_Config.RegionType = "any"
+
+	var input interface{}
+	err := json.Unmarshal([]byte(`{"logs":{"log_stream_name":"LOG_STREAM_NAME","validate_permissions":true}}`), &input)
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+
+	context.CurrentContext().SetMode(config.ModeEC2)
+	_, actual := l.ApplyRule(input)
+	expected := map[string]interface{}{
+		"outputs": map[string]interface{}{
+			"cloudwatchlogs": []interface{}{
+				map[string]interface{}{
+					"region":               "us-east-1",
+					"region_type":          "any",
+					"mode":                 "EC2",
+					"log_stream_name":      "LOG_STREAM_NAME",
+					"force_flush_interval": "5s",
+					"validate_permissions": true,
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual, "Expected to be equal")
+}
+
+func TestLogs_StreamConcurrency(t *testing.T) {
+	l := new(Logs)
+	agent.Global_Config.Region = "us-east-1"
+	agent.Global_Config.RegionType = "any"
+
+	var input interface{}
+	err := json.Unmarshal([]byte(`{"logs":{"log_stream_name":"LOG_STREAM_NAME","stream_concurrency":4}}`), &input)
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+
+	context.CurrentContext().SetMode(config.ModeEC2)
+	_, actual := l.ApplyRule(input)
+	expected := map[string]interface{}{
+		"outputs": map[string]interface{}{
+			"cloudwatchlogs": []interface{}{
+				map[string]interface{}{
+					"region":               "us-east-1",
+					"region_type":          "any",
+					"mode":                 "EC2",
+					"log_stream_name":      "LOG_STREAM_NAME",
+					"force_flush_interval": "5s",
+					"stream_concurrency":   4,
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual, "Expected to be equal")
+}
+
+func TestLogs_MaxEventRetries(t *testing.T) {
+	l := new(Logs)
+	agent.Global_Config.Region = "us-east-1"
+	agent.Global_Config.RegionType = "any"
+
+	var input interface{}
+	err := json.Unmarshal([]byte(`{"logs":{"log_stream_name":"LOG_STREAM_NAME","max_event_retries":5}}`), &input)
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+
+	context.CurrentContext().SetMode(config.ModeEC2)
+	_, actual := l.ApplyRule(input)
+	expected := map[string]interface{}{
+		"outputs": map[string]interface{}{
+			"cloudwatchlogs": []interface{}{
+				map[string]interface{}{
+					"region":               "us-east-1",
+					"region_type":          "any",
+					"mode":                 "EC2",
+					"log_stream_name":      "LOG_STREAM_NAME",
+					"force_flush_interval": "5s",
+					"max_event_retries":    5,
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual, "Expected to be equal")
+}
+
+func TestLogs_MaxInFlightRequests(t *testing.T) {
+	l := new(Logs)
+	agent.Global_Config.Region = "us-east-1"
+	agent.Global_Config.RegionType = "any"
+
+	var input interface{}
+	err := json.Unmarshal([]byte(`{"logs":{"log_stream_name":"LOG_STREAM_NAME","max_in_flight_requests":20}}`), &input)
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+
+	context.CurrentContext().SetMode(config.ModeEC2)
+	_, actual := l.ApplyRule(input)
+	expected := map[string]interface{}{
+		"outputs": map[string]interface{}{
+			"cloudwatchlogs": []interface{}{
+				map[string]interface{}{
+					"region":                 "us-east-1",
+					"region_type":            "any",
+					"mode":                   "EC2",
+					"log_stream_name":        "LOG_STREAM_NAME",
+					"force_flush_interval":   "5s",
+					"max_in_flight_requests": 20,
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual, "Expected to be equal")
+}
+
+func TestLogs_MaxCreateRequestsPerSecond(t *testing.T) {
+	l := new(Logs)
+	agent.Global_Config.Region = "us-east-1"
+	agent.Global_Config.RegionType = "any"
+
+	var input interface{}
+	err := json.Unmarshal([]byte(`{"logs":{"log_stream_name":"LOG_STREAM_NAME","max_create_requests_per_second":10}}`), &input)
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+
+	context.CurrentContext().SetMode(config.ModeEC2)
+	_, actual := l.ApplyRule(input)
+	expected := map[string]interface{}{
+		"outputs": map[string]interface{}{
+			"cloudwatchlogs": []interface{}{
+				map[string]interface{}{
+					"region":                         "us-east-1",
+					"region_type":                    "any",
+					"mode":                           "EC2",
+					"log_stream_name":                "LOG_STREAM_NAME",
+					"force_flush_interval":           "5s",
+					"max_create_requests_per_second": 10,
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual, "Expected to be equal")
+}
+
+func TestLogs_Destination(t *testing.T) {
+	l := new(Logs)
+	agent.Global_Config.Region = "us-east-1"
+	agent.Global_Config.RegionType = "any"
+	translator.ResetMessages()
+	defer translator.ResetMessages()
+
+	var input interface{}
+	err := json.Unmarshal([]byte(`{"logs":{"log_stream_name":"LOG_STREAM_NAME",
+		"destination_arn":"arn:aws:logs:eu-west-1:123456789012:log-group:central",
+		"destination_role_arn":"arn:aws:iam::123456789012:role/CloudWatchAgentLogs"}}`), &input)
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+
+	context.CurrentContext().SetMode(config.ModeEC2)
+	_, actual := l.ApplyRule(input)
+	expected := map[string]interface{}{
+		"outputs": map[string]interface{}{
+			"cloudwatchlogs": []interface{}{
+				map[string]interface{}{
+					"region":               "us-east-1",
+					"region_type":          "any",
+					"mode":                 "EC2",
+					"log_stream_name":      "LOG_STREAM_NAME",
+					"force_flush_interval": "5s",
+					"destination_arn":      "arn:aws:logs:eu-west-1:123456789012:log-group:central",
+					"destination_role_arn": "arn:aws:iam::123456789012:role/CloudWatchAgentLogs",
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual, "Expected to be equal")
+	assert.Empty(t, translator.ErrorMessages)
+
+	err = json.Unmarshal([]byte(`{"logs":{"log_stream_name":"LOG_STREAM_NAME",
+		"destination_arn":"arn:aws:logs:eu-west-1:123456789012:log-group:central"}}`), &input)
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+	l.ApplyRule(input)
+	assert.Len(t, translator.ErrorMessages, 1)
+}
+
+func TestLogs_DeadLetter(t *testing.T) {
+	l := new(Logs)
+	agent.Global_Config.Region = "us-east-1"
+	agent.Global_Config.RegionType = "any"
+
+	var input interface{}
+	err := json.Unmarshal([]byte(`{"logs":{"log_stream_name":"LOG_STREAM_NAME",
+		"deadletter_log_group":"deadletter","deadletter_max_events_per_minute":10}}`), &input)
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+
+	context.CurrentContext().SetMode(config.ModeEC2)
+	_, actual := l.ApplyRule(input)
+	expected := map[string]interface{}{
+		"outputs": map[string]interface{}{
+			"cloudwatchlogs": []interface{}{
+				map[string]interface{}{
+					"region":                           "us-east-1",
+					"region_type":                      "any",
+					"mode":                             "EC2",
+					"log_stream_name":                  "LOG_STREAM_NAME",
+					"force_flush_interval":             "5s",
+					"deadletter_log_group":             "deadletter",
+					"deadletter_max_events_per_minute": 10,
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual, "Expected to be equal")
+}
+
+func TestLogs_LogGroupClassRules(t *testing.T) {
+	l := new(Logs)
+	agent.Global_Config.Region = "us-east-1"
+	agent.Global_Config.RegionType = "any"
+	translator.ResetMessages()
+	defer translator.ResetMessages()
+
+	var input interface{}
+	err := json.Unmarshal([]byte(`{"logs":{"log_stream_name":"LOG_STREAM_NAME","log_group_class_rules":[
+		{"attribute":"Namespace","pattern":"^debug$","log_group_class":"infrequent_access"},
+		{"attribute":"Namespace","pattern":"(","log_group_class":"STANDARD"},
+		{"attribute":"Namespace","pattern":"prod","log_group_class":"archive"},
+		{"pattern":"prod","log_group_class":"STANDARD"}]}}`), &input)
+	if err != nil {
+		assert.Fail(t, err.Error())
+	}
+
+	context.CurrentContext().SetMode(config.ModeEC2)
+	_, actual := l.ApplyRule(input)
+	expected := map[string]interface{}{
+		"outputs": map[string]interface{}{
+			"cloudwatchlogs": []interface{}{
+				map[string]interface{}{
+					"region":               "us-east-1",
+					"region_type":          "any",
+					"mode":                 "EC2",
+					"log_stream_name":      "LOG_STREAM_NAME",
+					"force_flush_interval": "5s",
+					"log_group_class_rules": []interface{}{
+						map[string]interface{}{
+							"attribute":       "Namespace",
+							"pattern":         "^debug$",
+							"log_group_class": "INFREQUENT_ACCESS",
+						},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, actual, "Expected to be equal")
+	assert.Len(t, translator.ErrorMessages, 3)
+}
+
 func TestLogs_ServiceAndEnvironment(t *testing.T) {
 	l := new(Logs)
 	agent.Global_Config.Region = "us-east-1"
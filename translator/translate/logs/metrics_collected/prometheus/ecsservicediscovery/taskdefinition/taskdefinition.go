@@ -22,7 +22,7 @@ func GetCurPath() string {
 }
 
 func RegisterRule(fieldname string, r Rule) {
-	ChildRule[fieldname] = r
+	ChildRule[fieldname] = translator.TrackRule(r)
 }
 
 type TaskDefinition struct {
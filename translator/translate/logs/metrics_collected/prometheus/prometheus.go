@@ -25,7 +25,7 @@ func GetCurPath() string {
 }
 
 func RegisterRule(fieldname string, r Rule) {
-	ChildRule[fieldname] = r
+	ChildRule[fieldname] = translator.TrackRule(r)
 }
 
 func (p *Prometheus) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
@@ -0,0 +1,64 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const (
+	SectionKeyMetricNameRules = "metric_name_rules"
+
+	metricNameRuleActionKey = "action"
+	metricNameRuleValueKey  = "value"
+)
+
+var metricNameRuleActions = map[string]bool{
+	"strip_prefix": true,
+	"strip_suffix": true,
+	"replace":      true,
+	"pascal_case":  true,
+}
+
+type MetricNameRules struct {
+}
+
+// ApplyRule validates the rules that transform the scraped metric names and passes them to the plugin.
+func (m *MetricNameRules) ApplyRule(input interface{}) (string, interface{}) {
+	rules, ok := input.(map[string]interface{})[SectionKeyMetricNameRules].([]interface{})
+	if !ok {
+		return "", nil
+	}
+	result := []interface{}{}
+	for i, r := range rules {
+		rule, ok := r.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		action, _ := rule[metricNameRuleActionKey].(string)
+		value, _ := rule[metricNameRuleValueKey].(string)
+		switch {
+		case !metricNameRuleActions[action]:
+			translator.AddErrorMessages(GetCurPath()+SectionKeyMetricNameRules, fmt.Sprintf("Unsupported action %q in rule %d", action, i))
+			continue
+		case value == "" && (action == "strip_prefix" || action == "strip_suffix"):
+			translator.AddErrorMessages(GetCurPath()+SectionKeyMetricNameRules, fmt.Sprintf("Value is required for %s in rule %d", action, i))
+			continue
+		case action == "replace":
+			if _, err := regexp.Compile(value); err != nil {
+				translator.AddErrorMessages(GetCurPath()+SectionKeyMetricNameRules, fmt.Sprintf("Invalid regex %q in rule %d: %v", value, i, err))
+				continue
+			}
+		}
+		result = append(result, rule)
+	}
+	return SectionKeyMetricNameRules, result
+}
+
+func init() {
+	RegisterRule(SectionKeyMetricNameRules, new(MetricNameRules))
+}
@@ -0,0 +1,42 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestMetricNameRules(t *testing.T) {
+	translator.ResetMessages()
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"metric_name_rules": [
+			{"action": "strip_suffix", "value": "_total"},
+			{"action": "replace", "value": "^node_", "replacement": ""},
+			{"action": "pascal_case"},
+			{"action": "snake_case"},
+			{"action": "strip_prefix"},
+			{"action": "replace", "value": "("}
+		]
+	}`), &input))
+	key, val := new(MetricNameRules).ApplyRule(input)
+	assert.Equal(t, SectionKeyMetricNameRules, key)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"action": "strip_suffix", "value": "_total"},
+		map[string]interface{}{"action": "replace", "value": "^node_", "replacement": ""},
+		map[string]interface{}{"action": "pascal_case"},
+	}, val)
+	assert.Len(t, translator.ErrorMessages, 3)
+}
+
+func TestMetricNameRulesWithoutRules(t *testing.T) {
+	key, _ := new(MetricNameRules).ApplyRule(map[string]interface{}{})
+	assert.Equal(t, "", key)
+}
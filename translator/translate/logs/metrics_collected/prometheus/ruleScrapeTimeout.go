@@ -0,0 +1,36 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const (
+	SectionKeyScrapeTimeout = "scrape_timeout"
+)
+
+type ScrapeTimeout struct {
+}
+
+// ApplyRule validates the scrape timeout that limits the scrape of each target.
+func (s *ScrapeTimeout) ApplyRule(input interface{}) (string, interface{}) {
+	_, val := translator.DefaultCase(SectionKeyScrapeTimeout, "", input)
+	scrapeTimeout, ok := val.(string)
+	if !ok || scrapeTimeout == "" {
+		return "", nil
+	}
+	if d, err := time.ParseDuration(scrapeTimeout); err != nil || d <= 0 {
+		translator.AddErrorMessages(GetCurPath()+SectionKeyScrapeTimeout, fmt.Sprintf("Invalid scrape_timeout %q", scrapeTimeout))
+		return "", nil
+	}
+	return SectionKeyScrapeTimeout, scrapeTimeout
+}
+
+func init() {
+	RegisterRule(SectionKeyScrapeTimeout, new(ScrapeTimeout))
+}
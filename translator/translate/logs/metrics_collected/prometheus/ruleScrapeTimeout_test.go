@@ -0,0 +1,29 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package prometheus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestScrapeTimeout(t *testing.T) {
+	translator.ResetMessages()
+	defer translator.ResetMessages()
+
+	key, val := new(ScrapeTimeout).ApplyRule(map[string]interface{}{"scrape_timeout": "5s"})
+	assert.Equal(t, SectionKeyScrapeTimeout, key)
+	assert.Equal(t, "5s", val)
+
+	key, _ = new(ScrapeTimeout).ApplyRule(map[string]interface{}{})
+	assert.Equal(t, "", key)
+	assert.Empty(t, translator.ErrorMessages)
+
+	key, _ = new(ScrapeTimeout).ApplyRule(map[string]interface{}{"scrape_timeout": "5"})
+	assert.Equal(t, "", key)
+	assert.Len(t, translator.ErrorMessages, 1)
+}
@@ -0,0 +1,34 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import "github.com/aws/amazon-cloudwatch-agent/translator"
+
+const (
+	DeadLetterLogGroupSectionKey           = "deadletter_log_group"
+	DeadLetterMaxEventsPerMinuteSectionKey = "deadletter_max_events_per_minute"
+)
+
+// DeadLetter sends the log events that cannot be sent to their log group to the dead letter log group.
+type DeadLetter struct {
+}
+
+func (d *DeadLetter) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	_, logGroup := translator.DefaultCase(DeadLetterLogGroupSectionKey, "", input)
+	if logGroup == "" {
+		return
+	}
+	result := map[string]interface{}{
+		DeadLetterLogGroupSectionKey: logGroup,
+	}
+	_, val := translator.DefaultCase(DeadLetterMaxEventsPerMinuteSectionKey, float64(0), input)
+	if v, ok := val.(float64); ok && v > 0 {
+		result[DeadLetterMaxEventsPerMinuteSectionKey] = int(v)
+	}
+	return Output_Cloudwatch_Logs, result
+}
+
+func init() {
+	RegisterRule(DeadLetterLogGroupSectionKey, new(DeadLetter))
+}
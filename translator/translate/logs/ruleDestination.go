@@ -0,0 +1,40 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import (
+	"fmt"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const (
+	DestinationARNSectionKey     = "destination_arn"
+	DestinationRoleARNSectionKey = "destination_role_arn"
+)
+
+// Destination sends all of the log events to a log group in another account, such as the sink of a central
+// monitoring account, by assuming the destination role.
+type Destination struct {
+}
+
+func (d *Destination) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	_, destinationARN := translator.DefaultCase(DestinationARNSectionKey, "", input)
+	if destinationARN == "" {
+		return
+	}
+	_, roleARN := translator.DefaultCase(DestinationRoleARNSectionKey, "", input)
+	if roleARN == "" {
+		translator.AddErrorMessages(GetCurPath()+DestinationRoleARNSectionKey, fmt.Sprintf("%s is required with %s", DestinationRoleARNSectionKey, DestinationARNSectionKey))
+		return
+	}
+	return Output_Cloudwatch_Logs, map[string]interface{}{
+		DestinationARNSectionKey:     destinationARN,
+		DestinationRoleARNSectionKey: roleARN,
+	}
+}
+
+func init() {
+	RegisterRule(DestinationARNSectionKey, new(Destination))
+}
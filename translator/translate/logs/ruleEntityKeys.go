@@ -0,0 +1,32 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const (
+	EntityServiceNameKeysSectionKey = "entity_service_name_keys"
+	EntityEnvironmentKeysSectionKey = "entity_environment_keys"
+)
+
+// EntityKeys sets the resource attributes used to derive the service entity of structured logs.
+type EntityKeys struct {
+	key string
+}
+
+func (r *EntityKeys) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	_, val := translator.DefaultCase(r.key, []interface{}{}, input)
+	if keys, ok := val.([]interface{}); ok && len(keys) > 0 {
+		returnKey = Output_Cloudwatch_Logs
+		returnVal = map[string]interface{}{r.key: keys}
+	}
+	return
+}
+
+func init() {
+	RegisterRule(EntityServiceNameKeysSectionKey, &EntityKeys{key: EntityServiceNameKeysSectionKey})
+	RegisterRule(EntityEnvironmentKeysSectionKey, &EntityKeys{key: EntityEnvironmentKeysSectionKey})
+}
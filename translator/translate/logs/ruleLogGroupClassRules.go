@@ -0,0 +1,71 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const (
+	LogGroupClassRulesSectionKey = "log_group_class_rules"
+	logGroupClassRuleAttribute   = "attribute"
+	logGroupClassRulePattern     = "pattern"
+	logGroupClassRuleClass       = "log_group_class"
+)
+
+// LogGroupClassRules maps resource attribute value patterns to the log group class used when creating the
+// log groups of structured logs. Log groups that match none of the rules use the STANDARD class.
+type LogGroupClassRules struct {
+}
+
+func (r *LogGroupClassRules) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	_, val := translator.DefaultCase(LogGroupClassRulesSectionKey, []interface{}{}, input)
+	configs, ok := val.([]interface{})
+	if !ok || len(configs) == 0 {
+		return
+	}
+	path := GetCurPath() + LogGroupClassRulesSectionKey
+	var rules []interface{}
+	for _, config := range configs {
+		m, ok := config.(map[string]interface{})
+		if !ok {
+			translator.AddErrorMessages(path, fmt.Sprintf("%v is not a valid log group class rule", config))
+			continue
+		}
+		attribute, _ := m[logGroupClassRuleAttribute].(string)
+		pattern, _ := m[logGroupClassRulePattern].(string)
+		class, _ := m[logGroupClassRuleClass].(string)
+		class = strings.ToUpper(class)
+		if attribute == "" {
+			translator.AddErrorMessages(path, fmt.Sprintf("%s is required in log group class rule %v", logGroupClassRuleAttribute, m))
+			continue
+		}
+		if _, err := regexp.Compile(pattern); err != nil {
+			translator.AddErrorMessages(path, fmt.Sprintf("%s %q is not a valid regular expression: %v", logGroupClassRulePattern, pattern, err))
+			continue
+		}
+		if class == "" || !translator.IsValidLogGroupClass(class) {
+			translator.AddErrorMessages(path, fmt.Sprintf("%s %q is not a valid Log Group Class", logGroupClassRuleClass, m[logGroupClassRuleClass]))
+			continue
+		}
+		rules = append(rules, map[string]interface{}{
+			logGroupClassRuleAttribute: attribute,
+			logGroupClassRulePattern:   pattern,
+			logGroupClassRuleClass:     class,
+		})
+	}
+	if len(rules) > 0 {
+		returnKey = Output_Cloudwatch_Logs
+		returnVal = map[string]interface{}{LogGroupClassRulesSectionKey: rules}
+	}
+	return
+}
+
+func init() {
+	RegisterRule(LogGroupClassRulesSectionKey, new(LogGroupClassRules))
+}
@@ -0,0 +1,25 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import "github.com/aws/amazon-cloudwatch-agent/translator"
+
+const MaxCreateRequestsPerSecondSectionKey = "max_create_requests_per_second"
+
+// MaxCreateRequestsPerSecond limits the rate at which log groups and streams are created.
+type MaxCreateRequestsPerSecond struct {
+}
+
+func (m *MaxCreateRequestsPerSecond) ApplyRule(input any) (string, any) {
+	result := map[string]interface{}{}
+	_, val := translator.DefaultCase(MaxCreateRequestsPerSecondSectionKey, float64(0), input)
+	if v, ok := val.(float64); ok && v > 0 {
+		result[MaxCreateRequestsPerSecondSectionKey] = int(v)
+	}
+	return Output_Cloudwatch_Logs, result
+}
+
+func init() {
+	RegisterRule(MaxCreateRequestsPerSecondSectionKey, new(MaxCreateRequestsPerSecond))
+}
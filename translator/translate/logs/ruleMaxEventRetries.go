@@ -0,0 +1,24 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import "github.com/aws/amazon-cloudwatch-agent/translator"
+
+const MaxEventRetriesSectionKey = "max_event_retries"
+
+type MaxEventRetries struct {
+}
+
+func (c *MaxEventRetries) ApplyRule(input any) (string, any) {
+	result := map[string]interface{}{}
+	_, val := translator.DefaultCase(MaxEventRetriesSectionKey, float64(0), input)
+	if v, ok := val.(float64); ok && v > 0 {
+		result[MaxEventRetriesSectionKey] = int(v)
+	}
+	return Output_Cloudwatch_Logs, result
+}
+
+func init() {
+	RegisterRule(MaxEventRetriesSectionKey, new(MaxEventRetries))
+}
@@ -0,0 +1,24 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import "github.com/aws/amazon-cloudwatch-agent/translator"
+
+const MaxInFlightRequestsSectionKey = "max_in_flight_requests"
+
+type MaxInFlightRequests struct {
+}
+
+func (m *MaxInFlightRequests) ApplyRule(input any) (string, any) {
+	result := map[string]interface{}{}
+	_, val := translator.DefaultCase(MaxInFlightRequestsSectionKey, float64(0), input)
+	if v, ok := val.(float64); ok && v > 0 {
+		result[MaxInFlightRequestsSectionKey] = int(v)
+	}
+	return Output_Cloudwatch_Logs, result
+}
+
+func init() {
+	RegisterRule(MaxInFlightRequestsSectionKey, new(MaxInFlightRequests))
+}
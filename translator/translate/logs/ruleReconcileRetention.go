@@ -0,0 +1,27 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const ReconcileRetentionSectionKey = "reconcile_retention"
+
+type ReconcileRetention struct {
+}
+
+func (r *ReconcileRetention) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	// the retention is reconciled by default, so it is only set when disabled
+	_, val := translator.DefaultCase(ReconcileRetentionSectionKey, true, input)
+	if reconcile, ok := val.(bool); ok && !reconcile {
+		returnKey = Output_Cloudwatch_Logs
+		returnVal = map[string]interface{}{ReconcileRetentionSectionKey: false}
+	}
+	return
+}
+
+func init() {
+	RegisterRule(ReconcileRetentionSectionKey, new(ReconcileRetention))
+}
@@ -0,0 +1,24 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import "github.com/aws/amazon-cloudwatch-agent/translator"
+
+const StreamConcurrencySectionKey = "stream_concurrency"
+
+type StreamConcurrency struct {
+}
+
+func (c *StreamConcurrency) ApplyRule(input any) (string, any) {
+	result := map[string]interface{}{}
+	_, val := translator.DefaultCase(StreamConcurrencySectionKey, float64(0), input)
+	if v, ok := val.(float64); ok && v > 0 {
+		result[StreamConcurrencySectionKey] = int(v)
+	}
+	return Output_Cloudwatch_Logs, result
+}
+
+func init() {
+	RegisterRule(StreamConcurrencySectionKey, new(StreamConcurrency))
+}
@@ -0,0 +1,26 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package logs
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+const ValidatePermissionsSectionKey = "validate_permissions"
+
+type ValidatePermissions struct {
+}
+
+func (r *ValidatePermissions) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	_, val := translator.DefaultCase(ValidatePermissionsSectionKey, false, input)
+	if validate, ok := val.(bool); ok && validate {
+		returnKey = Output_Cloudwatch_Logs
+		returnVal = map[string]interface{}{ValidatePermissionsSectionKey: true}
+	}
+	return
+}
+
+func init() {
+	RegisterRule(ValidatePermissionsSectionKey, new(ValidatePermissions))
+}
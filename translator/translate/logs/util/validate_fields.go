@@ -5,6 +5,7 @@ package util
 
 import (
 	"fmt"
+	"regexp"
 	"strings"
 
 	"golang.org/x/exp/slices"
@@ -16,11 +17,35 @@ const (
 	logRetentionKey  = "retention_in_days"
 	logGroupKey      = "log_group_name"
 	logGroupClassKey = "log_group_class"
+
+	logGroupARNPrefix = "arn:"
 )
 
+// logGroupARNRegexp matches a log group ARN such as arn:aws:logs:us-east-1:123456789012:log-group:central, which can
+// be used as the log_group_name to target a log group of another account.
+var logGroupARNRegexp = regexp.MustCompile(`^arn:aws[a-z-]*:logs:[a-z0-9-]+:\d{12}:log-group:[^:]+(:\*)?$`)
+
 func ValidateLogGroupFields(logConfigs []interface{}, currPath string) []interface{} {
 	logConfigs = validateLogRetentionSettings(logConfigs, currPath)
 	logConfigs = validateLogGroupClassSettings(logConfigs, currPath)
+	logConfigs = validateLogGroupARNs(logConfigs, currPath)
+	return logConfigs
+}
+
+func validateLogGroupARNs(logConfigs []interface{}, currPath string) []interface{} {
+	for _, logConfig := range logConfigs {
+		if logConfigMap, ok := logConfig.(map[string]interface{}); ok {
+			logGroup, ok := logConfigMap[logGroupKey].(string)
+			if !ok || !strings.HasPrefix(logGroup, logGroupARNPrefix) {
+				continue
+			}
+			if !logGroupARNRegexp.MatchString(logGroup) {
+				translator.AddErrorMessages(
+					currPath,
+					fmt.Sprintf("Invalid log group ARN, expected arn:<partition>:logs:<region>:<account-id>:log-group:<name>: %v", logGroup))
+			}
+		}
+	}
 	return logConfigs
 }
 
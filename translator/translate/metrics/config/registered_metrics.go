@@ -22,6 +22,10 @@ var Registered_Metrics_Linux = map[string][]string{
 		"rlimit_realtime_priority_hard", "rlimit_realtime_priority_soft", "rlimit_signals_pending_hard", "rlimit_signals_pending_soft", "signals_pending", "voluntary_context_switches", "write_bytes", "write_count", "pid_count"},
 	"nvidia_smi": {"utilization_gpu", "temperature_gpu", "power_draw", "utilization_memory", "fan_speed", "memory_total", "memory_used", "memory_free", "temperature_gpu", "pcie_link_gen_current", "pcie_link_width_current",
 		"encoder_stats_session_count", "encoder_stats_average_fps", "encoder_stats_average_latency", "clocks_current_graphics", "clocks_current_sm", "clocks_current_memory", "clocks_current_video"},
+	"pressure": {"cpu_some_avg10", "cpu_some_avg60", "cpu_some_avg300", "cpu_some_total", "cpu_full_avg10", "cpu_full_avg60", "cpu_full_avg300", "cpu_full_total",
+		"memory_some_avg10", "memory_some_avg60", "memory_some_avg300", "memory_some_total", "memory_full_avg10", "memory_full_avg60", "memory_full_avg300", "memory_full_total",
+		"io_some_avg10", "io_some_avg60", "io_some_avg300", "io_some_total", "io_full_avg10", "io_full_avg60", "io_full_avg300", "io_full_total"},
+	"tcp_state": {"established", "syn_sent", "syn_recv", "fin_wait1", "fin_wait2", "time_wait", "close", "close_wait", "last_ack", "listen", "closing", "total"},
 }
 
 // This served as the allowlisted metric name, which is registered under the plugin name
@@ -35,7 +35,7 @@ func GetCurPath() string {
 }
 
 func RegisterRule(fieldName string, r Rule) {
-	ChildRule[fieldName] = r
+	ChildRule[fieldName] = translator.TrackRule(r)
 }
 
 type Metrics struct {
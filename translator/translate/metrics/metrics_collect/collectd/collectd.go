@@ -18,6 +18,7 @@ import (
 //	    "collectd_auth_file": "/etc/collectd/auth_file",
 //	    "collectd_security_level": "encrypt",
 //	    "collectd_typesdb": ["/usr/share/collectd/types.db"],
+//	    "use_packet_hostname": true,
 //	    "metrics_aggregation_interval": 60
 //	}
 const (
@@ -33,7 +34,7 @@ func GetCurPath() string {
 }
 
 func RegisterRule(fieldname string, r translator.Rule) {
-	ChildRule[fieldname] = r
+	ChildRule[fieldname] = translator.TrackRule(r)
 }
 
 type CollectD struct {
@@ -40,6 +40,29 @@ func TestCollectD_HappyCase(t *testing.T) {
 	assert.Equal(t, expect, actual)
 }
 
+func TestCollectD_UsePacketHostname(t *testing.T) {
+	obj := new(CollectD)
+	var input interface{}
+	err := json.Unmarshal([]byte(`{"collectd": {"use_packet_hostname": true}}`), &input)
+	assert.NoError(t, err)
+
+	_, actual := obj.ApplyRule(input)
+
+	expect := []interface{}{
+		map[string]interface{}{
+			"data_format":             "collectd",
+			"service_address":         "udp://127.0.0.1:25826",
+			"name_prefix":             "collectd_",
+			"collectd_auth_file":      "/etc/collectd/auth_file",
+			"collectd_security_level": "encrypt",
+			"collectd_typesdb":        []interface{}{"/usr/share/collectd/types.db"},
+			"tags":                    map[string]interface{}{"aws:AggregationInterval": "60s", "aws:KeepHost": "true"},
+		},
+	}
+
+	assert.Equal(t, expect, actual)
+}
+
 func TestCollectD_MinimumConfig(t *testing.T) {
 	obj := new(CollectD)
 	var input interface{}
@@ -4,6 +4,12 @@
 package collected
 
 import (
+	"bufio"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
 	"github.com/aws/amazon-cloudwatch-agent/translator"
 )
 
@@ -14,9 +20,81 @@ const SectionKey_TypesDB = "collectd_typesdb"
 
 func (obj *TypesDB) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
 	returnKey, returnVal = translator.DefaultCase(SectionKey_TypesDB, []interface{}{"/usr/share/collectd/types.db"}, input)
+	if paths, ok := returnVal.([]interface{}); ok {
+		for _, path := range paths {
+			if p, ok := path.(string); ok {
+				if err := validateTypesDB(p); err != nil {
+					translator.AddErrorMessages(GetCurPath()+SectionKey_TypesDB, err.Error())
+				}
+			}
+		}
+	}
 	return
 }
 
+// validateTypesDB checks that every data set in the types.db file can be
+// parsed. The collectd parser silently skips malformed lines, which drops all
+// metrics of that type, so fail the translation instead. Files that do not
+// exist yet are not validated here since the agent reports them on startup.
+func validateTypesDB(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("unable to read types.db file %s: %v", path, err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if err = validateDataSet(line); err != nil {
+			return fmt.Errorf("invalid types.db file %s on line %d: %v", path, lineNumber, err)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return fmt.Errorf("unable to read types.db file %s: %v", path, err)
+	}
+	return nil
+}
+
+// validateDataSet validates a data set line in the form
+// <type> <name>:<GAUGE|DERIVE|COUNTER|ABSOLUTE>:<min>:<max>[, ...]
+// ABSOLUTE data sources are used by the stock types.db of collectd, so they
+// are allowed even though the values of their types are not collected.
+func validateDataSet(line string) error {
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return fmt.Errorf("type %q has no data sources", fields[0])
+	}
+	for _, source := range fields[1:] {
+		parts := strings.Split(strings.TrimSuffix(source, ","), ":")
+		if len(parts) != 4 {
+			return fmt.Errorf("data source %q of type %q must be in the form name:type:min:max", source, fields[0])
+		}
+		switch parts[1] {
+		case "GAUGE", "DERIVE", "COUNTER":
+		case "ABSOLUTE":
+			fmt.Printf("W! Data source %q of type %q is ABSOLUTE, which is not supported, so the values of the type are dropped\n", parts[0], fields[0])
+		default:
+			return fmt.Errorf("data source %q of type %q has unsupported data source type %q", parts[0], fields[0], parts[1])
+		}
+		for _, bound := range parts[2:] {
+			if bound == "U" {
+				continue
+			}
+			if _, err := strconv.ParseFloat(bound, 64); err != nil {
+				return fmt.Errorf("data source %q of type %q has invalid bound %q", parts[0], fields[0], bound)
+			}
+		}
+	}
+	return nil
+}
+
 func init() {
 	obj := new(TypesDB)
 	RegisterRule(SectionKey_TypesDB, obj)
@@ -0,0 +1,108 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collected
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"collectd.org/api"
+	"collectd.org/network"
+	"github.com/influxdata/telegraf/plugins/parsers/collectd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+func TestTypesDB_Valid(t *testing.T) {
+	translator.ResetMessages()
+	defer translator.ResetMessages()
+	path := filepath.Join("testdata", "types.db")
+	_, actual := new(TypesDB).ApplyRule(map[string]interface{}{
+		SectionKey_TypesDB: []interface{}{path, "/does/not/exist/types.db"},
+	})
+	assert.Equal(t, []interface{}{path, "/does/not/exist/types.db"}, actual)
+	assert.True(t, translator.IsTranslateSuccess())
+}
+
+func TestTypesDB_Invalid(t *testing.T) {
+	translator.ResetMessages()
+	defer translator.ResetMessages()
+	path := filepath.Join("testdata", "invalid_types.db")
+	new(TypesDB).ApplyRule(map[string]interface{}{
+		SectionKey_TypesDB: []interface{}{path},
+	})
+	assert.False(t, translator.IsTranslateSuccess())
+	require.Len(t, translator.ErrorMessages, 1)
+	assert.Contains(t, translator.ErrorMessages[0], "invalid_types.db on line 1")
+	assert.Contains(t, translator.ErrorMessages[0], `"post:DERIVE:0"`)
+}
+
+func TestValidateDataSet(t *testing.T) {
+	testCases := map[string]struct {
+		line    string
+		wantErr bool
+	}{
+		"Valid":          {line: "myapp_requests get:DERIVE:0:U, post:DERIVE:0:U"},
+		"ValidBounds":    {line: "percent value:GAUGE:0:100.1"},
+		"NoSources":      {line: "myapp_requests", wantErr: true},
+		"MissingBound":   {line: "myapp_requests get:DERIVE:0", wantErr: true},
+		"Absolute":       {line: "absolute value:ABSOLUTE:0:U"},
+		"UnsupportedDS":  {line: "myapp_requests get:HISTOGRAM:0:U", wantErr: true},
+		"InvalidBound":   {line: "myapp_requests get:DERIVE:zero:U", wantErr: true},
+		"TrailingSpaces": {line: "myapp_requests   get:DERIVE:0:U,   post:DERIVE:0:U"},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			err := validateDataSet(testCase.line)
+			if testCase.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+// TestTypesDB_CustomTypeNaming verifies that a value list of a custom type is
+// named by the data sources in the configured types.db file.
+func TestTypesDB_CustomTypeNaming(t *testing.T) {
+	path := filepath.Join("testdata", "types.db")
+	require.NoError(t, validateTypesDB(path))
+
+	buf := network.NewBuffer(0)
+	require.NoError(t, buf.Write(context.Background(), &api.ValueList{
+		Identifier: api.Identifier{
+			Host:   "host",
+			Plugin: "myapp",
+			Type:   "myapp_requests",
+		},
+		Time:     time.Unix(1700000000, 0),
+		Interval: 10 * time.Second,
+		Values:   []api.Value{api.Derive(1), api.Derive(2)},
+	}))
+	packet, err := buf.Bytes()
+	require.NoError(t, err)
+
+	parser, err := collectd.NewCollectdParser("", "none", []string{path}, "split")
+	require.NoError(t, err)
+	metrics, err := parser.Parse(packet)
+	require.NoError(t, err)
+	require.Len(t, metrics, 2)
+	assert.Equal(t, "myapp_get", metrics[0].Name())
+	assert.Equal(t, "myapp_post", metrics[1].Name())
+	value, ok := metrics[1].GetField("value")
+	assert.True(t, ok)
+	assert.Equal(t, float64(2), value)
+
+	// without the custom types.db the type is unknown and the values are dropped
+	parser, err = collectd.NewCollectdParser("", "none", []string{filepath.Join("testdata", "invalid_types.db")}, "split")
+	require.NoError(t, err)
+	metrics, err = parser.Parse(packet)
+	require.NoError(t, err)
+	assert.Empty(t, metrics)
+}
@@ -13,7 +13,7 @@ type MetricsAggregationInterval struct {
 const SectionKey_MetricsAggregationInterval = "metrics_aggregation_interval"
 
 func (obj *MetricsAggregationInterval) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
-	return util.ProcessMetricsAggregationInterval(input, "60s", SectionKey)
+	return util.ProcessMetricsAggregationInterval(input, "60s", "", SectionKey)
 }
 
 func init() {
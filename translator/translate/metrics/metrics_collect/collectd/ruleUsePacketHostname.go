@@ -0,0 +1,30 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package collected
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/ec2tagger"
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+// UsePacketHostname keeps the hostname of the collectd packets as the host dimension when append_dimensions are
+// applied, which otherwise remove it. Packets without a hostname are tagged with the agent host, if any.
+type UsePacketHostname struct {
+}
+
+const SectionKey_UsePacketHostname = "use_packet_hostname"
+
+func (obj *UsePacketHostname) ApplyRule(input interface{}) (string, interface{}) {
+	_, returnVal := translator.DefaultCase(SectionKey_UsePacketHostname, false, input)
+	if usePacketHostname, ok := returnVal.(bool); !ok || !usePacketHostname {
+		return "", nil
+	}
+	return common.Tags, map[string]interface{}{ec2tagger.AttributeKeepHost: "true"}
+}
+
+func init() {
+	obj := new(UsePacketHostname)
+	RegisterRule(SectionKey_UsePacketHostname, obj)
+}
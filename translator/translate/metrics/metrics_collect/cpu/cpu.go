@@ -19,7 +19,7 @@ func GetCurPath() string {
 }
 
 func RegisterRule(fieldname string, r translator.Rule) {
-	CPU_ChildRule[fieldname] = r
+	CPU_ChildRule[fieldname] = translator.TrackRule(r)
 }
 
 type Cpu struct {
@@ -19,7 +19,7 @@ func GetCurPath() string {
 }
 
 func RegisterRule(fieldname string, r translator.Rule) {
-	ChildRule[fieldname] = r
+	ChildRule[fieldname] = translator.TrackRule(r)
 }
 
 type Disk struct {
@@ -27,7 +27,7 @@ var ChildRule = map[string]translator.Rule{}
 const SectionKey_Ethtool = "ethtool"
 
 func RegisterRule(fieldname string, r translator.Rule) {
-	ChildRule[fieldname] = r
+	ChildRule[fieldname] = translator.TrackRule(r)
 }
 
 type Ethtool struct {
@@ -0,0 +1,57 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kubelet_summary
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect"
+)
+
+// SectionKey
+//
+//	"kubelet_summary" : {
+//	    "host_ip": "10.0.0.1",
+//	    "port": 10250,
+//	    "metrics_collection_interval": 60
+//	}
+const SectionKey = "kubelet_summary"
+
+var ChildRule = map[string]translator.Rule{}
+
+func GetCurPath() string {
+	curPath := parent.GetCurPath() + SectionKey + "/"
+	return curPath
+}
+
+func RegisterRule(fieldname string, r translator.Rule) {
+	ChildRule[fieldname] = translator.TrackRule(r)
+}
+
+type KubeletSummary struct {
+}
+
+func (obj *KubeletSummary) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	resArray := []interface{}{}
+	result := map[string]interface{}{}
+	//Check if this plugin exist in the input instance
+	//If not, not process
+	if _, ok := m[SectionKey]; !ok {
+		returnKey = ""
+		returnVal = ""
+	} else {
+		//If exists, process it
+		//Check if there are some config entry with rules applied
+		result = translator.ProcessRuleToApply(m[SectionKey], ChildRule, result)
+		resArray = append(resArray, result)
+		returnKey = SectionKey
+		returnVal = resArray
+	}
+	return
+}
+
+func init() {
+	obj := new(KubeletSummary)
+	parent.RegisterLinuxRule(SectionKey, obj)
+}
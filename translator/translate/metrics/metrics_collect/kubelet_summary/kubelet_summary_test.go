@@ -0,0 +1,37 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kubelet_summary
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKubeletSummary(t *testing.T) {
+	obj := new(KubeletSummary)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"kubelet_summary": {
+					"host_ip": "10.0.0.1",
+					"port": 10255,
+					"metrics_collection_interval": 30
+					}}`), &input))
+	_, actual := obj.ApplyRule(input)
+	expected := []interface{}{map[string]interface{}{
+		"host_ip":  "10.0.0.1",
+		"port":     "10255",
+		"interval": "30s",
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestKubeletSummaryMinimumConfig(t *testing.T) {
+	obj := new(KubeletSummary)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"kubelet_summary": {}}`), &input))
+	_, actual := obj.ApplyRule(input)
+	assert.Equal(t, []interface{}{map[string]interface{}{}}, actual)
+}
@@ -0,0 +1,25 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kubelet_summary
+
+type HostIP struct {
+}
+
+const SectionKey_HostIP = "host_ip"
+
+// ApplyRule sets the IP of the node only when it is in the config, so the plugin falls back to the HOST_IP
+// environment variable.
+func (obj *HostIP) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_HostIP].(string); ok {
+		returnKey = SectionKey_HostIP
+		returnVal = val
+	}
+	return
+}
+
+func init() {
+	obj := new(HostIP)
+	RegisterRule(SectionKey_HostIP, obj)
+}
@@ -0,0 +1,28 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package kubelet_summary
+
+import (
+	"strconv"
+)
+
+type Port struct {
+}
+
+const SectionKey_Port = "port"
+
+func (obj *Port) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	// By default json unmarshal will store number as float64
+	if val, ok := m[SectionKey_Port].(float64); ok {
+		returnKey = SectionKey_Port
+		returnVal = strconv.Itoa(int(val))
+	}
+	return
+}
+
+func init() {
+	obj := new(Port)
+	RegisterRule(SectionKey_Port, obj)
+}
@@ -0,0 +1,35 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package log_staleness
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogStaleness(t *testing.T) {
+	obj := new(LogStaleness)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"log_staleness": {
+					"files": ["/var/log/app/*.log", "/opt/app/server.log"],
+					"metrics_collection_interval": 30
+					}}`), &input))
+	_, actual := obj.ApplyRule(input)
+	expected := []interface{}{map[string]interface{}{
+		"files":    []string{"/var/log/app/*.log", "/opt/app/server.log"},
+		"interval": "30s",
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestLogStalenessMinimumConfig(t *testing.T) {
+	obj := new(LogStaleness)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"log_staleness": {}}`), &input))
+	_, actual := obj.ApplyRule(input)
+	assert.Equal(t, []interface{}{map[string]interface{}{}}, actual)
+}
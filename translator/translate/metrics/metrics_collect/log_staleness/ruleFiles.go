@@ -0,0 +1,31 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package log_staleness
+
+type Files struct {
+}
+
+const SectionKey_Files = "files"
+
+func (obj *Files) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	val, ok := m[SectionKey_Files].([]interface{})
+	if !ok {
+		return
+	}
+	files := make([]string, 0, len(val))
+	for _, file := range val {
+		if s, ok := file.(string); ok {
+			files = append(files, s)
+		}
+	}
+	returnKey = SectionKey_Files
+	returnVal = files
+	return
+}
+
+func init() {
+	obj := new(Files)
+	RegisterRule(SectionKey_Files, obj)
+}
@@ -32,15 +32,15 @@ func GetCurPath() string {
 }
 
 func RegisterLinuxRule(ruleName string, r Rule) {
-	linuxMetricCollectRule[ruleName] = r
+	linuxMetricCollectRule[ruleName] = translator.TrackRule(r)
 }
 
 func RegisterDarwinRule(ruleName string, r Rule) {
-	darwinMetricCollectRule[ruleName] = r
+	darwinMetricCollectRule[ruleName] = translator.TrackRule(r)
 }
 
 func RegisterWindowsRule(ruleName string, r Rule) {
-	windowsMetricCollectRule[ruleName] = r
+	windowsMetricCollectRule[ruleName] = translator.TrackRule(r)
 }
 
 type CollectMetrics struct {
@@ -0,0 +1,34 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pressure
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPressure(t *testing.T) {
+	p := new(Pressure)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"pressure":{"measurement": [
+						"cpu_some_avg10",
+						"pressure_memory_full_avg60",
+						"io_some_total"]}}`), &input))
+	_, actual := p.ApplyRule(input)
+	expected := []interface{}{map[string]interface{}{
+		"fieldpass": []string{"cpu_some_avg10", "memory_full_avg60", "io_some_total"},
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestPressureInvalidMeasurement(t *testing.T) {
+	p := new(Pressure)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"pressure":{"measurement": ["irq_full_avg10"]}}`), &input))
+	key, _ := p.ApplyRule(input)
+	assert.Equal(t, "", key)
+}
@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package statsd
+
+type AggregateByKey struct {
+}
+
+const SectionKey_AggregateByKey = "aggregate_by_key"
+
+func (obj *AggregateByKey) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_AggregateByKey].(bool); ok {
+		returnKey = SectionKey_AggregateByKey
+		returnVal = val
+	}
+	return
+}
+
+func init() {
+	obj := new(AggregateByKey)
+	RegisterRule(SectionKey_AggregateByKey, obj)
+}
@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package tcp_state
+
+type PerPort struct {
+}
+
+const SectionKey_PerPort = "per_port"
+
+func (obj *PerPort) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	if val, ok := m[SectionKey_PerPort].(bool); ok {
+		returnKey = SectionKey_PerPort
+		returnVal = val
+	}
+	return
+}
+
+func init() {
+	obj := new(PerPort)
+	RegisterRule(SectionKey_PerPort, obj)
+}
@@ -0,0 +1,32 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package tcp_state
+
+type Ports struct {
+}
+
+const SectionKey_Ports = "ports"
+
+func (obj *Ports) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	val, ok := m[SectionKey_Ports].([]interface{})
+	if !ok {
+		return
+	}
+	ports := make([]int, 0, len(val))
+	for _, port := range val {
+		// By default json unmarshal will store number as float64
+		if floatVal, ok := port.(float64); ok {
+			ports = append(ports, int(floatVal))
+		}
+	}
+	returnKey = SectionKey_Ports
+	returnVal = ports
+	return
+}
+
+func init() {
+	obj := new(Ports)
+	RegisterRule(SectionKey_Ports, obj)
+}
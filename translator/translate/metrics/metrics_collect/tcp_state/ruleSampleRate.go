@@ -0,0 +1,23 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package tcp_state
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+)
+
+type SampleRate struct {
+}
+
+const SectionKey_SampleRate = "sample_rate"
+
+func (obj *SampleRate) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	returnKey, returnVal = translator.DefaultIntegralCase(SectionKey_SampleRate, float64(1), input)
+	return
+}
+
+func init() {
+	obj := new(SampleRate)
+	RegisterRule(SectionKey_SampleRate, obj)
+}
@@ -0,0 +1,62 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package tcp_state
+
+import (
+	"github.com/aws/amazon-cloudwatch-agent/translator"
+	parent "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/util"
+)
+
+var ChildRule = map[string]translator.Rule{}
+
+const SectionKey_TCPState = "tcp_state"
+
+func GetCurPath() string {
+	curPath := parent.GetCurPath() + SectionKey_TCPState + "/"
+	return curPath
+}
+
+func RegisterRule(fieldname string, r translator.Rule) {
+	ChildRule[fieldname] = translator.TrackRule(r)
+}
+
+type TCPState struct {
+}
+
+func (t *TCPState) ApplyRule(input interface{}) (returnKey string, returnVal interface{}) {
+	m := input.(map[string]interface{})
+	//Generate the config file for monitoring system metrics on non-windows
+	res := []interface{}{}
+	result := map[string]interface{}{}
+	//Check if this plugin exist in the input instance
+	//If not, not process
+	if _, ok := m[SectionKey_TCPState]; !ok {
+		returnKey = ""
+		returnVal = ""
+	} else {
+		/*
+		  In JSON config file, it represents as "tcp_state" : {//specification config information}
+		  To check the specification config entry
+		*/
+		//Check if there are some config entry with rules applied
+		result = translator.ProcessRuleToApply(m[SectionKey_TCPState], ChildRule, result)
+
+		//Process common config, like measurement
+		hasValidMetric := util.ProcessLinuxCommonConfig(m[SectionKey_TCPState], SectionKey_TCPState, GetCurPath(), result)
+		if hasValidMetric {
+			res = append(res, result)
+			returnKey = SectionKey_TCPState
+			returnVal = res
+		} else {
+			returnKey = ""
+		}
+	}
+	return
+}
+
+func init() {
+	t := new(TCPState)
+	parent.RegisterLinuxRule(SectionKey_TCPState, t)
+}
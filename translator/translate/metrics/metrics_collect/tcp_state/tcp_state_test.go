@@ -0,0 +1,44 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package tcp_state
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTCPState(t *testing.T) {
+	s := new(TCPState)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"tcp_state":{"measurement": [
+						"established",
+						"tcp_state_close_wait"]}}`), &input))
+	_, actual := s.ApplyRule(input)
+	expected := []interface{}{map[string]interface{}{
+		"fieldpass":   []string{"established", "close_wait"},
+		"sample_rate": 1,
+	}}
+	assert.Equal(t, expected, actual)
+}
+
+func TestTCPStatePerPort(t *testing.T) {
+	s := new(TCPState)
+	var input interface{}
+	require.NoError(t, json.Unmarshal([]byte(`{"tcp_state":{
+					"measurement": ["time_wait"],
+					"per_port": true,
+					"ports": [443, 8080],
+					"sample_rate": 10}}`), &input))
+	_, actual := s.ApplyRule(input)
+	expected := []interface{}{map[string]interface{}{
+		"fieldpass":   []string{"time_wait"},
+		"per_port":    true,
+		"ports":       []int{443, 8080},
+		"sample_rate": 10,
+	}}
+	assert.Equal(t, expected, actual)
+}
@@ -5,6 +5,7 @@ package util
 
 import (
 	"fmt"
+	"log"
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/util/hash"
 	"github.com/aws/amazon-cloudwatch-agent/translator"
@@ -19,6 +20,7 @@ const (
 	Collect_Interval_Key         = "metrics_collection_interval"
 	Collect_Interval_Mapped_Key  = "interval"
 	Aggregation_Interval_Key     = "metrics_aggregation_interval"
+	Infer_Storage_Resolution_Key = "infer_storage_resolution"
 	Append_Dimensions_Key        = "append_dimensions"
 	Append_Dimensions_Mapped_Key = "tags"
 	Windows_Object_Name_Key      = "ObjectName"
@@ -181,7 +183,10 @@ func ProcessMetricsCollectionInterval(input interface{}, defaultValue, pluginNam
 	return
 }
 
-func ProcessMetricsAggregationInterval(input interface{}, defaultValue, pluginName string) (returnKey string, returnVal interface{}) {
+// ProcessMetricsAggregationInterval sets the aggregation interval of the metrics as a tag. When the metrics are
+// collected below a minute, they are stored at high resolution if infer_storage_resolution is set in the plugin.
+// The collection interval falls back to defaultCollectionInterval when it is not set, and plugins without one pass "".
+func ProcessMetricsAggregationInterval(input interface{}, defaultValue, defaultCollectionInterval, pluginName string) (returnKey string, returnVal interface{}) {
 	if inputMap, ok := input.(map[string]interface{}); ok {
 		if val, ok := inputMap[Aggregation_Interval_Key]; ok {
 			if floatVal, ok := val.(float64); ok {
@@ -190,7 +195,9 @@ func ProcessMetricsAggregationInterval(input interface{}, defaultValue, pluginNa
 					// customer specifically disabled the metrics aggregation interval by putting "0"
 					return Append_Dimensions_Mapped_Key, map[string]interface{}{util.High_Resolution_Tag_Key: "true"}
 				}
-				return Append_Dimensions_Mapped_Key, map[string]interface{}{util.Aggregation_Interval_Tag_Key: val}
+				tags := map[string]interface{}{util.Aggregation_Interval_Tag_Key: val}
+				inferStorageResolution(inputMap, tags, defaultCollectionInterval, pluginName)
+				return Append_Dimensions_Mapped_Key, tags
 			} else {
 				translator.AddErrorMessages(
 					fmt.Sprintf("metrics plugin %s", pluginName),
@@ -198,12 +205,33 @@ func ProcessMetricsAggregationInterval(input interface{}, defaultValue, pluginNa
 			}
 		}
 		if defaultValue != "" {
-			return Append_Dimensions_Mapped_Key, map[string]interface{}{util.Aggregation_Interval_Tag_Key: defaultValue}
+			tags := map[string]interface{}{util.Aggregation_Interval_Tag_Key: defaultValue}
+			inferStorageResolution(inputMap, tags, defaultCollectionInterval, pluginName)
+			return Append_Dimensions_Mapped_Key, tags
 		}
 	}
 	return
 }
 
+// inferStorageResolution adds the high resolution tag when the metrics are collected below a minute and the plugin
+// opted in with infer_storage_resolution. Otherwise, a sub-minute collection interval set in the config is logged,
+// since the aggregated metrics are stored at standard resolution.
+func inferStorageResolution(inputMap map[string]interface{}, tags map[string]interface{}, defaultCollectionInterval, pluginName string) {
+	interval := defaultCollectionInterval
+	floatVal, configured := inputMap[Collect_Interval_Key].(float64)
+	if configured {
+		interval = fmt.Sprintf("%ds", int(floatVal))
+	}
+	if interval == "" || !IsHighResolution(interval) {
+		return
+	}
+	if inputMap[Infer_Storage_Resolution_Key] == true {
+		tags[util.High_Resolution_Tag_Key] = "true"
+	} else if configured {
+		log.Printf("W! metrics plugin %s collects metrics every %s, but they are stored at standard resolution. Set %s in the plugin to store them at high resolution.", pluginName, interval, Infer_Storage_Resolution_Key)
+	}
+}
+
 // check if desiredVal exist in inputs list
 func ListContains(inputs []string, desiredVal string) bool {
 	for _, val := range inputs {
@@ -61,3 +61,81 @@ func TestProcessLinuxCommonConfigHappy(t *testing.T) {
 		panic(err)
 	}
 }
+
+func TestProcessMetricsAggregationIntervalStorageResolution(t *testing.T) {
+	testCases := map[string]struct {
+		input map[string]interface{}
+		want  map[string]interface{}
+	}{
+		"SubMinuteCollection": {
+			input: map[string]interface{}{
+				Collect_Interval_Key:     float64(10),
+				Aggregation_Interval_Key: float64(60),
+			},
+			want: map[string]interface{}{"aws:AggregationInterval": "60s"},
+		},
+		"SubMinuteCollectionInferred": {
+			input: map[string]interface{}{
+				Collect_Interval_Key:         float64(10),
+				Aggregation_Interval_Key:     float64(60),
+				Infer_Storage_Resolution_Key: true,
+			},
+			want: map[string]interface{}{"aws:AggregationInterval": "60s", "aws:StorageResolution": "true"},
+		},
+		"DefaultCollectionInferred": {
+			input: map[string]interface{}{
+				Aggregation_Interval_Key:     float64(300),
+				Infer_Storage_Resolution_Key: true,
+			},
+			want: map[string]interface{}{"aws:AggregationInterval": "300s", "aws:StorageResolution": "true"},
+		},
+		"MinuteCollectionInferred": {
+			input: map[string]interface{}{
+				Collect_Interval_Key:         float64(60),
+				Aggregation_Interval_Key:     float64(30),
+				Infer_Storage_Resolution_Key: true,
+			},
+			want: map[string]interface{}{"aws:AggregationInterval": "30s"},
+		},
+		"DefaultAggregationInferred": {
+			input: map[string]interface{}{
+				Collect_Interval_Key:         float64(5),
+				Infer_Storage_Resolution_Key: true,
+			},
+			want: map[string]interface{}{"aws:AggregationInterval": "60s", "aws:StorageResolution": "true"},
+		},
+		"Disabled": {
+			input: map[string]interface{}{
+				Aggregation_Interval_Key: float64(0),
+			},
+			want: map[string]interface{}{"aws:StorageResolution": "true"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			key, got := ProcessMetricsAggregationInterval(testCase.input, "60s", "10s", "statsd")
+			assert.Equal(t, Append_Dimensions_Mapped_Key, key)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
+
+func TestProcessMetricsAggregationIntervalNoCollectionInterval(t *testing.T) {
+	_, got := ProcessMetricsAggregationInterval(map[string]interface{}{
+		Infer_Storage_Resolution_Key: true,
+	}, "60s", "", "collectd")
+	assert.Equal(t, map[string]interface{}{"aws:AggregationInterval": "60s"}, got)
+}
+
+func TestIsHighResolution(t *testing.T) {
+	for interval, want := range map[string]bool{
+		"1s":      true,
+		"59s":     true,
+		"60s":     false,
+		"1m":      false,
+		"300s":    false,
+		"invalid": false,
+	} {
+		assert.Equal(t, want, IsHighResolution(interval), interval)
+	}
+}
@@ -22,6 +22,7 @@ const (
 	measurement_category    = "category"
 	measurement_rename      = "rename"
 	measurement_unit        = "unit"
+	measurement_interval    = "metrics_collection_interval"
 )
 
 const (
@@ -102,6 +103,8 @@ func ApplyMeasurementRuleForMetricDecoration(inputs interface{}, pluginName stri
 					fallthrough
 				case measurement_unit:
 					decorationMap[k] = strings.TrimSpace(v.(string))
+				case measurement_interval:
+					// the interval is set on the receiver of the plugin
 				default:
 					fmt.Printf("Warning, detect unexpected field in measurement: %v", k)
 				}
@@ -35,10 +35,12 @@ const (
 	PrometheusConfigPathKey            = "prometheus_config_path"
 	AMPKey                             = "amp"
 	WorkspaceIDKey                     = "workspace_id"
+	TimestreamKey                      = "timestream"
 	EMFProcessorKey                    = "emf_processor"
 	DisableMetricExtraction            = "disable_metric_extraction"
 	XrayKey                            = "xray"
 	OtlpKey                            = "otlp"
+	HeartbeatKey                       = "heartbeat"
 	JmxKey                             = "jmx"
 	TLSKey                             = "tls"
 	Endpoint                           = "endpoint"
@@ -61,6 +63,8 @@ const (
 	EnableAcceleratedComputeMetric     = "accelerated_compute_metrics"
 	EnableKueueContainerInsights       = "kueue_container_insights"
 	AppendDimensionsKey                = "append_dimensions"
+	DimensionKeysKey                   = "dimension_keys"
+	KeepDroppedDimensionsKey           = "keep_dropped_dimensions"
 	Console                            = "console"
 	DiskKey                            = "disk"
 	DiskIOKey                          = "diskio"
@@ -78,6 +82,10 @@ const (
 	NameKey                            = "name"
 	RenameKey                          = "rename"
 	UnitKey                            = "unit"
+	MetricUnitsKey                     = "metric_units"
+	PatternKey                         = "pattern"
+	MetricTemporalityKey               = "metric_temporality"
+	TemporalityKey                     = "temporality"
 )
 
 const (
@@ -115,8 +123,10 @@ const (
 	PipelineNameJmx                  = "jmx"
 	PipelineNameContainerInsightsJmx = "containerinsightsjmx"
 	PipelineNameEmfLogs              = "emf_logs"
+	PipelineNameOtlpLogs             = "otlp_logs"
 	PipelineNamePrometheus           = "prometheus"
 	PipelineNameKueue                = "kueueContainerInsights"
+	PipelineNameHeartbeat            = "heartbeat"
 	AppSignals                       = "application_signals"
 	AppSignalsFallback               = "app_signals"
 	AppSignalsRules                  = "rules"
@@ -57,6 +57,27 @@ func TestGetMetricsDestinations(t *testing.T) {
 			},
 			want: []string{CloudWatchKey, AMPKey},
 		},
+		"WithMetrics/Timestream": {
+			input: map[string]any{
+				"metrics": map[string]any{
+					"metrics_destinations": map[string]any{
+						"timestream": map[string]any{},
+					},
+				},
+			},
+			want: []string{TimestreamKey},
+		},
+		"WithMetrics/CloudWatch&Timestream": {
+			input: map[string]any{
+				"metrics": map[string]any{
+					"metrics_destinations": map[string]any{
+						"cloudwatch": map[string]any{},
+						"timestream": map[string]any{},
+					},
+				},
+			},
+			want: []string{CloudWatchKey, TimestreamKey},
+		},
 	}
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -4,6 +4,9 @@
 package common
 
 import (
+	"fmt"
+	"log"
+	"regexp"
 	"strings"
 
 	"go.opentelemetry.io/collector/confmap"
@@ -15,6 +18,9 @@ import (
 
 const (
 	dropOriginalWildcard = "*"
+
+	TemporalityDelta      = "delta"
+	TemporalityCumulative = "cumulative"
 )
 
 // Map to support dropping metrics without measurement.
@@ -175,3 +181,39 @@ func GetDropOriginalMetrics(conf *confmap.Conf) map[string]bool {
 	}
 	return dropOriginalMetrics
 }
+
+// GetMetricTemporality returns the metric name patterns of the metric_temporality
+// rules that pin the output temporality to delta and to cumulative. A pattern
+// given with both temporalities keeps the first rule.
+func GetMetricTemporality(conf *confmap.Conf) (delta []string, cumulative []string, err error) {
+	key := ConfigKey(MetricsKey, MetricTemporalityKey)
+	rules := GetArray[any](conf, key)
+	seen := make(map[string]string, len(rules))
+	for i, rule := range rules {
+		ruleMap, ok := rule.(map[string]any)
+		if !ok {
+			return nil, nil, fmt.Errorf("invalid %s rule at index %d", key, i)
+		}
+		pattern, _ := ruleMap[PatternKey].(string)
+		if _, compileErr := regexp.Compile(pattern); pattern == "" || compileErr != nil {
+			return nil, nil, fmt.Errorf("invalid %s pattern %q at index %d", key, pattern, i)
+		}
+		temporality, _ := ruleMap[TemporalityKey].(string)
+		if temporality != TemporalityDelta && temporality != TemporalityCumulative {
+			return nil, nil, fmt.Errorf("invalid %s temporality %q at index %d, must be %q or %q", key, temporality, i, TemporalityDelta, TemporalityCumulative)
+		}
+		if previous, ok := seen[pattern]; ok {
+			if previous != temporality {
+				log.Printf("W! %s pattern %q is pinned to both %s and %s, using %s", key, pattern, previous, temporality, previous)
+			}
+			continue
+		}
+		seen[pattern] = temporality
+		if temporality == TemporalityDelta {
+			delta = append(delta, pattern)
+		} else {
+			cumulative = append(cumulative, pattern)
+		}
+	}
+	return delta, cumulative, nil
+}
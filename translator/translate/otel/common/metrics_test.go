@@ -31,3 +31,67 @@ func TestGetDropOriginalMetrics(t *testing.T) {
 		"tx_packets":  true,
 	}, GetDropOriginalMetrics(conf))
 }
+
+func TestGetMetricTemporality(t *testing.T) {
+	testCases := map[string]struct {
+		rules          []any
+		wantDelta      []string
+		wantCumulative []string
+		wantErr        string
+	}{
+		"Empty": {},
+		"PinnedRules": {
+			rules: []any{
+				map[string]any{"pattern": "^errors_", "temporality": "cumulative"},
+				map[string]any{"pattern": "^requests_", "temporality": "delta"},
+				map[string]any{"pattern": "^latency_", "temporality": "cumulative"},
+			},
+			wantDelta:      []string{"^requests_"},
+			wantCumulative: []string{"^errors_", "^latency_"},
+		},
+		"ConflictKeepsFirst": {
+			rules: []any{
+				map[string]any{"pattern": "^errors_", "temporality": "delta"},
+				map[string]any{"pattern": "^errors_", "temporality": "cumulative"},
+				map[string]any{"pattern": "^errors_", "temporality": "delta"},
+			},
+			wantDelta: []string{"^errors_"},
+		},
+		"InvalidPattern": {
+			rules:   []any{map[string]any{"pattern": "(", "temporality": "delta"}},
+			wantErr: "invalid metrics::metric_temporality pattern",
+		},
+		"MissingPattern": {
+			rules:   []any{map[string]any{"temporality": "delta"}},
+			wantErr: "invalid metrics::metric_temporality pattern",
+		},
+		"InvalidTemporality": {
+			rules:   []any{map[string]any{"pattern": "^errors_", "temporality": "gauge"}},
+			wantErr: "invalid metrics::metric_temporality temporality",
+		},
+		"InvalidRule": {
+			rules:   []any{"^errors_"},
+			wantErr: "invalid metrics::metric_temporality rule",
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(map[string]any{
+				"metrics": map[string]any{},
+			})
+			if testCase.rules != nil {
+				conf = confmap.NewFromStringMap(map[string]any{
+					"metrics": map[string]any{"metric_temporality": testCase.rules},
+				})
+			}
+			delta, cumulative, err := GetMetricTemporality(conf)
+			if testCase.wantErr != "" {
+				assert.ErrorContains(t, err, testCase.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.wantDelta, delta)
+			assert.Equal(t, testCase.wantCumulative, cumulative)
+		})
+	}
+}
@@ -4,6 +4,9 @@
 package awscloudwatch
 
 import (
+	"fmt"
+	"slices"
+
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/exporter"
@@ -15,9 +18,19 @@ import (
 )
 
 const (
-	namespaceKey          = "namespace"
-	forceFlushIntervalKey = "force_flush_interval"
-	dropOriginalWildcard  = "*"
+	namespaceKey           = "namespace"
+	forceFlushIntervalKey  = "force_flush_interval"
+	dimensionKeysKey       = "dimension_keys"
+	maxInFlightRequestsKey = "max_in_flight_requests"
+	replicaRegionsKey      = "replica_regions"
+	dimensionLimitKey      = "dimension_limit_action"
+	aggregationIntervalKey = "aggregation_intervals"
+	allowedNamespacesKey   = "allowed_namespaces"
+	strictNamespacesKey    = "strict_namespaces"
+	pipelineIDDimensionKey = "pipeline_id_dimension"
+	maxMetricAgeKey        = "max_metric_age"
+	validatePermissionsKey = "validate_permissions"
+	dropOriginalWildcard   = "*"
 
 	internalMaxValuesPerDatum = 5000
 )
@@ -62,6 +75,9 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 	if forceFlushInterval, ok := common.GetDuration(conf, common.ConfigKey(common.MetricsKey, forceFlushIntervalKey)); ok {
 		cfg.ForceFlushInterval = forceFlushInterval
 	}
+	if maxInFlightRequests, ok := common.GetNumber(conf, common.ConfigKey(common.MetricsKey, maxInFlightRequestsKey)); ok {
+		cfg.MaxInFlightRequests = int(maxInFlightRequests)
+	}
 	if agent.Global_Config.Internal {
 		cfg.MaxValuesPerDatum = internalMaxValuesPerDatum
 	}
@@ -71,7 +87,53 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 	if dropOriginalMetrics := common.GetDropOriginalMetrics(conf); len(dropOriginalMetrics) != 0 {
 		cfg.DropOriginalConfigs = dropOriginalMetrics
 	}
+	if dimensionKeys := common.GetArray[string](conf, common.ConfigKey(common.MetricsKey, dimensionKeysKey)); len(dimensionKeys) != 0 {
+		cfg.DimensionKeys = dimensionKeys
+	}
+	if replicaRegions := common.GetArray[string](conf, common.ConfigKey(common.MetricsKey, replicaRegionsKey)); len(replicaRegions) != 0 {
+		cfg.ReplicaRegions = replicaRegions
+	}
+	if dimensionLimitAction, ok := common.GetString(conf, common.ConfigKey(common.MetricsKey, dimensionLimitKey)); ok {
+		cfg.DimensionLimitAction = dimensionLimitAction
+	}
+	if allowedNamespaces := common.GetArray[string](conf, common.ConfigKey(common.MetricsKey, allowedNamespacesKey)); len(allowedNamespaces) != 0 {
+		cfg.AllowedNamespaces = allowedNamespaces
+	}
+	if strictNamespaces, ok := common.GetBool(conf, common.ConfigKey(common.MetricsKey, strictNamespacesKey)); ok {
+		cfg.StrictNamespaces = strictNamespaces
+	}
+	if maxMetricAge, ok := common.GetDuration(conf, common.ConfigKey(common.MetricsKey, maxMetricAgeKey)); ok {
+		cfg.MaxMetricAge = maxMetricAge
+	}
+	if validatePermissions, ok := common.GetBool(conf, common.ConfigKey(common.MetricsKey, validatePermissionsKey)); ok {
+		cfg.ValidatePermissions = validatePermissions
+	}
+	if pipelineIDDimension, ok := common.GetBool(conf, common.ConfigKey(common.MetricsKey, pipelineIDDimensionKey)); ok {
+		cfg.PipelineIDDimension = pipelineIDDimension
+	}
+	aggregationIntervals, err := getAggregationIntervals(conf)
+	if err != nil {
+		return nil, err
+	}
+	cfg.AggregationIntervals = aggregationIntervals
 	cfg.MiddlewareID = &agenthealth.MetricsID
+	if t.name == common.PipelineNameHeartbeat {
+		// the heartbeats keep their own dimensions and can be published to their own namespace
+		cfg.RollupDimensions = nil
+		cfg.DropOriginalConfigs = nil
+		cfg.DimensionKeys = nil
+		cfg.AggregationIntervals = nil
+		cfg.PipelineIDDimension = false
+		// the heartbeats are sent after an outage to report it, so they are not dropped for their age
+		cfg.MaxMetricAge = 0
+		if namespace, ok := common.GetString(conf, common.ConfigKey(common.MetricsKey, common.HeartbeatKey, namespaceKey)); ok {
+			cfg.Namespace = namespace
+		}
+		// the exporter would drop every heartbeat, so the namespace has to be allowed explicitly
+		if len(cfg.AllowedNamespaces) != 0 && !slices.Contains(cfg.AllowedNamespaces, cfg.Namespace) {
+			return nil, fmt.Errorf("heartbeat namespace %s is not in %s %v", cfg.Namespace, allowedNamespacesKey, cfg.AllowedNamespaces)
+		}
+	}
 	return cfg, nil
 }
 
@@ -83,3 +145,38 @@ func getRoleARN(conf *confmap.Conf) string {
 	}
 	return roleARN
 }
+
+// getAggregationIntervals gets the aggregation interval rules, such as
+//
+//	{"namespace": "CWAgent", "metric_names": ["^cpu_"], "interval": "1m"}
+//
+// where the interval is a duration or a number of seconds.
+func getAggregationIntervals(conf *confmap.Conf) ([]cloudwatch.AggregationInterval, error) {
+	key := common.ConfigKey(common.MetricsKey, aggregationIntervalKey)
+	var intervals []cloudwatch.AggregationInterval
+	for i, rule := range common.GetArray[any](conf, key) {
+		ruleMap, ok := rule.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("invalid %s rule at index %d", key, i)
+		}
+		interval, err := common.ParseDuration(ruleMap["interval"])
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s interval at index %d: %v", key, i, err)
+		}
+		namespace, _ := ruleMap[namespaceKey].(string)
+		var metricNames []string
+		if names, ok := ruleMap["metric_names"].([]any); ok {
+			for _, name := range names {
+				if s, ok := name.(string); ok {
+					metricNames = append(metricNames, s)
+				}
+			}
+		}
+		intervals = append(intervals, cloudwatch.AggregationInterval{
+			Namespace:   namespace,
+			MetricNames: metricNames,
+			Interval:    interval,
+		})
+	}
+	return intervals, nil
+}
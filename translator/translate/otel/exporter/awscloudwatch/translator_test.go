@@ -4,6 +4,7 @@
 package awscloudwatch
 
 import (
+	"errors"
 	"path/filepath"
 	"runtime"
 	"testing"
@@ -42,11 +43,12 @@ func TestTranslator(t *testing.T) {
 		"WithDefault": {
 			input: map[string]interface{}{"metrics": map[string]interface{}{}},
 			want: &cloudwatch.Config{
-				Namespace:          "CWAgent",
-				Region:             "us-east-1",
-				ForceFlushInterval: time.Minute,
-				MaxValuesPerDatum:  150,
-				RoleARN:            "global_arn",
+				Namespace:           "CWAgent",
+				Region:              "us-east-1",
+				ForceFlushInterval:  time.Minute,
+				MaxValuesPerDatum:   150,
+				MaxInFlightRequests: 10,
+				RoleARN:             "global_arn",
 			},
 		},
 		"WithEndpointOverride": {
@@ -54,12 +56,154 @@ func TestTranslator(t *testing.T) {
 				"endpoint_override": "https://monitoring-fips.us-east-1.amazonaws.com",
 			}},
 			want: &cloudwatch.Config{
-				Namespace:          "CWAgent",
-				Region:             "us-east-1",
-				ForceFlushInterval: time.Minute,
-				MaxValuesPerDatum:  150,
-				EndpointOverride:   "https://monitoring-fips.us-east-1.amazonaws.com",
-				RoleARN:            "global_arn",
+				Namespace:           "CWAgent",
+				Region:              "us-east-1",
+				ForceFlushInterval:  time.Minute,
+				MaxValuesPerDatum:   150,
+				MaxInFlightRequests: 10,
+				EndpointOverride:    "https://monitoring-fips.us-east-1.amazonaws.com",
+				RoleARN:             "global_arn",
+			},
+		},
+		"WithDimensionKeys": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"dimension_keys": []interface{}{"InstanceId", "host"},
+			}},
+			want: &cloudwatch.Config{
+				Namespace:           "CWAgent",
+				Region:              "us-east-1",
+				ForceFlushInterval:  time.Minute,
+				MaxValuesPerDatum:   150,
+				MaxInFlightRequests: 10,
+				RoleARN:             "global_arn",
+				DimensionKeys:       []string{"InstanceId", "host"},
+			},
+		},
+		"WithReplicaRegions": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"replica_regions": []interface{}{"us-west-2"},
+			}},
+			want: &cloudwatch.Config{
+				Namespace:           "CWAgent",
+				Region:              "us-east-1",
+				ForceFlushInterval:  time.Minute,
+				MaxValuesPerDatum:   150,
+				MaxInFlightRequests: 10,
+				RoleARN:             "global_arn",
+				ReplicaRegions:      []string{"us-west-2"},
+			},
+		},
+		"WithDimensionLimitAction": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"dimension_limit_action": "drop",
+			}},
+			want: &cloudwatch.Config{
+				Namespace:            "CWAgent",
+				Region:               "us-east-1",
+				ForceFlushInterval:   time.Minute,
+				MaxValuesPerDatum:    150,
+				MaxInFlightRequests:  10,
+				RoleARN:              "global_arn",
+				DimensionLimitAction: "drop",
+			},
+		},
+		"WithAggregationIntervals": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"aggregation_intervals": []interface{}{
+					map[string]interface{}{"namespace": "CWAgent", "metric_names": []interface{}{"^cpu_"}, "interval": "1m"},
+					map[string]interface{}{"metric_names": []interface{}{"^requests_"}, "interval": float64(0)},
+				},
+			}},
+			want: &cloudwatch.Config{
+				Namespace:           "CWAgent",
+				Region:              "us-east-1",
+				ForceFlushInterval:  time.Minute,
+				MaxValuesPerDatum:   150,
+				MaxInFlightRequests: 10,
+				RoleARN:             "global_arn",
+				AggregationIntervals: []cloudwatch.AggregationInterval{
+					{Namespace: "CWAgent", MetricNames: []string{"^cpu_"}, Interval: time.Minute},
+					{MetricNames: []string{"^requests_"}},
+				},
+			},
+		},
+		"WithInvalidAggregationInterval": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"aggregation_intervals": []interface{}{
+					map[string]interface{}{"metric_names": []interface{}{"^cpu_"}},
+				},
+			}},
+			wantErr: errors.New("invalid metrics::aggregation_intervals interval at index 0: invalid type <nil>"),
+		},
+		"WithAllowedNamespaces": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"allowed_namespaces": []interface{}{"CWAgent", "Custom"},
+				"strict_namespaces":  true,
+			}},
+			want: &cloudwatch.Config{
+				Namespace:           "CWAgent",
+				Region:              "us-east-1",
+				ForceFlushInterval:  time.Minute,
+				MaxValuesPerDatum:   150,
+				MaxInFlightRequests: 10,
+				RoleARN:             "global_arn",
+				AllowedNamespaces:   []string{"CWAgent", "Custom"},
+				StrictNamespaces:    true,
+			},
+		},
+		"WithPipelineIDDimension": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"pipeline_id_dimension": true,
+			}},
+			want: &cloudwatch.Config{
+				Namespace:           "CWAgent",
+				Region:              "us-east-1",
+				ForceFlushInterval:  time.Minute,
+				MaxValuesPerDatum:   150,
+				MaxInFlightRequests: 10,
+				RoleARN:             "global_arn",
+				PipelineIDDimension: true,
+			},
+		},
+		"WithMaxMetricAge": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"max_metric_age": "2h",
+			}},
+			want: &cloudwatch.Config{
+				Namespace:           "CWAgent",
+				Region:              "us-east-1",
+				ForceFlushInterval:  time.Minute,
+				MaxValuesPerDatum:   150,
+				MaxInFlightRequests: 10,
+				RoleARN:             "global_arn",
+				MaxMetricAge:        2 * time.Hour,
+			},
+		},
+		"WithValidatePermissions": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"validate_permissions": true,
+			}},
+			want: &cloudwatch.Config{
+				Namespace:           "CWAgent",
+				Region:              "us-east-1",
+				ForceFlushInterval:  time.Minute,
+				MaxValuesPerDatum:   150,
+				MaxInFlightRequests: 10,
+				RoleARN:             "global_arn",
+				ValidatePermissions: true,
+			},
+		},
+		"WithMaxInFlightRequests": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"max_in_flight_requests": 4,
+			}},
+			want: &cloudwatch.Config{
+				Namespace:           "CWAgent",
+				Region:              "us-east-1",
+				ForceFlushInterval:  time.Minute,
+				MaxValuesPerDatum:   150,
+				MaxInFlightRequests: 4,
+				RoleARN:             "global_arn",
 			},
 		},
 		"WithInvalidCredentialFields": {
@@ -72,14 +216,15 @@ func TestTranslator(t *testing.T) {
 				"shared_cred": "invalid field name",
 			},
 			want: &cloudwatch.Config{
-				Namespace:          "CWAgent",
-				Region:             "us-east-1",
-				ForceFlushInterval: time.Minute,
-				MaxValuesPerDatum:  150,
-				RoleARN:            "global_arn",
-				AccessKey:          "access_key",
-				SecretKey:          "secret_key",
-				Token:              "token",
+				Namespace:           "CWAgent",
+				Region:              "us-east-1",
+				ForceFlushInterval:  time.Minute,
+				MaxValuesPerDatum:   150,
+				MaxInFlightRequests: 10,
+				RoleARN:             "global_arn",
+				AccessKey:           "access_key",
+				SecretKey:           "secret_key",
+				Token:               "token",
 			},
 		},
 		"WithValidCredentials": {
@@ -96,6 +241,7 @@ func TestTranslator(t *testing.T) {
 				Region:                   "us-east-1",
 				ForceFlushInterval:       time.Minute,
 				MaxValuesPerDatum:        150,
+				MaxInFlightRequests:      10,
 				RoleARN:                  "global_arn",
 				AccessKey:                "access_key",
 				SecretKey:                "secret_key",
@@ -108,13 +254,14 @@ func TestTranslator(t *testing.T) {
 			input:    testutil.GetJson(t, filepath.Join("..", "..", "common", "testdata", "config.json")),
 			internal: true,
 			want: &cloudwatch.Config{
-				Namespace:          "namespace",
-				Region:             "us-east-1",
-				ForceFlushInterval: 30 * time.Second,
-				MaxValuesPerDatum:  5000,
-				EndpointOverride:   "https://monitoring-fips.us-west-2.amazonaws.com",
-				RoleARN:            "metrics_role_arn_value_test",
-				RollupDimensions:   [][]string{{"ImageId"}, {"InstanceId", "InstanceType"}, {"d1"}, {}},
+				Namespace:           "namespace",
+				Region:              "us-east-1",
+				ForceFlushInterval:  30 * time.Second,
+				MaxValuesPerDatum:   5000,
+				MaxInFlightRequests: 10,
+				EndpointOverride:    "https://monitoring-fips.us-west-2.amazonaws.com",
+				RoleARN:             "metrics_role_arn_value_test",
+				RollupDimensions:    [][]string{{"ImageId"}, {"InstanceId", "InstanceType"}, {"d1"}, {}},
 				DropOriginalConfigs: map[string]bool{
 					"CPU_USAGE_IDLE":  true,
 					"collectd_drop":   true,
@@ -124,13 +271,14 @@ func TestTranslator(t *testing.T) {
 				},
 			},
 			wantWindows: &cloudwatch.Config{
-				Namespace:          "namespace",
-				Region:             "us-east-1",
-				ForceFlushInterval: 30 * time.Second,
-				MaxValuesPerDatum:  5000,
-				EndpointOverride:   "https://monitoring-fips.us-west-2.amazonaws.com",
-				RoleARN:            "metrics_role_arn_value_test",
-				RollupDimensions:   [][]string{{"ImageId"}, {"InstanceId", "InstanceType"}, {"d1"}, {}},
+				Namespace:           "namespace",
+				Region:              "us-east-1",
+				ForceFlushInterval:  30 * time.Second,
+				MaxValuesPerDatum:   5000,
+				MaxInFlightRequests: 10,
+				EndpointOverride:    "https://monitoring-fips.us-west-2.amazonaws.com",
+				RoleARN:             "metrics_role_arn_value_test",
+				RollupDimensions:    [][]string{{"ImageId"}, {"InstanceId", "InstanceType"}, {"d1"}, {}},
 				DropOriginalConfigs: map[string]bool{
 					"CPU_USAGE_IDLE":  true,
 					"collectd_drop":   true,
@@ -163,7 +311,14 @@ func TestTranslator(t *testing.T) {
 				assert.Equal(t, testCase.want.Profile, gotCfg.Profile)
 				assert.Equal(t, testCase.want.SharedCredentialFilename, gotCfg.SharedCredentialFilename)
 				assert.Equal(t, testCase.want.MaxValuesPerDatum, gotCfg.MaxValuesPerDatum)
+				assert.Equal(t, testCase.want.MaxInFlightRequests, gotCfg.MaxInFlightRequests)
 				assert.Equal(t, testCase.want.RollupDimensions, gotCfg.RollupDimensions)
+				assert.Equal(t, testCase.want.DimensionKeys, gotCfg.DimensionKeys)
+				assert.Equal(t, testCase.want.ReplicaRegions, gotCfg.ReplicaRegions)
+				assert.Equal(t, testCase.want.DimensionLimitAction, gotCfg.DimensionLimitAction)
+				assert.Equal(t, testCase.want.AggregationIntervals, gotCfg.AggregationIntervals)
+				assert.Equal(t, testCase.want.AllowedNamespaces, gotCfg.AllowedNamespaces)
+				assert.Equal(t, testCase.want.StrictNamespaces, gotCfg.StrictNamespaces)
 				assert.NotNil(t, gotCfg.MiddlewareID)
 				assert.Equal(t, "agenthealth/metrics", gotCfg.MiddlewareID.String())
 				if testCase.wantWindows != nil && runtime.GOOS == "windows" {
@@ -175,3 +330,86 @@ func TestTranslator(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslatorHeartbeat(t *testing.T) {
+	agent.Global_Config.Region = "us-east-1"
+	agent.Global_Config.Internal = false
+	agent.Global_Config.Credentials = nil
+	cwt := NewTranslatorWithName(common.PipelineNameHeartbeat)
+	require.EqualValues(t, "awscloudwatch/heartbeat", cwt.ID().String())
+	testCases := map[string]struct {
+		input         map[string]any
+		wantNamespace string
+		wantErr       error
+	}{
+		"WithMetricsNamespace": {
+			input: map[string]any{"metrics": map[string]any{
+				"namespace": "MyNamespace",
+				"heartbeat": map[string]any{},
+			}},
+			wantNamespace: "MyNamespace",
+		},
+		"WithHeartbeatNamespace": {
+			input: map[string]any{"metrics": map[string]any{
+				"namespace": "MyNamespace",
+				"heartbeat": map[string]any{
+					"namespace": "CWAgent/Heartbeat",
+				},
+				"aggregation_dimensions": []any{[]any{"ImageId"}},
+				"dimension_keys":         []any{"InstanceId"},
+				"pipeline_id_dimension":  true,
+			}},
+			wantNamespace: "CWAgent/Heartbeat",
+		},
+		"WithAllowedHeartbeatNamespace": {
+			input: map[string]any{"metrics": map[string]any{
+				"namespace": "MyNamespace",
+				"heartbeat": map[string]any{
+					"namespace": "CWAgent/Heartbeat",
+				},
+				"allowed_namespaces": []any{"MyNamespace", "CWAgent/Heartbeat"},
+				"strict_namespaces":  true,
+				"max_metric_age":     "1h",
+			}},
+			wantNamespace: "CWAgent/Heartbeat",
+		},
+		"WithDisallowedHeartbeatNamespace": {
+			input: map[string]any{"metrics": map[string]any{
+				"namespace": "MyNamespace",
+				"heartbeat": map[string]any{
+					"namespace": "CWAgent/Heartbeat",
+				},
+				"allowed_namespaces": []any{"MyNamespace"},
+			}},
+			wantErr: errors.New("heartbeat namespace CWAgent/Heartbeat is not in allowed_namespaces [MyNamespace]"),
+		},
+		"WithDisallowedMetricsNamespace": {
+			input: map[string]any{"metrics": map[string]any{
+				"namespace":          "MyNamespace",
+				"heartbeat":          map[string]any{},
+				"allowed_namespaces": []any{"OtherNamespace"},
+				"strict_namespaces":  true,
+			}},
+			wantErr: errors.New("heartbeat namespace MyNamespace is not in allowed_namespaces [OtherNamespace]"),
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := cwt.Translate(confmap.NewFromStringMap(testCase.input))
+			if testCase.wantErr != nil {
+				assert.EqualError(t, err, testCase.wantErr.Error())
+				return
+			}
+			require.NoError(t, err)
+			gotCfg, ok := got.(*cloudwatch.Config)
+			require.True(t, ok)
+			assert.Equal(t, testCase.wantNamespace, gotCfg.Namespace)
+			// the heartbeats are published with their own dimensions
+			assert.Nil(t, gotCfg.RollupDimensions)
+			assert.Nil(t, gotCfg.DimensionKeys)
+			assert.Nil(t, gotCfg.DropOriginalConfigs)
+			assert.False(t, gotCfg.PipelineIDDimension)
+			assert.Zero(t, gotCfg.MaxMetricAge)
+		})
+	}
+}
@@ -24,7 +24,8 @@ import (
 )
 
 const (
-	defaultLogGroupName = "emf/logs/default"
+	defaultLogGroupName     = "emf/logs/default"
+	defaultOtlpLogGroupName = "otlp/logs/default"
 )
 
 var (
@@ -32,6 +33,7 @@ var (
 	roleARNPathKey      = common.ConfigKey(common.LogsKey, common.CredentialsKey, common.RoleARNKey)
 	endpointOverrideKey = common.ConfigKey(common.LogsKey, common.EndpointOverrideKey)
 	streamNameKey       = common.ConfigKey(common.LogsKey, common.LogStreamName)
+	otlpLogsKey         = common.ConfigKey(common.LogsKey, common.LogsCollectedKey, common.OtlpKey)
 )
 
 type translator struct {
@@ -59,6 +61,10 @@ func (t *translator) Translate(c *confmap.Conf) (component.Config, error) {
 		if err := t.setEmfFields(c, cfg); err != nil {
 			return nil, err
 		}
+	} else if t.name == common.PipelineNameOtlpLogs && c.IsSet(otlpLogsKey) {
+		if err := t.setOtlpFields(c, cfg); err != nil {
+			return nil, err
+		}
 	}
 
 	cfg.AWSSessionSettings.CertificateFilePath = os.Getenv(envconfig.AWS_CA_BUNDLE)
@@ -96,12 +102,45 @@ func (t *translator) setEmfFields(conf *confmap.Conf, cfg *awscloudwatchlogsexpo
 	cfg.RawLog = true
 	cfg.LogGroupName = defaultLogGroupName
 
-	rule := logs.LogStreamName{}
-	_, val := rule.ApplyRule(conf.Get(common.LogsKey))
-	if logStreamName, ok := val.(map[string]any)[common.LogStreamName]; !ok {
+	logStreamName, ok := t.logStreamName(conf.Get(common.LogsKey))
+	if !ok {
 		return &common.MissingKeyError{ID: t.ID(), JsonKey: streamNameKey}
-	} else {
-		cfg.LogStreamName = logStreamName.(string)
 	}
+	cfg.LogStreamName = logStreamName
 	return nil
 }
+
+// setOtlpFields sends the OTLP log records, which are converted to CloudWatch
+// Logs events by the cwlogs processor, as raw logs. The log group and stream
+// configured here are used for records whose resource does not set them.
+func (t *translator) setOtlpFields(conf *confmap.Conf, cfg *awscloudwatchlogsexporter.Config) error {
+	cfg.Region = agent.Global_Config.Region
+	cfg.RawLog = true
+	cfg.LogGroupName = defaultOtlpLogGroupName
+	if logGroupName, ok := common.GetString(conf, common.ConfigKey(otlpLogsKey, common.LogGroupName)); ok {
+		cfg.LogGroupName = logGroupName
+	}
+
+	// fall back to the log_stream_name of the logs section
+	input := conf.Get(common.LogsKey)
+	if conf.IsSet(common.ConfigKey(otlpLogsKey, common.LogStreamName)) {
+		input = conf.Get(otlpLogsKey)
+	}
+	logStreamName, ok := t.logStreamName(input)
+	if !ok {
+		return &common.MissingKeyError{ID: t.ID(), JsonKey: streamNameKey}
+	}
+	cfg.LogStreamName = logStreamName
+	return nil
+}
+
+func (t *translator) logStreamName(input any) (string, bool) {
+	rule := logs.LogStreamName{}
+	_, val := rule.ApplyRule(input)
+	res, _ := val.(map[string]any)
+	logStreamName, ok := res[common.LogStreamName]
+	if !ok {
+		return "", false
+	}
+	return logStreamName.(string), true
+}
@@ -169,3 +169,89 @@ func TestTranslator(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslatorOtlpLogs(t *testing.T) {
+	t.Setenv(envconfig.AWS_CA_BUNDLE, "")
+	agent.Global_Config.Region = "us-east-1"
+	agent.Global_Config.Role_arn = ""
+	agent.Global_Config.Credentials = map[string]any{}
+	globallogs.GlobalLogConfig.MetadataInfo = logsutil.GetMetadataInfo(testMetadata)
+	tt := NewTranslatorWithName(common.PipelineNameOtlpLogs)
+	require.EqualValues(t, "awscloudwatchlogs/otlp_logs", tt.ID().String())
+	testCases := map[string]struct {
+		input map[string]any
+		want  *confmap.Conf
+	}{
+		"WithDefaults": {
+			input: map[string]any{
+				"logs": map[string]any{
+					"logs_collected": map[string]any{
+						"otlp": map[string]any{},
+					},
+				},
+			},
+			want: confmap.NewFromStringMap(map[string]any{
+				"imds_retries":    1,
+				"log_group_name":  "otlp/logs/default",
+				"log_stream_name": "some_instance_id",
+				"middleware":      "agenthealth/logs",
+				"raw_log":         true,
+				"region":          "us-east-1",
+			}),
+		},
+		"WithLogsLogStreamName": {
+			input: map[string]any{
+				"logs": map[string]any{
+					"logs_collected": map[string]any{
+						"otlp": map[string]any{},
+					},
+					"log_stream_name": "{hostname}",
+				},
+			},
+			want: confmap.NewFromStringMap(map[string]any{
+				"imds_retries":    1,
+				"log_group_name":  "otlp/logs/default",
+				"log_stream_name": "some_hostname",
+				"middleware":      "agenthealth/logs",
+				"raw_log":         true,
+				"region":          "us-east-1",
+			}),
+		},
+		"WithOtlpLogGroupAndStream": {
+			input: map[string]any{
+				"logs": map[string]any{
+					"logs_collected": map[string]any{
+						"otlp": map[string]any{
+							"log_group_name":  "app",
+							"log_stream_name": "{instance_id}/otlp",
+						},
+					},
+					"log_stream_name": "{hostname}",
+				},
+			},
+			want: confmap.NewFromStringMap(map[string]any{
+				"imds_retries":    1,
+				"log_group_name":  "app",
+				"log_stream_name": "some_instance_id/otlp",
+				"middleware":      "agenthealth/logs",
+				"raw_log":         true,
+				"region":          "us-east-1",
+			}),
+		},
+	}
+	factory := awscloudwatchlogsexporter.NewFactory()
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			translatorcontext.CurrentContext().SetMode(config.ModeEC2)
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			require.NoError(t, err)
+			require.Truef(t, legacytranslator.IsTranslateSuccess(), "Error in legacy translation rules: %v", legacytranslator.ErrorMessages)
+			gotCfg, ok := got.(*awscloudwatchlogsexporter.Config)
+			require.True(t, ok)
+			wantCfg := factory.CreateDefaultConfig()
+			require.NoError(t, testCase.want.Unmarshal(wantCfg))
+			assert.Equal(t, wantCfg, gotCfg)
+		})
+	}
+}
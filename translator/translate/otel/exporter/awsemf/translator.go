@@ -53,6 +53,8 @@ var (
 	emfProcessorBasePathKey    = common.ConfigKey(prometheusBasePathKey, common.EMFProcessorKey)
 	endpointOverrideKey        = common.ConfigKey(common.LogsKey, common.EndpointOverrideKey)
 	roleARNPathKey             = common.ConfigKey(common.LogsKey, common.CredentialsKey, common.RoleARNKey)
+	dimensionKeysKey           = common.ConfigKey(common.MetricsKey, common.DimensionKeysKey)
+	keepDroppedDimensionsKey   = common.ConfigKey(common.MetricsKey, common.KeepDroppedDimensionsKey)
 )
 
 type translator struct {
@@ -148,6 +150,8 @@ func (t *translator) Translate(c *confmap.Conf) (component.Config, error) {
 		if err := setPrometheusFields(c, cfg); err != nil {
 			return nil, err
 		}
+	} else if t.name == "" {
+		setDimensionKeysFields(c, cfg)
 	}
 	return cfg, nil
 }
@@ -243,6 +247,22 @@ func setPrometheusFields(conf *confmap.Conf, cfg *awsemfexporter.Config) error {
 	return nil
 }
 
+// setDimensionKeysFields declares the dimension_keys of the metrics section as the dimensions of all of the metrics
+// when keep_dropped_dimensions is set, so that the other attributes are stored in the EMF logs as fields instead of
+// being dimensions. Otherwise, the other attributes are dropped by the host pipeline before the exporter.
+func setDimensionKeysFields(conf *confmap.Conf, cfg *awsemfexporter.Config) {
+	keys := common.GetArray[string](conf, dimensionKeysKey)
+	if keep, _ := common.GetBool(conf, keepDroppedDimensionsKey); len(keys) == 0 || !keep {
+		return
+	}
+	cfg.MetricDeclarations = []*awsemfexporter.MetricDeclaration{
+		{
+			Dimensions:          [][]string{keys},
+			MetricNameSelectors: []string{".*"},
+		},
+	}
+}
+
 func setDisableMetricExtraction(baseKey string, conf *confmap.Conf, cfg *awsemfexporter.Config) {
 	cfg.DisableMetricExtraction = common.GetOrDefaultBool(conf, common.ConfigKey(baseKey, common.DisableMetricExtraction), false)
 }
@@ -403,21 +403,21 @@ func TestTranslator(t *testing.T) {
 						MetricNameSelectors: []string{"apiserver_flowcontrol_request_concurrency_limit"},
 					},
 					{
-						Dimensions: [][]string{{"ClusterName"}, {"ClusterName", "Namespace", "PodName", "ContainerName"}, {"ClusterName", "Namespace", "PodName", "FullPodName", "ContainerName"}, {"ClusterName", "Namespace", "PodName", "FullPodName", "ContainerName", "GpuDevice"}},
+						Dimensions: [][]string{{"ClusterName"}, {"ClusterName", "Namespace", "PodName", "ContainerName"}, {"ClusterName", "Namespace", "PodName", "FullPodName", "ContainerName"}, {"ClusterName", "Namespace", "PodName", "FullPodName", "ContainerName", "GpuDevice"}, {"ClusterName", "Namespace", "PodName", "FullPodName", "ContainerName", "GpuDevice", "MigDevice"}},
 						MetricNameSelectors: []string{
 							"container_gpu_utilization", "container_gpu_memory_utilization", "container_gpu_memory_total", "container_gpu_memory_used", "container_gpu_power_draw", "container_gpu_temperature",
 						},
 					},
 					{
-						Dimensions: [][]string{{"ClusterName"}, {"ClusterName", "Namespace"}, {"ClusterName", "Namespace", "Service"}, {"ClusterName", "Namespace", "PodName"}, {"ClusterName", "Namespace", "PodName", "FullPodName"}, {"ClusterName", "Namespace", "PodName", "FullPodName", "GpuDevice"}},
+						Dimensions: [][]string{{"ClusterName"}, {"ClusterName", "Namespace"}, {"ClusterName", "Namespace", "Service"}, {"ClusterName", "Namespace", "PodName"}, {"ClusterName", "Namespace", "PodName", "FullPodName"}, {"ClusterName", "Namespace", "PodName", "FullPodName", "GpuDevice"}, {"ClusterName", "Namespace", "PodName", "FullPodName", "GpuDevice", "MigDevice"}},
 						MetricNameSelectors: []string{
 							"pod_gpu_utilization", "pod_gpu_memory_utilization", "pod_gpu_memory_total", "pod_gpu_memory_used", "pod_gpu_power_draw", "pod_gpu_temperature",
 						},
 					},
 					{
-						Dimensions: [][]string{{"ClusterName"}, {"ClusterName", "NodeName", "InstanceId"}, {"ClusterName", "NodeName", "InstanceId", "InstanceType", "GpuDevice"}},
+						Dimensions: [][]string{{"ClusterName"}, {"ClusterName", "NodeName", "InstanceId"}, {"ClusterName", "NodeName", "InstanceId", "InstanceType", "GpuDevice"}, {"ClusterName", "NodeName", "InstanceId", "InstanceType", "GpuDevice", "MigDevice"}},
 						MetricNameSelectors: []string{
-							"node_gpu_utilization", "node_gpu_memory_utilization", "node_gpu_memory_total", "node_gpu_memory_used", "node_gpu_power_draw", "node_gpu_temperature",
+							"node_gpu_utilization", "node_gpu_memory_utilization", "node_gpu_memory_total", "node_gpu_memory_used", "node_gpu_power_draw", "node_gpu_temperature", "node_gpu_ecc_single_bit_errors", "node_gpu_ecc_double_bit_errors", "node_gpu_unsupported_version",
 						},
 					},
 					{
@@ -1069,3 +1069,40 @@ func TestTranslateAppSignals(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslateDimensionKeys(t *testing.T) {
+	tt := NewTranslator()
+	testCases := map[string]struct {
+		input map[string]any
+		want  []*awsemfexporter.MetricDeclaration
+	}{
+		"WithoutDimensionKeys": {
+			input: map[string]any{"metrics": map[string]any{"keep_dropped_dimensions": true}},
+		},
+		"WithDroppedDimensions": {
+			input: map[string]any{"metrics": map[string]any{"dimension_keys": []any{"InstanceId", "host"}}},
+		},
+		"WithKeptDimensions": {
+			input: map[string]any{"metrics": map[string]any{
+				"dimension_keys":          []any{"InstanceId", "host"},
+				"keep_dropped_dimensions": true,
+			}},
+			want: []*awsemfexporter.MetricDeclaration{
+				{
+					Dimensions:          [][]string{{"InstanceId", "host"}},
+					MetricNameSelectors: []string{".*"},
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.Translate(confmap.NewFromStringMap(testCase.input))
+			require.NoError(t, err)
+			gotCfg, ok := got.(*awsemfexporter.Config)
+			require.True(t, ok)
+			assert.Equal(t, "CWAgent", gotCfg.Namespace)
+			assert.Equal(t, testCase.want, gotCfg.MetricDeclarations)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package awstimestream
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/exporter"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/timestream"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+const (
+	databaseNameKey      = "database_name"
+	tableNameKey         = "table_name"
+	maxRecordsPerCallKey = "max_records_per_call"
+)
+
+var (
+	SectionKey = common.ConfigKey(common.MetricsKey, common.MetricsDestinationsKey, common.TimestreamKey)
+)
+
+type translator struct {
+	name    string
+	factory exporter.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+func NewTranslator() common.ComponentTranslator {
+	return NewTranslatorWithName("")
+}
+
+func NewTranslatorWithName(name string) common.ComponentTranslator {
+	return &translator{name, timestream.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+// Translate creates an exporter config based on the fields in the
+// timestream section of the metrics destinations.
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(SectionKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: SectionKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*timestream.Config)
+	credentials := confmap.NewFromStringMap(agent.Global_Config.Credentials)
+	_ = credentials.Unmarshal(cfg)
+	cfg.RoleARN = getRoleARN(conf)
+	cfg.Region = agent.Global_Config.Region
+	if databaseName, ok := common.GetString(conf, common.ConfigKey(SectionKey, databaseNameKey)); ok {
+		cfg.DatabaseName = databaseName
+	}
+	if tableName, ok := common.GetString(conf, common.ConfigKey(SectionKey, tableNameKey)); ok {
+		cfg.TableName = tableName
+	}
+	if maxRecordsPerCall, ok := common.GetNumber(conf, common.ConfigKey(SectionKey, maxRecordsPerCallKey)); ok {
+		cfg.MaxRecordsPerCall = int(maxRecordsPerCall)
+	}
+	if endpointOverride, ok := common.GetString(conf, common.ConfigKey(SectionKey, common.EndpointOverrideKey)); ok {
+		cfg.EndpointOverride = endpointOverride
+	}
+	return cfg, nil
+}
+
+func getRoleARN(conf *confmap.Conf) string {
+	key := common.ConfigKey(common.MetricsKey, common.CredentialsKey, common.RoleARNKey)
+	roleARN, ok := common.GetString(conf, key)
+	if !ok {
+		roleARN = agent.Global_Config.Role_arn
+	}
+	return roleARN
+}
@@ -0,0 +1,98 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package awstimestream
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/timestream"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/agent"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslator(t *testing.T) {
+	agent.Global_Config.Region = "us-east-1"
+	agent.Global_Config.Role_arn = "global_arn"
+	t.Cleanup(func() {
+		agent.Global_Config.Role_arn = ""
+	})
+	tt := NewTranslator()
+	require.EqualValues(t, "awstimestream", tt.ID().String())
+
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *timestream.Config
+		wantErr error
+	}{
+		"WithMissingDestination": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"metrics_destinations": map[string]interface{}{},
+				},
+			},
+			wantErr: &common.MissingKeyError{ID: tt.ID(), JsonKey: SectionKey},
+		},
+		"WithTable": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"metrics_destinations": map[string]interface{}{
+						"timestream": map[string]interface{}{
+							"database_name": "metrics",
+							"table_name":    "host",
+						},
+					},
+				},
+			},
+			want: &timestream.Config{
+				Region:            "us-east-1",
+				RoleARN:           "global_arn",
+				DatabaseName:      "metrics",
+				TableName:         "host",
+				MaxRecordsPerCall: 100,
+				BackOffConfig:     configretry.NewDefaultBackOffConfig(),
+			},
+		},
+		"WithAll": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"credentials": map[string]interface{}{
+						"role_arn": "metrics_arn",
+					},
+					"metrics_destinations": map[string]interface{}{
+						"timestream": map[string]interface{}{
+							"database_name":        "metrics",
+							"table_name":           "host",
+							"max_records_per_call": 50,
+							"endpoint_override":    "https://ingest-cell1.timestream.us-east-1.amazonaws.com",
+						},
+					},
+				},
+			},
+			want: &timestream.Config{
+				Region:            "us-east-1",
+				RoleARN:           "metrics_arn",
+				DatabaseName:      "metrics",
+				TableName:         "host",
+				MaxRecordsPerCall: 50,
+				EndpointOverride:  "https://ingest-cell1.timestream.us-east-1.amazonaws.com",
+				BackOffConfig:     configretry.NewDefaultBackOffConfig(),
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package debugfile
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/exporter"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/debugfile"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+var (
+	// ConfigKey is the debug output that the metrics and logs pipelines are written to.
+	ConfigKey = common.ConfigKey(common.AgentKey, "debug_output")
+	// ReplaceOutputsKey writes the metrics and logs pipelines to the debug output only, instead of sending them.
+	ReplaceOutputsKey = common.ConfigKey(ConfigKey, "replace_outputs")
+	pathKey           = common.ConfigKey(ConfigKey, "path")
+	sampleRateKey     = common.ConfigKey(ConfigKey, "sample_rate")
+)
+
+type translator struct {
+	name    string
+	factory exporter.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+func NewTranslator() common.ComponentTranslator {
+	return &translator{factory: debugfile.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(ConfigKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: ConfigKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*debugfile.Config)
+	if path, ok := common.GetString(conf, pathKey); ok {
+		cfg.Path = path
+	}
+	if sampleRate, ok := common.GetNumber(conf, sampleRateKey); ok {
+		cfg.SampleRate = int(sampleRate)
+	}
+	return cfg, nil
+}
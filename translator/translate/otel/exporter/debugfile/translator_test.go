@@ -0,0 +1,65 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package debugfile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/outputs/debugfile"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	tt := NewTranslator()
+	assert.EqualValues(t, "debugfile", tt.ID().String())
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *debugfile.Config
+		wantErr error
+	}{
+		"WithMissingKey": {
+			input: map[string]interface{}{"agent": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{
+				ID:      tt.ID(),
+				JsonKey: ConfigKey,
+			},
+		},
+		"WithDefault": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"debug_output": map[string]interface{}{},
+				},
+			},
+			want: &debugfile.Config{SampleRate: 1},
+		},
+		"WithPathAndSampleRate": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"debug_output": map[string]interface{}{
+						"path":        "/tmp/debug.json",
+						"sample_rate": 10,
+					},
+				},
+			},
+			want: &debugfile.Config{Path: "/tmp/debug.json", SampleRate: 10},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if err == nil {
+				require.NotNil(t, got)
+				gotCfg, ok := got.(*debugfile.Config)
+				require.True(t, ok)
+				assert.Equal(t, testCase.want, gotCfg)
+			}
+		})
+	}
+}
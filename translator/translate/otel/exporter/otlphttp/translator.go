@@ -0,0 +1,68 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otlphttp
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+var (
+	// TracesDestinationKey is the OTLP endpoint that the traces are sent to alongside X-Ray.
+	TracesDestinationKey = common.ConfigKey(common.TracesKey, "otlp_destination")
+	endpointKey          = "endpoint"
+	headersKey           = "headers"
+	compressionKey       = "compression"
+	queueSizeKey         = "queue_size"
+)
+
+type translator struct {
+	name      string
+	configKey string
+	factory   exporter.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+// NewTranslatorWithName creates a translator for the OTLP/HTTP exporter configured by the section of the JSON config
+// under the config key.
+func NewTranslatorWithName(name string, configKey string) common.ComponentTranslator {
+	return &translator{name, configKey, otlphttpexporter.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+// Translate creates an exporter config with its own sending queue and retries, so that a failing endpoint does not
+// hold back the other exporters of the pipeline.
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	endpoint := common.ConfigKey(t.configKey, endpointKey)
+	if conf == nil || !conf.IsSet(endpoint) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: endpoint}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*otlphttpexporter.Config)
+	cfg.ClientConfig.Endpoint, _ = common.GetString(conf, endpoint)
+	if headers, ok := conf.Get(common.ConfigKey(t.configKey, headersKey)).(map[string]interface{}); ok {
+		cfg.ClientConfig.Headers = make(map[string]configopaque.String, len(headers))
+		for key, value := range headers {
+			if s, ok := value.(string); ok {
+				cfg.ClientConfig.Headers[key] = configopaque.String(s)
+			}
+		}
+	}
+	if compression, ok := common.GetString(conf, common.ConfigKey(t.configKey, compressionKey)); ok {
+		cfg.ClientConfig.Compression = configcompression.Type(compression)
+	}
+	if queueSize, ok := common.GetNumber(conf, common.ConfigKey(t.configKey, queueSizeKey)); ok {
+		cfg.QueueConfig.QueueSize = int(queueSize)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,141 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otlphttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/exporter/otlphttpexporter"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslator(t *testing.T) {
+	tt := NewTranslatorWithName("xray", TracesDestinationKey)
+	require.EqualValues(t, "otlphttp/xray", tt.ID().String())
+
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    func(cfg *otlphttpexporter.Config)
+		wantErr error
+	}{
+		"WithMissingEndpoint": {
+			input: map[string]interface{}{
+				"traces": map[string]interface{}{
+					"otlp_destination": map[string]interface{}{},
+				},
+			},
+			wantErr: &common.MissingKeyError{ID: tt.ID(), JsonKey: "traces::otlp_destination::endpoint"},
+		},
+		"WithEndpoint": {
+			input: map[string]interface{}{
+				"traces": map[string]interface{}{
+					"otlp_destination": map[string]interface{}{
+						"endpoint": "https://collector.example.com:4318",
+					},
+				},
+			},
+			want: func(cfg *otlphttpexporter.Config) {
+				cfg.ClientConfig.Endpoint = "https://collector.example.com:4318"
+			},
+		},
+		"WithAllOptions": {
+			input: map[string]interface{}{
+				"traces": map[string]interface{}{
+					"otlp_destination": map[string]interface{}{
+						"endpoint":    "https://collector.example.com:4318",
+						"headers":     map[string]interface{}{"x-api-key": "secret"},
+						"compression": "none",
+						"queue_size":  5000,
+					},
+				},
+			},
+			want: func(cfg *otlphttpexporter.Config) {
+				cfg.ClientConfig.Endpoint = "https://collector.example.com:4318"
+				cfg.ClientConfig.Headers = map[string]configopaque.String{"x-api-key": "secret"}
+				cfg.ClientConfig.Compression = configcompression.Type("none")
+				cfg.QueueConfig.QueueSize = 5000
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if err == nil {
+				want := otlphttpexporter.NewFactory().CreateDefaultConfig().(*otlphttpexporter.Config)
+				testCase.want(want)
+				assert.Equal(t, want, got)
+				assert.NoError(t, got.(*otlphttpexporter.Config).Validate())
+			}
+		})
+	}
+}
+
+// newTracesExporter creates a started exporter from the translated config of the endpoint.
+func newTracesExporter(t *testing.T, endpoint string) exporter.Traces {
+	conf := confmap.NewFromStringMap(map[string]interface{}{
+		"traces": map[string]interface{}{
+			"otlp_destination": map[string]interface{}{"endpoint": endpoint},
+		},
+	})
+	tt := NewTranslatorWithName("test", TracesDestinationKey)
+	cfg, err := tt.Translate(conf)
+	require.NoError(t, err)
+	exp, err := otlphttpexporter.NewFactory().CreateTraces(context.Background(), exportertest.NewNopSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	return exp
+}
+
+func TestFailingDestinationDoesNotBlockOthers(t *testing.T) {
+	var received atomic.Int64
+	healthy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/traces" {
+			received.Add(1)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer healthy.Close()
+	release := make(chan struct{})
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		<-release
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+
+	// the exporters are called in order like the fan out of a pipeline with both destinations
+	exporters := []exporter.Traces{newTracesExporter(t, failing.URL), newTracesExporter(t, healthy.URL)}
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span")
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		for _, exp := range exporters {
+			assert.NoError(t, exp.ConsumeTraces(context.Background(), td))
+		}
+	}
+	// the failing destination buffers the spans in its queue instead of holding back the other one
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Eventually(t, func() bool { return received.Load() == 3 }, 5*time.Second, 10*time.Millisecond)
+
+	close(release)
+	for _, exp := range exporters {
+		assert.NoError(t, exp.Shutdown(context.Background()))
+	}
+}
@@ -0,0 +1,55 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pipelinehealth
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/pipelinehealth"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+// EndpointKey is the address that the health server binds to.
+var EndpointKey = common.ConfigKey(common.AgentKey, "health_endpoint")
+
+var (
+	debugStateKey = common.ConfigKey(common.AgentKey, "health_debug_state")
+	authTokenKey  = common.ConfigKey(common.AgentKey, "health_auth_token")
+)
+
+type translator struct {
+	name    string
+	factory extension.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+func NewTranslator() common.ComponentTranslator {
+	return &translator{
+		factory: pipelinehealth.NewFactory(),
+	}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+// Translate creates an extension configuration.
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(EndpointKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: EndpointKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*pipelinehealth.Config)
+	if endpoint, ok := common.GetString(conf, EndpointKey); ok && endpoint != "" {
+		cfg.Endpoint = endpoint
+	}
+	cfg.DebugState = common.GetOrDefaultBool(conf, debugStateKey, false)
+	if authToken, ok := common.GetString(conf, authTokenKey); ok {
+		cfg.AuthToken = configopaque.String(authToken)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,55 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pipelinehealth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/extension/pipelinehealth"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *pipelinehealth.Config
+		wantErr error
+	}{
+		"WithoutKey": {
+			input:   map[string]interface{}{"agent": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{ID: NewTranslator().ID(), JsonKey: EndpointKey},
+		},
+		"WithEndpoint": {
+			input: map[string]interface{}{"agent": map[string]interface{}{"health_endpoint": "0.0.0.0:8080"}},
+			want:  &pipelinehealth.Config{Endpoint: "0.0.0.0:8080"},
+		},
+		"WithEmptyEndpoint": {
+			input: map[string]interface{}{"agent": map[string]interface{}{"health_endpoint": ""}},
+			want:  &pipelinehealth.Config{Endpoint: "127.0.0.1:13133"},
+		},
+		"WithDebugState": {
+			input: map[string]interface{}{"agent": map[string]interface{}{
+				"health_endpoint":    "127.0.0.1:8080",
+				"health_debug_state": true,
+				"health_auth_token":  "secret",
+			}},
+			want: &pipelinehealth.Config{Endpoint: "127.0.0.1:8080", DebugState: true, AuthToken: "secret"},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "pipelinehealth", tt.ID().String())
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/awsemf"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/agenthealth"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/counterresetprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/cumulativetodeltaprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/filterprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/metricstransformprocessor"
@@ -56,10 +57,11 @@ func (t *translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators
 			otlp.NewTranslator(common.WithName(common.PipelineNameJmx)),
 		),
 		Processors: common.NewTranslatorMap(
-			filterprocessor.NewTranslator(common.WithName(common.PipelineNameContainerInsightsJmx)),   // Filter metrics
-			resourceprocessor.NewTranslator(common.WithName(common.PipelineNameContainerInsightsJmx)), // Change resource attribute names
-			transformprocessor.NewTranslatorWithName(common.PipelineNameContainerInsightsJmx),         // Removes attributes that are not of [ClusterName, Namespace]
-			metricstransformprocessor.NewTranslatorWithName(common.PipelineNameContainerInsightsJmx),  // Renames metrics and adds pool and area dimensions
+			filterprocessor.NewTranslator(common.WithName(common.PipelineNameContainerInsightsJmx)),       // Filter metrics
+			resourceprocessor.NewTranslator(common.WithName(common.PipelineNameContainerInsightsJmx)),     // Change resource attribute names
+			transformprocessor.NewTranslatorWithName(common.PipelineNameContainerInsightsJmx),             // Removes attributes that are not of [ClusterName, Namespace]
+			metricstransformprocessor.NewTranslatorWithName(common.PipelineNameContainerInsightsJmx),      // Renames metrics and adds pool and area dimensions
+			counterresetprocessor.NewTranslator(common.WithName(common.PipelineNameContainerInsightsJmx)), // Keeps counters monotonic across resets
 			cumulativetodeltaprocessor.NewTranslator(
 				common.WithName(common.PipelineNameContainerInsightsJmx),
 				cumulativetodeltaprocessor.WithConfigKeys(jmxKey),
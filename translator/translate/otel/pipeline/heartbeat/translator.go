@@ -0,0 +1,52 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package heartbeat
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/awscloudwatch"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/agenthealth"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/heartbeatprocessor"
+)
+
+var heartbeatKey = common.ConfigKey(common.MetricsKey, common.HeartbeatKey)
+
+type translator struct {
+	receivers common.ComponentTranslatorMap
+}
+
+var _ common.PipelineTranslator = (*translator)(nil)
+
+// NewTranslator creates the pipeline that publishes a heartbeat for each of the receivers. The receivers are shared
+// with the pipelines of their metrics.
+func NewTranslator(receivers common.ComponentTranslatorMap) common.PipelineTranslator {
+	return &translator{receivers: receivers}
+}
+
+func (t *translator) ID() pipeline.ID {
+	return pipeline.NewIDWithName(pipeline.SignalMetrics, common.PipelineNameHeartbeat)
+}
+
+// Translate creates the pipeline if the heartbeat section is present under metrics.
+func (t *translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators, error) {
+	if conf == nil || !conf.IsSet(heartbeatKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: heartbeatKey}
+	}
+	if t.receivers.Len() == 0 {
+		return nil, fmt.Errorf("no receivers configured in pipeline %s", common.PipelineNameHeartbeat)
+	}
+	return &common.ComponentTranslators{
+		Receivers:  t.receivers,
+		Processors: common.NewTranslatorMap(heartbeatprocessor.NewTranslator(common.WithName(common.PipelineNameHeartbeat))),
+		Exporters:  common.NewTranslatorMap(awscloudwatch.NewTranslatorWithName(common.PipelineNameHeartbeat)),
+		Extensions: common.NewTranslatorMap(agenthealth.NewTranslator(agenthealth.MetricsName, []string{agenthealth.OperationPutMetricData}),
+			agenthealth.NewTranslatorWithStatusCode(agenthealth.StatusCodeName, nil, true),
+		),
+	}, nil
+}
@@ -0,0 +1,84 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package heartbeat
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
+	"github.com/aws/amazon-cloudwatch-agent/receiver/adapter"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslator(t *testing.T) {
+	type want struct {
+		receivers  []string
+		processors []string
+		exporters  []string
+		extensions []string
+	}
+	receivers := common.NewTranslatorMap(
+		&testTranslator{id: component.NewID(adapter.Type("cpu"))},
+		&testTranslator{id: component.NewID(adapter.Type("mem"))},
+	)
+	tt := NewTranslator(receivers)
+	require.EqualValues(t, "metrics/heartbeat", tt.ID().String())
+	testCases := map[string]struct {
+		input     map[string]any
+		receivers common.ComponentTranslatorMap
+		want      *want
+		wantErr   error
+	}{
+		"WithoutHeartbeatKey": {
+			input:     map[string]any{"metrics": map[string]any{}},
+			receivers: receivers,
+			wantErr:   &common.MissingKeyError{ID: tt.ID(), JsonKey: heartbeatKey},
+		},
+		"WithHeartbeatKey": {
+			input:     map[string]any{"metrics": map[string]any{"heartbeat": map[string]any{}}},
+			receivers: receivers,
+			want: &want{
+				receivers:  []string{"telegraf_cpu", "telegraf_mem"},
+				processors: []string{"heartbeat/heartbeat"},
+				exporters:  []string{"awscloudwatch/heartbeat"},
+				extensions: []string{"agenthealth/metrics", "agenthealth/statuscode"},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := NewTranslator(testCase.receivers).Translate(conf)
+			require.Equal(t, testCase.wantErr, err)
+			if testCase.want == nil {
+				require.Nil(t, got)
+			} else {
+				require.NotNil(t, got)
+				assert.Equal(t, testCase.want.receivers, collections.MapSlice(got.Receivers.Keys(), component.ID.String))
+				assert.Equal(t, testCase.want.processors, collections.MapSlice(got.Processors.Keys(), component.ID.String))
+				assert.Equal(t, testCase.want.exporters, collections.MapSlice(got.Exporters.Keys(), component.ID.String))
+				assert.Equal(t, testCase.want.extensions, collections.MapSlice(got.Extensions.Keys(), component.ID.String))
+			}
+		})
+	}
+}
+
+type testTranslator struct {
+	id component.ID
+}
+
+var _ common.ComponentTranslator = (*testTranslator)(nil)
+
+func (t *testTranslator) Translate(*confmap.Conf) (component.Config, error) {
+	return nil, nil
+}
+
+func (t *testTranslator) ID() component.ID {
+	return t.id
+}
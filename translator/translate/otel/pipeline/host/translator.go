@@ -18,15 +18,18 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/awscloudwatch"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/awsemf"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/awstimestream"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/prometheusremotewrite"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/agenthealth"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/sigv4auth"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/awsentity"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/batchprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/counterresetprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/cumulativetodeltaprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/ec2taggerprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/metricsdecorator"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/rollupprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/transformprocessor"
 	"github.com/aws/amazon-cloudwatch-agent/translator/util/ecsutil"
 )
 
@@ -84,7 +87,18 @@ func (t translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators,
 
 	if strings.HasPrefix(t.name, common.PipelineNameHostDeltaMetrics) || strings.HasPrefix(t.name, common.PipelineNameHostOtlpMetrics) {
 		log.Printf("D! delta processor required because metrics with diskio or net are set")
+		translators.Processors.Set(counterresetprocessor.NewTranslator(common.WithName(t.name)))
 		translators.Processors.Set(cumulativetodeltaprocessor.NewTranslator(common.WithName(t.name), cumulativetodeltaprocessor.WithDefaultKeys()))
+	} else if conf.IsSet(common.ConfigKey(common.MetricsKey, common.MetricTemporalityKey)) {
+		delta, _, err := common.GetMetricTemporality(conf)
+		if err != nil {
+			return nil, err
+		}
+		log.Printf("D! temporality processors required because metric_temporality is set")
+		translators.Processors.Set(counterresetprocessor.NewTranslator(common.WithName(t.name)))
+		if len(delta) != 0 {
+			translators.Processors.Set(cumulativetodeltaprocessor.NewTranslator(common.WithName(t.name), cumulativetodeltaprocessor.WithTemporalityRules()))
+		}
 	}
 
 	if t.Destination() != common.CloudWatchLogsKey {
@@ -138,7 +152,17 @@ func (t translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators,
 		translators.Processors.Set(batchprocessor.NewTranslatorWithNameAndSection(t.name, common.MetricsKey))
 		translators.Exporters.Set(prometheusremotewrite.NewTranslatorWithName(common.AMPKey))
 		translators.Extensions.Set(sigv4auth.NewTranslator())
+	case common.TimestreamKey:
+		translators.Processors.Set(batchprocessor.NewTranslatorWithNameAndSection(t.name, common.MetricsKey))
+		translators.Exporters.Set(awstimestream.NewTranslator())
 	case common.CloudWatchLogsKey:
+		if conf.IsSet(common.ConfigKey(common.MetricsKey, common.DimensionKeysKey)) {
+			// the EMF logs store the attributes that are not dimensions, unless they are dropped first
+			if keep, _ := common.GetBool(conf, common.ConfigKey(common.MetricsKey, common.KeepDroppedDimensionsKey)); !keep {
+				log.Printf("D! transform processor required because dimension_keys is set")
+				translators.Processors.Set(transformprocessor.NewDimensionKeysTranslator(t.name))
+			}
+		}
 		translators.Processors.Set(batchprocessor.NewTranslatorWithNameAndSection(t.name, common.LogsKey))
 		translators.Exporters.Set(awsemf.NewTranslator())
 		translators.Extensions.Set(agenthealth.NewTranslator(agenthealth.LogsName, []string{agenthealth.OperationPutLogEvents}))
@@ -77,7 +77,44 @@ func TestTranslator(t *testing.T) {
 			want: &want{
 				pipelineID: "metrics/hostDeltaMetrics",
 				receivers:  []string{"nop", "other"},
-				processors: []string{"cumulativetodelta/hostDeltaMetrics", "awsentity/resource"},
+				processors: []string{"counterreset/hostDeltaMetrics", "cumulativetodelta/hostDeltaMetrics", "awsentity/resource"},
+				exporters:  []string{"awscloudwatch"},
+				extensions: []string{"agenthealth/metrics", "agenthealth/statuscode"},
+			},
+		},
+		"WithMetricTemporality": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"metric_temporality": []interface{}{
+						map[string]interface{}{"pattern": "^errors_", "temporality": "cumulative"},
+						map[string]interface{}{"pattern": "^requests_", "temporality": "delta"},
+					},
+				},
+			},
+			pipelineName: common.PipelineNameHost,
+			mode:         config.ModeEC2,
+			want: &want{
+				pipelineID: "metrics/host",
+				receivers:  []string{"nop", "other"},
+				processors: []string{"counterreset/host", "cumulativetodelta/host", "awsentity/resource"},
+				exporters:  []string{"awscloudwatch"},
+				extensions: []string{"agenthealth/metrics", "agenthealth/statuscode"},
+			},
+		},
+		"WithCumulativeMetricTemporality": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"metric_temporality": []interface{}{
+						map[string]interface{}{"pattern": "^errors_", "temporality": "cumulative"},
+					},
+				},
+			},
+			pipelineName: common.PipelineNameHost,
+			mode:         config.ModeEC2,
+			want: &want{
+				pipelineID: "metrics/host",
+				receivers:  []string{"nop", "other"},
+				processors: []string{"counterreset/host", "awsentity/resource"},
 				exporters:  []string{"awscloudwatch"},
 				extensions: []string{"agenthealth/metrics", "agenthealth/statuscode"},
 			},
@@ -95,7 +132,7 @@ func TestTranslator(t *testing.T) {
 			want: &want{
 				pipelineID: "metrics/hostOtlpMetrics",
 				receivers:  []string{"nop", "other"},
-				processors: []string{"cumulativetodelta/hostOtlpMetrics"},
+				processors: []string{"counterreset/hostOtlpMetrics", "cumulativetodelta/hostOtlpMetrics"},
 				exporters:  []string{"awscloudwatch"},
 				extensions: []string{"agenthealth/metrics", "agenthealth/statuscode"},
 			},
@@ -114,7 +151,7 @@ func TestTranslator(t *testing.T) {
 			want: &want{
 				pipelineID: "metrics/hostOtlpMetrics",
 				receivers:  []string{"nop", "other"},
-				processors: []string{"cumulativetodelta/hostOtlpMetrics"},
+				processors: []string{"counterreset/hostOtlpMetrics", "cumulativetodelta/hostOtlpMetrics"},
 				exporters:  []string{"awscloudwatch"},
 				extensions: []string{"agenthealth/metrics", "agenthealth/statuscode"},
 			},
@@ -132,7 +169,7 @@ func TestTranslator(t *testing.T) {
 			want: &want{
 				pipelineID: "metrics/hostOtlpMetrics",
 				receivers:  []string{"nop", "other"},
-				processors: []string{"cumulativetodelta/hostOtlpMetrics", "awsentity/service/otlp"},
+				processors: []string{"counterreset/hostOtlpMetrics", "cumulativetodelta/hostOtlpMetrics", "awsentity/service/otlp"},
 				exporters:  []string{"awscloudwatch"},
 				extensions: []string{"agenthealth/metrics", "agenthealth/statuscode"},
 			},
@@ -151,7 +188,40 @@ func TestTranslator(t *testing.T) {
 			want: &want{
 				pipelineID: "metrics/hostOtlpMetrics/cloudwatchlogs",
 				receivers:  []string{"nop", "other"},
-				processors: []string{"cumulativetodelta/hostOtlpMetrics/cloudwatchlogs", "batch/hostOtlpMetrics/cloudwatchlogs"},
+				processors: []string{"counterreset/hostOtlpMetrics/cloudwatchlogs", "cumulativetodelta/hostOtlpMetrics/cloudwatchlogs", "batch/hostOtlpMetrics/cloudwatchlogs"},
+				exporters:  []string{"awsemf"},
+				extensions: []string{"agenthealth/logs", "agenthealth/statuscode"},
+			},
+		},
+		"WithDimensionKeys/CloudWatchLogs": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"dimension_keys": []interface{}{"InstanceId"},
+				},
+			},
+			pipelineName: common.PipelineNameHost,
+			destination:  common.CloudWatchLogsKey,
+			want: &want{
+				pipelineID: "metrics/host/cloudwatchlogs",
+				receivers:  []string{"nop", "other"},
+				processors: []string{"awsentity/resource", "transform/dimension_keys/host/cloudwatchlogs", "batch/host/cloudwatchlogs"},
+				exporters:  []string{"awsemf"},
+				extensions: []string{"agenthealth/logs", "agenthealth/statuscode"},
+			},
+		},
+		"WithKeptDimensions/CloudWatchLogs": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"dimension_keys":          []interface{}{"InstanceId"},
+					"keep_dropped_dimensions": true,
+				},
+			},
+			pipelineName: common.PipelineNameHost,
+			destination:  common.CloudWatchLogsKey,
+			want: &want{
+				pipelineID: "metrics/host/cloudwatchlogs",
+				receivers:  []string{"nop", "other"},
+				processors: []string{"awsentity/resource", "batch/host/cloudwatchlogs"},
 				exporters:  []string{"awsemf"},
 				extensions: []string{"agenthealth/logs", "agenthealth/statuscode"},
 			},
@@ -171,7 +241,7 @@ func TestTranslator(t *testing.T) {
 			want: &want{
 				pipelineID: "metrics/hostOtlpMetrics/cloudwatchlogs",
 				receivers:  []string{"nop", "other"},
-				processors: []string{"cumulativetodelta/hostOtlpMetrics/cloudwatchlogs", "batch/hostOtlpMetrics/cloudwatchlogs"},
+				processors: []string{"counterreset/hostOtlpMetrics/cloudwatchlogs", "cumulativetodelta/hostOtlpMetrics/cloudwatchlogs", "batch/hostOtlpMetrics/cloudwatchlogs"},
 				exporters:  []string{"awsemf"},
 				extensions: []string{"agenthealth/logs", "agenthealth/statuscode"},
 			},
@@ -190,7 +260,7 @@ func TestTranslator(t *testing.T) {
 			want: &want{
 				pipelineID: "metrics/hostOtlpMetrics/cloudwatchlogs",
 				receivers:  []string{"nop", "other"},
-				processors: []string{"cumulativetodelta/hostOtlpMetrics/cloudwatchlogs", "awsentity/service/otlp", "batch/hostOtlpMetrics/cloudwatchlogs"},
+				processors: []string{"counterreset/hostOtlpMetrics/cloudwatchlogs", "cumulativetodelta/hostOtlpMetrics/cloudwatchlogs", "awsentity/service/otlp", "batch/hostOtlpMetrics/cloudwatchlogs"},
 				exporters:  []string{"awsemf"},
 				extensions: []string{"agenthealth/logs", "agenthealth/statuscode"},
 			},
@@ -327,6 +397,21 @@ func TestTranslator(t *testing.T) {
 				extensions: []string{"sigv4auth"},
 			},
 		},
+		"WithTimestreamExporter": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{},
+			},
+			pipelineName: common.PipelineNameHost,
+			destination:  common.TimestreamKey,
+			mode:         config.ModeEC2,
+			want: &want{
+				pipelineID: "metrics/host/timestream",
+				receivers:  []string{"nop", "other"},
+				processors: []string{"batch/host/timestream"},
+				exporters:  []string{"awstimestream"},
+				extensions: []string{},
+			},
+		},
 	}
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -12,6 +12,7 @@ import (
 
 	"github.com/aws/amazon-cloudwatch-agent/receiver/adapter"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline/heartbeat"
 	adaptertranslator "github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/receiver/adapter"
 	otlpreceiver "github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/receiver/otlp"
 )
@@ -77,8 +78,8 @@ func NewTranslators(conf *confmap.Conf, configSection, os string) (common.Transl
 
 	for _, destination := range destinations {
 		switch destination {
-		case common.AMPKey:
-			// PRW exporter does not need the delta conversion.
+		case common.AMPKey, common.TimestreamKey:
+			// PRW and Timestream exporters do not need the delta conversion.
 			receivers := common.NewTranslatorMap[component.Config, component.ID]()
 			receivers.Merge(hostReceivers)
 			receivers.Merge(deltaReceivers)
@@ -119,5 +120,16 @@ func NewTranslators(conf *confmap.Conf, configSection, os string) (common.Transl
 		}
 	}
 
+	// The heartbeat pipeline shares the receivers of the plugins, so that each of them has a heartbeat.
+	if configSection == MetricsKey && conf.IsSet(common.ConfigKey(common.MetricsKey, common.HeartbeatKey)) {
+		receivers := common.NewTranslatorMap[component.Config, component.ID]()
+		receivers.Merge(hostReceivers)
+		receivers.Merge(hostCustomReceivers)
+		receivers.Merge(deltaReceivers)
+		if receivers.Len() != 0 {
+			translators.Set(heartbeat.NewTranslator(receivers))
+		}
+	}
+
 	return translators, nil
 }
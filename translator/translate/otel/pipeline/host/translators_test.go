@@ -96,6 +96,33 @@ func TestTranslators(t *testing.T) {
 				},
 			},
 		},
+		"WithTimestreamAndCloudWatchDestinations": {
+			input: map[string]any{
+				"metrics": map[string]any{
+					"metrics_destinations": map[string]any{
+						"timestream": map[string]any{
+							"database_name": "metrics",
+							"table_name":    "host",
+						},
+						"cloudwatch": map[string]any{},
+					},
+					"metrics_collected": map[string]any{
+						"net": map[string]any{},
+					},
+				},
+			},
+			configSection: MetricsKey,
+			want: map[string]want{
+				"metrics/hostDeltaMetrics/cloudwatch": {
+					receivers: []string{"telegraf_net"},
+					exporters: []string{"awscloudwatch"},
+				},
+				"metrics/host/timestream": {
+					receivers: []string{"telegraf_net"},
+					exporters: []string{"awstimestream"},
+				},
+			},
+		},
 		"WithDeltaMetrics": {
 			input: map[string]any{
 				"metrics": map[string]any{
@@ -170,6 +197,44 @@ func TestTranslators(t *testing.T) {
 				},
 			},
 		},
+		"WithHeartbeat": {
+			input: map[string]any{
+				"metrics": map[string]any{
+					"heartbeat": map[string]any{
+						"namespace": "CWAgent/Heartbeat",
+					},
+					"metrics_collected": map[string]any{
+						"cpu":    map[string]any{},
+						"net":    map[string]any{},
+						"statsd": map[string]any{},
+						"otlp":   map[string]any{},
+					},
+				},
+			},
+			configSection: MetricsKey,
+			want: map[string]want{
+				"metrics/host": {
+					receivers: []string{"telegraf_cpu"},
+					exporters: []string{"awscloudwatch"},
+				},
+				"metrics/hostCustomMetrics": {
+					receivers: []string{"telegraf_statsd"},
+					exporters: []string{"awscloudwatch"},
+				},
+				"metrics/hostDeltaMetrics": {
+					receivers: []string{"telegraf_net"},
+					exporters: []string{"awscloudwatch"},
+				},
+				"metrics/hostOtlpMetrics": {
+					receivers: []string{"otlp/metrics"},
+					exporters: []string{"awscloudwatch"},
+				},
+				"metrics/heartbeat": {
+					receivers: []string{"telegraf_cpu", "telegraf_statsd", "telegraf_net"},
+					exporters: []string{"awscloudwatch/heartbeat"},
+				},
+			},
+		},
 	}
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
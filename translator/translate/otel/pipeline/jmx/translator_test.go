@@ -127,7 +127,7 @@ func TestTranslator(t *testing.T) {
 			want: &want{
 				pipelineID: "metrics/jmx",
 				receivers:  []string{"jmx"},
-				processors: []string{"filter/jmx", "resource/jmx", "cumulativetodelta/jmx"},
+				processors: []string{"filter/jmx", "resource/jmx", "counterreset/jmx", "cumulativetodelta/jmx"},
 				exporters:  []string{"awscloudwatch"},
 				extensions: []string{"agenthealth/metrics"},
 			},
@@ -151,7 +151,7 @@ func TestTranslator(t *testing.T) {
 			want: &want{
 				pipelineID: "metrics/jmx",
 				receivers:  []string{"otlp/jmx"},
-				processors: []string{"filter/jmx", "metricstransform/jmx", "transform/jmx/drop", "cumulativetodelta/jmx"},
+				processors: []string{"filter/jmx", "metricstransform/jmx", "transform/jmx/drop", "counterreset/jmx", "cumulativetodelta/jmx"},
 				exporters:  []string{"awscloudwatch"},
 				extensions: []string{"agenthealth/metrics"},
 			},
@@ -178,7 +178,7 @@ func TestTranslator(t *testing.T) {
 			want: &want{
 				pipelineID: "metrics/jmx",
 				receivers:  []string{"otlp/jmx"},
-				processors: []string{"filter/jmx", "metricstransform/jmx", "resource/jmx", "transform/jmx/drop", "cumulativetodelta/jmx"},
+				processors: []string{"filter/jmx", "metricstransform/jmx", "resource/jmx", "transform/jmx/drop", "counterreset/jmx", "cumulativetodelta/jmx"},
 				exporters:  []string{"awscloudwatch"},
 				extensions: []string{"agenthealth/metrics"},
 			},
@@ -267,7 +267,7 @@ func TestTranslator(t *testing.T) {
 			want: &want{
 				pipelineID: "metrics/jmx/cloudwatch",
 				receivers:  []string{"jmx"},
-				processors: []string{"filter/jmx", "resource/jmx", "transform/jmx", "cumulativetodelta/jmx"},
+				processors: []string{"filter/jmx", "resource/jmx", "transform/jmx", "counterreset/jmx", "cumulativetodelta/jmx"},
 				exporters:  []string{"awscloudwatch"},
 				extensions: []string{"agenthealth/metrics"},
 			},
@@ -301,7 +301,7 @@ func TestTranslator(t *testing.T) {
 			want: &want{
 				pipelineID: "metrics/jmx/0",
 				receivers:  []string{"jmx/0"},
-				processors: []string{"filter/jmx/0", "resource/jmx", "transform/jmx/0", "ec2tagger", "cumulativetodelta/jmx"},
+				processors: []string{"filter/jmx/0", "resource/jmx", "transform/jmx/0", "ec2tagger", "counterreset/jmx", "cumulativetodelta/jmx"},
 				exporters:  []string{"awscloudwatch"},
 				extensions: []string{"agenthealth/metrics"},
 			},
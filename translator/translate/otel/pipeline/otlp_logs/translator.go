@@ -0,0 +1,52 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package otlp_logs
+
+import (
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/awscloudwatchlogs"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/agenthealth"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/batchprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/cwlogsprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/receiver/otlp"
+)
+
+var otlpKey = common.ConfigKey(common.LogsKey, common.LogsCollectedKey, common.OtlpKey)
+
+type translator struct{}
+
+var _ common.PipelineTranslator = (*translator)(nil)
+
+func NewTranslator() common.PipelineTranslator {
+	return &translator{}
+}
+
+func (t *translator) ID() pipeline.ID {
+	return pipeline.NewIDWithName(pipeline.SignalLogs, common.PipelineNameOtlpLogs)
+}
+
+// Translate creates a pipeline that sends the OTLP logs to CloudWatch Logs if
+// the otlp section is present under logs_collected.
+func (t *translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators, error) {
+	if conf == nil || !conf.IsSet(otlpKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: otlpKey}
+	}
+	return &common.ComponentTranslators{
+		Receivers: common.NewTranslatorMap(otlp.NewTranslator(
+			otlp.WithSignal(pipeline.SignalLogs),
+			otlp.WithConfigKey(otlpKey)),
+		),
+		Processors: common.NewTranslatorMap(
+			cwlogsprocessor.NewTranslator(common.WithName(common.PipelineNameOtlpLogs)),
+			batchprocessor.NewTranslatorWithNameAndSection(common.PipelineNameOtlpLogs, common.LogsKey),
+		),
+		Exporters: common.NewTranslatorMap(awscloudwatchlogs.NewTranslatorWithName(common.PipelineNameOtlpLogs)),
+		Extensions: common.NewTranslatorMap(agenthealth.NewTranslator(agenthealth.LogsName, []string{agenthealth.OperationPutLogEvents}),
+			agenthealth.NewTranslatorWithStatusCode(agenthealth.StatusCodeName, nil, true),
+		),
+	}, nil
+}
@@ -13,6 +13,7 @@ import (
 
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
 	awsxrayexporter "github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/awsxray"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/otlphttp"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/agenthealth"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor"
 	awsxrayreceiver "github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/receiver/awsxray"
@@ -21,6 +22,8 @@ import (
 
 const (
 	pipelineName = "xray"
+	// otlpDestinationPipelineName is the pipeline of the OTLP destination, which receives the same traces.
+	otlpDestinationPipelineName = "xray_otlp"
 )
 
 var (
@@ -45,21 +48,54 @@ func (t *translator) Translate(conf *confmap.Conf) (*common.ComponentTranslators
 	if conf == nil || !(conf.IsSet(xrayKey) || conf.IsSet(otlpKey)) {
 		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: fmt.Sprint(xrayKey, " or ", otlpKey)}
 	}
-	translators := &common.ComponentTranslators{
-		Receivers:  common.NewTranslatorMap[component.Config, component.ID](),
+	return &common.ComponentTranslators{
+		Receivers:  receiverTranslators(conf),
 		Processors: common.NewTranslatorMap(processor.NewDefaultTranslatorWithName(pipelineName, batchprocessor.NewFactory())),
 		Exporters:  common.NewTranslatorMap(awsxrayexporter.NewTranslator()),
 		Extensions: common.NewTranslatorMap(agenthealth.NewTranslator(agenthealth.TracesName, []string{agenthealth.OperationPutTraceSegments}),
 			agenthealth.NewTranslatorWithStatusCode(agenthealth.StatusCodeName, nil, true)),
+	}, nil
+}
+
+type otlpDestinationTranslator struct {
+}
+
+var _ common.PipelineTranslator = (*otlpDestinationTranslator)(nil)
+
+// NewOtlpDestinationTranslator creates the pipeline that sends the traces of the X-Ray pipeline to the OTLP
+// destination. The X-Ray exporter has no sending queue, so the OTLP exporter is in a pipeline with its own batch
+// processor instead of sharing the batches of the X-Ray pipeline, whose exports wait on PutTraceSegments.
+func NewOtlpDestinationTranslator() common.PipelineTranslator {
+	return &otlpDestinationTranslator{}
+}
+
+func (t *otlpDestinationTranslator) ID() pipeline.ID {
+	return pipeline.NewIDWithName(pipeline.SignalTraces, otlpDestinationPipelineName)
+}
+
+func (t *otlpDestinationTranslator) Translate(conf *confmap.Conf) (*common.ComponentTranslators, error) {
+	if conf == nil || !(conf.IsSet(xrayKey) || conf.IsSet(otlpKey)) || !conf.IsSet(otlphttp.TracesDestinationKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: otlphttp.TracesDestinationKey}
 	}
+	return &common.ComponentTranslators{
+		Receivers:  receiverTranslators(conf),
+		Processors: common.NewTranslatorMap(processor.NewDefaultTranslatorWithName(otlpDestinationPipelineName, batchprocessor.NewFactory())),
+		Exporters:  common.NewTranslatorMap(otlphttp.NewTranslatorWithName(pipelineName, otlphttp.TracesDestinationKey)),
+		Extensions: common.NewTranslatorMap[component.Config, component.ID](),
+	}, nil
+}
+
+// receiverTranslators returns the receivers of the traces, which are shared by the pipelines.
+func receiverTranslators(conf *confmap.Conf) common.TranslatorMap[component.Config, component.ID] {
+	receivers := common.NewTranslatorMap[component.Config, component.ID]()
 	if conf.IsSet(xrayKey) {
-		translators.Receivers.Set(awsxrayreceiver.NewTranslator())
+		receivers.Set(awsxrayreceiver.NewTranslator())
 	}
 	if conf.IsSet(otlpKey) {
-		translators.Receivers.Set(otlp.NewTranslator(
+		receivers.Set(otlp.NewTranslator(
 			otlp.WithSignal(pipeline.SignalTraces),
 			otlp.WithConfigKey(otlpKey)),
 		)
 	}
-	return translators, nil
+	return receivers
 }
@@ -80,6 +80,24 @@ func TestTranslator(t *testing.T) {
 				extensions: []string{"agenthealth/traces", "agenthealth/statuscode"},
 			},
 		},
+		"WithOtlpDestination": {
+			input: map[string]interface{}{
+				"traces": map[string]interface{}{
+					"traces_collected": map[string]interface{}{
+						"xray": nil,
+					},
+					"otlp_destination": map[string]interface{}{
+						"endpoint": "https://collector.example.com:4318",
+					},
+				},
+			},
+			want: &want{
+				receivers:  []string{"awsxray"},
+				processors: []string{"batch/xray"},
+				exporters:  []string{"awsxray"},
+				extensions: []string{"agenthealth/traces", "agenthealth/statuscode"},
+			},
+		},
 	}
 	for name, testCase := range testCases {
 		t.Run(name, func(t *testing.T) {
@@ -98,3 +116,73 @@ func TestTranslator(t *testing.T) {
 		})
 	}
 }
+
+func TestOtlpDestinationTranslator(t *testing.T) {
+	type want struct {
+		receivers  []string
+		processors []string
+		exporters  []string
+	}
+	tt := NewOtlpDestinationTranslator()
+	assert.EqualValues(t, "traces/xray_otlp", tt.ID().String())
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *want
+		wantErr error
+	}{
+		"WithoutOtlpDestination": {
+			input: map[string]interface{}{
+				"traces": map[string]interface{}{
+					"traces_collected": map[string]interface{}{
+						"xray": nil,
+					},
+				},
+			},
+			wantErr: &common.MissingKeyError{ID: tt.ID(), JsonKey: "traces::otlp_destination"},
+		},
+		"WithoutTracesCollected": {
+			input: map[string]interface{}{
+				"traces": map[string]interface{}{
+					"otlp_destination": map[string]interface{}{
+						"endpoint": "https://collector.example.com:4318",
+					},
+				},
+			},
+			wantErr: &common.MissingKeyError{ID: tt.ID(), JsonKey: "traces::otlp_destination"},
+		},
+		"WithOtlpDestination": {
+			input: map[string]interface{}{
+				"traces": map[string]interface{}{
+					"traces_collected": map[string]interface{}{
+						"xray": nil,
+						"otlp": nil,
+					},
+					"otlp_destination": map[string]interface{}{
+						"endpoint": "https://collector.example.com:4318",
+					},
+				},
+			},
+			want: &want{
+				receivers:  []string{"awsxray", "otlp/traces"},
+				processors: []string{"batch/xray_otlp"},
+				exporters:  []string{"otlphttp/xray"},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want == nil {
+				assert.Nil(t, got)
+			} else {
+				require.NotNil(t, got)
+				assert.Equal(t, testCase.want.receivers, collections.MapSlice(got.Receivers.Keys(), component.ID.String))
+				assert.Equal(t, testCase.want.processors, collections.MapSlice(got.Processors.Keys(), component.ID.String))
+				assert.Equal(t, testCase.want.exporters, collections.MapSlice(got.Exporters.Keys(), component.ID.String))
+				assert.Empty(t, got.Extensions.Keys())
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package attributedenylist
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/attributedenylistprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+var (
+	// ConfigKey is the attribute denylist that is applied to all metrics and logs pipelines.
+	ConfigKey   = common.ConfigKey(common.AgentKey, "attribute_denylist")
+	keysKey     = common.ConfigKey(ConfigKey, "keys")
+	patternsKey = common.ConfigKey(ConfigKey, "patterns")
+)
+
+type translator struct {
+	name    string
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+func NewTranslator() common.ComponentTranslator {
+	return &translator{factory: attributedenylistprocessor.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(ConfigKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: ConfigKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*attributedenylistprocessor.Config)
+	cfg.Keys = common.GetArray[string](conf, keysKey)
+	cfg.Patterns = common.GetArray[string](conf, patternsKey)
+	return cfg, nil
+}
@@ -0,0 +1,61 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package attributedenylist
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/attributedenylistprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *attributedenylistprocessor.Config
+		wantErr error
+	}{
+		"WithoutKey": {
+			input:   map[string]interface{}{"agent": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{ID: NewTranslator().ID(), JsonKey: ConfigKey},
+		},
+		"WithKeysAndPatterns": {
+			input: map[string]interface{}{"agent": map[string]interface{}{
+				"attribute_denylist": map[string]interface{}{
+					"keys":     []interface{}{"Authorization", "aws.secret"},
+					"patterns": []interface{}{"(?i).*token.*"},
+				},
+			}},
+			want: &attributedenylistprocessor.Config{
+				Keys:     []string{"Authorization", "aws.secret"},
+				Patterns: []string{"(?i).*token.*"},
+			},
+		},
+		"WithKeys": {
+			input: map[string]interface{}{"agent": map[string]interface{}{
+				"attribute_denylist": map[string]interface{}{
+					"keys": []interface{}{"Authorization"},
+				},
+			}},
+			want: &attributedenylistprocessor.Config{
+				Keys: []string{"Authorization"},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "attributedenylist", tt.ID().String())
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
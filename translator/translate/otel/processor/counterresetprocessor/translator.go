@@ -0,0 +1,70 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package counterresetprocessor
+
+import (
+	"path/filepath"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/counterresetprocessor"
+	translatorcontext "github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/config"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+	"github.com/aws/amazon-cloudwatch-agent/translator/util"
+)
+
+const stateFolderLinux = "/opt/aws/amazon-cloudwatch-agent/var/counterreset"
+
+var persistDeltaStateKey = common.ConfigKey(common.MetricsKey, "persist_delta_state")
+
+type translator struct {
+	common.NameProvider
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+var _ common.NameSetter = (*translator)(nil)
+
+// NewTranslator creates the counter reset processor that runs ahead of the
+// cumulative to delta conversion, so a counter reset results in the new value
+// as the delta instead of a dropped data point. The delta sums pinned to
+// cumulative by the metric_temporality rules are also converted by it.
+func NewTranslator(opts ...common.TranslatorOption) common.ComponentTranslator {
+	t := &translator{factory: counterresetprocessor.NewFactory()}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.Name())
+}
+
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	cfg := t.factory.CreateDefaultConfig().(*counterresetprocessor.Config)
+	_, cumulative, err := common.GetMetricTemporality(conf)
+	if err != nil {
+		return nil, err
+	}
+	cfg.DeltaToCumulative = cumulative
+	if enabled, ok := common.GetBool(conf, persistDeltaStateKey); ok && enabled {
+		cfg.StateFile = filepath.Join(getStateFolder(), t.Name()+".json")
+	}
+	return cfg, nil
+}
+
+// getStateFolder returns the folder of the state files, which is left in
+// place when the agent is upgraded. It is not the state folder of the log
+// files, since the logfile plugin removes the files there that do not belong
+// to a log file.
+func getStateFolder() string {
+	if translatorcontext.GetTargetPlatform() == config.OS_TYPE_WINDOWS {
+		return util.GetWindowsProgramDataPath() + "\\Amazon\\AmazonCloudWatchAgent\\var\\counterreset"
+	}
+	return stateFolderLinux
+}
@@ -0,0 +1,77 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package counterresetprocessor
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/counterresetprocessor"
+	translatorcontext "github.com/aws/amazon-cloudwatch-agent/translator"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslator(t *testing.T) {
+	tt := NewTranslator(common.WithName("test"))
+	assert.EqualValues(t, "counterreset/test", tt.ID().String())
+	got, err := tt.Translate(confmap.New())
+	require.NoError(t, err)
+	assert.Equal(t, counterresetprocessor.NewFactory().CreateDefaultConfig(), got)
+}
+
+func TestTranslatorWithMetricTemporality(t *testing.T) {
+	tt := NewTranslator(common.WithName("test"))
+	got, err := tt.Translate(confmap.NewFromStringMap(map[string]any{
+		"metrics": map[string]any{
+			"metric_temporality": []any{
+				map[string]any{"pattern": "^errors_", "temporality": "cumulative"},
+				map[string]any{"pattern": "^requests_", "temporality": "delta"},
+			},
+		},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, &counterresetprocessor.Config{DeltaToCumulative: []string{"^errors_"}}, got)
+
+	_, err = tt.Translate(confmap.NewFromStringMap(map[string]any{
+		"metrics": map[string]any{
+			"metric_temporality": []any{map[string]any{"pattern": "^errors_", "temporality": "gauge"}},
+		},
+	}))
+	assert.Error(t, err)
+}
+
+func TestTranslatorWithPersistDeltaState(t *testing.T) {
+	translatorcontext.SetTargetPlatform("linux")
+	tt := NewTranslator(common.WithName("test"))
+	got, err := tt.Translate(confmap.NewFromStringMap(map[string]any{
+		"metrics": map[string]any{"persist_delta_state": true},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, &counterresetprocessor.Config{
+		StateFile: filepath.Join(stateFolderLinux, "test.json"),
+	}, got)
+
+	got, err = tt.Translate(confmap.NewFromStringMap(map[string]any{
+		"metrics": map[string]any{"persist_delta_state": false},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, counterresetprocessor.NewFactory().CreateDefaultConfig(), got)
+}
+
+func TestTranslatorWithPersistDeltaStateOnWindows(t *testing.T) {
+	translatorcontext.SetTargetPlatform("windows")
+	t.Cleanup(func() { translatorcontext.SetTargetPlatform("linux") })
+	got, err := NewTranslator(common.WithName("test")).Translate(confmap.NewFromStringMap(map[string]any{
+		"metrics": map[string]any{"persist_delta_state": true},
+	}))
+	require.NoError(t, err)
+	stateFile := got.(*counterresetprocessor.Config).StateFile
+	assert.Contains(t, stateFile, `\Amazon\AmazonCloudWatchAgent\var\counterreset`)
+	assert.Equal(t, "test.json", filepath.Base(strings.ReplaceAll(stateFile, `\`, "/")))
+}
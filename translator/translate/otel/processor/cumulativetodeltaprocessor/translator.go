@@ -4,6 +4,7 @@
 package cumulativetodeltaprocessor
 
 import (
+	"regexp"
 	"strings"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/cumulativetodeltaprocessor"
@@ -18,6 +19,8 @@ const (
 	// Match types are in an internal package from contrib
 	// Strict is the FilterType for filtering by exact string matches.
 	strict = "strict"
+	// Regexp is the FilterType for filtering by regular expressions.
+	regexpMatchType = "regexp"
 	// InitialValue types are in an internal package
 	// 2 is the int value for the enum
 	initialValueDrop = 2
@@ -50,10 +53,21 @@ func WithConfigKeys(keys ...string) common.TranslatorOption {
 	}
 }
 
+// WithTemporalityRules only converts the metrics pinned to delta by the
+// metric_temporality rules instead of the metrics of the config keys.
+func WithTemporalityRules() common.TranslatorOption {
+	return func(target any) {
+		if setter, ok := target.(*translator); ok {
+			setter.temporalityRules = true
+		}
+	}
+}
+
 type translator struct {
 	factory processor.Factory
 	common.NameProvider
-	keys []string
+	keys             []string
+	temporalityRules bool
 }
 
 var _ common.ComponentTranslator = (*translator)(nil)
@@ -74,14 +88,37 @@ func (t *translator) ID() component.ID {
 // Translate creates a processor config based on the fields in the
 // Metrics section of the JSON config.
 func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
-	if conf == nil || !common.IsAnySet(conf, t.keys) {
+	if conf == nil {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: strings.Join(t.keys, " or ")}
+	}
+	delta, cumulative, err := common.GetMetricTemporality(conf)
+	if err != nil {
+		return nil, err
+	}
+	if t.temporalityRules {
+		if len(delta) == 0 {
+			return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: common.ConfigKey(common.MetricsKey, common.MetricTemporalityKey)}
+		}
+	} else if !common.IsAnySet(conf, t.keys) {
 		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: strings.Join(t.keys, " or ")}
 	}
 
 	cfg := t.factory.CreateDefaultConfig().(*cumulativetodeltaprocessor.Config)
 	cfg.InitialValue = initialValueDrop
+	if t.temporalityRules {
+		cfg.Include.MatchType = regexpMatchType
+		cfg.Include.Metrics = delta
+	}
 	excludeMetrics := t.getExcludeMetrics(conf)
-	if len(excludeMetrics) != 0 {
+	if len(cumulative) != 0 {
+		// the metrics pinned to cumulative are left for the counter reset processor,
+		// so the exclusions are matched as regular expressions
+		for i, name := range excludeMetrics {
+			excludeMetrics[i] = "^" + regexp.QuoteMeta(name) + "$"
+		}
+		cfg.Exclude.MatchType = regexpMatchType
+		cfg.Exclude.Metrics = append(excludeMetrics, cumulative...)
+	} else if len(excludeMetrics) != 0 {
 		cfg.Exclude.MatchType = strict
 		cfg.Exclude.Metrics = excludeMetrics
 	}
@@ -61,6 +61,26 @@ func TestTranslator(t *testing.T) {
 				"initial_value": "drop",
 			},
 		},
+		"GenerateDeltaProcessorConfigWithCumulativeRules": {
+			input: map[string]any{
+				"metrics": map[string]any{
+					"metrics_collected": map[string]any{
+						"diskio": map[string]any{},
+					},
+					"metric_temporality": []any{
+						map[string]any{"pattern": "^errors_", "temporality": "cumulative"},
+						map[string]any{"pattern": "^requests_", "temporality": "delta"},
+					},
+				},
+			},
+			want: map[string]any{
+				"exclude": map[string]any{
+					"match_type": "regexp",
+					"metrics":    []string{"^iops_in_progress$", "^diskio_iops_in_progress$", "^errors_"},
+				},
+				"initial_value": "drop",
+			},
+		},
 	}
 	factory := cumulativetodeltaprocessor.NewFactory()
 	for name, testCase := range testCases {
@@ -80,3 +100,40 @@ func TestTranslator(t *testing.T) {
 		})
 	}
 }
+
+func TestTranslatorWithTemporalityRules(t *testing.T) {
+	cdpTranslator := NewTranslator(common.WithName("host"), WithTemporalityRules())
+	conf := confmap.NewFromStringMap(map[string]any{
+		"metrics": map[string]any{
+			"metric_temporality": []any{
+				map[string]any{"pattern": "^errors_", "temporality": "cumulative"},
+			},
+		},
+	})
+	_, err := cdpTranslator.Translate(conf)
+	assert.Equal(t, &common.MissingKeyError{ID: cdpTranslator.ID(), JsonKey: "metrics::metric_temporality"}, err)
+
+	conf = confmap.NewFromStringMap(map[string]any{
+		"metrics": map[string]any{
+			"metric_temporality": []any{
+				map[string]any{"pattern": "^errors_", "temporality": "cumulative"},
+				map[string]any{"pattern": "^requests_", "temporality": "delta"},
+			},
+		},
+	})
+	got, err := cdpTranslator.Translate(conf)
+	require.NoError(t, err)
+	wantCfg := cumulativetodeltaprocessor.NewFactory().CreateDefaultConfig()
+	require.NoError(t, confmap.NewFromStringMap(map[string]any{
+		"include": map[string]any{
+			"match_type": "regexp",
+			"metrics":    []string{"^requests_"},
+		},
+		"exclude": map[string]any{
+			"match_type": "regexp",
+			"metrics":    []string{"^errors_"},
+		},
+		"initial_value": "drop",
+	}).Unmarshal(&wantCfg))
+	assert.Equal(t, wantCfg, got)
+}
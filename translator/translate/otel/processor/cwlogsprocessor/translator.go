@@ -0,0 +1,64 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cwlogsprocessor
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/cwlogsprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+var (
+	structuredAttributesKey  = common.ConfigKey(common.LogsKey, common.LogsCollectedKey, common.OtlpKey, "structured_attributes")
+	partitionKey             = common.ConfigKey(common.LogsKey, common.LogsCollectedKey, common.OtlpKey, "log_stream_partition")
+	partitionAttributesKey   = common.ConfigKey(partitionKey, "attributes")
+	maxLogStreamsKey         = common.ConfigKey(partitionKey, "max_log_streams")
+	overflowLogStreamNameKey = common.ConfigKey(partitionKey, "overflow_log_stream_name")
+)
+
+type translator struct {
+	common.NameProvider
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+var _ common.NameSetter = (*translator)(nil)
+
+// NewTranslator creates the processor that converts OTLP log records into
+// CloudWatch Logs events routed by the log group and stream of their resource.
+func NewTranslator(opts ...common.TranslatorOption) common.ComponentTranslator {
+	t := &translator{factory: cwlogsprocessor.NewFactory()}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.Name())
+}
+
+// Translate sets the structured attributes and partitions the log streams by
+// the resource attributes of the log_stream_partition section, if present.
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	cfg := t.factory.CreateDefaultConfig().(*cwlogsprocessor.Config)
+	if conf == nil {
+		return cfg, nil
+	}
+	cfg.StructuredAttributes = common.GetArray[string](conf, structuredAttributesKey)
+	if !conf.IsSet(partitionKey) {
+		return cfg, nil
+	}
+	cfg.LogStreamPartitionAttributes = common.GetArray[string](conf, partitionAttributesKey)
+	if maxLogStreams, ok := common.GetNumber(conf, maxLogStreamsKey); ok {
+		cfg.MaxLogStreams = int(maxLogStreams)
+	}
+	if overflowLogStreamName, ok := common.GetString(conf, overflowLogStreamNameKey); ok {
+		cfg.OverflowLogStreamName = overflowLogStreamName
+	}
+	return cfg, nil
+}
@@ -0,0 +1,45 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package cwlogsprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/cwlogsprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslator(t *testing.T) {
+	tt := NewTranslator(common.WithName("test"))
+	assert.EqualValues(t, "cwlogs/test", tt.ID().String())
+	got, err := tt.Translate(confmap.New())
+	require.NoError(t, err)
+	assert.Equal(t, cwlogsprocessor.NewFactory().CreateDefaultConfig(), got)
+
+	got, err = tt.Translate(confmap.NewFromStringMap(map[string]any{
+		"logs": map[string]any{
+			"logs_collected": map[string]any{
+				"otlp": map[string]any{
+					"structured_attributes": []any{"level", "http.status_code"},
+					"log_stream_partition": map[string]any{
+						"attributes":               []any{"k8s.namespace.name", "k8s.pod.name"},
+						"max_log_streams":          50,
+						"overflow_log_stream_name": "other-pods",
+					},
+				},
+			},
+		},
+	}))
+	require.NoError(t, err)
+	want := cwlogsprocessor.NewFactory().CreateDefaultConfig().(*cwlogsprocessor.Config)
+	want.StructuredAttributes = []string{"level", "http.status_code"}
+	want.LogStreamPartitionAttributes = []string{"k8s.namespace.name", "k8s.pod.name"}
+	want.MaxLogStreams = 50
+	want.OverflowLogStreamName = "other-pods"
+	assert.Equal(t, want, got)
+}
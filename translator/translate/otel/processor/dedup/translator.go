@@ -0,0 +1,49 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dedup
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/dedupprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+var (
+	// ConfigKey is the dedup window that is applied to all metrics pipelines.
+	ConfigKey     = common.ConfigKey(common.MetricsKey, "dedup")
+	windowKey     = common.ConfigKey(ConfigKey, "window")
+	maxEntriesKey = common.ConfigKey(ConfigKey, "max_entries")
+)
+
+type translator struct {
+	name    string
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+func NewTranslator() common.ComponentTranslator {
+	return &translator{factory: dedupprocessor.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(ConfigKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: ConfigKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*dedupprocessor.Config)
+	if window, ok := common.GetDuration(conf, windowKey); ok {
+		cfg.Window = window
+	}
+	if maxEntries, ok := common.GetNumber(conf, maxEntriesKey); ok {
+		cfg.MaxEntries = int(maxEntries)
+	}
+	return cfg, nil
+}
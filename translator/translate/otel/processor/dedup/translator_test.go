@@ -0,0 +1,72 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dedup
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/dedupprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *dedupprocessor.Config
+		wantErr error
+	}{
+		"WithoutKey": {
+			input:   map[string]interface{}{"metrics": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{ID: NewTranslator().ID(), JsonKey: ConfigKey},
+		},
+		"WithDefaults": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"dedup": map[string]interface{}{},
+			}},
+			want: &dedupprocessor.Config{
+				Window:     time.Minute,
+				MaxEntries: 100000,
+			},
+		},
+		"WithWindowInSeconds": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"dedup": map[string]interface{}{
+					"window":      30,
+					"max_entries": 10000,
+				},
+			}},
+			want: &dedupprocessor.Config{
+				Window:     30 * time.Second,
+				MaxEntries: 10000,
+			},
+		},
+		"WithWindowAsDuration": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"dedup": map[string]interface{}{
+					"window": "2m",
+				},
+			}},
+			want: &dedupprocessor.Config{
+				Window:     2 * time.Minute,
+				MaxEntries: 100000,
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "dedup", tt.ID().String())
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
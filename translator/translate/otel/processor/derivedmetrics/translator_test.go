@@ -0,0 +1,66 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package derivedmetrics
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/derivedmetricsprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *derivedmetricsprocessor.Config
+		wantErr error
+	}{
+		"WithoutKey": {
+			input:   map[string]interface{}{"metrics": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{ID: NewTranslator().ID(), JsonKey: ConfigKey},
+		},
+		"WithRules": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"derived_metrics": map[string]interface{}{
+					"rules": []interface{}{
+						map[string]interface{}{
+							"name":      "mem_used_percent",
+							"unit":      "Percent",
+							"metric1":   "mem_used",
+							"metric2":   "mem_total",
+							"operation": "percent",
+						},
+						map[string]interface{}{
+							"name":      "net_bytes",
+							"metric1":   "net_bytes_recv",
+							"metric2":   "net_bytes_sent",
+							"operation": "add",
+						},
+					},
+				},
+			}},
+			want: &derivedmetricsprocessor.Config{
+				Rules: []derivedmetricsprocessor.Rule{
+					{Name: "mem_used_percent", Unit: "Percent", Metric1: "mem_used", Metric2: "mem_total", Operation: derivedmetricsprocessor.OperationPercent},
+					{Name: "net_bytes", Metric1: "net_bytes_recv", Metric2: "net_bytes_sent", Operation: derivedmetricsprocessor.OperationAdd},
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "derivedmetrics", tt.ID().String())
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
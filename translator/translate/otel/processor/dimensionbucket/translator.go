@@ -0,0 +1,48 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dimensionbucket
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/dimensionbucketprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+// ConfigKey is the dimensions whose values are hashed into buckets in all metrics pipelines.
+var ConfigKey = common.ConfigKey(common.MetricsKey, "dimension_bucket")
+
+type translator struct {
+	name    string
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+func NewTranslator() common.ComponentTranslator {
+	return &translator{factory: dimensionbucketprocessor.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(ConfigKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: ConfigKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*dimensionbucketprocessor.Config)
+	sub, err := conf.Sub(ConfigKey)
+	if err != nil {
+		return nil, err
+	}
+	if err = sub.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal dimensionbucket processor: %w", err)
+	}
+	return cfg, nil
+}
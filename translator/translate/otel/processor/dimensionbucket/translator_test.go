@@ -0,0 +1,55 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dimensionbucket
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/dimensionbucketprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *dimensionbucketprocessor.Config
+		wantErr error
+	}{
+		"WithoutKey": {
+			input:   map[string]interface{}{"metrics": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{ID: NewTranslator().ID(), JsonKey: ConfigKey},
+		},
+		"WithDimensions": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"dimension_bucket": map[string]interface{}{
+					"dimensions": []interface{}{
+						map[string]interface{}{"key": "request_id", "buckets": 16},
+						map[string]interface{}{"key": "session_id", "buckets": 4},
+					},
+				},
+			}},
+			want: &dimensionbucketprocessor.Config{
+				Dimensions: []dimensionbucketprocessor.Dimension{
+					{Key: "request_id", Buckets: 16},
+					{Key: "session_id", Buckets: 4},
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "dimensionbucket", tt.ID().String())
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
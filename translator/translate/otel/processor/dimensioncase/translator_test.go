@@ -0,0 +1,61 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dimensioncase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/dimensioncaseprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *dimensioncaseprocessor.Config
+		wantErr error
+	}{
+		"WithoutKey": {
+			input:   map[string]interface{}{"metrics": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{ID: NewTranslator().ID(), JsonKey: ConfigKey},
+		},
+		"WithCase": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"dimension_case": map[string]interface{}{
+					"case": "snake_case",
+				},
+			}},
+			want: &dimensioncaseprocessor.Config{
+				Case: dimensioncaseprocessor.CaseSnake,
+			},
+		},
+		"WithExclude": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"dimension_case": map[string]interface{}{
+					"case":    "PascalCase",
+					"exclude": []interface{}{"host", "k8s.pod.name"},
+				},
+			}},
+			want: &dimensioncaseprocessor.Config{
+				Case:    dimensioncaseprocessor.CasePascal,
+				Exclude: []string{"host", "k8s.pod.name"},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "dimensioncase", tt.ID().String())
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dimensioninheritance
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/dimensioninheritanceprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *dimensioninheritanceprocessor.Config
+		wantErr error
+	}{
+		"WithoutKey": {
+			input:   map[string]interface{}{"metrics": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{ID: NewTranslator().ID(), JsonKey: ConfigKey},
+		},
+		"WithDefaultDirection": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"dimension_inheritance": map[string]interface{}{
+					"attributes": []interface{}{"ClusterName", "Namespace"},
+				},
+			}},
+			want: &dimensioninheritanceprocessor.Config{
+				Attributes: []string{"ClusterName", "Namespace"},
+				Direction:  dimensioninheritanceprocessor.DirectionResourceToDataPoint,
+			},
+		},
+		"WithDirection": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"dimension_inheritance": map[string]interface{}{
+					"attributes": []interface{}{"host"},
+					"direction":  "datapoint_to_resource",
+					"overwrite":  true,
+				},
+			}},
+			want: &dimensioninheritanceprocessor.Config{
+				Attributes: []string{"host"},
+				Direction:  dimensioninheritanceprocessor.DirectionDataPointToResource,
+				Overwrite:  true,
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "dimensioninheritance", tt.ID().String())
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
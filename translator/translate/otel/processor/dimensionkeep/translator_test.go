@@ -0,0 +1,55 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dimensionkeep
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/dimensionkeepprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *dimensionkeepprocessor.Config
+		wantErr error
+	}{
+		"WithoutKey": {
+			input:   map[string]interface{}{"metrics": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{ID: NewTranslator().ID(), JsonKey: ConfigKey},
+		},
+		"WithRules": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"dimension_keep": map[string]interface{}{
+					"rules": []interface{}{
+						map[string]interface{}{"pattern": "^cpu_", "dimensions": []interface{}{"host", "cpu"}},
+						map[string]interface{}{"pattern": "^disk_", "dimensions": []interface{}{"host", "path"}},
+					},
+				},
+			}},
+			want: &dimensionkeepprocessor.Config{
+				Rules: []dimensionkeepprocessor.Rule{
+					{Pattern: "^cpu_", Dimensions: []string{"host", "cpu"}},
+					{Pattern: "^disk_", Dimensions: []string{"host", "path"}},
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "dimensionkeep", tt.ID().String())
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package dimensionlimit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/dimensionlimitprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *dimensionlimitprocessor.Config
+		wantErr error
+	}{
+		"WithoutKey": {
+			input:   map[string]interface{}{"metrics": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{ID: NewTranslator().ID(), JsonKey: ConfigKey},
+		},
+		"WithPriority": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"dimension_limit": map[string]interface{}{
+					"priority": []interface{}{"ClusterName", "Namespace"},
+				},
+			}},
+			want: &dimensionlimitprocessor.Config{
+				MaxDimensions: 30,
+				Priority:      []string{"ClusterName", "Namespace"},
+			},
+		},
+		"WithMaxDimensions": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"dimension_limit": map[string]interface{}{
+					"max_dimensions": 10,
+				},
+			}},
+			want: &dimensionlimitprocessor.Config{
+				MaxDimensions: 10,
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "dimensionlimit", tt.ID().String())
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
@@ -46,6 +46,21 @@ func TestTranslator(t *testing.T) {
 				EC2InstanceTagKeys:     []string{"AutoScalingGroupName"},
 			},
 		},
+		"WithAvailabilityZone": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"append_dimensions": map[string]interface{}{
+						"AvailabilityZone":   "${aws:AvailabilityZone}",
+						"AvailabilityZoneId": "${aws:AvailabilityZoneId}",
+						"InstanceId":         "${aws:InstanceId}",
+					},
+				},
+			},
+			want: &ec2tagger.Config{
+				RefreshIntervalSeconds: 0 * time.Second,
+				EC2MetadataTags:        []string{"AvailabilityZone", "AvailabilityZoneId", "InstanceId"},
+			},
+		},
 		"WithDiskAppendDimensions": {
 			input: map[string]interface{}{
 				"metrics": map[string]interface{}{
@@ -12,6 +12,15 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
 )
 
+var (
+	nodeAggregatesKey       = common.ConfigKey(common.LogsKey, common.MetricsCollectedKey, common.KubernetesKey, "accelerated_compute_node_aggregates")
+	nodeAggregateMetricsKey = common.ConfigKey(nodeAggregatesKey, "metrics")
+	dropDeviceMetricsKey    = common.ConfigKey(nodeAggregatesKey, "drop_device_metrics")
+	xidEventsKey            = common.ConfigKey(common.LogsKey, common.MetricsCollectedKey, common.KubernetesKey, "accelerated_compute_xid_events")
+	repackLabelsKey         = common.ConfigKey(common.LogsKey, common.MetricsCollectedKey, common.KubernetesKey, "accelerated_compute_repack_labels")
+	supportedVersionsKey    = common.ConfigKey(common.LogsKey, common.MetricsCollectedKey, common.KubernetesKey, "accelerated_compute_supported_versions")
+)
+
 type translator struct {
 	name    string
 	factory processor.Factory
@@ -29,5 +38,14 @@ func (t *translator) ID() component.ID {
 
 func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 	cfg := t.factory.CreateDefaultConfig().(*gpuattributes.Config)
+	if conf != nil && conf.IsSet(nodeAggregatesKey) {
+		cfg.NodeAggregates = common.GetArray[string](conf, nodeAggregateMetricsKey)
+		cfg.DropDeviceMetrics, _ = common.GetBool(conf, dropDeviceMetricsKey)
+	}
+	if conf != nil {
+		cfg.XidEvents, _ = common.GetBool(conf, xidEventsKey)
+		cfg.RepackLabels, _ = common.GetBool(conf, repackLabelsKey)
+		cfg.SupportedVersions = common.GetArray[string](conf, supportedVersionsKey)
+	}
 	return cfg, nil
 }
@@ -0,0 +1,95 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package gpu
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/gpuattributes"
+)
+
+func TestTranslate(t *testing.T) {
+	tt := NewTranslatorWithName("containerinsights")
+	assert.EqualValues(t, "gpuattributes/containerinsights", tt.ID().String())
+	testCases := map[string]struct {
+		input map[string]interface{}
+		want  *gpuattributes.Config
+	}{
+		"WithDefault": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"kubernetes": map[string]interface{}{},
+					},
+				},
+			},
+			want: &gpuattributes.Config{},
+		},
+		"WithNodeAggregates": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"kubernetes": map[string]interface{}{
+							"accelerated_compute_node_aggregates": map[string]interface{}{
+								"metrics":             []interface{}{"node_gpu_utilization"},
+								"drop_device_metrics": true,
+							},
+						},
+					},
+				},
+			},
+			want: &gpuattributes.Config{
+				NodeAggregates:    []string{"node_gpu_utilization"},
+				DropDeviceMetrics: true,
+			},
+		},
+		"WithXidEvents": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"kubernetes": map[string]interface{}{
+							"accelerated_compute_xid_events": true,
+						},
+					},
+				},
+			},
+			want: &gpuattributes.Config{XidEvents: true},
+		},
+		"WithRepackLabels": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"kubernetes": map[string]interface{}{
+							"accelerated_compute_repack_labels": true,
+						},
+					},
+				},
+			},
+			want: &gpuattributes.Config{RepackLabels: true},
+		},
+		"WithSupportedVersions": {
+			input: map[string]interface{}{
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"kubernetes": map[string]interface{}{
+							"accelerated_compute_supported_versions": []interface{}{"535", "550.54.15"},
+						},
+					},
+				},
+			},
+			want: &gpuattributes.Config{SupportedVersions: []string{"535", "550.54.15"}},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.Translate(confmap.NewFromStringMap(testCase.input))
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, got)
+		})
+	}
+}
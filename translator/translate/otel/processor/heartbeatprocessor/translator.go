@@ -0,0 +1,67 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package heartbeatprocessor
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/heartbeatprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+// expirationIntervals is the number of intervals without metrics after which the heartbeat of a source stops.
+const expirationIntervals = 3
+
+var (
+	heartbeatKey  = common.ConfigKey(common.MetricsKey, common.HeartbeatKey)
+	metricNameKey = common.ConfigKey(heartbeatKey, "metric_name")
+	dimensionsKey = common.ConfigKey(heartbeatKey, "dimensions")
+	intervalKey   = common.ConfigKey(heartbeatKey, "interval")
+)
+
+type translator struct {
+	common.NameProvider
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+var _ common.NameSetter = (*translator)(nil)
+
+// NewTranslator creates the processor that emits a heartbeat for each source of the pipeline. The metrics of the
+// sources are dropped, so that only the heartbeats are exported.
+func NewTranslator(opts ...common.TranslatorOption) common.ComponentTranslator {
+	t := &translator{factory: heartbeatprocessor.NewFactory()}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.Name())
+}
+
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(heartbeatKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: heartbeatKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*heartbeatprocessor.Config)
+	cfg.DropMetrics = true
+	if metricName, ok := common.GetString(conf, metricNameKey); ok {
+		cfg.MetricName = metricName
+	}
+	if dimensions, ok := conf.Get(dimensionsKey).(map[string]any); ok && len(dimensions) > 0 {
+		cfg.Dimensions = make(map[string]string, len(dimensions))
+		for key := range dimensions {
+			cfg.Dimensions[key], _ = common.GetString(conf, common.ConfigKey(dimensionsKey, key))
+		}
+	}
+	if interval, ok := common.GetDuration(conf, intervalKey); ok {
+		cfg.Interval = interval
+		cfg.Expiration = expirationIntervals * interval
+	}
+	return cfg, nil
+}
@@ -0,0 +1,58 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package heartbeatprocessor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/heartbeatprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslator(t *testing.T) {
+	tt := NewTranslator(common.WithName(common.PipelineNameHeartbeat))
+	assert.EqualValues(t, "heartbeat/heartbeat", tt.ID().String())
+
+	_, err := tt.Translate(confmap.New())
+	assert.Error(t, err)
+
+	testCases := map[string]struct {
+		input map[string]any
+		want  func(*heartbeatprocessor.Config)
+	}{
+		"WithDefault": {
+			input: map[string]any{"metrics": map[string]any{"heartbeat": map[string]any{}}},
+			want:  func(*heartbeatprocessor.Config) {},
+		},
+		"WithCompleteConfig": {
+			input: map[string]any{"metrics": map[string]any{"heartbeat": map[string]any{
+				"namespace":   "CWAgent/Heartbeat",
+				"metric_name": "AgentHeartbeat",
+				"dimensions":  map[string]any{"Fleet": "web"},
+				"interval":    30,
+			}}},
+			want: func(cfg *heartbeatprocessor.Config) {
+				cfg.MetricName = "AgentHeartbeat"
+				cfg.Dimensions = map[string]string{"Fleet": "web"}
+				cfg.Interval = 30 * time.Second
+				cfg.Expiration = 90 * time.Second
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := tt.Translate(confmap.NewFromStringMap(testCase.input))
+			require.NoError(t, err)
+			want := heartbeatprocessor.NewFactory().CreateDefaultConfig().(*heartbeatprocessor.Config)
+			want.DropMetrics = true
+			testCase.want(want)
+			assert.Equal(t, want, got)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package histogramstats
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/histogramstatsprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *histogramstatsprocessor.Config
+		wantErr error
+	}{
+		"WithoutKey": {
+			input:   map[string]interface{}{"metrics": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{ID: NewTranslator().ID(), JsonKey: ConfigKey},
+		},
+		"WithDefault": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"histogram_stats": map[string]interface{}{},
+			}},
+			want: &histogramstatsprocessor.Config{
+				Mode:        histogramstatsprocessor.ModeStatisticSet,
+				Percentiles: []float64{50, 90, 99},
+			},
+		},
+		"WithPercentiles": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"histogram_stats": map[string]interface{}{
+					"mode":         "percentiles",
+					"percentiles":  []interface{}{50, 99.9},
+					"metric_names": []interface{}{"http.server.duration"},
+				},
+			}},
+			want: &histogramstatsprocessor.Config{
+				Mode:        histogramstatsprocessor.ModePercentiles,
+				Percentiles: []float64{50, 99.9},
+				MetricNames: []string{"http.server.duration"},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "histogramstats", tt.ID().String())
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
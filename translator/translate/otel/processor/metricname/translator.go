@@ -0,0 +1,53 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package metricname
+
+import (
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/metricnameprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+var (
+	// ConfigKey is the metric name normalization that is applied to all metrics pipelines.
+	ConfigKey      = common.ConfigKey(common.MetricsKey, "metric_name")
+	maxLengthKey   = common.ConfigKey(ConfigKey, "max_length")
+	replacementKey = common.ConfigKey(ConfigKey, "replacement")
+	dropInvalidKey = common.ConfigKey(ConfigKey, "drop_invalid")
+)
+
+type translator struct {
+	name    string
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+func NewTranslator() common.ComponentTranslator {
+	return &translator{factory: metricnameprocessor.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(ConfigKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: ConfigKey}
+	}
+	cfg := t.factory.CreateDefaultConfig().(*metricnameprocessor.Config)
+	if maxLength, ok := common.GetNumber(conf, maxLengthKey); ok {
+		cfg.MaxLength = int(maxLength)
+	}
+	if replacement, ok := common.GetString(conf, replacementKey); ok {
+		cfg.Replacement = replacement
+	}
+	if dropInvalid, ok := common.GetBool(conf, dropInvalidKey); ok {
+		cfg.DropInvalid = dropInvalid
+	}
+	return cfg, nil
+}
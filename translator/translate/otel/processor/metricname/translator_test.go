@@ -0,0 +1,62 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package metricname
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/metricnameprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *metricnameprocessor.Config
+		wantErr error
+	}{
+		"WithoutKey": {
+			input:   map[string]interface{}{"metrics": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{ID: NewTranslator().ID(), JsonKey: ConfigKey},
+		},
+		"WithDefaults": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"metric_name": map[string]interface{}{},
+			}},
+			want: &metricnameprocessor.Config{
+				MaxLength:   255,
+				Replacement: "_",
+			},
+		},
+		"WithDropInvalid": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"metric_name": map[string]interface{}{
+					"max_length":   100,
+					"replacement":  "-",
+					"drop_invalid": true,
+				},
+			}},
+			want: &metricnameprocessor.Config{
+				MaxLength:   100,
+				Replacement: "-",
+				DropInvalid: true,
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "metricname", tt.ID().String())
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
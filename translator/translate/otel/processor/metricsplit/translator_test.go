@@ -0,0 +1,55 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package metricsplit
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/metricsplitprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *metricsplitprocessor.Config
+		wantErr error
+	}{
+		"WithoutKey": {
+			input:   map[string]interface{}{"metrics": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{ID: NewTranslator().ID(), JsonKey: ConfigKey},
+		},
+		"WithRules": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"metric_split": map[string]interface{}{
+					"rules": []interface{}{
+						map[string]interface{}{"metric_name": "pods", "attribute": "state"},
+						map[string]interface{}{"metric_name": "nodes", "attribute": "condition", "new_name": "nodes_{value}_count"},
+					},
+				},
+			}},
+			want: &metricsplitprocessor.Config{
+				Rules: []metricsplitprocessor.Rule{
+					{MetricName: "pods", Attribute: "state"},
+					{MetricName: "nodes", Attribute: "condition", NewName: "nodes_{value}_count"},
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "metricsplit", tt.ID().String())
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
@@ -38,6 +38,14 @@ var renameMapForDcgm = map[string]string{
 	"DCGM_FI_DEV_POWER_USAGE":     containerinsightscommon.GpuPowerDraw,
 }
 
+// renameMapForDcgmNode are the DCGM metrics of the GPU devices, which are only renamed with the node type since they
+// are not attributed to the workloads using the device.
+var renameMapForDcgmNode = map[string]string{
+	"DCGM_FI_DEV_ECC_SBE_VOL_TOTAL": containerinsightscommon.GpuEccSingleBitErrors,
+	"DCGM_FI_DEV_ECC_DBE_VOL_TOTAL": containerinsightscommon.GpuEccDoubleBitErrors,
+	"DCGM_FI_DEV_XID_ERRORS":        containerinsightscommon.GpuXidError,
+}
+
 var renameMapForNeuronMonitor = map[string]string{
 	"execution_errors_total":                          containerinsightscommon.NeuronExecutionErrors,
 	"execution_status_total":                          containerinsightscommon.NeuronExecutionStatus,
@@ -134,6 +142,21 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 				}
 			}
 
+			for old, new := range renameMapForDcgmNode {
+				transformRules = append(transformRules, map[string]interface{}{
+					"include":  old,
+					"action":   "insert",
+					"new_name": containerinsightscommon.MetricName(containerinsightscommon.TypeGpuNode, new),
+					"operations": []map[string]interface{}{
+						{
+							"action":    "add_label",
+							"new_label": containerinsightscommon.MetricType,
+							"new_value": containerinsightscommon.TypeGpuNode,
+						},
+					},
+				})
+			}
+
 			for oldName, newName := range renameMapForNeuronMonitor {
 				var operations []map[string]interface{}
 				if newName == containerinsightscommon.NeuronCoreUtilization {
@@ -0,0 +1,73 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package nonfinite
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/nonfiniteprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *nonfiniteprocessor.Config
+		wantErr error
+	}{
+		"WithoutKey": {
+			input:   map[string]interface{}{"metrics": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{ID: NewTranslator().ID(), JsonKey: ConfigKey},
+		},
+		"WithDefaults": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"non_finite": map[string]interface{}{},
+			}},
+			want: &nonfiniteprocessor.Config{
+				Action:   "drop",
+				MaxValue: 1.174271e+108,
+			},
+		},
+		"WithReplace": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"non_finite": map[string]interface{}{
+					"action":      "replace",
+					"replacement": -1,
+				},
+			}},
+			want: &nonfiniteprocessor.Config{
+				Action:      "replace",
+				Replacement: -1,
+				MaxValue:    1.174271e+108,
+			},
+		},
+		"WithClamp": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"non_finite": map[string]interface{}{
+					"action":    "clamp",
+					"max_value": 1e9,
+				},
+			}},
+			want: &nonfiniteprocessor.Config{
+				Action:   "clamp",
+				MaxValue: 1e9,
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "nonfinite", tt.ID().String())
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pipelineid
+
+import (
+	"fmt"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/attributesprocessor"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+const (
+	name = "pipeline_id"
+	// AttributeKey is the internal attribute that the id is set in. The cloudwatch output removes it unless it is
+	// promoted to a dimension.
+	AttributeKey = "aws:PipelineId"
+)
+
+// ConfigKey is the id of the pipeline that the metrics are labeled with.
+var ConfigKey = common.ConfigKey(common.AgentKey, "pipeline_id")
+
+type translator struct {
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*translator)(nil)
+
+func NewTranslator() common.ComponentTranslator {
+	return &translator{factory: attributesprocessor.NewFactory()}
+}
+
+func (t *translator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), name)
+}
+
+// Translate creates an attributes processor that inserts the pipeline id into the attributes of the data points,
+// so it does not override an id that is already set.
+func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
+	if conf == nil || !conf.IsSet(ConfigKey) {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: ConfigKey}
+	}
+	id, _ := common.GetString(conf, ConfigKey)
+	cfg := t.factory.CreateDefaultConfig().(*attributesprocessor.Config)
+	c := confmap.NewFromStringMap(map[string]any{
+		"actions": []any{
+			map[string]any{
+				"key":    AttributeKey,
+				"value":  id,
+				"action": "insert",
+			},
+		},
+	})
+	if err := c.Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal attributes processor: %w", err)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,35 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package pipelineid
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/attributesprocessor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	tt := NewTranslator()
+	assert.Equal(t, "attributes/pipeline_id", tt.ID().String())
+
+	_, err := tt.Translate(confmap.NewFromStringMap(map[string]interface{}{"agent": map[string]interface{}{}}))
+	assert.Equal(t, &common.MissingKeyError{ID: tt.ID(), JsonKey: ConfigKey}, err)
+
+	got, err := tt.Translate(confmap.NewFromStringMap(map[string]interface{}{
+		"agent": map[string]interface{}{"pipeline_id": "frontend"},
+	}))
+	require.NoError(t, err)
+	cfg, ok := got.(*attributesprocessor.Config)
+	require.True(t, ok)
+	require.Len(t, cfg.Actions, 1)
+	assert.Equal(t, AttributeKey, cfg.Actions[0].Key)
+	assert.Equal(t, "frontend", cfg.Actions[0].Value)
+	assert.EqualValues(t, "insert", cfg.Actions[0].Action)
+	assert.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,59 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package presencefilter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/presencefilterprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *presencefilterprocessor.Config
+		wantErr error
+	}{
+		"WithoutKey": {
+			input:   map[string]interface{}{"metrics": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{ID: NewTranslator().ID(), JsonKey: ConfigKey},
+		},
+		"WithDefaults": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"presence_filter": map[string]interface{}{},
+			}},
+			want: &presencefilterprocessor.Config{
+				PresenceMetric: "up",
+			},
+		},
+		"WithPresenceMetric": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"presence_filter": map[string]interface{}{
+					"presence_metric":      "target_up",
+					"drop_presence_metric": true,
+				},
+			}},
+			want: &presencefilterprocessor.Config{
+				PresenceMetric:     "target_up",
+				DropPresenceMetric: true,
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "presencefilter", tt.ID().String())
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package resourcemapping
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/resourcemappingprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *resourcemappingprocessor.Config
+		wantErr error
+	}{
+		"WithoutKey": {
+			input:   map[string]interface{}{"agent": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{ID: NewTranslator().ID(), JsonKey: ConfigKey},
+		},
+		"WithDefaults": {
+			input: map[string]interface{}{"agent": map[string]interface{}{
+				"resource_mapping": map[string]interface{}{
+					"file": "/etc/cwagent/hosts.yaml",
+				},
+			}},
+			want: &resourcemappingprocessor.Config{
+				File:         "/etc/cwagent/hosts.yaml",
+				KeyAttribute: "host.id",
+			},
+		},
+		"WithDefaultAttributes": {
+			input: map[string]interface{}{"agent": map[string]interface{}{
+				"resource_mapping": map[string]interface{}{
+					"file":          "/etc/cwagent/hosts.json",
+					"key_attribute": "host.name",
+					"default":       map[string]interface{}{"team": "unknown"},
+					"override":      true,
+				},
+			}},
+			want: &resourcemappingprocessor.Config{
+				File:         "/etc/cwagent/hosts.json",
+				KeyAttribute: "host.name",
+				Default:      map[string]string{"team": "unknown"},
+				Override:     true,
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "resourcemapping", tt.ID().String())
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
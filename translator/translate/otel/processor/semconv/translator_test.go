@@ -0,0 +1,64 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package semconv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/semconvprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *semconvprocessor.Config
+		wantErr error
+	}{
+		"WithoutKey": {
+			input:   map[string]interface{}{"metrics": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{ID: NewTranslator().ID(), JsonKey: ConfigKey},
+		},
+		"WithDefault": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"semantic_conventions": map[string]interface{}{},
+			}},
+			want: &semconvprocessor.Config{
+				IncludeDefaultRenames: true,
+			},
+		},
+		"WithRenames": {
+			input: map[string]interface{}{"metrics": map[string]interface{}{
+				"semantic_conventions": map[string]interface{}{
+					"renames": map[string]interface{}{
+						"hostname":    "host.name",
+						"k8s.cluster": "k8s.cluster.name",
+					},
+					"include_default_renames": false,
+				},
+			}},
+			want: &semconvprocessor.Config{
+				Renames: map[string]string{
+					"hostname":    "host.name",
+					"k8s.cluster": "k8s.cluster.name",
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "semconv", tt.ID().String())
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
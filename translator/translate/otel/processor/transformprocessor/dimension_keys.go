@@ -0,0 +1,74 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package transformprocessor
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/transformprocessor"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/processor"
+
+	"github.com/aws/amazon-cloudwatch-agent/plugins/processors/awsentity/entityattributes"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+var dimensionKeysKey = common.ConfigKey(common.MetricsKey, common.DimensionKeysKey)
+
+type dimensionKeysTranslator struct {
+	name    string
+	factory processor.Factory
+}
+
+var _ common.ComponentTranslator = (*dimensionKeysTranslator)(nil)
+
+// NewDimensionKeysTranslator creates a transform processor that drops the data point and resource attributes that
+// are not in the dimension_keys of the metrics section, for the exporters that would otherwise keep all of the
+// attributes, such as the EMF exporter. The resource attributes of the entity are kept.
+func NewDimensionKeysTranslator(name string) common.ComponentTranslator {
+	return &dimensionKeysTranslator{"dimension_keys/" + name, transformprocessor.NewFactory()}
+}
+
+func (t *dimensionKeysTranslator) ID() component.ID {
+	return component.NewIDWithName(t.factory.Type(), t.name)
+}
+
+func (t *dimensionKeysTranslator) Translate(conf *confmap.Conf) (component.Config, error) {
+	keys := common.GetArray[string](conf, dimensionKeysKey)
+	if len(keys) == 0 {
+		return nil, &common.MissingKeyError{ID: t.ID(), JsonKey: dimensionKeysKey}
+	}
+	quotedKeys := make([]string, 0, len(keys))
+	patterns := make([]string, 0, len(keys)+1)
+	for _, key := range keys {
+		quotedKeys = append(quotedKeys, strconv.Quote(key))
+		patterns = append(patterns, regexp.QuoteMeta(key))
+	}
+	patterns = append(patterns, regexp.QuoteMeta(entityattributes.AWSEntityPrefix)+".*")
+	statements := map[string]any{
+		"metric_statements": []any{
+			map[string]any{
+				"context": "resource",
+				"statements": []any{
+					fmt.Sprintf("keep_matching_keys(attributes, %s)", strconv.Quote("^(?:"+strings.Join(patterns, "|")+")$")),
+				},
+			},
+			map[string]any{
+				"context": "datapoint",
+				"statements": []any{
+					fmt.Sprintf("keep_keys(attributes, [%s])", strings.Join(quotedKeys, ", ")),
+				},
+			},
+		},
+	}
+	cfg := t.factory.CreateDefaultConfig().(*transformprocessor.Config)
+	if err := confmap.NewFromStringMap(statements).Unmarshal(cfg); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal transform processor (%s): %w", t.ID(), err)
+	}
+	return cfg, nil
+}
@@ -53,3 +53,37 @@ func TestJmxTranslate(t *testing.T) {
 	sort.Strings(expectedCfg.MetricStatements[0].Statements)
 	sort.Strings(actualCfg.MetricStatements[0].Statements)
 }
+
+func TestDimensionKeysTranslate(t *testing.T) {
+	transl := NewDimensionKeysTranslator("host/cloudwatchlogs")
+	assert.Equal(t, "transform/dimension_keys/host/cloudwatchlogs", transl.ID().String())
+
+	_, err := transl.Translate(confmap.NewFromStringMap(map[string]any{"metrics": map[string]any{}}))
+	assert.Error(t, err)
+
+	conf := confmap.NewFromStringMap(map[string]any{
+		"metrics": map[string]any{
+			"dimension_keys": []any{"InstanceId", "device.name"},
+		},
+	})
+	translatedCfg, err := transl.Translate(conf)
+	require.NoError(t, err)
+	actualCfg, ok := translatedCfg.(*transformprocessor.Config)
+	require.True(t, ok)
+	require.NoError(t, actualCfg.Validate())
+
+	expectedCfg := transformprocessor.NewFactory().CreateDefaultConfig().(*transformprocessor.Config)
+	require.NoError(t, confmap.NewFromStringMap(map[string]any{
+		"metric_statements": []any{
+			map[string]any{
+				"context":    "resource",
+				"statements": []any{`keep_matching_keys(attributes, "^(?:InstanceId|device\\.name|com\\.amazonaws\\.cloudwatch\\.entity\\.internal\\..*)$")`},
+			},
+			map[string]any{
+				"context":    "datapoint",
+				"statements": []any{`keep_keys(attributes, ["InstanceId", "device.name"])`},
+			},
+		},
+	}).Unmarshal(expectedCfg))
+	assert.Equal(t, expectedCfg, actualCfg)
+}
@@ -0,0 +1,70 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package valuemap
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/valuemapprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *valuemapprocessor.Config
+		wantErr error
+	}{
+		"WithoutKey": {
+			input:   map[string]interface{}{"agent": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{ID: NewTranslator().ID(), JsonKey: ConfigKey},
+		},
+		"WithAttributes": {
+			input: map[string]interface{}{"agent": map[string]interface{}{
+				"value_map": map[string]interface{}{
+					"attributes": []interface{}{
+						map[string]interface{}{
+							"key":     "instance_type_code",
+							"values":  map[string]interface{}{"c5": "Compute Optimized"},
+							"default": "Other",
+						},
+						map[string]interface{}{
+							"key":    "status_code",
+							"values": map[string]interface{}{"200": "OK", "404": "Not Found"},
+						},
+					},
+				},
+			}},
+			want: &valuemapprocessor.Config{
+				Attributes: []valuemapprocessor.Attribute{
+					{
+						Key:     "instance_type_code",
+						Values:  map[string]string{"c5": "Compute Optimized"},
+						Default: aws.String("Other"),
+					},
+					{
+						Key:    "status_code",
+						Values: map[string]string{"200": "OK", "404": "Not Found"},
+					},
+				},
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "valuemap", tt.ID().String())
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
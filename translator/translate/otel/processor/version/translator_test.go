@@ -0,0 +1,66 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package version
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/aws/amazon-cloudwatch-agent/processor/versionprocessor"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+)
+
+func TestTranslate(t *testing.T) {
+	testCases := map[string]struct {
+		input   map[string]interface{}
+		want    *versionprocessor.Config
+		wantErr error
+	}{
+		"WithoutKey": {
+			input:   map[string]interface{}{"agent": map[string]interface{}{}},
+			wantErr: &common.MissingKeyError{ID: NewTranslator().ID(), JsonKey: ConfigKey},
+		},
+		"WithFile": {
+			input: map[string]interface{}{"agent": map[string]interface{}{
+				"application_version": map[string]interface{}{
+					"file": "/opt/app/VERSION",
+				},
+			}},
+			want: &versionprocessor.Config{
+				Attribute: "service.version",
+				File:      "/opt/app/VERSION",
+			},
+		},
+		"WithAll": {
+			input: map[string]interface{}{"agent": map[string]interface{}{
+				"application_version": map[string]interface{}{
+					"attribute": "deployment.version",
+					"file":      "/opt/app/VERSION",
+					"env":       "APP_VERSION",
+					"override":  true,
+				},
+			}},
+			want: &versionprocessor.Config{
+				Attribute: "deployment.version",
+				File:      "/opt/app/VERSION",
+				Env:       "APP_VERSION",
+				Override:  true,
+			},
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			tt := NewTranslator()
+			assert.Equal(t, "version", tt.ID().String())
+			conf := confmap.NewFromStringMap(testCase.input)
+			got, err := tt.Translate(conf)
+			assert.Equal(t, testCase.wantErr, err)
+			if testCase.want != nil {
+				assert.Equal(t, testCase.want, got)
+			}
+		})
+	}
+}
@@ -4,6 +4,8 @@
 package adapter
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -12,9 +14,18 @@ import (
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/util/hash"
 	"github.com/aws/amazon-cloudwatch-agent/receiver/adapter"
+	"github.com/aws/amazon-cloudwatch-agent/receiver/adapter/accumulator"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
 )
 
+const (
+	adaptiveIntervalKey   = "adaptive_collection_interval"
+	queueThresholdKey     = "queue_threshold"
+	maxIntervalKey        = "max_interval"
+	defaultQueueThreshold = 0.8
+	defaultMaxInterval    = 5 * time.Minute
+)
+
 type translator struct {
 	name string
 	// cfgType determines the type set in the config.
@@ -80,5 +91,77 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 		cfg.CollectionInterval = common.GetOrDefaultDuration(conf, intervalKeyChain, t.defaultMetricCollectionInterval)
 	}
 
+	if rules, ok := conf.Get(common.ConfigKey(t.cfgKey, common.MetricUnitsKey)).([]any); ok {
+		for _, rule := range rules {
+			if ruleMap, ok := rule.(map[string]any); ok {
+				pattern, _ := ruleMap[common.PatternKey].(string)
+				unit, _ := ruleMap[common.UnitKey].(string)
+				cfg.MetricUnits = append(cfg.MetricUnits, accumulator.UnitRule{Pattern: pattern, Unit: unit})
+			}
+		}
+	}
+
+	metricIntervals, err := t.metricIntervals(conf, cfg.CollectionInterval)
+	if err != nil {
+		return nil, err
+	}
+	cfg.MetricIntervals = metricIntervals
+	cfg.AdaptiveInterval = adaptiveInterval(conf, cfg.CollectionInterval)
+
 	return cfg, nil
 }
+
+// adaptiveInterval gets the adaptive collection interval from the agent section. The max interval is rounded down
+// to a multiple of the collection interval of the plugin, and the interval is fixed if that is not longer than the
+// collection interval.
+func adaptiveInterval(conf *confmap.Conf, collectionInterval time.Duration) *adapter.AdaptiveIntervalConfig {
+	key := common.ConfigKey(common.AgentKey, adaptiveIntervalKey)
+	if !conf.IsSet(key) || collectionInterval <= 0 {
+		return nil
+	}
+	cfg := &adapter.AdaptiveIntervalConfig{QueueThreshold: defaultQueueThreshold, MaxInterval: defaultMaxInterval}
+	if threshold, ok := common.GetNumber(conf, common.ConfigKey(key, queueThresholdKey)); ok {
+		cfg.QueueThreshold = threshold
+	}
+	if maxInterval, ok := common.GetDuration(conf, common.ConfigKey(key, maxIntervalKey)); ok {
+		cfg.MaxInterval = maxInterval
+	}
+	cfg.MaxInterval -= cfg.MaxInterval % collectionInterval
+	if cfg.MaxInterval <= collectionInterval {
+		return nil
+	}
+	return cfg
+}
+
+// metricIntervals gets the metrics_collection_interval overrides of the measurements, keyed by the name of the
+// metric. The overrides must be multiples of the collection interval of the plugin, which is used to sample them.
+func (t *translator) metricIntervals(conf *confmap.Conf, collectionInterval time.Duration) (map[string]time.Duration, error) {
+	measurements, ok := conf.Get(common.ConfigKey(t.cfgKey, common.MeasurementKey)).([]any)
+	if !ok {
+		return nil, nil
+	}
+	inputName := strings.TrimPrefix(t.cfgType.String(), adapter.TelegrafPrefix)
+	var intervals map[string]time.Duration
+	for _, measurement := range measurements {
+		m, ok := measurement.(map[string]any)
+		if !ok {
+			continue
+		}
+		name, _ := m[common.NameKey].(string)
+		value, ok := m[common.MetricsCollectionIntervalKey]
+		if name == "" || !ok {
+			continue
+		}
+		interval, err := common.ParseDuration(value)
+		if err != nil || interval <= 0 || collectionInterval <= 0 || interval%collectionInterval != 0 {
+			return nil, fmt.Errorf("%s of measurement %q in %s must be a multiple of the collection interval %v",
+				common.MetricsCollectionIntervalKey, name, t.cfgKey, collectionInterval)
+		}
+		if intervals == nil {
+			intervals = map[string]time.Duration{}
+		}
+		metricName := strings.TrimPrefix(strings.TrimSpace(name), inputName+"_")
+		intervals[inputName+"_"+metricName] = interval
+	}
+	return intervals, nil
+}
@@ -4,6 +4,7 @@
 package adapter
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -13,6 +14,7 @@ import (
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/util/hash"
 	"github.com/aws/amazon-cloudwatch-agent/receiver/adapter"
+	"github.com/aws/amazon-cloudwatch-agent/receiver/adapter/accumulator"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
 )
 
@@ -26,6 +28,9 @@ func TestTranslator(t *testing.T) {
 		cfgPreferInterval time.Duration
 		wantErr           error
 		wantInterval      time.Duration
+		wantMetricUnits   []accumulator.UnitRule
+		wantIntervals     map[string]time.Duration
+		wantAdaptive      *adapter.AdaptiveIntervalConfig
 	}{
 		"WithoutKeyInConfig": {
 			input:   map[string]interface{}{},
@@ -82,6 +87,133 @@ func TestTranslator(t *testing.T) {
 			cfgPreferInterval: time.Duration(0),
 			wantInterval:      20 * time.Second,
 		},
+		"WithMetricUnits": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"statsd": map[string]interface{}{
+							"metric_units": []interface{}{
+								map[string]interface{}{"pattern": "*_bytes", "unit": "Bytes"},
+								map[string]interface{}{"pattern": "*_seconds", "unit": "Seconds"},
+							},
+						},
+					},
+				},
+			},
+			cfgName:           "",
+			cfgType:           "test",
+			cfgKey:            "metrics::metrics_collected::statsd",
+			cfgPreferInterval: time.Duration(0),
+			wantInterval:      time.Minute,
+			wantMetricUnits: []accumulator.UnitRule{
+				{Pattern: "*_bytes", Unit: "Bytes"},
+				{Pattern: "*_seconds", Unit: "Seconds"},
+			},
+		},
+		"WithMetricIntervals": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"nvidia_gpu": map[string]interface{}{
+							"measurement": []interface{}{
+								"temperature_gpu",
+								map[string]interface{}{"name": "power_draw", "metrics_collection_interval": 60},
+								map[string]interface{}{"name": "nvidia_smi_fan_speed", "unit": "Percent", "metrics_collection_interval": 30},
+							},
+							"metrics_collection_interval": 10,
+						},
+					},
+				},
+			},
+			cfgName:           "",
+			cfgType:           "nvidia_smi",
+			cfgKey:            "metrics::metrics_collected::nvidia_gpu",
+			cfgPreferInterval: time.Duration(0),
+			wantInterval:      10 * time.Second,
+			wantIntervals: map[string]time.Duration{
+				"nvidia_smi_power_draw": time.Minute,
+				"nvidia_smi_fan_speed":  30 * time.Second,
+			},
+		},
+		"WithMetricIntervalNotMultiple": {
+			input: map[string]interface{}{
+				"metrics": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"nvidia_gpu": map[string]interface{}{
+							"measurement": []interface{}{
+								map[string]interface{}{"name": "power_draw", "metrics_collection_interval": 15},
+							},
+							"metrics_collection_interval": 10,
+						},
+					},
+				},
+			},
+			cfgName:           "",
+			cfgType:           "nvidia_smi",
+			cfgKey:            "metrics::metrics_collected::nvidia_gpu",
+			cfgPreferInterval: time.Duration(0),
+			wantErr:           errors.New(`metrics_collection_interval of measurement "power_draw" in metrics::metrics_collected::nvidia_gpu must be a multiple of the collection interval 10s`),
+		},
+		"WithAdaptiveInterval": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"adaptive_collection_interval": map[string]interface{}{
+						"queue_threshold": 0.5,
+						"max_interval":    "100s",
+					},
+				},
+				"metrics": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"cpu": map[string]interface{}{
+							"metrics_collection_interval": 30,
+						},
+					},
+				},
+			},
+			cfgName:           "",
+			cfgType:           "test",
+			cfgKey:            "metrics::metrics_collected::cpu",
+			cfgPreferInterval: time.Duration(0),
+			wantInterval:      30 * time.Second,
+			wantAdaptive:      &adapter.AdaptiveIntervalConfig{QueueThreshold: 0.5, MaxInterval: 90 * time.Second},
+		},
+		"WithAdaptiveIntervalDefaults": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"adaptive_collection_interval": map[string]interface{}{},
+				},
+				"metrics": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"cpu": map[string]interface{}{},
+					},
+				},
+			},
+			cfgName:           "",
+			cfgType:           "test",
+			cfgKey:            "metrics::metrics_collected::cpu",
+			cfgPreferInterval: time.Duration(0),
+			wantInterval:      time.Minute,
+			wantAdaptive:      &adapter.AdaptiveIntervalConfig{QueueThreshold: 0.8, MaxInterval: 5 * time.Minute},
+		},
+		"WithAdaptiveIntervalNotLonger": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"adaptive_collection_interval": map[string]interface{}{
+						"max_interval": 60,
+					},
+				},
+				"metrics": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"cpu": map[string]interface{}{},
+					},
+				},
+			},
+			cfgName:           "",
+			cfgType:           "test",
+			cfgKey:            "metrics::metrics_collected::cpu",
+			cfgPreferInterval: time.Duration(0),
+			wantInterval:      time.Minute,
+		},
 		"WithWindowsConfig": {
 			input: map[string]interface{}{
 				"metrics": map[string]interface{}{
@@ -114,6 +246,9 @@ func TestTranslator(t *testing.T) {
 				require.Equal(t, adapter.Type(testCase.cfgType), tt.ID().Type())
 				require.Equal(t, testCase.wantInterval, gotCfg.CollectionInterval)
 				require.Equal(t, testCase.cfgName, gotCfg.AliasName)
+				require.Equal(t, testCase.wantMetricUnits, gotCfg.MetricUnits)
+				require.Equal(t, testCase.wantIntervals, gotCfg.MetricIntervals)
+				require.Equal(t, testCase.wantAdaptive, gotCfg.AdaptiveInterval)
 			}
 		})
 	}
@@ -13,12 +13,19 @@ import (
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/util/collections"
 	translatorconfig "github.com/aws/amazon-cloudwatch-agent/translator/config"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected/docker"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected/files"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected/kubernetes"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected/pipeline_errors"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected/spot_interruption"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected/syslog"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected/windows_etw"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/logs/logs_collected/windows_events"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect"
 	collectd "github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/collectd"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/customizedmetrics"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/gpu"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/log_staleness"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/procstat"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/metrics/metrics_collect/statsd"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
@@ -31,7 +38,7 @@ const (
 var (
 	logKey           = common.ConfigKey(common.LogsKey, common.LogsCollectedKey)
 	metricKey        = common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey)
-	skipInputSet     = collections.NewSet[string](files.SectionKey, windows_events.SectionKey)
+	skipInputSet     = collections.NewSet[string](files.SectionKey, windows_events.SectionKey, windows_etw.SectionKey, docker.SectionKey, kubernetes.SectionKey, spot_interruption.SectionKey, pipeline_errors.SectionKey, syslog.SectionKey, common.OtlpKey)
 	multipleInputSet = collections.NewSet[string](procstat.SectionKey)
 	// Order by PidFile, ExeKey, Pattern Key according to the public documents
 	// if multiple configuration is specified
@@ -45,6 +52,7 @@ var (
 	// An exception would be procstat metrics
 	windowsInputSet = collections.NewSet[string](
 		gpu.SectionKey,
+		log_staleness.SectionKey,
 		statsd.SectionKey,
 	)
 	// skipWindowsInputSet contains all the supported metric input plugins that should not be included in telegraf windows plugins
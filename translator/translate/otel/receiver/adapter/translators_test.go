@@ -23,6 +23,10 @@ func TestFindReceiversInConfig(t *testing.T) {
 	telegrafCPUType, _ := component.NewType("telegraf_cpu")
 	telegrafEthtoolType, _ := component.NewType("telegraf_ethtool")
 	telegrafNvidiaSmiType, _ := component.NewType("telegraf_nvidia_smi")
+	telegrafPressureType, _ := component.NewType("telegraf_pressure")
+	telegrafKubeletSummaryType, _ := component.NewType("telegraf_kubelet_summary")
+	telegrafTCPStateType, _ := component.NewType("telegraf_tcp_state")
+	telegrafLogStalenessType, _ := component.NewType("telegraf_log_staleness")
 	telegrafStatsdType, _ := component.NewType("telegraf_statsd")
 	telegrafProcstatType, _ := component.NewType("telegraf_procstat")
 	telegrafWinPerfCountersType, _ := component.NewType("telegraf_win_perf_counters")
@@ -44,11 +48,15 @@ func TestFindReceiversInConfig(t *testing.T) {
 			input: map[string]interface{}{
 				"metrics": map[string]interface{}{
 					"metrics_collected": map[string]interface{}{
-						"collectd":   map[string]interface{}{},
-						"cpu":        map[string]interface{}{},
-						"ethtool":    map[string]interface{}{},
-						"nvidia_gpu": map[string]interface{}{},
-						"statsd":     map[string]interface{}{},
+						"collectd":        map[string]interface{}{},
+						"cpu":             map[string]interface{}{},
+						"ethtool":         map[string]interface{}{},
+						"log_staleness":   map[string]interface{}{},
+						"nvidia_gpu":      map[string]interface{}{},
+						"pressure":        map[string]interface{}{},
+						"kubelet_summary": map[string]interface{}{},
+						"statsd":          map[string]interface{}{},
+						"tcp_state":       map[string]interface{}{},
 						"procstat": []interface{}{
 							map[string]interface{}{
 								"exe":                         "amazon-cloudwatch-agent",
@@ -66,8 +74,12 @@ func TestFindReceiversInConfig(t *testing.T) {
 				component.NewID(telegrafSocketListenerType):                 {"metrics::metrics_collected::collectd", time.Minute},
 				component.NewID(telegrafCPUType):                            {"metrics::metrics_collected::cpu", time.Minute},
 				component.NewID(telegrafEthtoolType):                        {"metrics::metrics_collected::ethtool", time.Minute},
+				component.NewID(telegrafLogStalenessType):                   {"metrics::metrics_collected::log_staleness", time.Minute},
 				component.NewID(telegrafNvidiaSmiType):                      {"metrics::metrics_collected::nvidia_gpu", time.Minute},
+				component.NewID(telegrafPressureType):                       {"metrics::metrics_collected::pressure", time.Minute},
+				component.NewID(telegrafKubeletSummaryType):                 {"metrics::metrics_collected::kubelet_summary", time.Minute},
 				component.NewID(telegrafStatsdType):                         {"metrics::metrics_collected::statsd", 10 * time.Second},
+				component.NewID(telegrafTCPStateType):                       {"metrics::metrics_collected::tcp_state", time.Minute},
 				component.NewIDWithName(telegrafProcstatType, "793254176"):  {"metrics::metrics_collected::procstat", time.Minute},
 				component.NewIDWithName(telegrafProcstatType, "3599690165"): {"metrics::metrics_collected::procstat", time.Minute},
 			},
@@ -80,10 +92,11 @@ func TestFindReceiversInConfig(t *testing.T) {
 							"measurement":                 []string{"% Free Space"},
 							"metrics_collection_interval": 10,
 						},
-						"Memory":       map[string]interface{}{},
-						"Paging File":  map[string]interface{}{},
-						"PhysicalDisk": map[string]interface{}{},
-						"nvidia_gpu":   map[string]interface{}{},
+						"Memory":        map[string]interface{}{},
+						"Paging File":   map[string]interface{}{},
+						"PhysicalDisk":  map[string]interface{}{},
+						"log_staleness": map[string]interface{}{},
+						"nvidia_gpu":    map[string]interface{}{},
 						"procstat": []interface{}{
 							map[string]interface{}{
 								"exe":                         "amazon-cloudwatch-agent",
@@ -99,6 +112,7 @@ func TestFindReceiversInConfig(t *testing.T) {
 			},
 			os: translatorconfig.OS_TYPE_WINDOWS,
 			want: map[component.ID]wantResult{
+				component.NewID(telegrafLogStalenessType):                          {"metrics::metrics_collected::log_staleness", time.Minute},
 				component.NewID(telegrafNvidiaSmiType):                             {"metrics::metrics_collected::nvidia_gpu", time.Minute},
 				component.NewIDWithName(telegrafProcstatType, "793254176"):         {"metrics::metrics_collected::procstat", time.Minute},
 				component.NewIDWithName(telegrafProcstatType, "3599690165"):        {"metrics::metrics_collected::procstat", time.Minute},
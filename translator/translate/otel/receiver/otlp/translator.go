@@ -24,6 +24,8 @@ const (
 	defaultAppSignalsGrpcEndpoint = "0.0.0.0:4315"
 	defaultAppSignalsHttpEndpoint = "0.0.0.0:4316"
 	defaultJMXHttpEndpoint        = "0.0.0.0:4314"
+
+	maxRequestSizeMiBKey = "max_request_size_mib"
 )
 
 type translator struct {
@@ -123,5 +125,11 @@ func (t *translator) Translate(conf *confmap.Conf) (component.Config, error) {
 	if httpOk {
 		cfg.HTTP.Endpoint = httpEndpoint.(string)
 	}
+	// Compressed requests are decompressed by the receivers, and the limit applies to the decompressed size so that a
+	// small compressed request cannot expand into an unbounded amount of memory.
+	if maxRequestSizeMiB, ok := common.GetNumber(confmap.NewFromStringMap(otlpMap), maxRequestSizeMiBKey); ok && maxRequestSizeMiB > 0 {
+		cfg.GRPC.MaxRecvMsgSizeMiB = int(maxRequestSizeMiB)
+		cfg.HTTP.MaxRequestBodySize = int64(maxRequestSizeMiB) * 1024 * 1024
+	}
 	return cfg, nil
 }
@@ -4,14 +4,29 @@
 package otlp
 
 import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"net"
+	"net/http"
 	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
 	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/receiver/otlpreceiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	grpcgzip "google.golang.org/grpc/encoding/gzip"
 
 	"github.com/aws/amazon-cloudwatch-agent/internal/util/testutil"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
@@ -424,3 +439,92 @@ func TestTranslateJMX(t *testing.T) {
 	assert.NotNil(t, gotCfg.HTTP)
 	assert.Equal(t, "0.0.0.0:4314", gotCfg.HTTP.Endpoint)
 }
+
+func TestCompressedRequests(t *testing.T) {
+	grpcEndpoint, httpEndpoint := freeEndpoint(t), freeEndpoint(t)
+	conf := confmap.NewFromStringMap(map[string]interface{}{"metrics": map[string]interface{}{
+		"metrics_collected": map[string]interface{}{
+			"otlp": map[string]interface{}{
+				"grpc_endpoint":        grpcEndpoint,
+				"http_endpoint":        httpEndpoint,
+				"max_request_size_mib": 1,
+			},
+		},
+	}})
+	tt := NewTranslator(WithSignal(pipeline.SignalMetrics), WithConfigKey(common.ConfigKey(common.MetricsKey, common.MetricsCollectedKey, common.OtlpKey)))
+	cfg, err := tt.Translate(conf)
+	require.NoError(t, err)
+
+	sink := new(consumertest.MetricsSink)
+	r, err := otlpreceiver.NewFactory().CreateMetrics(context.Background(), receivertest.NewNopSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("requests")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	body, err := pmetricotlp.NewExportRequestFromMetrics(md).MarshalProto()
+	require.NoError(t, err)
+
+	t.Run("HTTP", func(t *testing.T) {
+		sink.Reset()
+		resp, err := postGzip(t, "http://"+httpEndpoint+"/v1/metrics", body)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 1, sink.DataPointCount())
+	})
+
+	t.Run("GRPC", func(t *testing.T) {
+		sink.Reset()
+		conn, err := grpc.NewClient(grpcEndpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		require.NoError(t, err)
+		defer conn.Close()
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		_, err = pmetricotlp.NewGRPCClient(conn).Export(ctx, pmetricotlp.NewExportRequestFromMetrics(md), grpc.UseCompressor(grpcgzip.Name))
+		require.NoError(t, err)
+		assert.Equal(t, 1, sink.DataPointCount())
+	})
+
+	t.Run("HTTPOverLimit", func(t *testing.T) {
+		sink.Reset()
+		// a valid request that compresses to a few KiB, but is over the limit once decompressed
+		large := pmetric.NewMetrics()
+		md.CopyTo(large)
+		large.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).SetName(strings.Repeat("a", 2*1024*1024))
+		oversized, err := pmetricotlp.NewExportRequestFromMetrics(large).MarshalProto()
+		require.NoError(t, err)
+		resp, err := postGzip(t, "http://"+httpEndpoint+"/v1/metrics", oversized)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, resp.StatusCode, http.StatusBadRequest)
+		assert.Equal(t, 0, sink.DataPointCount())
+	})
+}
+
+func freeEndpoint(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer l.Close()
+	return l.Addr().String()
+}
+
+func postGzip(t *testing.T, url string, body []byte) (*http.Response, error) {
+	t.Helper()
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write(body)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	req, err := http.NewRequest(http.MethodPost, url, &buf)
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Content-Encoding", "gzip")
+	resp, err := http.DefaultClient.Do(req)
+	if err == nil {
+		resp.Body.Close()
+	}
+	return resp, err
+}
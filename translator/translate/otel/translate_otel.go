@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -21,7 +22,10 @@ import (
 
 	"github.com/aws/amazon-cloudwatch-agent/translator/context"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/common"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/awscloudwatch"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/exporter/debugfile"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/entitystore"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/pipelinehealth"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/extension/server"
 	pipelinetranslator "github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline/applicationsignals"
@@ -31,8 +35,28 @@ import (
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline/host"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline/jmx"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline/nop"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline/otlp_logs"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline/prometheus"
 	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/pipeline/xray"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/attributedenylist"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/dedup"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/derivedmetrics"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/dimensionbucket"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/dimensioncase"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/dimensioninheritance"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/dimensionkeep"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/dimensionlimit"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/histogramstats"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/metricname"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/metricsplit"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/nonfinite"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/pipelineid"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/presencefilter"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/resourcemapping"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/rounding"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/semconv"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/valuemap"
+	"github.com/aws/amazon-cloudwatch-agent/translator/translate/otel/processor/version"
 	"github.com/aws/amazon-cloudwatch-agent/translator/util/ecsutil"
 )
 
@@ -73,7 +97,9 @@ func Translate(jsonConfig interface{}, os string) (*otelcol.Config, error) {
 	translators.Set(applicationsignals.NewTranslator(pipeline.SignalMetrics))
 	translators.Merge(prometheus.NewTranslators(conf))
 	translators.Set(emf_logs.NewTranslator())
+	translators.Set(otlp_logs.NewTranslator())
 	translators.Set(xray.NewTranslator())
+	translators.Set(xray.NewOtlpDestinationTranslator())
 	translators.Set(containerinsightsjmx.NewTranslator())
 	translators.Merge(jmx.NewTranslators(conf))
 	translators.Merge(registry)
@@ -92,6 +118,74 @@ func Translate(jsonConfig interface{}, os string) (*otelcol.Config, error) {
 	if context.CurrentContext().KubernetesMode() != "" {
 		pipelines.Translators.Extensions.Set(server.NewTranslator())
 	}
+	if conf.IsSet(pipelinehealth.EndpointKey) {
+		pipelines.Translators.Extensions.Set(pipelinehealth.NewTranslator())
+	}
+	if conf.IsSet(pipelineid.ConfigKey) {
+		addPipelineID(pipelines)
+	}
+	if conf.IsSet(resourcemapping.ConfigKey) {
+		addProcessor(pipelines, resourcemapping.NewTranslator(), pipeline.SignalMetrics, pipeline.SignalLogs, pipeline.SignalTraces)
+	}
+	if conf.IsSet(version.ConfigKey) {
+		addProcessor(pipelines, version.NewTranslator(), pipeline.SignalMetrics, pipeline.SignalLogs)
+	}
+	if conf.IsSet(valuemap.ConfigKey) {
+		addProcessor(pipelines, valuemap.NewTranslator(), pipeline.SignalMetrics, pipeline.SignalLogs)
+	}
+	if conf.IsSet(dimensioninheritance.ConfigKey) {
+		// before the other metrics processors, so that they see the attributes in a consistent location
+		addProcessor(pipelines, dimensioninheritance.NewTranslator(), pipeline.SignalMetrics)
+	}
+	if conf.IsSet(metricsplit.ConfigKey) {
+		// before the derived metrics, so that the split metrics can be used as operands
+		addProcessor(pipelines, metricsplit.NewTranslator(), pipeline.SignalMetrics)
+	}
+	if conf.IsSet(derivedmetrics.ConfigKey) {
+		// before the processors that change the metrics, so that they apply to the derived metrics as well
+		addProcessor(pipelines, derivedmetrics.NewTranslator(), pipeline.SignalMetrics)
+	}
+	if conf.IsSet(histogramstats.ConfigKey) {
+		addProcessor(pipelines, histogramstats.NewTranslator(), pipeline.SignalMetrics)
+	}
+	if conf.IsSet(metricname.ConfigKey) {
+		addProcessor(pipelines, metricname.NewTranslator(), pipeline.SignalMetrics)
+	}
+	if conf.IsSet(rounding.ConfigKey) {
+		addProcessor(pipelines, rounding.NewTranslator(), pipeline.SignalMetrics)
+	}
+	if conf.IsSet(dimensioncase.ConfigKey) {
+		addProcessor(pipelines, dimensioncase.NewTranslator(), pipeline.SignalMetrics)
+	}
+	if conf.IsSet(presencefilter.ConfigKey) {
+		addProcessor(pipelines, presencefilter.NewTranslator(), pipeline.SignalMetrics)
+	}
+	if conf.IsSet(dimensionbucket.ConfigKey) {
+		addProcessor(pipelines, dimensionbucket.NewTranslator(), pipeline.SignalMetrics)
+	}
+	if conf.IsSet(nonfinite.ConfigKey) {
+		addProcessor(pipelines, nonfinite.NewTranslator(), pipeline.SignalMetrics)
+	}
+	if conf.IsSet(dimensionkeep.ConfigKey) {
+		addProcessor(pipelines, dimensionkeep.NewTranslator(), pipeline.SignalMetrics)
+	}
+	if conf.IsSet(dimensionlimit.ConfigKey) {
+		// after the processors that add or keep dimensions, so that the limit applies to the dimensions that are sent
+		addProcessor(pipelines, dimensionlimit.NewTranslator(), pipeline.SignalMetrics)
+	}
+	if conf.IsSet(semconv.ConfigKey) {
+		addSemconv(pipelines)
+	}
+	if conf.IsSet(attributedenylist.ConfigKey) {
+		addAttributeDenylist(pipelines)
+	}
+	if conf.IsSet(dedup.ConfigKey) {
+		// after all other processors, so that the data points are compared as they are exported
+		addProcessor(pipelines, dedup.NewTranslator(), pipeline.SignalMetrics)
+	}
+	if conf.IsSet(debugfile.ConfigKey) {
+		addDebugOutput(conf, pipelines)
+	}
 
 	cfg := &otelcol.Config{
 		Receivers:  map[component.ID]component.Config{},
@@ -117,6 +211,73 @@ func Translate(jsonConfig interface{}, os string) (*otelcol.Config, error) {
 	return cfg, nil
 }
 
+// addPipelineID adds the pipeline id processor to the start of the metrics pipelines that export to CloudWatch, so
+// that the id is set before any other processor runs. The cloudwatch output removes the id unless it is promoted to
+// a dimension, so it is not added to the pipelines of the other exporters.
+func addPipelineID(pipelines *pipelinetranslator.Translation) {
+	pipelineID := pipelineid.NewTranslator()
+	cloudwatchID := awscloudwatch.NewTranslator().ID()
+	for id, p := range pipelines.Pipelines {
+		if id.Signal() == pipeline.SignalMetrics && slices.Contains(p.Exporters, cloudwatchID) {
+			p.Processors = append([]component.ID{pipelineID.ID()}, p.Processors...)
+		}
+	}
+	pipelines.Translators.Processors.Set(pipelineID)
+}
+
+// addSemconv adds the semantic conventions processor to the end of the metrics pipelines that export to CloudWatch,
+// so that the attributes added by the other processors are renamed as well. It is added before the attribute
+// denylist, which removes the attributes by their renamed keys.
+func addSemconv(pipelines *pipelinetranslator.Translation) {
+	renames := semconv.NewTranslator()
+	cloudwatchID := awscloudwatch.NewTranslator().ID()
+	for id, p := range pipelines.Pipelines {
+		if id.Signal() == pipeline.SignalMetrics && slices.Contains(p.Exporters, cloudwatchID) {
+			p.Processors = append(p.Processors, renames.ID())
+		}
+	}
+	pipelines.Translators.Processors.Set(renames)
+}
+
+// addAttributeDenylist adds the attribute denylist processor to the end of the metrics and logs pipelines, so that
+// the denylisted attributes are removed after all other processors that change the attributes have run.
+func addAttributeDenylist(pipelines *pipelinetranslator.Translation) {
+	denylist := attributedenylist.NewTranslator()
+	for id, p := range pipelines.Pipelines {
+		if id.Signal() == pipeline.SignalMetrics || id.Signal() == pipeline.SignalLogs {
+			p.Processors = append(p.Processors, denylist.ID())
+		}
+	}
+	pipelines.Translators.Processors.Set(denylist)
+}
+
+// addProcessor adds the processor to the end of the pipelines of the signals.
+func addProcessor(pipelines *pipelinetranslator.Translation, processor common.ComponentTranslator, signals ...pipeline.Signal) {
+	for id, p := range pipelines.Pipelines {
+		if slices.Contains(signals, id.Signal()) {
+			p.Processors = append(p.Processors, processor.ID())
+		}
+	}
+	pipelines.Translators.Processors.Set(processor)
+}
+
+// addDebugOutput adds the debug file exporter to the metrics and logs pipelines. The pipelines are only written to
+// the debug output when its outputs are replaced.
+func addDebugOutput(conf *confmap.Conf, pipelines *pipelinetranslator.Translation) {
+	debugOutput := debugfile.NewTranslator()
+	replaceOutputs, _ := common.GetBool(conf, debugfile.ReplaceOutputsKey)
+	for id, p := range pipelines.Pipelines {
+		if id.Signal() != pipeline.SignalMetrics && id.Signal() != pipeline.SignalLogs {
+			continue
+		}
+		if replaceOutputs {
+			p.Exporters = nil
+		}
+		p.Exporters = append(p.Exporters, debugOutput.ID())
+	}
+	pipelines.Translators.Exporters.Set(debugOutput)
+}
+
 // parseAgentLogLevel returns the logging level from the JSON config, or the
 // default value.
 func parseAgentLogLevel(conf *confmap.Conf) zapcore.Level {
@@ -4,10 +4,12 @@
 package otel
 
 import (
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/confmap"
 	"go.opentelemetry.io/collector/pipeline"
 
@@ -26,6 +28,7 @@ func TestTranslator(t *testing.T) {
 	testCases := map[string]struct {
 		input           interface{}
 		wantErrContains string
+		wantExtension   string
 		detector        func() (eksdetector.Detector, error)
 		isEKSDataStore  func() eksdetector.IsEKSCache
 	}{
@@ -63,6 +66,19 @@ func TestTranslator(t *testing.T) {
 				},
 			},
 		},
+		"WithHealthEndpoint": {
+			input: map[string]interface{}{
+				"agent": map[string]interface{}{
+					"health_endpoint": "0.0.0.0:13133",
+				},
+				"metrics": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"cpu": map[string]interface{}{},
+					},
+				},
+			},
+			wantExtension: "pipelinehealth",
+		},
 		"WithAppSignalsMetricsEnabled": {
 			input: map[string]interface{}{
 				"logs": map[string]interface{}{
@@ -223,11 +239,378 @@ func TestTranslator(t *testing.T) {
 			} else {
 				require.NoError(t, err)
 				assert.NotNil(t, got)
+				if testCase.wantExtension != "" {
+					assert.Contains(t, got.Service.Extensions, component.MustNewID(testCase.wantExtension))
+				}
 			}
 		})
 	}
 }
 
+func TestTranslatorWithAttributeDenylist(t *testing.T) {
+	agent.Global_Config.Region = "us-east-1"
+	translator.SetTargetPlatform("linux")
+	input := map[string]interface{}{
+		"agent": map[string]interface{}{
+			"attribute_denylist": map[string]interface{}{
+				"keys": []interface{}{"Authorization"},
+			},
+		},
+		"metrics": map[string]interface{}{
+			"metrics_collected": map[string]interface{}{
+				"cpu": map[string]interface{}{},
+			},
+			"dimension_case": map[string]interface{}{"case": "snake_case"},
+		},
+		"logs": map[string]interface{}{
+			"metrics_collected": map[string]interface{}{
+				"emf": map[string]interface{}{},
+			},
+		},
+	}
+	got, err := Translate(input, "linux")
+	require.NoError(t, err)
+	denylistID := component.MustNewID("attributedenylist")
+	assert.Contains(t, got.Processors, denylistID)
+	var checked int
+	for id, p := range got.Service.Pipelines {
+		if id.Signal() != pipeline.SignalMetrics && id.Signal() != pipeline.SignalLogs {
+			continue
+		}
+		require.NotEmpty(t, p.Processors, id.String())
+		assert.Equal(t, denylistID, p.Processors[len(p.Processors)-1], id.String())
+		checked++
+	}
+	assert.GreaterOrEqual(t, checked, 2)
+}
+
+func TestTranslatorWithSemconv(t *testing.T) {
+	agent.Global_Config.Region = "us-east-1"
+	translator.SetTargetPlatform("linux")
+	input := map[string]interface{}{
+		"agent": map[string]interface{}{
+			"attribute_denylist": map[string]interface{}{
+				"keys": []interface{}{"Authorization"},
+			},
+		},
+		"metrics": map[string]interface{}{
+			"metrics_collected": map[string]interface{}{
+				"cpu": map[string]interface{}{},
+			},
+			"semantic_conventions": map[string]interface{}{
+				"renames": map[string]interface{}{"hostname": "host.name"},
+			},
+		},
+		"logs": map[string]interface{}{
+			"metrics_collected": map[string]interface{}{
+				"emf": map[string]interface{}{},
+			},
+		},
+	}
+	got, err := Translate(input, "linux")
+	require.NoError(t, err)
+	semconvID := component.MustNewID("semconv")
+	denylistID := component.MustNewID("attributedenylist")
+	assert.Contains(t, got.Processors, semconvID)
+	var checked int
+	for id, p := range got.Service.Pipelines {
+		if id.Signal() == pipeline.SignalMetrics && slices.Contains(p.Exporters, component.MustNewID("awscloudwatch")) {
+			require.GreaterOrEqual(t, len(p.Processors), 2, id.String())
+			assert.Equal(t, []component.ID{semconvID, denylistID}, p.Processors[len(p.Processors)-2:], id.String())
+			checked++
+		} else {
+			assert.NotContains(t, p.Processors, semconvID, id.String())
+		}
+	}
+	assert.Equal(t, 1, checked)
+}
+
+func TestTranslatorWithMetricsProcessors(t *testing.T) {
+	agent.Global_Config.Region = "us-east-1"
+	translator.SetTargetPlatform("linux")
+	testCases := map[string]struct {
+		metrics map[string]interface{}
+		id      component.ID
+	}{
+		"WithDedup": {
+			metrics: map[string]interface{}{
+				"dedup": map[string]interface{}{"window": "30s"},
+			},
+			id: component.MustNewID("dedup"),
+		},
+		"WithRounding": {
+			metrics: map[string]interface{}{
+				"rounding": map[string]interface{}{
+					"rules": []interface{}{
+						map[string]interface{}{"pattern": "^cpu_", "precision": 0.01},
+					},
+				},
+			},
+			id: component.MustNewID("rounding"),
+		},
+		"WithDimensionCase": {
+			metrics: map[string]interface{}{
+				"dimension_case": map[string]interface{}{"case": "snake_case"},
+			},
+			id: component.MustNewID("dimensioncase"),
+		},
+		"WithPresenceFilter": {
+			metrics: map[string]interface{}{
+				"presence_filter": map[string]interface{}{"presence_metric": "up"},
+			},
+			id: component.MustNewID("presencefilter"),
+		},
+		"WithDimensionBucket": {
+			metrics: map[string]interface{}{
+				"dimension_bucket": map[string]interface{}{
+					"dimensions": []interface{}{
+						map[string]interface{}{"key": "request_id", "buckets": 16},
+					},
+				},
+			},
+			id: component.MustNewID("dimensionbucket"),
+		},
+		"WithNonFinite": {
+			metrics: map[string]interface{}{
+				"non_finite": map[string]interface{}{"action": "clamp"},
+			},
+			id: component.MustNewID("nonfinite"),
+		},
+		"WithDimensionKeep": {
+			metrics: map[string]interface{}{
+				"dimension_keep": map[string]interface{}{
+					"rules": []interface{}{
+						map[string]interface{}{"pattern": "^cpu_", "dimensions": []interface{}{"host"}},
+					},
+				},
+			},
+			id: component.MustNewID("dimensionkeep"),
+		},
+		"WithDerivedMetrics": {
+			metrics: map[string]interface{}{
+				"derived_metrics": map[string]interface{}{
+					"rules": []interface{}{
+						map[string]interface{}{"name": "mem_used_percent", "metric1": "mem_used", "metric2": "mem_total", "operation": "percent"},
+					},
+				},
+			},
+			id: component.MustNewID("derivedmetrics"),
+		},
+		"WithMetricSplit": {
+			metrics: map[string]interface{}{
+				"metric_split": map[string]interface{}{
+					"rules": []interface{}{
+						map[string]interface{}{"metric_name": "pods", "attribute": "state"},
+					},
+				},
+			},
+			id: component.MustNewID("metricsplit"),
+		},
+		"WithDimensionInheritance": {
+			metrics: map[string]interface{}{
+				"dimension_inheritance": map[string]interface{}{
+					"attributes": []interface{}{"ClusterName"},
+				},
+			},
+			id: component.MustNewID("dimensioninheritance"),
+		},
+		"WithDimensionLimit": {
+			metrics: map[string]interface{}{
+				"dimension_limit": map[string]interface{}{"max_dimensions": 10},
+			},
+			id: component.MustNewID("dimensionlimit"),
+		},
+		"WithHistogramStats": {
+			metrics: map[string]interface{}{
+				"histogram_stats": map[string]interface{}{"mode": "statistic_set"},
+			},
+			id: component.MustNewID("histogramstats"),
+		},
+		"WithMetricName": {
+			metrics: map[string]interface{}{
+				"metric_name": map[string]interface{}{"drop_invalid": true},
+			},
+			id: component.MustNewID("metricname"),
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			metrics := map[string]interface{}{
+				"metrics_collected": map[string]interface{}{
+					"cpu": map[string]interface{}{},
+				},
+			}
+			for key, value := range testCase.metrics {
+				metrics[key] = value
+			}
+			input := map[string]interface{}{
+				"metrics": metrics,
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"emf": map[string]interface{}{},
+					},
+				},
+			}
+			got, err := Translate(input, "linux")
+			require.NoError(t, err)
+			assert.Contains(t, got.Processors, testCase.id)
+			var checked int
+			for id, p := range got.Service.Pipelines {
+				if id.Signal() == pipeline.SignalMetrics {
+					assert.Contains(t, p.Processors, testCase.id, id.String())
+					checked++
+				} else {
+					assert.NotContains(t, p.Processors, testCase.id, id.String())
+				}
+			}
+			assert.GreaterOrEqual(t, checked, 1)
+		})
+	}
+}
+
+func TestTranslatorWithMetricsAndLogsProcessors(t *testing.T) {
+	agent.Global_Config.Region = "us-east-1"
+	translator.SetTargetPlatform("linux")
+	testCases := map[string]struct {
+		agent map[string]interface{}
+		id    component.ID
+	}{
+		"WithValueMap": {
+			agent: map[string]interface{}{
+				"value_map": map[string]interface{}{
+					"attributes": []interface{}{
+						map[string]interface{}{
+							"key":    "status_code",
+							"values": map[string]interface{}{"200": "OK"},
+						},
+					},
+				},
+			},
+			id: component.MustNewID("valuemap"),
+		},
+		"WithApplicationVersion": {
+			agent: map[string]interface{}{
+				"application_version": map[string]interface{}{"env": "APP_VERSION"},
+			},
+			id: component.MustNewID("version"),
+		},
+		"WithResourceMapping": {
+			agent: map[string]interface{}{
+				"resource_mapping": map[string]interface{}{
+					"file": "/etc/cwagent/hosts.yaml",
+				},
+			},
+			id: component.MustNewID("resourcemapping"),
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			input := map[string]interface{}{
+				"agent": testCase.agent,
+				"metrics": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"cpu": map[string]interface{}{},
+					},
+				},
+				"logs": map[string]interface{}{
+					"metrics_collected": map[string]interface{}{
+						"emf": map[string]interface{}{},
+					},
+				},
+			}
+			got, err := Translate(input, "linux")
+			require.NoError(t, err)
+			assert.Contains(t, got.Processors, testCase.id)
+			var checked int
+			for id, p := range got.Service.Pipelines {
+				if id.Signal() == pipeline.SignalMetrics || id.Signal() == pipeline.SignalLogs {
+					assert.Contains(t, p.Processors, testCase.id, id.String())
+					checked++
+				}
+			}
+			assert.GreaterOrEqual(t, checked, 2)
+		})
+	}
+}
+
+func TestTranslatorWithDebugOutput(t *testing.T) {
+	agent.Global_Config.Region = "us-east-1"
+	translator.SetTargetPlatform("linux")
+	for _, replaceOutputs := range []bool{false, true} {
+		input := map[string]interface{}{
+			"agent": map[string]interface{}{
+				"debug_output": map[string]interface{}{
+					"path":            "/tmp/debug.json",
+					"replace_outputs": replaceOutputs,
+				},
+			},
+			"metrics": map[string]interface{}{
+				"metrics_collected": map[string]interface{}{
+					"cpu": map[string]interface{}{},
+				},
+			},
+			"logs": map[string]interface{}{
+				"metrics_collected": map[string]interface{}{
+					"emf": map[string]interface{}{},
+				},
+			},
+		}
+		got, err := Translate(input, "linux")
+		require.NoError(t, err)
+		debugOutputID := component.MustNewID("debugfile")
+		assert.Contains(t, got.Exporters, debugOutputID)
+		var checked int
+		for id, p := range got.Service.Pipelines {
+			if id.Signal() != pipeline.SignalMetrics && id.Signal() != pipeline.SignalLogs {
+				continue
+			}
+			if replaceOutputs {
+				assert.Equal(t, []component.ID{debugOutputID}, p.Exporters, id.String())
+			} else {
+				assert.Greater(t, len(p.Exporters), 1, id.String())
+				assert.Contains(t, p.Exporters, debugOutputID, id.String())
+			}
+			checked++
+		}
+		assert.GreaterOrEqual(t, checked, 2)
+	}
+}
+
+func TestTranslatorWithPipelineID(t *testing.T) {
+	agent.Global_Config.Region = "us-east-1"
+	translator.SetTargetPlatform("linux")
+	input := map[string]interface{}{
+		"agent": map[string]interface{}{
+			"pipeline_id": "frontend",
+		},
+		"metrics": map[string]interface{}{
+			"metrics_collected": map[string]interface{}{
+				"cpu": map[string]interface{}{},
+			},
+		},
+		"logs": map[string]interface{}{
+			"metrics_collected": map[string]interface{}{
+				"emf": map[string]interface{}{},
+			},
+		},
+	}
+	got, err := Translate(input, "linux")
+	require.NoError(t, err)
+	pipelineID := component.MustNewIDWithName("attributes", "pipeline_id")
+	assert.Contains(t, got.Processors, pipelineID)
+	var checked int
+	for id, p := range got.Service.Pipelines {
+		if id.Signal() == pipeline.SignalMetrics {
+			require.NotEmpty(t, p.Processors, id.String())
+			assert.Equal(t, pipelineID, p.Processors[0], id.String())
+			checked++
+		} else {
+			assert.NotContains(t, p.Processors, pipelineID, id.String())
+		}
+	}
+	assert.GreaterOrEqual(t, checked, 1)
+}
+
 type testTranslator struct {
 	id      pipeline.ID
 	version int
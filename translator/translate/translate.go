@@ -26,15 +26,15 @@ var (
 )
 
 func RegisterLinuxRule(fieldname string, r Rule) {
-	linuxTranslateRule[fieldname] = r
+	linuxTranslateRule[fieldname] = translator.TrackRule(r)
 }
 
 func RegisterDarwinRule(fieldname string, r Rule) {
-	darwinTranslateRule[fieldname] = r
+	darwinTranslateRule[fieldname] = translator.TrackRule(r)
 }
 
 func RegisterWindowsRule(fieldname string, r Rule) {
-	windowsTranslateRule[fieldname] = r
+	windowsTranslateRule[fieldname] = translator.TrackRule(r)
 }
 
 type Translator struct {
@@ -15,6 +15,10 @@ import (
 func GetJsonMapFromJsonBytes(jsonArray []byte) (map[string]interface{}, error) {
 	var c map[string]interface{}
 
+	jsonArray, err := StripJsonComments(jsonArray)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse json, error: %v", err)
+	}
 	if err := json.Unmarshal(jsonArray, &c); err != nil {
 		return nil, fmt.Errorf("unable to parse json, error: %v", err)
 	}
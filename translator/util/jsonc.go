@@ -0,0 +1,84 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"fmt"
+)
+
+// StripJsonComments removes // line comments and /* */ block comments from
+// the input so that JSONC configs can be parsed by the standard JSON decoder.
+// Comment characters are replaced with spaces and newlines inside comments are
+// kept, so the line and column offsets of the remaining content are unchanged
+// and parse errors still point at the right place in the original file.
+// Comment markers inside JSON strings are left untouched.
+func StripJsonComments(input []byte) ([]byte, error) {
+	out := make([]byte, len(input))
+	copy(out, input)
+
+	inString := false
+	for i := 0; i < len(out); i++ {
+		c := out[i]
+		if inString {
+			switch c {
+			case '\\':
+				// skip the escaped character
+				i++
+			case '"':
+				inString = false
+			}
+			continue
+		}
+		switch c {
+		case '"':
+			inString = true
+		case '/':
+			if i+1 >= len(out) {
+				continue
+			}
+			switch out[i+1] {
+			case '/':
+				for ; i < len(out) && out[i] != '\n'; i++ {
+					blank(out, i)
+				}
+			case '*':
+				start := i
+				blank(out, i)
+				blank(out, i+1)
+				i += 2
+				for ; i < len(out); i++ {
+					if out[i] == '*' && i+1 < len(out) && out[i+1] == '/' {
+						blank(out, i)
+						blank(out, i+1)
+						i++
+						break
+					}
+					blank(out, i)
+				}
+				if i >= len(out) {
+					return nil, fmt.Errorf("unterminated /* comment starting on line %d", lineNumber(input, start))
+				}
+			}
+		}
+	}
+	return out, nil
+}
+
+// blank replaces the byte at the index with a space unless it is a line break.
+func blank(b []byte, i int) {
+	if b[i] != '\n' && b[i] != '\r' {
+		b[i] = ' '
+	}
+}
+
+// lineNumber returns the 1-based line number of the offset in the input.
+func lineNumber(input []byte, offset int) int {
+	line := 1
+	for _, c := range input[:offset] {
+		if c == '\n' {
+			line++
+		}
+	}
+	return line
+}
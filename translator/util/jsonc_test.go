@@ -0,0 +1,78 @@
+// Copyright Amazon.com, Inc. or its affiliates. All Rights Reserved.
+// SPDX-License-Identifier: MIT
+
+package util
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStripJsonComments(t *testing.T) {
+	testCases := map[string]struct {
+		input string
+		want  string
+	}{
+		"NoComments": {
+			input: `{"a": 1}`,
+			want:  `{"a": 1}`,
+		},
+		"LineComment": {
+			input: "{\n// comment\n\"a\": 1 // trailing\n}",
+			want:  "{\n          \n\"a\": 1            \n}",
+		},
+		"BlockComment": {
+			input: "{/* a\nb */\"a\": 1}",
+			want:  "{    \n    \"a\": 1}",
+		},
+		"SlashesInString": {
+			input: `{"url": "http://example.com/*path*/", "a": "b\"//c"}`,
+			want:  `{"url": "http://example.com/*path*/", "a": "b\"//c"}`,
+		},
+		"TrailingSlash": {
+			input: `{"a": 1}/`,
+			want:  `{"a": 1}/`,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			got, err := StripJsonComments([]byte(testCase.input))
+			require.NoError(t, err)
+			assert.Equal(t, testCase.want, string(got))
+			assert.Equal(t, bytes.Count([]byte(testCase.input), []byte("\n")), bytes.Count(got, []byte("\n")))
+		})
+	}
+}
+
+func TestStripJsonCommentsUnterminated(t *testing.T) {
+	_, err := StripJsonComments([]byte("{\n\"a\": 1\n/* never closed\n}"))
+	assert.ErrorContains(t, err, "line 3")
+}
+
+func TestGetJsonMapFromJsonBytesWithComments(t *testing.T) {
+	input := `{
+	// agent section
+	"agent": {
+		"region": "us-west-2" /* inline */
+	},
+	/*
+	 * logs section
+	 */
+	"logs": {
+		"endpoint_override": "https://logs.us-west-2.amazonaws.com//path"
+	}
+}`
+	got, err := GetJsonMapFromJsonBytes([]byte(input))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"agent": map[string]interface{}{
+			"region": "us-west-2",
+		},
+		"logs": map[string]interface{}{
+			"endpoint_override": "https://logs.us-west-2.amazonaws.com//path",
+		},
+	}, got)
+}
@@ -32,6 +32,9 @@ var IsEKS = isEKS
 var runInAws = os.Getenv(config.RUN_IN_AWS)
 var runWithIrsa = os.Getenv(config.RUN_WITH_IRSA)
 
+// regionEnvVars are the environment variables the region is read from, in order of precedence.
+var regionEnvVars = []string{"AWS_REGION", "AWS_DEFAULT_REGION"}
+
 func DetectAgentMode(configuredMode string) string {
 	if configuredMode != "auto" {
 		return configuredMode
@@ -124,12 +127,15 @@ func isEKS() eksdetector.IsEKSCache {
 
 func detectRegion(mode string, credsConfig map[string]string) (region string, regionType string) {
 	region = SDKRegionWithCredsMap(mode, credsConfig)
-	regionType = config.RegionTypeNotFound
-	if region != "" {
-		regionType = config.RegionTypeCredsMap
+	regionType = config.RegionTypeCredsMap
+
+	// fallback to the environment when no region info found in credential profile.
+	if region == "" {
+		region = regionFromEnv()
+		regionType = config.RegionTypeEnv
 	}
 
-	// For ec2, fallback to metadata when no region info found in credential profile.
+	// For ec2, fallback to metadata when no region info found in credential profile or environment.
 	if region == "" && mode == config.ModeEC2 {
 
 		fmt.Println("I! Trying to detect region from ec2")
@@ -144,9 +150,22 @@ func detectRegion(mode string, credsConfig map[string]string) (region string, re
 		regionType = config.RegionTypeECSMetadata
 	}
 
+	if region == "" {
+		regionType = config.RegionTypeNotFound
+	}
 	return
 }
 
+func regionFromEnv() string {
+	for _, key := range regionEnvVars {
+		if region := os.Getenv(key); region != "" {
+			fmt.Printf("I! Detected region from ENV %s\n", key)
+			return region
+		}
+	}
+	return ""
+}
+
 func CheckAndSetHomeDir() {
 	homeDir := detectHomeDirectory()
 	if runtime.GOOS == config.OS_TYPE_WINDOWS {
@@ -35,6 +35,68 @@ func TestDetectAgentModeAuto(t *testing.T) {
 	}
 }
 
+func TestDetectRegion(t *testing.T) {
+	testCases := map[string]struct {
+		mode           string
+		env            map[string]string
+		ec2Region      string
+		ecsRegion      string
+		wantRegion     string
+		wantRegionType string
+	}{
+		"WithEnv": {
+			mode:           config.ModeEC2,
+			env:            map[string]string{"AWS_REGION": "us-west-2", "AWS_DEFAULT_REGION": "us-east-2"},
+			ec2Region:      "us-east-1",
+			wantRegion:     "us-west-2",
+			wantRegionType: config.RegionTypeEnv,
+		},
+		"WithDefaultEnv": {
+			mode:           config.ModeEC2,
+			env:            map[string]string{"AWS_DEFAULT_REGION": "us-east-2"},
+			ec2Region:      "us-east-1",
+			wantRegion:     "us-east-2",
+			wantRegionType: config.RegionTypeEnv,
+		},
+		"WithEC2Metadata": {
+			mode:           config.ModeEC2,
+			ec2Region:      "us-east-1",
+			ecsRegion:      "eu-west-1",
+			wantRegion:     "us-east-1",
+			wantRegionType: config.RegionTypeEC2Metadata,
+		},
+		"WithECSMetadata": {
+			mode:           config.ModeEC2,
+			ecsRegion:      "eu-west-1",
+			wantRegion:     "eu-west-1",
+			wantRegionType: config.RegionTypeECSMetadata,
+		},
+		"WithOnPremNoMetadata": {
+			mode:           config.ModeOnPrem,
+			ec2Region:      "us-east-1",
+			wantRegionType: config.RegionTypeNotFound,
+		},
+		"WithNone": {
+			mode:           config.ModeEC2,
+			wantRegionType: config.RegionTypeNotFound,
+		},
+	}
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			for _, key := range regionEnvVars {
+				t.Setenv(key, testCase.env[key])
+			}
+			// restore HOME, which is set when looking up the region of the credentials profile
+			t.Setenv("HOME", t.TempDir())
+			DefaultEC2Region = func() string { return testCase.ec2Region }
+			DefaultECSRegion = func() string { return testCase.ecsRegion }
+			region, regionType := detectRegion(testCase.mode, map[string]string{})
+			require.Equal(t, testCase.wantRegion, region)
+			require.Equal(t, testCase.wantRegionType, regionType)
+		})
+	}
+}
+
 func TestDetectKubernetesMode(t *testing.T) {
 	testCases := map[string]struct {
 		isEKS              bool